@@ -0,0 +1,98 @@
+// Command audit-verify walks every ticket in ticket-rpc and verifies its event hash chain.
+//
+// Exit status is 1 when any chain is broken (or a head signature does not verify), 2 on RPC errors.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"time"
+
+	"github.com/cloudwego/kitex/client"
+	"github.com/cloudwego/kitex/client/callopt"
+	"github.com/cloudwego/kitex/transport"
+	"github.com/gogogo1024/assist-fusion/internal/audit"
+	"github.com/gogogo1024/assist-fusion/internal/common"
+	"github.com/gogogo1024/assist-fusion/kitex_gen/ticket"
+	"github.com/gogogo1024/assist-fusion/kitex_gen/ticket/ticketservice"
+)
+
+func envOr(key, def string) string {
+	if v, ok := os.LookupEnv(key); ok && v != "" {
+		return v
+	}
+	return def
+}
+
+func main() {
+	var (
+		addr    = flag.String("addr", envOr("TICKET_RPC_ADDR", ":8201"), "ticket-rpc address")
+		timeout = flag.Duration("timeout", 60*time.Second, "overall timeout")
+		verbose = flag.Bool("v", false, "print a line for every ticket, not only failures")
+		tenant  = flag.String("tenant", common.DefaultTenant, "tenant whose tickets are verified")
+	)
+	flag.Parse()
+	// with the same key as ticket-rpc, exported head signatures are checked as well
+	signer := audit.NewSigner(os.Getenv("AUDIT_SIGNING_KEY_ID"), []byte(os.Getenv("AUDIT_SIGNING_KEY")))
+
+	// TTHeader carries the tenant to ticket-rpc via metainfo
+	cli, err := ticketservice.NewClient("ticket-rpc", client.WithHostPorts(*addr), client.WithTransportProtocol(transport.TTHeaderFramed))
+	if err != nil {
+		log.Fatalf("create ticket client %s: %v", *addr, err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+	ctx = common.WithTenant(ctx, *tenant)
+
+	total, broken, err := verifyAll(ctx, cli, signer, *verbose, os.Stdout)
+	if err != nil {
+		log.Print(err)
+		os.Exit(2)
+	}
+	fmt.Printf("verified %d tickets, %d broken\n", total, broken)
+	if broken > 0 {
+		os.Exit(1)
+	}
+}
+
+// auditClient is the part of the ticket-rpc client the verifier uses.
+type auditClient interface {
+	ListTickets(ctx context.Context, req *ticket.ListTicketsRequest, callOptions ...callopt.Option) (*ticket.ListTicketsResponse, error)
+	VerifyAudit(ctx context.Context, req *ticket.VerifyAuditRequest, callOptions ...callopt.Option) (*ticket.VerifyAuditResponse, error)
+}
+
+// verifyAll checks the chain of every ticket of the tenant in ctx, archived and soft-deleted
+// ones included, printing failures (and, when verbose, every ticket) to w.
+func verifyAll(ctx context.Context, cli auditClient, signer *audit.Signer, verbose bool, w io.Writer) (total, broken int, err error) {
+	list, err := cli.ListTickets(ctx, &ticket.ListTicketsRequest{Include: []string{"archived", "deleted"}})
+	if err != nil {
+		return 0, 0, fmt.Errorf("list tickets: %w", err)
+	}
+	for _, t := range list.Tickets {
+		r, err := cli.VerifyAudit(ctx, &ticket.VerifyAuditRequest{Id: t.Id})
+		if err != nil {
+			return 0, 0, fmt.Errorf("verify %s: %w", t.Id, err)
+		}
+		if !r.Ok {
+			broken++
+			fmt.Fprintf(w, "BROKEN %s at event %d/%d: %s\n", r.TicketId, r.BrokenIndex, r.Events, r.Reason)
+			continue
+		}
+		if signer != nil && r.Head != nil {
+			h := r.Head
+			if h.KeyId != signer.KeyID || !signer.Check(audit.HeadPayload(h.TicketId, int(h.EventCount), h.HeadHash, h.ExportedAt), h.Signature) {
+				broken++
+				fmt.Fprintf(w, "BADSIG %s key_id=%q\n", r.TicketId, h.KeyId)
+				continue
+			}
+		}
+		if verbose {
+			fmt.Fprintf(w, "OK     %s events=%d head=%s\n", r.TicketId, r.Events, r.Head.GetHeadHash())
+		}
+	}
+	return len(list.Tickets), broken, nil
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/kitex/client/callopt"
+	"github.com/gogogo1024/assist-fusion/internal/common"
+	"github.com/gogogo1024/assist-fusion/kitex_gen/ticket"
+	"github.com/gogogo1024/assist-fusion/rpc/ticket/impl"
+)
+
+// localClient calls the ticket service in process.
+type localClient struct{ s *impl.TicketServiceImpl }
+
+func (c localClient) ListTickets(ctx context.Context, req *ticket.ListTicketsRequest, _ ...callopt.Option) (*ticket.ListTicketsResponse, error) {
+	return c.s.ListTickets(ctx, req)
+}
+
+func (c localClient) VerifyAudit(ctx context.Context, req *ticket.VerifyAuditRequest, _ ...callopt.Option) (*ticket.VerifyAuditResponse, error) {
+	return c.s.VerifyAudit(ctx, req)
+}
+
+func TestVerifyAllCoversArchivedAndDeleted(t *testing.T) {
+	ctx := context.Background()
+	repo := common.NewMemoryTicketRepo()
+	s := impl.NewTicketService(repo)
+	create := func(title string) string {
+		t.Helper()
+		r, err := s.CreateTicket(ctx, &ticket.CreateTicketRequest{Title: title})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return r.Ticket.Id
+	}
+	archived, deleted := create("archived"), create("deleted")
+	if _, err := s.Resolve(ctx, &ticket.TicketActionRequest{Id: archived}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Archive(ctx, &ticket.TicketActionRequest{Id: archived}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.DeleteTicket(ctx, &ticket.TicketActionRequest{Id: deleted}); err != nil {
+		t.Fatal(err)
+	}
+	var out bytes.Buffer
+	if total, broken, err := verifyAll(ctx, localClient{s}, nil, false, &out); err != nil || total != 2 || broken != 0 {
+		t.Fatalf("intact: total=%d broken=%d err=%v\n%s", total, broken, err, out.String())
+	}
+
+	// rewriting history of a ticket hidden from default lists is still caught
+	for _, id := range []string{archived, deleted} {
+		tk, _ := repo.Get(ctx, id)
+		tk.Events[0].Note = "edited"
+		_ = repo.Update(ctx, tk)
+	}
+	out.Reset()
+	total, broken, err := verifyAll(ctx, localClient{s}, nil, false, &out)
+	if err != nil || total != 2 || broken != 2 || !strings.Contains(out.String(), "BROKEN "+archived) {
+		t.Fatalf("tampered: total=%d broken=%d err=%v\n%s", total, broken, err, out.String())
+	}
+}
@@ -0,0 +1,73 @@
+// Command mail-ingest turns inbound email into tickets and comments on ticket-rpc.
+//
+// Sources (any combination):
+//
+//	-dir   Maildir (new/ + cur/) or a directory of *.mbox files, polled every -poll
+//	-smtp  address of the embedded SMTP listener, e.g. :2525
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/cloudwego/kitex/client"
+	"github.com/cloudwego/kitex/transport"
+	"github.com/gogogo1024/assist-fusion/internal/common"
+	"github.com/gogogo1024/assist-fusion/internal/mailin"
+	"github.com/gogogo1024/assist-fusion/kitex_gen/ticket/ticketservice"
+)
+
+func envOr(key, def string) string {
+	if v, ok := os.LookupEnv(key); ok && v != "" {
+		return v
+	}
+	return def
+}
+
+func main() {
+	var (
+		addr     = flag.String("addr", envOr("TICKET_RPC_ADDR", ":8201"), "ticket-rpc address")
+		dir      = flag.String("dir", os.Getenv("MAILIN_DIR"), "Maildir or mbox directory to poll")
+		poll     = flag.Duration("poll", 30*time.Second, "directory poll interval")
+		smtpAddr = flag.String("smtp", os.Getenv("MAILIN_SMTP_ADDR"), "embedded SMTP listen address")
+		domain   = flag.String("domain", envOr("MAILIN_DOMAIN", "localhost"), "SMTP greeting domain")
+		category = flag.String("category", os.Getenv("MAILIN_CATEGORY"), "category for tickets opened by email")
+		tenant   = flag.String("tenant", os.Getenv("MAILIN_TENANT"), "tenant owning ingested tickets (default tenant when empty)")
+	)
+	flag.Parse()
+	if *dir == "" && *smtpAddr == "" {
+		log.Fatal("nothing to do: set -dir and/or -smtp")
+	}
+	if *tenant != "" && !common.ValidTenantID(*tenant) {
+		log.Fatalf("invalid tenant id %q", *tenant)
+	}
+	// TTHeader carries the sender (actor) and tenant to ticket-rpc via metainfo
+	cli, err := ticketservice.NewClient("ticket-rpc", client.WithHostPorts(*addr), client.WithTransportProtocol(transport.TTHeaderFramed))
+	if err != nil {
+		log.Fatalf("create ticket client %s: %v", *addr, err)
+	}
+	ing := mailin.NewIngestor(&mailin.RPCSink{Client: cli, Category: *category, Tenant: *tenant})
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if *dir != "" {
+		log.Printf("mail-ingest polling %s every %s", *dir, *poll)
+		go mailin.PollDir(ctx, *dir, *poll, ing.Deliver)
+	}
+	if *smtpAddr != "" {
+		srv := &mailin.SMTPServer{Addr: *smtpAddr, Domain: *domain, Handler: ing.Deliver}
+		go func() {
+			log.Printf("mail-ingest SMTP listening on %s", *smtpAddr)
+			if err := srv.ListenAndServe(); err != nil {
+				log.Fatalf("smtp: %v", err)
+			}
+		}()
+		defer srv.Close()
+	}
+	<-ctx.Done()
+}
@@ -105,3 +105,15 @@ func (s *AIServiceImpl) Chat(ctx context.Context, req *ai.ChatRequest) (resp *ai
 	// TODO: Your code here...
 	return
 }
+
+// SubmitSurvey implements the TicketServiceImpl interface.
+func (s *TicketServiceImpl) SubmitSurvey(ctx context.Context, req *ticket.SubmitSurveyRequest) (resp *ticket.SubmitSurveyResponse, err error) {
+	// TODO: Your code here...
+	return
+}
+
+// CSATReport implements the TicketServiceImpl interface.
+func (s *TicketServiceImpl) CSATReport(ctx context.Context, req *ticket.CSATReportRequest) (resp *ticket.CSATReportResponse, err error) {
+	// TODO: Your code here...
+	return
+}
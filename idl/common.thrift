@@ -18,18 +18,65 @@ enum TicketStatus {
   RESOLVED = 3,
 }
 
+struct CSATResponse {
+  1: i32 rating,         // 1..5
+  2: string comment,
+  3: i64 submitted_at,
+}
+
 struct TicketCycle {
   1: i64 created_at,
   2: i64 assigned_at,
   3: i64 resolved_at,
   4: i64 escalated_at,
   5: TicketStatus status,
+  // 6: survey_token moved to TicketResponse.survey_token (Resolve only)
+  7: optional CSATResponse csat,
+  8: string tier,          // support tier the cycle is (or ended) at
+}
+
+struct Actor {
+  1: string id,
+  2: string kind,          // user | system | automation
+  3: string display_name,
 }
 
 struct TicketEvent {
   1: string type,
   2: i64 at,
   3: string note,
+  4: optional Actor actor,
+  5: string source,        // gateway | cli | rule | scheduler | rpc
+  6: string prev_hash,     // audit chain: hash of the previous event ("" for the first)
+  7: string hash,          // sha256(prev_hash + "\n" + content digest)
+  8: bool redacted,        // personal fields erased; the chain keeps the original digest
+  9: string from_tier,     // escalated / deescalated only
+ 10: string to_tier,       // created / escalated / deescalated
+}
+
+// Attachment metadata; content is not stored by ticket-rpc.
+struct Attachment {
+  1: string name,
+  2: string content_type,
+  3: i64 size,
+}
+
+struct TicketComment {
+  1: string id,
+  2: string body,
+  3: i64 created_at,
+  4: optional Actor actor,
+  5: string source,
+  6: list<Attachment> attachments,
+}
+
+// EditLock is a short-lived soft lock taken by the agent working on a ticket and renewed
+// by heartbeat; other agents' actions are rejected while it is held.
+struct EditLock {
+  1: string ticket_id,
+  2: Actor holder,
+  3: i64 acquired_at,
+  4: i64 expires_at,
 }
 
 struct Ticket {
@@ -45,6 +92,23 @@ struct Ticket {
  10: list<TicketCycle> cycles,
  11: i32 current_cycle,
  12: list<TicketEvent> events,
+ 13: string assignee,
+ 14: string category,
+ 15: string customer,
+ 16: list<string> watchers,
+ 17: list<TicketComment> comments,
+ 18: i64 archived_at,       // 0 unless archived (hidden from default lists)
+ 19: i64 deleted_at,        // 0 unless soft-deleted (hidden everywhere until restored)
+ 20: bool anonymized,       // personal data scrubbed by a retention policy
+ 21: string tier,            // current support tier (L1 / L2 / L3 / vendor ...), i.e. its queue
+ 22: string priority,        // low | normal | high | urgent ("" = unset)
+ 23: list<string> tags,
+ 24: map<string,string> custom_fields,
+ 25: i64 due_at,             // SLA due time (0 = no SLA)
+ 26: string sla_status,      // none | on_track | at_risk | breached (computed)
+ 27: string claimed_by,       // agent holding a pull-queue claim (empty once expired)
+ 28: i64 claim_expires_at,
+ 29: optional EditLock lock,  // current edit lock, if any
 }
 
 struct KBDoc {
@@ -52,6 +116,14 @@ struct KBDoc {
   2: string title,
   3: string content,
   4: optional map<string,string> tags,
+  5: i64 created_at,
+  6: i64 updated_at,
+}
+
+// a highlighted query match in a snippet: [start, end) in Unicode code points
+struct HighlightSpan {
+  1: i32 start,
+  2: i32 end,
 }
 
 struct SearchItem {
@@ -59,6 +131,15 @@ struct SearchItem {
   2: string title,
   3: double score,
   4: string snippet,
+  5: optional map<string,string> tags,
+  6: optional i32 chunk_index,           // best matching chunk of the document
+  7: optional list<string> heading_path, // markdown headings above that chunk
+  // vector and hybrid modes: each retriever's raw score and 1-based rank (absent when it missed the doc)
+  8: optional double lexical_score,
+  9: optional i32 lexical_rank,
+  10: optional double vector_score,
+  11: optional i32 vector_rank,
+  12: optional list<HighlightSpan> highlights, // query matches in snippet; absent with pre_tag/post_tag
 }
 
 struct EmbeddingRequest {
@@ -88,3 +169,16 @@ struct PageInfo {
   3: i32 total_items,
   4: i32 total_pages,
 }
+
+// Data subject (GDPR) operations: the subject is the customer's identity as stored on
+// tickets and comments (user id or email address).
+struct SubjectRequest {
+  1: string subject,
+}
+
+// ErasureSummary reports what one service redacted for a subject.
+struct ErasureSummary {
+  1: string service,           // ticket | kb
+  2: list<string> records,     // ids of the touched records (tickets, docs)
+  3: i32 fields,               // number of redacted fields
+}
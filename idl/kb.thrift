@@ -5,6 +5,7 @@ struct AddDocRequest {
   1: string title,
   2: string content,
   3: optional map<string,string> tags,
+  4: optional string idempotency_key, // retries with the same key return the original doc
 }
 
 struct UpdateDocRequest {
@@ -15,6 +16,21 @@ struct UpdateDocRequest {
 }
 
 struct DeleteDocRequest { 1: string id }
+struct GetDocRequest { 1: string id }
+
+struct ListDocsRequest {
+  1: optional i32 limit,         // default 20, cap 100
+  2: optional string cursor,     // next_cursor of the previous page
+  3: optional list<string> tags, // same terms as SearchRequest.tags
+  4: optional string tag_mode,   // "all" (default) or "any"
+  5: optional string order,      // by updated_at: "desc" (default) or "asc"
+}
+
+struct ListDocsResponse {
+  1: list<common.KBDoc> docs,
+  2: optional string next_cursor,
+  3: i32 total, // docs matching the tag filter
+}
 struct DeleteDocResponse { 1: bool ok }
 
 struct SearchRequest {
@@ -22,6 +38,18 @@ struct SearchRequest {
   2: optional i32 limit,       // default 10, cap 50 (server side)
   3: optional i32 offset,      // for incremental pagination
   4: optional bool with_snippet,
+  5: optional list<string> tags, // "key" or "key:value"; only docs carrying the tags match
+  6: optional string tag_mode,   // "all" (default, every tag must match) or "any"
+  7: optional string cursor,     // opaque; "" starts cursor pagination, then pass next_cursor (excludes offset)
+  8: optional string mode,       // "lexical" (default), "vector" or "hybrid"; cursor is lexical only
+  9: optional string fusion,     // hybrid only: "rrf" (default) or "weighted"
+  10: optional double lexical_weight, // hybrid weights, default from server config
+  11: optional double vector_weight,
+  12: optional i32 rrf_k,        // rrf rank constant, default 60
+  13: optional i32 snippet_length, // runes per snippet fragment, default 120, max 1000
+  14: optional i32 fragments,      // snippet fragments around the best matches, default 1, max 5
+  15: optional string pre_tag,     // with post_tag: wrap matches in the snippet instead of returning highlights
+  16: optional string post_tag,
 }
 
 struct SearchResponse {
@@ -29,14 +57,38 @@ struct SearchResponse {
   2: i32 returned,
   3: optional i32 next_offset,
   4: optional i32 total, // total matched (untruncated) count
+  5: optional string next_cursor, // cursor mode only: resume after this page
+}
+
+struct VectorSearchRequest {
+  1: string query,
+  2: optional i32 limit,         // default 10, cap 50
+  3: optional list<string> tags, // same terms as SearchRequest.tags
+  4: optional string tag_mode,   // "all" (default) or "any"
+}
+
+struct VectorSearchResponse {
+  1: list<common.SearchItem> items,
+  2: i32 returned,
+  3: i32 indexed, // the tenant's documents that have vectors
 }
 
 struct InfoResponse { 1: map<string,string> stats }
 
+struct ExportSubjectResponse {
+  1: list<common.KBDoc> docs,  // documents mentioning the subject
+}
+
 service KBService {
   common.KBDoc AddDoc(1: AddDocRequest req) throws (1: common.ServiceError err)
   common.KBDoc UpdateDoc(1: UpdateDocRequest req) throws (1: common.ServiceError err)
   DeleteDocResponse DeleteDoc(1: DeleteDocRequest req) throws (1: common.ServiceError err)
+  common.KBDoc GetDoc(1: GetDocRequest req) throws (1: common.ServiceError err)
+  ListDocsResponse ListDocs(1: ListDocsRequest req) throws (1: common.ServiceError err)
   SearchResponse Search(1: SearchRequest req) throws (1: common.ServiceError err)
+  VectorSearchResponse VectorSearch(1: VectorSearchRequest req) throws (1: common.ServiceError err)
   InfoResponse Info() throws (1: common.ServiceError err)
+
+  ExportSubjectResponse ExportSubject(1: common.SubjectRequest req) throws (1: common.ServiceError err)
+  common.ErasureSummary EraseSubject(1: common.SubjectRequest req) throws (1: common.ServiceError err)
 }
@@ -1,12 +1,30 @@
 namespace go ticket
 include "common.thrift"
 
-struct TicketResponse { 1: common.Ticket ticket }
+struct DuplicateCandidate {
+  1: string id,
+  2: string title,
+  3: double similarity,         // cosine similarity of title+desc embeddings
+}
+
+struct TicketResponse {
+  1: common.Ticket ticket,
+  2: optional list<DuplicateCandidate> possible_duplicates,   // CreateTicket only
+  3: optional string survey_token,   // Resolve only: the customer's survey credential, never exposed on reads
+}
 
 struct CreateTicketRequest {
   1: string title,
   2: string desc,
   3: optional string note,
+  4: optional string category,
+  5: optional string customer,   // defaults to the creating user
+  6: optional bool strict_duplicates,   // reject (conflict) when a near-identical open ticket exists
+  7: optional string idempotency_key,   // retries with the same key return the original response
+  8: optional string priority,          // low | normal | high | urgent
+  9: optional list<string> tags,
+ 10: optional map<string,string> custom_fields,
+ 11: optional i64 due_at,               // SLA due time (unix seconds)
 }
 
 struct GetTicketRequest { 1: string id }
@@ -16,6 +34,8 @@ struct ListTicketsRequest {
   2: optional list<common.TicketStatus> statuses,
   3: optional i64 created_from,
   4: optional i64 created_to,
+  5: optional list<string> include,   // archived | deleted (hidden by default)
+  6: optional string tier,            // only tickets in this tier's queue
 }
 
 struct ListTicketsResponse {
@@ -26,10 +46,276 @@ struct ListTicketsResponse {
 struct TicketActionRequest {
   1: string id,
   2: optional string note,
+  3: optional string assignee, // Assign, Escalate, Deescalate
+  4: optional string tier,     // Escalate / Deescalate target (default: next / previous tier on the path)
 }
 
 struct GetCyclesRequest { 1: string id }
-struct GetEventsRequest { 1: string id }
+struct GetEventsRequest {
+  1: string id,
+  2: optional string actor_id,   // only events performed by this actor
+  3: optional string actor_kind, // user | system | automation
+}
+
+struct AddCommentRequest {
+  1: string id,
+  2: string body,
+  3: optional list<common.Attachment> attachments,
+}
+
+struct WatchRequest {
+  1: string id,
+  2: optional string user_id,   // defaults to the calling actor
+}
+
+struct Notification {
+  1: string id,
+  2: string ticket_id,
+  3: string event_type,
+  4: string subject,
+  5: string body,
+  6: i64 created_at,
+  7: bool read,
+  8: optional common.Actor actor,
+  9: string role,               // customer | assignee | watcher
+}
+
+// Notification RPCs act on the calling actor ("me") propagated via metainfo.
+struct ListNotificationsRequest {
+  1: optional bool unread_only,
+  2: optional i32 limit,
+}
+
+struct ListNotificationsResponse {
+  1: list<Notification> items,
+  2: i32 unread,
+}
+
+struct MarkNotificationsRequest {
+  1: list<string> ids,
+  2: optional bool all,
+  3: bool read,
+}
+
+struct MarkNotificationsResponse {
+  1: i32 updated,
+  2: i32 unread,
+}
+
+struct NotificationPrefs {
+  1: list<string> channels,     // inbox | email | webhook
+  2: string email,
+  3: string webhook_url,
+}
+
+struct GetNotificationPrefsRequest {}
+
+struct SetNotificationPrefsRequest { 1: NotificationPrefs prefs }
+
+struct VerifyAuditRequest { 1: string id }
+
+struct AuditHead {
+  1: string ticket_id,
+  2: i32 event_count,
+  3: string head_hash,
+  4: i64 exported_at,
+  5: string key_id,            // empty when the service has no signing key configured
+  6: string signature,         // hex HMAC-SHA256 over the canonical head payload
+}
+
+struct VerifyAuditResponse {
+  1: string ticket_id,
+  2: bool ok,
+  3: i32 events,
+  4: i32 broken_index,         // -1 when the chain verifies
+  5: string reason,
+  6: AuditHead head,
+}
+
+struct ExportAuditHeadRequest { 1: string id }
+
+struct SubmitSurveyRequest {
+  1: string token,
+  2: i32 rating,               // 1..5
+  3: optional string comment,
+}
+
+struct SubmitSurveyResponse {
+  1: string ticket_id,
+  2: i32 cycle,
+  3: bool reopened,            // low score triggered an automatic reopen
+}
+
+struct CSATReportRequest {
+  1: string group_by,          // assignee | category | period
+  2: optional string period,   // day | week | month (group_by=period), default day
+  3: optional i64 from,        // submitted_at lower bound (inclusive, unix seconds)
+  4: optional i64 to,          // submitted_at upper bound (exclusive, unix seconds)
+}
+
+struct CSATReportRow {
+  1: string key,
+  2: i32 responses,
+  3: double average,
+  4: double satisfied_ratio,   // share of ratings >= 4
+  5: map<i32,i32> distribution,
+}
+
+struct CSATReportResponse {
+  1: list<CSATReportRow> rows,
+  2: i32 total_responses,
+  3: double average,
+}
+
+// ViewFilter selects tickets for a saved view; empty fields match everything and the
+// set fields are combined with AND (values within one list with OR).
+struct ViewFilter {
+  1: list<common.TicketStatus> statuses,
+  2: list<string> assignees,          // "me" = the caller, "none" = unassigned
+  3: list<string> priorities,
+  4: list<string> tags,               // the ticket must carry every tag
+  5: list<string> sla,                // none | on_track | at_risk | breached
+  6: map<string,string> custom_fields,   // exact match on every key
+  7: string text,                     // case-insensitive match in title / description
+  8: list<string> tiers,
+}
+
+struct SavedView {
+  1: string id,
+  2: string name,
+  3: string owner,                    // user id of the creator
+  4: string scope,                    // personal | team
+  5: string team,                     // scope=team: team whose members see the view
+  6: ViewFilter filter,
+  7: string sort_by,                  // created_at (default) | updated_at | priority | due_at
+  8: bool sort_desc,
+  9: i64 created_at,
+ 10: i64 updated_at,
+}
+
+struct SaveViewRequest { 1: SavedView view }      // UpdateView uses view.id
+struct ViewRequest { 1: string id }
+struct DeleteViewResponse { 1: bool ok }
+
+struct ListViewsRequest {
+  1: bool counts,                     // evaluate every view and fill ViewSummary.count
+}
+
+struct ViewSummary {
+  1: SavedView view,
+  2: i32 count,                       // -1 when counts were not requested
+}
+
+struct ListViewsResponse { 1: list<ViewSummary> views }
+
+struct ViewTicketsRequest {
+  1: string id,
+  2: optional common.Pagination pagination,   // default page 1, page_size 20 (max 100)
+}
+
+struct LockRequest {
+  1: string id,
+  2: bool force,    // release only: supervisors may drop another agent's lock
+}
+
+struct LockResponse { 1: optional common.EditLock lock }   // unset once released
+
+struct LockEvent {
+  1: i64 seq,
+  2: string type,                     // acquired | renewed | released | force_released | expired
+  3: string ticket_id,
+  4: optional common.EditLock lock,   // the lock the event is about
+  5: optional common.Actor actor,     // who caused it (unset for expiry)
+  6: i64 at,
+}
+
+struct ListLockEventsRequest {
+  1: i64 after,                  // events with seq > after; a negative value only returns the cursor
+  2: optional string ticket_id,
+}
+
+struct ListLockEventsResponse {
+  1: list<LockEvent> events,
+  2: i64 last_seq,
+}
+
+struct PullNextRequest {
+  1: list<string> skills,          // the agent's skills, matched against category and tags
+  2: optional string tier,         // only pull from this tier's queue
+}
+
+struct PullNextResponse {
+  1: optional common.Ticket ticket,  // unset when nothing is eligible
+  2: double score,
+  3: i64 claim_expires_at,
+}
+
+struct EscalationReportRequest {
+  1: optional i64 from,        // event time lower bound (inclusive, unix seconds)
+  2: optional i64 to,          // event time upper bound (exclusive, unix seconds)
+  3: optional string category,
+}
+
+struct EscalationTierRow {
+  1: string tier,
+  2: i32 entered,              // tickets that entered the tier's queue (created, reopened or moved in)
+  3: i32 escalated,            // moved up out of the tier
+  4: i32 deescalated,          // moved down out of the tier
+  5: i32 resolved,             // resolved while in the tier
+  6: double escalation_rate,   // escalated / entered
+}
+
+struct EscalationReportResponse {
+  1: list<EscalationTierRow> rows,
+  2: i32 total_escalations,
+}
+
+struct ApplyRetentionRequest {
+  1: bool dry_run,             // report what would change without touching tickets
+}
+
+struct RetentionAction {
+  1: string ticket_id,
+  2: string action,            // archive | anonymize | purge
+  3: string policy,            // the matching policy, e.g. "resolved:90d:archive"
+  4: i64 since,                // when the ticket entered the matched state (unix seconds)
+}
+
+struct RetentionReport {
+  1: bool dry_run,
+  2: i64 ran_at,
+  3: list<RetentionAction> actions,
+}
+
+struct SubjectTicket {
+  1: common.Ticket ticket,
+  2: list<string> roles,       // customer | assignee | watcher | commenter | actor | mentioned
+}
+
+struct ExportSubjectResponse {
+  1: string subject,
+  2: list<SubjectTicket> tickets,
+  3: list<Notification> notifications,   // the subject's own inbox
+  4: optional NotificationPrefs prefs,
+}
+
+struct EraseSubjectRequest {
+  1: string subject,
+  2: optional list<common.ErasureSummary> related,   // results of other services, covered by the receipt
+}
+
+struct ErasureReceipt {
+  1: string id,
+  2: string subject_hash,      // sha256 of the normalized subject; the subject itself is not kept
+  3: string tenant,
+  4: i64 erased_at,
+  5: list<common.ErasureSummary> services,
+  6: optional common.Actor requested_by,
+  7: string key_id,            // empty when the service has no signing key configured
+  8: string signature,         // hex HMAC-SHA256 over the canonical receipt payload
+}
+
+struct GetErasureReceiptRequest { 1: string id }
 
 service TicketService {
   TicketResponse CreateTicket(1: CreateTicketRequest req) throws (1: common.ServiceError err)
@@ -40,7 +326,50 @@ service TicketService {
   TicketResponse Resolve(1: TicketActionRequest req) throws (1: common.ServiceError err)
   TicketResponse Escalate(1: TicketActionRequest req) throws (1: common.ServiceError err)
   TicketResponse Reopen(1: TicketActionRequest req) throws (1: common.ServiceError err)
+  TicketResponse Deescalate(1: TicketActionRequest req) throws (1: common.ServiceError err)
 
   list<common.TicketCycle> GetCycles(1: GetCyclesRequest req) throws (1: common.ServiceError err)
   list<common.TicketEvent> GetEvents(1: GetEventsRequest req) throws (1: common.ServiceError err)
+
+  TicketResponse AddComment(1: AddCommentRequest req) throws (1: common.ServiceError err)
+  TicketResponse Watch(1: WatchRequest req) throws (1: common.ServiceError err)
+  TicketResponse Unwatch(1: WatchRequest req) throws (1: common.ServiceError err)
+  ListNotificationsResponse ListNotifications(1: ListNotificationsRequest req) throws (1: common.ServiceError err)
+  MarkNotificationsResponse MarkNotifications(1: MarkNotificationsRequest req) throws (1: common.ServiceError err)
+  NotificationPrefs GetNotificationPrefs(1: GetNotificationPrefsRequest req) throws (1: common.ServiceError err)
+  NotificationPrefs SetNotificationPrefs(1: SetNotificationPrefsRequest req) throws (1: common.ServiceError err)
+
+  VerifyAuditResponse VerifyAudit(1: VerifyAuditRequest req) throws (1: common.ServiceError err)
+  AuditHead ExportAuditHead(1: ExportAuditHeadRequest req) throws (1: common.ServiceError err)
+
+  SubmitSurveyResponse SubmitSurvey(1: SubmitSurveyRequest req) throws (1: common.ServiceError err)
+  CSATReportResponse CSATReport(1: CSATReportRequest req) throws (1: common.ServiceError err)
+  EscalationReportResponse EscalationReport(1: EscalationReportRequest req) throws (1: common.ServiceError err)
+
+  // Saved views are visible to their owner (personal) or to members of their team.
+  SavedView CreateView(1: SaveViewRequest req) throws (1: common.ServiceError err)
+  SavedView UpdateView(1: SaveViewRequest req) throws (1: common.ServiceError err)
+  SavedView GetView(1: ViewRequest req) throws (1: common.ServiceError err)
+  DeleteViewResponse DeleteView(1: ViewRequest req) throws (1: common.ServiceError err)
+  ListViewsResponse ListViews(1: ListViewsRequest req) throws (1: common.ServiceError err)
+  ListTicketsResponse ViewTickets(1: ViewTicketsRequest req) throws (1: common.ServiceError err)
+
+  // PullNext claims the best-scoring unassigned ticket for the calling agent; the claim
+  // lapses unless the agent starts work (Assign) before it expires.
+  PullNextResponse PullNext(1: PullNextRequest req) throws (1: common.ServiceError err)
+
+  // Edit locks: AcquireLock also renews (heartbeat) the caller's own lock.
+  LockResponse AcquireLock(1: LockRequest req) throws (1: common.ServiceError err)
+  LockResponse ReleaseLock(1: LockRequest req) throws (1: common.ServiceError err)
+  ListLockEventsResponse ListLockEvents(1: ListLockEventsRequest req) throws (1: common.ServiceError err)
+
+  TicketResponse DeleteTicket(1: TicketActionRequest req) throws (1: common.ServiceError err)    // soft delete
+  TicketResponse RestoreTicket(1: TicketActionRequest req) throws (1: common.ServiceError err)   // undo soft delete
+  TicketResponse Archive(1: TicketActionRequest req) throws (1: common.ServiceError err)
+  TicketResponse Unarchive(1: TicketActionRequest req) throws (1: common.ServiceError err)
+  RetentionReport ApplyRetention(1: ApplyRetentionRequest req) throws (1: common.ServiceError err)   // caller's tenant only
+
+  ExportSubjectResponse ExportSubject(1: common.SubjectRequest req) throws (1: common.ServiceError err)
+  ErasureReceipt EraseSubject(1: EraseSubjectRequest req) throws (1: common.ServiceError err)
+  ErasureReceipt GetErasureReceipt(1: GetErasureReceiptRequest req) throws (1: common.ServiceError err)
 }
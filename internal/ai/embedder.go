@@ -0,0 +1,30 @@
+package ai
+
+import (
+	"context"
+
+	"github.com/gogogo1024/assist-fusion/kitex_gen/ai/aiservice"
+	kcommon "github.com/gogogo1024/assist-fusion/kitex_gen/common"
+)
+
+// Embedder turns texts into vectors (one per text).
+type Embedder interface {
+	Embed(ctx context.Context, texts []string) ([][]float64, error)
+}
+
+// rpcEmbedder calls AIService.Embeddings.
+type rpcEmbedder struct {
+	c   aiservice.Client
+	dim int32
+}
+
+// NewRPCEmbedder adapts an ai-rpc client; dim <= 0 lets the service choose.
+func NewRPCEmbedder(c aiservice.Client, dim int32) Embedder { return &rpcEmbedder{c: c, dim: dim} }
+
+func (e *rpcEmbedder) Embed(ctx context.Context, texts []string) ([][]float64, error) {
+	resp, err := e.c.Embeddings(ctx, &kcommon.EmbeddingRequest{Texts: texts, Dim: e.dim})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Vectors, nil
+}
@@ -0,0 +1,159 @@
+// Package audit implements the tamper-evident hash chain over ticket events.
+//
+// Each event stores PrevHash (the previous event's Hash, empty for the first one) and
+// Hash = sha256(PrevHash || "\n" || Digest(event)), where Digest covers the event content
+// and the owning ticket id. Editing, removing or reordering any event breaks every later link.
+// Erasure (GDPR) redacts event content in place but pins the original digest, see Redact.
+package audit
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gogogo1024/assist-fusion/internal/common"
+)
+
+// Digest hashes the content of one event. Fields are length-prefixed so that
+// shifting bytes between adjacent fields changes the digest.
+func Digest(ticketID string, e *common.TicketEvent) string {
+	h := sha256.New()
+	fields := []string{ticketID, e.Type, strconv.FormatInt(e.At, 10), e.Note, e.Actor.ID, e.Actor.Kind, e.Actor.Name, e.Source}
+	// tier fields only join the digest when set, so digests of other events stay unchanged
+	if e.FromTier != "" || e.ToTier != "" {
+		fields = append(fields, e.FromTier, e.ToTier)
+	}
+	for _, f := range fields {
+		h.Write([]byte(strconv.Itoa(len(f))))
+		h.Write([]byte{':'})
+		h.Write([]byte(f))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// eventDigest is the digest the chain was built with: the pinned one for redacted events.
+func eventDigest(ticketID string, e *common.TicketEvent) string {
+	if e.RedactedDigest != "" {
+		return e.RedactedDigest
+	}
+	return Digest(ticketID, e)
+}
+
+// Redact pins the current content digest of e and then lets scrub erase its personal
+// fields. Links stay verifiable; the redacted content itself can no longer be checked.
+func Redact(ticketID string, e *common.TicketEvent, scrub func(*common.TicketEvent)) {
+	if e.RedactedDigest == "" {
+		e.RedactedDigest = Digest(ticketID, e)
+	}
+	scrub(e)
+}
+
+// Link computes the chained hash of an event given its predecessor's hash.
+func Link(prevHash, digest string) string {
+	sum := sha256.Sum256([]byte(prevHash + "\n" + digest))
+	return hex.EncodeToString(sum[:])
+}
+
+// Append chains e onto events and returns the extended slice.
+func Append(ticketID string, events []common.TicketEvent, e common.TicketEvent) []common.TicketEvent {
+	prev := ""
+	if n := len(events); n > 0 {
+		prev = events[n-1].Hash
+	}
+	e.PrevHash = prev
+	e.Hash = Link(prev, Digest(ticketID, &e))
+	return append(events, e)
+}
+
+// Head returns the hash of the last event (empty when there are no events).
+func Head(events []common.TicketEvent) string {
+	if len(events) == 0 {
+		return ""
+	}
+	return events[len(events)-1].Hash
+}
+
+// Result reports the outcome of walking one ticket's chain.
+type Result struct {
+	OK bool
+	// BrokenIndex is the first event whose link does not verify, -1 when OK.
+	BrokenIndex int
+	Reason      string
+}
+
+// Verify walks events in order and reports the first broken link.
+func Verify(ticketID string, events []common.TicketEvent) Result {
+	prev := ""
+	for i := range events {
+		e := &events[i]
+		if e.PrevHash != prev {
+			return Result{BrokenIndex: i, Reason: "prev_hash does not match previous event"}
+		}
+		if want := Link(prev, eventDigest(ticketID, e)); e.Hash != want {
+			return Result{BrokenIndex: i, Reason: "hash does not match event content"}
+		}
+		prev = e.Hash
+	}
+	return Result{OK: true, BrokenIndex: -1}
+}
+
+// Signer produces HMAC-SHA256 signatures over exported chain heads.
+type Signer struct {
+	KeyID string
+	key   []byte
+}
+
+// NewSigner returns a signer for key; an empty key yields nil (exports stay unsigned).
+func NewSigner(keyID string, key []byte) *Signer {
+	if len(key) == 0 {
+		return nil
+	}
+	if keyID == "" {
+		keyID = "default"
+	}
+	return &Signer{KeyID: keyID, key: key}
+}
+
+// HeadPayload is the canonical byte string signed for a chain head export.
+func HeadPayload(ticketID string, count int, headHash string, exportedAt int64) []byte {
+	return []byte(fmt.Sprintf("assistfusion-audit-head/v1\n%s\n%d\n%s\n%d", ticketID, count, headHash, exportedAt))
+}
+
+// ErasureService is one service's part of an erasure receipt.
+type ErasureService struct {
+	Service string
+	Records []string
+	Fields  int
+}
+
+// ReceiptPayload is the canonical byte string signed for a subject erasure receipt.
+// Services and their records must already be in a stable order.
+func ReceiptPayload(id, subjectHash, tenant string, erasedAt int64, services []ErasureService) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "assistfusion-erasure-receipt/v1\n%s\n%s\n%s\n%d", id, subjectHash, tenant, erasedAt)
+	for _, s := range services {
+		fmt.Fprintf(&b, "\n%s %d %s", s.Service, s.Fields, strings.Join(s.Records, ","))
+	}
+	return []byte(b.String())
+}
+
+// Sign returns the hex HMAC of payload.
+func (s *Signer) Sign(payload []byte) string {
+	m := hmac.New(sha256.New, s.key)
+	m.Write(payload)
+	return hex.EncodeToString(m.Sum(nil))
+}
+
+// Check reports whether sig is a valid signature of payload.
+func (s *Signer) Check(payload []byte, sig string) bool {
+	want, err := hex.DecodeString(sig)
+	if err != nil {
+		return false
+	}
+	m := hmac.New(sha256.New, s.key)
+	m.Write(payload)
+	return hmac.Equal(m.Sum(nil), want)
+}
@@ -0,0 +1,83 @@
+package audit
+
+import (
+	"testing"
+
+	"github.com/gogogo1024/assist-fusion/internal/common"
+)
+
+func buildChain(id string) []common.TicketEvent {
+	var es []common.TicketEvent
+	for i, typ := range []string{"created", "assigned", "resolved"} {
+		es = Append(id, es, common.TicketEvent{Type: typ, At: int64(100 + i), Note: typ, Actor: common.SystemActor, Source: common.SourceRPC})
+	}
+	return es
+}
+
+func TestVerifyIntactChain(t *testing.T) {
+	es := buildChain("t1")
+	if es[0].PrevHash != "" || es[1].PrevHash != es[0].Hash {
+		t.Fatalf("links not chained: %+v", es)
+	}
+	if r := Verify("t1", es); !r.OK || r.BrokenIndex != -1 {
+		t.Fatalf("expected ok, got %+v", r)
+	}
+}
+
+func TestVerifyDetectsTampering(t *testing.T) {
+	cases := map[string]struct {
+		mutate func([]common.TicketEvent) []common.TicketEvent
+		at     int
+	}{
+		"edited note":  {func(es []common.TicketEvent) []common.TicketEvent { es[1].Note = "changed"; return es }, 1},
+		"edited actor": {func(es []common.TicketEvent) []common.TicketEvent { es[2].Actor.ID = "mallory"; return es }, 2},
+		"removed":      {func(es []common.TicketEvent) []common.TicketEvent { return append(es[:1], es[2:]...) }, 1},
+		"swapped":      {func(es []common.TicketEvent) []common.TicketEvent { es[1], es[2] = es[2], es[1]; return es }, 1},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := Verify("t1", tc.mutate(buildChain("t1")))
+			if r.OK || r.BrokenIndex != tc.at {
+				t.Fatalf("expected break at %d, got %+v", tc.at, r)
+			}
+		})
+	}
+	// events moved to another ticket do not verify there
+	if r := Verify("t2", buildChain("t1")); r.OK || r.BrokenIndex != 0 {
+		t.Fatalf("cross-ticket chain verified: %+v", r)
+	}
+}
+
+func TestSignerRoundTrip(t *testing.T) {
+	if NewSigner("k", nil) != nil {
+		t.Fatal("empty key should yield nil signer")
+	}
+	s := NewSigner("", []byte("secret"))
+	if s.KeyID != "default" {
+		t.Fatalf("key id %q", s.KeyID)
+	}
+	p := HeadPayload("t1", 3, "abc", 42)
+	sig := s.Sign(p)
+	if !s.Check(p, sig) {
+		t.Fatal("signature did not verify")
+	}
+	if s.Check(HeadPayload("t1", 4, "abc", 42), sig) {
+		t.Fatal("signature verified for different payload")
+	}
+	if NewSigner("k", []byte("other")).Check(p, sig) {
+		t.Fatal("signature verified with different key")
+	}
+}
+
+func TestRedactKeepsChainVerifiable(t *testing.T) {
+	es := buildChain("t1")
+	Redact("t1", &es[1], func(e *common.TicketEvent) { e.Note = ""; e.Actor = common.Actor{ID: "[redacted]"} })
+	if r := Verify("t1", es); !r.OK {
+		t.Fatalf("redacted chain should verify: %+v", r)
+	}
+	// tampering with links of a redacted event is still detected
+	es[1].PrevHash = "x"
+	if r := Verify("t1", es); r.OK || r.BrokenIndex != 1 {
+		t.Fatalf("expected break at 1, got %+v", r)
+	}
+}
@@ -0,0 +1,133 @@
+package common
+
+import (
+	"context"
+	"strings"
+
+	"github.com/bytedance/gopkg/cloud/metainfo"
+)
+
+// Actor kinds recorded on ticket events.
+const (
+	ActorKindUser       = "user"
+	ActorKindSystem     = "system"
+	ActorKindAutomation = "automation"
+)
+
+// Event sources: which entry point performed the change.
+const (
+	SourceGateway   = "gateway"
+	SourceCLI       = "cli"
+	SourceRule      = "rule"
+	SourceScheduler = "scheduler"
+	SourceEmail     = "email"
+	// SourceRPC marks direct RPC callers that did not declare a source.
+	SourceRPC = "rpc"
+)
+
+// Actor identifies who performed an action.
+type Actor struct {
+	ID   string `json:"id"`
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+}
+
+// SystemActor is used when no principal was propagated with the request.
+var SystemActor = Actor{ID: "system", Kind: ActorKindSystem, Name: "system"}
+
+// metainfo keys; transient values travel one hop (gateway -> rpc) over TTHeader.
+const (
+	metaActorID   = "AF_ACTOR_ID"
+	metaActorKind = "AF_ACTOR_KIND"
+	metaActorName = "AF_ACTOR_NAME"
+	metaSource    = "AF_SOURCE"
+	metaTeams     = "AF_ACTOR_TEAMS"
+	metaRoles     = "AF_ACTOR_ROLES"
+)
+
+// RoleSupervisor may override other agents (e.g. force-release their edit locks).
+const RoleSupervisor = "supervisor"
+
+// RoleAdmin administers the tenant; like supervisors, admins may run destructive operations.
+const RoleAdmin = "admin"
+
+// WithActor attaches the principal and source to ctx so Kitex clients forward them downstream.
+func WithActor(ctx context.Context, a Actor, source string) context.Context {
+	if a.ID != "" {
+		ctx = metainfo.WithValue(ctx, metaActorID, a.ID)
+	}
+	if a.Kind != "" {
+		ctx = metainfo.WithValue(ctx, metaActorKind, a.Kind)
+	}
+	if a.Name != "" {
+		ctx = metainfo.WithValue(ctx, metaActorName, a.Name)
+	}
+	if source != "" {
+		ctx = metainfo.WithValue(ctx, metaSource, source)
+	}
+	return ctx
+}
+
+// ActorFromContext returns the propagated principal and source.
+// Missing values fall back to SystemActor and SourceRPC.
+func ActorFromContext(ctx context.Context) (Actor, string) {
+	a := SystemActor
+	if id, ok := metainfo.GetValue(ctx, metaActorID); ok && id != "" {
+		a = Actor{ID: id, Kind: ActorKindUser, Name: id}
+		if k, ok := metainfo.GetValue(ctx, metaActorKind); ok && k != "" {
+			a.Kind = k
+		}
+		if n, ok := metainfo.GetValue(ctx, metaActorName); ok && n != "" {
+			a.Name = n
+		}
+	}
+	source := SourceRPC
+	if s, ok := metainfo.GetValue(ctx, metaSource); ok && s != "" {
+		source = s
+	}
+	return a, source
+}
+
+// WithTeams attaches the caller's team memberships (forwarded like the actor).
+func WithTeams(ctx context.Context, teams []string) context.Context {
+	return withList(ctx, metaTeams, teams)
+}
+
+// TeamsFromContext returns the propagated team memberships (nil when none).
+func TeamsFromContext(ctx context.Context) []string { return listFromContext(ctx, metaTeams) }
+
+// WithRoles attaches the caller's roles (forwarded like the actor).
+func WithRoles(ctx context.Context, roles []string) context.Context {
+	return withList(ctx, metaRoles, roles)
+}
+
+// HasRole reports whether the propagated roles include role.
+func HasRole(ctx context.Context, role string) bool {
+	for _, r := range listFromContext(ctx, metaRoles) {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+func withList(ctx context.Context, key string, vals []string) context.Context {
+	if len(vals) == 0 {
+		return ctx
+	}
+	return metainfo.WithValue(ctx, key, strings.Join(vals, ","))
+}
+
+func listFromContext(ctx context.Context, key string) []string {
+	v, ok := metainfo.GetValue(ctx, key)
+	if !ok || v == "" {
+		return nil
+	}
+	var out []string
+	for _, t := range strings.Split(v, ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			out = append(out, t)
+		}
+	}
+	return out
+}
@@ -25,6 +25,8 @@ type Config struct {
 	TicketRPCAddr string
 	KBRPCAddr     string
 	AIRPCAddr     string
+	// TenantJWTSecret, when set, makes the gateway verify HS256 bearer tokens before trusting their tenant claim
+	TenantJWTSecret string
 }
 
 func LoadConfig() *Config {
@@ -54,6 +56,8 @@ func LoadConfig() *Config {
 		TicketRPCAddr: getenv("TICKET_RPC_ADDR", "127.0.0.1:8201"),
 		KBRPCAddr:     getenv("KB_RPC_ADDR", "127.0.0.1:8202"),
 		AIRPCAddr:     getenv("AI_RPC_ADDR", "127.0.0.1:8203"),
+		// no default: tokens are then assumed verified by the upstream auth proxy
+		TenantJWTSecret: getenv("TENANT_JWT_SECRET", ""),
 	}
 }
 
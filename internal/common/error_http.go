@@ -33,6 +33,12 @@ func MapErrorCodeToHTTP(code string) int32 {
 		return 503
 	case ErrCodeInternal:
 		return 500
+	case ErrCodeUnauthorized:
+		return 401
+	case ErrCodeForbidden:
+		return 403
+	case ErrCodeQuotaExceeded:
+		return 429
 	default:
 		return 500
 	}
@@ -3,6 +3,7 @@ package common
 import (
 	"context"
 	"errors"
+	"sync"
 )
 
 // Minimal subset recreated after cleanup to support ticket RPC & probe.
@@ -13,30 +14,46 @@ const (
 	ErrCodeConflict      = "conflict"
 	ErrCodeKBUnavailable = "kb_unavailable"
 	ErrCodeInternal      = "internal_error"
+	ErrCodeUnauthorized  = "unauthorized"
+	ErrCodeForbidden     = "forbidden"
+	ErrCodeQuotaExceeded = "quota_exceeded"
 )
 
 // Ticket domain model (simplified) kept for in-memory probe & RPC service.
 type Ticket struct {
-	ID           string        `json:"id"`
-	Title        string        `json:"title"`
-	Desc         string        `json:"desc"`
-	Status       string        `json:"status"`
-	CreatedAt    int64         `json:"created_at"`
-	AssignedAt   int64         `json:"assigned_at"`
-	ResolvedAt   int64         `json:"resolved_at"`
-	EscalatedAt  int64         `json:"escalated_at"`
-	ReopenedAt   int64         `json:"reopened_at"`
-	ClosedAt     int64         `json:"closed_at"`
-	CanceledAt   int64         `json:"canceled_at"`
-	Assignee     string        `json:"assignee"`
-	Priority     string        `json:"priority"`
-	Customer     string        `json:"customer"`
-	Category     string        `json:"category"`
-	Tags         []string      `json:"tags"`
-	DueAt        int64         `json:"due_at"`
-	Cycles       []TicketCycle `json:"cycles,omitempty"`
-	CurrentCycle int           `json:"current_cycle"`
-	Events       []TicketEvent `json:"events,omitempty"`
+	ID           string            `json:"id"`
+	Tenant       string            `json:"tenant"`
+	Title        string            `json:"title"`
+	Desc         string            `json:"desc"`
+	Status       string            `json:"status"`
+	CreatedAt    int64             `json:"created_at"`
+	AssignedAt   int64             `json:"assigned_at"`
+	ResolvedAt   int64             `json:"resolved_at"`
+	EscalatedAt  int64             `json:"escalated_at"`
+	ReopenedAt   int64             `json:"reopened_at"`
+	ClosedAt     int64             `json:"closed_at"`
+	CanceledAt   int64             `json:"canceled_at"`
+	Assignee     string            `json:"assignee"`
+	Priority     string            `json:"priority"`
+	Customer     string            `json:"customer"`
+	Category     string            `json:"category"`
+	Tags         []string          `json:"tags"`
+	CustomFields map[string]string `json:"custom_fields,omitempty"`
+	Watchers     []string          `json:"watchers,omitempty"`
+	DueAt        int64             `json:"due_at"`
+	Cycles       []TicketCycle     `json:"cycles,omitempty"`
+	CurrentCycle int               `json:"current_cycle"`
+	Events       []TicketEvent     `json:"events,omitempty"`
+	Comments     []TicketComment   `json:"comments,omitempty"`
+	// ArchivedAt hides the ticket from default lists; DeletedAt hides it everywhere until restored.
+	ArchivedAt int64 `json:"archived_at,omitempty"`
+	DeletedAt  int64 `json:"deleted_at,omitempty"`
+	Anonymized bool  `json:"anonymized,omitempty"`
+	// Tier is the support tier whose queue holds the ticket (see the escalation paths).
+	Tier string `json:"tier,omitempty"`
+	// ClaimedBy holds a pull-queue claim until the agent starts work or ClaimExpiresAt passes.
+	ClaimedBy      string `json:"claimed_by,omitempty"`
+	ClaimExpiresAt int64  `json:"claim_expires_at,omitempty"`
 }
 
 // TicketCycle stores timestamps of one lifecycle iteration.
@@ -48,16 +65,59 @@ type TicketCycle struct {
 	ClosedAt    int64  `json:"closed_at"`
 	CanceledAt  int64  `json:"canceled_at"`
 	Status      string `json:"status"`
+	// SurveyToken is issued when the cycle is resolved; SurveyIssuedAt bounds its validity.
+	SurveyToken    string        `json:"survey_token,omitempty"`
+	SurveyIssuedAt int64         `json:"survey_issued_at,omitempty"`
+	CSAT           *CSATResponse `json:"csat,omitempty"`
+	Tier           string        `json:"tier,omitempty"`
+	// ResolvedBy is the assignee at resolve time; the cycle's CSAT answer is credited to them.
+	ResolvedBy string `json:"resolved_by,omitempty"`
+}
+
+// CSATResponse is a customer satisfaction answer attached to one cycle.
+type CSATResponse struct {
+	Rating      int    `json:"rating"`
+	Comment     string `json:"comment"`
+	SubmittedAt int64  `json:"submitted_at"`
+}
+
+// TicketComment is a free-text reply on a ticket (agents, customers, inbound email).
+type TicketComment struct {
+	ID          string       `json:"id"`
+	Body        string       `json:"body"`
+	At          int64        `json:"at"`
+	Actor       Actor        `json:"actor"`
+	Source      string       `json:"source"`
+	Attachments []Attachment `json:"attachments,omitempty"`
+}
+
+// Attachment describes a file that came with a comment; only metadata is kept.
+type Attachment struct {
+	Name        string `json:"name"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
 }
 
 // TicketEvent is an immutable audit entry.
 type TicketEvent struct {
-	Type string `json:"type"`
-	At   int64  `json:"at"`
-	Note string `json:"note"`
+	Type   string `json:"type"`
+	At     int64  `json:"at"`
+	Note   string `json:"note"`
+	Actor  Actor  `json:"actor"`
+	Source string `json:"source"`
+	// hash chain links (see internal/audit)
+	PrevHash string `json:"prev_hash"`
+	Hash     string `json:"hash"`
+	// RedactedDigest keeps the content digest of an event whose personal fields were erased,
+	// so the chain still verifies (see audit.Redact).
+	RedactedDigest string `json:"redacted_digest,omitempty"`
+	// FromTier / ToTier record tier moves (ToTier alone on creation).
+	FromTier string `json:"from_tier,omitempty"`
+	ToTier   string `json:"to_tier,omitempty"`
 }
 
-// TicketRepo defines required persistence operations.
+// TicketRepo defines required persistence operations. Implementations scope every
+// operation to TenantFromContext(ctx).
 type TicketRepo interface {
 	Create(ctx context.Context, t *Ticket) error
 	Get(ctx context.Context, id string) (*Ticket, error)
@@ -66,39 +126,85 @@ type TicketRepo interface {
 	Delete(ctx context.Context, id string) error
 }
 
-// MemoryTicketRepo simple in-memory implementation (non-concurrent) retained for ticket RPC & probe.
-type MemoryTicketRepo struct{ store map[string]*Ticket }
+// TenantLister is implemented by repos that can enumerate their tenants, so background
+// jobs (retention) can visit every partition.
+type TenantLister interface {
+	Tenants() []string
+}
+
+// MemoryTicketRepo simple in-memory implementation retained for ticket RPC & probe. The mutex
+// guards the maps only; tickets themselves are mutated in place by the service.
+// Tickets are partitioned by the tenant carried in ctx; other tenants' tickets are invisible.
+type MemoryTicketRepo struct {
+	mu    sync.RWMutex
+	store map[string]map[string]*Ticket
+}
 
 func NewMemoryTicketRepo() *MemoryTicketRepo {
-	return &MemoryTicketRepo{store: make(map[string]*Ticket)}
+	return &MemoryTicketRepo{store: make(map[string]map[string]*Ticket)}
+}
+
+// Tenants lists every tenant that has stored tickets.
+func (r *MemoryTicketRepo) Tenants() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	out := make([]string, 0, len(r.store))
+	for t := range r.store {
+		out = append(out, t)
+	}
+	return out
+}
+
+// tenant returns the caller's partition; callers hold r.mu for writing.
+func (r *MemoryTicketRepo) tenant(ctx context.Context) map[string]*Ticket {
+	tenant := TenantFromContext(ctx)
+	m, ok := r.store[tenant]
+	if !ok {
+		m = make(map[string]*Ticket)
+		r.store[tenant] = m
+	}
+	return m
 }
 
 func (r *MemoryTicketRepo) Create(ctx context.Context, t *Ticket) error {
-	r.store[t.ID] = t
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	t.Tenant = TenantFromContext(ctx)
+	r.tenant(ctx)[t.ID] = t
 	return nil
 }
 func (r *MemoryTicketRepo) Get(ctx context.Context, id string) (*Ticket, error) {
-	if t, ok := r.store[id]; ok {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if t, ok := r.store[TenantFromContext(ctx)][id]; ok {
 		return t, nil
 	}
 	return nil, nil
 }
 func (r *MemoryTicketRepo) List(ctx context.Context) ([]*Ticket, error) {
-	out := make([]*Ticket, 0, len(r.store))
-	for _, t := range r.store {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	store := r.store[TenantFromContext(ctx)]
+	out := make([]*Ticket, 0, len(store))
+	for _, t := range store {
 		out = append(out, t)
 	}
 	return out, nil
 }
 func (r *MemoryTicketRepo) Update(ctx context.Context, t *Ticket) error {
-	if _, ok := r.store[t.ID]; !ok {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	store := r.tenant(ctx)
+	if _, ok := store[t.ID]; !ok {
 		return ErrNotFound
 	}
-	r.store[t.ID] = t
+	store[t.ID] = t
 	return nil
 }
 func (r *MemoryTicketRepo) Delete(ctx context.Context, id string) error {
-	delete(r.store, id)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.tenant(ctx), id)
 	return nil
 }
 
@@ -0,0 +1,37 @@
+package common
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/bytedance/gopkg/cloud/metainfo"
+)
+
+// DefaultTenant owns requests that carry no tenant (single-tenant deployments, internal tools).
+const DefaultTenant = "default"
+
+// metaTenant is a persistent metainfo key: unlike the actor it must survive every hop
+// (gateway -> ticket-rpc -> ai-rpc) so downstream services stay scoped to the same tenant.
+const metaTenant = "AF_TENANT"
+
+// tenantIDPattern keeps tenant ids usable as ES index suffixes and map keys.
+var tenantIDPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,62}$`)
+
+// ValidTenantID reports whether id is an acceptable tenant id (lowercase, 1-63 chars).
+func ValidTenantID(id string) bool { return tenantIDPattern.MatchString(id) }
+
+// WithTenant scopes ctx (and every Kitex call made with it) to tenant.
+func WithTenant(ctx context.Context, tenant string) context.Context {
+	if tenant == "" {
+		return ctx
+	}
+	return metainfo.WithPersistentValue(ctx, metaTenant, tenant)
+}
+
+// TenantFromContext returns the propagated tenant, or DefaultTenant when none was set.
+func TenantFromContext(ctx context.Context) string {
+	if t, ok := metainfo.GetPersistentValue(ctx, metaTenant); ok && t != "" {
+		return t
+	}
+	return DefaultTenant
+}
@@ -0,0 +1,161 @@
+// Package fieldcrypt encrypts individual string fields at rest with AES-GCM envelope
+// encryption: every value gets a fresh data key, which is itself sealed under a
+// key-encryption key (KEK) from a KeyProvider.
+//
+// Sealed values are self-describing text, "enc:v1:<kek id>:<wrapped data key>:<ciphertext>"
+// (base64url), so they fit existing string columns and unsealed legacy values can be
+// told apart and migrated lazily. The prefix alone does not make a value sealed: legacy
+// plaintext may start with it too, so only values with the exact envelope shape (a wrapped
+// 32-byte data key, a non-empty ciphertext) are opened. The caller's AAD binds a value to
+// its record and field.
+package fieldcrypt
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"strings"
+)
+
+const (
+	prefix = "enc:v1:"
+	// sizes of the envelope parts; they make a sealed value recognizable beyond its prefix
+	dekSize   = 32
+	nonceSize = 12
+	tagSize   = 16
+)
+
+var ErrMalformed = errors.New("fieldcrypt: malformed sealed value")
+
+var b64 = base64.RawURLEncoding
+
+// Envelope seals and opens field values with keys from a KeyProvider.
+type Envelope struct {
+	keys KeyProvider
+}
+
+func New(keys KeyProvider) *Envelope { return &Envelope{keys: keys} }
+
+// Keys returns the provider (e.g. to reload it before re-encryption).
+func (e *Envelope) Keys() KeyProvider { return e.keys }
+
+// IsSealed reports whether v was produced by Seal.
+func IsSealed(v string) bool {
+	_, _, _, ok := parse(v)
+	return ok
+}
+
+// KeyID returns the KEK id a sealed value is wrapped with ("" for plain values).
+func KeyID(v string) string {
+	id, _, _, _ := parse(v)
+	return id
+}
+
+// parse splits a sealed value into its parts. ok is false for anything Seal cannot have
+// produced, including plaintext that merely starts with the prefix.
+func parse(v string) (id string, wrapped, ct []byte, ok bool) {
+	rest, found := strings.CutPrefix(v, prefix)
+	if !found {
+		return "", nil, nil, false
+	}
+	parts := strings.Split(rest, ":")
+	if len(parts) != 3 || parts[0] == "" || strings.ContainsAny(parts[0], " \t\r\n") {
+		return "", nil, nil, false
+	}
+	wrapped, err1 := b64.DecodeString(parts[1])
+	ct, err2 := b64.DecodeString(parts[2])
+	if err1 != nil || err2 != nil || len(wrapped) != nonceSize+dekSize+tagSize || len(ct) <= nonceSize+tagSize {
+		return "", nil, nil, false
+	}
+	return parts[0], wrapped, ct, true
+}
+
+// Seal encrypts plain under the primary KEK. Empty strings stay empty.
+func (e *Envelope) Seal(plain, aad string) (string, error) {
+	if plain == "" {
+		return "", nil
+	}
+	kek, err := e.keys.Primary()
+	if err != nil {
+		return "", err
+	}
+	dek := make([]byte, dekSize)
+	if _, err := rand.Read(dek); err != nil {
+		return "", err
+	}
+	wrapped, err := gcmSeal(kek.Secret, dek, []byte(kek.ID))
+	if err != nil {
+		return "", err
+	}
+	ct, err := gcmSeal(dek, []byte(plain), []byte(aad))
+	if err != nil {
+		return "", err
+	}
+	return prefix + kek.ID + ":" + b64.EncodeToString(wrapped) + ":" + b64.EncodeToString(ct), nil
+}
+
+// Open decrypts a sealed value; plain (legacy) values are returned unchanged. A value
+// with the envelope shape that does not decrypt is an error, never plaintext: treating it
+// as plain would let re-encryption seal the ciphertext and lose the original.
+func (e *Envelope) Open(v, aad string) (string, error) {
+	id, wrapped, ct, ok := parse(v)
+	if !ok {
+		return v, nil
+	}
+	kek, err := e.keys.Lookup(id)
+	if err != nil {
+		return "", err
+	}
+	dek, err := gcmOpen(kek.Secret, wrapped, []byte(kek.ID))
+	if err != nil {
+		return "", err
+	}
+	plain, err := gcmOpen(dek, ct, []byte(aad))
+	if err != nil {
+		return "", err
+	}
+	return string(plain), nil
+}
+
+// Stale reports whether v should be rewritten: it is plain, or sealed under a non-primary KEK.
+func (e *Envelope) Stale(v string) bool {
+	if v == "" {
+		return false
+	}
+	kek, err := e.keys.Primary()
+	return err == nil && KeyID(v) != kek.ID
+}
+
+// gcmSeal returns nonce || ciphertext.
+func gcmSeal(key, plain, aad []byte) ([]byte, error) {
+	g, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, g.NonceSize(), g.NonceSize()+len(plain)+g.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return g.Seal(nonce, nonce, plain, aad), nil
+}
+
+func gcmOpen(key, sealed, aad []byte) ([]byte, error) {
+	g, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < g.NonceSize() {
+		return nil, ErrMalformed
+	}
+	return g.Open(nil, sealed[:g.NonceSize()], sealed[g.NonceSize():], aad)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	b, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(b)
+}
@@ -0,0 +1,112 @@
+package fieldcrypt
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSealOpenAndRotate(t *testing.T) {
+	k1 := Key{ID: "k1", Secret: bytes.Repeat([]byte{1}, 32)}
+	k2 := Key{ID: "k2", Secret: bytes.Repeat([]byte{2}, 32)}
+	env := New(StaticKeys{k1})
+
+	v, err := env.Seal("call 555-0100", "t1/desc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !IsSealed(v) || KeyID(v) != "k1" || strings.Contains(v, "555") {
+		t.Fatalf("unexpected sealed value %q", v)
+	}
+	if got, err := env.Open(v, "t1/desc"); err != nil || got != "call 555-0100" {
+		t.Fatalf("Open = %q, %v", got, err)
+	}
+	if _, err := env.Open(v, "t2/desc"); err == nil {
+		t.Fatal("value moved to another record must not open")
+	}
+	if got, _ := env.Open("legacy plain", "t1/desc"); got != "legacy plain" {
+		t.Fatal("plain values pass through")
+	}
+	if s, _ := env.Seal("", "x"); s != "" {
+		t.Fatal("empty stays empty")
+	}
+
+	// rotation: new primary seals, old key still opens, old values are stale
+	env = New(StaticKeys{k1, k2})
+	if !env.Stale(v) || !env.Stale("legacy plain") || env.Stale("") {
+		t.Fatal("stale detection")
+	}
+	if got, err := env.Open(v, "t1/desc"); err != nil || got != "call 555-0100" {
+		t.Fatalf("old key Open = %q, %v", got, err)
+	}
+	v2, _ := env.Seal("x", "a")
+	if KeyID(v2) != "k2" || env.Stale(v2) {
+		t.Fatalf("new values use the primary key: %q", v2)
+	}
+	if _, err := New(StaticKeys{k2}).Open(v, "t1/desc"); !errors.Is(err, ErrUnknownKey) {
+		t.Fatalf("retired key err = %v", err)
+	}
+}
+
+func TestPlaintextWithPrefix(t *testing.T) {
+	k1 := Key{ID: "k1", Secret: bytes.Repeat([]byte{1}, 32)}
+	env := New(StaticKeys{k1})
+	// legacy rows stored before encryption may start with the marker
+	for _, plain := range []string{"enc:v1:", "enc:v1: see ticket 42", "enc:v1:k1:abc:def", "enc:v1:k9:" + strings.Repeat("A", 80) + ":x"} {
+		if IsSealed(plain) || KeyID(plain) != "" {
+			t.Fatalf("%q taken for a sealed value", plain)
+		}
+		if got, err := env.Open(plain, "t1/desc"); err != nil || got != plain {
+			t.Fatalf("Open(%q) = %q, %v", plain, got, err)
+		}
+		if !env.Stale(plain) {
+			t.Fatalf("%q must be re-sealed", plain)
+		}
+		v, _ := env.Seal(plain, "t1/desc")
+		if got, err := env.Open(v, "t1/desc"); err != nil || got != plain {
+			t.Fatalf("round trip of %q = %q, %v", plain, got, err)
+		}
+	}
+	// a well-formed envelope that fails to decrypt stays an error
+	v, _ := env.Seal("secret", "t1/desc")
+	i := strings.LastIndexByte(v, ':') + 1
+	c := "A"
+	if v[i] == 'A' {
+		c = "B"
+	}
+	flipped := v[:i] + c + v[i+1:]
+	if _, err := env.Open(flipped, "t1/desc"); err == nil {
+		t.Fatal("tampered ciphertext opened as plaintext")
+	}
+}
+
+func TestLoadKeyFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys")
+	key := func(b byte) string { return base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{b}, 32)) }
+	os.WriteFile(path, []byte("# ticket field keys\nold "+key(1)+"\n"), 0o600)
+	p, err := LoadKeyFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.WriteFile(path, []byte("old "+key(1)+"\nnew "+key(2)+" # rotated\n"), 0o600)
+	if err := p.Reload(); err != nil {
+		t.Fatal(err)
+	}
+	if k, _ := p.Primary(); k.ID != "new" {
+		t.Fatalf("primary = %q", k.ID)
+	}
+	if _, err := p.Lookup("old"); err != nil {
+		t.Fatal(err)
+	}
+	os.WriteFile(path, []byte("bad c2hvcnQ=\n"), 0o600)
+	if err := p.Reload(); err == nil {
+		t.Fatal("short key accepted")
+	}
+	if k, _ := p.Primary(); k.ID != "new" {
+		t.Fatal("failed reload must keep previous keys")
+	}
+}
@@ -0,0 +1,134 @@
+package fieldcrypt
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Key is a key-encryption key (KEK). Secret is 32 bytes (AES-256).
+type Key struct {
+	ID     string
+	Secret []byte
+}
+
+// KeyProvider supplies KEKs: Primary wraps new data keys, Lookup opens values sealed
+// under any key that is still known (rotated-out keys stay until re-encryption is done).
+type KeyProvider interface {
+	Primary() (Key, error)
+	Lookup(id string) (Key, error)
+}
+
+var (
+	ErrNoKeys     = errors.New("fieldcrypt: no keys configured")
+	ErrUnknownKey = errors.New("fieldcrypt: unknown key id")
+)
+
+// FileKeyProvider reads KEKs from a local keyfile, one "<id> <base64 32-byte key>" per
+// line ('#' starts a comment). The last key is the primary: rotate by appending a line.
+type FileKeyProvider struct {
+	path string
+	mu   sync.RWMutex
+	keys map[string]Key
+	pri  Key
+}
+
+// LoadKeyFile reads path into a provider.
+func LoadKeyFile(path string) (*FileKeyProvider, error) {
+	p := &FileKeyProvider{path: path}
+	if err := p.Reload(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Reload re-reads the keyfile; on error the previously loaded keys stay in use.
+func (p *FileKeyProvider) Reload() error {
+	data, err := os.ReadFile(p.path)
+	if err != nil {
+		return err
+	}
+	keys, pri, err := parseKeys(data)
+	if err != nil {
+		return fmt.Errorf("%s: %w", p.path, err)
+	}
+	p.mu.Lock()
+	p.keys, p.pri = keys, pri
+	p.mu.Unlock()
+	return nil
+}
+
+func (p *FileKeyProvider) Primary() (Key, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.pri.ID == "" {
+		return Key{}, ErrNoKeys
+	}
+	return p.pri, nil
+}
+
+func (p *FileKeyProvider) Lookup(id string) (Key, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	k, ok := p.keys[id]
+	if !ok {
+		return Key{}, fmt.Errorf("%w %q", ErrUnknownKey, id)
+	}
+	return k, nil
+}
+
+func parseKeys(data []byte) (map[string]Key, Key, error) {
+	keys := map[string]Key{}
+	var pri Key
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimSpace(sc.Text())
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = strings.TrimSpace(line[:i])
+		}
+		if line == "" {
+			continue
+		}
+		f := strings.Fields(line)
+		if len(f) != 2 || strings.Contains(f[0], ":") {
+			return nil, Key{}, fmt.Errorf("line %d: want \"<id> <base64 key>\"", n)
+		}
+		secret, err := base64.StdEncoding.DecodeString(f[1])
+		if err != nil || len(secret) != 32 {
+			return nil, Key{}, fmt.Errorf("line %d: key must be 32 bytes, base64 encoded", n)
+		}
+		if _, dup := keys[f[0]]; dup {
+			return nil, Key{}, fmt.Errorf("line %d: duplicate key id %q", n, f[0])
+		}
+		pri = Key{ID: f[0], Secret: secret}
+		keys[pri.ID] = pri
+	}
+	if len(keys) == 0 {
+		return nil, Key{}, ErrNoKeys
+	}
+	return keys, pri, sc.Err()
+}
+
+// StaticKeys is an in-memory provider; the last key is the primary.
+type StaticKeys []Key
+
+func (s StaticKeys) Primary() (Key, error) {
+	if len(s) == 0 {
+		return Key{}, ErrNoKeys
+	}
+	return s[len(s)-1], nil
+}
+
+func (s StaticKeys) Lookup(id string) (Key, error) {
+	for _, k := range s {
+		if k.ID == id {
+			return k, nil
+		}
+	}
+	return Key{}, fmt.Errorf("%w %q", ErrUnknownKey, id)
+}
@@ -16,15 +16,90 @@ import (
 // ----- Interfaces exposed to HTTP handlers -----
 
 type TicketAPI interface {
-	Create(ctx context.Context, title, desc, note string) (*kcommon.Ticket, error)
+	Create(ctx context.Context, in CreateTicketInput) (*ticket.TicketResponse, error)
 	Get(ctx context.Context, id string) (*kcommon.Ticket, error)
-	List(ctx context.Context) ([]*kcommon.Ticket, error)
-	Assign(ctx context.Context, id, note string) (*kcommon.Ticket, error)
-	Resolve(ctx context.Context, id, note string) (*kcommon.Ticket, error)
-	Escalate(ctx context.Context, id, note string) (*kcommon.Ticket, error)
-	Reopen(ctx context.Context, id, note string) (*kcommon.Ticket, error)
+	List(ctx context.Context, f ListFilter) ([]*kcommon.Ticket, error)
+	Assign(ctx context.Context, id string, in ActionInput) (*kcommon.Ticket, error)
+	// Resolve returns the ticket plus the new cycle's survey token (never included in ticket reads).
+	Resolve(ctx context.Context, id string, in ActionInput) (*ticket.TicketResponse, error)
+	Escalate(ctx context.Context, id string, in ActionInput) (*kcommon.Ticket, error)
+	Deescalate(ctx context.Context, id string, in ActionInput) (*kcommon.Ticket, error)
+	Reopen(ctx context.Context, id string, in ActionInput) (*kcommon.Ticket, error)
 	Cycles(ctx context.Context, id string) ([]*kcommon.TicketCycle, error)
-	Events(ctx context.Context, id string) ([]*kcommon.TicketEvent, error)
+	Events(ctx context.Context, id string, f EventFilter) ([]*kcommon.TicketEvent, error)
+	SubmitSurvey(ctx context.Context, token string, rating int32, comment string) (*ticket.SubmitSurveyResponse, error)
+	CSATReport(ctx context.Context, req *ticket.CSATReportRequest) (*ticket.CSATReportResponse, error)
+	EscalationReport(ctx context.Context, req *ticket.EscalationReportRequest) (*ticket.EscalationReportResponse, error)
+	AddComment(ctx context.Context, id, body string) (*kcommon.Ticket, error)
+	Watch(ctx context.Context, id, userID string) (*kcommon.Ticket, error)
+	Unwatch(ctx context.Context, id, userID string) (*kcommon.Ticket, error)
+	Notifications(ctx context.Context, unreadOnly bool, limit int32) (*ticket.ListNotificationsResponse, error)
+	MarkNotifications(ctx context.Context, ids []string, all, read bool) (*ticket.MarkNotificationsResponse, error)
+	NotificationPrefs(ctx context.Context) (*ticket.NotificationPrefs, error)
+	SetNotificationPrefs(ctx context.Context, p *ticket.NotificationPrefs) (*ticket.NotificationPrefs, error)
+	Delete(ctx context.Context, id string, in ActionInput) (*kcommon.Ticket, error)
+	Restore(ctx context.Context, id string, in ActionInput) (*kcommon.Ticket, error)
+	Archive(ctx context.Context, id string, in ActionInput) (*kcommon.Ticket, error)
+	Unarchive(ctx context.Context, id string, in ActionInput) (*kcommon.Ticket, error)
+	ApplyRetention(ctx context.Context, dryRun bool) (*ticket.RetentionReport, error)
+	ExportSubject(ctx context.Context, subject string) (*ticket.ExportSubjectResponse, error)
+	// EraseSubject erases the subject from tickets and signs a receipt that also lists related (other services').
+	EraseSubject(ctx context.Context, subject string, related []*kcommon.ErasureSummary) (*ticket.ErasureReceipt, error)
+	ErasureReceipt(ctx context.Context, id string) (*ticket.ErasureReceipt, error)
+	CreateView(ctx context.Context, v *ticket.SavedView) (*ticket.SavedView, error)
+	UpdateView(ctx context.Context, v *ticket.SavedView) (*ticket.SavedView, error)
+	GetView(ctx context.Context, id string) (*ticket.SavedView, error)
+	DeleteView(ctx context.Context, id string) error
+	// ListViews returns the caller's views; counts evaluates each one (otherwise Count is -1).
+	ListViews(ctx context.Context, counts bool) ([]*ticket.ViewSummary, error)
+	ViewTickets(ctx context.Context, id string, page, pageSize int32) (*ticket.ListTicketsResponse, error)
+	// PullNext claims the best eligible ticket for the caller (Ticket unset when the queue is empty).
+	PullNext(ctx context.Context, skills []string, tier string) (*ticket.PullNextResponse, error)
+	// AcquireLock takes or renews (heartbeat) the caller's edit lock on a ticket.
+	AcquireLock(ctx context.Context, id string) (*kcommon.EditLock, error)
+	ReleaseLock(ctx context.Context, id string, force bool) error
+	// LockEvents returns lock events after seq after (negative: only the cursor), optionally for one ticket.
+	LockEvents(ctx context.Context, after int64, ticketID string) (*ticket.ListLockEventsResponse, error)
+}
+
+// CreateTicketInput carries the optional fields accepted by POST /v1/tickets.
+type CreateTicketInput struct {
+	Title    string
+	Desc     string
+	Note     string
+	Category string
+	Customer string
+	Priority string
+	Tags     []string
+	// CustomFields are free-form key/value attributes views can filter on.
+	CustomFields map[string]string
+	// DueAt (unix seconds) drives the ticket's SLA status; 0 means no deadline.
+	DueAt int64
+	// StrictDuplicates rejects the ticket with a conflict when a near-identical open ticket exists.
+	StrictDuplicates bool
+	// IdempotencyKey replays the original response when a retry carries the same key.
+	IdempotencyKey string
+}
+
+// EventFilter narrows GET /v1/tickets/:id/events; empty fields match everything.
+type EventFilter struct {
+	ActorID   string
+	ActorKind string
+}
+
+// ListFilter narrows GET /v1/tickets.
+type ListFilter struct {
+	// Include reveals hidden tickets: "archived" and/or "deleted".
+	Include []string
+	// Tier limits the list to one tier's queue.
+	Tier string
+}
+
+// ActionInput carries the optional body of ticket action endpoints.
+type ActionInput struct {
+	Note     string
+	Assignee string // assign, escalate, deescalate
+	Tier     string // escalate / deescalate target
 }
 
 type KBAPI interface {
@@ -46,16 +121,31 @@ type AIAPI interface {
 // TicketAPI (RPC)
 type ticketRPC struct{ c ticketservice.Client }
 
-func (t *ticketRPC) Create(ctx context.Context, title, desc, note string) (*kcommon.Ticket, error) {
-	req := &ticket.CreateTicketRequest{Title: title, Desc: desc}
-	if note != "" {
-		req.Note = &note
+func (t *ticketRPC) Create(ctx context.Context, in CreateTicketInput) (*ticket.TicketResponse, error) {
+	req := &ticket.CreateTicketRequest{Title: in.Title, Desc: in.Desc}
+	if in.Note != "" {
+		req.Note = &in.Note
 	}
-	resp, err := t.c.CreateTicket(ctx, req)
-	if err != nil {
-		return nil, err
+	if in.Category != "" {
+		req.Category = &in.Category
 	}
-	return resp.GetTicket(), nil
+	if in.Customer != "" {
+		req.Customer = &in.Customer
+	}
+	if in.Priority != "" {
+		req.Priority = &in.Priority
+	}
+	req.Tags, req.CustomFields = in.Tags, in.CustomFields
+	if in.DueAt != 0 {
+		req.DueAt = &in.DueAt
+	}
+	if in.StrictDuplicates {
+		req.StrictDuplicates = &in.StrictDuplicates
+	}
+	if in.IdempotencyKey != "" {
+		req.IdempotencyKey = &in.IdempotencyKey
+	}
+	return t.c.CreateTicket(ctx, req)
 }
 func (t *ticketRPC) Get(ctx context.Context, id string) (*kcommon.Ticket, error) {
 	resp, err := t.c.GetTicket(ctx, &ticket.GetTicketRequest{Id: id})
@@ -64,62 +154,238 @@ func (t *ticketRPC) Get(ctx context.Context, id string) (*kcommon.Ticket, error)
 	}
 	return resp.GetTicket(), nil
 }
-func (t *ticketRPC) List(ctx context.Context) ([]*kcommon.Ticket, error) {
-	resp, err := t.c.ListTickets(ctx, &ticket.ListTicketsRequest{})
+func (t *ticketRPC) List(ctx context.Context, f ListFilter) ([]*kcommon.Ticket, error) {
+	req := &ticket.ListTicketsRequest{Include: f.Include}
+	if f.Tier != "" {
+		req.Tier = &f.Tier
+	}
+	resp, err := t.c.ListTickets(ctx, req)
 	if err != nil {
 		return nil, err
 	}
 	return resp.GetTickets(), nil
 }
-func (t *ticketRPC) Assign(ctx context.Context, id, note string) (*kcommon.Ticket, error) {
-	req := &ticket.TicketActionRequest{Id: id}
-	if note != "" {
-		req.Note = &note
+func (t *ticketRPC) Assign(ctx context.Context, id string, in ActionInput) (*kcommon.Ticket, error) {
+	resp, err := t.c.Assign(ctx, actionRequest(id, in))
+	if err != nil {
+		return nil, err
 	}
-	resp, err := t.c.Assign(ctx, req)
+	return resp.GetTicket(), nil
+}
+func (t *ticketRPC) Resolve(ctx context.Context, id string, in ActionInput) (*ticket.TicketResponse, error) {
+	return t.c.Resolve(ctx, actionRequest(id, in))
+}
+func (t *ticketRPC) Escalate(ctx context.Context, id string, in ActionInput) (*kcommon.Ticket, error) {
+	resp, err := t.c.Escalate(ctx, actionRequest(id, in))
 	if err != nil {
 		return nil, err
 	}
 	return resp.GetTicket(), nil
 }
-func (t *ticketRPC) Resolve(ctx context.Context, id, note string) (*kcommon.Ticket, error) {
-	req := &ticket.TicketActionRequest{Id: id}
-	if note != "" {
-		req.Note = &note
+func (t *ticketRPC) Deescalate(ctx context.Context, id string, in ActionInput) (*kcommon.Ticket, error) {
+	resp, err := t.c.Deescalate(ctx, actionRequest(id, in))
+	if err != nil {
+		return nil, err
 	}
-	resp, err := t.c.Resolve(ctx, req)
+	return resp.GetTicket(), nil
+}
+func (t *ticketRPC) Reopen(ctx context.Context, id string, in ActionInput) (*kcommon.Ticket, error) {
+	resp, err := t.c.Reopen(ctx, actionRequest(id, in))
 	if err != nil {
 		return nil, err
 	}
 	return resp.GetTicket(), nil
 }
-func (t *ticketRPC) Escalate(ctx context.Context, id, note string) (*kcommon.Ticket, error) {
-	req := &ticket.TicketActionRequest{Id: id}
-	if note != "" {
-		req.Note = &note
+func (t *ticketRPC) Delete(ctx context.Context, id string, in ActionInput) (*kcommon.Ticket, error) {
+	resp, err := t.c.DeleteTicket(ctx, actionRequest(id, in))
+	if err != nil {
+		return nil, err
 	}
-	resp, err := t.c.Escalate(ctx, req)
+	return resp.GetTicket(), nil
+}
+func (t *ticketRPC) Restore(ctx context.Context, id string, in ActionInput) (*kcommon.Ticket, error) {
+	resp, err := t.c.RestoreTicket(ctx, actionRequest(id, in))
 	if err != nil {
 		return nil, err
 	}
 	return resp.GetTicket(), nil
 }
-func (t *ticketRPC) Reopen(ctx context.Context, id, note string) (*kcommon.Ticket, error) {
-	req := &ticket.TicketActionRequest{Id: id}
-	if note != "" {
-		req.Note = &note
+func (t *ticketRPC) Archive(ctx context.Context, id string, in ActionInput) (*kcommon.Ticket, error) {
+	resp, err := t.c.Archive(ctx, actionRequest(id, in))
+	if err != nil {
+		return nil, err
 	}
-	resp, err := t.c.Reopen(ctx, req)
+	return resp.GetTicket(), nil
+}
+func (t *ticketRPC) Unarchive(ctx context.Context, id string, in ActionInput) (*kcommon.Ticket, error) {
+	resp, err := t.c.Unarchive(ctx, actionRequest(id, in))
 	if err != nil {
 		return nil, err
 	}
 	return resp.GetTicket(), nil
 }
+func (t *ticketRPC) ApplyRetention(ctx context.Context, dryRun bool) (*ticket.RetentionReport, error) {
+	return t.c.ApplyRetention(ctx, &ticket.ApplyRetentionRequest{DryRun: dryRun})
+}
+func (t *ticketRPC) ExportSubject(ctx context.Context, subject string) (*ticket.ExportSubjectResponse, error) {
+	return t.c.ExportSubject(ctx, &kcommon.SubjectRequest{Subject: subject})
+}
+func (t *ticketRPC) EraseSubject(ctx context.Context, subject string, related []*kcommon.ErasureSummary) (*ticket.ErasureReceipt, error) {
+	return t.c.EraseSubject(ctx, &ticket.EraseSubjectRequest{Subject: subject, Related: related})
+}
+func (t *ticketRPC) ErasureReceipt(ctx context.Context, id string) (*ticket.ErasureReceipt, error) {
+	return t.c.GetErasureReceipt(ctx, &ticket.GetErasureReceiptRequest{Id: id})
+}
 func (t *ticketRPC) Cycles(ctx context.Context, id string) ([]*kcommon.TicketCycle, error) {
 	return t.c.GetCycles(ctx, &ticket.GetCyclesRequest{Id: id})
 }
-func (t *ticketRPC) Events(ctx context.Context, id string) ([]*kcommon.TicketEvent, error) {
-	return t.c.GetEvents(ctx, &ticket.GetEventsRequest{Id: id})
+func (t *ticketRPC) Events(ctx context.Context, id string, f EventFilter) ([]*kcommon.TicketEvent, error) {
+	req := &ticket.GetEventsRequest{Id: id}
+	if f.ActorID != "" {
+		req.ActorId = &f.ActorID
+	}
+	if f.ActorKind != "" {
+		req.ActorKind = &f.ActorKind
+	}
+	return t.c.GetEvents(ctx, req)
+}
+func (t *ticketRPC) SubmitSurvey(ctx context.Context, token string, rating int32, comment string) (*ticket.SubmitSurveyResponse, error) {
+	req := &ticket.SubmitSurveyRequest{Token: token, Rating: rating}
+	if comment != "" {
+		req.Comment = &comment
+	}
+	return t.c.SubmitSurvey(ctx, req)
+}
+func (t *ticketRPC) CSATReport(ctx context.Context, req *ticket.CSATReportRequest) (*ticket.CSATReportResponse, error) {
+	return t.c.CSATReport(ctx, req)
+}
+func (t *ticketRPC) EscalationReport(ctx context.Context, req *ticket.EscalationReportRequest) (*ticket.EscalationReportResponse, error) {
+	return t.c.EscalationReport(ctx, req)
+}
+
+func (t *ticketRPC) CreateView(ctx context.Context, v *ticket.SavedView) (*ticket.SavedView, error) {
+	return t.c.CreateView(ctx, &ticket.SaveViewRequest{View: v})
+}
+
+func (t *ticketRPC) UpdateView(ctx context.Context, v *ticket.SavedView) (*ticket.SavedView, error) {
+	return t.c.UpdateView(ctx, &ticket.SaveViewRequest{View: v})
+}
+
+func (t *ticketRPC) GetView(ctx context.Context, id string) (*ticket.SavedView, error) {
+	return t.c.GetView(ctx, &ticket.ViewRequest{Id: id})
+}
+
+func (t *ticketRPC) DeleteView(ctx context.Context, id string) error {
+	_, err := t.c.DeleteView(ctx, &ticket.ViewRequest{Id: id})
+	return err
+}
+
+func (t *ticketRPC) ListViews(ctx context.Context, counts bool) ([]*ticket.ViewSummary, error) {
+	resp, err := t.c.ListViews(ctx, &ticket.ListViewsRequest{Counts: counts})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Views, nil
+}
+
+func (t *ticketRPC) ViewTickets(ctx context.Context, id string, page, pageSize int32) (*ticket.ListTicketsResponse, error) {
+	return t.c.ViewTickets(ctx, &ticket.ViewTicketsRequest{Id: id, Pagination: &kcommon.Pagination{Page: page, PageSize: pageSize}})
+}
+
+func (t *ticketRPC) PullNext(ctx context.Context, skills []string, tier string) (*ticket.PullNextResponse, error) {
+	req := &ticket.PullNextRequest{Skills: skills}
+	if tier != "" {
+		req.Tier = &tier
+	}
+	return t.c.PullNext(ctx, req)
+}
+
+func (t *ticketRPC) AcquireLock(ctx context.Context, id string) (*kcommon.EditLock, error) {
+	resp, err := t.c.AcquireLock(ctx, &ticket.LockRequest{Id: id})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Lock, nil
+}
+
+func (t *ticketRPC) ReleaseLock(ctx context.Context, id string, force bool) error {
+	_, err := t.c.ReleaseLock(ctx, &ticket.LockRequest{Id: id, Force: force})
+	return err
+}
+
+func (t *ticketRPC) LockEvents(ctx context.Context, after int64, ticketID string) (*ticket.ListLockEventsResponse, error) {
+	req := &ticket.ListLockEventsRequest{After: after}
+	if ticketID != "" {
+		req.TicketId = &ticketID
+	}
+	return t.c.ListLockEvents(ctx, req)
+}
+
+func (t *ticketRPC) AddComment(ctx context.Context, id, body string) (*kcommon.Ticket, error) {
+	resp, err := t.c.AddComment(ctx, &ticket.AddCommentRequest{Id: id, Body: body})
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetTicket(), nil
+}
+func (t *ticketRPC) Watch(ctx context.Context, id, userID string) (*kcommon.Ticket, error) {
+	resp, err := t.c.Watch(ctx, watchRequest(id, userID))
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetTicket(), nil
+}
+func (t *ticketRPC) Unwatch(ctx context.Context, id, userID string) (*kcommon.Ticket, error) {
+	resp, err := t.c.Unwatch(ctx, watchRequest(id, userID))
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetTicket(), nil
+}
+func (t *ticketRPC) Notifications(ctx context.Context, unreadOnly bool, limit int32) (*ticket.ListNotificationsResponse, error) {
+	req := &ticket.ListNotificationsRequest{}
+	if unreadOnly {
+		req.UnreadOnly = &unreadOnly
+	}
+	if limit > 0 {
+		req.Limit = &limit
+	}
+	return t.c.ListNotifications(ctx, req)
+}
+func (t *ticketRPC) MarkNotifications(ctx context.Context, ids []string, all, read bool) (*ticket.MarkNotificationsResponse, error) {
+	req := &ticket.MarkNotificationsRequest{Ids: ids, Read_: read}
+	if all {
+		req.All = &all
+	}
+	return t.c.MarkNotifications(ctx, req)
+}
+func (t *ticketRPC) NotificationPrefs(ctx context.Context) (*ticket.NotificationPrefs, error) {
+	return t.c.GetNotificationPrefs(ctx, &ticket.GetNotificationPrefsRequest{})
+}
+func (t *ticketRPC) SetNotificationPrefs(ctx context.Context, p *ticket.NotificationPrefs) (*ticket.NotificationPrefs, error) {
+	return t.c.SetNotificationPrefs(ctx, &ticket.SetNotificationPrefsRequest{Prefs: p})
+}
+
+func watchRequest(id, userID string) *ticket.WatchRequest {
+	req := &ticket.WatchRequest{Id: id}
+	if userID != "" {
+		req.UserId = &userID
+	}
+	return req
+}
+
+func actionRequest(id string, in ActionInput) *ticket.TicketActionRequest {
+	req := &ticket.TicketActionRequest{Id: id}
+	if in.Note != "" {
+		req.Note = &in.Note
+	}
+	if in.Assignee != "" {
+		req.Assignee = &in.Assignee
+	}
+	if in.Tier != "" {
+		req.Tier = &in.Tier
+	}
+	return req
 }
 
 // KBAPI (RPC)
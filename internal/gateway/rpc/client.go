@@ -5,6 +5,7 @@ import (
 	"sync"
 
 	"github.com/cloudwego/kitex/client"
+	"github.com/cloudwego/kitex/transport"
 	"github.com/gogogo1024/assist-fusion/common/clientsuite"
 	"github.com/gogogo1024/assist-fusion/internal/common"
 	"github.com/gogogo1024/assist-fusion/kitex_gen/ai/aiservice"
@@ -27,16 +28,18 @@ func Init(cfg *common.Config) error {
 		// Test / fallback mode: allow disabling consul resolver entirely and use direct host:ports.
 		// Triggered when DISABLE_CONSUL=1 (or empty registry address) so integration tests do not require a registry.
 		disableConsul := os.Getenv("DISABLE_CONSUL") == "1" || cfg.RegistryAddr == "" || cfg.RegistryAddr == "0"
+		// TTHeader carries metainfo (actor / source to ticket-rpc, tenant to every service).
+		ttheader := client.WithTransportProtocol(transport.TTHeaderFramed)
 		if disableConsul {
-			TicketClient, initErr = ticketservice.NewClient("ticket", client.WithHostPorts(cfg.TicketRPCAddr))
+			TicketClient, initErr = ticketservice.NewClient("ticket", ttheader, client.WithHostPorts(cfg.TicketRPCAddr))
 			if initErr != nil {
 				return
 			}
-			KBClient, initErr = kbservice.NewClient("kb", client.WithHostPorts(cfg.KBRPCAddr))
+			KBClient, initErr = kbservice.NewClient("kb", ttheader, client.WithHostPorts(cfg.KBRPCAddr))
 			if initErr != nil {
 				return
 			}
-			AIClient, initErr = aiservice.NewClient("ai", client.WithHostPorts(cfg.AIRPCAddr))
+			AIClient, initErr = aiservice.NewClient("ai", ttheader, client.WithHostPorts(cfg.AIRPCAddr))
 			return
 		}
 
@@ -44,7 +47,7 @@ func Init(cfg *common.Config) error {
 			RegistryAddr:       cfg.RegistryAddr,
 			CurrentServiceName: "gateway", // service name for tracing peer info
 		}
-		opts := []client.Option{client.WithSuite(suite)}
+		opts := []client.Option{client.WithSuite(suite), ttheader}
 
 		TicketClient, initErr = ticketservice.NewClient("ticket", opts...)
 		if initErr != nil {
@@ -0,0 +1,109 @@
+// Package idempotency replays the first response for repeated client request keys.
+package idempotency
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"time"
+)
+
+// MaxKeyLen bounds client supplied keys.
+const MaxKeyLen = 255
+
+// ErrKeyReused is returned when a key arrives again with a different payload.
+var ErrKeyReused = errors.New("idempotency key reused with a different request")
+
+type entry struct {
+	fingerprint string
+	done        chan struct{} // closed once resp/err are set
+	resp        any
+	err         error
+	expires     time.Time
+}
+
+// Store remembers responses by key for ttl. Keys are opaque; callers namespace them
+// (e.g. by tenant and operation) before calling Do.
+type Store struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu        sync.Mutex
+	entries   map[string]*entry
+	lastSweep time.Time
+}
+
+func NewStore(ttl time.Duration) *Store {
+	return &Store{ttl: ttl, now: time.Now, entries: map[string]*entry{}}
+}
+
+// Do runs fn once per key. A repeated key with the same fingerprint gets the stored
+// response (waiting for an in-flight first call); a different fingerprint gets ErrKeyReused.
+// Failed calls are not remembered, so the client may retry them with the same key.
+func (s *Store) Do(key, fingerprint string, fn func() (any, error)) (any, error) {
+	s.mu.Lock()
+	now := s.now()
+	s.sweepLocked(now)
+	if e, ok := s.entries[key]; ok && now.Before(e.expires) {
+		s.mu.Unlock()
+		if e.fingerprint != fingerprint {
+			return nil, ErrKeyReused
+		}
+		<-e.done
+		if e.err != nil {
+			// the first call failed while we waited; run our own attempt
+			return s.Do(key, fingerprint, fn)
+		}
+		return e.resp, nil
+	}
+	e := &entry{fingerprint: fingerprint, done: make(chan struct{}), expires: now.Add(s.ttl)}
+	s.entries[key] = e
+	s.mu.Unlock()
+
+	e.resp, e.err = fn()
+	if e.err != nil {
+		s.mu.Lock()
+		if s.entries[key] == e {
+			delete(s.entries, key)
+		}
+		s.mu.Unlock()
+	}
+	close(e.done)
+	return e.resp, e.err
+}
+
+// sweepLocked drops expired entries at most once per minute.
+func (s *Store) sweepLocked(now time.Time) {
+	if now.Sub(s.lastSweep) < time.Minute {
+		return
+	}
+	s.lastSweep = now
+	for k, e := range s.entries {
+		if !now.Before(e.expires) {
+			select {
+			case <-e.done:
+				delete(s.entries, k)
+			default: // still running
+			}
+		}
+	}
+}
+
+// Fingerprint hashes the request fields that must match for a replay.
+func Fingerprint(fields ...string) string {
+	h := sha256.New()
+	var n [8]byte
+	for _, f := range fields {
+		binary.BigEndian.PutUint64(n[:], uint64(len(f)))
+		h.Write(n[:])
+		h.Write([]byte(f))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Key namespaces a client key by scope (tenant, operation, ...).
+func Key(key string, scope ...string) string {
+	return Fingerprint(append(scope, key)...)
+}
@@ -0,0 +1,83 @@
+package idempotency
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDoReplaysAndRejectsReuse(t *testing.T) {
+	s := NewStore(time.Hour)
+	calls := 0
+	fn := func() (any, error) { calls++; return calls, nil }
+
+	a, err := s.Do("k", "fp1", fn)
+	if err != nil || a != 1 {
+		t.Fatalf("first call = %v, %v", a, err)
+	}
+	b, err := s.Do("k", "fp1", fn)
+	if err != nil || b != 1 || calls != 1 {
+		t.Fatalf("replay = %v, %v (calls=%d)", b, err, calls)
+	}
+	if _, err := s.Do("k", "fp2", fn); !errors.Is(err, ErrKeyReused) {
+		t.Fatalf("different payload err = %v, want ErrKeyReused", err)
+	}
+	if c, _ := s.Do("other", "fp1", fn); c != 2 {
+		t.Fatalf("other key should run fn, got %v", c)
+	}
+}
+
+func TestDoForgetsFailures(t *testing.T) {
+	s := NewStore(time.Hour)
+	boom := errors.New("boom")
+	if _, err := s.Do("k", "fp", func() (any, error) { return nil, boom }); err != boom {
+		t.Fatalf("err = %v", err)
+	}
+	v, err := s.Do("k", "fp", func() (any, error) { return "ok", nil })
+	if err != nil || v != "ok" {
+		t.Fatalf("retry after failure = %v, %v", v, err)
+	}
+}
+
+func TestDoExpires(t *testing.T) {
+	s := NewStore(time.Minute)
+	now := time.Unix(1_700_000_000, 0)
+	s.now = func() time.Time { return now }
+	s.Do("k", "fp", func() (any, error) { return 1, nil })
+	now = now.Add(2 * time.Minute)
+	v, _ := s.Do("k", "other", func() (any, error) { return 2, nil })
+	if v != 2 {
+		t.Fatalf("expired key should run again, got %v", v)
+	}
+}
+
+func TestDoConcurrentSingleCall(t *testing.T) {
+	s := NewStore(time.Hour)
+	var calls atomic.Int32
+	release := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.Do("k", "fp", func() (any, error) { calls.Add(1); <-release; return "x", nil })
+		}()
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	if n := calls.Load(); n != 1 {
+		t.Fatalf("fn ran %d times, want 1", n)
+	}
+}
+
+func TestKeyScopes(t *testing.T) {
+	if Key("k", "acme") == Key("k", "globex") {
+		t.Fatal("scopes must not collide")
+	}
+	if Fingerprint("ab", "c") == Fingerprint("a", "bc") {
+		t.Fatal("field boundaries must be part of the fingerprint")
+	}
+}
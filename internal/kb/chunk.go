@@ -0,0 +1,292 @@
+package kb
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Default chunk sizing, in Measure units.
+const (
+	DefaultChunkSize    = 300
+	DefaultChunkOverlap = 40
+)
+
+// Chunk is one indexed slice of a document. Heading is the markdown heading path the
+// chunk sits under (outermost first).
+type Chunk struct {
+	Index   int      `json:"index"`
+	Heading []string `json:"heading,omitempty"`
+	Text    string   `json:"text"`
+}
+
+// ChunkID is the id a chunk is indexed under: its parent document id and its index.
+func ChunkID(parent string, index int) string {
+	return parent + "#" + strconv.Itoa(index)
+}
+
+// Chunker splits documents into chunks of at most Size units, repeating the last
+// Overlap units of a chunk at the start of the next one within the same section.
+type Chunker struct {
+	Size    int
+	Overlap int
+}
+
+// NewChunker returns a chunker with sane bounds: size defaults to DefaultChunkSize and
+// overlap is kept below half the size.
+func NewChunker(size, overlap int) Chunker {
+	if size <= 0 {
+		size = DefaultChunkSize
+	}
+	if overlap < 0 {
+		overlap = 0
+	}
+	if overlap > size/2 {
+		overlap = size / 2
+	}
+	return Chunker{Size: size, Overlap: overlap}
+}
+
+// isCJK reports runes that carry a word's worth of meaning on their own.
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul)
+}
+
+// Measure is the CJK-aware length of s: every CJK character counts one, every run of
+// other letters or digits (a word) counts one, spaces and punctuation count nothing.
+func Measure(s string) int {
+	n, inWord := 0, false
+	for _, r := range s {
+		switch {
+		case isCJK(r):
+			n++
+			inWord = false
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if !inWord {
+				n++
+			}
+			inWord = true
+		default:
+			inWord = false
+		}
+	}
+	return n
+}
+
+// units cuts s into pieces of one unit each (a CJK character or a word) with the
+// spaces and punctuation that follow it, so joining them gives s back.
+func units(s string) []string {
+	var out []string
+	start, inWord := 0, false
+	for i, r := range s {
+		word := !isCJK(r) && (unicode.IsLetter(r) || unicode.IsDigit(r))
+		if (isCJK(r) || (word && !inWord)) && i > 0 && hasUnit(s[start:i]) {
+			out = append(out, s[start:i])
+			start = i
+		}
+		inWord = word
+	}
+	if start < len(s) {
+		out = append(out, s[start:])
+	}
+	return out
+}
+
+func hasUnit(s string) bool { return Measure(s) > 0 }
+
+// Split cuts content into chunks: sections at markdown headings, then paragraphs,
+// sentences and finally single units until every chunk fits Size.
+func (c Chunker) Split(content string) []Chunk {
+	if c.Size <= 0 {
+		c = NewChunker(c.Size, c.Overlap)
+	}
+	var out []Chunk
+	for _, sec := range sections(content) {
+		for _, text := range c.pack(c.pieces(sec.body)) {
+			out = append(out, Chunk{Index: len(out), Heading: sec.heading, Text: text})
+		}
+	}
+	return out
+}
+
+type section struct {
+	heading []string
+	body    string
+}
+
+// sections splits markdown at ATX headings ("# ..." to "###### ..."), ignoring fenced
+// code blocks. Text before the first heading has an empty heading path.
+func sections(content string) []section {
+	var out []section
+	var path []string
+	var body strings.Builder
+	flush := func() {
+		if strings.TrimSpace(body.String()) != "" {
+			out = append(out, section{heading: append([]string(nil), path...), body: body.String()})
+		}
+		body.Reset()
+	}
+	fenced := false
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			fenced = !fenced
+		}
+		if level, title := headingLevel(trimmed); !fenced && level > 0 {
+			flush()
+			if len(path) >= level {
+				path = path[:level-1]
+			}
+			path = append(path, title)
+			continue
+		}
+		body.WriteString(line)
+		body.WriteByte('\n')
+	}
+	flush()
+	return out
+}
+
+func headingLevel(line string) (int, string) {
+	level := 0
+	for level < len(line) && line[level] == '#' {
+		level++
+	}
+	if level == 0 || level > 6 || level == len(line) || line[level] != ' ' {
+		return 0, ""
+	}
+	title := strings.TrimSpace(strings.TrimRight(line[level:], "#"))
+	if title == "" {
+		return 0, ""
+	}
+	return level, title
+}
+
+// piece is a span of text that is never split further; sep goes before it when it
+// follows another piece in the same chunk.
+type piece struct {
+	text string
+	sep  string
+}
+
+// pieces breaks a section body into paragraphs, and paragraphs or sentences that exceed
+// the chunk size into smaller spans.
+func (c Chunker) pieces(body string) []piece {
+	var out []piece
+	for _, para := range strings.Split(body, "\n\n") {
+		para = strings.TrimSpace(para)
+		if para == "" {
+			continue
+		}
+		if Measure(para) <= c.Size {
+			out = append(out, piece{text: para, sep: "\n\n"})
+			continue
+		}
+		sep := "\n\n"
+		for _, sent := range sentences(para) {
+			if Measure(sent) <= c.Size {
+				out = append(out, piece{text: sent, sep: sep})
+				sep = ""
+				continue
+			}
+			// a run-on sentence: cut it into groups of units
+			var group strings.Builder
+			for _, u := range units(sent) {
+				if Measure(group.String()+u) > c.Size {
+					out = append(out, piece{text: group.String(), sep: sep})
+					sep = ""
+					group.Reset()
+				}
+				group.WriteString(u)
+			}
+			if group.Len() > 0 {
+				out = append(out, piece{text: group.String(), sep: sep})
+				sep = ""
+			}
+		}
+	}
+	return out
+}
+
+// sentences splits after sentence-ending punctuation (CJK or Latin) and at line breaks,
+// so joining the sentences gives s back.
+func sentences(s string) []string {
+	var out []string
+	start := 0
+	for i, r := range s {
+		_, w := utf8.DecodeRuneInString(s[i:])
+		next := i + w
+		end := false
+		switch r {
+		case '。', '！', '？', '；', '\n':
+			end = true
+		case '.', '!', '?', ';':
+			nr, _ := utf8.DecodeRuneInString(s[next:])
+			end = next == len(s) || unicode.IsSpace(nr)
+		}
+		if end {
+			out = appendSentence(out, s[start:next])
+			start = next
+		}
+	}
+	return appendSentence(out, s[start:])
+}
+
+// appendSentence adds sent, folding whitespace-only spans into the previous sentence.
+func appendSentence(out []string, sent string) []string {
+	if sent == "" {
+		return out
+	}
+	if strings.TrimSpace(sent) == "" && len(out) > 0 {
+		out[len(out)-1] += sent
+		return out
+	}
+	return append(out, sent)
+}
+
+// pack joins pieces greedily into chunks of at most Size units, seeding each new chunk
+// with the tail of the previous one.
+func (c Chunker) pack(ps []piece) []string {
+	var out []string
+	var cur strings.Builder
+	size, seeded := 0, false
+	for _, p := range ps {
+		n := Measure(p.text)
+		if size > 0 && size+n > c.Size {
+			out = append(out, strings.TrimSpace(cur.String()))
+			tail := c.tail(cur.String(), c.Size-n)
+			cur.Reset()
+			cur.WriteString(tail)
+			size, seeded = Measure(tail), tail != ""
+		}
+		if cur.Len() > 0 {
+			sep := p.sep
+			if seeded && sep == "" {
+				// keep the overlap from gluing onto the next word
+				sep = " "
+			}
+			cur.WriteString(sep)
+		}
+		seeded = false
+		cur.WriteString(p.text)
+		size += n
+	}
+	if strings.TrimSpace(cur.String()) != "" {
+		out = append(out, strings.TrimSpace(cur.String()))
+	}
+	return out
+}
+
+// tail returns the last Overlap units of s (never more than room units).
+func (c Chunker) tail(s string, room int) string {
+	want := min(c.Overlap, room)
+	if want <= 0 {
+		return ""
+	}
+	us := units(strings.TrimSpace(s))
+	if len(us) > want {
+		us = us[len(us)-want:]
+	}
+	return strings.Join(us, "")
+}
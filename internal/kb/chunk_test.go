@@ -0,0 +1,75 @@
+package kb
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMeasure(t *testing.T) {
+	cases := map[string]int{
+		"":                 0,
+		"客服系统":             4,
+		"reset the VPN":    3,
+		"重置 VPN 密码, v2.1!": 7, // 重 置 VPN 密 码 v2 1
+	}
+	for s, want := range cases {
+		if got := Measure(s); got != want {
+			t.Fatalf("Measure(%q) = %d, want %d", s, got, want)
+		}
+	}
+	if got := strings.Join(units("ab 客服, cd"), "|"); got != "ab |客|服, |cd" {
+		t.Fatalf("units = %q", got)
+	}
+}
+
+func TestChunkerSplit(t *testing.T) {
+	doc := strings.Join([]string{
+		"Intro paragraph.",
+		"# Install",
+		"## Windows",
+		"Run the installer. Then reboot the machine now.",
+		"```",
+		"# not a heading",
+		"```",
+		"## macOS",
+		strings.Repeat("客服系统很重要。", 6),
+		"# FAQ",
+		"Short answer.",
+	}, "\n")
+	chunks := NewChunker(12, 3).Split(doc)
+	var paths []string
+	for i, c := range chunks {
+		if c.Index != i {
+			t.Fatalf("chunk %d has index %d", i, c.Index)
+		}
+		if Measure(c.Text) > 12 {
+			t.Fatalf("chunk %d too long (%d): %q", i, Measure(c.Text), c.Text)
+		}
+		paths = append(paths, strings.Join(c.Heading, ">"))
+	}
+	if chunks[0].Text != "Intro paragraph." || len(chunks[0].Heading) != 0 {
+		t.Fatalf("first chunk = %+v", chunks[0])
+	}
+	if !strings.Contains(chunks[1].Text, "# not a heading") || paths[1] != "Install>Windows" {
+		t.Fatalf("fenced code split as heading: %+v", chunks[1])
+	}
+	if last := chunks[len(chunks)-1]; paths[len(paths)-1] != "FAQ" || last.Text != "Short answer." {
+		t.Fatalf("last chunk = %+v", last)
+	}
+	// the long CJK section is split, and each later chunk repeats the previous tail
+	var mac []Chunk
+	for i, c := range chunks {
+		if paths[i] == "Install>macOS" {
+			mac = append(mac, c)
+		}
+	}
+	if len(mac) < 3 {
+		t.Fatalf("macOS section chunks = %+v", mac)
+	}
+	for i := 1; i < len(mac); i++ {
+		prev := []rune(mac[i-1].Text)
+		if tail := string(prev[len(prev)-3:]); !strings.Contains(mac[i].Text, strings.TrimRight(tail, "。")) {
+			t.Fatalf("chunk %q does not overlap %q", mac[i].Text, mac[i-1].Text)
+		}
+	}
+}
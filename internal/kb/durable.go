@@ -0,0 +1,594 @@
+package kb
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/gogogo1024/assist-fusion/internal/common"
+)
+
+// WAL sync policies, see DurableOptions.
+const (
+	SyncAlways   = "always"
+	SyncInterval = "interval"
+	SyncNever    = "never"
+)
+
+// Defaults of DurableOptions.
+const (
+	DefaultWALSyncInterval  = time.Second
+	DefaultSnapshotInterval = 5 * time.Minute
+	DefaultSnapshotWALBytes = 64 << 20
+)
+
+// DurableOptions configures a DurableRepo.
+// Sync says when the write-ahead log reaches the disk: SyncAlways (default) fsyncs before
+// a write returns; SyncInterval every SyncEvery, so a power failure loses at most that
+// much; SyncNever leaves it to the OS, which survives a process crash but not an OS crash.
+// A snapshot replaces the log once SnapshotEvery has passed with records logged, or
+// sooner when the log grows past SnapshotBytes.
+type DurableOptions struct {
+	NGram         int
+	Sync          string
+	SyncEvery     time.Duration
+	SnapshotEvery time.Duration
+	SnapshotBytes int64
+}
+
+func (o DurableOptions) withDefaults() DurableOptions {
+	if o.NGram < 2 {
+		o.NGram = 2
+	}
+	if o.Sync == "" {
+		o.Sync = SyncAlways
+	}
+	if o.SyncEvery <= 0 {
+		o.SyncEvery = DefaultWALSyncInterval
+	}
+	if o.SnapshotEvery <= 0 {
+		o.SnapshotEvery = DefaultSnapshotInterval
+	}
+	if o.SnapshotBytes <= 0 {
+		o.SnapshotBytes = DefaultSnapshotWALBytes
+	}
+	return o
+}
+
+// ErrRepoClosed is returned by writes to a closed DurableRepo.
+var ErrRepoClosed = errors.New("kb repo closed")
+
+// File names in a DurableRepo's directory.
+const (
+	walFile      = "wal.log"
+	snapshotFile = "snapshot.gob"
+)
+
+// WAL record operations.
+const (
+	walAdd     = "add"
+	walUpdate  = "update"
+	walDelete  = "delete"
+	walVectors = "vectors"
+)
+
+// walRecord is one logged write. On disk it is framed by its length and CRC-32C (both
+// little-endian uint32) followed by its JSON.
+type walRecord struct {
+	Seq     uint64      `json:"seq"`
+	Op      string      `json:"op"`
+	Doc     *Doc        `json:"doc,omitempty"`
+	ID      string      `json:"id,omitempty"`
+	Vectors [][]float64 `json:"vectors,omitempty"`
+}
+
+const walHeaderSize = 8
+
+var walCRC = crc32.MakeTable(crc32.Castagnoli)
+
+func encodeWALRecord(rec walRecord) ([]byte, error) {
+	payload, err := json.Marshal(rec)
+	if err != nil {
+		return nil, err
+	}
+	buf := make([]byte, walHeaderSize+len(payload))
+	binary.LittleEndian.PutUint32(buf, uint32(len(payload)))
+	binary.LittleEndian.PutUint32(buf[4:], crc32.Checksum(payload, walCRC))
+	copy(buf[walHeaderSize:], payload)
+	return buf, nil
+}
+
+// decodeWALRecord reads the record at the start of b and its framed size; ok is false for
+// a short, corrupt or unreadable frame.
+func decodeWALRecord(b []byte) (rec walRecord, size int, ok bool) {
+	if len(b) < walHeaderSize {
+		return rec, 0, false
+	}
+	n := int(binary.LittleEndian.Uint32(b))
+	if len(b)-walHeaderSize < n {
+		return rec, 0, false
+	}
+	payload := b[walHeaderSize : walHeaderSize+n]
+	if crc32.Checksum(payload, walCRC) != binary.LittleEndian.Uint32(b[4:]) || json.Unmarshal(payload, &rec) != nil {
+		return rec, 0, false
+	}
+	return rec, walHeaderSize + n, true
+}
+
+// memorySnapshot is the gob-encoded content of a snapshot file: the documents, their chunk
+// vectors and, so a restore skips tokenizing, the n-gram indexes. It includes every record
+// up to Seq.
+type memorySnapshot struct {
+	Version          int
+	Seq              uint64
+	NGram            int
+	Docs             map[string]*Doc
+	IndexTitle       map[string]map[string]int
+	IndexBody        map[string]map[string]int
+	GramsTitleByDoc  map[string]map[string]int
+	GramsBodyByChunk map[string]map[string]int
+	Vectors          map[string][][]float64
+}
+
+const memorySnapshotVersion = 1
+
+// DurableRepo is a memory repo that survives restarts: every write is appended to a
+// write-ahead log in its directory before it is applied, and snapshots of the documents
+// and indexes let the log be emptied. OpenDurableRepo recovers the last snapshot and
+// replays the records logged after it. It is a VectorRepo, so chunk vectors are recovered
+// with the documents.
+type DurableRepo struct {
+	*memoryRepo
+	dir  string
+	opts DurableOptions
+
+	vectors map[string][][]float64 // doc id -> chunk vectors; guarded by memoryRepo.mu
+
+	seq uint64 // last logged record; guarded by memoryRepo.mu, writers log under its write lock
+
+	walMu    sync.Mutex // taken after memoryRepo.mu
+	wal      *os.File
+	walSize  int64 // bytes logged since the last snapshot
+	unsynced bool
+	closed   bool
+	err      error // sticky: the log can no longer be trusted
+
+	snapMu   sync.Mutex // serializes Snapshot
+	snapTime time.Time
+}
+
+// OpenDurableRepo opens (creating if needed) the repo stored in dir and recovers its
+// documents. A torn record at the end of the log, left by a crash mid-write, is dropped.
+func OpenDurableRepo(dir string, opts DurableOptions) (*DurableRepo, error) {
+	opts = opts.withDefaults()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	r := &DurableRepo{memoryRepo: NewMemoryRepoWithN(opts.NGram).(*memoryRepo), dir: dir, opts: opts, vectors: map[string][][]float64{}, snapTime: time.Now()}
+	if err := r.loadSnapshot(); err != nil {
+		return nil, fmt.Errorf("kb snapshot %s: %w", filepath.Join(dir, snapshotFile), err)
+	}
+	if err := r.replayWAL(); err != nil {
+		return nil, fmt.Errorf("kb wal %s: %w", filepath.Join(dir, walFile), err)
+	}
+	return r, nil
+}
+
+func (r *DurableRepo) loadSnapshot() error {
+	f, err := os.Open(filepath.Join(r.dir, snapshotFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	var snap memorySnapshot
+	if err := gob.NewDecoder(bufio.NewReader(f)).Decode(&snap); err != nil {
+		return err
+	}
+	if snap.Version != memorySnapshotVersion {
+		return fmt.Errorf("version %d not supported", snap.Version)
+	}
+	m := r.memoryRepo
+	if snap.NGram != m.ngramN {
+		// indexes of another n-gram size are rebuilt from the documents
+		for _, d := range snap.Docs {
+			m.addDocToIndexNoLock(d)
+		}
+	} else {
+		// gob drops empty maps
+		for dst, src := range map[*map[string]map[string]int]map[string]map[string]int{
+			&m.indexTitle: snap.IndexTitle, &m.indexBody: snap.IndexBody,
+			&m.gramsTitleByDoc: snap.GramsTitleByDoc, &m.gramsBodyByChunk: snap.GramsBodyByChunk,
+		} {
+			if src != nil {
+				*dst = src
+			}
+		}
+		for id, d := range snap.Docs {
+			m.docs[id], m.chunks[id] = d, d.IndexedChunks()
+		}
+	}
+	if snap.Vectors != nil {
+		r.vectors = snap.Vectors
+	}
+	r.seq = snap.Seq
+	return nil
+}
+
+// replayWAL applies the records newer than the snapshot and opens the log for appending,
+// cut after the last intact record.
+func (r *DurableRepo) replayWAL() error {
+	path := filepath.Join(r.dir, walFile)
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	off, replayed := 0, 0
+	for off < len(data) {
+		rec, n, ok := decodeWALRecord(data[off:])
+		if !ok {
+			break
+		}
+		off += n
+		// records up to the snapshot's are in it already: the log is emptied after the
+		// snapshot is written, so a crash in between leaves them behind
+		if rec.Seq <= r.seq {
+			continue
+		}
+		r.apply(rec)
+		r.seq = rec.Seq
+		replayed++
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	if off < len(data) {
+		log.Printf("[kb] %s: dropping %d bytes after the last intact record", path, len(data)-off)
+		if err := f.Truncate(int64(off)); err != nil {
+			f.Close()
+			return err
+		}
+		if err := f.Sync(); err != nil {
+			f.Close()
+			return err
+		}
+	}
+	if replayed > 0 {
+		log.Printf("[kb] %s: replayed %d records", path, replayed)
+	}
+	r.wal, r.walSize = f, int64(off)
+	return nil
+}
+
+func (r *DurableRepo) apply(rec walRecord) {
+	switch rec.Op {
+	case walAdd, walUpdate:
+		if rec.Doc != nil {
+			r.upsertLocked(rec.Doc)
+			delete(r.vectors, rec.Doc.ID)
+		}
+	case walDelete:
+		r.deleteLocked(rec.ID)
+		delete(r.vectors, rec.ID)
+	case walVectors:
+		r.putVectorsLocked(rec.ID, rec.Vectors)
+	}
+}
+
+// Add implements Repo.Add; the document is logged before it becomes visible. Vectors
+// stored for an earlier version of the document are dropped, as by Update.
+func (r *DurableRepo) Add(ctx context.Context, d *Doc) error { return r.put(walAdd, d) }
+
+// Update implements Repo.Update; the document is logged before it becomes visible. Its
+// stored vectors are dropped: they belong to the old chunks.
+func (r *DurableRepo) Update(ctx context.Context, d *Doc) error { return r.put(walUpdate, d) }
+
+func (r *DurableRepo) put(op string, d *Doc) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.log(walRecord{Op: op, Doc: d}); err != nil {
+		return err
+	}
+	delete(r.vectors, d.ID)
+	return r.upsertLocked(d)
+}
+
+// Delete implements Repo.Delete; deleting a missing document logs nothing.
+func (r *DurableRepo) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.docs[id]; !ok {
+		return nil
+	}
+	if err := r.log(walRecord{Op: walDelete, ID: id}); err != nil {
+		return err
+	}
+	r.deleteLocked(id)
+	delete(r.vectors, id)
+	return nil
+}
+
+// PutVectors implements VectorRepo; the vectors are logged like a document write. Vectors
+// of a missing document are ignored.
+func (r *DurableRepo) PutVectors(ctx context.Context, id string, vecs [][]float64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.docs[id]; !ok {
+		return nil
+	}
+	if err := r.log(walRecord{Op: walVectors, ID: id, Vectors: vecs}); err != nil {
+		return err
+	}
+	r.putVectorsLocked(id, vecs)
+	return nil
+}
+
+func (r *DurableRepo) putVectorsLocked(id string, vecs [][]float64) {
+	if _, ok := r.docs[id]; !ok {
+		return
+	}
+	if len(vecs) == 0 {
+		delete(r.vectors, id)
+		return
+	}
+	r.vectors[id] = vecs
+}
+
+// ScanVectors implements VectorRepo. fn runs without the repo lock held, on the vectors as
+// they were when the scan started.
+func (r *DurableRepo) ScanVectors(ctx context.Context, fn func(id string, vecs [][]float64) error) error {
+	r.mu.RLock()
+	ids := make([]string, 0, len(r.vectors))
+	all := make([][][]float64, 0, len(r.vectors))
+	for id, vecs := range r.vectors {
+		ids, all = append(ids, id), append(all, vecs)
+	}
+	r.mu.RUnlock()
+	for i, id := range ids {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := fn(id, all[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// log appends rec with the next sequence number, fsynced under SyncAlways. Callers hold
+// the write lock. A failed append, or one whose fsync fails, is cut off again so a restart
+// does not replay a write the caller was told failed; when an fsync fails (or the cut
+// does) the log is in an unknown state and every later write fails.
+func (r *DurableRepo) log(rec walRecord) error {
+	rec.Seq = r.seq + 1
+	buf, err := encodeWALRecord(rec)
+	if err != nil {
+		return err
+	}
+	r.walMu.Lock()
+	defer r.walMu.Unlock()
+	if r.err != nil {
+		return r.err
+	}
+	if _, err := r.wal.Write(buf); err != nil {
+		if terr := r.wal.Truncate(r.walSize); terr != nil {
+			r.err = fmt.Errorf("kb wal: %w", err)
+		}
+		return err
+	}
+	r.unsynced = true
+	if r.opts.Sync == SyncAlways {
+		if err := r.syncLocked(); err != nil {
+			if terr := r.wal.Truncate(r.walSize); terr != nil {
+				log.Printf("[kb] %s: cut failed record: %v", r.dir, terr)
+			}
+			return err
+		}
+	}
+	r.walSize += int64(len(buf))
+	r.seq = rec.Seq
+	return nil
+}
+
+// Sync fsyncs the records logged since the last sync. Under SyncInterval it has to run
+// every SyncEvery, see DurableStore.Run.
+func (r *DurableRepo) Sync() error {
+	r.walMu.Lock()
+	defer r.walMu.Unlock()
+	if r.err != nil || !r.unsynced {
+		return r.err
+	}
+	return r.syncLocked()
+}
+
+// fsyncFile is replaced in tests to inject fsync failures.
+var fsyncFile = (*os.File).Sync
+
+func (r *DurableRepo) syncLocked() error {
+	if err := fsyncFile(r.wal); err != nil {
+		r.err = fmt.Errorf("kb wal sync: %w", err)
+		return r.err
+	}
+	r.unsynced = false
+	return nil
+}
+
+// Snapshot writes the documents and indexes to the snapshot file and empties the log.
+// Searches go on while it is encoded; writes wait.
+func (r *DurableRepo) Snapshot() error {
+	r.snapMu.Lock()
+	defer r.snapMu.Unlock()
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	snap := memorySnapshot{
+		Version: memorySnapshotVersion, Seq: r.seq, NGram: r.ngramN, Docs: r.docs,
+		IndexTitle: r.indexTitle, IndexBody: r.indexBody,
+		GramsTitleByDoc: r.gramsTitleByDoc, GramsBodyByChunk: r.gramsBodyByChunk,
+		Vectors: r.vectors,
+	}
+	err := writeFileAtomic(filepath.Join(r.dir, snapshotFile), func(w io.Writer) error {
+		bw := bufio.NewWriter(w)
+		if err := gob.NewEncoder(bw).Encode(&snap); err != nil {
+			return err
+		}
+		return bw.Flush()
+	})
+	if err != nil {
+		return err
+	}
+	r.snapTime = time.Now()
+	// with writers held off, the log holds nothing the snapshot lacks
+	r.walMu.Lock()
+	defer r.walMu.Unlock()
+	if r.closed {
+		return nil
+	}
+	if err := r.wal.Truncate(0); err != nil {
+		return err
+	}
+	r.walSize, r.unsynced = 0, false
+	return nil
+}
+
+// snapshotDue reports whether records were logged since the last snapshot and either the
+// snapshot interval passed or the log outgrew SnapshotBytes.
+func (r *DurableRepo) snapshotDue(now time.Time) bool {
+	r.walMu.Lock()
+	size := r.walSize
+	r.walMu.Unlock()
+	r.snapMu.Lock()
+	last := r.snapTime
+	r.snapMu.Unlock()
+	return size > 0 && (size >= r.opts.SnapshotBytes || now.Sub(last) >= r.opts.SnapshotEvery)
+}
+
+// Close fsyncs and closes the log; later writes fail with ErrRepoClosed.
+func (r *DurableRepo) Close() error {
+	r.walMu.Lock()
+	defer r.walMu.Unlock()
+	if r.closed {
+		return nil
+	}
+	r.closed, r.err = true, ErrRepoClosed
+	err := r.wal.Sync()
+	if cerr := r.wal.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// DurableStore keeps one DurableRepo per tenant in dir/tenants/<tenant> and runs their
+// log syncs and snapshots.
+type DurableStore struct {
+	dir  string
+	opts DurableOptions
+
+	mu    sync.Mutex
+	repos map[string]*DurableRepo
+}
+
+func NewDurableStore(dir string, opts DurableOptions) *DurableStore {
+	return &DurableStore{dir: dir, opts: opts.withDefaults(), repos: map[string]*DurableRepo{}}
+}
+
+// Open returns the repo of tenant, recovering it on first use; it fits PerTenant.
+func (s *DurableStore) Open(tenant string) (Repo, error) {
+	if !common.ValidTenantID(tenant) {
+		return nil, fmt.Errorf("kb: invalid tenant id %q", tenant)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if r, ok := s.repos[tenant]; ok {
+		return r, nil
+	}
+	r, err := OpenDurableRepo(filepath.Join(s.dir, "tenants", tenant), s.opts)
+	if err != nil {
+		return nil, err
+	}
+	s.repos[tenant] = r
+	return r, nil
+}
+
+// Recover opens every tenant stored in the directory, so damaged files show at startup
+// rather than on a tenant's first request.
+func (s *DurableStore) Recover() error {
+	entries, err := os.ReadDir(filepath.Join(s.dir, "tenants"))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		if _, err := s.Open(e.Name()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *DurableStore) openRepos() []*DurableRepo {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]*DurableRepo, 0, len(s.repos))
+	for _, r := range s.repos {
+		out = append(out, r)
+	}
+	return out
+}
+
+// Run checks every SyncEvery until ctx is done: logs are fsynced under SyncInterval and
+// due snapshots are written.
+func (s *DurableStore) Run(ctx context.Context) {
+	t := time.NewTicker(s.opts.SyncEvery)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-t.C:
+			for _, r := range s.openRepos() {
+				if s.opts.Sync == SyncInterval {
+					if err := r.Sync(); err != nil {
+						log.Printf("[kb] %s: %v", r.dir, err)
+					}
+				}
+				if r.snapshotDue(now) {
+					if err := r.Snapshot(); err != nil {
+						log.Printf("[kb] %s: snapshot: %v", r.dir, err)
+					}
+				}
+			}
+		}
+	}
+}
+
+// Close snapshots every repo with logged records, so the next start replays nothing, and
+// closes them.
+func (s *DurableStore) Close() error {
+	var errs []error
+	for _, r := range s.openRepos() {
+		if r.snapshotDue(time.Now().Add(r.opts.SnapshotEvery)) {
+			errs = append(errs, r.Snapshot())
+		}
+		errs = append(errs, r.Close())
+	}
+	return errors.Join(errs...)
+}
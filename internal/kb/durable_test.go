@@ -0,0 +1,245 @@
+package kb
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func mustOpenDurable(t *testing.T, dir string, opts DurableOptions) *DurableRepo {
+	t.Helper()
+	r, err := OpenDurableRepo(dir, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+// searchIDs returns the ids q finds in r, sorted.
+func searchIDs(t *testing.T, r Repo, q string) []string {
+	t.Helper()
+	items, _, err := r.Search(context.TODO(), q, SearchOptions{Limit: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids := []string{}
+	for _, it := range items {
+		ids = append(ids, it.ID)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func TestDurableRepoRecovery(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.TODO()
+	r := mustOpenDurable(t, dir, DurableOptions{Sync: SyncNever})
+	for _, d := range []*Doc{
+		{ID: "a", Title: "网络故障", Content: "重启路由器"},
+		{ID: "b", Title: "打印机", Content: "更换墨盒"},
+		{ID: "c", Title: "VPN", Content: "重置网络配置"},
+	} {
+		if err := r.Add(ctx, d); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := r.Update(ctx, &Doc{ID: "b", Title: "打印机", Content: "网络打印机离线时重启"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Delete(ctx, "c"); err != nil {
+		t.Fatal(err)
+	}
+	// no Close: the process "crashes" with the records only in the log
+	r2 := mustOpenDurable(t, dir, DurableOptions{})
+	if r2.seq != 5 || len(r2.docs) != 2 {
+		t.Fatalf("recovered seq %d, %d docs", r2.seq, len(r2.docs))
+	}
+	if got := searchIDs(t, r2, "网络"); !reflect.DeepEqual(got, []string{"a", "b"}) {
+		t.Fatalf("search after replay = %v", got)
+	}
+	if !reflect.DeepEqual(r2.indexBody, r.indexBody) || !reflect.DeepEqual(r2.indexTitle, r.indexTitle) {
+		t.Fatal("replayed indexes differ")
+	}
+
+	// a snapshot empties the log; later writes are replayed on top of it
+	if err := r2.Snapshot(); err != nil {
+		t.Fatal(err)
+	}
+	if fi, _ := os.Stat(filepath.Join(dir, walFile)); fi.Size() != 0 {
+		t.Fatalf("log not emptied: %d bytes", fi.Size())
+	}
+	if err := r2.Add(ctx, &Doc{ID: "d", Title: "网络设置"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := r2.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := r2.Add(ctx, &Doc{ID: "e", Title: "x"}); err != ErrRepoClosed {
+		t.Fatalf("write after close = %v", err)
+	}
+	r3 := mustOpenDurable(t, dir, DurableOptions{})
+	if r3.seq != 6 || !reflect.DeepEqual(searchIDs(t, r3, "网络"), []string{"a", "b", "d"}) {
+		t.Fatalf("seq %d, search = %v", r3.seq, searchIDs(t, r3, "网络"))
+	}
+	// the snapshot's indexes are used as is; another n-gram size rebuilds them
+	r4 := mustOpenDurable(t, dir, DurableOptions{NGram: 3})
+	if len(r4.docs) != 3 || !reflect.DeepEqual(searchIDs(t, r4, "网络设置"), []string{"d"}) {
+		t.Fatalf("trigram repo: %d docs, search = %v", len(r4.docs), searchIDs(t, r4, "网络设置"))
+	}
+}
+
+func TestDurableRepoTornLog(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.TODO()
+	r := mustOpenDurable(t, dir, DurableOptions{})
+	for _, id := range []string{"a", "b"} {
+		if err := r.Add(ctx, &Doc{ID: id, Title: "网络 " + id}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	r.Close()
+	path := filepath.Join(dir, walFile)
+	intact, _ := os.Stat(path)
+	// a crash mid-append leaves half a record
+	rec, _ := encodeWALRecord(walRecord{Seq: 3, Op: walAdd, Doc: &Doc{ID: "c", Title: "网络 c"}})
+	f, _ := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0)
+	f.Write(rec[:len(rec)-3])
+	f.Close()
+
+	r2 := mustOpenDurable(t, dir, DurableOptions{})
+	if fi, _ := os.Stat(path); fi.Size() != intact.Size() {
+		t.Fatalf("log is %d bytes, want %d", fi.Size(), intact.Size())
+	}
+	if err := r2.Add(ctx, &Doc{ID: "d", Title: "网络 d"}); err != nil {
+		t.Fatal(err)
+	}
+	r2.Close()
+	r3 := mustOpenDurable(t, dir, DurableOptions{})
+	if got := searchIDs(t, r3, "网络"); !reflect.DeepEqual(got, []string{"a", "b", "d"}) {
+		t.Fatalf("search = %v", got)
+	}
+	// a corrupt record ends the log the same way
+	data, _ := os.ReadFile(path)
+	data[walHeaderSize+2] ^= 0xff
+	os.WriteFile(path, data, 0o644)
+	r3.Close()
+	if r4 := mustOpenDurable(t, dir, DurableOptions{}); len(r4.docs) != 0 {
+		t.Fatalf("%d docs recovered past a corrupt record", len(r4.docs))
+	}
+}
+
+func TestDurableRepoFailedSync(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.TODO()
+	r := mustOpenDurable(t, dir, DurableOptions{})
+	if err := r.Add(ctx, &Doc{ID: "a", Title: "网络 a"}); err != nil {
+		t.Fatal(err)
+	}
+	failed := errors.New("disk gone")
+	fsyncFile = func(*os.File) error { return failed }
+	t.Cleanup(func() { fsyncFile = (*os.File).Sync })
+	if err := r.Add(ctx, &Doc{ID: "b", Title: "网络 b"}); !errors.Is(err, failed) {
+		t.Fatalf("add with failing fsync = %v", err)
+	}
+	fsyncFile = (*os.File).Sync
+	// later writes fail, and the failed one is not replayed after a restart
+	if err := r.Add(ctx, &Doc{ID: "c", Title: "网络 c"}); err == nil {
+		t.Fatal("write after a failed fsync succeeded")
+	}
+	if got := searchIDs(t, r, "网络"); !reflect.DeepEqual(got, []string{"a"}) {
+		t.Fatalf("search = %v", got)
+	}
+	if got := searchIDs(t, mustOpenDurable(t, dir, DurableOptions{}), "网络"); !reflect.DeepEqual(got, []string{"a"}) {
+		t.Fatalf("search after restart = %v", got)
+	}
+}
+
+func TestDurableStore(t *testing.T) {
+	dir := t.TempDir()
+	s := NewDurableStore(dir, DurableOptions{Sync: SyncInterval})
+	repo := PerTenant(s.Open)
+	if err := repo.Add(context.TODO(), &Doc{ID: "a", Title: "网络故障"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Open("../x"); err == nil {
+		t.Fatal("invalid tenant opened")
+	}
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+	// Close snapshotted the tenant
+	if fi, err := os.Stat(filepath.Join(dir, "tenants", "default", walFile)); err != nil || fi.Size() != 0 {
+		t.Fatalf("log after close: %v, %v", fi, err)
+	}
+	s2 := NewDurableStore(dir, DurableOptions{})
+	if err := s2.Recover(); err != nil {
+		t.Fatal(err)
+	}
+	if got := searchIDs(t, PerTenant(s2.Open), "网络"); !reflect.DeepEqual(got, []string{"a"}) {
+		t.Fatalf("search = %v", got)
+	}
+}
+
+// scanVectors returns the vectors r stores.
+func scanVectors(t *testing.T, r VectorRepo) map[string][][]float64 {
+	t.Helper()
+	out := map[string][][]float64{}
+	if err := r.ScanVectors(context.TODO(), func(id string, vecs [][]float64) error {
+		out[id] = vecs
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
+
+func TestDurableRepoVectors(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.TODO()
+	r := mustOpenDurable(t, dir, DurableOptions{})
+	for _, id := range []string{"a", "b", "c"} {
+		if err := r.Add(ctx, &Doc{ID: id, Title: "网络 " + id}); err != nil {
+			t.Fatal(err)
+		}
+		if err := r.PutVectors(ctx, id, [][]float64{{0.1, 0.2}, nil, {1.0 / 3, -2}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := r.Snapshot(); err != nil {
+		t.Fatal(err)
+	}
+	// after the snapshot: new vectors, an update (which drops them) and a delete
+	if err := r.PutVectors(ctx, "a", [][]float64{{0.5, 0.5}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Update(ctx, &Doc{ID: "b", Title: "网络 b2"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Delete(ctx, "c"); err != nil {
+		t.Fatal(err)
+	}
+	// vectors of a missing document are not kept
+	if err := r.PutVectors(ctx, "zz", [][]float64{{1, 1}}); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string][][]float64{"a": {{0.5, 0.5}}}
+	if got := scanVectors(t, r); !reflect.DeepEqual(got, want) {
+		t.Fatalf("vectors = %v", got)
+	}
+	// no Close: the later records are only in the log
+	r2 := mustOpenDurable(t, dir, DurableOptions{})
+	if got := scanVectors(t, r2); !reflect.DeepEqual(got, want) {
+		t.Fatalf("recovered vectors = %v, want %v", got, want)
+	}
+	if err := r2.Snapshot(); err != nil {
+		t.Fatal(err)
+	}
+	r2.Close()
+	if got := scanVectors(t, mustOpenDurable(t, dir, DurableOptions{})); !reflect.DeepEqual(got, want) {
+		t.Fatalf("vectors from snapshot = %v", got)
+	}
+}
@@ -2,32 +2,46 @@ package esrepo
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	elasticsearch "github.com/elastic/go-elasticsearch/v8"
 	"github.com/elastic/go-elasticsearch/v8/esapi"
+	"github.com/gogogo1024/assist-fusion/internal/common"
 	"github.com/gogogo1024/assist-fusion/internal/kb"
 )
 
 // Config for Elasticsearch repo.
 // Addresses: list of http(s) endpoints, e.g. ["http://localhost:9200"].
-// Index: index name, default "kb_docs".
+// Index: index name, default "kb_docs"; other tenants get "<index>_<tenant>" (see ForTenant).
 // Basic auth optional.
+// VectorDims: dims of the chunk_vectors dense_vector field, mapped when the index is created;
+// 0 maps it on the first vectors written, with their dims.
+// VectorSimilarity: kNN similarity of that field, default "cosine" (see the Similarity constants).
 type Config struct {
-	Addresses []string
-	Index     string
-	Username  string
-	Password  string
+	Addresses        []string
+	Index            string
+	Username         string
+	Password         string
+	VectorDims       int
+	VectorSimilarity string
 }
 
 type Repo struct {
 	cli   *elasticsearch.Client
 	index string
+	// fieldsMapped is set once the tag and timestamp fields are known to be in the index mapping.
+	fieldsMapped atomic.Bool
+	dims         int
+	similarity   string
+	// vectors is the mapping of chunk_vectors.vector once it is known to exist.
+	vectors atomic.Pointer[vectorField]
 }
 
 func New(cfg Config) (*Repo, error) {
@@ -37,6 +51,15 @@ func New(cfg Config) (*Repo, error) {
 	if cfg.Index == "" {
 		cfg.Index = "kb_docs"
 	}
+	if cfg.VectorSimilarity == "" {
+		cfg.VectorSimilarity = SimilarityCosine
+	}
+	if !validSimilarity(cfg.VectorSimilarity) {
+		return nil, fmt.Errorf("unknown vector similarity %q", cfg.VectorSimilarity)
+	}
+	if cfg.VectorDims < 0 {
+		return nil, fmt.Errorf("invalid vector dims %d", cfg.VectorDims)
+	}
 	esCfg := elasticsearch.Config{Addresses: cfg.Addresses}
 	if cfg.Username != "" || cfg.Password != "" {
 		esCfg.Username = cfg.Username
@@ -46,10 +69,20 @@ func New(cfg Config) (*Repo, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &Repo{cli: cli, index: cfg.Index}, nil
+	return &Repo{cli: cli, index: cfg.Index, dims: cfg.VectorDims, similarity: cfg.VectorSimilarity}, nil
+}
+
+// ForTenant returns a repo bound to the tenant's own index ("<index>_<tenant>"), sharing the client.
+// The default tenant keeps the base index so single-tenant deployments see no change.
+func (r *Repo) ForTenant(tenant string) *Repo {
+	if tenant == "" || tenant == common.DefaultTenant {
+		return r
+	}
+	return &Repo{cli: r.cli, index: r.index + "_" + tenant, dims: r.dims, similarity: r.similarity}
 }
 
-// ensureIndex creates the index with a minimal mapping if it doesn't exist.
+// ensureIndex creates the index with a minimal mapping if it doesn't exist. With
+// Config.VectorDims set the mapping includes chunk_vectors for either analyzer setup.
 func (r *Repo) ensureIndex(ctx context.Context) error {
 	res, err := r.cli.Indices.Exists([]string{r.index})
 	if err != nil {
@@ -57,10 +90,14 @@ func (r *Repo) ensureIndex(ctx context.Context) error {
 	}
 	defer res.Body.Close()
 	if res.StatusCode == http.StatusOK {
-		return nil
+		return r.ensureFieldMapping(ctx)
+	}
+	vectors := ""
+	if r.dims > 0 {
+		vectors = ",\n\t\t\t\t" + vectorProperty(r.dims, r.similarity)
 	}
 	// 1st attempt: IK analyzers (requires ik plugin). Index analyzer: ik_max_word; search analyzer: ik_smart.
-	ikBody := `{
+	ikBody := fmt.Sprintf(`{
 			"settings": {
 				"analysis": {
 					"analyzer": {
@@ -71,20 +108,30 @@ func (r *Repo) ensureIndex(ctx context.Context) error {
 			},
 			"mappings": {"properties": {
 				"title":   {"type": "text", "analyzer": "cn_index",  "search_analyzer": "cn_search"},
-				"content": {"type": "text", "analyzer": "cn_index",  "search_analyzer": "cn_search"}
+				"content": {"type": "text", "analyzer": "cn_index",  "search_analyzer": "cn_search"},
+				"chunks":  {"type": "nested", "properties": {
+					"index":   {"type": "integer"},
+					"heading": {"type": "text", "analyzer": "cn_index", "search_analyzer": "cn_search"},
+					"text":    {"type": "text", "analyzer": "cn_index", "search_analyzer": "cn_search"}
+				}},
+				"tags":       {"type": "object", "enabled": false},
+				"tag_terms":  {"type": "keyword"},
+				"created_at": {"type": "long"},
+				"updated_at": {"type": "long"}%s
 			}}
-		}`
+		}`, vectors)
 	cr := esapi.IndicesCreateRequest{Index: r.index, Body: strings.NewReader(ikBody)}
 	cres, err := cr.Do(ctx, r.cli)
 	if err == nil && cres != nil && cres.StatusCode < 300 {
 		defer cres.Body.Close()
+		r.mappedVectors()
 		return nil
 	}
 	// If IK not installed or creation failed, fallback to ngram-based Chinese-friendly mapping (no plugin needed).
 	if cres != nil {
 		defer cres.Body.Close()
 	}
-	ngramBody := `{
+	ngramBody := fmt.Sprintf(`{
 			"settings": {
 				"refresh_interval": "5s",
 				"analysis": {
@@ -109,9 +156,18 @@ func (r *Repo) ensureIndex(ctx context.Context) error {
 						"autocomplete": {"type": "text", "analyzer": "cn_autocomplete", "search_analyzer": "cn_search"}
 					}
 				},
-				"content": {"type": "text", "analyzer": "cn_index_content",  "search_analyzer": "cn_search"}
+				"content": {"type": "text", "analyzer": "cn_index_content",  "search_analyzer": "cn_search"},
+				"chunks":  {"type": "nested", "properties": {
+					"index":   {"type": "integer"},
+					"heading": {"type": "text", "analyzer": "cn_index_content", "search_analyzer": "cn_search"},
+					"text":    {"type": "text", "analyzer": "cn_index_content", "search_analyzer": "cn_search"}
+				}},
+				"tags":       {"type": "object", "enabled": false},
+				"tag_terms":  {"type": "keyword"},
+				"created_at": {"type": "long"},
+				"updated_at": {"type": "long"}%s
 			}}
-		}`
+		}`, vectors)
 	cr2 := esapi.IndicesCreateRequest{Index: r.index, Body: strings.NewReader(ngramBody)}
 	cres2, err2 := cr2.Do(ctx, r.cli)
 	if err2 != nil {
@@ -121,6 +177,55 @@ func (r *Repo) ensureIndex(ctx context.Context) error {
 	if cres2.StatusCode >= 300 {
 		return fmt.Errorf("create index failed (fallback): %s", cres2.String())
 	}
+	r.mappedVectors()
+	return nil
+}
+
+// mappedVectors records the chunk_vectors mapping of an index this repo just created.
+func (r *Repo) mappedVectors() {
+	if r.dims > 0 {
+		indexed := true
+		r.vectors.Store(&vectorField{Dims: r.dims, Indexed: &indexed, Similarity: r.similarity})
+	}
+}
+
+// fieldMapping adds the tag, timestamp and chunk fields to indexes created before documents
+// carried them. Chunk text uses the analyzers the index was created with (see Info).
+func fieldMapping(mode string) string {
+	text := `{"type": "text"}`
+	switch mode {
+	case "ik":
+		text = `{"type": "text", "analyzer": "cn_index", "search_analyzer": "cn_search"}`
+	case "ngram":
+		text = `{"type": "text", "analyzer": "cn_index_content", "search_analyzer": "cn_search"}`
+	}
+	return fmt.Sprintf(`{"properties": {
+	"tags": {"type": "object", "enabled": false}, "tag_terms": {"type": "keyword"},
+	"created_at": {"type": "long"}, "updated_at": {"type": "long"},
+	"chunks": {"type": "nested", "properties": {"index": {"type": "integer"}, "heading": %s, "text": %s}}
+}}`, text, text)
+}
+
+// ensureFieldMapping puts fieldMapping once per repo; adding new fields to an existing
+// mapping is a no-op when they are already there.
+func (r *Repo) ensureFieldMapping(ctx context.Context) error {
+	if r.fieldsMapped.Load() {
+		return nil
+	}
+	mode := ""
+	if info, err := r.Info(ctx); err == nil {
+		mode, _ = info["mode"].(string)
+	}
+	pr := esapi.IndicesPutMappingRequest{Index: []string{r.index}, Body: strings.NewReader(fieldMapping(mode))}
+	res, err := pr.Do(ctx, r.cli)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode >= 300 {
+		return fmt.Errorf("put field mapping failed: %s", res.String())
+	}
+	r.fieldsMapped.Store(true)
 	return nil
 }
 
@@ -129,7 +234,7 @@ func (r *Repo) Add(ctx context.Context, d *kb.Doc) error {
 }
 
 func (r *Repo) Get(ctx context.Context, id string) (*kb.Doc, bool) {
-	gr := esapi.GetRequest{Index: r.index, DocumentID: id}
+	gr := esapi.GetRequest{Index: r.index, DocumentID: id, SourceExcludes: []string{"chunks", "tag_terms", "chunk_vectors"}}
 	res, err := gr.Do(ctx, r.cli)
 	if err != nil {
 		return nil, false
@@ -143,79 +248,230 @@ func (r *Repo) Get(ctx context.Context, id string) (*kb.Doc, bool) {
 	}
 	// minimal parse: use _source passthrough via gjson-like or stdlib; to keep deps minimal, use a tiny manual decode
 	type hit struct {
+		ID     string `json:"_id"`
 		Source kb.Doc `json:"_source"`
 	}
 	var h hit
 	if err := decodeJSON(res.Body, &h); err != nil {
 		return nil, false
 	}
+	h.Source.ID = h.ID
 	return &h.Source, true
 }
 
-func (r *Repo) Search(ctx context.Context, q string, limit int) ([]*kb.Item, int, error) {
+func (r *Repo) Search(ctx context.Context, q string, opts kb.SearchOptions) ([]*kb.Item, int, error) {
 	if err := r.ensureIndex(ctx); err != nil {
 		return nil, 0, err
 	}
 	q = strings.TrimSpace(q)
-	if q == "" {
+	if q == "" && len(opts.Tags) == 0 {
 		return []*kb.Item{}, 0, nil
 	}
-	if limit <= 0 {
-		limit = 10
+	if opts.Limit <= 0 {
+		opts.Limit = 10
+	}
+	pg := page{from: max(opts.Offset, 0)}
+	if opts.Cursor || opts.After != "" {
+		var err error
+		if pg, err = r.cursorPage(ctx, opts.After); err != nil {
+			return nil, 0, err
+		}
+	}
+	query := buildSearchQuery(q, opts, pg)
+	sr := esapi.SearchRequest{Body: strings.NewReader(query)}
+	if pg.pit == "" {
+		// a point-in-time search already names its index
+		sr.Index = []string{r.index}
 	}
-	query := buildSearchQuery(q, limit)
-	sr := esapi.SearchRequest{Index: []string{r.index}, Body: strings.NewReader(query)}
 	res, err := sr.Do(ctx, r.cli)
 	if err != nil {
 		return nil, 0, err
 	}
 	defer res.Body.Close()
+	if res.StatusCode == http.StatusNotFound && pg.pit != "" {
+		// the point-in-time expired between pages
+		return nil, 0, kb.ErrInvalidCursor
+	}
 	if res.StatusCode >= 300 {
 		return nil, 0, fmt.Errorf("search failed: %s", res.String())
 	}
-	items, total, err := parseSearchResponse(res)
+	items, total, err := parseSearchResponse(res, pg.pit, opts.Snippet)
 	if err != nil {
 		return nil, 0, err
 	}
+	if pg.pit != "" && len(items) < opts.Limit {
+		r.closePIT(ctx, pg.pit)
+	}
 	return items, total, nil
 }
 
-func buildSearchQuery(q string, limit int) string {
+// pitKeepAlive is how long a cursor's point-in-time stays open between pages.
+const pitKeepAlive = "1m"
+
+// page selects the hits to return: from/size, or a point-in-time with search_after.
+type page struct {
+	from  int
+	pit   string
+	after []json.RawMessage
+}
+
+// esCursor is the opaque cursor: the point-in-time and the sort values of the last hit.
+type esCursor struct {
+	PIT  string            `json:"pit"`
+	Sort []json.RawMessage `json:"sort"`
+}
+
+// cursorPage resumes the cursor after, or opens a new point-in-time when after is empty.
+func (r *Repo) cursorPage(ctx context.Context, after string) (page, error) {
+	if after == "" {
+		pit, err := r.openPIT(ctx)
+		return page{pit: pit}, err
+	}
+	var c esCursor
+	b, err := base64.RawURLEncoding.DecodeString(after)
+	if err != nil || json.Unmarshal(b, &c) != nil || c.PIT == "" || len(c.Sort) == 0 {
+		return page{}, kb.ErrInvalidCursor
+	}
+	return page{pit: c.PIT, after: c.Sort}, nil
+}
+
+func (r *Repo) openPIT(ctx context.Context) (string, error) {
+	or := esapi.OpenPointInTimeRequest{Index: []string{r.index}, KeepAlive: pitKeepAlive}
+	res, err := or.Do(ctx, r.cli)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+	if res.StatusCode >= 300 {
+		return "", fmt.Errorf("open point in time failed: %s", res.String())
+	}
+	var out struct {
+		ID string `json:"id"`
+	}
+	if err := decodeJSON(res.Body, &out); err != nil || out.ID == "" {
+		return "", fmt.Errorf("open point in time: no id")
+	}
+	return out.ID, nil
+}
+
+// closePIT releases a point-in-time once its last page is served; errors are ignored
+// because an unclosed one lapses after pitKeepAlive anyway.
+func (r *Repo) closePIT(ctx context.Context, pit string) {
+	cr := esapi.ClosePointInTimeRequest{Body: strings.NewReader(fmt.Sprintf(`{"id": %q}`, pit))}
+	if res, err := cr.Do(ctx, r.cli); err == nil {
+		res.Body.Close()
+	}
+}
+
+func buildSearchQuery(q string, opts kb.SearchOptions, pg page) string {
+	match, fragment := matchClause(q, opts.Snippet)
+	if filter := tagFilter(opts.Tags, opts.AnyTag); filter != "" {
+		match = fmt.Sprintf(`{"bool": {"must": [%s], "filter": [%s]}}`, match, filter)
+	}
+	paging := fmt.Sprintf(`"from": %d,`, pg.from)
+	if pg.pit != "" {
+		// _shard_doc breaks score ties so search_after never skips or repeats a hit
+		paging = fmt.Sprintf(`"pit": {"id": %q, "keep_alive": %q},
+	"sort": [{"_score": "desc"}, {"_shard_doc": "asc"}],
+	"track_total_hits": true,`, pg.pit, pitKeepAlive)
+		if len(pg.after) > 0 {
+			after, _ := json.Marshal(pg.after)
+			paging += fmt.Sprintf(`
+	"search_after": %s,`, after)
+		}
+	}
+	return fmt.Sprintf(`{
+	"size": %d,
+	%s
+	"_source": {"excludes": ["chunks", "tag_terms", "chunk_vectors"]},
+	"query": %s,
+	"highlight": {"fields": %s}
+}`, opts.Limit, paging, match, highlightField("content", fragment, opts.Snippet))
+}
+
+// matchClause returns the full-text clause for q and the default highlight fragment size.
+// A document scores its title, its whole content (lightly, for documents indexed before
+// chunking) and its best chunk, which comes back as an inner hit highlighted per so.
+func matchClause(q string, so kb.SnippetOptions) (string, int) {
+	if q == "" {
+		return `{"match_all": {}}`, 120
+	}
 	// If query is short (<= 4 runes), add a should clause against title.autocomplete to improve precision
 	short := len([]rune(q)) <= 4
+	fragment := 120
 	if short {
-		return fmt.Sprintf(`{
-	"size": %d,
-	"query": {
+		fragment = 80
+	}
+	should := []string{
+		fmt.Sprintf(`{"multi_match": {"query": %q, "fields": ["title^2","content^0.5"], "type": "best_fields"}}`, q),
+		fmt.Sprintf(`{"nested": {
+				"path": "chunks", "score_mode": "max", "ignore_unmapped": true,
+				"query": {"multi_match": {"query": %q, "fields": ["chunks.heading^1.5","chunks.text"], "type": "best_fields"}},
+				"inner_hits": {"size": 1, "_source": ["chunks.index","chunks.heading","chunks.text"],
+					"highlight": {"fields": %s}}
+			}}`, q, highlightField("chunks.text", fragment, so)),
+	}
+	if short {
+		should = append(should, fmt.Sprintf(`{"match": {"title.autocomplete": {"query": %q, "boost": 1.2}}}`, q))
+	}
+	return fmt.Sprintf(`{
 		"bool": {
 			"should": [
-				{"multi_match": {"query": %q, "fields": ["title^2","content^1"], "type": "best_fields"}},
-				{"match": {"title.autocomplete": {"query": %q, "boost": 1.2}}}
+				%s
 			],
 			"minimum_should_match": 1
 		}
-	},
-	"highlight": {"fields": {"content": {"fragment_size": 80, "number_of_fragments": 1}}}
-}`, limit, q, q)
+	}`, strings.Join(should, ",\n\t\t\t\t")), fragment
+}
+
+// tagFilter matches tag terms against the tag_terms keyword field: one term clause per
+// tag for "all", a single terms clause for "any".
+func tagFilter(tags []string, any bool) string {
+	terms := make([]string, 0, len(tags))
+	for _, t := range tags {
+		if t = strings.ToLower(strings.TrimSpace(t)); t != "" {
+			b, _ := json.Marshal(t)
+			terms = append(terms, string(b))
+		}
 	}
-	return fmt.Sprintf(`{
-	"size": %d,
-	"query": {"multi_match": {"query": %q, "fields": ["title^2","content^1"], "type": "best_fields"}},
-	"highlight": {"fields": {"content": {"fragment_size": 120, "number_of_fragments": 1}}}
-}`, limit, q)
+	if len(terms) == 0 {
+		return ""
+	}
+	if any {
+		return fmt.Sprintf(`{"terms": {"tag_terms": [%s]}}`, strings.Join(terms, ","))
+	}
+	clauses := make([]string, len(terms))
+	for i, t := range terms {
+		clauses[i] = fmt.Sprintf(`{"term": {"tag_terms": %s}}`, t)
+	}
+	return strings.Join(clauses, ",")
 }
 
-func parseSearchResponse(res *esapi.Response) ([]*kb.Item, int, error) {
+// parseSearchResponse turns hits into items, their snippets shaped by so. For
+// point-in-time searches (pit set) every item also gets the cursor that resumes after it.
+func parseSearchResponse(res *esapi.Response, pit string, so kb.SnippetOptions) ([]*kb.Item, int, error) {
 	var resp struct {
-		Hits struct {
+		PitID string `json:"pit_id"`
+		Hits  struct {
 			Total struct {
 				Value int `json:"value"`
 			} `json:"total"`
 			Hits []struct {
-				ID     string         `json:"_id"`
-				Score  float64        `json:"_score"`
-				Source kb.Doc         `json:"_source"`
-				HL     map[string]any `json:"highlight"`
+				ID     string              `json:"_id"`
+				Score  float64             `json:"_score"`
+				Source kb.Doc              `json:"_source"`
+				HL     map[string][]string `json:"highlight"`
+				Sort   []json.RawMessage   `json:"sort"`
+				Inner  struct {
+					Chunks struct {
+						Hits struct {
+							Hits []struct {
+								Source kb.Chunk            `json:"_source"`
+								HL     map[string][]string `json:"highlight"`
+							} `json:"hits"`
+						} `json:"hits"`
+					} `json:"chunks"`
+				} `json:"inner_hits"`
 			} `json:"hits"`
 		} `json:"hits"`
 	}
@@ -224,19 +480,39 @@ func parseSearchResponse(res *esapi.Response) ([]*kb.Item, int, error) {
 	}
 	items := make([]*kb.Item, 0, len(resp.Hits.Hits))
 	for _, h := range resp.Hits.Hits {
-		snippet := h.Source.Content
-		if v, ok := h.HL["content"]; ok {
-			if arr, aok := v.([]any); aok && len(arr) > 0 {
-				if s, sok := arr[0].(string); sok {
-					snippet = stripTags(s)
-				}
+		it := &kb.Item{ID: h.ID, Title: h.Source.Title, Score: h.Score, Tags: h.Source.Tags}
+		// the best chunk's highlight beats the whole-content one
+		if best := h.Inner.Chunks.Hits.Hits; len(best) > 0 {
+			c := best[0]
+			it.Chunk, it.Heading = c.Source.Index, c.Source.Heading
+			setSnippet(it, c.Source.Text, so, c.HL["chunks.text"])
+		} else {
+			setSnippet(it, h.Source.Content, so, h.HL["content"])
+		}
+		if pit != "" {
+			// ES may hand back a refreshed id; later pages must use it
+			if resp.PitID != "" {
+				pit = resp.PitID
 			}
+			b, _ := json.Marshal(esCursor{PIT: pit, Sort: h.Sort})
+			it.Cursor = base64.RawURLEncoding.EncodeToString(b)
 		}
-		items = append(items, &kb.Item{ID: h.ID, Title: h.Source.Title, Snippet: snippet, Score: h.Score})
+		items = append(items, it)
 	}
 	return items, resp.Hits.Total.Value, nil
 }
 
+// esDoc is the indexed source: the document plus its flattened tag terms for filtering.
+type esDoc struct {
+	Title     string            `json:"title"`
+	Content   string            `json:"content"`
+	Tags      map[string]string `json:"tags,omitempty"`
+	TagTerms  []string          `json:"tag_terms,omitempty"`
+	CreatedAt int64             `json:"created_at,omitempty"`
+	UpdatedAt int64             `json:"updated_at,omitempty"`
+	Chunks    []kb.Chunk        `json:"chunks,omitempty"`
+}
+
 func (r *Repo) Update(ctx context.Context, d *kb.Doc) error {
 	if d == nil || d.ID == "" {
 		return errors.New("invalid doc")
@@ -244,8 +520,11 @@ func (r *Repo) Update(ctx context.Context, d *kb.Doc) error {
 	if err := r.ensureIndex(ctx); err != nil {
 		return err
 	}
-	payload := fmt.Sprintf(`{"title":%q,"content":%q}`, d.Title, d.Content)
-	ir := esapi.IndexRequest{Index: r.index, DocumentID: d.ID, Body: strings.NewReader(payload), Refresh: "true"}
+	payload, err := json.Marshal(esDoc{Title: d.Title, Content: d.Content, Tags: d.Tags, TagTerms: kb.TagTerms(d.Tags), CreatedAt: d.CreatedAt, UpdatedAt: d.UpdatedAt, Chunks: d.IndexedChunks()})
+	if err != nil {
+		return err
+	}
+	ir := esapi.IndexRequest{Index: r.index, DocumentID: d.ID, Body: strings.NewReader(string(payload)), Refresh: "true"}
 	res, err := ir.Do(ctx, r.cli)
 	if err != nil {
 		return err
@@ -359,24 +638,6 @@ func decodeJSON(rdr any, out any) error {
 	return dec.Decode(out)
 }
 
-func stripTags(s string) string {
-	res := make([]rune, 0, len(s))
-	in := false
-	for _, r := range s {
-		switch r {
-		case '<':
-			in = true
-		case '>':
-			in = false
-		default:
-			if !in {
-				res = append(res, r)
-			}
-		}
-	}
-	return string(res)
-}
-
 // wrap std json decoder behind a minimal interface to keep imports local to this file.
 // We do this to avoid alias conflicts if the repo already imports other json libs elsewhere.
 
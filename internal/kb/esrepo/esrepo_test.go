@@ -0,0 +1,100 @@
+package esrepo
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+	"github.com/gogogo1024/assist-fusion/internal/kb"
+)
+
+func TestBuildSearchQueryTagFilter(t *testing.T) {
+	cases := []struct {
+		q    string
+		opts kb.SearchOptions
+		pg   page
+		want []string
+	}{
+		{"客服", kb.SearchOptions{Limit: 5}, page{from: 20}, []string{`"title.autocomplete"`, `"from": 20`, `"path": "chunks"`, `"inner_hits"`}},
+		{"如何升级客服流程", kb.SearchOptions{Limit: 5, Tags: []string{"Lang:ZH", "product"}}, page{}, []string{`{"term": {"tag_terms": "lang:zh"}}`, `{"term": {"tag_terms": "product"}}`}},
+		{"", kb.SearchOptions{Limit: 5, Tags: []string{"a", "b"}, AnyTag: true}, page{}, []string{`"match_all"`, `{"terms": {"tag_terms": ["a","b"]}}`}},
+		{"客服", kb.SearchOptions{Limit: 5}, page{pit: "p1", after: []json.RawMessage{[]byte("1.5"), []byte("42")}}, []string{`"pit": {"id": "p1"`, `"_shard_doc"`, `"search_after": [1.5,42]`}},
+		{"重置网络", kb.SearchOptions{Limit: 5, Snippet: kb.SnippetOptions{Length: 200, Fragments: 3}}, page{}, []string{`"fragment_size": 200, "number_of_fragments": 3, "no_match_size": 200`, `"pre_tags": ["\ue000"]`}},
+	}
+	for _, c := range cases {
+		body := buildSearchQuery(c.q, c.opts, c.pg)
+		var v map[string]any
+		if err := json.Unmarshal([]byte(body), &v); err != nil {
+			t.Fatalf("query for %q is not JSON: %v\n%s", c.q, err, body)
+		}
+		for _, w := range c.want {
+			if !strings.Contains(body, w) {
+				t.Fatalf("query for %q missing %s:\n%s", c.q, w, body)
+			}
+		}
+	}
+}
+
+func TestBuildListQuery(t *testing.T) {
+	body := buildListQuery(kb.ListOptions{Limit: 3, Tags: []string{"lang:zh"}, Asc: true}, page{pit: "p1", after: []json.RawMessage{[]byte("1700000000"), []byte("7")}})
+	var v map[string]any
+	if err := json.Unmarshal([]byte(body), &v); err != nil {
+		t.Fatalf("list query is not JSON: %v\n%s", err, body)
+	}
+	for _, w := range []string{`"size": 4`, `"order": "asc"`, `"search_after": [1700000000,7]`, `{"term": {"tag_terms": "lang:zh"}}`} {
+		if !strings.Contains(body, w) {
+			t.Fatalf("list query missing %s:\n%s", w, body)
+		}
+	}
+}
+
+func TestParseSearchResponseBestChunk(t *testing.T) {
+	body := `{"hits": {"total": {"value": 1}, "hits": [{
+		"_id": "d1", "_score": 3.5,
+		"_source": {"title": "VPN", "content": "intro ... long", "tags": {"product": "vpn"}},
+		"highlight": {"content": ["\ue000intro\ue001"]},
+		"inner_hits": {"chunks": {"hits": {"hits": [{
+			"_source": {"index": 4, "heading": ["Install", "macOS"], "text": "reset the profile"},
+			"highlight": {"chunks.text": ["\ue000reset\ue001 the profile", "\ue000reset\ue001 again"]}
+		}]}}}
+	}]}}`
+	parse := func(so kb.SnippetOptions) *kb.Item {
+		items, total, err := parseSearchResponse(&esapi.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(body))}, "", so)
+		if err != nil || total != 1 || len(items) != 1 {
+			t.Fatalf("parse = %v, %d, %v", items, total, err)
+		}
+		return items[0]
+	}
+	it := parse(kb.SnippetOptions{})
+	if it.Chunk != 4 || strings.Join(it.Heading, ">") != "Install>macOS" || it.Snippet != "reset the profile … reset again" || it.Tags["product"] != "vpn" {
+		t.Fatalf("item = %+v", it)
+	}
+	if fmt.Sprint(it.Highlights) != "[{0 5} {20 25}]" {
+		t.Fatalf("highlights = %v", it.Highlights)
+	}
+	if it := parse(kb.SnippetOptions{PreTag: "<b>", PostTag: "</b>"}); it.Snippet != "<b>reset</b> the profile … <b>reset</b> again" || it.Highlights != nil {
+		t.Fatalf("tagged item = %+v", it)
+	}
+}
+
+func TestVectorQueries(t *testing.T) {
+	for _, body := range []string{
+		vectorMapping(128, SimilarityCosine),
+		buildScanQuery("p1", nil),
+		buildScanQuery("p1", []json.RawMessage{[]byte("12")}),
+	} {
+		var v map[string]any
+		if err := json.Unmarshal([]byte(body), &v); err != nil {
+			t.Fatalf("not JSON: %v\n%s", err, body)
+		}
+	}
+	if body := vectorMapping(128, SimilarityCosine); !strings.Contains(body, `"dense_vector", "dims": 128, "index": true, "similarity": "cosine"`) {
+		t.Fatalf("mapping = %s", body)
+	}
+	if body := buildScanQuery("p1", []json.RawMessage{[]byte("12")}); !strings.Contains(body, `"search_after": [12]`) || !strings.Contains(body, `"_source": ["chunk_vectors"]`) {
+		t.Fatalf("scan query = %s", body)
+	}
+}
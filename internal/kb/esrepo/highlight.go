@@ -0,0 +1,62 @@
+package esrepo
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gogogo1024/assist-fusion/internal/kb"
+)
+
+// Highlights are requested with private-use runes as tags, which no document text is
+// expected to contain, and turned into kb.Span offsets or the caller's tags afterwards.
+const (
+	markPre  = '\uE000'
+	markPost = '\uE001'
+)
+
+// highlightField is the highlight settings of one field: fragments of the requested
+// length (fragment when unset), the leading text when nothing matched, and the markers.
+func highlightField(field string, fragment int, so kb.SnippetOptions) string {
+	if so.Length > 0 {
+		fragment = so.Length
+	}
+	return fmt.Sprintf(`{%q: {"fragment_size": %d, "number_of_fragments": %d, "no_match_size": %d, "pre_tags": [%q], "post_tags": [%q]}}`,
+		field, fragment, max(so.Fragments, 1), fragment, string(markPre), string(markPost))
+}
+
+// parseHighlight joins the fragments of a field highlight into a snippet and converts
+// the markers to spans, or to so's tags when it has them.
+func parseHighlight(frags []string, so kb.SnippetOptions) (string, []kb.Span) {
+	var b strings.Builder
+	var spans []kb.Span
+	at, start := 0, -1 // runes written, open span
+	for _, r := range []rune(strings.Join(frags, kb.FragmentSeparator)) {
+		switch {
+		case r == markPre:
+			start = at
+			b.WriteString(so.PreTag)
+		case r == markPost:
+			if start >= 0 && !so.Tagged() {
+				spans = append(spans, kb.Span{Start: start, End: at})
+			}
+			start = -1
+			b.WriteString(so.PostTag)
+		default:
+			b.WriteRune(r)
+			at++
+		}
+	}
+	return b.String(), spans
+}
+
+// setSnippet sets the item's snippet from the first field with a highlight, else the leading
+// window of text.
+func setSnippet(it *kb.Item, text string, so kb.SnippetOptions, hls ...[]string) {
+	for _, frags := range hls {
+		if len(frags) > 0 {
+			it.Snippet, it.Highlights = parseHighlight(frags, so)
+			return
+		}
+	}
+	it.Snippet, it.Highlights = kb.Snippet(text, "", 0, so)
+}
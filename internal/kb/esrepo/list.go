@@ -0,0 +1,103 @@
+package esrepo
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+	"github.com/gogogo1024/assist-fusion/internal/kb"
+)
+
+// List implements kb.Repo.List on a point-in-time: the first page opens one, later pages
+// resume it with search_after, and the last page closes it.
+func (r *Repo) List(ctx context.Context, opts kb.ListOptions) (*kb.DocPage, error) {
+	if err := r.ensureIndex(ctx); err != nil {
+		return nil, err
+	}
+	if opts.Limit <= 0 {
+		opts.Limit = 20
+	}
+	pg, err := r.cursorPage(ctx, opts.After)
+	if err != nil {
+		return nil, err
+	}
+	sr := esapi.SearchRequest{Body: strings.NewReader(buildListQuery(opts, pg))}
+	res, err := sr.Do(ctx, r.cli)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusNotFound {
+		// the point-in-time expired between pages
+		return nil, kb.ErrInvalidCursor
+	}
+	if res.StatusCode >= 300 {
+		return nil, fmt.Errorf("list failed: %s", res.String())
+	}
+	var resp struct {
+		PitID string `json:"pit_id"`
+		Hits  struct {
+			Total struct {
+				Value int `json:"value"`
+			} `json:"total"`
+			Hits []struct {
+				ID     string            `json:"_id"`
+				Source kb.Doc            `json:"_source"`
+				Sort   []json.RawMessage `json:"sort"`
+			} `json:"hits"`
+		} `json:"hits"`
+	}
+	if err := decodeJSON(res.Body, &resp); err != nil {
+		return nil, err
+	}
+	hits := resp.Hits.Hits
+	page := &kb.DocPage{Docs: make([]*kb.Doc, 0, opts.Limit), Total: resp.Hits.Total.Value}
+	// one extra hit was requested to tell whether another page follows
+	if len(hits) > opts.Limit {
+		hits = hits[:opts.Limit]
+		pit := pg.pit
+		if resp.PitID != "" {
+			pit = resp.PitID
+		}
+		b, _ := json.Marshal(esCursor{PIT: pit, Sort: hits[len(hits)-1].Sort})
+		page.Next = base64.RawURLEncoding.EncodeToString(b)
+	} else {
+		r.closePIT(ctx, pg.pit)
+	}
+	for _, h := range hits {
+		d := h.Source
+		d.ID = h.ID
+		page.Docs = append(page.Docs, &d)
+	}
+	return page, nil
+}
+
+func buildListQuery(opts kb.ListOptions, pg page) string {
+	query := `{"match_all": {}}`
+	if filter := tagFilter(opts.Tags, opts.AnyTag); filter != "" {
+		query = fmt.Sprintf(`{"bool": {"filter": [%s]}}`, filter)
+	}
+	order := "desc"
+	if opts.Asc {
+		order = "asc"
+	}
+	after := ""
+	if len(pg.after) > 0 {
+		b, _ := json.Marshal(pg.after)
+		after = fmt.Sprintf(`
+	"search_after": %s,`, b)
+	}
+	// documents written before timestamps existed sort last; _shard_doc breaks ties
+	return fmt.Sprintf(`{
+	"size": %d,
+	"pit": {"id": %q, "keep_alive": %q},
+	"sort": [{"updated_at": {"order": %q, "missing": "_last", "unmapped_type": "long"}}, {"_shard_doc": "asc"}],
+	"track_total_hits": true,%s
+	"_source": {"excludes": ["chunks", "tag_terms", "chunk_vectors"]},
+	"query": %s
+}`, opts.Limit+1, pg.pit, pitKeepAlive, order, after, query)
+}
@@ -0,0 +1,451 @@
+package esrepo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+	"github.com/gogogo1024/assist-fusion/internal/kb"
+)
+
+// scanPageSize is how many documents one ScanVectors page reads.
+const scanPageSize = 200
+
+// Similarities a dense_vector field can be searched with.
+const (
+	SimilarityCosine          = "cosine"
+	SimilarityDotProduct      = "dot_product"
+	SimilarityL2Norm          = "l2_norm"
+	SimilarityMaxInnerProduct = "max_inner_product"
+)
+
+func validSimilarity(s string) bool {
+	switch s {
+	case SimilarityCosine, SimilarityDotProduct, SimilarityL2Norm, SimilarityMaxInnerProduct:
+		return true
+	}
+	return false
+}
+
+// chunkVector is one entry of the chunk_vectors nested field.
+type chunkVector struct {
+	Index  int       `json:"index"`
+	Vector []float64 `json:"vector"`
+}
+
+// vectorField is how chunk_vectors.vector is mapped in the index.
+type vectorField struct {
+	Dims       int    `json:"dims"`
+	Indexed    *bool  `json:"index"` // nil: the ES default, indexed since 8.11
+	Similarity string `json:"similarity"`
+}
+
+// searchable reports whether kNN queries can run on the field.
+func (f *vectorField) searchable() bool { return f.Indexed == nil || *f.Indexed }
+
+// vectorProperty maps chunk_vectors as nested dense vectors of dims, indexed for kNN
+// with similarity; each chunk's vector is a nested document next to its chunk index.
+func vectorProperty(dims int, similarity string) string {
+	return fmt.Sprintf(`"chunk_vectors": {"type": "nested", "properties": {
+	"index": {"type": "integer"},
+	"vector": {"type": "dense_vector", "dims": %d, "index": true, "similarity": %q}
+}}`, dims, similarity)
+}
+
+func vectorMapping(dims int, similarity string) string {
+	return fmt.Sprintf(`{"properties": {%s}}`, vectorProperty(dims, similarity))
+}
+
+// vectorField returns the mapping of chunk_vectors.vector, nil while it is not mapped. A
+// mapped field never changes, so it is cached.
+func (r *Repo) vectorField(ctx context.Context) (*vectorField, error) {
+	if f := r.vectors.Load(); f != nil {
+		return f, nil
+	}
+	gr := esapi.IndicesGetFieldMappingRequest{Index: []string{r.index}, Fields: []string{"chunk_vectors.vector"}}
+	res, err := gr.Do(ctx, r.cli)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if res.StatusCode >= 300 {
+		return nil, fmt.Errorf("get vector mapping failed: %s", res.String())
+	}
+	var resp map[string]struct {
+		Mappings map[string]struct {
+			Mapping map[string]vectorField `json:"mapping"`
+		} `json:"mappings"`
+	}
+	if err := decodeJSON(res.Body, &resp); err != nil {
+		return nil, err
+	}
+	f, ok := resp[r.index].Mappings["chunk_vectors.vector"].Mapping["vector"]
+	if !ok || f.Dims == 0 {
+		return nil, nil
+	}
+	if f.Similarity == "" {
+		f.Similarity = SimilarityCosine
+	}
+	r.vectors.Store(&f)
+	return &f, nil
+}
+
+// ensureVectorMapping checks that vectors of dims fit the index, mapping chunk_vectors on
+// the first write when the index was created without it (Config.VectorDims unset). ES
+// refuses to change dims later, which surfaces as kb.ErrDimMismatch.
+func (r *Repo) ensureVectorMapping(ctx context.Context, dims int) error {
+	if r.dims > 0 && dims != r.dims {
+		return kb.ErrDimMismatch
+	}
+	f, err := r.vectorField(ctx)
+	if err != nil {
+		return err
+	}
+	if f != nil {
+		if f.Dims != dims {
+			return kb.ErrDimMismatch
+		}
+		return nil
+	}
+	pr := esapi.IndicesPutMappingRequest{Index: []string{r.index}, Body: strings.NewReader(vectorMapping(dims, r.similarity))}
+	res, err := pr.Do(ctx, r.cli)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusBadRequest {
+		return kb.ErrDimMismatch
+	}
+	if res.StatusCode >= 300 {
+		return fmt.Errorf("put vector mapping failed: %s", res.String())
+	}
+	indexed := true
+	r.vectors.Store(&vectorField{Dims: dims, Indexed: &indexed, Similarity: r.similarity})
+	return nil
+}
+
+// PutVectors implements kb.VectorRepo with a partial update of the document's
+// chunk_vectors; a later Update of the document drops them. Vectors whose dims differ
+// from each other or from the index are rejected with kb.ErrDimMismatch.
+func (r *Repo) PutVectors(ctx context.Context, id string, vecs [][]float64) error {
+	if err := r.ensureIndex(ctx); err != nil {
+		return err
+	}
+	entries := make([]chunkVector, 0, len(vecs))
+	for i, v := range vecs {
+		if len(v) == 0 {
+			continue
+		}
+		if len(entries) > 0 && len(v) != len(entries[0].Vector) {
+			return kb.ErrDimMismatch
+		}
+		entries = append(entries, chunkVector{Index: i, Vector: r.prepareVector(v)})
+	}
+	if len(entries) > 0 {
+		if err := r.ensureVectorMapping(ctx, len(entries[0].Vector)); err != nil {
+			return err
+		}
+	}
+	body, err := json.Marshal(map[string]any{"doc": map[string]any{"chunk_vectors": entries}})
+	if err != nil {
+		return err
+	}
+	ur := esapi.UpdateRequest{Index: r.index, DocumentID: id, Body: strings.NewReader(string(body)), Refresh: "true"}
+	res, err := ur.Do(ctx, r.cli)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode >= 300 {
+		return fmt.Errorf("put vectors failed: %s", res.String())
+	}
+	return nil
+}
+
+// prepareVector scales v to unit length for dot_product, which ES requires; the other
+// similarities take vectors as they are.
+func (r *Repo) prepareVector(v []float64) []float64 {
+	if r.similarity != SimilarityDotProduct {
+		return v
+	}
+	var n float64
+	for _, x := range v {
+		n += x * x
+	}
+	if n == 0 {
+		return v
+	}
+	n = math.Sqrt(n)
+	out := make([]float64, len(v))
+	for i, x := range v {
+		out[i] = x / n
+	}
+	return out
+}
+
+// knnCandidates is how many nearest chunks each shard considers per requested hit.
+const knnCandidates = 10
+
+// KNNSearch implements kb.KNNRepo with an approximate kNN query on chunk_vectors, plus
+// the lexical query of Search when opts.Query is set. It returns kb.ErrKNNUnsupported for
+// indexes whose vectors are stored unindexed (created before kNN support) and for
+// clusters that reject nested kNN (before 8.11).
+func (r *Repo) KNNSearch(ctx context.Context, opts kb.KNNOptions) ([]*kb.Item, error) {
+	if err := r.ensureIndex(ctx); err != nil {
+		return nil, err
+	}
+	f, err := r.vectorField(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if f == nil || opts.K <= 0 {
+		return []*kb.Item{}, nil
+	}
+	if !f.searchable() {
+		return nil, kb.ErrKNNUnsupported
+	}
+	if len(opts.Vector) != f.Dims {
+		return nil, kb.ErrDimMismatch
+	}
+	opts.Vector = r.prepareVector(opts.Vector)
+	sr := esapi.SearchRequest{Index: []string{r.index}, Body: strings.NewReader(buildKNNQuery(opts))}
+	res, err := sr.Do(ctx, r.cli)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusBadRequest {
+		return nil, fmt.Errorf("%w: %s", kb.ErrKNNUnsupported, res.String())
+	}
+	if res.StatusCode >= 300 {
+		return nil, fmt.Errorf("knn search failed: %s", res.String())
+	}
+	// without a lexical part and boost, scores map back to the similarity
+	similarity := ""
+	if opts.Query == "" && (opts.VectorBoost == 0 || opts.VectorBoost == 1) {
+		similarity = f.Similarity
+	}
+	return parseKNNResponse(res, similarity, opts.Snippet)
+}
+
+// CountVectors implements kb.KNNRepo.
+func (r *Repo) CountVectors(ctx context.Context) (int, error) {
+	if err := r.ensureIndex(ctx); err != nil {
+		return 0, err
+	}
+	cr := esapi.CountRequest{Index: []string{r.index}, Body: strings.NewReader(fmt.Sprintf(`{"query": %s}`, vectorDocsQuery))}
+	res, err := cr.Do(ctx, r.cli)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode >= 300 {
+		return 0, fmt.Errorf("count vectors failed: %s", res.String())
+	}
+	var out struct {
+		Count int `json:"count"`
+	}
+	if err := decodeJSON(res.Body, &out); err != nil {
+		return 0, err
+	}
+	return out.Count, nil
+}
+
+// vectorDocsQuery matches the documents that have chunk vectors.
+const vectorDocsQuery = `{"nested": {"path": "chunk_vectors", "query": {"match_all": {}}, "ignore_unmapped": true}}`
+
+// buildKNNQuery is a nested kNN query whose inner hit names the nearest chunk. With
+// opts.Query the lexical clause of Search joins it and ES adds the boosted scores; the
+// tag filter applies to both.
+func buildKNNQuery(opts kb.KNNOptions) string {
+	vec, _ := json.Marshal(opts.Vector)
+	filter := tagFilter(opts.Tags, opts.AnyTag)
+	knnFilter := ""
+	if filter != "" {
+		knnFilter = fmt.Sprintf(`
+		"filter": {"bool": {"filter": [%s]}},`, filter)
+	}
+	vectorBoost := opts.VectorBoost
+	if vectorBoost <= 0 {
+		vectorBoost = 1
+	}
+	query := ""
+	if q := strings.TrimSpace(opts.Query); q != "" {
+		match, fragment := matchClause(q, opts.Snippet)
+		queryBoost := opts.QueryBoost
+		if queryBoost <= 0 {
+			queryBoost = 1
+		}
+		if filter != "" {
+			match = fmt.Sprintf(`{"bool": {"must": [%s], "filter": [%s], "boost": %g}}`, match, filter, queryBoost)
+		} else {
+			match = fmt.Sprintf(`{"bool": {"must": [%s], "boost": %g}}`, match, queryBoost)
+		}
+		query = fmt.Sprintf(`
+	"query": %s,
+	"highlight": {"fields": %s},`, match, highlightField("content", fragment, opts.Snippet))
+	}
+	return fmt.Sprintf(`{
+	"size": %d,
+	"_source": {"excludes": ["tag_terms", "chunk_vectors"]},%s
+	"knn": {
+		"field": "chunk_vectors.vector",
+		"query_vector": %s,
+		"k": %d,
+		"num_candidates": %d,
+		"boost": %g,%s
+		"inner_hits": {"size": 1, "_source": ["chunk_vectors.index"]}
+	}
+}`, opts.K, query, vec, opts.K, min(opts.K*knnCandidates, 10000), vectorBoost, knnFilter)
+}
+
+// knnScore maps a kNN _score back to the similarity it was computed from; ES scores
+// cosine and dot_product as (1+s)/2. Other similarities keep the score.
+func knnScore(score float64, similarity string) float64 {
+	switch similarity {
+	case SimilarityCosine, SimilarityDotProduct:
+		return 2*score - 1
+	}
+	return score
+}
+
+// parseKNNResponse turns kNN hits into items. The nearest chunk supplies snippet and
+// heading unless the lexical part matched a chunk, which wins as in kb.Fuse. similarity,
+// when set, converts scores back to it; so shapes the snippets.
+func parseKNNResponse(res *esapi.Response, similarity string, so kb.SnippetOptions) ([]*kb.Item, error) {
+	var resp struct {
+		Hits struct {
+			Hits []struct {
+				ID     string  `json:"_id"`
+				Score  float64 `json:"_score"`
+				Source kb.Doc  `json:"_source"`
+				Inner  struct {
+					Chunks struct {
+						Hits struct {
+							Hits []struct {
+								Source kb.Chunk            `json:"_source"`
+								HL     map[string][]string `json:"highlight"`
+							} `json:"hits"`
+						} `json:"hits"`
+					} `json:"chunks"`
+					ChunkVectors struct {
+						Hits struct {
+							Hits []struct {
+								Source struct {
+									Index int `json:"index"`
+								} `json:"_source"`
+							} `json:"hits"`
+						} `json:"hits"`
+					} `json:"chunk_vectors"`
+				} `json:"inner_hits"`
+			} `json:"hits"`
+		} `json:"hits"`
+	}
+	if err := decodeJSON(res.Body, &resp); err != nil {
+		return nil, err
+	}
+	items := make([]*kb.Item, 0, len(resp.Hits.Hits))
+	for _, h := range resp.Hits.Hits {
+		d := h.Source
+		d.ID = h.ID
+		hit := kb.VectorHit{ID: h.ID, Chunk: -1, Score: knnScore(h.Score, similarity)}
+		if near := h.Inner.ChunkVectors.Hits.Hits; len(near) > 0 {
+			hit.Chunk = near[0].Source.Index
+		}
+		it := kb.VectorItem(&d, d.Chunks, hit, so)
+		if best := h.Inner.Chunks.Hits.Hits; len(best) > 0 {
+			c := best[0]
+			it.Chunk, it.Heading = c.Source.Index, c.Source.Heading
+			setSnippet(it, c.Source.Text, so, c.HL["chunks.text"])
+		}
+		items = append(items, it)
+	}
+	return items, nil
+}
+
+// ScanVectors implements kb.VectorRepo, walking the documents that carry chunk_vectors
+// on a point-in-time.
+func (r *Repo) ScanVectors(ctx context.Context, fn func(id string, vecs [][]float64) error) error {
+	if err := r.ensureIndex(ctx); err != nil {
+		return err
+	}
+	pit, err := r.openPIT(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { r.closePIT(ctx, pit) }()
+	var after []json.RawMessage
+	for {
+		sr := esapi.SearchRequest{Body: strings.NewReader(buildScanQuery(pit, after))}
+		res, err := sr.Do(ctx, r.cli)
+		if err != nil {
+			return err
+		}
+		var resp struct {
+			PitID string `json:"pit_id"`
+			Hits  struct {
+				Hits []struct {
+					ID     string `json:"_id"`
+					Source struct {
+						ChunkVectors []chunkVector `json:"chunk_vectors"`
+					} `json:"_source"`
+					Sort []json.RawMessage `json:"sort"`
+				} `json:"hits"`
+			} `json:"hits"`
+		}
+		if res.StatusCode >= 300 {
+			err = fmt.Errorf("scan vectors failed: %s", res.String())
+		} else {
+			err = decodeJSON(res.Body, &resp)
+		}
+		res.Body.Close()
+		if err != nil {
+			return err
+		}
+		if resp.PitID != "" {
+			pit = resp.PitID
+		}
+		for _, h := range resp.Hits.Hits {
+			var vecs [][]float64
+			for _, cv := range h.Source.ChunkVectors {
+				if cv.Index < 0 {
+					continue
+				}
+				for len(vecs) <= cv.Index {
+					vecs = append(vecs, nil)
+				}
+				vecs[cv.Index] = cv.Vector
+			}
+			if err := fn(h.ID, vecs); err != nil {
+				return err
+			}
+		}
+		if len(resp.Hits.Hits) < scanPageSize {
+			return nil
+		}
+		after = resp.Hits.Hits[len(resp.Hits.Hits)-1].Sort
+	}
+}
+
+func buildScanQuery(pit string, after []json.RawMessage) string {
+	searchAfter := ""
+	if len(after) > 0 {
+		b, _ := json.Marshal(after)
+		searchAfter = fmt.Sprintf(`
+	"search_after": %s,`, b)
+	}
+	return fmt.Sprintf(`{
+	"size": %d,
+	"pit": {"id": %q, "keep_alive": %q},
+	"sort": [{"_shard_doc": "asc"}],%s
+	"_source": ["chunk_vectors"],
+	"query": %s
+}`, scanPageSize, pit, pitKeepAlive, searchAfter, vectorDocsQuery)
+}
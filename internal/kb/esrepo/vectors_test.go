@@ -0,0 +1,259 @@
+package esrepo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gogogo1024/assist-fusion/internal/kb"
+)
+
+// stubES is a stand-in Elasticsearch: routes map "METHOD /path" to a status and body, and
+// every request is recorded.
+type stubES struct {
+	mu     sync.Mutex
+	routes map[string]func(body string) (int, string)
+	calls  []string // "METHOD /path body"
+}
+
+func newStubES(t *testing.T, cfg Config, routes map[string]func(body string) (int, string)) (*Repo, *stubES) {
+	t.Helper()
+	stub := &stubES{routes: routes}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		b, _ := io.ReadAll(req.Body)
+		key := req.Method + " " + req.URL.Path
+		stub.mu.Lock()
+		stub.calls = append(stub.calls, key+" "+string(b))
+		route := stub.routes[key]
+		stub.mu.Unlock()
+		// the client refuses to talk to servers that do not identify as Elasticsearch
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		w.Header().Set("Content-Type", "application/json")
+		if route == nil {
+			w.WriteHeader(http.StatusNotFound)
+			io.WriteString(w, `{"error": "no route `+key+`"}`)
+			return
+		}
+		status, out := route(string(b))
+		w.WriteHeader(status)
+		io.WriteString(w, out)
+	}))
+	t.Cleanup(srv.Close)
+	cfg.Addresses = []string{srv.URL}
+	r, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r, stub
+}
+
+// requests returns the recorded bodies of calls to key ("METHOD /path").
+func (s *stubES) requests(key string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var out []string
+	for _, c := range s.calls {
+		if strings.HasPrefix(c, key+" ") {
+			out = append(out, strings.TrimPrefix(c, key+" "))
+		}
+	}
+	return out
+}
+
+func reply(status int, body string) func(string) (int, string) {
+	return func(string) (int, string) { return status, body }
+}
+
+// existingIndex routes an existing kb_docs index whose chunk_vectors.vector mapping is
+// field ("" while unmapped).
+func existingIndex(field string) map[string]func(string) (int, string) {
+	mapping := `{"kb_docs": {"mappings": {}}}`
+	if field != "" {
+		mapping = `{"kb_docs": {"mappings": {"chunk_vectors.vector": {"full_name": "chunk_vectors.vector", "mapping": {"vector": ` + field + `}}}}}`
+	}
+	return map[string]func(string) (int, string){
+		"HEAD /kb_docs":         reply(200, ``),
+		"GET /kb_docs":          reply(200, `{}`),
+		"PUT /kb_docs/_mapping": reply(200, `{"acknowledged": true}`),
+		"GET /kb_docs/_mapping/field/chunk_vectors.vector": reply(200, mapping),
+		"POST /kb_docs/_update/d1":                         reply(200, `{"result": "updated"}`),
+	}
+}
+
+func TestEnsureIndexMapsVectors(t *testing.T) {
+	creates := 0
+	r, stub := newStubES(t, Config{VectorDims: 4, VectorSimilarity: SimilarityDotProduct}, map[string]func(string) (int, string){
+		"HEAD /kb_docs": func(string) (int, string) {
+			if creates < 2 {
+				return 404, ``
+			}
+			return 200, ``
+		},
+		"PUT /kb_docs": func(string) (int, string) {
+			// the IK analyzers are missing; the n-gram fallback succeeds
+			if creates++; creates == 1 {
+				return 400, `{"error": {"type": "illegal_argument_exception", "reason": "unknown tokenizer [ik_max_word]"}}`
+			}
+			return 200, `{"acknowledged": true}`
+		},
+		"GET /kb_docs":             reply(200, `{}`),
+		"PUT /kb_docs/_mapping":    reply(200, `{"acknowledged": true}`),
+		"POST /kb_docs/_update/d1": reply(200, `{"result": "updated"}`),
+	})
+	ctx := context.Background()
+	if err := r.ensureIndex(ctx); err != nil {
+		t.Fatal(err)
+	}
+	bodies := stub.requests("PUT /kb_docs")
+	if len(bodies) != 2 {
+		t.Fatalf("create requests = %d", len(bodies))
+	}
+	for i, body := range bodies {
+		var v map[string]any
+		if err := json.Unmarshal([]byte(body), &v); err != nil {
+			t.Fatalf("create body %d is not JSON: %v\n%s", i, err, body)
+		}
+		if !strings.Contains(body, `"dense_vector", "dims": 4, "index": true, "similarity": "dot_product"`) {
+			t.Fatalf("create body %d lacks the vector mapping:\n%s", i, body)
+		}
+	}
+	if !strings.Contains(bodies[0], "ik_max_word") || !strings.Contains(bodies[1], "cn_ngram3") {
+		t.Fatal("expected the IK body, then the n-gram body")
+	}
+
+	// the index just created fixes the dims: no mapping lookups, mismatches never reach ES
+	if err := r.PutVectors(ctx, "d1", [][]float64{{1, 2, 3}}); !errors.Is(err, kb.ErrDimMismatch) {
+		t.Fatalf("3-d put = %v", err)
+	}
+	if err := r.PutVectors(ctx, "d1", [][]float64{{1, 0, 0, 0}, {1, 2}}); !errors.Is(err, kb.ErrDimMismatch) {
+		t.Fatalf("mixed put = %v", err)
+	}
+	if n := len(stub.requests("POST /kb_docs/_update/d1")); n != 0 {
+		t.Fatalf("rejected puts reached ES %d times", n)
+	}
+	if n := len(stub.requests("GET /kb_docs/_mapping/field/chunk_vectors.vector")); n != 0 {
+		t.Fatalf("mapping looked up %d times", n)
+	}
+	if err := r.PutVectors(ctx, "d1", [][]float64{{3, 0, 0, 4}, nil}); err != nil {
+		t.Fatal(err)
+	}
+	// dot_product needs unit vectors
+	if body := stub.requests("POST /kb_docs/_update/d1"); len(body) != 1 || !strings.Contains(body[0], `{"index":0,"vector":[0.6,0,0,0.8]}`) {
+		t.Fatalf("update = %v", body)
+	}
+}
+
+func TestPutVectorsMapping(t *testing.T) {
+	ctx := context.Background()
+	// an index mapped with other dims rejects the write
+	r, stub := newStubES(t, Config{}, existingIndex(`{"type": "dense_vector", "dims": 3, "index": true, "similarity": "cosine"}`))
+	if err := r.PutVectors(ctx, "d1", [][]float64{{1, 2, 3, 4}}); !errors.Is(err, kb.ErrDimMismatch) {
+		t.Fatalf("put = %v", err)
+	}
+	if n := len(stub.requests("POST /kb_docs/_update/d1")); n != 0 {
+		t.Fatal("mismatched vectors were written")
+	}
+	// an unmapped field is mapped with the dims of the first write
+	r, stub = newStubES(t, Config{}, existingIndex(""))
+	if err := r.PutVectors(ctx, "d1", [][]float64{{1, 2, 3, 4}}); err != nil {
+		t.Fatal(err)
+	}
+	var mapped bool
+	for _, body := range stub.requests("PUT /kb_docs/_mapping") {
+		mapped = mapped || strings.Contains(body, `"dims": 4, "index": true, "similarity": "cosine"`)
+	}
+	if !mapped {
+		t.Fatalf("mappings = %v", stub.requests("PUT /kb_docs/_mapping"))
+	}
+	if err := r.PutVectors(ctx, "d1", [][]float64{{1, 2}}); !errors.Is(err, kb.ErrDimMismatch) {
+		t.Fatalf("second put = %v", err)
+	}
+}
+
+func TestKNNSearch(t *testing.T) {
+	routes := existingIndex(`{"type": "dense_vector", "dims": 3, "index": true, "similarity": "cosine"}`)
+	routes["POST /kb_docs/_search"] = reply(200, `{"hits": {"total": {"value": 1}, "hits": [{
+		"_id": "d1", "_score": 0.9,
+		"_source": {"title": "VPN", "content": "intro", "tags": {"product": "vpn"},
+			"chunks": [{"index": 0, "text": "intro"}, {"index": 1, "heading": ["Install"], "text": "reset the profile"}]},
+		"inner_hits": {"chunk_vectors": {"hits": {"hits": [{"_source": {"index": 1}}]}}}
+	}]}}`)
+	routes["POST /kb_docs/_count"] = reply(200, `{"count": 7}`)
+	r, stub := newStubES(t, Config{}, routes)
+	ctx := context.Background()
+
+	items, err := r.KNNSearch(ctx, kb.KNNOptions{Vector: []float64{1, 0, 0}, K: 5, Tags: []string{"product:vpn"}})
+	if err != nil || len(items) != 1 {
+		t.Fatalf("knn = %v, %v", items, err)
+	}
+	it := items[0]
+	if it.ID != "d1" || it.Chunk != 1 || strings.Join(it.Heading, ">") != "Install" || it.Snippet != "reset the profile" || it.Score < 0.799 || it.Score > 0.801 {
+		t.Fatalf("item = %+v", it)
+	}
+	body := stub.requests("POST /kb_docs/_search")[0]
+	var v map[string]any
+	if err := json.Unmarshal([]byte(body), &v); err != nil {
+		t.Fatalf("knn body is not JSON: %v\n%s", err, body)
+	}
+	for _, w := range []string{`"field": "chunk_vectors.vector"`, `"query_vector": [1,0,0]`, `"k": 5`, `"num_candidates": 50`, `{"term": {"tag_terms": "product:vpn"}}`, `"inner_hits"`} {
+		if !strings.Contains(body, w) {
+			t.Fatalf("knn body missing %s:\n%s", w, body)
+		}
+	}
+	if strings.Contains(body, `"query":`) {
+		t.Fatalf("pure knn body has a query:\n%s", body)
+	}
+
+	// combined with the lexical query in one request; scores stay as ES combined them
+	items, err = r.KNNSearch(ctx, kb.KNNOptions{Vector: []float64{1, 0, 0}, K: 5, Query: "reset", QueryBoost: 0.5, VectorBoost: 2})
+	if err != nil || len(items) != 1 || items[0].Score != 0.9 {
+		t.Fatalf("combined = %v, %v", items, err)
+	}
+	body = stub.requests("POST /kb_docs/_search")[1]
+	if err := json.Unmarshal([]byte(body), &v); err != nil {
+		t.Fatalf("combined body is not JSON: %v\n%s", err, body)
+	}
+	for _, w := range []string{`"multi_match": {"query": "reset"`, `"boost": 0.5`, `"boost": 2,`, `"knn"`} {
+		if !strings.Contains(body, w) {
+			t.Fatalf("combined body missing %s:\n%s", w, body)
+		}
+	}
+
+	if _, err := r.KNNSearch(ctx, kb.KNNOptions{Vector: []float64{1, 0}, K: 5}); !errors.Is(err, kb.ErrDimMismatch) {
+		t.Fatalf("2-d query = %v", err)
+	}
+	if n, err := r.CountVectors(ctx); err != nil || n != 7 {
+		t.Fatalf("count = %d, %v", n, err)
+	}
+}
+
+func TestKNNSearchUnsupported(t *testing.T) {
+	ctx := context.Background()
+	opts := kb.KNNOptions{Vector: []float64{1, 0, 0}, K: 5}
+	// vectors stored before kNN support are not indexed
+	r, _ := newStubES(t, Config{}, existingIndex(`{"type": "dense_vector", "dims": 3, "index": false}`))
+	if _, err := r.KNNSearch(ctx, opts); !errors.Is(err, kb.ErrKNNUnsupported) {
+		t.Fatalf("unindexed = %v", err)
+	}
+	// clusters without nested kNN reject the query
+	routes := existingIndex(`{"type": "dense_vector", "dims": 3}`)
+	routes["POST /kb_docs/_search"] = reply(400, `{"error": {"type": "parsing_exception", "reason": "[knn] unknown field [inner_hits]"}}`)
+	r, _ = newStubES(t, Config{}, routes)
+	if _, err := r.KNNSearch(ctx, opts); !errors.Is(err, kb.ErrKNNUnsupported) {
+		t.Fatalf("rejected = %v", err)
+	}
+	// nothing mapped yet: no hits
+	r, _ = newStubES(t, Config{}, existingIndex(""))
+	if items, err := r.KNNSearch(ctx, opts); err != nil || len(items) != 0 {
+		t.Fatalf("unmapped = %v, %v", items, err)
+	}
+	if _, err := New(Config{VectorSimilarity: "hamming"}); err == nil {
+		t.Fatal("unknown similarity accepted")
+	}
+}
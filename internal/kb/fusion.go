@@ -0,0 +1,105 @@
+package kb
+
+import "sort"
+
+// Fusion methods for combining a lexical and a vector ranking. FusionNative leaves the
+// combination to a KNNRepo, which scores both in one request; Fuse treats it as weighted.
+const (
+	FusionRRF      = "rrf"
+	FusionWeighted = "weighted"
+	FusionNative   = "native"
+)
+
+// DefaultRRFK is the rank constant k of reciprocal rank fusion; larger values flatten
+// the advantage of top ranks.
+const DefaultRRFK = 60
+
+// FusionOptions tunes Fuse. RRF scores a document sum(w / (k + rank)) over the rankings
+// that returned it. Weighted min-max normalizes each ranking's scores to [0, 1] and takes
+// their weighted mean, a missing document counting 0.
+type FusionOptions struct {
+	Method        string
+	LexicalWeight float64
+	VectorWeight  float64
+	RRFK          int
+}
+
+// DefaultFusion is RRF with equal weights.
+func DefaultFusion() FusionOptions {
+	return FusionOptions{Method: FusionRRF, LexicalWeight: 1, VectorWeight: 1, RRFK: DefaultRRFK}
+}
+
+// Fuse merges two rankings (best first) into one, best first with ties broken by id.
+// Every result records its component scores and ranks; snippet, heading and chunk come
+// from the lexical item when both rankings returned the document.
+func Fuse(lexical, vector []*Item, opts FusionOptions) []*Item {
+	if opts.RRFK <= 0 {
+		opts.RRFK = DefaultRRFK
+	}
+	byID := make(map[string]*Item, len(lexical)+len(vector))
+	var out []*Item
+	add := func(it *Item, rank int, isLexical bool) {
+		f, ok := byID[it.ID]
+		if !ok {
+			cp := *it
+			cp.Cursor = ""
+			f = &cp
+			byID[it.ID] = f
+			out = append(out, f)
+		}
+		if isLexical {
+			f.LexicalScore, f.LexicalRank = it.Score, rank
+		} else {
+			f.VectorScore, f.VectorRank = it.Score, rank
+		}
+	}
+	for i, it := range lexical {
+		add(it, i+1, true)
+	}
+	for i, it := range vector {
+		add(it, i+1, false)
+	}
+	lexNorm, vecNorm := minMax(lexical), minMax(vector)
+	wsum := opts.LexicalWeight + opts.VectorWeight
+	for _, f := range out {
+		score := 0.0
+		switch opts.Method {
+		case FusionWeighted, FusionNative:
+			if f.LexicalRank > 0 {
+				score += opts.LexicalWeight * lexNorm(f.LexicalScore)
+			}
+			if f.VectorRank > 0 {
+				score += opts.VectorWeight * vecNorm(f.VectorScore)
+			}
+			if wsum > 0 {
+				score /= wsum
+			}
+		default:
+			if f.LexicalRank > 0 {
+				score += opts.LexicalWeight / float64(opts.RRFK+f.LexicalRank)
+			}
+			if f.VectorRank > 0 {
+				score += opts.VectorWeight / float64(opts.RRFK+f.VectorRank)
+			}
+		}
+		f.Score = score
+	}
+	sort.Slice(out, func(i, j int) bool { return itemBefore(out[i].Score, out[i].ID, out[j].Score, out[j].ID) })
+	return out
+}
+
+// minMax returns the min-max normalization of items' scores; a ranking whose scores are
+// all equal normalizes to 1.
+func minMax(items []*Item) func(float64) float64 {
+	if len(items) == 0 {
+		return func(float64) float64 { return 0 }
+	}
+	lo, hi := items[0].Score, items[0].Score
+	for _, it := range items[1:] {
+		lo, hi = min(lo, it.Score), max(hi, it.Score)
+	}
+	if hi == lo {
+		return func(float64) float64 { return 1 }
+	}
+	return func(s float64) float64 { return (s - lo) / (hi - lo) }
+}
@@ -0,0 +1,67 @@
+package kb
+
+import (
+	"strings"
+	"testing"
+)
+
+func ranking(ids string, scores ...float64) []*Item {
+	var out []*Item
+	for i, id := range strings.Split(ids, ",") {
+		out = append(out, &Item{ID: id, Score: scores[i], Snippet: "from " + id})
+	}
+	return out
+}
+
+func fusedIDs(items []*Item) string {
+	ids := make([]string, len(items))
+	for i, it := range items {
+		ids[i] = it.ID
+	}
+	return strings.Join(ids, ",")
+}
+
+func TestFuseRRF(t *testing.T) {
+	lexical := ranking("a,b,c", 9, 5, 1)
+	vector := ranking("c,d,a", 0.9, 0.8, 0.1)
+	got := Fuse(lexical, vector, DefaultFusion())
+	// a: 1/61+1/63, c: 1/63+1/61 tie -> id order; then b (1/62) before d (1/62) by id
+	if ids := fusedIDs(got); ids != "a,c,b,d" {
+		t.Fatalf("rrf order = %s", ids)
+	}
+	if a := got[0]; a.LexicalRank != 1 || a.VectorRank != 3 || a.LexicalScore != 9 || a.VectorScore != 0.1 {
+		t.Fatalf("components of a = %+v", a)
+	}
+	if d := got[3]; d.LexicalRank != 0 || d.VectorRank != 2 {
+		t.Fatalf("components of d = %+v", d)
+	}
+	// vector-heavy weights lift the vector ranking
+	got = Fuse(lexical, vector, FusionOptions{Method: FusionRRF, LexicalWeight: 0.1, VectorWeight: 1})
+	if ids := fusedIDs(got); ids != "c,a,d,b" {
+		t.Fatalf("weighted rrf order = %s", ids)
+	}
+	if lexical[0].LexicalRank != 0 {
+		t.Fatal("Fuse modified its input")
+	}
+}
+
+func TestFuseWeighted(t *testing.T) {
+	lexical := ranking("a,b,c", 10, 6, 2)
+	vector := ranking("b,c", 0.9, 0.5)
+	got := Fuse(lexical, vector, FusionOptions{Method: FusionWeighted, LexicalWeight: 1, VectorWeight: 1})
+	// a: (1+0)/2, b: (0.5+1)/2, c: (0+0)/2
+	if ids := fusedIDs(got); ids != "b,a,c" {
+		t.Fatalf("weighted order = %s", ids)
+	}
+	if got[0].Score != 0.75 || got[1].Score != 0.5 || got[2].Score != 0 {
+		t.Fatalf("weighted scores = %v %v %v", got[0].Score, got[1].Score, got[2].Score)
+	}
+	if got[0].Snippet != "from b" {
+		t.Fatalf("lexical item should supply the snippet: %+v", got[0])
+	}
+	// a single hit (or a flat ranking) normalizes to 1
+	got = Fuse(nil, ranking("x", 0.3), FusionOptions{Method: FusionWeighted, LexicalWeight: 1, VectorWeight: 3})
+	if got[0].Score != 0.75 {
+		t.Fatalf("single vector hit score = %v", got[0].Score)
+	}
+}
@@ -0,0 +1,435 @@
+package kb
+
+import (
+	"container/heap"
+	"math"
+	"math/rand"
+	"sort"
+	"sync"
+)
+
+// HNSWConfig tunes a hierarchical navigable small world graph (Malkov & Yashunin): M is
+// the number of links a node keeps per layer (2*M on the bottom layer), EfConstruction
+// the candidate list size while inserting and EfSearch the one while querying. Larger
+// values raise recall at the cost of memory and latency.
+type HNSWConfig struct {
+	M              int
+	EfConstruction int
+	EfSearch       int
+}
+
+// DefaultHNSWConfig suits corpora of up to a few hundred thousand chunks.
+func DefaultHNSWConfig() HNSWConfig {
+	return HNSWConfig{M: 16, EfConstruction: 200, EfSearch: 64}
+}
+
+func (c HNSWConfig) withDefaults() HNSWConfig {
+	d := DefaultHNSWConfig()
+	if c.M < 2 {
+		c.M = d.M
+	}
+	if c.EfConstruction < c.M {
+		c.EfConstruction = max(d.EfConstruction, c.M)
+	}
+	if c.EfSearch <= 0 {
+		c.EfSearch = d.EfSearch
+	}
+	return c
+}
+
+// hnswNode is one chunk vector in the graph. Deleted nodes stay as waypoints until the
+// graph is compacted but are never returned.
+type hnswNode struct {
+	doc     string
+	chunk   int
+	vec     []float32
+	links   [][]int32 // per layer, 0 is the bottom
+	deleted bool
+}
+
+// hnsw is the graph. Writers must be serialized by the caller (VectorIndex.writeMu): an
+// insert plans its links under the read lock, so searches run concurrently, and only
+// takes the write lock to commit them.
+type hnsw struct {
+	cfg HNSWConfig
+	ml  float64
+	rng *rand.Rand // writers only
+
+	mu       sync.RWMutex
+	nodes    []*hnswNode
+	entry    int32 // -1 while empty
+	maxLevel int
+	deleted  int
+
+	visited sync.Pool
+}
+
+func newHNSW(cfg HNSWConfig) *hnsw {
+	cfg = cfg.withDefaults()
+	return &hnsw{cfg: cfg, ml: 1 / math.Log(float64(cfg.M)), rng: rand.New(rand.NewSource(1)), entry: -1}
+}
+
+func (g *hnsw) maxConn(layer int) int {
+	if layer == 0 {
+		return 2 * g.cfg.M
+	}
+	return g.cfg.M
+}
+
+func (g *hnsw) randomLevel() int {
+	return int(math.Floor(-math.Log(1-g.rng.Float64()) * g.ml))
+}
+
+// candidate is a node and its similarity to the query.
+type candidate struct {
+	id  int32
+	sim float64
+}
+
+// nearHeap pops the most similar candidate, farHeap the least similar.
+type nearHeap []candidate
+type farHeap []candidate
+
+func (h nearHeap) Len() int           { return len(h) }
+func (h nearHeap) Less(i, j int) bool { return h[i].sim > h[j].sim }
+func (h nearHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *nearHeap) Push(x any)        { *h = append(*h, x.(candidate)) }
+func (h *nearHeap) Pop() any          { old := *h; x := old[len(old)-1]; *h = old[:len(old)-1]; return x }
+
+func (h farHeap) Len() int           { return len(h) }
+func (h farHeap) Less(i, j int) bool { return h[i].sim < h[j].sim }
+func (h farHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *farHeap) Push(x any)        { *h = append(*h, x.(candidate)) }
+func (h *farHeap) Pop() any          { old := *h; x := old[len(old)-1]; *h = old[:len(old)-1]; return x }
+
+// visitSet marks visited nodes with an epoch so it can be reused without clearing.
+type visitSet struct {
+	marks []uint32
+	epoch uint32
+}
+
+func (g *hnsw) visitSet() *visitSet {
+	v, _ := g.visited.Get().(*visitSet)
+	if v == nil {
+		v = &visitSet{}
+	}
+	if len(v.marks) < len(g.nodes) {
+		v.marks = make([]uint32, len(g.nodes)+len(g.nodes)/4+16)
+		v.epoch = 0
+	}
+	v.epoch++
+	if v.epoch == 0 {
+		clear(v.marks)
+		v.epoch = 1
+	}
+	return v
+}
+
+// visit marks id and reports whether it was unvisited.
+func (v *visitSet) visit(id int32) bool {
+	if v.marks[id] == v.epoch {
+		return false
+	}
+	v.marks[id] = v.epoch
+	return true
+}
+
+// searchLayer is the greedy beam search of one layer from the entry points eps, keeping
+// the ef most similar nodes; live drops deleted nodes from the result (they are still
+// traversed). The result is sorted most similar first. Callers hold g.mu.
+func (g *hnsw) searchLayer(q []float32, eps []candidate, ef, layer int, live bool) []candidate {
+	vs := g.visitSet()
+	defer g.visited.Put(vs)
+	cands := make(nearHeap, 0, ef)
+	results := make(farHeap, 0, ef+1)
+	for _, ep := range eps {
+		vs.visit(ep.id)
+		cands = append(cands, ep)
+		if !live || !g.nodes[ep.id].deleted {
+			results = append(results, ep)
+		}
+	}
+	heap.Init(&cands)
+	heap.Init(&results)
+	for len(results) > ef {
+		heap.Pop(&results)
+	}
+	for cands.Len() > 0 {
+		c := heap.Pop(&cands).(candidate)
+		if results.Len() >= ef && c.sim < results[0].sim {
+			break
+		}
+		n := g.nodes[c.id]
+		if layer >= len(n.links) {
+			continue
+		}
+		for _, e := range n.links[layer] {
+			if !vs.visit(e) {
+				continue
+			}
+			s := dot(q, g.nodes[e].vec)
+			if results.Len() < ef || s > results[0].sim {
+				heap.Push(&cands, candidate{id: e, sim: s})
+				if !live || !g.nodes[e].deleted {
+					heap.Push(&results, candidate{id: e, sim: s})
+					if results.Len() > ef {
+						heap.Pop(&results)
+					}
+				}
+			}
+		}
+	}
+	out := []candidate(results)
+	sort.Slice(out, func(i, j int) bool { return out[i].sim > out[j].sim })
+	return out
+}
+
+// selectNeighbors keeps up to m of cands (sorted most similar first) with the HNSW
+// heuristic: a candidate closer to an already selected neighbour than to the base node is
+// skipped, which keeps links spread over directions. Skipped ones fill remaining slots.
+func selectNeighbors(cands []candidate, m int, vecOf func(int32) []float32) []int32 {
+	out := make([]int32, 0, m)
+	var skipped []int32
+	for _, c := range cands {
+		if len(out) == m {
+			break
+		}
+		good := true
+		for _, s := range out {
+			if dot(vecOf(c.id), vecOf(s)) > c.sim {
+				good = false
+				break
+			}
+		}
+		if good {
+			out = append(out, c.id)
+		} else {
+			skipped = append(skipped, c.id)
+		}
+	}
+	for _, id := range skipped {
+		if len(out) == m {
+			break
+		}
+		out = append(out, id)
+	}
+	return out
+}
+
+func (g *hnsw) vecOf(id int32) []float32 { return g.nodes[id].vec }
+
+// descend walks the layers above level greedily and returns the entry point for level.
+func (g *hnsw) descend(q []float32, level int) []candidate {
+	ep := []candidate{{id: g.entry, sim: dot(q, g.nodes[g.entry].vec)}}
+	for l := g.maxLevel; l > level; l-- {
+		if next := g.searchLayer(q, ep, 1, l, false); len(next) > 0 {
+			ep = next[:1]
+		}
+	}
+	return ep
+}
+
+// linkUpdate is a neighbour's new link list on one layer.
+type linkUpdate struct {
+	id    int32
+	layer int
+	links []int32
+}
+
+// insert adds a unit vector for chunk of doc and returns its node id.
+func (g *hnsw) insert(doc string, chunk int, vec []float32) int32 {
+	level := g.randomLevel()
+	node := &hnswNode{doc: doc, chunk: chunk, vec: vec, links: make([][]int32, level+1)}
+
+	// plan under the read lock: writers are serialized, so the graph cannot change until commit
+	g.mu.RLock()
+	id := int32(len(g.nodes))
+	var updates []linkUpdate
+	if g.entry >= 0 {
+		ep := g.descend(vec, level)
+		for l := min(level, g.maxLevel); l >= 0; l-- {
+			found := g.searchLayer(vec, ep, g.cfg.EfConstruction, l, true)
+			if len(found) == 0 {
+				continue
+			}
+			node.links[l] = selectNeighbors(found, g.cfg.M, g.vecOf)
+			for _, n := range node.links[l] {
+				updates = append(updates, linkUpdate{id: n, layer: l, links: g.addLink(n, l, id, vec)})
+			}
+			ep = found
+		}
+	}
+	g.mu.RUnlock()
+
+	g.mu.Lock()
+	g.nodes = append(g.nodes, node)
+	for _, u := range updates {
+		g.nodes[u.id].links[u.layer] = u.links
+	}
+	if g.entry < 0 || level > g.maxLevel {
+		g.entry, g.maxLevel = id, level
+	}
+	g.mu.Unlock()
+	return id
+}
+
+// addLink returns n's links on layer with the new node (id, vec) added. A full list is
+// pruned back to maxConn with the selection heuristic, dropping deleted neighbours first.
+func (g *hnsw) addLink(n int32, layer int, id int32, vec []float32) []int32 {
+	base := g.nodes[n]
+	cur := base.links[layer]
+	if len(cur) < g.maxConn(layer) {
+		return append(append(make([]int32, 0, len(cur)+1), cur...), id)
+	}
+	cands := []candidate{{id: id, sim: dot(base.vec, vec)}}
+	for _, e := range cur {
+		if !g.nodes[e].deleted {
+			cands = append(cands, candidate{id: e, sim: dot(base.vec, g.nodes[e].vec)})
+		}
+	}
+	sort.Slice(cands, func(i, j int) bool { return cands[i].sim > cands[j].sim })
+	// the new node is not in g.nodes until commit
+	return selectNeighbors(cands, g.maxConn(layer), func(e int32) []float32 {
+		if e == id {
+			return vec
+		}
+		return g.nodes[e].vec
+	})
+}
+
+// remove tombstones nodes.
+func (g *hnsw) remove(ids []int32) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for _, id := range ids {
+		if n := g.nodes[id]; !n.deleted {
+			n.deleted = true
+			g.deleted++
+		}
+	}
+}
+
+// needsCompaction reports a graph that is mostly tombstones.
+func (g *hnsw) needsCompaction() bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return len(g.nodes) >= 64 && g.deleted*2 > len(g.nodes)
+}
+
+// graphHit is a live node returned by search.
+type graphHit struct {
+	doc   string
+	chunk int
+	sim   float64
+}
+
+// search returns up to ef live nodes most similar to q, best first, and whether the
+// search may have missed live nodes (it filled ef).
+func (g *hnsw) search(q []float32, ef int) ([]graphHit, bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	if g.entry < 0 {
+		return nil, false
+	}
+	found := g.searchLayer(q, g.descend(q, 0), ef, 0, true)
+	out := make([]graphHit, len(found))
+	for i, c := range found {
+		n := g.nodes[c.id]
+		out[i] = graphHit{doc: n.doc, chunk: n.chunk, sim: c.sim}
+	}
+	return out, len(found) >= ef && len(g.nodes)-g.deleted > ef
+}
+
+// hnswSnapshot is the serialized graph. Vectors of live nodes are restored from the
+// index's documents; deleted nodes carry their own.
+type hnswSnapshot struct {
+	M, EfConstruction int
+	Entry             int32
+	MaxLevel          int
+	Nodes             []hnswNodeSnapshot
+}
+
+type hnswNodeSnapshot struct {
+	Doc     string
+	Chunk   int
+	Links   [][]int32
+	Deleted bool
+	Vec     []float32
+}
+
+func (g *hnsw) snapshot() *hnswSnapshot {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	s := &hnswSnapshot{M: g.cfg.M, EfConstruction: g.cfg.EfConstruction, Entry: g.entry, MaxLevel: g.maxLevel, Nodes: make([]hnswNodeSnapshot, len(g.nodes))}
+	for i, n := range g.nodes {
+		s.Nodes[i] = hnswNodeSnapshot{Doc: n.doc, Chunk: n.chunk, Links: append([][]int32(nil), n.links...), Deleted: n.deleted}
+		if n.deleted {
+			s.Nodes[i].Vec = n.vec
+		}
+	}
+	return s
+}
+
+// restoreHNSW rebuilds a graph from s and the live vectors in docs. It returns nil when s
+// was built with other parameters, does not match docs or is not a consistent graph (entry
+// point, top level or a link out of range), so the caller re-inserts.
+func restoreHNSW(cfg HNSWConfig, s *hnswSnapshot, docs map[string][][]float32) (*hnsw, map[string][]int32) {
+	g := newHNSW(cfg)
+	if s == nil || s.M != g.cfg.M || s.EfConstruction != g.cfg.EfConstruction {
+		return nil, nil
+	}
+	nodes := make(map[string][]int32, len(docs))
+	g.nodes = make([]*hnswNode, len(s.Nodes))
+	for i, ns := range s.Nodes {
+		n := &hnswNode{doc: ns.Doc, chunk: ns.Chunk, links: ns.Links, deleted: ns.Deleted, vec: ns.Vec}
+		if len(ns.Links) == 0 || len(ns.Links) > s.MaxLevel+1 || ns.Chunk < 0 {
+			return nil, nil
+		}
+		if !ns.Deleted {
+			vecs := docs[ns.Doc]
+			if ns.Chunk >= len(vecs) || vecs[ns.Chunk] == nil {
+				return nil, nil
+			}
+			n.vec = vecs[ns.Chunk]
+			nodes[ns.Doc] = append(nodes[ns.Doc], int32(i))
+		} else {
+			if ns.Vec == nil {
+				return nil, nil
+			}
+			g.deleted++
+		}
+		g.nodes[i] = n
+	}
+	// a link on layer l leads to a node that has layer l
+	for _, n := range g.nodes {
+		for l, links := range n.links {
+			for _, e := range links {
+				if e < 0 || int(e) >= len(g.nodes) || len(g.nodes[e].links) <= l {
+					return nil, nil
+				}
+			}
+		}
+	}
+	// the entry point is the node on the top level, or -1 for an empty graph
+	switch {
+	case s.Entry == -1 && len(g.nodes) != 0,
+		s.Entry < -1 || s.Entry >= int32(len(g.nodes)),
+		s.Entry >= 0 && len(g.nodes[s.Entry].links) != s.MaxLevel+1:
+		return nil, nil
+	}
+	g.entry, g.maxLevel = s.Entry, s.MaxLevel
+	// every stored chunk vector must be in the graph
+	for id, vecs := range docs {
+		want := 0
+		for _, v := range vecs {
+			if v != nil {
+				want++
+			}
+		}
+		if len(nodes[id]) != want {
+			return nil, nil
+		}
+	}
+	g.rng = rand.New(rand.NewSource(int64(len(g.nodes)) + 1))
+	return g, nodes
+}
@@ -0,0 +1,250 @@
+package kb
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+	"testing"
+)
+
+func randomVectors(rng *rand.Rand, n, dim int) [][]float64 {
+	out := make([][]float64, n)
+	for i := range out {
+		v := make([]float64, dim)
+		for j := range v {
+			v[j] = rng.NormFloat64()
+		}
+		out[i] = v
+	}
+	return out
+}
+
+// fillIndexes puts the same n single-chunk documents into an exact and an HNSW index.
+func fillIndexes(tb testing.TB, n, dim int, cfg HNSWConfig) (exact, approx *VectorIndex) {
+	tb.Helper()
+	exact, approx = NewVectorIndex(), NewHNSWVectorIndex(cfg)
+	for i, v := range randomVectors(rand.New(rand.NewSource(7)), n, dim) {
+		id := fmt.Sprintf("d%05d", i)
+		if err := exact.Put(id, [][]float64{v}); err != nil {
+			tb.Fatal(err)
+		}
+		if err := approx.Put(id, [][]float64{v}); err != nil {
+			tb.Fatal(err)
+		}
+	}
+	return exact, approx
+}
+
+// recall is the share of the exact top k that approx also returns, over queries.
+func recall(exact, approx *VectorIndex, queries [][]float64, k int) float64 {
+	found, total := 0, 0
+	for _, q := range queries {
+		want := map[string]bool{}
+		for _, h := range exact.Search(q, k, nil) {
+			want[h.ID] = true
+		}
+		for _, h := range approx.Search(q, k, nil) {
+			if want[h.ID] {
+				found++
+			}
+		}
+		total += len(want)
+	}
+	return float64(found) / float64(total)
+}
+
+func TestHNSWRecall(t *testing.T) {
+	exact, approx := fillIndexes(t, 3000, 32, DefaultHNSWConfig())
+	queries := randomVectors(rand.New(rand.NewSource(8)), 100, 32)
+	if r := recall(exact, approx, queries, 10); r < 0.95 {
+		t.Fatalf("recall@10 = %.3f", r)
+	}
+}
+
+func TestHNSWDeleteAndUpdate(t *testing.T) {
+	vi := NewHNSWVectorIndex(HNSWConfig{M: 4, EfConstruction: 16, EfSearch: 8})
+	vecs := randomVectors(rand.New(rand.NewSource(1)), 200, 8)
+	for i, v := range vecs {
+		if err := vi.Put(fmt.Sprintf("d%03d", i), [][]float64{v}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if hits := vi.Search(vecs[5], 1, nil); len(hits) != 1 || hits[0].ID != "d005" || hits[0].Score < 0.999 {
+		t.Fatalf("hits = %+v", hits)
+	}
+	vi.Delete("d005")
+	if hits := vi.Search(vecs[5], 3, nil); len(hits) != 3 || hits[0].ID == "d005" {
+		t.Fatalf("deleted doc returned: %+v", hits)
+	}
+	// an update replaces the chunks: the old vector no longer matches, the new ones do
+	if err := vi.Put("d006", [][]float64{nil, vecs[5]}); err != nil {
+		t.Fatal(err)
+	}
+	if hits := vi.Search(vecs[5], 1, nil); len(hits) != 1 || hits[0].ID != "d006" || hits[0].Chunk != 1 {
+		t.Fatalf("updated hits = %+v", hits)
+	}
+	if hits := vi.Search(vecs[6], 1, nil); len(hits) == 1 && hits[0].ID == "d006" && hits[0].Score > 0.999 {
+		t.Fatalf("stale vector returned: %+v", hits)
+	}
+	// a filter that rejects most documents still fills k from deeper candidates
+	hits := vi.Search(vecs[0], 5, func(id string) bool { return id >= "d150" })
+	if len(hits) != 5 {
+		t.Fatalf("filtered hits = %+v", hits)
+	}
+	for _, h := range hits {
+		if h.ID < "d150" {
+			t.Fatalf("filter ignored: %+v", hits)
+		}
+	}
+	// deleting most documents compacts the graph; the rest stay searchable
+	for i := 0; i < 180; i++ {
+		vi.Delete(fmt.Sprintf("d%03d", i))
+	}
+	if n := len(vi.graph.nodes); n >= 200 {
+		t.Fatalf("not compacted: %d nodes, %d deleted", n, vi.graph.deleted)
+	}
+	if hits := vi.Search(vecs[190], 1, nil); len(hits) != 1 || hits[0].ID != "d190" {
+		t.Fatalf("after compaction hits = %+v", hits)
+	}
+}
+
+func TestHNSWConcurrentSearch(t *testing.T) {
+	vi := NewHNSWVectorIndex(HNSWConfig{M: 8, EfConstruction: 32, EfSearch: 16})
+	vecs := randomVectors(rand.New(rand.NewSource(2)), 400, 16)
+	if err := vi.Put("seed", [][]float64{vecs[0]}); err != nil {
+		t.Fatal(err)
+	}
+	var wg sync.WaitGroup
+	for w := 0; w < 4; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := w; i < len(vecs); i += 4 {
+				if len(vi.Search(vecs[i], 5, nil)) == 0 {
+					t.Error("empty result while inserting")
+					return
+				}
+			}
+		}(w)
+	}
+	for i, v := range vecs {
+		if err := vi.Put(fmt.Sprintf("d%03d", i), [][]float64{v}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	wg.Wait()
+	if vi.Len() != len(vecs)+1 {
+		t.Fatalf("len = %d", vi.Len())
+	}
+}
+
+func TestHNSWSnapshot(t *testing.T) {
+	cfg := HNSWConfig{M: 6, EfConstruction: 24, EfSearch: 12}
+	newIndex := func() *VectorIndex { return NewHNSWVectorIndex(cfg) }
+	set := NewVectorSet(newIndex, nil)
+	vi, _ := set.ForTenant(context.Background())
+	vecs := randomVectors(rand.New(rand.NewSource(3)), 100, 8)
+	for i, v := range vecs {
+		if err := vi.Put(fmt.Sprintf("d%03d", i), [][]float64{v, vecs[(i+1)%len(vecs)]}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	vi.Delete("d010")
+	var buf bytes.Buffer
+	if err := set.WriteSnapshot(&buf); err != nil {
+		t.Fatal(err)
+	}
+	snap := buf.Bytes()
+
+	restored := NewVectorSet(newIndex, nil)
+	if err := restored.ReadSnapshot(bytes.NewReader(snap)); err != nil {
+		t.Fatal(err)
+	}
+	rvi, _ := restored.ForTenant(context.Background())
+	if len(rvi.graph.nodes) != len(vi.graph.nodes) || rvi.graph.deleted != vi.graph.deleted {
+		t.Fatalf("graph rebuilt instead of restored: %d/%d nodes", len(rvi.graph.nodes), len(vi.graph.nodes))
+	}
+	for i := 0; i < len(vecs); i += 7 {
+		want, got := vi.Search(vecs[i], 5, nil), rvi.Search(vecs[i], 5, nil)
+		if fmt.Sprint(want) != fmt.Sprint(got) {
+			t.Fatalf("query %d: %v, want %v", i, got, want)
+		}
+	}
+	// an inconsistent graph is rejected, which makes the caller rebuild it
+	for name, corrupt := range map[string]func(s *hnswSnapshot){
+		"entry below -1":     func(s *hnswSnapshot) { s.Entry = -2 },
+		"entry out of range": func(s *hnswSnapshot) { s.Entry = int32(len(s.Nodes)) },
+		"empty entry":        func(s *hnswSnapshot) { s.Entry = -1 },
+		"max level too high": func(s *hnswSnapshot) { s.MaxLevel++ },
+		"max level too low":  func(s *hnswSnapshot) { s.MaxLevel-- },
+		"link out of range": func(s *hnswSnapshot) {
+			s.Nodes[1].Links = [][]int32{{int32(len(s.Nodes))}}
+		},
+		"negative link": func(s *hnswSnapshot) { s.Nodes[1].Links = [][]int32{{-1}} },
+		"link above the neighbour's top layer": func(s *hnswSnapshot) {
+			top := s.Entry
+			for i, n := range s.Nodes {
+				if len(n.Links) == 1 && int32(i) != top {
+					s.Nodes[top].Links = append([][]int32{}, s.Nodes[top].Links...)
+					s.Nodes[top].Links[1] = []int32{int32(i)}
+					return
+				}
+			}
+		},
+	} {
+		bad := vi.graph.snapshot()
+		corrupt(bad)
+		if g, _ := restoreHNSW(cfg, bad, vi.docs); g != nil {
+			t.Fatalf("%s: corrupt snapshot restored", name)
+		}
+	}
+	// the graph is rebuilt (not restored) for other parameters
+	other := NewVectorSet(func() *VectorIndex { return NewHNSWVectorIndex(HNSWConfig{M: 10}) }, nil)
+	if err := other.ReadSnapshot(bytes.NewReader(snap)); err != nil {
+		t.Fatal(err)
+	}
+	ovi, _ := other.ForTenant(context.Background())
+	if ovi.graph.deleted != 0 || ovi.Len() != 99 {
+		t.Fatalf("rebuilt graph: %d deleted, %d docs", ovi.graph.deleted, ovi.Len())
+	}
+	// vecs[20] is chunk 0 of d020 and chunk 1 of d019
+	if hits := ovi.Search(vecs[20], 2, nil); len(hits) != 2 || hits[0].ID != "d019" || hits[1].ID != "d020" {
+		t.Fatalf("rebuilt hits = %+v", hits)
+	}
+}
+
+// BenchmarkVectorSearch compares the exact scan with HNSW at several efSearch values on
+// 20k random 64-d vectors (a hard case: real embeddings cluster). The recall@10 metric is
+// the share of the exact top 10 returned.
+func BenchmarkVectorSearch(b *testing.B) {
+	const k = 10
+	exact, approx := fillIndexes(b, 20000, 64, DefaultHNSWConfig())
+	queries := randomVectors(rand.New(rand.NewSource(9)), 200, 64)
+	run := func(name string, vi *VectorIndex) {
+		b.Run(name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				vi.Search(queries[i%len(queries)], k, nil)
+			}
+			b.StopTimer()
+			b.ReportMetric(recall(exact, vi, queries, k), "recall@10")
+		})
+	}
+	run("exact", exact)
+	for _, ef := range []int{32, 64, 128, 256} {
+		approx.hnsw.EfSearch = ef
+		run(fmt.Sprintf("hnsw/ef=%d", ef), approx)
+	}
+}
+
+func BenchmarkHNSWInsert(b *testing.B) {
+	vecs := randomVectors(rand.New(rand.NewSource(10)), b.N, 64)
+	vi := NewHNSWVectorIndex(DefaultHNSWConfig())
+	b.ResetTimer()
+	for i, v := range vecs {
+		if err := vi.Put(fmt.Sprintf("d%07d", i), [][]float64{v}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -2,24 +2,71 @@ package kb
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"math"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"unicode"
 )
 
 type Doc struct {
-	ID      string `json:"id"`
-	Title   string `json:"title"`
-	Content string `json:"content"`
+	ID        string            `json:"id"`
+	Title     string            `json:"title"`
+	Content   string            `json:"content"`
+	Tags      map[string]string `json:"tags,omitempty"`
+	CreatedAt int64             `json:"created_at,omitempty"`
+	UpdatedAt int64             `json:"updated_at,omitempty"`
+	// Chunks are the indexed slices of Content; repos index the whole content as one
+	// chunk when empty.
+	Chunks []Chunk `json:"chunks,omitempty"`
 }
 
 type Item struct {
-	ID      string  `json:"id"`
-	Title   string  `json:"title"`
-	Snippet string  `json:"snippet"`
-	Score   float64 `json:"score"`
+	ID      string            `json:"id"`
+	Title   string            `json:"title"`
+	Snippet string            `json:"snippet"`
+	Score   float64           `json:"score"`
+	Tags    map[string]string `json:"tags,omitempty"`
+	// Chunk is the index of the document's best matching chunk; Heading is its heading path.
+	Chunk   int      `json:"chunk"`
+	Heading []string `json:"heading,omitempty"`
+	// Highlights are the query matches in Snippet; empty when SnippetOptions sets tags.
+	Highlights []Span `json:"highlights,omitempty"`
+	// Cursor resumes a search right after this item; set only when SearchOptions asks for it.
+	Cursor string `json:"-"`
+	// Component scores and 1-based ranks of a fused search; a zero rank means that
+	// retriever did not return the document.
+	LexicalScore float64 `json:"lexical_score,omitempty"`
+	LexicalRank  int     `json:"lexical_rank,omitempty"`
+	VectorScore  float64 `json:"vector_score,omitempty"`
+	VectorRank   int     `json:"vector_rank,omitempty"`
+}
+
+// MaxOffsetWindow bounds Offset+Limit, matching Elasticsearch's default max_result_window.
+// Deeper pages need cursor pagination.
+const MaxOffsetWindow = 10000
+
+// ErrInvalidCursor reports a cursor the repo did not issue or can no longer resume.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// SearchOptions narrows a Search.
+// Tags filters by tag terms ("key" or "key:value"); every term must match unless AnyTag
+// is set. With a tag filter an empty query lists the tagged documents.
+// Offset skips hits for page-numbered pagination. Cursor asks for Item.Cursor on every
+// returned item; After resumes right after the item that carried that cursor and implies
+// Cursor (Offset is then ignored). Snippet shapes the returned snippets.
+type SearchOptions struct {
+	Limit   int
+	Offset  int
+	Tags    []string
+	AnyTag  bool
+	Cursor  bool
+	After   string
+	Snippet SnippetOptions
 }
 
 type Repo interface {
@@ -29,37 +76,37 @@ type Repo interface {
 	// Get returns the document by id if present.
 	Get(ctx context.Context, id string) (*Doc, bool)
 	// Search finds matching documents for query q and returns (items, total).
-	// items are sorted by score desc and truncated to 'limit'; total is the untruncated size.
-	Search(ctx context.Context, q string, limit int) ([]*Item, int, error)
+	// items are sorted by score desc (ties broken stably) and hold the page selected by
+	// opts (Offset or After, then Limit); total is the untruncated size after the tag filter.
+	Search(ctx context.Context, q string, opts SearchOptions) ([]*Item, int, error)
 	// Update replaces the document with the same ID and updates indexes accordingly (upsert).
 	// If the document does not exist, it will be inserted. The operation is atomic.
 	Update(ctx context.Context, d *Doc) error
 	// Delete removes a document and cleans up indexes.
 	Delete(ctx context.Context, id string) error
+	// List pages through documents ordered by UpdatedAt (ties by a stable key), optionally
+	// filtered by tags.
+	List(ctx context.Context, opts ListOptions) (*DocPage, error)
 }
 
 type memoryRepo struct {
 	mu   sync.RWMutex
 	docs map[string]*Doc
-	// inverted indexes counted by bigram -> docID -> count
+	// chunks holds each document's indexed chunks (one chunk of the whole content when the
+	// document arrived unchunked)
+	chunks map[string][]Chunk
+	// inverted indexes counted by bigram -> docID -> count (title) or chunk ID -> count (body)
 	indexTitle map[string]map[string]int
 	indexBody  map[string]map[string]int
-	// per-doc ngram frequencies for proper update/delete bookkeeping
-	gramsTitleByDoc map[string]map[string]int
-	gramsBodyByDoc  map[string]map[string]int
+	// per-doc and per-chunk ngram frequencies for proper update/delete bookkeeping
+	gramsTitleByDoc  map[string]map[string]int
+	gramsBodyByChunk map[string]map[string]int
 	// n-gram size, default 2 (bigrams)
 	ngramN int
 }
 
 func NewMemoryRepo() Repo {
-	return &memoryRepo{
-		docs:            map[string]*Doc{},
-		indexTitle:      map[string]map[string]int{},
-		indexBody:       map[string]map[string]int{},
-		gramsTitleByDoc: map[string]map[string]int{},
-		gramsBodyByDoc:  map[string]map[string]int{},
-		ngramN:          2,
-	}
+	return NewMemoryRepoWithN(2)
 }
 
 // NewMemoryRepoWithN returns a memory repo configured to use n-grams of size n (n>=2 recommended).
@@ -68,12 +115,13 @@ func NewMemoryRepoWithN(n int) Repo {
 		n = 2
 	}
 	return &memoryRepo{
-		docs:            map[string]*Doc{},
-		indexTitle:      map[string]map[string]int{},
-		indexBody:       map[string]map[string]int{},
-		gramsTitleByDoc: map[string]map[string]int{},
-		gramsBodyByDoc:  map[string]map[string]int{},
-		ngramN:          n,
+		docs:             map[string]*Doc{},
+		chunks:           map[string][]Chunk{},
+		indexTitle:       map[string]map[string]int{},
+		indexBody:        map[string]map[string]int{},
+		gramsTitleByDoc:  map[string]map[string]int{},
+		gramsBodyByChunk: map[string]map[string]int{},
+		ngramN:           n,
 	}
 }
 
@@ -83,57 +131,83 @@ func NewMemoryRepoWithN(n int) Repo {
 func (m *memoryRepo) Add(ctx context.Context, d *Doc) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	// If doc exists, treat as Update to keep index consistent
-	if _, ok := m.docs[d.ID]; ok {
-		return m.upsertLocked(d)
-	}
-	m.docs[d.ID] = d
-	// compute ngram counts
-	tCounts := countNGrams(d.Title, m.ngramN)
-	bCounts := countNGrams(d.Content, m.ngramN)
-	// update inverted indexes
-	for g, c := range tCounts {
-		if m.indexTitle[g] == nil {
-			m.indexTitle[g] = map[string]int{}
-		}
-		m.indexTitle[g][d.ID] += c
-	}
-	for g, c := range bCounts {
-		if m.indexBody[g] == nil {
-			m.indexBody[g] = map[string]int{}
-		}
-		m.indexBody[g][d.ID] += c
-	}
-	// store per-doc counts
-	m.gramsTitleByDoc[d.ID] = tCounts
-	m.gramsBodyByDoc[d.ID] = bCounts
-	return nil
+	return m.upsertLocked(d)
 }
 
-func (m *memoryRepo) Search(ctx context.Context, q string, limit int) ([]*Item, int, error) {
+func (m *memoryRepo) Search(ctx context.Context, q string, opts SearchOptions) ([]*Item, int, error) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 	q = strings.ToLower(strings.TrimSpace(q))
-	if q == "" {
+	terms := normalizeFilter(opts.Tags)
+	if q == "" && len(terms) == 0 {
 		return []*Item{}, 0, nil
 	}
+	limit := opts.Limit
 	if limit <= 0 {
 		limit = 10
 	}
-	// bigram score first
-	grams := toNGrams(q, m.ngramN)
-	items := itemsFromIndex(grams, m.docs, m.indexTitle, m.indexBody)
-	if len(items) == 0 { // fallback
-		items = itemsFromSubstring(m.docs, q)
+	var items []*Item
+	if q == "" {
+		items = m.itemsFromTags(terms, opts.AnyTag)
+	} else {
+		// bigram score first
+		items = m.itemsFromIndex(toNGrams(q, m.ngramN))
+		if len(items) == 0 { // fallback
+			items = m.itemsFromSubstring(q)
+		}
+		items = filterItems(items, m.docs, terms, opts.AnyTag)
 	}
-	sort.Slice(items, func(i, j int) bool { return items[i].Score > items[j].Score })
+	sort.Slice(items, func(i, j int) bool { return itemBefore(items[i].Score, items[i].ID, items[j].Score, items[j].ID) })
 	total := len(items)
-	if len(items) > limit {
-		items = items[:limit]
+	start := min(max(opts.Offset, 0), total)
+	if opts.After != "" {
+		c, err := decodeMemCursor(opts.After)
+		if err != nil {
+			return nil, 0, err
+		}
+		// first item ordered after the cursor position
+		start = sort.Search(total, func(i int) bool { return itemBefore(c.Score, c.ID, items[i].Score, items[i].ID) })
+	}
+	items = items[start:min(start+limit, total)]
+	for _, it := range items {
+		m.snippet(it, q, opts.Snippet)
+	}
+	if opts.Cursor || opts.After != "" {
+		for _, it := range items {
+			it.Cursor = encodeMemCursor(memCursor{Score: it.Score, ID: it.ID})
+		}
 	}
 	return items, total, nil
 }
 
+// itemBefore is the result order: score descending, then id ascending.
+func itemBefore(scoreA float64, idA string, scoreB float64, idB string) bool {
+	if scoreA != scoreB {
+		return scoreA > scoreB
+	}
+	return idA < idB
+}
+
+// memCursor is the sort position of an item in the memory repo.
+type memCursor struct {
+	Score float64 `json:"s"`
+	ID    string  `json:"id"`
+}
+
+func encodeMemCursor(c memCursor) string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeMemCursor(s string) (memCursor, error) {
+	var c memCursor
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || json.Unmarshal(b, &c) != nil || c.ID == "" {
+		return c, ErrInvalidCursor
+	}
+	return c, nil
+}
+
 // Get returns a copy-safe pointer to the document and a boolean flag.
 // Note: returns the stored pointer; callers must treat it as read-only.
 func (m *memoryRepo) Get(ctx context.Context, id string) (*Doc, bool) {
@@ -156,17 +230,6 @@ func scoreDoc(d *Doc, q string) float64 {
 	return score
 }
 
-func makeSnippet(s string, max int) string {
-	if max <= 0 {
-		return s
-	}
-	runes := []rune(s)
-	if len(runes) <= max {
-		return s
-	}
-	return string(runes[:max])
-}
-
 // toNGrams generates lowercased overlapping n-grams after stripping spaces and punctuation.
 func toNGrams(s string, n int) []string {
 	if n < 2 {
@@ -206,31 +269,70 @@ func countNGrams(s string, n int) map[string]int {
 	return m
 }
 
-// itemsFromIndex builds items using inverted index scores.
-func itemsFromIndex(grams []string, docs map[string]*Doc, idxTitle, idxBody map[string]map[string]int) []*Item {
-	if len(grams) == 0 {
+// item builds the search item for d with the heading path of its chunk ci; Search adds
+// the snippet once the page is cut.
+func (m *memoryRepo) item(d *Doc, score float64, ci int) *Item {
+	it := &Item{ID: d.ID, Title: d.Title, Score: score, Tags: d.Tags}
+	if cs := m.chunks[d.ID]; ci >= 0 && ci < len(cs) {
+		it.Heading, it.Chunk = cs[ci].Heading, ci
+	}
+	return it
+}
+
+// snippet sets the item's snippet and highlights from the best matching windows of its chunk,
+// or of the whole content for a document without chunks.
+func (m *memoryRepo) snippet(it *Item, q string, opts SnippetOptions) {
+	text := m.docs[it.ID].Content
+	if cs := m.chunks[it.ID]; it.Chunk < len(cs) {
+		text = cs[it.Chunk].Text
+	}
+	it.Snippet, it.Highlights = Snippet(text, q, m.ngramN, opts)
+}
+
+// itemsFromIndex builds items using inverted index scores: a document scores its title
+// plus its best chunk, which also supplies the snippet.
+func (m *memoryRepo) itemsFromIndex(grams []string) []*Item {
+	if len(grams) == 0 || len(m.docs) == 0 {
 		return nil
 	}
-	scores := buildScores(grams, idxTitle, idxBody, len(docs))
+	uniq := dedupStrings(grams)
+	idf := computeIDF(uniq, m.indexTitle, m.indexBody, len(m.docs))
+	scores, chunkScores := accumulateScores(uniq, idf, m.indexTitle, m.indexBody)
+	best := map[string]int{}
+	bestScore := map[string]float64{}
+	for cid, s := range chunkScores {
+		doc, ci := chunkParent(cid)
+		if prev, ok := bestScore[doc]; !ok || s > prev || (s == prev && ci < best[doc]) {
+			best[doc], bestScore[doc] = ci, s
+		}
+	}
+	for doc, s := range bestScore {
+		scores[doc] += s
+	}
 	items := make([]*Item, 0, len(scores))
 	for id, s := range scores {
 		if s <= 0 {
 			continue
 		}
-		if d, ok := docs[id]; ok {
-			items = append(items, &Item{ID: d.ID, Title: d.Title, Snippet: makeSnippet(d.Content, 120), Score: s})
+		if d, ok := m.docs[id]; ok {
+			ci, ok := best[id]
+			if !ok {
+				ci = 0
+			}
+			items = append(items, m.item(d, s, ci))
 		}
 	}
 	return items
 }
 
-func buildScores(grams []string, idxTitle, idxBody map[string]map[string]int, numDocs int) map[string]float64 {
-	if numDocs <= 0 || len(grams) == 0 {
-		return map[string]float64{}
-	}
-	uniq := dedupStrings(grams)
-	idf := computeIDF(uniq, idxTitle, idxBody, numDocs)
-	return accumulateScores(uniq, idf, idxTitle, idxBody)
+// chunkParent splits a ChunkID into its document id and chunk index.
+func chunkParent(cid string) (string, int) {
+	i := strings.LastIndexByte(cid, '#')
+	if i < 0 {
+		return cid, 0
+	}
+	n, _ := strconv.Atoi(cid[i+1:])
+	return cid[:i], n
 }
 
 func dedupStrings(ss []string) map[string]struct{} {
@@ -241,6 +343,7 @@ func dedupStrings(ss []string) map[string]struct{} {
 	return m
 }
 
+// computeIDF weighs grams by how many documents contain them, in the title or in any chunk.
 func computeIDF(uniq map[string]struct{}, idxTitle, idxBody map[string]map[string]int, numDocs int) map[string]float64 {
 	idf := make(map[string]float64, len(uniq))
 	for g := range uniq {
@@ -251,7 +354,8 @@ func computeIDF(uniq map[string]struct{}, idxTitle, idxBody map[string]map[strin
 			}
 		}
 		if postings, ok := idxBody[g]; ok {
-			for docID := range postings {
+			for cid := range postings {
+				docID, _ := chunkParent(cid)
 				dfSet[docID] = struct{}{}
 			}
 		}
@@ -261,8 +365,10 @@ func computeIDF(uniq map[string]struct{}, idxTitle, idxBody map[string]map[strin
 	return idf
 }
 
-func accumulateScores(uniq map[string]struct{}, idf map[string]float64, idxTitle, idxBody map[string]map[string]int) map[string]float64 {
+// accumulateScores returns title scores per document and body scores per chunk.
+func accumulateScores(uniq map[string]struct{}, idf map[string]float64, idxTitle, idxBody map[string]map[string]int) (map[string]float64, map[string]float64) {
 	scores := map[string]float64{}
+	chunkScores := map[string]float64{}
 	for g := range uniq {
 		w := idf[g]
 		if postings, ok := idxTitle[g]; ok {
@@ -271,22 +377,55 @@ func accumulateScores(uniq map[string]struct{}, idf map[string]float64, idxTitle
 			}
 		}
 		if postings, ok := idxBody[g]; ok {
-			for docID, c := range postings {
-				scores[docID] += float64(1*c) * w
+			for cid, c := range postings {
+				chunkScores[cid] += float64(1*c) * w
 			}
 		}
 	}
-	return scores
+	return scores, chunkScores
 }
 
-func itemsFromSubstring(docs map[string]*Doc, q string) []*Item {
+func (m *memoryRepo) itemsFromSubstring(q string) []*Item {
 	out := []*Item{}
-	for _, d := range docs {
+	for _, d := range m.docs {
 		score := scoreDoc(d, q)
 		if score <= 0 {
 			continue
 		}
-		out = append(out, &Item{ID: d.ID, Title: d.Title, Snippet: makeSnippet(d.Content, 120), Score: score})
+		// the first chunk mentioning q makes the snippet
+		ci := 0
+		for i, c := range m.chunks[d.ID] {
+			if strings.Contains(strings.ToLower(c.Text), q) {
+				ci = i
+				break
+			}
+		}
+		out = append(out, m.item(d, score, ci))
+	}
+	return out
+}
+
+// itemsFromTags lists every document matching the tag filter, all with the same score.
+func (m *memoryRepo) itemsFromTags(terms []string, any bool) []*Item {
+	out := []*Item{}
+	for _, d := range m.docs {
+		if matchTags(d.Tags, terms, any) {
+			out = append(out, m.item(d, 1, 0))
+		}
+	}
+	return out
+}
+
+// filterItems keeps the items whose document matches the tag filter.
+func filterItems(items []*Item, docs map[string]*Doc, terms []string, any bool) []*Item {
+	if len(terms) == 0 {
+		return items
+	}
+	out := items[:0]
+	for _, it := range items {
+		if d, ok := docs[it.ID]; ok && matchTags(d.Tags, terms, any) {
+			out = append(out, it)
+		}
 	}
 	return out
 }
@@ -300,12 +439,12 @@ func (m *memoryRepo) Update(ctx context.Context, d *Doc) error {
 
 // upsertLocked performs an upsert for document d while the caller holds the write lock.
 // Steps:
-// 1) Remove previous per-doc n-gram counts from the inverted indexes (if any).
-// 2) Recompute n-grams for the new content and add them back to the indexes.
-// 3) Replace the stored document and refresh per-doc n-gram caches.
+// 1) Remove previous per-doc and per-chunk n-gram counts from the inverted indexes (if any).
+// 2) Recompute n-grams for the new title and chunks and add them back to the indexes.
+// 3) Replace the stored document and refresh the n-gram caches.
 // Invariants after return:
 //   - For any gram g: indexTitle[g][id] == gramsTitleByDoc[id][g] (or both absent)
-//     indexBody[g][id]  == gramsBodyByDoc[id][g]  (or both absent)
+//     indexBody[g][cid] == gramsBodyByChunk[cid][g] (or both absent) for every chunk cid of id
 //   - docs[id] == d
 //
 // Big-O: O(G_old + G_new), where G_* is the number of n-grams in old/new content.
@@ -316,15 +455,21 @@ func (m *memoryRepo) upsertLocked(d *Doc) error {
 	return nil
 }
 
-// removeDocFromIndexNoLock subtracts the stored per-doc n-gram counts from the field indexes.
-// No-op if the document has no cached grams (i.e., not present).
+// removeDocFromIndexNoLock subtracts the stored n-gram counts of the document and its
+// chunks from the field indexes and drops the caches. No-op if the document is not present.
 func (m *memoryRepo) removeDocFromIndexNoLock(id string) {
 	m.removeFieldIndex(id, m.gramsTitleByDoc[id], m.indexTitle)
-	m.removeFieldIndex(id, m.gramsBodyByDoc[id], m.indexBody)
+	delete(m.gramsTitleByDoc, id)
+	for _, c := range m.chunks[id] {
+		cid := ChunkID(id, c.Index)
+		m.removeFieldIndex(cid, m.gramsBodyByChunk[cid], m.indexBody)
+		delete(m.gramsBodyByChunk, cid)
+	}
+	delete(m.chunks, id)
 }
 
-// removeFieldIndex applies negative deltas for a document across all grams of a single field,
-// removing empty postings and gram keys when counts drop to zero.
+// removeFieldIndex applies negative deltas for a document or chunk across all grams of a
+// single field, removing empty postings and gram keys when counts drop to zero.
 func (m *memoryRepo) removeFieldIndex(id string, grams map[string]int, index map[string]map[string]int) {
 	if grams == nil {
 		return
@@ -342,37 +487,57 @@ func (m *memoryRepo) removeFieldIndex(id string, grams map[string]int, index map
 	}
 }
 
-// addDocToIndexNoLock (re)computes per-doc gram counts and adds them to indexes and caches.
+// IndexedChunks returns d's chunks, or one chunk of the whole content when it has none.
+func (d *Doc) IndexedChunks() []Chunk {
+	if len(d.Chunks) > 0 {
+		return d.Chunks
+	}
+	if d.Content == "" {
+		return nil
+	}
+	return []Chunk{{Index: 0, Text: d.Content}}
+}
+
+// addDocToIndexNoLock (re)computes the title and chunk gram counts and adds them to indexes and caches.
+// A chunk is indexed with its heading path so section titles count towards it.
 func (m *memoryRepo) addDocToIndexNoLock(d *Doc) {
 	tCounts := countNGrams(d.Title, m.ngramN)
-	bCounts := countNGrams(d.Content, m.ngramN)
-	for g, c := range tCounts {
-		if m.indexTitle[g] == nil {
-			m.indexTitle[g] = map[string]int{}
-		}
-		m.indexTitle[g][d.ID] += c
+	addFieldIndex(d.ID, tCounts, m.indexTitle)
+	m.gramsTitleByDoc[d.ID] = tCounts
+	chunks := d.IndexedChunks()
+	for _, c := range chunks {
+		cid := ChunkID(d.ID, c.Index)
+		counts := countNGrams(strings.Join(append(append([]string(nil), c.Heading...), c.Text), " "), m.ngramN)
+		addFieldIndex(cid, counts, m.indexBody)
+		m.gramsBodyByChunk[cid] = counts
 	}
-	for g, c := range bCounts {
-		if m.indexBody[g] == nil {
-			m.indexBody[g] = map[string]int{}
+	m.docs[d.ID] = d
+	m.chunks[d.ID] = chunks
+}
+
+func addFieldIndex(id string, grams map[string]int, index map[string]map[string]int) {
+	for g, c := range grams {
+		if index[g] == nil {
+			index[g] = map[string]int{}
 		}
-		m.indexBody[g][d.ID] += c
+		index[g][id] += c
 	}
-	m.docs[d.ID] = d
-	m.gramsTitleByDoc[d.ID] = tCounts
-	m.gramsBodyByDoc[d.ID] = bCounts
 }
 
 // Delete implements Repo.Delete.
 func (m *memoryRepo) Delete(ctx context.Context, id string) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	m.deleteLocked(id)
+	return nil
+}
+
+// deleteLocked removes document id and its index entries while the caller holds the write
+// lock. No-op if the document is not present.
+func (m *memoryRepo) deleteLocked(id string) {
 	if _, ok := m.docs[id]; !ok {
-		return nil
+		return
 	}
 	m.removeDocFromIndexNoLock(id)
 	delete(m.docs, id)
-	delete(m.gramsTitleByDoc, id)
-	delete(m.gramsBodyByDoc, id)
-	return nil
 }
@@ -2,9 +2,12 @@ package kb
 
 import (
 	"context"
+	"sort"
 	"strings"
 	"testing"
 	"unicode/utf8"
+
+	"github.com/gogogo1024/assist-fusion/internal/common"
 )
 
 const errAddFmt = "add: %v"
@@ -24,7 +27,7 @@ func TestMemoryRepoAddAndSearch(t *testing.T) {
 	}
 
 	// empty query returns empty
-	items, total, err := repo.Search(context.TODO(), " ", 10)
+	items, total, err := repo.Search(context.TODO(), " ", SearchOptions{Limit: 10})
 	if err != nil {
 		t.Fatalf("search empty: %v", err)
 	}
@@ -33,7 +36,7 @@ func TestMemoryRepoAddAndSearch(t *testing.T) {
 	}
 
 	// keyword
-	items, total, err = repo.Search(context.TODO(), "客服", 10)
+	items, total, err = repo.Search(context.TODO(), "客服", SearchOptions{Limit: 10})
 	if err != nil {
 		t.Fatalf("search: %v", err)
 	}
@@ -48,7 +51,7 @@ func TestMemoryRepoAddAndSearch(t *testing.T) {
 	}
 
 	// limit
-	items, total, err = repo.Search(context.TODO(), "客服", 1)
+	items, total, err = repo.Search(context.TODO(), "客服", SearchOptions{Limit: 1})
 	if err != nil {
 		t.Fatalf("search limit: %v", err)
 	}
@@ -64,7 +67,7 @@ func TestUpdateAndDeleteIndexConsistency(t *testing.T) {
 		t.Fatalf(errAddFmt, err)
 	}
 	// should hit by "安装"
-	_, total, _ := repo.Search(context.TODO(), "安装", 10)
+	_, total, _ := repo.Search(context.TODO(), "安装", SearchOptions{Limit: 10})
 	if total == 0 {
 		t.Fatalf("expected hits for 安装 before update")
 	}
@@ -73,13 +76,13 @@ func TestUpdateAndDeleteIndexConsistency(t *testing.T) {
 	if err := repo.Update(context.TODO(), &d2); err != nil {
 		t.Fatalf("update: %v", err)
 	}
-	_, total, _ = repo.Search(context.TODO(), "安装", 10)
+	_, total, _ = repo.Search(context.TODO(), "安装", SearchOptions{Limit: 10})
 	// may still hit via substring fallback only if any field contains query; should be 0 now
 	if total != 0 {
 		t.Fatalf("expected 0 hit after update for 安装, got %d", total)
 	}
 	// new query should hit
-	_, total, _ = repo.Search(context.TODO(), "排错", 10)
+	_, total, _ = repo.Search(context.TODO(), "排错", SearchOptions{Limit: 10})
 	if total == 0 {
 		t.Fatalf("expected hits for 排错 after update")
 	}
@@ -87,7 +90,7 @@ func TestUpdateAndDeleteIndexConsistency(t *testing.T) {
 	if err := repo.Delete(context.TODO(), d.ID); err != nil {
 		t.Fatalf("delete: %v", err)
 	}
-	_, total, _ = repo.Search(context.TODO(), "排错", 10)
+	_, total, _ = repo.Search(context.TODO(), "排错", SearchOptions{Limit: 10})
 	if total != 0 {
 		t.Fatalf("expected 0 hit after delete, got %d", total)
 	}
@@ -101,7 +104,7 @@ func TestUTF8SafeSnippet(t *testing.T) {
 	if err := repo.Add(context.TODO(), &d); err != nil {
 		t.Fatalf(errAddFmt, err)
 	}
-	items, total, _ := repo.Search(context.TODO(), "客服", 1)
+	items, total, _ := repo.Search(context.TODO(), "客服", SearchOptions{Limit: 1})
 	if total == 0 || len(items) == 0 {
 		t.Fatalf("expected hit for 客服")
 	}
@@ -123,13 +126,226 @@ func TestNGramConfiguration(t *testing.T) {
 		t.Fatalf(errAddFmt, err)
 	}
 	// short query of length 2 should trigger fallback (no trigrams), still find via substring
-	_, total, _ := repo.Search(context.TODO(), "安装", 10)
+	_, total, _ := repo.Search(context.TODO(), "安装", SearchOptions{Limit: 10})
 	if total == 0 {
 		t.Fatalf("expected fallback hit for 安装 with trigram repo")
 	}
 	// full query with length >=3 should hit via index as well
-	_, total, _ = repo.Search(context.TODO(), "安装指南", 10)
+	_, total, _ = repo.Search(context.TODO(), "安装指南", SearchOptions{Limit: 10})
 	if total == 0 {
 		t.Fatalf("expected indexed hit for 安装指南 with trigram repo")
 	}
 }
+
+func TestPerTenantIsolation(t *testing.T) {
+	repo := PerTenant(func(string) (Repo, error) { return NewMemoryRepo(), nil })
+	acme := common.WithTenant(context.TODO(), "acme")
+	globex := common.WithTenant(context.TODO(), "globex")
+	if err := repo.Add(acme, &Doc{ID: "1", Title: "退款流程", Content: "acme 内部退款说明"}); err != nil {
+		t.Fatalf(errAddFmt, err)
+	}
+	if _, ok := repo.Get(globex, "1"); ok {
+		t.Fatalf("globex must not see acme doc")
+	}
+	if items, total, _ := repo.Search(globex, "退款", SearchOptions{Limit: 10}); len(items) != 0 || total != 0 {
+		t.Fatalf("globex search leaked acme docs: %#v", items)
+	}
+	if _, total, _ := repo.Search(acme, "退款", SearchOptions{Limit: 10}); total != 1 {
+		t.Fatalf("acme should find its own doc, total=%d", total)
+	}
+	// deleting the same id in another tenant is a no-op
+	_ = repo.Delete(globex, "1")
+	if _, ok := repo.Get(acme, "1"); !ok {
+		t.Fatalf("acme doc removed by globex delete")
+	}
+}
+
+func TestTagFilteredSearch(t *testing.T) {
+	repo := NewMemoryRepo()
+	docs := []Doc{
+		{ID: "1", Title: "VPN 连接失败", Content: "客服排查 VPN", Tags: map[string]string{"product": "vpn", "lang": "zh"}},
+		{ID: "2", Title: "邮箱配置", Content: "客服配置邮箱", Tags: map[string]string{"product": "mail", "lang": "zh"}},
+		{ID: "3", Title: "Billing FAQ", Content: "客服 billing", Tags: map[string]string{"internal": ""}},
+	}
+	for i := range docs {
+		if err := repo.Add(context.TODO(), &docs[i]); err != nil {
+			t.Fatalf(errAddFmt, err)
+		}
+	}
+	ids := func(items []*Item) string {
+		out := make([]string, 0, len(items))
+		for _, it := range items {
+			out = append(out, it.ID)
+		}
+		return strings.Join(out, ",")
+	}
+	cases := []struct {
+		q    string
+		opts SearchOptions
+		want string
+	}{
+		{"客服", SearchOptions{Tags: []string{"lang:zh"}}, "1,2"},
+		{"客服", SearchOptions{Tags: []string{"lang:zh", "product:vpn"}}, "1"},
+		{"客服", SearchOptions{Tags: []string{"product:vpn", "internal"}, AnyTag: true}, "1,3"},
+		{"客服", SearchOptions{Tags: []string{"Product"}}, "1,2"},
+		{"", SearchOptions{Tags: []string{"product:mail"}}, "2"},
+		{"客服", SearchOptions{Tags: []string{"product:fax"}}, ""},
+	}
+	for _, c := range cases {
+		items, total, _ := repo.Search(context.TODO(), c.q, c.opts)
+		got := ids(items)
+		if c.q != "" { // score order differs per doc, compare as sets
+			parts := strings.Split(got, ",")
+			sort.Strings(parts)
+			got = strings.Join(parts, ",")
+		}
+		if got != c.want || total != len(items) {
+			t.Fatalf("search %q %+v = %q (total %d), want %q", c.q, c.opts, got, total, c.want)
+		}
+	}
+	items, _, _ := repo.Search(context.TODO(), "VPN", SearchOptions{Tags: []string{"product:vpn"}})
+	if len(items) != 1 || items[0].Tags["lang"] != "zh" {
+		t.Fatalf("search item tags = %+v", items)
+	}
+}
+
+func TestNormalizeTags(t *testing.T) {
+	got, err := NormalizeTags(map[string]string{" Product ": " VPN "})
+	if err != nil || got["product"] != "vpn" {
+		t.Fatalf("normalize = %v, %v", got, err)
+	}
+	for _, bad := range []map[string]string{{"": "x"}, {"a:b": "x"}, {"k": strings.Repeat("v", MaxTagLength+1)}} {
+		if _, err := NormalizeTags(bad); err == nil {
+			t.Fatalf("normalize %v: want error", bad)
+		}
+	}
+	if terms := TagTerms(map[string]string{"product": "vpn", "internal": ""}); strings.Join(terms, ",") != "internal,product,product:vpn" {
+		t.Fatalf("terms = %v", terms)
+	}
+}
+
+func TestSearchPagination(t *testing.T) {
+	repo := NewMemoryRepo()
+	// identical docs score the same, so page order relies on the id tie-breaker
+	for i := 0; i < 7; i++ {
+		if err := repo.Add(context.TODO(), &Doc{ID: string(rune('a' + i)), Title: "分页", Content: "分页测试"}); err != nil {
+			t.Fatalf(errAddFmt, err)
+		}
+	}
+	var byOffset []string
+	for off := 0; off < 7; off += 3 {
+		items, total, _ := repo.Search(context.TODO(), "分页", SearchOptions{Limit: 3, Offset: off})
+		if total != 7 {
+			t.Fatalf("offset %d total = %d", off, total)
+		}
+		for _, it := range items {
+			byOffset = append(byOffset, it.ID)
+		}
+	}
+	if got := strings.Join(byOffset, ""); got != "abcdefg" {
+		t.Fatalf("offset pages = %q", got)
+	}
+
+	var byCursor []string
+	opts := SearchOptions{Limit: 3, Cursor: true}
+	for {
+		items, _, err := repo.Search(context.TODO(), "分页", opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(items) == 0 {
+			break
+		}
+		for _, it := range items {
+			byCursor = append(byCursor, it.ID)
+		}
+		opts.After = items[len(items)-1].Cursor
+	}
+	if got := strings.Join(byCursor, ""); got != "abcdefg" {
+		t.Fatalf("cursor pages = %q", got)
+	}
+	if _, _, err := repo.Search(context.TODO(), "分页", SearchOptions{After: "not-a-cursor"}); err != ErrInvalidCursor {
+		t.Fatalf("bad cursor err = %v", err)
+	}
+}
+
+func TestListOrderAndCursor(t *testing.T) {
+	repo := NewMemoryRepo()
+	for i, u := range []int64{30, 10, 20, 20} {
+		odd := "n"
+		if i%2 == 1 {
+			odd = "y"
+		}
+		d := &Doc{ID: string(rune('a' + i)), Title: "t", Tags: map[string]string{"odd": odd}, UpdatedAt: u}
+		if err := repo.Add(context.TODO(), d); err != nil {
+			t.Fatalf(errAddFmt, err)
+		}
+	}
+	walk := func(opts ListOptions) string {
+		var out []string
+		for {
+			page, err := repo.List(context.TODO(), opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, d := range page.Docs {
+				out = append(out, d.ID)
+			}
+			if page.Next == "" {
+				return strings.Join(out, "")
+			}
+			opts.After = page.Next
+		}
+	}
+	if got := walk(ListOptions{Limit: 1}); got != "acdb" {
+		t.Fatalf("newest first = %q", got)
+	}
+	if got := walk(ListOptions{Limit: 3, Asc: true}); got != "bcda" {
+		t.Fatalf("oldest first = %q", got)
+	}
+	if got := walk(ListOptions{Limit: 2, Tags: []string{"odd:y"}}); got != "db" {
+		t.Fatalf("tag filtered = %q", got)
+	}
+	if _, err := repo.List(context.TODO(), ListOptions{After: "%%"}); err != ErrInvalidCursor {
+		t.Fatalf("bad cursor err = %v", err)
+	}
+}
+
+func TestSearchBestChunk(t *testing.T) {
+	repo := NewMemoryRepo()
+	content := "# 概述\n" + strings.Repeat("这是一段关于产品背景的介绍。", 30) + "\n# 故障排查\n## 网络\n如果 VPN 无法连接，请先重置网络配置。"
+	d := &Doc{ID: "long", Title: "产品手册", Content: content}
+	d.Chunks = NewChunker(60, 10).Split(d.Content)
+	if err := repo.Add(context.TODO(), d); err != nil {
+		t.Fatalf(errAddFmt, err)
+	}
+	if err := repo.Add(context.TODO(), &Doc{ID: "short", Title: "网络配置", Content: "网络配置说明"}); err != nil {
+		t.Fatalf(errAddFmt, err)
+	}
+	items, _, _ := repo.Search(context.TODO(), "重置网络", SearchOptions{Limit: 5})
+	var got *Item
+	for _, it := range items {
+		if it.ID == "long" {
+			got = it
+		}
+	}
+	if got == nil {
+		t.Fatalf("long doc missing from %+v", items)
+	}
+	if strings.Join(got.Heading, ">") != "故障排查>网络" || !strings.Contains(got.Snippet, "重置网络配置") || got.Chunk != len(d.Chunks)-1 {
+		t.Fatalf("best chunk = %+v", got)
+	}
+	// re-chunking on update leaves no stale chunk postings behind
+	d2 := &Doc{ID: "long", Title: "产品手册", Content: "只剩一句话。"}
+	if err := repo.Update(context.TODO(), d2); err != nil {
+		t.Fatal(err)
+	}
+	m := repo.(*memoryRepo)
+	for g, postings := range m.indexBody {
+		for cid := range postings {
+			if doc, ci := chunkParent(cid); doc == "long" && ci > 0 {
+				t.Fatalf("stale chunk posting %s for gram %q", cid, g)
+			}
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package kb
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"sort"
+)
+
+// ListOptions selects one page of List.
+// Tags and AnyTag filter like SearchOptions; Asc lists oldest updates first (default is
+// newest first); After is the Next of the previous page.
+type ListOptions struct {
+	Limit  int
+	Tags   []string
+	AnyTag bool
+	Asc    bool
+	After  string
+}
+
+// DocPage is one page of List. Next is empty on the last page; Total counts every
+// document matching the tag filter.
+type DocPage struct {
+	Docs  []*Doc
+	Next  string
+	Total int
+}
+
+// listCursor is the position of a document in the memory repo's listing order.
+type listCursor struct {
+	UpdatedAt int64  `json:"u"`
+	ID        string `json:"id"`
+}
+
+// docBefore is the listing order: UpdatedAt (descending unless asc), then id ascending.
+func docBefore(a, b listCursor, asc bool) bool {
+	if a.UpdatedAt != b.UpdatedAt {
+		return (a.UpdatedAt < b.UpdatedAt) == asc
+	}
+	return a.ID < b.ID
+}
+
+// List implements Repo.List.
+func (m *memoryRepo) List(ctx context.Context, opts ListOptions) (*DocPage, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	terms := normalizeFilter(opts.Tags)
+	docs := make([]*Doc, 0, len(m.docs))
+	for _, d := range m.docs {
+		if matchTags(d.Tags, terms, opts.AnyTag) {
+			docs = append(docs, d)
+		}
+	}
+	pos := func(d *Doc) listCursor { return listCursor{UpdatedAt: d.UpdatedAt, ID: d.ID} }
+	sort.Slice(docs, func(i, j int) bool { return docBefore(pos(docs[i]), pos(docs[j]), opts.Asc) })
+	start := 0
+	if opts.After != "" {
+		var c listCursor
+		b, err := base64.RawURLEncoding.DecodeString(opts.After)
+		if err != nil || json.Unmarshal(b, &c) != nil || c.ID == "" {
+			return nil, ErrInvalidCursor
+		}
+		start = sort.Search(len(docs), func(i int) bool { return docBefore(c, pos(docs[i]), opts.Asc) })
+	}
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = 20
+	}
+	end := min(start+limit, len(docs))
+	page := &DocPage{Docs: docs[start:end], Total: len(docs)}
+	if end < len(docs) {
+		b, _ := json.Marshal(pos(docs[end-1]))
+		page.Next = base64.RawURLEncoding.EncodeToString(b)
+	}
+	return page, nil
+}
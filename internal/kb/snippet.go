@@ -0,0 +1,235 @@
+package kb
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// DefaultSnippetLength is the length of a snippet fragment in runes.
+const DefaultSnippetLength = 120
+
+// FragmentSeparator joins the fragments of a multi-fragment snippet.
+const FragmentSeparator = " … "
+
+// SnippetOptions shapes Item.Snippet: Length runes per fragment (DefaultSnippetLength
+// when 0) and up to Fragments fragments (1 when 0), joined by FragmentSeparator. With
+// PreTag and PostTag set, matches are wrapped in the snippet instead of being returned as
+// Item.Highlights.
+type SnippetOptions struct {
+	Length    int
+	Fragments int
+	PreTag    string
+	PostTag   string
+}
+
+func (o SnippetOptions) withDefaults() SnippetOptions {
+	if o.Length <= 0 {
+		o.Length = DefaultSnippetLength
+	}
+	if o.Fragments <= 0 {
+		o.Fragments = 1
+	}
+	return o
+}
+
+// Tagged reports whether matches are wrapped in tags rather than returned as spans.
+func (o SnippetOptions) Tagged() bool { return o.PreTag != "" || o.PostTag != "" }
+
+// Span is a highlighted range of a snippet, [Start, End) in runes (Unicode code points).
+type Span struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+// gramHit is one occurrence of a query n-gram in a text, in runes of the text.
+type gramHit struct {
+	Span
+	gram string
+}
+
+// gramHits finds the n-grams of q in text. Like the index it compares lowercased letters
+// and skips spaces and punctuation, so a hit may cover them. A query shorter than n is
+// matched as a whole.
+func gramHits(text []rune, q string, n int) []gramHit {
+	norm := make([]rune, 0, len(text))
+	pos := make([]int, 0, len(text))
+	for i, r := range text {
+		if unicode.IsSpace(r) || unicode.IsPunct(r) {
+			continue
+		}
+		norm = append(norm, unicode.ToLower(r))
+		pos = append(pos, i)
+	}
+	grams := dedupStrings(toNGrams(q, n))
+	if len(grams) == 0 {
+		qn := normalizeRunes(q)
+		if len(qn) == 0 {
+			return nil
+		}
+		grams, n = map[string]struct{}{string(qn): {}}, len(qn)
+	}
+	var hits []gramHit
+	for i := 0; i+n <= len(norm); i++ {
+		if g := string(norm[i : i+n]); hasKey(grams, g) {
+			hits = append(hits, gramHit{Span: Span{Start: pos[i], End: pos[i+n-1] + 1}, gram: g})
+		}
+	}
+	return hits
+}
+
+func hasKey(m map[string]struct{}, k string) bool {
+	_, ok := m[k]
+	return ok
+}
+
+// mergeHits joins overlapping and touching hits into highlight spans, in text order.
+func mergeHits(hits []gramHit) []Span {
+	var out []Span
+	for _, h := range hits {
+		if k := len(out) - 1; k >= 0 && h.Start <= out[k].End {
+			out[k].End = max(out[k].End, h.End)
+			continue
+		}
+		out = append(out, h.Span)
+	}
+	return out
+}
+
+// windowScore ranks a window by the distinct query grams it contains, then by how many
+// runes its hits cover, so a window with every query term beats one repeating a term.
+func windowScore(hits []gramHit, w Span) (int, int) {
+	distinct := map[string]struct{}{}
+	covered := 0
+	for _, h := range hits {
+		if h.Start >= w.Start && h.End <= w.End {
+			distinct[h.gram] = struct{}{}
+			covered += h.End - h.Start
+		}
+	}
+	return len(distinct), covered
+}
+
+// pickWindows chooses up to count non-overlapping windows of length runes with the best
+// windowScore, in text order. Each candidate starts a quarter window before a hit so the
+// match has leading context. Without hits the text's start is the only window.
+func pickWindows(text []rune, hits []gramHit, length, count int) []Span {
+	if len(text) <= length {
+		return []Span{{0, len(text)}}
+	}
+	var chosen []Span
+	overlaps := func(w Span) bool {
+		for _, c := range chosen {
+			if w.Start < c.End && c.Start < w.End {
+				return true
+			}
+		}
+		return false
+	}
+	for len(chosen) < count {
+		best, bestDistinct, bestCovered := Span{}, 0, 0
+		for _, h := range hits {
+			start := min(max(h.Start-length/4, 0), len(text)-length)
+			w := Span{start, start + length}
+			if overlaps(w) {
+				continue
+			}
+			if d, c := windowScore(hits, w); d > bestDistinct || (d == bestDistinct && c > bestCovered) {
+				best, bestDistinct, bestCovered = w, d, c
+			}
+		}
+		if bestDistinct == 0 {
+			break
+		}
+		chosen = append(chosen, best)
+	}
+	if len(chosen) == 0 {
+		return []Span{{0, length}}
+	}
+	sort.Slice(chosen, func(i, j int) bool { return chosen[i].Start < chosen[j].Start })
+	return chosen
+}
+
+// isWordRune reports runes that belong to a space-delimited word: letters and digits
+// outside CJK scripts, and combining marks, which stay with their base.
+func isWordRune(r rune) bool {
+	if unicode.In(r, unicode.Mn, unicode.Mc, unicode.Me) {
+		return true
+	}
+	return (unicode.IsLetter(r) || unicode.IsDigit(r)) && !isCJK(r)
+}
+
+// snapWindow moves the edges of w inward off the middle of words (CJK text may break at
+// any rune) and past surrounding spaces. An edge moves at most slack runes; a longer word
+// is cut where it is.
+func snapWindow(text []rune, w Span) Span {
+	slack := max((w.End-w.Start)/8, 1)
+	midWord := func(i int) bool { return i > 0 && i < len(text) && isWordRune(text[i-1]) && isWordRune(text[i]) }
+	if midWord(w.Start) {
+		s := w.Start
+		for s < w.End && s-w.Start <= slack && midWord(s) {
+			s++
+		}
+		if !midWord(s) {
+			w.Start = s
+		}
+	}
+	if midWord(w.End) {
+		e := w.End
+		for e > w.Start && w.End-e <= slack && midWord(e) {
+			e--
+		}
+		if !midWord(e) {
+			w.End = e
+		}
+	}
+	for w.Start < w.End && unicode.IsSpace(text[w.Start]) {
+		w.Start++
+	}
+	for w.End > w.Start && unicode.IsSpace(text[w.End-1]) {
+		w.End--
+	}
+	return w
+}
+
+// Snippet returns the fragments of text that best match query q (compared as n-grams, see
+// NewMemoryRepoWithN), joined by FragmentSeparator, with the matches as spans of the
+// snippet or, when opts.Tagged, wrapped in the tags. An empty query takes the start of
+// text. Fragment edges never split a rune or a non-CJK word that fits the slack.
+func Snippet(text, q string, n int, opts SnippetOptions) (string, []Span) {
+	opts = opts.withDefaults()
+	runes := []rune(text)
+	var hits []gramHit
+	if strings.TrimSpace(q) != "" {
+		hits = gramHits(runes, q, n)
+	}
+	spans := mergeHits(hits)
+	var b strings.Builder
+	var out []Span
+	at := 0 // runes written
+	for i, w := range pickWindows(runes, hits, opts.Length, opts.Fragments) {
+		w = snapWindow(runes, w)
+		if i > 0 {
+			b.WriteString(FragmentSeparator)
+			at += len([]rune(FragmentSeparator))
+		}
+		cur := w.Start
+		for _, sp := range spans {
+			s, e := max(sp.Start, w.Start), min(sp.End, w.End)
+			if s >= e {
+				continue
+			}
+			b.WriteString(string(runes[cur:s]))
+			if opts.Tagged() {
+				b.WriteString(opts.PreTag + string(runes[s:e]) + opts.PostTag)
+			} else {
+				b.WriteString(string(runes[s:e]))
+				out = append(out, Span{Start: at + s - w.Start, End: at + e - w.Start})
+			}
+			cur = e
+		}
+		b.WriteString(string(runes[cur:w.End]))
+		at += w.End - w.Start
+	}
+	return b.String(), out
+}
@@ -0,0 +1,85 @@
+package kb
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// marked renders the spans of s as [..] for comparison.
+func marked(s string, spans []Span) string {
+	r := []rune(s)
+	var b strings.Builder
+	cur := 0
+	for _, sp := range spans {
+		b.WriteString(string(r[cur:sp.Start]) + "[" + string(r[sp.Start:sp.End]) + "]")
+		cur = sp.End
+	}
+	return b.String() + string(r[cur:])
+}
+
+func TestSnippetBestWindow(t *testing.T) {
+	filler := strings.Repeat("background words about the product line. ", 10)
+	text := filler + "To fix it, reset the VPN profile and restart. " + filler + "The VPN client is shipped separately."
+	s, spans := Snippet(text, "reset VPN profile", 2, SnippetOptions{Length: 60})
+	if n := utf8.RuneCountInString(s); n > 60 {
+		t.Fatalf("snippet has %d runes: %q", n, s)
+	}
+	got := marked(s, spans)
+	if !strings.Contains(got, "[reset] the [VPN profile]") {
+		t.Fatalf("snippet = %q", got)
+	}
+	// edges snap to word boundaries
+	words := strings.Fields(s)
+	for _, w := range []string{words[0], words[len(words)-1]} {
+		if !strings.Contains(text, " "+strings.TrimRight(w, ".,")) {
+			t.Fatalf("cut word %q in %q", w, s)
+		}
+	}
+	// two fragments: the best window plus the next best, in text order
+	s, spans = Snippet(text, "reset VPN profile", 2, SnippetOptions{Length: 60, Fragments: 2})
+	got = marked(s, spans)
+	if strings.Count(s, FragmentSeparator) != 1 || !strings.Contains(got, "[reset]") || !strings.Contains(strings.SplitN(got, FragmentSeparator, 2)[1], "[VPN]") {
+		t.Fatalf("fragments = %q", got)
+	}
+}
+
+func TestSnippetCJKAndTags(t *testing.T) {
+	text := strings.Repeat("这是一段关于产品背景的介绍。", 20) + "如果 VPN 无法连接，请先重置网络配置。" + strings.Repeat("其他说明😀。", 20)
+	s, spans := Snippet(text, "重置网络", 2, SnippetOptions{Length: 30})
+	if !utf8.ValidString(s) || utf8.RuneCountInString(s) > 30 {
+		t.Fatalf("snippet = %q", s)
+	}
+	if got := marked(s, spans); !strings.Contains(got, "请先[重置网络]配置") {
+		t.Fatalf("snippet = %q", got)
+	}
+	s, spans = Snippet(text, "重置网络", 2, SnippetOptions{Length: 30, PreTag: "<em>", PostTag: "</em>"})
+	if spans != nil || !strings.Contains(s, "请先<em>重置网络</em>配置") {
+		t.Fatalf("tagged snippet = %q, %v", s, spans)
+	}
+	// a match across punctuation and case is still found
+	if s, spans := Snippet("Step one: Wi-Fi settings.", "wifi", 2, SnippetOptions{}); marked(s, spans) != "Step one: [Wi-Fi] settings." {
+		t.Fatalf("snippet = %q", marked(s, spans))
+	}
+	// no match: the leading window without highlights
+	if s, spans := Snippet(text, "打印机", 2, SnippetOptions{Length: 10}); s != "这是一段关于产品背景" || spans != nil {
+		t.Fatalf("snippet = %q, %v", s, spans)
+	}
+}
+
+func TestSearchSnippetOptions(t *testing.T) {
+	repo := NewMemoryRepo()
+	content := strings.Repeat("这是一段关于产品背景的介绍。", 20) + "如果 VPN 无法连接，请先重置网络配置。"
+	if err := repo.Add(context.TODO(), &Doc{ID: "d1", Title: "手册", Content: content}); err != nil {
+		t.Fatal(err)
+	}
+	items, _, _ := repo.Search(context.TODO(), "重置网络", SearchOptions{Limit: 1, Snippet: SnippetOptions{Length: 20}})
+	if len(items) != 1 {
+		t.Fatalf("items = %+v", items)
+	}
+	// the window ends with the text, 20 runes back
+	if got := marked(items[0].Snippet, items[0].Highlights); got != "果 VPN 无法连接，请先[重置网络]配置。" {
+		t.Fatalf("snippet = %q", got)
+	}
+}
@@ -0,0 +1,86 @@
+package kb
+
+import (
+	"errors"
+	"sort"
+	"strings"
+)
+
+// Tag limits enforced by NormalizeTags.
+const (
+	MaxTags      = 20
+	MaxTagLength = 64
+)
+
+// ErrInvalidTags reports a tag map that NormalizeTags rejects.
+var ErrInvalidTags = errors.New("invalid tags")
+
+// NormalizeTags lowercases and trims tag keys and values. Keys must be non-empty and must
+// not contain ':' (it separates key and value in filters). A nil map stays nil.
+func NormalizeTags(tags map[string]string) (map[string]string, error) {
+	if tags == nil {
+		return nil, nil
+	}
+	if len(tags) > MaxTags {
+		return nil, ErrInvalidTags
+	}
+	out := make(map[string]string, len(tags))
+	for k, v := range tags {
+		k = strings.ToLower(strings.TrimSpace(k))
+		v = strings.ToLower(strings.TrimSpace(v))
+		if k == "" || strings.Contains(k, ":") || len(k) > MaxTagLength || len(v) > MaxTagLength {
+			return nil, ErrInvalidTags
+		}
+		out[k] = v
+	}
+	return out, nil
+}
+
+// TagTerms flattens tags into the terms a filter can match: "key" for every tag and
+// "key:value" for tags with a value. The result is sorted.
+func TagTerms(tags map[string]string) []string {
+	out := make([]string, 0, 2*len(tags))
+	for k, v := range tags {
+		out = append(out, k)
+		if v != "" {
+			out = append(out, k+":"+v)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// normalizeFilter lowercases filter terms and drops blanks and duplicates.
+func normalizeFilter(terms []string) []string {
+	seen := make(map[string]struct{}, len(terms))
+	out := make([]string, 0, len(terms))
+	for _, t := range terms {
+		t = strings.ToLower(strings.TrimSpace(t))
+		if _, dup := seen[t]; t == "" || dup {
+			continue
+		}
+		seen[t] = struct{}{}
+		out = append(out, t)
+	}
+	return out
+}
+
+// matchTags reports whether tags satisfy the filter terms: all of them, or at least one
+// when any is set. An empty filter matches everything.
+func matchTags(tags map[string]string, terms []string, any bool) bool {
+	if len(terms) == 0 {
+		return true
+	}
+	for _, t := range terms {
+		k, v, hasValue := strings.Cut(t, ":")
+		got, ok := tags[k]
+		hit := ok && (!hasValue || got == v)
+		if hit && any {
+			return true
+		}
+		if !hit && !any {
+			return false
+		}
+	}
+	return !any
+}
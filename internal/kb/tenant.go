@@ -0,0 +1,133 @@
+package kb
+
+import (
+	"context"
+	"sync"
+
+	"github.com/gogogo1024/assist-fusion/internal/common"
+)
+
+// tenantRepo routes every operation to the repo of the tenant carried in ctx.
+type tenantRepo struct {
+	newRepo func(tenant string) (Repo, error)
+
+	mu    sync.Mutex
+	repos map[string]Repo
+}
+
+// PerTenant isolates tenants by giving each one its own Repo, created on first use by newRepo
+// (e.g. a fresh memory repo, or an ES repo bound to a per-tenant index).
+func PerTenant(newRepo func(tenant string) (Repo, error)) Repo {
+	return &tenantRepo{newRepo: newRepo, repos: map[string]Repo{}}
+}
+
+func (t *tenantRepo) repo(ctx context.Context) (Repo, error) {
+	tenant := common.TenantFromContext(ctx)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if r, ok := t.repos[tenant]; ok {
+		return r, nil
+	}
+	r, err := t.newRepo(tenant)
+	if err != nil {
+		return nil, err
+	}
+	t.repos[tenant] = r
+	return r, nil
+}
+
+func (t *tenantRepo) Add(ctx context.Context, d *Doc) error {
+	r, err := t.repo(ctx)
+	if err != nil {
+		return err
+	}
+	return r.Add(ctx, d)
+}
+
+func (t *tenantRepo) Get(ctx context.Context, id string) (*Doc, bool) {
+	r, err := t.repo(ctx)
+	if err != nil {
+		return nil, false
+	}
+	return r.Get(ctx, id)
+}
+
+func (t *tenantRepo) Search(ctx context.Context, q string, opts SearchOptions) ([]*Item, int, error) {
+	r, err := t.repo(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	return r.Search(ctx, q, opts)
+}
+
+func (t *tenantRepo) Update(ctx context.Context, d *Doc) error {
+	r, err := t.repo(ctx)
+	if err != nil {
+		return err
+	}
+	return r.Update(ctx, d)
+}
+
+func (t *tenantRepo) Delete(ctx context.Context, id string) error {
+	r, err := t.repo(ctx)
+	if err != nil {
+		return err
+	}
+	return r.Delete(ctx, id)
+}
+
+func (t *tenantRepo) List(ctx context.Context, opts ListOptions) (*DocPage, error) {
+	r, err := t.repo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return r.List(ctx, opts)
+}
+
+func (t *tenantRepo) PutVectors(ctx context.Context, id string, vecs [][]float64) error {
+	r, err := t.repo(ctx)
+	if err != nil {
+		return err
+	}
+	vr, ok := r.(VectorRepo)
+	if !ok {
+		return ErrVectorsNotStored
+	}
+	return vr.PutVectors(ctx, id, vecs)
+}
+
+func (t *tenantRepo) ScanVectors(ctx context.Context, fn func(id string, vecs [][]float64) error) error {
+	r, err := t.repo(ctx)
+	if err != nil {
+		return err
+	}
+	vr, ok := r.(VectorRepo)
+	if !ok {
+		return ErrVectorsNotStored
+	}
+	return vr.ScanVectors(ctx, fn)
+}
+
+func (t *tenantRepo) KNNSearch(ctx context.Context, opts KNNOptions) ([]*Item, error) {
+	r, err := t.repo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	kr, ok := r.(KNNRepo)
+	if !ok {
+		return nil, ErrKNNUnsupported
+	}
+	return kr.KNNSearch(ctx, opts)
+}
+
+func (t *tenantRepo) CountVectors(ctx context.Context) (int, error) {
+	r, err := t.repo(ctx)
+	if err != nil {
+		return 0, err
+	}
+	kr, ok := r.(KNNRepo)
+	if !ok {
+		return 0, ErrKNNUnsupported
+	}
+	return kr.CountVectors(ctx)
+}
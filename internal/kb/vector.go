@@ -0,0 +1,296 @@
+package kb
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ErrDimMismatch reports a vector whose length differs from the index dimension.
+var ErrDimMismatch = errors.New("vector dimension mismatch")
+
+// VectorIndex holds unit-normalized chunk embeddings of one tenant's documents. The first
+// vector fixes the dimension. Search scans every chunk unless the index was created with
+// NewHNSWVectorIndex.
+type VectorIndex struct {
+	writeMu sync.Mutex         // serializes Put and Delete
+	nodes   map[string][]int32 // doc id -> graph nodes; writers only
+
+	mu    sync.RWMutex
+	dim   int
+	docs  map[string][][]float32 // doc id -> vector per chunk index (nil: not embedded)
+	gen   uint64                 // bumped on every change, see VectorSet.Generation
+	hnsw  *HNSWConfig
+	graph *hnsw
+}
+
+// VectorHit is a document returned by VectorIndex.Search with its nearest chunk.
+type VectorHit struct {
+	ID    string
+	Chunk int
+	Score float64 // cosine similarity
+}
+
+func NewVectorIndex() *VectorIndex {
+	return &VectorIndex{docs: map[string][][]float32{}}
+}
+
+// NewHNSWVectorIndex returns an index that answers Search from an HNSW graph over the chunk
+// vectors instead of scanning them all; results are approximate.
+func NewHNSWVectorIndex(cfg HNSWConfig) *VectorIndex {
+	cfg = cfg.withDefaults()
+	vi := NewVectorIndex()
+	vi.hnsw, vi.graph, vi.nodes = &cfg, newHNSW(cfg), map[string][]int32{}
+	return vi
+}
+
+// Put replaces the vectors of document id, one per chunk index; an empty vector marks a
+// chunk that was not embedded.
+func (vi *VectorIndex) Put(id string, vecs [][]float64) error {
+	norm := make([][]float32, len(vecs))
+	dim := 0
+	for i, v := range vecs {
+		if len(v) == 0 {
+			continue
+		}
+		if dim != 0 && len(v) != dim {
+			return ErrDimMismatch
+		}
+		dim = len(v)
+		norm[i] = unitVector(v)
+	}
+	if dim == 0 {
+		vi.Delete(id)
+		return nil
+	}
+	vi.writeMu.Lock()
+	defer vi.writeMu.Unlock()
+	vi.mu.Lock()
+	if vi.dim == 0 {
+		vi.dim = dim
+	}
+	if dim != vi.dim {
+		vi.mu.Unlock()
+		return ErrDimMismatch
+	}
+	vi.docs[id] = norm
+	vi.gen++
+	g := vi.graph
+	vi.mu.Unlock()
+	if g != nil {
+		g.remove(vi.nodes[id])
+		ids := make([]int32, 0, len(norm))
+		for ci, v := range norm {
+			if v != nil {
+				ids = append(ids, g.insert(id, ci, v))
+			}
+		}
+		vi.nodes[id] = ids
+		vi.compact()
+	}
+	return nil
+}
+
+func (vi *VectorIndex) Delete(id string) {
+	vi.writeMu.Lock()
+	defer vi.writeMu.Unlock()
+	vi.mu.Lock()
+	_, ok := vi.docs[id]
+	if ok {
+		delete(vi.docs, id)
+		vi.gen++
+	}
+	g := vi.graph
+	vi.mu.Unlock()
+	if ok && g != nil {
+		g.remove(vi.nodes[id])
+		delete(vi.nodes, id)
+		vi.compact()
+	}
+}
+
+// compact rebuilds a graph that is mostly tombstones from the live vectors. Callers hold
+// writeMu; searches keep using the old graph until the new one is swapped in.
+func (vi *VectorIndex) compact() {
+	if !vi.graph.needsCompaction() {
+		return
+	}
+	vi.mu.RLock()
+	docs := make(map[string][][]float32, len(vi.docs))
+	for id, vecs := range vi.docs {
+		docs[id] = vecs
+	}
+	vi.mu.RUnlock()
+	g, nodes := buildHNSW(*vi.hnsw, docs)
+	vi.mu.Lock()
+	vi.graph, vi.nodes = g, nodes
+	vi.mu.Unlock()
+}
+
+// buildHNSW inserts docs into a new graph in id order, so rebuilds are reproducible.
+func buildHNSW(cfg HNSWConfig, docs map[string][][]float32) (*hnsw, map[string][]int32) {
+	ids := make([]string, 0, len(docs))
+	for id := range docs {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	g, nodes := newHNSW(cfg), make(map[string][]int32, len(docs))
+	for _, id := range ids {
+		for ci, v := range docs[id] {
+			if v != nil {
+				nodes[id] = append(nodes[id], g.insert(id, ci, v))
+			}
+		}
+	}
+	return g, nodes
+}
+
+// Len is the number of documents with vectors.
+func (vi *VectorIndex) Len() int {
+	vi.mu.RLock()
+	defer vi.mu.RUnlock()
+	return len(vi.docs)
+}
+
+// Search returns up to k documents nearest to q, each scored by its nearest chunk, best
+// first. keep, when set, filters documents before they take a slot.
+func (vi *VectorIndex) Search(q []float64, k int, keep func(id string) bool) []VectorHit {
+	if k <= 0 {
+		return nil
+	}
+	qv := unitVector(q)
+	vi.mu.RLock()
+	if len(qv) != vi.dim {
+		vi.mu.RUnlock()
+		return nil
+	}
+	if g := vi.graph; g != nil {
+		ef := vi.hnsw.EfSearch
+		vi.mu.RUnlock()
+		return searchGraph(g, qv, k, max(ef, k), keep)
+	}
+	defer vi.mu.RUnlock()
+	return vi.scan(qv, k, keep)
+}
+
+// scan is the exact search: every chunk is scored. Callers hold mu.
+func (vi *VectorIndex) scan(qv []float32, k int, keep func(id string) bool) []VectorHit {
+	out := make([]VectorHit, 0, len(vi.docs))
+	for id, vecs := range vi.docs {
+		best := VectorHit{ID: id, Chunk: -1, Score: math.Inf(-1)}
+		for ci, v := range vecs {
+			if v == nil {
+				continue
+			}
+			if s := dot(qv, v); s > best.Score {
+				best.Chunk, best.Score = ci, s
+			}
+		}
+		if best.Chunk >= 0 {
+			out = append(out, best)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return itemBefore(out[i].Score, out[i].ID, out[j].Score, out[j].ID) })
+	hits := out[:0]
+	for _, h := range out {
+		if len(hits) == k {
+			break
+		}
+		if keep == nil || keep(h.ID) {
+			hits = append(hits, h)
+		}
+	}
+	return hits
+}
+
+// searchGraph collects the best chunk of up to k documents from the graph. Several chunks
+// of one document and filtered documents use up candidates, so the candidate list doubles
+// until k documents are found or the graph has no more.
+func searchGraph(g *hnsw, qv []float32, k, ef int, keep func(id string) bool) []VectorHit {
+	kept := map[string]bool{}
+	for {
+		cands, more := g.search(qv, ef)
+		hits := make([]VectorHit, 0, k)
+		seen := map[string]bool{}
+		for _, c := range cands {
+			if len(hits) == k {
+				break
+			}
+			if seen[c.doc] {
+				continue
+			}
+			seen[c.doc] = true
+			ok, checked := kept[c.doc]
+			if !checked {
+				ok = keep == nil || keep(c.doc)
+				kept[c.doc] = ok
+			}
+			if ok {
+				hits = append(hits, VectorHit{ID: c.doc, Chunk: c.chunk, Score: c.sim})
+			}
+		}
+		if len(hits) == k || !more {
+			sort.SliceStable(hits, func(i, j int) bool {
+				return itemBefore(hits[i].Score, hits[i].ID, hits[j].Score, hits[j].ID)
+			})
+			return hits
+		}
+		ef *= 2
+	}
+}
+
+func dot(a, b []float32) float64 {
+	var s float64
+	for i := range a {
+		s += float64(a[i]) * float64(b[i])
+	}
+	return s
+}
+
+// unitVector scales v to unit length; a zero vector stays zero.
+func unitVector(v []float64) []float32 {
+	var n float64
+	for _, x := range v {
+		n += x * x
+	}
+	out := make([]float32, len(v))
+	if n == 0 {
+		return out
+	}
+	n = math.Sqrt(n)
+	for i, x := range v {
+		out[i] = float32(x / n)
+	}
+	return out
+}
+
+// EmbedText is the text a chunk is embedded as: the document title, the chunk's heading
+// path and its text, one per line.
+func EmbedText(title string, c Chunk) string {
+	parts := []string{title}
+	if len(c.Heading) > 0 {
+		parts = append(parts, strings.Join(c.Heading, " > "))
+	}
+	return strings.TrimSpace(strings.Join(append(parts, c.Text), "\n"))
+}
+
+// VectorItem builds the search item for a vector hit on d from chunks (d's chunks as
+// indexed); the hit's chunk supplies the heading path and the snippet, its leading window
+// shaped by opts (a semantic match has no highlights).
+func VectorItem(d *Doc, chunks []Chunk, h VectorHit, opts SnippetOptions) *Item {
+	text := d.Content
+	it := &Item{ID: d.ID, Title: d.Title, Score: h.Score, Tags: d.Tags}
+	if h.Chunk >= 0 && h.Chunk < len(chunks) {
+		c := chunks[h.Chunk]
+		text, it.Heading, it.Chunk = c.Text, c.Heading, h.Chunk
+	}
+	it.Snippet, _ = Snippet(text, "", 0, opts)
+	return it
+}
+
+// MatchTags reports whether tags satisfy the filter terms as SearchOptions.Tags would.
+func MatchTags(tags map[string]string, terms []string, any bool) bool {
+	return matchTags(tags, normalizeFilter(terms), any)
+}
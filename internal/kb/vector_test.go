@@ -0,0 +1,28 @@
+package kb
+
+import "testing"
+
+func TestVectorIndexSearch(t *testing.T) {
+	vi := NewVectorIndex()
+	if err := vi.Put("a", [][]float64{{1, 0, 0}, {0, 1, 0}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := vi.Put("b", [][]float64{{0, 0, 2}, nil, {1, 1, 0}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := vi.Put("c", [][]float64{{1, 2}}); err != ErrDimMismatch {
+		t.Fatalf("mixed dims = %v", err)
+	}
+	hits := vi.Search([]float64{0, 3, 0}, 10, nil)
+	if len(hits) != 2 || hits[0].ID != "a" || hits[0].Chunk != 1 || hits[0].Score < 0.999 || hits[1].ID != "b" || hits[1].Chunk != 2 {
+		t.Fatalf("hits = %+v", hits)
+	}
+	hits = vi.Search([]float64{0, 3, 0}, 1, func(id string) bool { return id != "a" })
+	if len(hits) != 1 || hits[0].ID != "b" {
+		t.Fatalf("filtered hits = %+v", hits)
+	}
+	vi.Delete("a")
+	if vi.Len() != 1 || len(vi.Search([]float64{1, 2}, 5, nil)) != 0 {
+		t.Fatalf("after delete len = %d", vi.Len())
+	}
+}
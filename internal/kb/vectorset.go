@@ -0,0 +1,267 @@
+package kb
+
+import (
+	"context"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/gogogo1024/assist-fusion/internal/common"
+)
+
+// VectorRepo is implemented by repos that persist chunk vectors next to the documents, so
+// the vector index can be rebuilt from them after a restart.
+type VectorRepo interface {
+	// PutVectors replaces the stored vectors of document id; vecs[i] belongs to chunk i and
+	// is empty when that chunk was not embedded.
+	PutVectors(ctx context.Context, id string, vecs [][]float64) error
+	// ScanVectors calls fn for every document with stored vectors.
+	ScanVectors(ctx context.Context, fn func(id string, vecs [][]float64) error) error
+}
+
+// ErrVectorsNotStored is returned by VectorRepo methods of repos that keep no vectors.
+var ErrVectorsNotStored = errors.New("repo does not store vectors")
+
+// KNNRepo is implemented by repos that search their stored vectors themselves.
+type KNNRepo interface {
+	// KNNSearch returns up to opts.K documents nearest to opts.Vector, best first, each
+	// with its nearest chunk.
+	KNNSearch(ctx context.Context, opts KNNOptions) ([]*Item, error)
+	// CountVectors is the number of documents with stored vectors.
+	CountVectors(ctx context.Context) (int, error)
+}
+
+// KNNOptions configures KNNRepo.KNNSearch.
+type KNNOptions struct {
+	Vector []float64
+	K      int
+	Tags   []string
+	AnyTag bool
+	// Query, when set, is matched lexically in the same request; a document then scores
+	// QueryBoost times its text score plus VectorBoost times its vector score. Boosts of
+	// 0 count as 1.
+	Query       string
+	QueryBoost  float64
+	VectorBoost float64
+	// Snippet shapes the returned snippets as SearchOptions.Snippet does.
+	Snippet SnippetOptions
+}
+
+// ErrKNNUnsupported is returned by KNNRepo methods when the repo (or its index) cannot
+// search vectors; callers fall back to a VectorIndex.
+var ErrKNNUnsupported = errors.New("repo cannot search vectors")
+
+// VectorSet holds one VectorIndex per tenant so similarity search never crosses tenants.
+// A tenant's index is filled by load on first use (e.g. from a VectorRepo).
+type VectorSet struct {
+	newIndex func() *VectorIndex
+	load     func(ctx context.Context, vi *VectorIndex) error
+
+	mu       sync.Mutex
+	byTenant map[string]*tenantVectors
+}
+
+type tenantVectors struct {
+	mu     sync.Mutex // held while loading
+	loaded bool
+	vi     *VectorIndex
+}
+
+// NewVectorSet returns an empty set whose tenant indexes come from newIndex
+// (NewVectorIndex when nil); load may be nil.
+func NewVectorSet(newIndex func() *VectorIndex, load func(ctx context.Context, vi *VectorIndex) error) *VectorSet {
+	if newIndex == nil {
+		newIndex = NewVectorIndex
+	}
+	return &VectorSet{newIndex: newIndex, load: load, byTenant: map[string]*tenantVectors{}}
+}
+
+func (s *VectorSet) entry(tenant string) *tenantVectors {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tv, ok := s.byTenant[tenant]
+	if !ok {
+		tv = &tenantVectors{vi: s.newIndex(), loaded: s.load == nil}
+		s.byTenant[tenant] = tv
+	}
+	return tv
+}
+
+// Loaded returns the tenant's index if it is already in memory, without loading it.
+func (s *VectorSet) Loaded(ctx context.Context) (*VectorIndex, bool) {
+	s.mu.Lock()
+	tv, ok := s.byTenant[common.TenantFromContext(ctx)]
+	s.mu.Unlock()
+	if !ok {
+		return nil, false
+	}
+	tv.mu.Lock()
+	defer tv.mu.Unlock()
+	return tv.vi, tv.loaded
+}
+
+// ForTenant returns the index of the tenant carried in ctx, loading it first if needed. A
+// failed load is returned with the (partial) index and retried on the next call.
+func (s *VectorSet) ForTenant(ctx context.Context) (*VectorIndex, error) {
+	tv := s.entry(common.TenantFromContext(ctx))
+	tv.mu.Lock()
+	defer tv.mu.Unlock()
+	if !tv.loaded {
+		if err := s.load(ctx, tv.vi); err != nil {
+			return tv.vi, err
+		}
+		tv.loaded = true
+	}
+	return tv.vi, nil
+}
+
+// Generation changes whenever a vector is put or deleted in any tenant.
+func (s *VectorSet) Generation() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var gen uint64
+	for _, tv := range s.byTenant {
+		tv.vi.mu.RLock()
+		gen += tv.vi.gen
+		tv.vi.mu.RUnlock()
+	}
+	return gen
+}
+
+// vectorSnapshot is the gob-encoded content of a snapshot file.
+type vectorSnapshot struct {
+	Version int
+	Tenants map[string]indexSnapshot
+}
+
+type indexSnapshot struct {
+	Dim   int
+	Docs  map[string][][]float32
+	Graph *hnswSnapshot // nil for exact indexes and in version 1
+}
+
+const vectorSnapshotVersion = 2
+
+// WriteSnapshot encodes the vectors of every tenant to w.
+func (s *VectorSet) WriteSnapshot(w io.Writer) error {
+	snap := vectorSnapshot{Version: vectorSnapshotVersion, Tenants: map[string]indexSnapshot{}}
+	s.mu.Lock()
+	for tenant, tv := range s.byTenant {
+		snap.Tenants[tenant] = tv.vi.snapshot()
+	}
+	s.mu.Unlock()
+	return gob.NewEncoder(w).Encode(&snap)
+}
+
+// ReadSnapshot replaces the set's vectors with the ones in r. Restored tenants count as
+// loaded.
+func (s *VectorSet) ReadSnapshot(r io.Reader) error {
+	var snap vectorSnapshot
+	if err := gob.NewDecoder(r).Decode(&snap); err != nil {
+		return err
+	}
+	if snap.Version < 1 || snap.Version > vectorSnapshotVersion {
+		return fmt.Errorf("vector snapshot version %d not supported", snap.Version)
+	}
+	byTenant := make(map[string]*tenantVectors, len(snap.Tenants))
+	for tenant, is := range snap.Tenants {
+		vi := s.newIndex()
+		vi.restore(is)
+		byTenant[tenant] = &tenantVectors{vi: vi, loaded: true}
+	}
+	s.mu.Lock()
+	s.byTenant = byTenant
+	s.mu.Unlock()
+	return nil
+}
+
+// snapshot copies the index; writers wait so documents and graph agree.
+func (vi *VectorIndex) snapshot() indexSnapshot {
+	vi.writeMu.Lock()
+	defer vi.writeMu.Unlock()
+	vi.mu.RLock()
+	is := indexSnapshot{Dim: vi.dim, Docs: make(map[string][][]float32, len(vi.docs))}
+	for id, vecs := range vi.docs {
+		is.Docs[id] = vecs // vectors are replaced, never modified in place
+	}
+	g := vi.graph
+	vi.mu.RUnlock()
+	if g != nil {
+		is.Graph = g.snapshot()
+	}
+	return is
+}
+
+// restore fills a new index from is, rebuilding the graph when the snapshot has none or
+// one built with other parameters.
+func (vi *VectorIndex) restore(is indexSnapshot) {
+	vi.dim = is.Dim
+	if is.Docs != nil {
+		vi.docs = is.Docs
+	}
+	if vi.graph == nil {
+		return
+	}
+	if g, nodes := restoreHNSW(*vi.hnsw, is.Graph, vi.docs); g != nil {
+		vi.graph, vi.nodes = g, nodes
+		return
+	}
+	vi.graph, vi.nodes = buildHNSW(*vi.hnsw, vi.docs)
+}
+
+// SaveFile writes a snapshot to path atomically.
+func (s *VectorSet) SaveFile(path string) error {
+	return writeFileAtomic(path, s.WriteSnapshot)
+}
+
+// writeFileAtomic replaces path with what write produces: a temp file in the same
+// directory is written, fsynced and renamed over path, and the directory is fsynced so
+// the rename survives a crash.
+func writeFileAtomic(path string, write func(io.Writer) error) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if err := write(tmp); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return err
+	}
+	return syncDir(filepath.Dir(path))
+}
+
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
+
+// LoadFile reads a snapshot written by SaveFile; a missing file leaves the set empty.
+func (s *VectorSet) LoadFile(path string) error {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return s.ReadSnapshot(f)
+}
@@ -0,0 +1,68 @@
+package kb
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/gogogo1024/assist-fusion/internal/common"
+)
+
+func TestVectorSetSnapshot(t *testing.T) {
+	acme := common.WithTenant(context.Background(), "acme")
+	set := NewVectorSet(nil, nil)
+	vi, _ := set.ForTenant(acme)
+	if err := vi.Put("d1", [][]float64{{1, 0}, nil, {0, 1}}); err != nil {
+		t.Fatal(err)
+	}
+	other, _ := set.ForTenant(context.Background())
+	_ = other.Put("d2", [][]float64{{1, 1, 1}})
+	gen := set.Generation()
+
+	path := filepath.Join(t.TempDir(), "vectors.snap")
+	if err := set.SaveFile(path); err != nil {
+		t.Fatal(err)
+	}
+	restored := NewVectorSet(nil, nil)
+	if err := restored.LoadFile(path); err != nil {
+		t.Fatal(err)
+	}
+	vi, _ = restored.ForTenant(acme)
+	if hits := vi.Search([]float64{0, 5}, 1, nil); len(hits) != 1 || hits[0].ID != "d1" || hits[0].Chunk != 2 {
+		t.Fatalf("restored hits = %+v", hits)
+	}
+	if err := vi.Put("d3", [][]float64{{1, 2, 3}}); err != ErrDimMismatch {
+		t.Fatalf("restored index lost its dimension: %v", err)
+	}
+	if other, _ := restored.ForTenant(context.Background()); other.Len() != 1 {
+		t.Fatalf("default tenant has %d docs", other.Len())
+	}
+	if set.Generation() != gen {
+		t.Fatal("saving changed the generation")
+	}
+	if err := NewVectorSet(nil, nil).LoadFile(filepath.Join(t.TempDir(), "missing")); err != nil {
+		t.Fatalf("missing snapshot = %v", err)
+	}
+}
+
+func TestVectorSetLoadRetries(t *testing.T) {
+	calls := 0
+	set := NewVectorSet(nil, func(ctx context.Context, vi *VectorIndex) error {
+		calls++
+		if calls == 1 {
+			return errors.New("es down")
+		}
+		return vi.Put("d1", [][]float64{{1, 0}})
+	})
+	if _, err := set.ForTenant(context.Background()); err == nil {
+		t.Fatal("first load should fail")
+	}
+	vi, err := set.ForTenant(context.Background())
+	if err != nil || vi.Len() != 1 {
+		t.Fatalf("second load = %v, %d docs", err, vi.Len())
+	}
+	if _, _ = set.ForTenant(context.Background()); calls != 2 {
+		t.Fatalf("loaded %d times", calls)
+	}
+}
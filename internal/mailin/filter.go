@@ -0,0 +1,52 @@
+package mailin
+
+import (
+	"mime"
+	"strings"
+)
+
+// autoSubjectPrefixes mark out-of-office and similar automatic replies.
+var autoSubjectPrefixes = []string{
+	"auto:", "automatic reply", "autoreply", "auto-reply", "out of office", "out of the office",
+	"自动回复", "自動回覆", "undeliverable:", "undelivered mail", "delivery status notification",
+	"mail delivery failed", "returned mail",
+}
+
+// Automated reports whether m is a bounce or an automatic reply that must not create
+// or update tickets (answering them risks mail loops). reason names the signal that matched.
+func Automated(m *Message) (bool, string) {
+	h := m.Header
+	if v := strings.ToLower(strings.TrimSpace(h.Get("Auto-Submitted"))); v != "" && v != "no" {
+		return true, "auto-submitted"
+	}
+	for _, k := range []string{"X-Autoreply", "X-Autorespond", "X-Autoresponder"} {
+		if h.Get(k) != "" {
+			return true, strings.ToLower(k)
+		}
+	}
+	switch strings.ToLower(strings.TrimSpace(h.Get("Precedence"))) {
+	case "bulk", "junk", "list", "auto_reply":
+		return true, "precedence"
+	}
+	if rp := strings.TrimSpace(h.Get("Return-Path")); rp == "<>" {
+		return true, "null return-path"
+	}
+	if mt, params, err := mime.ParseMediaType(h.Get("Content-Type")); err == nil && mt == "multipart/report" {
+		if rt := strings.ToLower(params["report-type"]); rt == "delivery-status" || rt == "disposition-notification" {
+			return true, "delivery report"
+		}
+	}
+	if m.From != nil {
+		local, _, _ := strings.Cut(strings.ToLower(m.From.Address), "@")
+		if local == "mailer-daemon" || local == "postmaster" {
+			return true, "mailer-daemon"
+		}
+	}
+	subj := strings.ToLower(strings.TrimSpace(m.Subject))
+	for _, p := range autoSubjectPrefixes {
+		if strings.HasPrefix(subj, p) {
+			return true, "subject"
+		}
+	}
+	return false, ""
+}
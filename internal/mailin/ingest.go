@@ -0,0 +1,162 @@
+package mailin
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/gogogo1024/assist-fusion/internal/common"
+	"github.com/gogogo1024/assist-fusion/internal/notify"
+)
+
+// ErrTicketNotFound is returned by a Sink when a referenced ticket does not exist;
+// the message then starts a new thread instead.
+var ErrTicketNotFound = errors.New("mailin: ticket not found")
+
+// Sink applies parsed messages to the ticket store. The ctx carries the sender as actor.
+type Sink interface {
+	CreateTicket(ctx context.Context, m *Message) (ticketID string, err error)
+	AddComment(ctx context.Context, ticketID string, m *Message) error
+}
+
+// Actions reported by Ingestor.Handle.
+const (
+	ActionCreated   = "created"
+	ActionCommented = "commented"
+	ActionIgnored   = "ignored"
+)
+
+// Result describes what happened to one message.
+type Result struct {
+	Action   string
+	TicketID string
+	Reason   string // why a message was ignored
+}
+
+// maxThreads bounds the Message-ID -> ticket map kept for In-Reply-To matching.
+const maxThreads = 10000
+
+// Ingestor routes inbound mail: replies become comments, everything else opens a ticket.
+type Ingestor struct {
+	sink Sink
+
+	mu      sync.Mutex
+	threads map[string]string // Message-ID of ingested mail -> ticket id
+	order   []string
+}
+
+func NewIngestor(sink Sink) *Ingestor {
+	return &Ingestor{sink: sink, threads: map[string]string{}}
+}
+
+// subjectToken matches the "[#<ticket id>]" reference that notify puts in outgoing subjects.
+var subjectToken = regexp.MustCompile(`\[#([0-9a-fA-F-]{8,})\]`)
+
+// HandleRaw parses and ingests one raw RFC 5322 message.
+func (in *Ingestor) HandleRaw(ctx context.Context, raw []byte) (Result, error) {
+	m, err := Parse(bytes.NewReader(raw))
+	if err != nil {
+		return Result{}, fmt.Errorf("%w: %v", ErrMalformed, err)
+	}
+	return in.Handle(ctx, m)
+}
+
+// Handle ingests a parsed message.
+func (in *Ingestor) Handle(ctx context.Context, m *Message) (Result, error) {
+	if auto, reason := Automated(m); auto {
+		return Result{Action: ActionIgnored, Reason: reason}, nil
+	}
+	if m.From == nil || m.From.Address == "" {
+		return Result{Action: ActionIgnored, Reason: "no sender"}, nil
+	}
+	ctx = common.WithActor(ctx, senderActor(m), common.SourceEmail)
+	if id := in.match(m); id != "" {
+		err := in.sink.AddComment(ctx, id, m)
+		if err == nil {
+			in.remember(m.MessageID, id)
+			return Result{Action: ActionCommented, TicketID: id}, nil
+		}
+		if !errors.Is(err, ErrTicketNotFound) {
+			return Result{}, err
+		}
+		log.Printf("[mailin] referenced ticket %s not found, opening a new one", id)
+	}
+	id, err := in.sink.CreateTicket(ctx, m)
+	if err != nil {
+		return Result{}, err
+	}
+	in.remember(m.MessageID, id)
+	return Result{Action: ActionCreated, TicketID: id}, nil
+}
+
+func senderActor(m *Message) common.Actor {
+	addr := strings.ToLower(m.From.Address)
+	name := m.From.Name
+	if name == "" {
+		name = addr
+	}
+	return common.Actor{ID: addr, Kind: common.ActorKindUser, Name: name}
+}
+
+// match finds the ticket a message replies to: subject token first, then the
+// In-Reply-To / References chain (our notification Message-IDs or earlier inbound mail).
+func (in *Ingestor) match(m *Message) string {
+	if sm := subjectToken.FindStringSubmatch(m.Subject); sm != nil {
+		return sm[1]
+	}
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	refs := append(append([]string{}, m.InReplyTo...), m.References...)
+	for _, ref := range refs {
+		if id, ok := notify.TicketIDFromMessageID(ref); ok {
+			return id
+		}
+		if id, ok := in.threads[ref]; ok {
+			return id
+		}
+	}
+	return ""
+}
+
+func (in *Ingestor) remember(msgID, ticketID string) {
+	if msgID == "" {
+		return
+	}
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	if _, ok := in.threads[msgID]; !ok {
+		in.order = append(in.order, msgID)
+	}
+	in.threads[msgID] = ticketID
+	if len(in.order) > maxThreads {
+		delete(in.threads, in.order[0])
+		in.order = in.order[1:]
+	}
+}
+
+// TicketTitle derives a ticket title from the subject, dropping reply/forward prefixes.
+func TicketTitle(m *Message) string {
+	s := strings.TrimSpace(m.Subject)
+	for {
+		trimmed := false
+		for _, p := range []string{"re:", "fw:", "fwd:", "回复:", "回复：", "转发:", "转发："} {
+			if len(s) >= len(p) && strings.EqualFold(s[:len(p)], p) {
+				s = strings.TrimSpace(s[len(p):])
+				trimmed = true
+				break
+			}
+		}
+		if !trimmed {
+			break
+		}
+	}
+	if s == "" {
+		s = "(no subject)"
+	}
+	return s
+}
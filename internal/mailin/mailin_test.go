@@ -0,0 +1,259 @@
+package mailin
+
+import (
+	"context"
+	"encoding/base64"
+	"mime"
+	"net"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"golang.org/x/text/encoding/simplifiedchinese"
+
+	"github.com/gogogo1024/assist-fusion/internal/common"
+	"github.com/gogogo1024/assist-fusion/internal/notify"
+)
+
+func gbk(t *testing.T, s string) string {
+	t.Helper()
+	b, err := simplifiedchinese.GBK.NewEncoder().String(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestParseGBKMultipartWithAttachment(t *testing.T) {
+	subject := mime.BEncoding.Encode("gbk", gbk(t, "打印机无法连接"))
+	raw := "From: =?utf-8?q?Zhang_San?= <ZS@Example.com>\n" +
+		"Subject: " + subject + "\n" +
+		"Message-ID: <m1@example.com>\n" +
+		"MIME-Version: 1.0\n" +
+		"Content-Type: multipart/mixed; boundary=OUTER\n\n" +
+		"--OUTER\n" +
+		"Content-Type: multipart/alternative; boundary=INNER\n\n" +
+		"--INNER\n" +
+		"Content-Type: text/plain; charset=GBK\n" +
+		"Content-Transfer-Encoding: base64\n\n" +
+		base64.StdEncoding.EncodeToString([]byte(gbk(t, "办公室的打印机坏了"))) + "\n" +
+		"--INNER\n" +
+		"Content-Type: text/html; charset=utf-8\n\n" +
+		"<p>html body</p>\n" +
+		"--INNER--\n" +
+		"--OUTER\n" +
+		"Content-Type: image/png; name=\"shot.png\"\n" +
+		"Content-Disposition: attachment; filename=\"shot.png\"\n" +
+		"Content-Transfer-Encoding: base64\n\n" +
+		base64.StdEncoding.EncodeToString([]byte("PNGDATA")) + "\n" +
+		"--OUTER--\n"
+	m, err := Parse(strings.NewReader(raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Subject != "打印机无法连接" || m.From.Name != "Zhang San" || m.MessageID != "<m1@example.com>" {
+		t.Fatalf("unexpected headers: %q %#v %q", m.Subject, m.From, m.MessageID)
+	}
+	if strings.TrimSpace(m.Text) != "办公室的打印机坏了" || !strings.Contains(m.HTML, "html body") {
+		t.Fatalf("unexpected bodies: text=%q html=%q", m.Text, m.HTML)
+	}
+	if len(m.Attachments) != 1 || m.Attachments[0].Filename != "shot.png" || m.Attachments[0].Size != 7 {
+		t.Fatalf("unexpected attachments %#v", m.Attachments)
+	}
+}
+
+func TestParseHTMLOnly(t *testing.T) {
+	raw := "From: a@example.com\nSubject: hi\nContent-Type: text/html; charset=utf-8\nContent-Transfer-Encoding: quoted-printable\n\n" +
+		"<html><head><style>p{}</style></head><body><p>Hello&nbsp;there</p><p>line =\n2</p></body></html>\n"
+	m, err := Parse(strings.NewReader(raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Text != "Hello there\nline 2" {
+		t.Fatalf("unexpected text %q", m.Text)
+	}
+}
+
+func TestAutomated(t *testing.T) {
+	cases := map[string]string{
+		"auto-submitted": "From: a@example.com\nAuto-Submitted: auto-replied\nSubject: x\n\nbody\n",
+		"precedence":     "From: a@example.com\nPrecedence: bulk\nSubject: x\n\nbody\n",
+		"null path":      "Return-Path: <>\nFrom: a@example.com\nSubject: x\n\nbody\n",
+		"daemon":         "From: MAILER-DAEMON@mx.example.com\nSubject: failure\n\nbody\n",
+		"report":         "From: a@example.com\nSubject: x\nContent-Type: multipart/report; report-type=delivery-status; boundary=B\n\n--B\n\nx\n--B--\n",
+		"ooo subject":    "From: a@example.com\nSubject: Automatic reply: on leave\n\nbody\n",
+		"chinese ooo":    "From: a@example.com\nSubject: 自动回复: 休假中\n\nbody\n",
+	}
+	for name, raw := range cases {
+		m, err := Parse(strings.NewReader(raw))
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if auto, _ := Automated(m); !auto {
+			t.Errorf("%s: expected automated", name)
+		}
+	}
+	m, _ := Parse(strings.NewReader("From: a@example.com\nAuto-Submitted: no\nSubject: Re: printer\n\nthanks\n"))
+	if auto, reason := Automated(m); auto {
+		t.Fatalf("human reply flagged as automated (%s)", reason)
+	}
+}
+
+type fakeSink struct {
+	mu       sync.Mutex
+	tickets  map[string]bool
+	comments map[string][]string
+	actors   []common.Actor
+	next     int
+}
+
+func newFakeSink() *fakeSink {
+	return &fakeSink{tickets: map[string]bool{}, comments: map[string][]string{}}
+}
+
+func (s *fakeSink) CreateTicket(ctx context.Context, m *Message) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.next++
+	id := strings.Repeat("0", 7) + string(rune('0'+s.next)) + "-aaaa-bbbb-cccc-000000000000"
+	s.tickets[id] = true
+	a, _ := common.ActorFromContext(ctx)
+	s.actors = append(s.actors, a)
+	return id, nil
+}
+
+func (s *fakeSink) AddComment(ctx context.Context, id string, m *Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.tickets[id] {
+		return ErrTicketNotFound
+	}
+	s.comments[id] = append(s.comments[id], strings.TrimSpace(m.Text))
+	return nil
+}
+
+func TestIngestorThreading(t *testing.T) {
+	sink := newFakeSink()
+	in := NewIngestor(sink)
+	ctx := context.Background()
+	handle := func(raw string) Result {
+		t.Helper()
+		r, err := in.HandleRaw(ctx, []byte(raw))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return r
+	}
+	first := handle("From: Cust <cust@example.com>\nSubject: VPN down\nMessage-ID: <c1@example.com>\n\nhelp\n")
+	if first.Action != ActionCreated || sink.actors[0].ID != "cust@example.com" || sink.actors[0].Name != "Cust" {
+		t.Fatalf("unexpected first result %#v actors=%#v", first, sink.actors)
+	}
+	// subject token
+	r := handle("From: cust@example.com\nSubject: Re: [#" + first.TicketID + "] VPN down\n\nstill down\n")
+	if r.Action != ActionCommented || r.TicketID != first.TicketID {
+		t.Fatalf("subject token not matched: %#v", r)
+	}
+	// reply to one of our notifications
+	r = handle("From: cust@example.com\nSubject: Re: VPN down\nIn-Reply-To: " + notify.MessageID(first.TicketID, "n1", "example.com") + "\n\nworks now\n")
+	if r.Action != ActionCommented || r.TicketID != first.TicketID {
+		t.Fatalf("In-Reply-To notification id not matched: %#v", r)
+	}
+	// reply to the customer's own earlier mail (e.g. a colleague in CC)
+	r = handle("From: boss@example.com\nSubject: Re: VPN down\nReferences: <x@y> <c1@example.com>\n\n+1\n")
+	if r.Action != ActionCommented || r.TicketID != first.TicketID {
+		t.Fatalf("References chain not matched: %#v", r)
+	}
+	if got := sink.comments[first.TicketID]; len(got) != 3 || got[2] != "+1" {
+		t.Fatalf("unexpected comments %#v", got)
+	}
+	// unknown ticket reference starts a new thread
+	r = handle("From: cust@example.com\nSubject: [#deadbeef-0000-0000-0000-000000000000] old\n\nhello\n")
+	if r.Action != ActionCreated {
+		t.Fatalf("expected new ticket for unknown reference: %#v", r)
+	}
+	if r := handle("From: cust@example.com\nAuto-Submitted: auto-replied\nSubject: [#" + first.TicketID + "] Out\n\naway\n"); r.Action != ActionIgnored {
+		t.Fatalf("auto-reply not ignored: %#v", r)
+	}
+	if _, err := in.HandleRaw(ctx, []byte("not a message")); err == nil {
+		t.Fatal("expected parse error")
+	}
+}
+
+func TestTicketTitle(t *testing.T) {
+	for in, want := range map[string]string{"Re: Fwd: RE: printer": "printer", "回复：打印机": "打印机", "": "(no subject)"} {
+		if got := TicketTitle(&Message{Subject: in}); got != want {
+			t.Errorf("TicketTitle(%q)=%q want %q", in, got, want)
+		}
+	}
+}
+
+func TestScanMaildirAndMbox(t *testing.T) {
+	var got []string
+	h := func(_ context.Context, raw []byte) error {
+		m, err := Parse(strings.NewReader(string(raw)))
+		if err != nil {
+			return err
+		}
+		got = append(got, strings.TrimSpace(m.Text))
+		return nil
+	}
+	md := t.TempDir()
+	for _, d := range []string{"new", "cur", "tmp"} {
+		_ = os.Mkdir(filepath.Join(md, d), 0o755)
+	}
+	_ = os.WriteFile(filepath.Join(md, "new", "1.eml"), []byte("From: a@example.com\nSubject: s\n\nmaildir body\n"), 0o600)
+	if n, err := ScanDir(context.Background(), md, h); err != nil || n != 1 {
+		t.Fatalf("maildir scan n=%d err=%v", n, err)
+	}
+	if _, err := os.Stat(filepath.Join(md, "cur", "1.eml:2,S")); err != nil {
+		t.Fatalf("message not moved to cur: %v", err)
+	}
+
+	mb := t.TempDir()
+	mbox := "From a@example.com Mon Jan  1 00:00:00 2024\nFrom: a@example.com\nSubject: one\n\nfirst\n>From the start\n\n" +
+		"From b@example.com Mon Jan  1 00:00:00 2024\nFrom: b@example.com\nSubject: two\n\nsecond\n\n"
+	_ = os.WriteFile(filepath.Join(mb, "inbox.mbox"), []byte(mbox), 0o600)
+	got = nil
+	if n, err := ScanDir(context.Background(), mb, h); err != nil || n != 2 {
+		t.Fatalf("mbox scan n=%d err=%v", n, err)
+	}
+	if got[0] != "first\nFrom the start" || got[1] != "second" {
+		t.Fatalf("unexpected mbox bodies %q", got)
+	}
+	if _, err := os.Stat(filepath.Join(mb, "inbox.mbox.done")); err != nil {
+		t.Fatalf("mbox not marked done: %v", err)
+	}
+}
+
+func TestSMTPServerDeliversToHandler(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make(chan *Message, 1)
+	srv := &SMTPServer{Domain: "mx.test", Handler: func(_ context.Context, raw []byte) error {
+		m, err := Parse(strings.NewReader(string(raw)))
+		if err != nil {
+			return err
+		}
+		got <- m
+		return nil
+	}}
+	go func() { _ = srv.Serve(ln) }()
+	defer srv.Close()
+	msg := "From: cust@example.com\r\nSubject: via smtp\r\n\r\nline one\r\n.leading dot\r\n"
+	if err := smtp.SendMail(ln.Addr().String(), nil, "", []string{"support@mx.test"}, []byte(msg)); err != nil {
+		t.Fatal(err)
+	}
+	m := <-got
+	if m.Subject != "via smtp" || m.Text != "line one\n.leading dot\n" {
+		t.Fatalf("unexpected message %q %q", m.Subject, m.Text)
+	}
+	// the null envelope sender marks a bounce
+	if auto, _ := Automated(m); !auto {
+		t.Fatal("null envelope sender should be treated as a bounce")
+	}
+}
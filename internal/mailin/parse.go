@@ -0,0 +1,209 @@
+// Package mailin turns inbound email into tickets and ticket comments.
+package mailin
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"html"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"regexp"
+	"strings"
+
+	"golang.org/x/text/encoding/htmlindex"
+)
+
+// maxPartDepth bounds multipart nesting.
+const maxPartDepth = 8
+
+// Message is the parsed form of one RFC 5322 message.
+type Message struct {
+	Header      mail.Header
+	From        *mail.Address
+	Subject     string
+	MessageID   string
+	InReplyTo   []string
+	References  []string
+	Text        string // text/plain body, or the HTML body reduced to text
+	HTML        string
+	Attachments []Attachment
+}
+
+// Attachment is a non-body MIME part.
+type Attachment struct {
+	Filename    string
+	ContentType string
+	Size        int64
+}
+
+// wordDecoder decodes RFC 2047 encoded-words in any charset known to x/text (GBK, GB18030, Big5, ...).
+var wordDecoder = &mime.WordDecoder{CharsetReader: charsetReader}
+
+func charsetReader(charset string, r io.Reader) (io.Reader, error) {
+	enc, err := htmlindex.Get(charset)
+	if err != nil {
+		return nil, fmt.Errorf("unsupported charset %q", charset)
+	}
+	return enc.NewDecoder().Reader(r), nil
+}
+
+// Parse reads a full message (headers and body).
+func Parse(r io.Reader) (*Message, error) {
+	raw, err := mail.ReadMessage(r)
+	if err != nil {
+		return nil, err
+	}
+	m := &Message{Header: raw.Header}
+	m.Subject = decodeHeader(raw.Header.Get("Subject"))
+	if from := raw.Header.Get("From"); from != "" {
+		parser := mail.AddressParser{WordDecoder: wordDecoder}
+		if a, err := parser.Parse(from); err == nil {
+			m.From = a
+		}
+	}
+	m.MessageID = strings.TrimSpace(raw.Header.Get("Message-ID"))
+	m.InReplyTo = msgIDs(raw.Header.Get("In-Reply-To"))
+	m.References = msgIDs(raw.Header.Get("References"))
+	if err := m.walk(raw.Header, raw.Body, 0); err != nil {
+		return nil, err
+	}
+	if m.Text == "" && m.HTML != "" {
+		m.Text = htmlToText(m.HTML)
+	}
+	return m, nil
+}
+
+// partHeader is the subset of header access shared by mail.Header and multipart parts.
+type partHeader interface{ Get(string) string }
+
+func (m *Message) walk(h partHeader, body io.Reader, depth int) error {
+	if depth > maxPartDepth {
+		return fmt.Errorf("mime nesting deeper than %d", maxPartDepth)
+	}
+	ctype := h.Get("Content-Type")
+	if ctype == "" {
+		ctype = "text/plain; charset=us-ascii"
+	}
+	mediaType, params, err := mime.ParseMediaType(ctype)
+	if err != nil {
+		mediaType, params = "text/plain", map[string]string{}
+	}
+	if strings.HasPrefix(mediaType, "multipart/") {
+		mr := multipart.NewReader(body, params["boundary"])
+		for {
+			p, err := mr.NextRawPart()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			if err := m.walk(p.Header, p, depth+1); err != nil {
+				return err
+			}
+		}
+	}
+	data, err := io.ReadAll(transferDecoder(h.Get("Content-Transfer-Encoding"), body))
+	if err != nil {
+		return err
+	}
+	disp, dparams, _ := mime.ParseMediaType(h.Get("Content-Disposition"))
+	filename := decodeHeader(dparams["filename"])
+	if filename == "" {
+		filename = decodeHeader(params["name"])
+	}
+	isBody := disp != "attachment" && filename == "" && (mediaType == "text/plain" || mediaType == "text/html")
+	if !isBody {
+		m.Attachments = append(m.Attachments, Attachment{Filename: filename, ContentType: mediaType, Size: int64(len(data))})
+		return nil
+	}
+	text, err := decodeCharset(params["charset"], data)
+	if err != nil {
+		return err
+	}
+	// keep the first body of each kind; later ones are usually quoted forwards
+	if mediaType == "text/html" {
+		if m.HTML == "" {
+			m.HTML = text
+		}
+	} else if m.Text == "" {
+		m.Text = text
+	}
+	return nil
+}
+
+func transferDecoder(enc string, r io.Reader) io.Reader {
+	switch strings.ToLower(strings.TrimSpace(enc)) {
+	case "base64":
+		return base64.NewDecoder(base64.StdEncoding, &newlineStripper{r: r})
+	case "quoted-printable":
+		return quotedprintable.NewReader(r)
+	}
+	return r
+}
+
+// newlineStripper drops CR/LF so the base64 decoder sees one continuous stream.
+type newlineStripper struct{ r io.Reader }
+
+func (s *newlineStripper) Read(p []byte) (int, error) {
+	n, err := s.r.Read(p)
+	j := 0
+	for _, b := range p[:n] {
+		if b != '\r' && b != '\n' {
+			p[j] = b
+			j++
+		}
+	}
+	return j, err
+}
+
+func decodeCharset(charset string, data []byte) (string, error) {
+	switch strings.ToLower(charset) {
+	case "", "utf-8", "utf8", "us-ascii", "ascii":
+		return string(data), nil
+	}
+	r, err := charsetReader(charset, bytes.NewReader(data))
+	if err != nil {
+		return "", err
+	}
+	out, err := io.ReadAll(r)
+	return string(out), err
+}
+
+func decodeHeader(v string) string {
+	if v == "" {
+		return ""
+	}
+	if d, err := wordDecoder.DecodeHeader(v); err == nil {
+		return d
+	}
+	return v
+}
+
+var msgIDPattern = regexp.MustCompile(`<[^<>\s]+>`)
+
+func msgIDs(v string) []string { return msgIDPattern.FindAllString(v, -1) }
+
+var (
+	htmlDropBlocks = regexp.MustCompile(`(?is)<(script|style|head)[^>]*>.*?</(script|style|head)>`)
+	htmlBreaks     = regexp.MustCompile(`(?i)<(br|/p|/div|/li|/tr|/h[1-6])[^>]*>`)
+	htmlTags       = regexp.MustCompile(`<[^>]+>`)
+	blankLines     = regexp.MustCompile(`\n{3,}`)
+)
+
+// htmlToText is a best-effort reduction of an HTML body to readable text.
+func htmlToText(s string) string {
+	s = htmlDropBlocks.ReplaceAllString(s, "")
+	s = htmlBreaks.ReplaceAllString(s, "\n")
+	s = htmlTags.ReplaceAllString(s, "")
+	s = html.UnescapeString(s)
+	lines := strings.Split(s, "\n")
+	for i, l := range lines {
+		lines[i] = strings.TrimSpace(l)
+	}
+	return strings.TrimSpace(blankLines.ReplaceAllString(strings.Join(lines, "\n"), "\n\n"))
+}
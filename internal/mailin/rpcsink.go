@@ -0,0 +1,56 @@
+package mailin
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/gogogo1024/assist-fusion/internal/common"
+	kcommon "github.com/gogogo1024/assist-fusion/kitex_gen/common"
+	"github.com/gogogo1024/assist-fusion/kitex_gen/ticket"
+	"github.com/gogogo1024/assist-fusion/kitex_gen/ticket/ticketservice"
+)
+
+// RPCSink writes to ticket-rpc. The client must use TTHeader so the sender actor reaches the service.
+type RPCSink struct {
+	Client ticketservice.Client
+	// Category is set on tickets opened from email (optional).
+	Category string
+	// Tenant owns every ticket this sink touches; empty means the default tenant.
+	Tenant string
+}
+
+func (s *RPCSink) CreateTicket(ctx context.Context, m *Message) (string, error) {
+	ctx = common.WithTenant(ctx, s.Tenant)
+	customer := strings.ToLower(m.From.Address)
+	note := "via email " + m.MessageID
+	req := &ticket.CreateTicketRequest{Title: TicketTitle(m), Desc: m.Text, Customer: &customer, Note: &note}
+	if s.Category != "" {
+		req.Category = &s.Category
+	}
+	resp, err := s.Client.CreateTicket(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	if len(m.Attachments) > 0 {
+		// attachments of the opening mail are recorded on a comment so their metadata is kept
+		if err := s.AddComment(ctx, resp.Ticket.Id, &Message{From: m.From, Attachments: m.Attachments}); err != nil {
+			return resp.Ticket.Id, err
+		}
+	}
+	return resp.Ticket.Id, nil
+}
+
+func (s *RPCSink) AddComment(ctx context.Context, ticketID string, m *Message) error {
+	ctx = common.WithTenant(ctx, s.Tenant)
+	req := &ticket.AddCommentRequest{Id: ticketID, Body: m.Text}
+	for _, a := range m.Attachments {
+		req.Attachments = append(req.Attachments, &kcommon.Attachment{Name: a.Filename, ContentType: a.ContentType, Size: a.Size})
+	}
+	_, err := s.Client.AddComment(ctx, req)
+	var se *kcommon.ServiceError
+	if errors.As(err, &se) && se.Code == common.ErrCodeNotFound {
+		return ErrTicketNotFound
+	}
+	return err
+}
@@ -0,0 +1,181 @@
+package mailin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/textproto"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SMTPServer is a minimal receive-only SMTP listener (RFC 5321 subset: no AUTH, no TLS).
+// Run it behind an MTA or on a private network; it accepts mail for any recipient.
+type SMTPServer struct {
+	Addr    string
+	Domain  string // announced in the greeting
+	MaxSize int64  // bytes per message; 0 means 10 MiB
+	Handler Handler
+
+	mu sync.Mutex
+	ln net.Listener
+}
+
+const (
+	defaultMaxMessageSize = 10 << 20
+	smtpIdleTimeout       = 5 * time.Minute
+)
+
+// ListenAndServe listens on s.Addr and serves until Close is called.
+func (s *SMTPServer) ListenAndServe() error {
+	ln, err := net.Listen("tcp", s.Addr)
+	if err != nil {
+		return err
+	}
+	return s.Serve(ln)
+}
+
+// Serve accepts connections on ln.
+func (s *SMTPServer) Serve(ln net.Listener) error {
+	s.mu.Lock()
+	s.ln = ln
+	s.mu.Unlock()
+	for {
+		c, err := ln.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+		go s.serveConn(c)
+	}
+}
+
+// Close stops accepting new connections.
+func (s *SMTPServer) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.ln == nil {
+		return nil
+	}
+	return s.ln.Close()
+}
+
+type smtpSession struct {
+	from  string
+	rcpts []string
+	mail  bool // MAIL FROM seen
+}
+
+func (s *SMTPServer) serveConn(c net.Conn) {
+	defer c.Close()
+	tc := textproto.NewConn(c)
+	domain := s.Domain
+	if domain == "" {
+		domain = "localhost"
+	}
+	maxSize := s.MaxSize
+	if maxSize <= 0 {
+		maxSize = defaultMaxMessageSize
+	}
+	reply := func(code int, msg string) { _ = tc.PrintfLine("%d %s", code, msg) }
+	reply(220, domain+" ESMTP assistfusion-mailin")
+	var sess smtpSession
+	for {
+		_ = c.SetDeadline(time.Now().Add(smtpIdleTimeout))
+		line, err := tc.ReadLine()
+		if err != nil {
+			return
+		}
+		verb, arg, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(verb) {
+		case "HELO":
+			reply(250, domain)
+		case "EHLO":
+			_ = tc.PrintfLine("250-%s", domain)
+			_ = tc.PrintfLine("250-SIZE %d", maxSize)
+			_ = tc.PrintfLine("250 8BITMIME")
+		case "MAIL":
+			addr, ok := pathArg(arg, "FROM:")
+			if !ok {
+				reply(501, "syntax: MAIL FROM:<address>")
+				continue
+			}
+			sess = smtpSession{from: addr, mail: true}
+			reply(250, "OK")
+		case "RCPT":
+			addr, ok := pathArg(arg, "TO:")
+			if !ok || addr == "" {
+				reply(501, "syntax: RCPT TO:<address>")
+				continue
+			}
+			if !sess.mail {
+				reply(503, "need MAIL first")
+				continue
+			}
+			sess.rcpts = append(sess.rcpts, addr)
+			reply(250, "OK")
+		case "DATA":
+			if len(sess.rcpts) == 0 {
+				reply(503, "need RCPT first")
+				continue
+			}
+			reply(354, "end data with <CR><LF>.<CR><LF>")
+			dr := tc.DotReader() // undoes dot-stuffing and converts CRLF to LF
+			body, err := io.ReadAll(io.LimitReader(dr, maxSize+1))
+			if err != nil {
+				return
+			}
+			if int64(len(body)) > maxSize {
+				// drain the rest of the message so the session stays in sync
+				_, _ = io.Copy(io.Discard, dr)
+				reply(552, "message exceeds size limit")
+				sess = smtpSession{}
+				continue
+			}
+			// the envelope sender is kept so bounces (null path) are recognized downstream
+			raw := append([]byte(fmt.Sprintf("Return-Path: <%s>\n", sess.from)), body...)
+			if err := s.Handler(context.Background(), raw); err != nil {
+				log.Printf("[mailin] smtp handler: %v", err)
+				reply(451, "temporary failure, try again later")
+			} else {
+				reply(250, "OK queued")
+			}
+			sess = smtpSession{}
+		case "RSET":
+			sess = smtpSession{}
+			reply(250, "OK")
+		case "NOOP":
+			reply(250, "OK")
+		case "VRFY":
+			reply(252, "cannot verify")
+		case "QUIT":
+			reply(221, "bye")
+			return
+		default:
+			reply(502, "command not implemented")
+		}
+	}
+}
+
+// pathArg extracts the address from "FROM:<addr> [params]" / "TO:<addr>". The null path "<>" yields "".
+func pathArg(arg, prefix string) (string, bool) {
+	arg = strings.TrimSpace(arg)
+	if len(arg) < len(prefix) || !strings.EqualFold(arg[:len(prefix)], prefix) {
+		return "", false
+	}
+	rest := strings.TrimSpace(arg[len(prefix):])
+	if !strings.HasPrefix(rest, "<") {
+		return "", false
+	}
+	end := strings.IndexByte(rest, '>')
+	if end < 0 {
+		return "", false
+	}
+	return rest[1:end], true
+}
@@ -0,0 +1,189 @@
+package mailin
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Handler consumes one raw message. A non-nil error means "try again later".
+type Handler func(ctx context.Context, raw []byte) error
+
+// ErrMalformed wraps messages that cannot be parsed; retrying them is pointless.
+var ErrMalformed = errors.New("mailin: malformed message")
+
+// Deliver is a Handler that ingests raw and logs the outcome. Malformed mail is
+// logged and dropped so it does not block a source forever.
+func (in *Ingestor) Deliver(ctx context.Context, raw []byte) error {
+	res, err := in.HandleRaw(ctx, raw)
+	switch {
+	case errors.Is(err, ErrMalformed):
+		log.Printf("[mailin] dropping message: %v", err)
+		return nil
+	case err != nil:
+		return err
+	}
+	if res.Action == ActionIgnored {
+		log.Printf("[mailin] ignored message (%s)", res.Reason)
+	} else {
+		log.Printf("[mailin] %s ticket %s", res.Action, res.TicketID)
+	}
+	return nil
+}
+
+// ScanDir processes a Maildir (a directory with new/ and cur/) or, otherwise,
+// every *.mbox file in dir. It returns the number of messages handled.
+func ScanDir(ctx context.Context, dir string, h Handler) (int, error) {
+	if st, err := os.Stat(filepath.Join(dir, "new")); err == nil && st.IsDir() {
+		return scanMaildir(ctx, dir, h)
+	}
+	files, err := filepath.Glob(filepath.Join(dir, "*.mbox"))
+	if err != nil {
+		return 0, err
+	}
+	total := 0
+	for _, f := range files {
+		n, err := scanMboxFile(ctx, f, h)
+		total += n
+		if err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
+
+// PollDir runs ScanDir every interval until ctx is done.
+func PollDir(ctx context.Context, dir string, interval time.Duration, h Handler) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		if _, err := ScanDir(ctx, dir, h); err != nil {
+			log.Printf("[mailin] scan %s: %v", dir, err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+	}
+}
+
+// scanMaildir handles dir/new and moves each handled file to dir/cur with the Seen flag.
+// Messages whose handler fails stay in new/ and are retried on the next scan.
+func scanMaildir(ctx context.Context, dir string, h Handler) (int, error) {
+	entries, err := os.ReadDir(filepath.Join(dir, "new"))
+	if err != nil {
+		return 0, err
+	}
+	n := 0
+	for _, e := range entries {
+		if e.IsDir() || strings.HasPrefix(e.Name(), ".") {
+			continue
+		}
+		src := filepath.Join(dir, "new", e.Name())
+		raw, err := os.ReadFile(src)
+		if err != nil {
+			return n, err
+		}
+		if err := h(ctx, raw); err != nil {
+			return n, fmt.Errorf("%s: %w", e.Name(), err)
+		}
+		if err := os.Rename(src, filepath.Join(dir, "cur", e.Name()+":2,S")); err != nil {
+			return n, err
+		}
+		n++
+	}
+	return n, nil
+}
+
+// scanMboxFile handles every message in path and renames it to *.done. On failure the
+// unprocessed tail is written back so already-ingested messages are not repeated.
+func scanMboxFile(ctx context.Context, path string, h Handler) (int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	msgs, err := SplitMbox(f)
+	f.Close()
+	if err != nil {
+		return 0, err
+	}
+	for i, raw := range msgs {
+		if err := h(ctx, raw); err != nil {
+			if werr := writeMbox(path, msgs[i:]); werr != nil {
+				return i, werr
+			}
+			return i, fmt.Errorf("%s message %d: %w", filepath.Base(path), i, err)
+		}
+	}
+	return len(msgs), os.Rename(path, path+".done")
+}
+
+// SplitMbox splits an mboxrd stream on "From " separator lines and un-escapes ">From " lines.
+func SplitMbox(r io.Reader) ([][]byte, error) {
+	var (
+		out [][]byte
+		cur *bytes.Buffer
+	)
+	br := bufio.NewReader(r)
+	for {
+		line, err := br.ReadBytes('\n')
+		if len(line) > 0 {
+			switch {
+			case bytes.HasPrefix(line, []byte("From ")):
+				if cur != nil {
+					out = append(out, trimMboxMessage(cur.Bytes()))
+				}
+				cur = &bytes.Buffer{}
+			case cur != nil:
+				if unq := bytes.TrimLeft(line, ">"); len(unq) < len(line) && bytes.HasPrefix(unq, []byte("From ")) {
+					line = line[1:]
+				}
+				cur.Write(line)
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if cur != nil {
+		out = append(out, trimMboxMessage(cur.Bytes()))
+	}
+	return out, nil
+}
+
+// trimMboxMessage drops the blank line that separates messages in an mbox.
+func trimMboxMessage(b []byte) []byte {
+	b = bytes.TrimSuffix(b, []byte("\n"))
+	return bytes.TrimSuffix(b, []byte("\r"))
+}
+
+func writeMbox(path string, msgs [][]byte) error {
+	var b bytes.Buffer
+	for _, m := range msgs {
+		b.WriteString("From MAILER-DAEMON Thu Jan  1 00:00:00 1970\n")
+		for _, line := range bytes.SplitAfter(m, []byte("\n")) {
+			if bytes.HasPrefix(bytes.TrimLeft(line, ">"), []byte("From ")) {
+				b.WriteByte('>')
+			}
+			b.Write(line)
+		}
+		b.WriteString("\n\n")
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, b.Bytes(), 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,125 @@
+package notify
+
+import (
+	"context"
+	"sync"
+)
+
+// defaultInboxLimit caps stored notifications per user; the oldest are evicted first.
+const defaultInboxLimit = 500
+
+// Notification is an inbox entry with its read state.
+type Notification struct {
+	Message
+	Read bool `json:"read"`
+}
+
+// Inbox is the in-memory store behind the in-app channel, partitioned by tenant.
+type Inbox struct {
+	mu     sync.Mutex
+	limit  int
+	byUser map[inboxKey][]*Notification // oldest first
+}
+
+type inboxKey struct{ tenant, user string }
+
+func NewInbox(limit int) *Inbox {
+	return &Inbox{limit: limit, byUser: map[inboxKey][]*Notification{}}
+}
+
+// Add stores m for m.UserID in m.Tenant as unread.
+func (b *Inbox) Add(m Message) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	k := inboxKey{m.Tenant, m.UserID}
+	list := append(b.byUser[k], &Notification{Message: m})
+	if b.limit > 0 && len(list) > b.limit {
+		list = list[len(list)-b.limit:]
+	}
+	b.byUser[k] = list
+}
+
+// List returns the newest notifications first (at most limit when > 0) and the unread count.
+func (b *Inbox) List(tenant, userID string, unreadOnly bool, limit int) ([]Notification, int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	list := b.byUser[inboxKey{tenant, userID}]
+	out := make([]Notification, 0, len(list))
+	unread := 0
+	for i := len(list) - 1; i >= 0; i-- {
+		n := list[i]
+		if !n.Read {
+			unread++
+		}
+		if unreadOnly && n.Read {
+			continue
+		}
+		if limit > 0 && len(out) >= limit {
+			continue
+		}
+		out = append(out, *n)
+	}
+	return out, unread
+}
+
+// Mark sets the read state of the given ids (or every entry when all is true)
+// and returns how many changed plus the remaining unread count.
+func (b *Inbox) Mark(tenant, userID string, ids []string, all, read bool) (updated, unread int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	want := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		want[id] = true
+	}
+	for _, n := range b.byUser[inboxKey{tenant, userID}] {
+		if (all || want[n.ID]) && n.Read != read {
+			n.Read = read
+			updated++
+		}
+		if !n.Read {
+			unread++
+		}
+	}
+	return updated, unread
+}
+
+// Erase drops every notification of userID in tenant and returns how many were removed.
+func (b *Inbox) Erase(tenant, userID string) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	k := inboxKey{tenant, userID}
+	n := len(b.byUser[k])
+	delete(b.byUser, k)
+	return n
+}
+
+// Rewrite applies fn to the subject and body of every notification in tenant and
+// returns how many fields changed (used to redact an erased data subject).
+func (b *Inbox) Rewrite(tenant string, fn func(string) string) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	changed := 0
+	for k, list := range b.byUser {
+		if k.tenant != tenant {
+			continue
+		}
+		for _, n := range list {
+			for _, f := range []*string{&n.Subject, &n.Body} {
+				if v := fn(*f); v != *f {
+					*f = v
+					changed++
+				}
+			}
+		}
+	}
+	return changed
+}
+
+type inboxChannel struct{ inbox *Inbox }
+
+func (inboxChannel) Name() string { return ChannelInbox }
+
+func (c inboxChannel) Send(_ context.Context, _ Preferences, m Message) error {
+	c.inbox.Add(m)
+	return nil
+}
@@ -0,0 +1,250 @@
+// Package notify turns ticket events into per-user messages and delivers them
+// through pluggable channels (in-app inbox, SMTP, webhook) with retries.
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/gogogo1024/assist-fusion/internal/common"
+	"github.com/gogogo1024/assist-fusion/internal/observability"
+)
+
+// Channel names used in user preferences.
+const (
+	ChannelInbox   = "inbox"
+	ChannelEmail   = "email"
+	ChannelWebhook = "webhook"
+)
+
+// Recipient roles.
+const (
+	RoleCustomer = "customer"
+	RoleAssignee = "assignee"
+	RoleWatcher  = "watcher"
+)
+
+// ErrNoAddress is returned by a channel when the recipient has no address for it;
+// such deliveries are skipped rather than retried.
+var ErrNoAddress = errors.New("notify: recipient has no address for channel")
+
+// Message is one notification for one user.
+type Message struct {
+	ID          string       `json:"id"`
+	Tenant      string       `json:"tenant"`
+	UserID      string       `json:"user_id"`
+	Role        string       `json:"role"`
+	TicketID    string       `json:"ticket_id"`
+	TicketTitle string       `json:"ticket_title"`
+	EventType   string       `json:"event_type"`
+	Subject     string       `json:"subject"`
+	Body        string       `json:"body"`
+	Actor       common.Actor `json:"actor"`
+	At          int64        `json:"at"`
+}
+
+// Channel delivers a message to a user using the address in their preferences.
+type Channel interface {
+	Name() string
+	Send(ctx context.Context, p Preferences, m Message) error
+}
+
+type delivery struct {
+	ch    Channel
+	prefs Preferences
+	msg   Message
+}
+
+// Dispatcher fans ticket events out to recipients and delivers them asynchronously.
+type Dispatcher struct {
+	channels    map[string]Channel
+	prefs       PrefStore
+	inbox       *Inbox
+	queue       chan delivery
+	workers     int
+	maxAttempts int
+	backoff     time.Duration
+	wg          sync.WaitGroup
+	mu          sync.RWMutex // guards closed against sends on the closed queue
+	closed      bool
+}
+
+type Option func(*Dispatcher)
+
+// WithChannel registers an additional delivery channel (keyed by Name()).
+func WithChannel(ch Channel) Option { return func(d *Dispatcher) { d.channels[ch.Name()] = ch } }
+
+// WithPrefStore replaces the default in-memory preference store.
+func WithPrefStore(p PrefStore) Option { return func(d *Dispatcher) { d.prefs = p } }
+
+// WithRetry sets the attempts per delivery and the initial backoff (doubled after each failure).
+func WithRetry(attempts int, backoff time.Duration) Option {
+	return func(d *Dispatcher) { d.maxAttempts, d.backoff = attempts, backoff }
+}
+
+// WithWorkers sets the number of delivery goroutines.
+func WithWorkers(n int) Option { return func(d *Dispatcher) { d.workers = n } }
+
+// NewDispatcher starts the delivery workers. The in-app inbox channel is always registered.
+func NewDispatcher(opts ...Option) *Dispatcher {
+	d := &Dispatcher{
+		channels:    map[string]Channel{},
+		prefs:       NewMemoryPrefStore(),
+		inbox:       NewInbox(defaultInboxLimit),
+		workers:     2,
+		maxAttempts: 3,
+		backoff:     500 * time.Millisecond,
+	}
+	d.channels[ChannelInbox] = inboxChannel{d.inbox}
+	for _, o := range opts {
+		o(d)
+	}
+	if d.workers < 1 {
+		d.workers = 1
+	}
+	if d.maxAttempts < 1 {
+		d.maxAttempts = 1
+	}
+	d.queue = make(chan delivery, 1024)
+	for i := 0; i < d.workers; i++ {
+		d.wg.Add(1)
+		go d.run()
+	}
+	return d
+}
+
+// Inbox returns the in-app inbox backing the inbox channel.
+func (d *Dispatcher) Inbox() *Inbox { return d.inbox }
+
+// Prefs returns the preference store.
+func (d *Dispatcher) Prefs() PrefStore { return d.prefs }
+
+// Close stops accepting work and waits for queued deliveries to finish. Later Notify
+// calls drop their deliveries.
+func (d *Dispatcher) Close() {
+	d.mu.Lock()
+	if !d.closed {
+		d.closed = true
+		close(d.queue)
+	}
+	d.mu.Unlock()
+	d.wg.Wait()
+}
+
+// Recipients returns who should hear about ev on t: customer, assignee and watchers,
+// de-duplicated (first role wins) and excluding the actor who caused the event.
+func Recipients(t *common.Ticket, ev common.TicketEvent) map[string]string {
+	out := map[string]string{}
+	add := func(id, role string) {
+		if id == "" || id == ev.Actor.ID {
+			return
+		}
+		if _, ok := out[id]; !ok {
+			out[id] = role
+		}
+	}
+	add(t.Customer, RoleCustomer)
+	add(t.Assignee, RoleAssignee)
+	for _, w := range t.Watchers {
+		add(w, RoleWatcher)
+	}
+	return out
+}
+
+// Notify enqueues one message per recipient and preferred channel. It never blocks the caller;
+// deliveries that do not fit in the queue, or arrive after Close, are dropped and counted.
+func (d *Dispatcher) Notify(ctx context.Context, t *common.Ticket, ev common.TicketEvent) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	if d.closed {
+		observability.NotifyDropped.Add(1)
+		return
+	}
+	for userID, role := range Recipients(t, ev) {
+		p, _ := d.prefs.Get(ctx, userID)
+		msg := newMessage(t, ev, userID, role)
+		for _, name := range p.Channels {
+			ch, ok := d.channels[name]
+			if !ok {
+				continue
+			}
+			select {
+			case d.queue <- delivery{ch: ch, prefs: p, msg: msg}:
+			default:
+				observability.NotifyDropped.Add(1)
+			}
+		}
+	}
+}
+
+func newMessage(t *common.Ticket, ev common.TicketEvent, userID, role string) Message {
+	body := fmt.Sprintf("%s: ticket %q %s by %s", role, t.Title, ev.Type, ev.Actor.Name)
+	if text := eventText(t, ev); text != "" {
+		body += "\n\n" + text
+	}
+	return Message{
+		ID:          uuid.NewString(),
+		Tenant:      t.Tenant,
+		UserID:      userID,
+		Role:        role,
+		TicketID:    t.ID,
+		TicketTitle: t.Title,
+		EventType:   ev.Type,
+		Subject:     Subject(t.ID, t.Title),
+		Body:        body,
+		Actor:       ev.Actor,
+		At:          ev.At,
+	}
+}
+
+// eventText is the human text of an event; comment events reference the comment by id.
+func eventText(t *common.Ticket, ev common.TicketEvent) string {
+	if ev.Type == "commented" {
+		for i := len(t.Comments) - 1; i >= 0; i-- {
+			if t.Comments[i].ID == ev.Note {
+				return t.Comments[i].Body
+			}
+		}
+	}
+	return ev.Note
+}
+
+// Subject carries the ticket token "[#<id>]" so that email replies can be threaded back.
+func Subject(ticketID, title string) string {
+	return fmt.Sprintf("[#%s] %s", ticketID, title)
+}
+
+func (d *Dispatcher) run() {
+	defer d.wg.Done()
+	for dl := range d.queue {
+		d.deliver(dl)
+	}
+}
+
+func (d *Dispatcher) deliver(dl delivery) {
+	wait := d.backoff
+	for attempt := 1; ; attempt++ {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		err := dl.ch.Send(ctx, dl.prefs, dl.msg)
+		cancel()
+		switch {
+		case err == nil:
+			observability.NotifySent.Add(1)
+			return
+		case errors.Is(err, ErrNoAddress):
+			return
+		case attempt >= d.maxAttempts:
+			observability.NotifyFailed.Add(1)
+			log.Printf("[notify] %s to %s failed after %d attempts: %v", dl.ch.Name(), dl.msg.UserID, attempt, err)
+			return
+		}
+		time.Sleep(wait)
+		wait *= 2
+	}
+}
@@ -0,0 +1,84 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gogogo1024/assist-fusion/internal/common"
+)
+
+type flakyChannel struct {
+	fails atomic.Int32 // remaining failures before success
+	calls atomic.Int32
+	mu    sync.Mutex
+	got   []Message
+}
+
+func (c *flakyChannel) Name() string { return ChannelWebhook }
+
+func (c *flakyChannel) Send(_ context.Context, _ Preferences, m Message) error {
+	c.calls.Add(1)
+	if c.fails.Add(-1) >= 0 {
+		return errors.New("temporary")
+	}
+	c.mu.Lock()
+	c.got = append(c.got, m)
+	c.mu.Unlock()
+	return nil
+}
+
+func TestRecipientsExcludeActorAndDedupe(t *testing.T) {
+	tk := &common.Ticket{ID: "t1", Customer: "c", Assignee: "a", Watchers: []string{"w", "c", "a"}}
+	got := Recipients(tk, common.TicketEvent{Type: "assigned", Actor: common.Actor{ID: "a"}})
+	if len(got) != 2 || got["c"] != RoleCustomer || got["w"] != RoleWatcher {
+		t.Fatalf("unexpected recipients %#v", got)
+	}
+}
+
+func TestDispatcherRetriesAndHonoursPrefs(t *testing.T) {
+	ch := &flakyChannel{}
+	ch.fails.Store(2)
+	d := NewDispatcher(WithChannel(ch), WithRetry(3, time.Millisecond), WithWorkers(1))
+	t.Cleanup(d.Close)
+	ctx := context.Background()
+	_ = d.Prefs().Set(ctx, "w", Preferences{Channels: []string{ChannelWebhook}, WebhookURL: "http://example.invalid/hook"})
+
+	tk := &common.Ticket{ID: "t1", Tenant: "acme", Title: "printer", Customer: "c", Watchers: []string{"w"}}
+	d.Notify(ctx, tk, common.TicketEvent{Type: "resolved", Actor: common.Actor{ID: "agent", Name: "Agent"}})
+	d.Close()
+
+	if ch.calls.Load() != 3 || len(ch.got) != 1 || ch.got[0].UserID != "w" {
+		t.Fatalf("expected 3 attempts and one webhook delivery to w, calls=%d got=%#v", ch.calls.Load(), ch.got)
+	}
+	// c kept the default (inbox only); w opted out of the inbox
+	if items, unread := d.Inbox().List("acme", "c", false, 0); len(items) != 1 || unread != 1 || items[0].Subject != "[#t1] printer" {
+		t.Fatalf("unexpected inbox for c: %#v", items)
+	}
+	if items, _ := d.Inbox().List("acme", "w", false, 0); len(items) != 0 {
+		t.Fatalf("w should have no inbox items: %#v", items)
+	}
+	// inboxes are per tenant
+	if items, _ := d.Inbox().List(common.DefaultTenant, "c", false, 0); len(items) != 0 {
+		t.Fatalf("c in another tenant should have no inbox items: %#v", items)
+	}
+	// events after Close are dropped rather than sent on the closed queue
+	d.Notify(ctx, tk, common.TicketEvent{Type: "reopened", Actor: common.Actor{ID: "agent"}})
+	if items, _ := d.Inbox().List("acme", "c", false, 0); len(items) != 1 {
+		t.Fatalf("delivery after close: %#v", items)
+	}
+}
+
+func TestMessageIDRoundTrip(t *testing.T) {
+	id := MessageID("5f0c1a2e-1111-2222-3333-444455556666", "m1", "mail.example.com")
+	got, ok := TicketIDFromMessageID(id)
+	if !ok || got != "5f0c1a2e-1111-2222-3333-444455556666" {
+		t.Fatalf("round trip failed: %q %v", got, ok)
+	}
+	if _, ok := TicketIDFromMessageID("<abc@example.com>"); ok {
+		t.Fatal("foreign message id should not match")
+	}
+}
@@ -0,0 +1,97 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/gogogo1024/assist-fusion/internal/common"
+)
+
+// Preferences are a user's delivery settings.
+type Preferences struct {
+	Channels   []string `json:"channels"`
+	Email      string   `json:"email"`
+	WebhookURL string   `json:"webhook_url"`
+}
+
+// DefaultPreferences apply to users who never saved any: in-app inbox only,
+// plus email for users identified by an email address (e.g. customers who wrote in by mail).
+func DefaultPreferences(userID string) Preferences {
+	if strings.Contains(userID, "@") {
+		return Preferences{Channels: []string{ChannelInbox, ChannelEmail}, Email: userID}
+	}
+	return Preferences{Channels: []string{ChannelInbox}}
+}
+
+// Validate normalizes channel names and checks addresses for the selected channels.
+func (p *Preferences) Validate() error {
+	seen := map[string]bool{}
+	chans := make([]string, 0, len(p.Channels))
+	for _, c := range p.Channels {
+		c = strings.ToLower(strings.TrimSpace(c))
+		switch c {
+		case ChannelInbox, ChannelEmail, ChannelWebhook:
+		default:
+			return fmt.Errorf("unknown channel %q", c)
+		}
+		if !seen[c] {
+			seen[c] = true
+			chans = append(chans, c)
+		}
+	}
+	p.Channels = chans
+	if seen[ChannelEmail] && !strings.Contains(p.Email, "@") {
+		return fmt.Errorf("email channel requires an email address")
+	}
+	if seen[ChannelWebhook] || p.WebhookURL != "" {
+		u, err := url.Parse(p.WebhookURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("webhook channel requires an http(s) url")
+		}
+	}
+	return nil
+}
+
+// PrefStore persists per-user preferences; users are scoped by the tenant in ctx.
+type PrefStore interface {
+	// Get returns the stored preferences, or DefaultPreferences when none exist.
+	Get(ctx context.Context, userID string) (Preferences, error)
+	Set(ctx context.Context, userID string, p Preferences) error
+	// Delete forgets the user's preferences (subject erasure).
+	Delete(ctx context.Context, userID string) error
+}
+
+type memoryPrefStore struct {
+	mu sync.RWMutex
+	m  map[inboxKey]Preferences
+}
+
+func NewMemoryPrefStore() PrefStore {
+	return &memoryPrefStore{m: map[inboxKey]Preferences{}}
+}
+
+func (s *memoryPrefStore) Get(ctx context.Context, userID string) (Preferences, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if p, ok := s.m[inboxKey{common.TenantFromContext(ctx), userID}]; ok {
+		return p, nil
+	}
+	return DefaultPreferences(userID), nil
+}
+
+func (s *memoryPrefStore) Set(ctx context.Context, userID string, p Preferences) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.m[inboxKey{common.TenantFromContext(ctx), userID}] = p
+	return nil
+}
+
+func (s *memoryPrefStore) Delete(ctx context.Context, userID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.m, inboxKey{common.TenantFromContext(ctx), userID})
+	return nil
+}
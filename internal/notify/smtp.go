@@ -0,0 +1,90 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// SMTPConfig configures the email channel.
+type SMTPConfig struct {
+	Addr     string // host:port
+	From     string
+	Username string // optional; PLAIN auth when set
+	Password string
+	// Domain is used in Message-ID headers (defaults to the host part of Addr).
+	Domain string
+}
+
+// SMTPChannel sends notifications as plain-text email.
+type SMTPChannel struct {
+	cfg  SMTPConfig
+	send func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+func NewSMTPChannel(cfg SMTPConfig) *SMTPChannel {
+	if cfg.Domain == "" {
+		cfg.Domain, _, _ = net.SplitHostPort(cfg.Addr)
+	}
+	return &SMTPChannel{cfg: cfg, send: smtp.SendMail}
+}
+
+func (c *SMTPChannel) Name() string { return ChannelEmail }
+
+func (c *SMTPChannel) Send(_ context.Context, p Preferences, m Message) error {
+	if p.Email == "" {
+		return ErrNoAddress
+	}
+	var auth smtp.Auth
+	if c.cfg.Username != "" {
+		host, _, _ := net.SplitHostPort(c.cfg.Addr)
+		auth = smtp.PlainAuth("", c.cfg.Username, c.cfg.Password, host)
+	}
+	return c.send(c.cfg.Addr, auth, c.cfg.From, []string{p.Email}, c.render(p.Email, m))
+}
+
+func (c *SMTPChannel) render(to string, m Message) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\n", c.cfg.From)
+	fmt.Fprintf(&b, "To: %s\r\n", to)
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", m.Subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Unix(m.At, 0).UTC().Format(time.RFC1123Z))
+	fmt.Fprintf(&b, "Message-ID: %s\r\n", MessageID(m.TicketID, m.ID, c.cfg.Domain))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	b.WriteString("Auto-Submitted: auto-generated\r\n\r\n")
+	b.WriteString(strings.ReplaceAll(m.Body, "\n", "\r\n"))
+	b.WriteString("\r\n")
+	return b.Bytes()
+}
+
+// messageIDPrefix marks Message-IDs minted for ticket notifications.
+const messageIDPrefix = "ticket."
+
+// MessageID embeds the ticket id so replies (In-Reply-To / References) can be mapped back.
+func MessageID(ticketID, msgID, domain string) string {
+	return fmt.Sprintf("<%s%s.%s@%s>", messageIDPrefix, ticketID, msgID, domain)
+}
+
+// TicketIDFromMessageID extracts the ticket id from a Message-ID produced by MessageID.
+func TicketIDFromMessageID(id string) (string, bool) {
+	id = strings.TrimSpace(id)
+	id = strings.TrimSuffix(strings.TrimPrefix(id, "<"), ">")
+	local, _, ok := strings.Cut(id, "@")
+	if !ok || !strings.HasPrefix(local, messageIDPrefix) {
+		return "", false
+	}
+	rest := strings.TrimPrefix(local, messageIDPrefix)
+	// ticket ids are UUIDs (contain no dots); the message id follows the last dot
+	i := strings.LastIndex(rest, ".")
+	if i <= 0 {
+		return "", false
+	}
+	return rest[:i], true
+}
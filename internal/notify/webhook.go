@@ -0,0 +1,48 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// WebhookChannel POSTs the message as JSON to the user's webhook URL.
+type WebhookChannel struct {
+	client *http.Client
+}
+
+func NewWebhookChannel(client *http.Client) *WebhookChannel {
+	if client == nil {
+		client = &http.Client{Timeout: 5 * time.Second}
+	}
+	return &WebhookChannel{client: client}
+}
+
+func (c *WebhookChannel) Name() string { return ChannelWebhook }
+
+func (c *WebhookChannel) Send(ctx context.Context, p Preferences, m Message) error {
+	if p.WebhookURL == "" {
+		return ErrNoAddress
+	}
+	body, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -6,17 +6,36 @@ import (
 )
 
 var (
-	TicketCreated    atomic.Int64
-	TicketAssigned   atomic.Int64
-	TicketEscalated  atomic.Int64
-	TicketResolved   atomic.Int64
-	TicketReopened   atomic.Int64
-	KBDocCreated     atomic.Int64
-	KBDocUpdated     atomic.Int64
-	KBDocDeleted     atomic.Int64
-	KBSearchRequests atomic.Int64
-	KBSearchHits     atomic.Int64
-	AIEmbeddingCalls atomic.Int64
+	TicketCreated     atomic.Int64
+	TicketAssigned    atomic.Int64
+	TicketEscalated   atomic.Int64
+	TicketDeescalated atomic.Int64
+	TicketResolved    atomic.Int64
+	TicketReopened    atomic.Int64
+	KBDocCreated      atomic.Int64
+	KBDocUpdated      atomic.Int64
+	KBDocDeleted      atomic.Int64
+	KBSearchRequests  atomic.Int64
+	KBSearchHits      atomic.Int64
+	AIEmbeddingCalls  atomic.Int64
+
+	// Ticket lifecycle (soft delete / archive / retention)
+	TicketDeleted    atomic.Int64
+	TicketArchived   atomic.Int64
+	TicketAnonymized atomic.Int64
+	TicketPurged     atomic.Int64
+
+	// Pull queue claims
+	TicketClaimed      atomic.Int64
+	TicketClaimExpired atomic.Int64
+
+	// Ticket CSAT survey answers
+	TicketCSATSubmitted atomic.Int64
+
+	// Notification deliveries
+	NotifySent    atomic.Int64
+	NotifyFailed  atomic.Int64
+	NotifyDropped atomic.Int64
 
 	// AI provider granular counters
 	AIEmbeddingSuccessMock  atomic.Int64
@@ -25,6 +44,8 @@ var (
 	AIChatSuccessMock       atomic.Int64
 	AIChatFallbackMock      atomic.Int64
 	AIChatError             atomic.Int64
+	// requests rejected by per-tenant quotas
+	AIQuotaRejected atomic.Int64
 
 	// OpenAI specific (attempted provider stats)
 	AIEmbeddingSuccessOpenAI  atomic.Int64
@@ -41,8 +62,19 @@ func Snapshot() string {
 assistfusion_ticket_created_total %d
 assistfusion_ticket_assigned_total %d
 assistfusion_ticket_escalated_total %d
+assistfusion_ticket_deescalated_total %d
 assistfusion_ticket_resolved_total %d
 assistfusion_ticket_reopened_total %d
+assistfusion_ticket_deleted_total %d
+assistfusion_ticket_archived_total %d
+assistfusion_ticket_anonymized_total %d
+assistfusion_ticket_purged_total %d
+assistfusion_ticket_claimed_total %d
+assistfusion_ticket_claim_expired_total %d
+assistfusion_ticket_csat_submitted_total %d
+assistfusion_notify_sent_total %d
+assistfusion_notify_failed_total %d
+assistfusion_notify_dropped_total %d
 assistfusion_kb_doc_created_total %d
 assistfusion_kb_doc_updated_total %d
 assistfusion_kb_doc_deleted_total %d
@@ -55,6 +87,7 @@ assistfusion_ai_embedding_error_total %d
 assistfusion_ai_chat_success_mock_total %d
 assistfusion_ai_chat_fallback_mock_total %d
 assistfusion_ai_chat_error_total %d
+assistfusion_ai_quota_rejected_total %d
 assistfusion_ai_embedding_success_openai_total %d
 assistfusion_ai_embedding_fallback_openai_total %d
 assistfusion_ai_embedding_error_openai_total %d
@@ -73,8 +106,19 @@ assistfusion_ai_provider_calls_total{provider="openai",result="error"} %d
 		TicketCreated.Load(),
 		TicketAssigned.Load(),
 		TicketEscalated.Load(),
+		TicketDeescalated.Load(),
 		TicketResolved.Load(),
 		TicketReopened.Load(),
+		TicketDeleted.Load(),
+		TicketArchived.Load(),
+		TicketAnonymized.Load(),
+		TicketPurged.Load(),
+		TicketClaimed.Load(),
+		TicketClaimExpired.Load(),
+		TicketCSATSubmitted.Load(),
+		NotifySent.Load(),
+		NotifyFailed.Load(),
+		NotifyDropped.Load(),
 		KBDocCreated.Load(),
 		KBDocUpdated.Load(),
 		KBDocDeleted.Load(),
@@ -87,6 +131,7 @@ assistfusion_ai_provider_calls_total{provider="openai",result="error"} %d
 		AIChatSuccessMock.Load(),
 		AIChatFallbackMock.Load(),
 		AIChatError.Load(),
+		AIQuotaRejected.Load(),
 		AIEmbeddingSuccessOpenAI.Load(),
 		AIEmbeddingFallbackOpenAI.Load(),
 		AIEmbeddingErrorOpenAI.Load(),
@@ -0,0 +1,86 @@
+// Package privacy locates and redacts a data subject's identity (GDPR access/erasure).
+package privacy
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"regexp"
+	"strings"
+)
+
+// Redacted replaces erased values.
+const Redacted = "[redacted]"
+
+// minSubjectLen guards against subjects so short that redacting mentions would shred text.
+const minSubjectLen = 3
+
+var ErrSubjectTooShort = errors.New("subject must be at least 3 characters")
+
+// Subject matches one identity (user id or email address) case-insensitively.
+type Subject struct {
+	key string
+	re  *regexp.Regexp
+}
+
+func NewSubject(s string) (*Subject, error) {
+	key := strings.ToLower(strings.TrimSpace(s))
+	if len(key) < minSubjectLen {
+		return nil, ErrSubjectTooShort
+	}
+	return &Subject{key: key, re: regexp.MustCompile(`(?i)` + regexp.QuoteMeta(key))}, nil
+}
+
+// Key is the normalized subject.
+func (s *Subject) Key() string { return s.key }
+
+// Hash identifies the subject in receipts without keeping the identity itself.
+func (s *Subject) Hash() string {
+	sum := sha256.Sum256([]byte(s.key))
+	return hex.EncodeToString(sum[:])
+}
+
+// Is reports whether v is exactly the subject.
+func (s *Subject) Is(v string) bool { return strings.EqualFold(strings.TrimSpace(v), s.key) }
+
+// Mentions reports whether text contains the subject as a whole token.
+func (s *Subject) Mentions(text string) bool { return len(s.mentions(text)) > 0 }
+
+// Redact replaces every whole-token mention in text and returns how many were replaced.
+func (s *Subject) Redact(text string) (string, int) {
+	idx := s.mentions(text)
+	if len(idx) == 0 {
+		return text, 0
+	}
+	var b strings.Builder
+	last := 0
+	for _, m := range idx {
+		b.WriteString(text[last:m[0]])
+		b.WriteString(Redacted)
+		last = m[1]
+	}
+	b.WriteString(text[last:])
+	return b.String(), len(idx)
+}
+
+// mentions returns match ranges not glued to identifier characters, so "bob" does not
+// match inside "bobby" or "bob@example.com". A leading dot also counts ("x.bob@..."), a
+// trailing one does not (sentence end).
+func (s *Subject) mentions(text string) [][]int {
+	var out [][]int
+	for _, m := range s.re.FindAllStringIndex(text, -1) {
+		if m[0] > 0 && (identByte(text[m[0]-1]) || text[m[0]-1] == '.') {
+			continue
+		}
+		if m[1] < len(text) && identByte(text[m[1]]) {
+			continue
+		}
+		out = append(out, m)
+	}
+	return out
+}
+
+func identByte(c byte) bool {
+	return c == '_' || c == '@' || c == '-' || c == '+' ||
+		'0' <= c && c <= '9' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
@@ -0,0 +1,31 @@
+package privacy
+
+import "testing"
+
+func TestSubjectRedact(t *testing.T) {
+	s, err := NewSubject(" Alice@Example.com ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !s.Is("alice@example.com") || s.Is("bob@example.com") {
+		t.Fatal("Is mismatch")
+	}
+	got, n := s.Redact("mail ALICE@example.com or alice@example.com.")
+	if n != 2 || got != "mail [redacted] or [redacted]." {
+		t.Fatalf("Redact = %q, %d", got, n)
+	}
+
+	bob, _ := NewSubject("bob")
+	if bob.Mentions("bobby and bob@example.com") {
+		t.Fatal("partial tokens must not match")
+	}
+	if got, _ := bob.Redact("ask bob, then bob."); got != "ask [redacted], then [redacted]." {
+		t.Fatalf("Redact = %q", got)
+	}
+	if _, err := NewSubject("ab"); err != ErrSubjectTooShort {
+		t.Fatalf("short subject err = %v", err)
+	}
+	if s.Hash() == bob.Hash() || len(s.Hash()) != 64 {
+		t.Fatal("unexpected hash")
+	}
+}
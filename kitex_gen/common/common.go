@@ -60,12 +60,61 @@ func (p *TicketStatus) Value() (driver.Value, error) {
 	return int64(*p), nil
 }
 
+type CSATResponse struct {
+	Rating      int32  `thrift:"rating,1" frugal:"1,default,i32" json:"rating"`
+	Comment     string `thrift:"comment,2" frugal:"2,default,string" json:"comment"`
+	SubmittedAt int64  `thrift:"submitted_at,3" frugal:"3,default,i64" json:"submitted_at"`
+}
+
+func NewCSATResponse() *CSATResponse {
+	return &CSATResponse{}
+}
+
+func (p *CSATResponse) InitDefault() {
+}
+
+func (p *CSATResponse) GetRating() (v int32) {
+	return p.Rating
+}
+
+func (p *CSATResponse) GetComment() (v string) {
+	return p.Comment
+}
+
+func (p *CSATResponse) GetSubmittedAt() (v int64) {
+	return p.SubmittedAt
+}
+func (p *CSATResponse) SetRating(val int32) {
+	p.Rating = val
+}
+func (p *CSATResponse) SetComment(val string) {
+	p.Comment = val
+}
+func (p *CSATResponse) SetSubmittedAt(val int64) {
+	p.SubmittedAt = val
+}
+
+func (p *CSATResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("CSATResponse(%+v)", *p)
+}
+
+var fieldIDToName_CSATResponse = map[int16]string{
+	1: "rating",
+	2: "comment",
+	3: "submitted_at",
+}
+
 type TicketCycle struct {
-	CreatedAt   int64        `thrift:"created_at,1" frugal:"1,default,i64" json:"created_at"`
-	AssignedAt  int64        `thrift:"assigned_at,2" frugal:"2,default,i64" json:"assigned_at"`
-	ResolvedAt  int64        `thrift:"resolved_at,3" frugal:"3,default,i64" json:"resolved_at"`
-	EscalatedAt int64        `thrift:"escalated_at,4" frugal:"4,default,i64" json:"escalated_at"`
-	Status      TicketStatus `thrift:"status,5" frugal:"5,default,TicketStatus" json:"status"`
+	CreatedAt   int64         `thrift:"created_at,1" frugal:"1,default,i64" json:"created_at"`
+	AssignedAt  int64         `thrift:"assigned_at,2" frugal:"2,default,i64" json:"assigned_at"`
+	ResolvedAt  int64         `thrift:"resolved_at,3" frugal:"3,default,i64" json:"resolved_at"`
+	EscalatedAt int64         `thrift:"escalated_at,4" frugal:"4,default,i64" json:"escalated_at"`
+	Status      TicketStatus  `thrift:"status,5" frugal:"5,default,TicketStatus" json:"status"`
+	Csat        *CSATResponse `thrift:"csat,7,optional" frugal:"7,optional,CSATResponse" json:"csat,omitempty"`
+	Tier        string        `thrift:"tier,8" frugal:"8,default,string" json:"tier"`
 }
 
 func NewTicketCycle() *TicketCycle {
@@ -94,6 +143,19 @@ func (p *TicketCycle) GetEscalatedAt() (v int64) {
 func (p *TicketCycle) GetStatus() (v TicketStatus) {
 	return p.Status
 }
+
+var TicketCycle_Csat_DEFAULT *CSATResponse
+
+func (p *TicketCycle) GetCsat() (v *CSATResponse) {
+	if !p.IsSetCsat() {
+		return TicketCycle_Csat_DEFAULT
+	}
+	return p.Csat
+}
+
+func (p *TicketCycle) GetTier() (v string) {
+	return p.Tier
+}
 func (p *TicketCycle) SetCreatedAt(val int64) {
 	p.CreatedAt = val
 }
@@ -109,6 +171,16 @@ func (p *TicketCycle) SetEscalatedAt(val int64) {
 func (p *TicketCycle) SetStatus(val TicketStatus) {
 	p.Status = val
 }
+func (p *TicketCycle) SetCsat(val *CSATResponse) {
+	p.Csat = val
+}
+func (p *TicketCycle) SetTier(val string) {
+	p.Tier = val
+}
+
+func (p *TicketCycle) IsSetCsat() bool {
+	return p.Csat != nil
+}
 
 func (p *TicketCycle) String() string {
 	if p == nil {
@@ -123,12 +195,68 @@ var fieldIDToName_TicketCycle = map[int16]string{
 	3: "resolved_at",
 	4: "escalated_at",
 	5: "status",
+	7: "csat",
+	8: "tier",
+}
+
+type Actor struct {
+	Id          string `thrift:"id,1" frugal:"1,default,string" json:"id"`
+	Kind        string `thrift:"kind,2" frugal:"2,default,string" json:"kind"`
+	DisplayName string `thrift:"display_name,3" frugal:"3,default,string" json:"display_name"`
+}
+
+func NewActor() *Actor {
+	return &Actor{}
+}
+
+func (p *Actor) InitDefault() {
+}
+
+func (p *Actor) GetId() (v string) {
+	return p.Id
+}
+
+func (p *Actor) GetKind() (v string) {
+	return p.Kind
+}
+
+func (p *Actor) GetDisplayName() (v string) {
+	return p.DisplayName
+}
+func (p *Actor) SetId(val string) {
+	p.Id = val
+}
+func (p *Actor) SetKind(val string) {
+	p.Kind = val
+}
+func (p *Actor) SetDisplayName(val string) {
+	p.DisplayName = val
+}
+
+func (p *Actor) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("Actor(%+v)", *p)
+}
+
+var fieldIDToName_Actor = map[int16]string{
+	1: "id",
+	2: "kind",
+	3: "display_name",
 }
 
 type TicketEvent struct {
-	Type string `thrift:"type,1" frugal:"1,default,string" json:"type"`
-	At   int64  `thrift:"at,2" frugal:"2,default,i64" json:"at"`
-	Note string `thrift:"note,3" frugal:"3,default,string" json:"note"`
+	Type     string `thrift:"type,1" frugal:"1,default,string" json:"type"`
+	At       int64  `thrift:"at,2" frugal:"2,default,i64" json:"at"`
+	Note     string `thrift:"note,3" frugal:"3,default,string" json:"note"`
+	Actor    *Actor `thrift:"actor,4,optional" frugal:"4,optional,Actor" json:"actor,omitempty"`
+	Source   string `thrift:"source,5" frugal:"5,default,string" json:"source"`
+	PrevHash string `thrift:"prev_hash,6" frugal:"6,default,string" json:"prev_hash"`
+	Hash     string `thrift:"hash,7" frugal:"7,default,string" json:"hash"`
+	Redacted bool   `thrift:"redacted,8" frugal:"8,default,bool" json:"redacted"`
+	FromTier string `thrift:"from_tier,9" frugal:"9,default,string" json:"from_tier"`
+	ToTier   string `thrift:"to_tier,10" frugal:"10,default,string" json:"to_tier"`
 }
 
 func NewTicketEvent() *TicketEvent {
@@ -149,6 +277,39 @@ func (p *TicketEvent) GetAt() (v int64) {
 func (p *TicketEvent) GetNote() (v string) {
 	return p.Note
 }
+
+var TicketEvent_Actor_DEFAULT *Actor
+
+func (p *TicketEvent) GetActor() (v *Actor) {
+	if !p.IsSetActor() {
+		return TicketEvent_Actor_DEFAULT
+	}
+	return p.Actor
+}
+
+func (p *TicketEvent) GetSource() (v string) {
+	return p.Source
+}
+
+func (p *TicketEvent) GetPrevHash() (v string) {
+	return p.PrevHash
+}
+
+func (p *TicketEvent) GetHash() (v string) {
+	return p.Hash
+}
+
+func (p *TicketEvent) GetRedacted() (v bool) {
+	return p.Redacted
+}
+
+func (p *TicketEvent) GetFromTier() (v string) {
+	return p.FromTier
+}
+
+func (p *TicketEvent) GetToTier() (v string) {
+	return p.ToTier
+}
 func (p *TicketEvent) SetType(val string) {
 	p.Type = val
 }
@@ -158,6 +319,31 @@ func (p *TicketEvent) SetAt(val int64) {
 func (p *TicketEvent) SetNote(val string) {
 	p.Note = val
 }
+func (p *TicketEvent) SetActor(val *Actor) {
+	p.Actor = val
+}
+func (p *TicketEvent) SetSource(val string) {
+	p.Source = val
+}
+func (p *TicketEvent) SetPrevHash(val string) {
+	p.PrevHash = val
+}
+func (p *TicketEvent) SetHash(val string) {
+	p.Hash = val
+}
+func (p *TicketEvent) SetRedacted(val bool) {
+	p.Redacted = val
+}
+func (p *TicketEvent) SetFromTier(val string) {
+	p.FromTier = val
+}
+func (p *TicketEvent) SetToTier(val string) {
+	p.ToTier = val
+}
+
+func (p *TicketEvent) IsSetActor() bool {
+	return p.Actor != nil
+}
 
 func (p *TicketEvent) String() string {
 	if p == nil {
@@ -167,24 +353,243 @@ func (p *TicketEvent) String() string {
 }
 
 var fieldIDToName_TicketEvent = map[int16]string{
-	1: "type",
-	2: "at",
-	3: "note",
+	1:  "type",
+	2:  "at",
+	3:  "note",
+	4:  "actor",
+	5:  "source",
+	6:  "prev_hash",
+	7:  "hash",
+	8:  "redacted",
+	9:  "from_tier",
+	10: "to_tier",
+}
+
+type Attachment struct {
+	Name        string `thrift:"name,1" frugal:"1,default,string" json:"name"`
+	ContentType string `thrift:"content_type,2" frugal:"2,default,string" json:"content_type"`
+	Size        int64  `thrift:"size,3" frugal:"3,default,i64" json:"size"`
+}
+
+func NewAttachment() *Attachment {
+	return &Attachment{}
+}
+
+func (p *Attachment) InitDefault() {
+}
+
+func (p *Attachment) GetName() (v string) {
+	return p.Name
+}
+
+func (p *Attachment) GetContentType() (v string) {
+	return p.ContentType
+}
+
+func (p *Attachment) GetSize() (v int64) {
+	return p.Size
+}
+func (p *Attachment) SetName(val string) {
+	p.Name = val
+}
+func (p *Attachment) SetContentType(val string) {
+	p.ContentType = val
+}
+func (p *Attachment) SetSize(val int64) {
+	p.Size = val
+}
+
+func (p *Attachment) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("Attachment(%+v)", *p)
+}
+
+var fieldIDToName_Attachment = map[int16]string{
+	1: "name",
+	2: "content_type",
+	3: "size",
+}
+
+type TicketComment struct {
+	Id          string        `thrift:"id,1" frugal:"1,default,string" json:"id"`
+	Body        string        `thrift:"body,2" frugal:"2,default,string" json:"body"`
+	CreatedAt   int64         `thrift:"created_at,3" frugal:"3,default,i64" json:"created_at"`
+	Actor       *Actor        `thrift:"actor,4,optional" frugal:"4,optional,Actor" json:"actor,omitempty"`
+	Source      string        `thrift:"source,5" frugal:"5,default,string" json:"source"`
+	Attachments []*Attachment `thrift:"attachments,6" frugal:"6,default,list<Attachment>" json:"attachments"`
+}
+
+func NewTicketComment() *TicketComment {
+	return &TicketComment{}
+}
+
+func (p *TicketComment) InitDefault() {
+}
+
+func (p *TicketComment) GetId() (v string) {
+	return p.Id
+}
+
+func (p *TicketComment) GetBody() (v string) {
+	return p.Body
+}
+
+func (p *TicketComment) GetCreatedAt() (v int64) {
+	return p.CreatedAt
+}
+
+var TicketComment_Actor_DEFAULT *Actor
+
+func (p *TicketComment) GetActor() (v *Actor) {
+	if !p.IsSetActor() {
+		return TicketComment_Actor_DEFAULT
+	}
+	return p.Actor
+}
+
+func (p *TicketComment) GetSource() (v string) {
+	return p.Source
+}
+
+func (p *TicketComment) GetAttachments() (v []*Attachment) {
+	return p.Attachments
+}
+func (p *TicketComment) SetId(val string) {
+	p.Id = val
+}
+func (p *TicketComment) SetBody(val string) {
+	p.Body = val
+}
+func (p *TicketComment) SetCreatedAt(val int64) {
+	p.CreatedAt = val
+}
+func (p *TicketComment) SetActor(val *Actor) {
+	p.Actor = val
+}
+func (p *TicketComment) SetSource(val string) {
+	p.Source = val
+}
+func (p *TicketComment) SetAttachments(val []*Attachment) {
+	p.Attachments = val
+}
+
+func (p *TicketComment) IsSetActor() bool {
+	return p.Actor != nil
+}
+
+func (p *TicketComment) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("TicketComment(%+v)", *p)
+}
+
+var fieldIDToName_TicketComment = map[int16]string{
+	1: "id",
+	2: "body",
+	3: "created_at",
+	4: "actor",
+	5: "source",
+	6: "attachments",
+}
+
+type EditLock struct {
+	TicketId   string `thrift:"ticket_id,1" frugal:"1,default,string" json:"ticket_id"`
+	Holder     *Actor `thrift:"holder,2" frugal:"2,default,Actor" json:"holder"`
+	AcquiredAt int64  `thrift:"acquired_at,3" frugal:"3,default,i64" json:"acquired_at"`
+	ExpiresAt  int64  `thrift:"expires_at,4" frugal:"4,default,i64" json:"expires_at"`
+}
+
+func NewEditLock() *EditLock {
+	return &EditLock{}
+}
+
+func (p *EditLock) InitDefault() {
+}
+
+func (p *EditLock) GetTicketId() (v string) {
+	return p.TicketId
+}
+
+var EditLock_Holder_DEFAULT *Actor
+
+func (p *EditLock) GetHolder() (v *Actor) {
+	if !p.IsSetHolder() {
+		return EditLock_Holder_DEFAULT
+	}
+	return p.Holder
+}
+
+func (p *EditLock) GetAcquiredAt() (v int64) {
+	return p.AcquiredAt
+}
+
+func (p *EditLock) GetExpiresAt() (v int64) {
+	return p.ExpiresAt
+}
+func (p *EditLock) SetTicketId(val string) {
+	p.TicketId = val
+}
+func (p *EditLock) SetHolder(val *Actor) {
+	p.Holder = val
+}
+func (p *EditLock) SetAcquiredAt(val int64) {
+	p.AcquiredAt = val
+}
+func (p *EditLock) SetExpiresAt(val int64) {
+	p.ExpiresAt = val
+}
+
+func (p *EditLock) IsSetHolder() bool {
+	return p.Holder != nil
+}
+
+func (p *EditLock) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("EditLock(%+v)", *p)
+}
+
+var fieldIDToName_EditLock = map[int16]string{
+	1: "ticket_id",
+	2: "holder",
+	3: "acquired_at",
+	4: "expires_at",
 }
 
 type Ticket struct {
-	Id           string         `thrift:"id,1" frugal:"1,default,string" json:"id"`
-	Title        string         `thrift:"title,2" frugal:"2,default,string" json:"title"`
-	Desc         string         `thrift:"desc,3" frugal:"3,default,string" json:"desc"`
-	Status       TicketStatus   `thrift:"status,4" frugal:"4,default,TicketStatus" json:"status"`
-	CreatedAt    int64          `thrift:"created_at,5" frugal:"5,default,i64" json:"created_at"`
-	AssignedAt   int64          `thrift:"assigned_at,6" frugal:"6,default,i64" json:"assigned_at"`
-	ResolvedAt   int64          `thrift:"resolved_at,7" frugal:"7,default,i64" json:"resolved_at"`
-	EscalatedAt  int64          `thrift:"escalated_at,8" frugal:"8,default,i64" json:"escalated_at"`
-	ReopenedAt   int64          `thrift:"reopened_at,9" frugal:"9,default,i64" json:"reopened_at"`
-	Cycles       []*TicketCycle `thrift:"cycles,10" frugal:"10,default,list<TicketCycle>" json:"cycles"`
-	CurrentCycle int32          `thrift:"current_cycle,11" frugal:"11,default,i32" json:"current_cycle"`
-	Events       []*TicketEvent `thrift:"events,12" frugal:"12,default,list<TicketEvent>" json:"events"`
+	Id             string            `thrift:"id,1" frugal:"1,default,string" json:"id"`
+	Title          string            `thrift:"title,2" frugal:"2,default,string" json:"title"`
+	Desc           string            `thrift:"desc,3" frugal:"3,default,string" json:"desc"`
+	Status         TicketStatus      `thrift:"status,4" frugal:"4,default,TicketStatus" json:"status"`
+	CreatedAt      int64             `thrift:"created_at,5" frugal:"5,default,i64" json:"created_at"`
+	AssignedAt     int64             `thrift:"assigned_at,6" frugal:"6,default,i64" json:"assigned_at"`
+	ResolvedAt     int64             `thrift:"resolved_at,7" frugal:"7,default,i64" json:"resolved_at"`
+	EscalatedAt    int64             `thrift:"escalated_at,8" frugal:"8,default,i64" json:"escalated_at"`
+	ReopenedAt     int64             `thrift:"reopened_at,9" frugal:"9,default,i64" json:"reopened_at"`
+	Cycles         []*TicketCycle    `thrift:"cycles,10" frugal:"10,default,list<TicketCycle>" json:"cycles"`
+	CurrentCycle   int32             `thrift:"current_cycle,11" frugal:"11,default,i32" json:"current_cycle"`
+	Events         []*TicketEvent    `thrift:"events,12" frugal:"12,default,list<TicketEvent>" json:"events"`
+	Assignee       string            `thrift:"assignee,13" frugal:"13,default,string" json:"assignee"`
+	Category       string            `thrift:"category,14" frugal:"14,default,string" json:"category"`
+	Customer       string            `thrift:"customer,15" frugal:"15,default,string" json:"customer"`
+	Watchers       []string          `thrift:"watchers,16" frugal:"16,default,list<string>" json:"watchers"`
+	Comments       []*TicketComment  `thrift:"comments,17" frugal:"17,default,list<TicketComment>" json:"comments"`
+	ArchivedAt     int64             `thrift:"archived_at,18" frugal:"18,default,i64" json:"archived_at"`
+	DeletedAt      int64             `thrift:"deleted_at,19" frugal:"19,default,i64" json:"deleted_at"`
+	Anonymized     bool              `thrift:"anonymized,20" frugal:"20,default,bool" json:"anonymized"`
+	Tier           string            `thrift:"tier,21" frugal:"21,default,string" json:"tier"`
+	Priority       string            `thrift:"priority,22" frugal:"22,default,string" json:"priority"`
+	Tags           []string          `thrift:"tags,23" frugal:"23,default,list<string>" json:"tags"`
+	CustomFields   map[string]string `thrift:"custom_fields,24" frugal:"24,default,map<string:string>" json:"custom_fields"`
+	DueAt          int64             `thrift:"due_at,25" frugal:"25,default,i64" json:"due_at"`
+	SlaStatus      string            `thrift:"sla_status,26" frugal:"26,default,string" json:"sla_status"`
+	ClaimedBy      string            `thrift:"claimed_by,27" frugal:"27,default,string" json:"claimed_by"`
+	ClaimExpiresAt int64             `thrift:"claim_expires_at,28" frugal:"28,default,i64" json:"claim_expires_at"`
+	Lock           *EditLock         `thrift:"lock,29,optional" frugal:"29,optional,EditLock" json:"lock,omitempty"`
 }
 
 func NewTicket() *Ticket {
@@ -241,6 +646,79 @@ func (p *Ticket) GetCurrentCycle() (v int32) {
 func (p *Ticket) GetEvents() (v []*TicketEvent) {
 	return p.Events
 }
+
+func (p *Ticket) GetAssignee() (v string) {
+	return p.Assignee
+}
+
+func (p *Ticket) GetCategory() (v string) {
+	return p.Category
+}
+
+func (p *Ticket) GetCustomer() (v string) {
+	return p.Customer
+}
+
+func (p *Ticket) GetWatchers() (v []string) {
+	return p.Watchers
+}
+
+func (p *Ticket) GetComments() (v []*TicketComment) {
+	return p.Comments
+}
+
+func (p *Ticket) GetArchivedAt() (v int64) {
+	return p.ArchivedAt
+}
+
+func (p *Ticket) GetDeletedAt() (v int64) {
+	return p.DeletedAt
+}
+
+func (p *Ticket) GetAnonymized() (v bool) {
+	return p.Anonymized
+}
+
+func (p *Ticket) GetTier() (v string) {
+	return p.Tier
+}
+
+func (p *Ticket) GetPriority() (v string) {
+	return p.Priority
+}
+
+func (p *Ticket) GetTags() (v []string) {
+	return p.Tags
+}
+
+func (p *Ticket) GetCustomFields() (v map[string]string) {
+	return p.CustomFields
+}
+
+func (p *Ticket) GetDueAt() (v int64) {
+	return p.DueAt
+}
+
+func (p *Ticket) GetSlaStatus() (v string) {
+	return p.SlaStatus
+}
+
+func (p *Ticket) GetClaimedBy() (v string) {
+	return p.ClaimedBy
+}
+
+func (p *Ticket) GetClaimExpiresAt() (v int64) {
+	return p.ClaimExpiresAt
+}
+
+var Ticket_Lock_DEFAULT *EditLock
+
+func (p *Ticket) GetLock() (v *EditLock) {
+	if !p.IsSetLock() {
+		return Ticket_Lock_DEFAULT
+	}
+	return p.Lock
+}
 func (p *Ticket) SetId(val string) {
 	p.Id = val
 }
@@ -277,6 +755,61 @@ func (p *Ticket) SetCurrentCycle(val int32) {
 func (p *Ticket) SetEvents(val []*TicketEvent) {
 	p.Events = val
 }
+func (p *Ticket) SetAssignee(val string) {
+	p.Assignee = val
+}
+func (p *Ticket) SetCategory(val string) {
+	p.Category = val
+}
+func (p *Ticket) SetCustomer(val string) {
+	p.Customer = val
+}
+func (p *Ticket) SetWatchers(val []string) {
+	p.Watchers = val
+}
+func (p *Ticket) SetComments(val []*TicketComment) {
+	p.Comments = val
+}
+func (p *Ticket) SetArchivedAt(val int64) {
+	p.ArchivedAt = val
+}
+func (p *Ticket) SetDeletedAt(val int64) {
+	p.DeletedAt = val
+}
+func (p *Ticket) SetAnonymized(val bool) {
+	p.Anonymized = val
+}
+func (p *Ticket) SetTier(val string) {
+	p.Tier = val
+}
+func (p *Ticket) SetPriority(val string) {
+	p.Priority = val
+}
+func (p *Ticket) SetTags(val []string) {
+	p.Tags = val
+}
+func (p *Ticket) SetCustomFields(val map[string]string) {
+	p.CustomFields = val
+}
+func (p *Ticket) SetDueAt(val int64) {
+	p.DueAt = val
+}
+func (p *Ticket) SetSlaStatus(val string) {
+	p.SlaStatus = val
+}
+func (p *Ticket) SetClaimedBy(val string) {
+	p.ClaimedBy = val
+}
+func (p *Ticket) SetClaimExpiresAt(val int64) {
+	p.ClaimExpiresAt = val
+}
+func (p *Ticket) SetLock(val *EditLock) {
+	p.Lock = val
+}
+
+func (p *Ticket) IsSetLock() bool {
+	return p.Lock != nil
+}
 
 func (p *Ticket) String() string {
 	if p == nil {
@@ -298,13 +831,32 @@ var fieldIDToName_Ticket = map[int16]string{
 	10: "cycles",
 	11: "current_cycle",
 	12: "events",
+	13: "assignee",
+	14: "category",
+	15: "customer",
+	16: "watchers",
+	17: "comments",
+	18: "archived_at",
+	19: "deleted_at",
+	20: "anonymized",
+	21: "tier",
+	22: "priority",
+	23: "tags",
+	24: "custom_fields",
+	25: "due_at",
+	26: "sla_status",
+	27: "claimed_by",
+	28: "claim_expires_at",
+	29: "lock",
 }
 
 type KBDoc struct {
-	Id      string            `thrift:"id,1" frugal:"1,default,string" json:"id"`
-	Title   string            `thrift:"title,2" frugal:"2,default,string" json:"title"`
-	Content string            `thrift:"content,3" frugal:"3,default,string" json:"content"`
-	Tags    map[string]string `thrift:"tags,4,optional" frugal:"4,optional,map<string:string>" json:"tags,omitempty"`
+	Id        string            `thrift:"id,1" frugal:"1,default,string" json:"id"`
+	Title     string            `thrift:"title,2" frugal:"2,default,string" json:"title"`
+	Content   string            `thrift:"content,3" frugal:"3,default,string" json:"content"`
+	Tags      map[string]string `thrift:"tags,4,optional" frugal:"4,optional,map<string:string>" json:"tags,omitempty"`
+	CreatedAt int64             `thrift:"created_at,5" frugal:"5,default,i64" json:"created_at"`
+	UpdatedAt int64             `thrift:"updated_at,6" frugal:"6,default,i64" json:"updated_at"`
 }
 
 func NewKBDoc() *KBDoc {
@@ -334,6 +886,14 @@ func (p *KBDoc) GetTags() (v map[string]string) {
 	}
 	return p.Tags
 }
+
+func (p *KBDoc) GetCreatedAt() (v int64) {
+	return p.CreatedAt
+}
+
+func (p *KBDoc) GetUpdatedAt() (v int64) {
+	return p.UpdatedAt
+}
 func (p *KBDoc) SetId(val string) {
 	p.Id = val
 }
@@ -346,6 +906,12 @@ func (p *KBDoc) SetContent(val string) {
 func (p *KBDoc) SetTags(val map[string]string) {
 	p.Tags = val
 }
+func (p *KBDoc) SetCreatedAt(val int64) {
+	p.CreatedAt = val
+}
+func (p *KBDoc) SetUpdatedAt(val int64) {
+	p.UpdatedAt = val
+}
 
 func (p *KBDoc) IsSetTags() bool {
 	return p.Tags != nil
@@ -363,13 +929,61 @@ var fieldIDToName_KBDoc = map[int16]string{
 	2: "title",
 	3: "content",
 	4: "tags",
+	5: "created_at",
+	6: "updated_at",
+}
+
+type HighlightSpan struct {
+	Start int32 `thrift:"start,1" frugal:"1,default,i32" json:"start"`
+	End   int32 `thrift:"end,2" frugal:"2,default,i32" json:"end"`
+}
+
+func NewHighlightSpan() *HighlightSpan {
+	return &HighlightSpan{}
+}
+
+func (p *HighlightSpan) InitDefault() {
+}
+
+func (p *HighlightSpan) GetStart() (v int32) {
+	return p.Start
+}
+
+func (p *HighlightSpan) GetEnd() (v int32) {
+	return p.End
+}
+func (p *HighlightSpan) SetStart(val int32) {
+	p.Start = val
+}
+func (p *HighlightSpan) SetEnd(val int32) {
+	p.End = val
+}
+
+func (p *HighlightSpan) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("HighlightSpan(%+v)", *p)
+}
+
+var fieldIDToName_HighlightSpan = map[int16]string{
+	1: "start",
+	2: "end",
 }
 
 type SearchItem struct {
-	Id      string  `thrift:"id,1" frugal:"1,default,string" json:"id"`
-	Title   string  `thrift:"title,2" frugal:"2,default,string" json:"title"`
-	Score   float64 `thrift:"score,3" frugal:"3,default,double" json:"score"`
-	Snippet string  `thrift:"snippet,4" frugal:"4,default,string" json:"snippet"`
+	Id           string            `thrift:"id,1" frugal:"1,default,string" json:"id"`
+	Title        string            `thrift:"title,2" frugal:"2,default,string" json:"title"`
+	Score        float64           `thrift:"score,3" frugal:"3,default,double" json:"score"`
+	Snippet      string            `thrift:"snippet,4" frugal:"4,default,string" json:"snippet"`
+	Tags         map[string]string `thrift:"tags,5,optional" frugal:"5,optional,map<string:string>" json:"tags,omitempty"`
+	ChunkIndex   *int32            `thrift:"chunk_index,6,optional" frugal:"6,optional,i32" json:"chunk_index,omitempty"`
+	HeadingPath  []string          `thrift:"heading_path,7,optional" frugal:"7,optional,list<string>" json:"heading_path,omitempty"`
+	LexicalScore *float64          `thrift:"lexical_score,8,optional" frugal:"8,optional,double" json:"lexical_score,omitempty"`
+	LexicalRank  *int32            `thrift:"lexical_rank,9,optional" frugal:"9,optional,i32" json:"lexical_rank,omitempty"`
+	VectorScore  *float64          `thrift:"vector_score,10,optional" frugal:"10,optional,double" json:"vector_score,omitempty"`
+	VectorRank   *int32            `thrift:"vector_rank,11,optional" frugal:"11,optional,i32" json:"vector_rank,omitempty"`
+	Highlights   []*HighlightSpan  `thrift:"highlights,12,optional" frugal:"12,optional,list<HighlightSpan>" json:"highlights,omitempty"`
 }
 
 func NewSearchItem() *SearchItem {
@@ -394,6 +1008,78 @@ func (p *SearchItem) GetScore() (v float64) {
 func (p *SearchItem) GetSnippet() (v string) {
 	return p.Snippet
 }
+
+var SearchItem_Tags_DEFAULT map[string]string
+
+func (p *SearchItem) GetTags() (v map[string]string) {
+	if !p.IsSetTags() {
+		return SearchItem_Tags_DEFAULT
+	}
+	return p.Tags
+}
+
+var SearchItem_ChunkIndex_DEFAULT int32
+
+func (p *SearchItem) GetChunkIndex() (v int32) {
+	if !p.IsSetChunkIndex() {
+		return SearchItem_ChunkIndex_DEFAULT
+	}
+	return *p.ChunkIndex
+}
+
+var SearchItem_HeadingPath_DEFAULT []string
+
+func (p *SearchItem) GetHeadingPath() (v []string) {
+	if !p.IsSetHeadingPath() {
+		return SearchItem_HeadingPath_DEFAULT
+	}
+	return p.HeadingPath
+}
+
+var SearchItem_LexicalScore_DEFAULT float64
+
+func (p *SearchItem) GetLexicalScore() (v float64) {
+	if !p.IsSetLexicalScore() {
+		return SearchItem_LexicalScore_DEFAULT
+	}
+	return *p.LexicalScore
+}
+
+var SearchItem_LexicalRank_DEFAULT int32
+
+func (p *SearchItem) GetLexicalRank() (v int32) {
+	if !p.IsSetLexicalRank() {
+		return SearchItem_LexicalRank_DEFAULT
+	}
+	return *p.LexicalRank
+}
+
+var SearchItem_VectorScore_DEFAULT float64
+
+func (p *SearchItem) GetVectorScore() (v float64) {
+	if !p.IsSetVectorScore() {
+		return SearchItem_VectorScore_DEFAULT
+	}
+	return *p.VectorScore
+}
+
+var SearchItem_VectorRank_DEFAULT int32
+
+func (p *SearchItem) GetVectorRank() (v int32) {
+	if !p.IsSetVectorRank() {
+		return SearchItem_VectorRank_DEFAULT
+	}
+	return *p.VectorRank
+}
+
+var SearchItem_Highlights_DEFAULT []*HighlightSpan
+
+func (p *SearchItem) GetHighlights() (v []*HighlightSpan) {
+	if !p.IsSetHighlights() {
+		return SearchItem_Highlights_DEFAULT
+	}
+	return p.Highlights
+}
 func (p *SearchItem) SetId(val string) {
 	p.Id = val
 }
@@ -406,6 +1092,62 @@ func (p *SearchItem) SetScore(val float64) {
 func (p *SearchItem) SetSnippet(val string) {
 	p.Snippet = val
 }
+func (p *SearchItem) SetTags(val map[string]string) {
+	p.Tags = val
+}
+func (p *SearchItem) SetChunkIndex(val *int32) {
+	p.ChunkIndex = val
+}
+func (p *SearchItem) SetHeadingPath(val []string) {
+	p.HeadingPath = val
+}
+func (p *SearchItem) SetLexicalScore(val *float64) {
+	p.LexicalScore = val
+}
+func (p *SearchItem) SetLexicalRank(val *int32) {
+	p.LexicalRank = val
+}
+func (p *SearchItem) SetVectorScore(val *float64) {
+	p.VectorScore = val
+}
+func (p *SearchItem) SetVectorRank(val *int32) {
+	p.VectorRank = val
+}
+func (p *SearchItem) SetHighlights(val []*HighlightSpan) {
+	p.Highlights = val
+}
+
+func (p *SearchItem) IsSetTags() bool {
+	return p.Tags != nil
+}
+
+func (p *SearchItem) IsSetChunkIndex() bool {
+	return p.ChunkIndex != nil
+}
+
+func (p *SearchItem) IsSetHeadingPath() bool {
+	return p.HeadingPath != nil
+}
+
+func (p *SearchItem) IsSetLexicalScore() bool {
+	return p.LexicalScore != nil
+}
+
+func (p *SearchItem) IsSetLexicalRank() bool {
+	return p.LexicalRank != nil
+}
+
+func (p *SearchItem) IsSetVectorScore() bool {
+	return p.VectorScore != nil
+}
+
+func (p *SearchItem) IsSetVectorRank() bool {
+	return p.VectorRank != nil
+}
+
+func (p *SearchItem) IsSetHighlights() bool {
+	return p.Highlights != nil
+}
 
 func (p *SearchItem) String() string {
 	if p == nil {
@@ -415,10 +1157,18 @@ func (p *SearchItem) String() string {
 }
 
 var fieldIDToName_SearchItem = map[int16]string{
-	1: "id",
-	2: "title",
-	3: "score",
-	4: "snippet",
+	1:  "id",
+	2:  "title",
+	3:  "score",
+	4:  "snippet",
+	5:  "tags",
+	6:  "chunk_index",
+	7:  "heading_path",
+	8:  "lexical_score",
+	9:  "lexical_rank",
+	10: "vector_score",
+	11: "vector_rank",
+	12: "highlights",
 }
 
 type EmbeddingRequest struct {
@@ -647,6 +1397,82 @@ var fieldIDToName_PageInfo = map[int16]string{
 	4: "total_pages",
 }
 
+type SubjectRequest struct {
+	Subject string `thrift:"subject,1" frugal:"1,default,string" json:"subject"`
+}
+
+func NewSubjectRequest() *SubjectRequest {
+	return &SubjectRequest{}
+}
+
+func (p *SubjectRequest) InitDefault() {
+}
+
+func (p *SubjectRequest) GetSubject() (v string) {
+	return p.Subject
+}
+func (p *SubjectRequest) SetSubject(val string) {
+	p.Subject = val
+}
+
+func (p *SubjectRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("SubjectRequest(%+v)", *p)
+}
+
+var fieldIDToName_SubjectRequest = map[int16]string{
+	1: "subject",
+}
+
+type ErasureSummary struct {
+	Service string   `thrift:"service,1" frugal:"1,default,string" json:"service"`
+	Records []string `thrift:"records,2" frugal:"2,default,list<string>" json:"records"`
+	Fields  int32    `thrift:"fields,3" frugal:"3,default,i32" json:"fields"`
+}
+
+func NewErasureSummary() *ErasureSummary {
+	return &ErasureSummary{}
+}
+
+func (p *ErasureSummary) InitDefault() {
+}
+
+func (p *ErasureSummary) GetService() (v string) {
+	return p.Service
+}
+
+func (p *ErasureSummary) GetRecords() (v []string) {
+	return p.Records
+}
+
+func (p *ErasureSummary) GetFields() (v int32) {
+	return p.Fields
+}
+func (p *ErasureSummary) SetService(val string) {
+	p.Service = val
+}
+func (p *ErasureSummary) SetRecords(val []string) {
+	p.Records = val
+}
+func (p *ErasureSummary) SetFields(val int32) {
+	p.Fields = val
+}
+
+func (p *ErasureSummary) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ErasureSummary(%+v)", *p)
+}
+
+var fieldIDToName_ErasureSummary = map[int16]string{
+	1: "service",
+	2: "records",
+	3: "fields",
+}
+
 type ServiceError struct {
 	Code    string            `thrift:"code,1" frugal:"1,default,string" json:"code"`
 	Message string            `thrift:"message,2" frugal:"2,default,string" json:"message"`
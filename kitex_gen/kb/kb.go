@@ -5,14 +5,14 @@ package kb
 import (
 	"context"
 	"fmt"
-
 	"github.com/gogogo1024/assist-fusion/kitex_gen/common"
 )
 
 type AddDocRequest struct {
-	Title   string            `thrift:"title,1" frugal:"1,default,string" json:"title"`
-	Content string            `thrift:"content,2" frugal:"2,default,string" json:"content"`
-	Tags    map[string]string `thrift:"tags,3,optional" frugal:"3,optional,map<string:string>" json:"tags,omitempty"`
+	Title          string            `thrift:"title,1" frugal:"1,default,string" json:"title"`
+	Content        string            `thrift:"content,2" frugal:"2,default,string" json:"content"`
+	Tags           map[string]string `thrift:"tags,3,optional" frugal:"3,optional,map<string:string>" json:"tags,omitempty"`
+	IdempotencyKey *string           `thrift:"idempotency_key,4,optional" frugal:"4,optional,string" json:"idempotency_key,omitempty"`
 }
 
 func NewAddDocRequest() *AddDocRequest {
@@ -38,6 +38,15 @@ func (p *AddDocRequest) GetTags() (v map[string]string) {
 	}
 	return p.Tags
 }
+
+var AddDocRequest_IdempotencyKey_DEFAULT string
+
+func (p *AddDocRequest) GetIdempotencyKey() (v string) {
+	if !p.IsSetIdempotencyKey() {
+		return AddDocRequest_IdempotencyKey_DEFAULT
+	}
+	return *p.IdempotencyKey
+}
 func (p *AddDocRequest) SetTitle(val string) {
 	p.Title = val
 }
@@ -47,11 +56,18 @@ func (p *AddDocRequest) SetContent(val string) {
 func (p *AddDocRequest) SetTags(val map[string]string) {
 	p.Tags = val
 }
+func (p *AddDocRequest) SetIdempotencyKey(val *string) {
+	p.IdempotencyKey = val
+}
 
 func (p *AddDocRequest) IsSetTags() bool {
 	return p.Tags != nil
 }
 
+func (p *AddDocRequest) IsSetIdempotencyKey() bool {
+	return p.IdempotencyKey != nil
+}
+
 func (p *AddDocRequest) String() string {
 	if p == nil {
 		return "<nil>"
@@ -63,6 +79,7 @@ var fieldIDToName_AddDocRequest = map[int16]string{
 	1: "title",
 	2: "content",
 	3: "tags",
+	4: "idempotency_key",
 }
 
 type UpdateDocRequest struct {
@@ -177,6 +194,201 @@ var fieldIDToName_DeleteDocRequest = map[int16]string{
 	1: "id",
 }
 
+type GetDocRequest struct {
+	Id string `thrift:"id,1" frugal:"1,default,string" json:"id"`
+}
+
+func NewGetDocRequest() *GetDocRequest {
+	return &GetDocRequest{}
+}
+
+func (p *GetDocRequest) InitDefault() {
+}
+
+func (p *GetDocRequest) GetId() (v string) {
+	return p.Id
+}
+func (p *GetDocRequest) SetId(val string) {
+	p.Id = val
+}
+
+func (p *GetDocRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("GetDocRequest(%+v)", *p)
+}
+
+var fieldIDToName_GetDocRequest = map[int16]string{
+	1: "id",
+}
+
+type ListDocsRequest struct {
+	Limit   *int32   `thrift:"limit,1,optional" frugal:"1,optional,i32" json:"limit,omitempty"`
+	Cursor  *string  `thrift:"cursor,2,optional" frugal:"2,optional,string" json:"cursor,omitempty"`
+	Tags    []string `thrift:"tags,3,optional" frugal:"3,optional,list<string>" json:"tags,omitempty"`
+	TagMode *string  `thrift:"tag_mode,4,optional" frugal:"4,optional,string" json:"tag_mode,omitempty"`
+	Order   *string  `thrift:"order,5,optional" frugal:"5,optional,string" json:"order,omitempty"`
+}
+
+func NewListDocsRequest() *ListDocsRequest {
+	return &ListDocsRequest{}
+}
+
+func (p *ListDocsRequest) InitDefault() {
+}
+
+var ListDocsRequest_Limit_DEFAULT int32
+
+func (p *ListDocsRequest) GetLimit() (v int32) {
+	if !p.IsSetLimit() {
+		return ListDocsRequest_Limit_DEFAULT
+	}
+	return *p.Limit
+}
+
+var ListDocsRequest_Cursor_DEFAULT string
+
+func (p *ListDocsRequest) GetCursor() (v string) {
+	if !p.IsSetCursor() {
+		return ListDocsRequest_Cursor_DEFAULT
+	}
+	return *p.Cursor
+}
+
+var ListDocsRequest_Tags_DEFAULT []string
+
+func (p *ListDocsRequest) GetTags() (v []string) {
+	if !p.IsSetTags() {
+		return ListDocsRequest_Tags_DEFAULT
+	}
+	return p.Tags
+}
+
+var ListDocsRequest_TagMode_DEFAULT string
+
+func (p *ListDocsRequest) GetTagMode() (v string) {
+	if !p.IsSetTagMode() {
+		return ListDocsRequest_TagMode_DEFAULT
+	}
+	return *p.TagMode
+}
+
+var ListDocsRequest_Order_DEFAULT string
+
+func (p *ListDocsRequest) GetOrder() (v string) {
+	if !p.IsSetOrder() {
+		return ListDocsRequest_Order_DEFAULT
+	}
+	return *p.Order
+}
+func (p *ListDocsRequest) SetLimit(val *int32) {
+	p.Limit = val
+}
+func (p *ListDocsRequest) SetCursor(val *string) {
+	p.Cursor = val
+}
+func (p *ListDocsRequest) SetTags(val []string) {
+	p.Tags = val
+}
+func (p *ListDocsRequest) SetTagMode(val *string) {
+	p.TagMode = val
+}
+func (p *ListDocsRequest) SetOrder(val *string) {
+	p.Order = val
+}
+
+func (p *ListDocsRequest) IsSetLimit() bool {
+	return p.Limit != nil
+}
+
+func (p *ListDocsRequest) IsSetCursor() bool {
+	return p.Cursor != nil
+}
+
+func (p *ListDocsRequest) IsSetTags() bool {
+	return p.Tags != nil
+}
+
+func (p *ListDocsRequest) IsSetTagMode() bool {
+	return p.TagMode != nil
+}
+
+func (p *ListDocsRequest) IsSetOrder() bool {
+	return p.Order != nil
+}
+
+func (p *ListDocsRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ListDocsRequest(%+v)", *p)
+}
+
+var fieldIDToName_ListDocsRequest = map[int16]string{
+	1: "limit",
+	2: "cursor",
+	3: "tags",
+	4: "tag_mode",
+	5: "order",
+}
+
+type ListDocsResponse struct {
+	Docs       []*common.KBDoc `thrift:"docs,1" frugal:"1,default,list<common.KBDoc>" json:"docs"`
+	NextCursor *string         `thrift:"next_cursor,2,optional" frugal:"2,optional,string" json:"next_cursor,omitempty"`
+	Total      int32           `thrift:"total,3" frugal:"3,default,i32" json:"total"`
+}
+
+func NewListDocsResponse() *ListDocsResponse {
+	return &ListDocsResponse{}
+}
+
+func (p *ListDocsResponse) InitDefault() {
+}
+
+func (p *ListDocsResponse) GetDocs() (v []*common.KBDoc) {
+	return p.Docs
+}
+
+var ListDocsResponse_NextCursor_DEFAULT string
+
+func (p *ListDocsResponse) GetNextCursor() (v string) {
+	if !p.IsSetNextCursor() {
+		return ListDocsResponse_NextCursor_DEFAULT
+	}
+	return *p.NextCursor
+}
+
+func (p *ListDocsResponse) GetTotal() (v int32) {
+	return p.Total
+}
+func (p *ListDocsResponse) SetDocs(val []*common.KBDoc) {
+	p.Docs = val
+}
+func (p *ListDocsResponse) SetNextCursor(val *string) {
+	p.NextCursor = val
+}
+func (p *ListDocsResponse) SetTotal(val int32) {
+	p.Total = val
+}
+
+func (p *ListDocsResponse) IsSetNextCursor() bool {
+	return p.NextCursor != nil
+}
+
+func (p *ListDocsResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ListDocsResponse(%+v)", *p)
+}
+
+var fieldIDToName_ListDocsResponse = map[int16]string{
+	1: "docs",
+	2: "next_cursor",
+	3: "total",
+}
+
 type DeleteDocResponse struct {
 	Ok bool `thrift:"ok,1" frugal:"1,default,bool" json:"ok"`
 }
@@ -207,10 +419,22 @@ var fieldIDToName_DeleteDocResponse = map[int16]string{
 }
 
 type SearchRequest struct {
-	Query       string `thrift:"query,1" frugal:"1,default,string" json:"query"`
-	Limit       *int32 `thrift:"limit,2,optional" frugal:"2,optional,i32" json:"limit,omitempty"`
-	Offset      *int32 `thrift:"offset,3,optional" frugal:"3,optional,i32" json:"offset,omitempty"`
-	WithSnippet *bool  `thrift:"with_snippet,4,optional" frugal:"4,optional,bool" json:"with_snippet,omitempty"`
+	Query         string   `thrift:"query,1" frugal:"1,default,string" json:"query"`
+	Limit         *int32   `thrift:"limit,2,optional" frugal:"2,optional,i32" json:"limit,omitempty"`
+	Offset        *int32   `thrift:"offset,3,optional" frugal:"3,optional,i32" json:"offset,omitempty"`
+	WithSnippet   *bool    `thrift:"with_snippet,4,optional" frugal:"4,optional,bool" json:"with_snippet,omitempty"`
+	Tags          []string `thrift:"tags,5,optional" frugal:"5,optional,list<string>" json:"tags,omitempty"`
+	TagMode       *string  `thrift:"tag_mode,6,optional" frugal:"6,optional,string" json:"tag_mode,omitempty"`
+	Cursor        *string  `thrift:"cursor,7,optional" frugal:"7,optional,string" json:"cursor,omitempty"`
+	Mode          *string  `thrift:"mode,8,optional" frugal:"8,optional,string" json:"mode,omitempty"`
+	Fusion        *string  `thrift:"fusion,9,optional" frugal:"9,optional,string" json:"fusion,omitempty"`
+	LexicalWeight *float64 `thrift:"lexical_weight,10,optional" frugal:"10,optional,double" json:"lexical_weight,omitempty"`
+	VectorWeight  *float64 `thrift:"vector_weight,11,optional" frugal:"11,optional,double" json:"vector_weight,omitempty"`
+	RrfK          *int32   `thrift:"rrf_k,12,optional" frugal:"12,optional,i32" json:"rrf_k,omitempty"`
+	SnippetLength *int32   `thrift:"snippet_length,13,optional" frugal:"13,optional,i32" json:"snippet_length,omitempty"`
+	Fragments     *int32   `thrift:"fragments,14,optional" frugal:"14,optional,i32" json:"fragments,omitempty"`
+	PreTag        *string  `thrift:"pre_tag,15,optional" frugal:"15,optional,string" json:"pre_tag,omitempty"`
+	PostTag       *string  `thrift:"post_tag,16,optional" frugal:"16,optional,string" json:"post_tag,omitempty"`
 }
 
 func NewSearchRequest() *SearchRequest {
@@ -250,6 +474,114 @@ func (p *SearchRequest) GetWithSnippet() (v bool) {
 	}
 	return *p.WithSnippet
 }
+
+var SearchRequest_Tags_DEFAULT []string
+
+func (p *SearchRequest) GetTags() (v []string) {
+	if !p.IsSetTags() {
+		return SearchRequest_Tags_DEFAULT
+	}
+	return p.Tags
+}
+
+var SearchRequest_TagMode_DEFAULT string
+
+func (p *SearchRequest) GetTagMode() (v string) {
+	if !p.IsSetTagMode() {
+		return SearchRequest_TagMode_DEFAULT
+	}
+	return *p.TagMode
+}
+
+var SearchRequest_Cursor_DEFAULT string
+
+func (p *SearchRequest) GetCursor() (v string) {
+	if !p.IsSetCursor() {
+		return SearchRequest_Cursor_DEFAULT
+	}
+	return *p.Cursor
+}
+
+var SearchRequest_Mode_DEFAULT string
+
+func (p *SearchRequest) GetMode() (v string) {
+	if !p.IsSetMode() {
+		return SearchRequest_Mode_DEFAULT
+	}
+	return *p.Mode
+}
+
+var SearchRequest_Fusion_DEFAULT string
+
+func (p *SearchRequest) GetFusion() (v string) {
+	if !p.IsSetFusion() {
+		return SearchRequest_Fusion_DEFAULT
+	}
+	return *p.Fusion
+}
+
+var SearchRequest_LexicalWeight_DEFAULT float64
+
+func (p *SearchRequest) GetLexicalWeight() (v float64) {
+	if !p.IsSetLexicalWeight() {
+		return SearchRequest_LexicalWeight_DEFAULT
+	}
+	return *p.LexicalWeight
+}
+
+var SearchRequest_VectorWeight_DEFAULT float64
+
+func (p *SearchRequest) GetVectorWeight() (v float64) {
+	if !p.IsSetVectorWeight() {
+		return SearchRequest_VectorWeight_DEFAULT
+	}
+	return *p.VectorWeight
+}
+
+var SearchRequest_RrfK_DEFAULT int32
+
+func (p *SearchRequest) GetRrfK() (v int32) {
+	if !p.IsSetRrfK() {
+		return SearchRequest_RrfK_DEFAULT
+	}
+	return *p.RrfK
+}
+
+var SearchRequest_SnippetLength_DEFAULT int32
+
+func (p *SearchRequest) GetSnippetLength() (v int32) {
+	if !p.IsSetSnippetLength() {
+		return SearchRequest_SnippetLength_DEFAULT
+	}
+	return *p.SnippetLength
+}
+
+var SearchRequest_Fragments_DEFAULT int32
+
+func (p *SearchRequest) GetFragments() (v int32) {
+	if !p.IsSetFragments() {
+		return SearchRequest_Fragments_DEFAULT
+	}
+	return *p.Fragments
+}
+
+var SearchRequest_PreTag_DEFAULT string
+
+func (p *SearchRequest) GetPreTag() (v string) {
+	if !p.IsSetPreTag() {
+		return SearchRequest_PreTag_DEFAULT
+	}
+	return *p.PreTag
+}
+
+var SearchRequest_PostTag_DEFAULT string
+
+func (p *SearchRequest) GetPostTag() (v string) {
+	if !p.IsSetPostTag() {
+		return SearchRequest_PostTag_DEFAULT
+	}
+	return *p.PostTag
+}
 func (p *SearchRequest) SetQuery(val string) {
 	p.Query = val
 }
@@ -262,6 +594,42 @@ func (p *SearchRequest) SetOffset(val *int32) {
 func (p *SearchRequest) SetWithSnippet(val *bool) {
 	p.WithSnippet = val
 }
+func (p *SearchRequest) SetTags(val []string) {
+	p.Tags = val
+}
+func (p *SearchRequest) SetTagMode(val *string) {
+	p.TagMode = val
+}
+func (p *SearchRequest) SetCursor(val *string) {
+	p.Cursor = val
+}
+func (p *SearchRequest) SetMode(val *string) {
+	p.Mode = val
+}
+func (p *SearchRequest) SetFusion(val *string) {
+	p.Fusion = val
+}
+func (p *SearchRequest) SetLexicalWeight(val *float64) {
+	p.LexicalWeight = val
+}
+func (p *SearchRequest) SetVectorWeight(val *float64) {
+	p.VectorWeight = val
+}
+func (p *SearchRequest) SetRrfK(val *int32) {
+	p.RrfK = val
+}
+func (p *SearchRequest) SetSnippetLength(val *int32) {
+	p.SnippetLength = val
+}
+func (p *SearchRequest) SetFragments(val *int32) {
+	p.Fragments = val
+}
+func (p *SearchRequest) SetPreTag(val *string) {
+	p.PreTag = val
+}
+func (p *SearchRequest) SetPostTag(val *string) {
+	p.PostTag = val
+}
 
 func (p *SearchRequest) IsSetLimit() bool {
 	return p.Limit != nil
@@ -275,6 +643,54 @@ func (p *SearchRequest) IsSetWithSnippet() bool {
 	return p.WithSnippet != nil
 }
 
+func (p *SearchRequest) IsSetTags() bool {
+	return p.Tags != nil
+}
+
+func (p *SearchRequest) IsSetTagMode() bool {
+	return p.TagMode != nil
+}
+
+func (p *SearchRequest) IsSetCursor() bool {
+	return p.Cursor != nil
+}
+
+func (p *SearchRequest) IsSetMode() bool {
+	return p.Mode != nil
+}
+
+func (p *SearchRequest) IsSetFusion() bool {
+	return p.Fusion != nil
+}
+
+func (p *SearchRequest) IsSetLexicalWeight() bool {
+	return p.LexicalWeight != nil
+}
+
+func (p *SearchRequest) IsSetVectorWeight() bool {
+	return p.VectorWeight != nil
+}
+
+func (p *SearchRequest) IsSetRrfK() bool {
+	return p.RrfK != nil
+}
+
+func (p *SearchRequest) IsSetSnippetLength() bool {
+	return p.SnippetLength != nil
+}
+
+func (p *SearchRequest) IsSetFragments() bool {
+	return p.Fragments != nil
+}
+
+func (p *SearchRequest) IsSetPreTag() bool {
+	return p.PreTag != nil
+}
+
+func (p *SearchRequest) IsSetPostTag() bool {
+	return p.PostTag != nil
+}
+
 func (p *SearchRequest) String() string {
 	if p == nil {
 		return "<nil>"
@@ -283,10 +699,22 @@ func (p *SearchRequest) String() string {
 }
 
 var fieldIDToName_SearchRequest = map[int16]string{
-	1: "query",
-	2: "limit",
-	3: "offset",
-	4: "with_snippet",
+	1:  "query",
+	2:  "limit",
+	3:  "offset",
+	4:  "with_snippet",
+	5:  "tags",
+	6:  "tag_mode",
+	7:  "cursor",
+	8:  "mode",
+	9:  "fusion",
+	10: "lexical_weight",
+	11: "vector_weight",
+	12: "rrf_k",
+	13: "snippet_length",
+	14: "fragments",
+	15: "pre_tag",
+	16: "post_tag",
 }
 
 type SearchResponse struct {
@@ -294,6 +722,7 @@ type SearchResponse struct {
 	Returned   int32                `thrift:"returned,2" frugal:"2,default,i32" json:"returned"`
 	NextOffset *int32               `thrift:"next_offset,3,optional" frugal:"3,optional,i32" json:"next_offset,omitempty"`
 	Total      *int32               `thrift:"total,4,optional" frugal:"4,optional,i32" json:"total,omitempty"`
+	NextCursor *string              `thrift:"next_cursor,5,optional" frugal:"5,optional,string" json:"next_cursor,omitempty"`
 }
 
 func NewSearchResponse() *SearchResponse {
@@ -328,6 +757,15 @@ func (p *SearchResponse) GetTotal() (v int32) {
 	}
 	return *p.Total
 }
+
+var SearchResponse_NextCursor_DEFAULT string
+
+func (p *SearchResponse) GetNextCursor() (v string) {
+	if !p.IsSetNextCursor() {
+		return SearchResponse_NextCursor_DEFAULT
+	}
+	return *p.NextCursor
+}
 func (p *SearchResponse) SetItems(val []*common.SearchItem) {
 	p.Items = val
 }
@@ -337,12 +775,24 @@ func (p *SearchResponse) SetReturned(val int32) {
 func (p *SearchResponse) SetNextOffset(val *int32) {
 	p.NextOffset = val
 }
-func (p *SearchResponse) SetTotal(val *int32) { p.Total = val }
+func (p *SearchResponse) SetTotal(val *int32) {
+	p.Total = val
+}
+func (p *SearchResponse) SetNextCursor(val *string) {
+	p.NextCursor = val
+}
 
 func (p *SearchResponse) IsSetNextOffset() bool {
 	return p.NextOffset != nil
 }
-func (p *SearchResponse) IsSetTotal() bool { return p.Total != nil }
+
+func (p *SearchResponse) IsSetTotal() bool {
+	return p.Total != nil
+}
+
+func (p *SearchResponse) IsSetNextCursor() bool {
+	return p.NextCursor != nil
+}
 
 func (p *SearchResponse) String() string {
 	if p == nil {
@@ -356,51 +806,221 @@ var fieldIDToName_SearchResponse = map[int16]string{
 	2: "returned",
 	3: "next_offset",
 	4: "total",
+	5: "next_cursor",
 }
 
-type InfoResponse struct {
-	Stats map[string]string `thrift:"stats,1" frugal:"1,default,map<string:string>" json:"stats"`
+type VectorSearchRequest struct {
+	Query   string   `thrift:"query,1" frugal:"1,default,string" json:"query"`
+	Limit   *int32   `thrift:"limit,2,optional" frugal:"2,optional,i32" json:"limit,omitempty"`
+	Tags    []string `thrift:"tags,3,optional" frugal:"3,optional,list<string>" json:"tags,omitempty"`
+	TagMode *string  `thrift:"tag_mode,4,optional" frugal:"4,optional,string" json:"tag_mode,omitempty"`
 }
 
-func NewInfoResponse() *InfoResponse {
-	return &InfoResponse{}
+func NewVectorSearchRequest() *VectorSearchRequest {
+	return &VectorSearchRequest{}
 }
 
-func (p *InfoResponse) InitDefault() {
+func (p *VectorSearchRequest) InitDefault() {
 }
 
-func (p *InfoResponse) GetStats() (v map[string]string) {
-	return p.Stats
-}
-func (p *InfoResponse) SetStats(val map[string]string) {
-	p.Stats = val
+func (p *VectorSearchRequest) GetQuery() (v string) {
+	return p.Query
 }
 
-func (p *InfoResponse) String() string {
-	if p == nil {
-		return "<nil>"
+var VectorSearchRequest_Limit_DEFAULT int32
+
+func (p *VectorSearchRequest) GetLimit() (v int32) {
+	if !p.IsSetLimit() {
+		return VectorSearchRequest_Limit_DEFAULT
 	}
-	return fmt.Sprintf("InfoResponse(%+v)", *p)
+	return *p.Limit
 }
 
-var fieldIDToName_InfoResponse = map[int16]string{
-	1: "stats",
-}
+var VectorSearchRequest_Tags_DEFAULT []string
 
-type KBService interface {
-	AddDoc(ctx context.Context, req *AddDocRequest) (r *common.KBDoc, err error)
+func (p *VectorSearchRequest) GetTags() (v []string) {
+	if !p.IsSetTags() {
+		return VectorSearchRequest_Tags_DEFAULT
+	}
+	return p.Tags
+}
 
-	UpdateDoc(ctx context.Context, req *UpdateDocRequest) (r *common.KBDoc, err error)
+var VectorSearchRequest_TagMode_DEFAULT string
 
-	DeleteDoc(ctx context.Context, req *DeleteDocRequest) (r *DeleteDocResponse, err error)
+func (p *VectorSearchRequest) GetTagMode() (v string) {
+	if !p.IsSetTagMode() {
+		return VectorSearchRequest_TagMode_DEFAULT
+	}
+	return *p.TagMode
+}
+func (p *VectorSearchRequest) SetQuery(val string) {
+	p.Query = val
+}
+func (p *VectorSearchRequest) SetLimit(val *int32) {
+	p.Limit = val
+}
+func (p *VectorSearchRequest) SetTags(val []string) {
+	p.Tags = val
+}
+func (p *VectorSearchRequest) SetTagMode(val *string) {
+	p.TagMode = val
+}
 
-	Search(ctx context.Context, req *SearchRequest) (r *SearchResponse, err error)
+func (p *VectorSearchRequest) IsSetLimit() bool {
+	return p.Limit != nil
+}
 
-	Info(ctx context.Context) (r *InfoResponse, err error)
+func (p *VectorSearchRequest) IsSetTags() bool {
+	return p.Tags != nil
 }
 
-type KBServiceAddDocArgs struct {
-	Req *AddDocRequest `thrift:"req,1" frugal:"1,default,AddDocRequest" json:"req"`
+func (p *VectorSearchRequest) IsSetTagMode() bool {
+	return p.TagMode != nil
+}
+
+func (p *VectorSearchRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("VectorSearchRequest(%+v)", *p)
+}
+
+var fieldIDToName_VectorSearchRequest = map[int16]string{
+	1: "query",
+	2: "limit",
+	3: "tags",
+	4: "tag_mode",
+}
+
+type VectorSearchResponse struct {
+	Items    []*common.SearchItem `thrift:"items,1" frugal:"1,default,list<common.SearchItem>" json:"items"`
+	Returned int32                `thrift:"returned,2" frugal:"2,default,i32" json:"returned"`
+	Indexed  int32                `thrift:"indexed,3" frugal:"3,default,i32" json:"indexed"`
+}
+
+func NewVectorSearchResponse() *VectorSearchResponse {
+	return &VectorSearchResponse{}
+}
+
+func (p *VectorSearchResponse) InitDefault() {
+}
+
+func (p *VectorSearchResponse) GetItems() (v []*common.SearchItem) {
+	return p.Items
+}
+
+func (p *VectorSearchResponse) GetReturned() (v int32) {
+	return p.Returned
+}
+
+func (p *VectorSearchResponse) GetIndexed() (v int32) {
+	return p.Indexed
+}
+func (p *VectorSearchResponse) SetItems(val []*common.SearchItem) {
+	p.Items = val
+}
+func (p *VectorSearchResponse) SetReturned(val int32) {
+	p.Returned = val
+}
+func (p *VectorSearchResponse) SetIndexed(val int32) {
+	p.Indexed = val
+}
+
+func (p *VectorSearchResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("VectorSearchResponse(%+v)", *p)
+}
+
+var fieldIDToName_VectorSearchResponse = map[int16]string{
+	1: "items",
+	2: "returned",
+	3: "indexed",
+}
+
+type InfoResponse struct {
+	Stats map[string]string `thrift:"stats,1" frugal:"1,default,map<string:string>" json:"stats"`
+}
+
+func NewInfoResponse() *InfoResponse {
+	return &InfoResponse{}
+}
+
+func (p *InfoResponse) InitDefault() {
+}
+
+func (p *InfoResponse) GetStats() (v map[string]string) {
+	return p.Stats
+}
+func (p *InfoResponse) SetStats(val map[string]string) {
+	p.Stats = val
+}
+
+func (p *InfoResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("InfoResponse(%+v)", *p)
+}
+
+var fieldIDToName_InfoResponse = map[int16]string{
+	1: "stats",
+}
+
+type ExportSubjectResponse struct {
+	Docs []*common.KBDoc `thrift:"docs,1" frugal:"1,default,list<common.KBDoc>" json:"docs"`
+}
+
+func NewExportSubjectResponse() *ExportSubjectResponse {
+	return &ExportSubjectResponse{}
+}
+
+func (p *ExportSubjectResponse) InitDefault() {
+}
+
+func (p *ExportSubjectResponse) GetDocs() (v []*common.KBDoc) {
+	return p.Docs
+}
+func (p *ExportSubjectResponse) SetDocs(val []*common.KBDoc) {
+	p.Docs = val
+}
+
+func (p *ExportSubjectResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ExportSubjectResponse(%+v)", *p)
+}
+
+var fieldIDToName_ExportSubjectResponse = map[int16]string{
+	1: "docs",
+}
+
+type KBService interface {
+	AddDoc(ctx context.Context, req *AddDocRequest) (r *common.KBDoc, err error)
+
+	UpdateDoc(ctx context.Context, req *UpdateDocRequest) (r *common.KBDoc, err error)
+
+	DeleteDoc(ctx context.Context, req *DeleteDocRequest) (r *DeleteDocResponse, err error)
+
+	GetDoc(ctx context.Context, req *GetDocRequest) (r *common.KBDoc, err error)
+
+	ListDocs(ctx context.Context, req *ListDocsRequest) (r *ListDocsResponse, err error)
+
+	Search(ctx context.Context, req *SearchRequest) (r *SearchResponse, err error)
+
+	VectorSearch(ctx context.Context, req *VectorSearchRequest) (r *VectorSearchResponse, err error)
+
+	Info(ctx context.Context) (r *InfoResponse, err error)
+
+	ExportSubject(ctx context.Context, req *common.SubjectRequest) (r *ExportSubjectResponse, err error)
+
+	EraseSubject(ctx context.Context, req *common.SubjectRequest) (r *common.ErasureSummary, err error)
+}
+
+type KBServiceAddDocArgs struct {
+	Req *AddDocRequest `thrift:"req,1" frugal:"1,default,AddDocRequest" json:"req"`
 }
 
 func NewKBServiceAddDocArgs() *KBServiceAddDocArgs {
@@ -681,6 +1301,194 @@ var fieldIDToName_KBServiceDeleteDocResult = map[int16]string{
 	1: "err",
 }
 
+type KBServiceGetDocArgs struct {
+	Req *GetDocRequest `thrift:"req,1" frugal:"1,default,GetDocRequest" json:"req"`
+}
+
+func NewKBServiceGetDocArgs() *KBServiceGetDocArgs {
+	return &KBServiceGetDocArgs{}
+}
+
+func (p *KBServiceGetDocArgs) InitDefault() {
+}
+
+var KBServiceGetDocArgs_Req_DEFAULT *GetDocRequest
+
+func (p *KBServiceGetDocArgs) GetReq() (v *GetDocRequest) {
+	if !p.IsSetReq() {
+		return KBServiceGetDocArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *KBServiceGetDocArgs) SetReq(val *GetDocRequest) {
+	p.Req = val
+}
+
+func (p *KBServiceGetDocArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *KBServiceGetDocArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("KBServiceGetDocArgs(%+v)", *p)
+}
+
+var fieldIDToName_KBServiceGetDocArgs = map[int16]string{
+	1: "req",
+}
+
+type KBServiceGetDocResult struct {
+	Success *common.KBDoc        `thrift:"success,0,optional" frugal:"0,optional,common.KBDoc" json:"success,omitempty"`
+	Err     *common.ServiceError `thrift:"err,1,optional" frugal:"1,optional,common.ServiceError" json:"err,omitempty"`
+}
+
+func NewKBServiceGetDocResult() *KBServiceGetDocResult {
+	return &KBServiceGetDocResult{}
+}
+
+func (p *KBServiceGetDocResult) InitDefault() {
+}
+
+var KBServiceGetDocResult_Success_DEFAULT *common.KBDoc
+
+func (p *KBServiceGetDocResult) GetSuccess() (v *common.KBDoc) {
+	if !p.IsSetSuccess() {
+		return KBServiceGetDocResult_Success_DEFAULT
+	}
+	return p.Success
+}
+
+var KBServiceGetDocResult_Err_DEFAULT *common.ServiceError
+
+func (p *KBServiceGetDocResult) GetErr() (v *common.ServiceError) {
+	if !p.IsSetErr() {
+		return KBServiceGetDocResult_Err_DEFAULT
+	}
+	return p.Err
+}
+func (p *KBServiceGetDocResult) SetSuccess(x interface{}) {
+	p.Success = x.(*common.KBDoc)
+}
+func (p *KBServiceGetDocResult) SetErr(val *common.ServiceError) {
+	p.Err = val
+}
+
+func (p *KBServiceGetDocResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *KBServiceGetDocResult) IsSetErr() bool {
+	return p.Err != nil
+}
+
+func (p *KBServiceGetDocResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("KBServiceGetDocResult(%+v)", *p)
+}
+
+var fieldIDToName_KBServiceGetDocResult = map[int16]string{
+	0: "success",
+	1: "err",
+}
+
+type KBServiceListDocsArgs struct {
+	Req *ListDocsRequest `thrift:"req,1" frugal:"1,default,ListDocsRequest" json:"req"`
+}
+
+func NewKBServiceListDocsArgs() *KBServiceListDocsArgs {
+	return &KBServiceListDocsArgs{}
+}
+
+func (p *KBServiceListDocsArgs) InitDefault() {
+}
+
+var KBServiceListDocsArgs_Req_DEFAULT *ListDocsRequest
+
+func (p *KBServiceListDocsArgs) GetReq() (v *ListDocsRequest) {
+	if !p.IsSetReq() {
+		return KBServiceListDocsArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *KBServiceListDocsArgs) SetReq(val *ListDocsRequest) {
+	p.Req = val
+}
+
+func (p *KBServiceListDocsArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *KBServiceListDocsArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("KBServiceListDocsArgs(%+v)", *p)
+}
+
+var fieldIDToName_KBServiceListDocsArgs = map[int16]string{
+	1: "req",
+}
+
+type KBServiceListDocsResult struct {
+	Success *ListDocsResponse    `thrift:"success,0,optional" frugal:"0,optional,ListDocsResponse" json:"success,omitempty"`
+	Err     *common.ServiceError `thrift:"err,1,optional" frugal:"1,optional,common.ServiceError" json:"err,omitempty"`
+}
+
+func NewKBServiceListDocsResult() *KBServiceListDocsResult {
+	return &KBServiceListDocsResult{}
+}
+
+func (p *KBServiceListDocsResult) InitDefault() {
+}
+
+var KBServiceListDocsResult_Success_DEFAULT *ListDocsResponse
+
+func (p *KBServiceListDocsResult) GetSuccess() (v *ListDocsResponse) {
+	if !p.IsSetSuccess() {
+		return KBServiceListDocsResult_Success_DEFAULT
+	}
+	return p.Success
+}
+
+var KBServiceListDocsResult_Err_DEFAULT *common.ServiceError
+
+func (p *KBServiceListDocsResult) GetErr() (v *common.ServiceError) {
+	if !p.IsSetErr() {
+		return KBServiceListDocsResult_Err_DEFAULT
+	}
+	return p.Err
+}
+func (p *KBServiceListDocsResult) SetSuccess(x interface{}) {
+	p.Success = x.(*ListDocsResponse)
+}
+func (p *KBServiceListDocsResult) SetErr(val *common.ServiceError) {
+	p.Err = val
+}
+
+func (p *KBServiceListDocsResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *KBServiceListDocsResult) IsSetErr() bool {
+	return p.Err != nil
+}
+
+func (p *KBServiceListDocsResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("KBServiceListDocsResult(%+v)", *p)
+}
+
+var fieldIDToName_KBServiceListDocsResult = map[int16]string{
+	0: "success",
+	1: "err",
+}
+
 type KBServiceSearchArgs struct {
 	Req *SearchRequest `thrift:"req,1" frugal:"1,default,SearchRequest" json:"req"`
 }
@@ -775,6 +1583,100 @@ var fieldIDToName_KBServiceSearchResult = map[int16]string{
 	1: "err",
 }
 
+type KBServiceVectorSearchArgs struct {
+	Req *VectorSearchRequest `thrift:"req,1" frugal:"1,default,VectorSearchRequest" json:"req"`
+}
+
+func NewKBServiceVectorSearchArgs() *KBServiceVectorSearchArgs {
+	return &KBServiceVectorSearchArgs{}
+}
+
+func (p *KBServiceVectorSearchArgs) InitDefault() {
+}
+
+var KBServiceVectorSearchArgs_Req_DEFAULT *VectorSearchRequest
+
+func (p *KBServiceVectorSearchArgs) GetReq() (v *VectorSearchRequest) {
+	if !p.IsSetReq() {
+		return KBServiceVectorSearchArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *KBServiceVectorSearchArgs) SetReq(val *VectorSearchRequest) {
+	p.Req = val
+}
+
+func (p *KBServiceVectorSearchArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *KBServiceVectorSearchArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("KBServiceVectorSearchArgs(%+v)", *p)
+}
+
+var fieldIDToName_KBServiceVectorSearchArgs = map[int16]string{
+	1: "req",
+}
+
+type KBServiceVectorSearchResult struct {
+	Success *VectorSearchResponse `thrift:"success,0,optional" frugal:"0,optional,VectorSearchResponse" json:"success,omitempty"`
+	Err     *common.ServiceError  `thrift:"err,1,optional" frugal:"1,optional,common.ServiceError" json:"err,omitempty"`
+}
+
+func NewKBServiceVectorSearchResult() *KBServiceVectorSearchResult {
+	return &KBServiceVectorSearchResult{}
+}
+
+func (p *KBServiceVectorSearchResult) InitDefault() {
+}
+
+var KBServiceVectorSearchResult_Success_DEFAULT *VectorSearchResponse
+
+func (p *KBServiceVectorSearchResult) GetSuccess() (v *VectorSearchResponse) {
+	if !p.IsSetSuccess() {
+		return KBServiceVectorSearchResult_Success_DEFAULT
+	}
+	return p.Success
+}
+
+var KBServiceVectorSearchResult_Err_DEFAULT *common.ServiceError
+
+func (p *KBServiceVectorSearchResult) GetErr() (v *common.ServiceError) {
+	if !p.IsSetErr() {
+		return KBServiceVectorSearchResult_Err_DEFAULT
+	}
+	return p.Err
+}
+func (p *KBServiceVectorSearchResult) SetSuccess(x interface{}) {
+	p.Success = x.(*VectorSearchResponse)
+}
+func (p *KBServiceVectorSearchResult) SetErr(val *common.ServiceError) {
+	p.Err = val
+}
+
+func (p *KBServiceVectorSearchResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *KBServiceVectorSearchResult) IsSetErr() bool {
+	return p.Err != nil
+}
+
+func (p *KBServiceVectorSearchResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("KBServiceVectorSearchResult(%+v)", *p)
+}
+
+var fieldIDToName_KBServiceVectorSearchResult = map[int16]string{
+	0: "success",
+	1: "err",
+}
+
 type KBServiceInfoArgs struct {
 }
 
@@ -850,6 +1752,194 @@ var fieldIDToName_KBServiceInfoResult = map[int16]string{
 	1: "err",
 }
 
+type KBServiceExportSubjectArgs struct {
+	Req *common.SubjectRequest `thrift:"req,1" frugal:"1,default,common.SubjectRequest" json:"req"`
+}
+
+func NewKBServiceExportSubjectArgs() *KBServiceExportSubjectArgs {
+	return &KBServiceExportSubjectArgs{}
+}
+
+func (p *KBServiceExportSubjectArgs) InitDefault() {
+}
+
+var KBServiceExportSubjectArgs_Req_DEFAULT *common.SubjectRequest
+
+func (p *KBServiceExportSubjectArgs) GetReq() (v *common.SubjectRequest) {
+	if !p.IsSetReq() {
+		return KBServiceExportSubjectArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *KBServiceExportSubjectArgs) SetReq(val *common.SubjectRequest) {
+	p.Req = val
+}
+
+func (p *KBServiceExportSubjectArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *KBServiceExportSubjectArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("KBServiceExportSubjectArgs(%+v)", *p)
+}
+
+var fieldIDToName_KBServiceExportSubjectArgs = map[int16]string{
+	1: "req",
+}
+
+type KBServiceExportSubjectResult struct {
+	Success *ExportSubjectResponse `thrift:"success,0,optional" frugal:"0,optional,ExportSubjectResponse" json:"success,omitempty"`
+	Err     *common.ServiceError   `thrift:"err,1,optional" frugal:"1,optional,common.ServiceError" json:"err,omitempty"`
+}
+
+func NewKBServiceExportSubjectResult() *KBServiceExportSubjectResult {
+	return &KBServiceExportSubjectResult{}
+}
+
+func (p *KBServiceExportSubjectResult) InitDefault() {
+}
+
+var KBServiceExportSubjectResult_Success_DEFAULT *ExportSubjectResponse
+
+func (p *KBServiceExportSubjectResult) GetSuccess() (v *ExportSubjectResponse) {
+	if !p.IsSetSuccess() {
+		return KBServiceExportSubjectResult_Success_DEFAULT
+	}
+	return p.Success
+}
+
+var KBServiceExportSubjectResult_Err_DEFAULT *common.ServiceError
+
+func (p *KBServiceExportSubjectResult) GetErr() (v *common.ServiceError) {
+	if !p.IsSetErr() {
+		return KBServiceExportSubjectResult_Err_DEFAULT
+	}
+	return p.Err
+}
+func (p *KBServiceExportSubjectResult) SetSuccess(x interface{}) {
+	p.Success = x.(*ExportSubjectResponse)
+}
+func (p *KBServiceExportSubjectResult) SetErr(val *common.ServiceError) {
+	p.Err = val
+}
+
+func (p *KBServiceExportSubjectResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *KBServiceExportSubjectResult) IsSetErr() bool {
+	return p.Err != nil
+}
+
+func (p *KBServiceExportSubjectResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("KBServiceExportSubjectResult(%+v)", *p)
+}
+
+var fieldIDToName_KBServiceExportSubjectResult = map[int16]string{
+	0: "success",
+	1: "err",
+}
+
+type KBServiceEraseSubjectArgs struct {
+	Req *common.SubjectRequest `thrift:"req,1" frugal:"1,default,common.SubjectRequest" json:"req"`
+}
+
+func NewKBServiceEraseSubjectArgs() *KBServiceEraseSubjectArgs {
+	return &KBServiceEraseSubjectArgs{}
+}
+
+func (p *KBServiceEraseSubjectArgs) InitDefault() {
+}
+
+var KBServiceEraseSubjectArgs_Req_DEFAULT *common.SubjectRequest
+
+func (p *KBServiceEraseSubjectArgs) GetReq() (v *common.SubjectRequest) {
+	if !p.IsSetReq() {
+		return KBServiceEraseSubjectArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *KBServiceEraseSubjectArgs) SetReq(val *common.SubjectRequest) {
+	p.Req = val
+}
+
+func (p *KBServiceEraseSubjectArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *KBServiceEraseSubjectArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("KBServiceEraseSubjectArgs(%+v)", *p)
+}
+
+var fieldIDToName_KBServiceEraseSubjectArgs = map[int16]string{
+	1: "req",
+}
+
+type KBServiceEraseSubjectResult struct {
+	Success *common.ErasureSummary `thrift:"success,0,optional" frugal:"0,optional,common.ErasureSummary" json:"success,omitempty"`
+	Err     *common.ServiceError   `thrift:"err,1,optional" frugal:"1,optional,common.ServiceError" json:"err,omitempty"`
+}
+
+func NewKBServiceEraseSubjectResult() *KBServiceEraseSubjectResult {
+	return &KBServiceEraseSubjectResult{}
+}
+
+func (p *KBServiceEraseSubjectResult) InitDefault() {
+}
+
+var KBServiceEraseSubjectResult_Success_DEFAULT *common.ErasureSummary
+
+func (p *KBServiceEraseSubjectResult) GetSuccess() (v *common.ErasureSummary) {
+	if !p.IsSetSuccess() {
+		return KBServiceEraseSubjectResult_Success_DEFAULT
+	}
+	return p.Success
+}
+
+var KBServiceEraseSubjectResult_Err_DEFAULT *common.ServiceError
+
+func (p *KBServiceEraseSubjectResult) GetErr() (v *common.ServiceError) {
+	if !p.IsSetErr() {
+		return KBServiceEraseSubjectResult_Err_DEFAULT
+	}
+	return p.Err
+}
+func (p *KBServiceEraseSubjectResult) SetSuccess(x interface{}) {
+	p.Success = x.(*common.ErasureSummary)
+}
+func (p *KBServiceEraseSubjectResult) SetErr(val *common.ServiceError) {
+	p.Err = val
+}
+
+func (p *KBServiceEraseSubjectResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *KBServiceEraseSubjectResult) IsSetErr() bool {
+	return p.Err != nil
+}
+
+func (p *KBServiceEraseSubjectResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("KBServiceEraseSubjectResult(%+v)", *p)
+}
+
+var fieldIDToName_KBServiceEraseSubjectResult = map[int16]string{
+	0: "success",
+	1: "err",
+}
+
 // exceptions of methods in KBService.
 var (
 	_ error = (*common.ServiceError)(nil)
@@ -15,8 +15,13 @@ type Client interface {
 	AddDoc(ctx context.Context, req *kb.AddDocRequest, callOptions ...callopt.Option) (r *common.KBDoc, err error)
 	UpdateDoc(ctx context.Context, req *kb.UpdateDocRequest, callOptions ...callopt.Option) (r *common.KBDoc, err error)
 	DeleteDoc(ctx context.Context, req *kb.DeleteDocRequest, callOptions ...callopt.Option) (r *kb.DeleteDocResponse, err error)
+	GetDoc(ctx context.Context, req *kb.GetDocRequest, callOptions ...callopt.Option) (r *common.KBDoc, err error)
+	ListDocs(ctx context.Context, req *kb.ListDocsRequest, callOptions ...callopt.Option) (r *kb.ListDocsResponse, err error)
 	Search(ctx context.Context, req *kb.SearchRequest, callOptions ...callopt.Option) (r *kb.SearchResponse, err error)
+	VectorSearch(ctx context.Context, req *kb.VectorSearchRequest, callOptions ...callopt.Option) (r *kb.VectorSearchResponse, err error)
 	Info(ctx context.Context, callOptions ...callopt.Option) (r *kb.InfoResponse, err error)
+	ExportSubject(ctx context.Context, req *common.SubjectRequest, callOptions ...callopt.Option) (r *kb.ExportSubjectResponse, err error)
+	EraseSubject(ctx context.Context, req *common.SubjectRequest, callOptions ...callopt.Option) (r *common.ErasureSummary, err error)
 }
 
 // NewClient creates a client for the service defined in IDL.
@@ -63,12 +68,37 @@ func (p *kKBServiceClient) DeleteDoc(ctx context.Context, req *kb.DeleteDocReque
 	return p.kClient.DeleteDoc(ctx, req)
 }
 
+func (p *kKBServiceClient) GetDoc(ctx context.Context, req *kb.GetDocRequest, callOptions ...callopt.Option) (r *common.KBDoc, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.GetDoc(ctx, req)
+}
+
+func (p *kKBServiceClient) ListDocs(ctx context.Context, req *kb.ListDocsRequest, callOptions ...callopt.Option) (r *kb.ListDocsResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.ListDocs(ctx, req)
+}
+
 func (p *kKBServiceClient) Search(ctx context.Context, req *kb.SearchRequest, callOptions ...callopt.Option) (r *kb.SearchResponse, err error) {
 	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
 	return p.kClient.Search(ctx, req)
 }
 
+func (p *kKBServiceClient) VectorSearch(ctx context.Context, req *kb.VectorSearchRequest, callOptions ...callopt.Option) (r *kb.VectorSearchResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.VectorSearch(ctx, req)
+}
+
 func (p *kKBServiceClient) Info(ctx context.Context, callOptions ...callopt.Option) (r *kb.InfoResponse, err error) {
 	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
 	return p.kClient.Info(ctx)
 }
+
+func (p *kKBServiceClient) ExportSubject(ctx context.Context, req *common.SubjectRequest, callOptions ...callopt.Option) (r *kb.ExportSubjectResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.ExportSubject(ctx, req)
+}
+
+func (p *kKBServiceClient) EraseSubject(ctx context.Context, req *common.SubjectRequest, callOptions ...callopt.Option) (r *common.ErasureSummary, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.EraseSubject(ctx, req)
+}
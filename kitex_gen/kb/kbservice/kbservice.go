@@ -35,6 +35,20 @@ var serviceMethods = map[string]kitex.MethodInfo{
 		false,
 		kitex.WithStreamingMode(kitex.StreamingNone),
 	),
+	"GetDoc": kitex.NewMethodInfo(
+		getDocHandler,
+		newKBServiceGetDocArgs,
+		newKBServiceGetDocResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
+	"ListDocs": kitex.NewMethodInfo(
+		listDocsHandler,
+		newKBServiceListDocsArgs,
+		newKBServiceListDocsResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
 	"Search": kitex.NewMethodInfo(
 		searchHandler,
 		newKBServiceSearchArgs,
@@ -42,6 +56,13 @@ var serviceMethods = map[string]kitex.MethodInfo{
 		false,
 		kitex.WithStreamingMode(kitex.StreamingNone),
 	),
+	"VectorSearch": kitex.NewMethodInfo(
+		vectorSearchHandler,
+		newKBServiceVectorSearchArgs,
+		newKBServiceVectorSearchResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
 	"Info": kitex.NewMethodInfo(
 		infoHandler,
 		newKBServiceInfoArgs,
@@ -49,6 +70,20 @@ var serviceMethods = map[string]kitex.MethodInfo{
 		false,
 		kitex.WithStreamingMode(kitex.StreamingNone),
 	),
+	"ExportSubject": kitex.NewMethodInfo(
+		exportSubjectHandler,
+		newKBServiceExportSubjectArgs,
+		newKBServiceExportSubjectResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
+	"EraseSubject": kitex.NewMethodInfo(
+		eraseSubjectHandler,
+		newKBServiceEraseSubjectArgs,
+		newKBServiceEraseSubjectResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
 }
 
 var (
@@ -187,6 +222,54 @@ func newKBServiceDeleteDocResult() interface{} {
 	return kb.NewKBServiceDeleteDocResult()
 }
 
+func getDocHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*kb.KBServiceGetDocArgs)
+	realResult := result.(*kb.KBServiceGetDocResult)
+	success, err := handler.(kb.KBService).GetDoc(ctx, realArg.Req)
+	if err != nil {
+		switch v := err.(type) {
+		case *common.ServiceError:
+			realResult.Err = v
+		default:
+			return err
+		}
+	} else {
+		realResult.Success = success
+	}
+	return nil
+}
+func newKBServiceGetDocArgs() interface{} {
+	return kb.NewKBServiceGetDocArgs()
+}
+
+func newKBServiceGetDocResult() interface{} {
+	return kb.NewKBServiceGetDocResult()
+}
+
+func listDocsHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*kb.KBServiceListDocsArgs)
+	realResult := result.(*kb.KBServiceListDocsResult)
+	success, err := handler.(kb.KBService).ListDocs(ctx, realArg.Req)
+	if err != nil {
+		switch v := err.(type) {
+		case *common.ServiceError:
+			realResult.Err = v
+		default:
+			return err
+		}
+	} else {
+		realResult.Success = success
+	}
+	return nil
+}
+func newKBServiceListDocsArgs() interface{} {
+	return kb.NewKBServiceListDocsArgs()
+}
+
+func newKBServiceListDocsResult() interface{} {
+	return kb.NewKBServiceListDocsResult()
+}
+
 func searchHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
 	realArg := arg.(*kb.KBServiceSearchArgs)
 	realResult := result.(*kb.KBServiceSearchResult)
@@ -211,6 +294,30 @@ func newKBServiceSearchResult() interface{} {
 	return kb.NewKBServiceSearchResult()
 }
 
+func vectorSearchHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*kb.KBServiceVectorSearchArgs)
+	realResult := result.(*kb.KBServiceVectorSearchResult)
+	success, err := handler.(kb.KBService).VectorSearch(ctx, realArg.Req)
+	if err != nil {
+		switch v := err.(type) {
+		case *common.ServiceError:
+			realResult.Err = v
+		default:
+			return err
+		}
+	} else {
+		realResult.Success = success
+	}
+	return nil
+}
+func newKBServiceVectorSearchArgs() interface{} {
+	return kb.NewKBServiceVectorSearchArgs()
+}
+
+func newKBServiceVectorSearchResult() interface{} {
+	return kb.NewKBServiceVectorSearchResult()
+}
+
 func infoHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
 	_ = arg.(*kb.KBServiceInfoArgs)
 	realResult := result.(*kb.KBServiceInfoResult)
@@ -235,6 +342,54 @@ func newKBServiceInfoResult() interface{} {
 	return kb.NewKBServiceInfoResult()
 }
 
+func exportSubjectHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*kb.KBServiceExportSubjectArgs)
+	realResult := result.(*kb.KBServiceExportSubjectResult)
+	success, err := handler.(kb.KBService).ExportSubject(ctx, realArg.Req)
+	if err != nil {
+		switch v := err.(type) {
+		case *common.ServiceError:
+			realResult.Err = v
+		default:
+			return err
+		}
+	} else {
+		realResult.Success = success
+	}
+	return nil
+}
+func newKBServiceExportSubjectArgs() interface{} {
+	return kb.NewKBServiceExportSubjectArgs()
+}
+
+func newKBServiceExportSubjectResult() interface{} {
+	return kb.NewKBServiceExportSubjectResult()
+}
+
+func eraseSubjectHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*kb.KBServiceEraseSubjectArgs)
+	realResult := result.(*kb.KBServiceEraseSubjectResult)
+	success, err := handler.(kb.KBService).EraseSubject(ctx, realArg.Req)
+	if err != nil {
+		switch v := err.(type) {
+		case *common.ServiceError:
+			realResult.Err = v
+		default:
+			return err
+		}
+	} else {
+		realResult.Success = success
+	}
+	return nil
+}
+func newKBServiceEraseSubjectArgs() interface{} {
+	return kb.NewKBServiceEraseSubjectArgs()
+}
+
+func newKBServiceEraseSubjectResult() interface{} {
+	return kb.NewKBServiceEraseSubjectResult()
+}
+
 type kClient struct {
 	c client.Client
 }
@@ -287,6 +442,34 @@ func (p *kClient) DeleteDoc(ctx context.Context, req *kb.DeleteDocRequest) (r *k
 	return _result.GetSuccess(), nil
 }
 
+func (p *kClient) GetDoc(ctx context.Context, req *kb.GetDocRequest) (r *common.KBDoc, err error) {
+	var _args kb.KBServiceGetDocArgs
+	_args.Req = req
+	var _result kb.KBServiceGetDocResult
+	if err = p.c.Call(ctx, "GetDoc", &_args, &_result); err != nil {
+		return
+	}
+	switch {
+	case _result.Err != nil:
+		return r, _result.Err
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) ListDocs(ctx context.Context, req *kb.ListDocsRequest) (r *kb.ListDocsResponse, err error) {
+	var _args kb.KBServiceListDocsArgs
+	_args.Req = req
+	var _result kb.KBServiceListDocsResult
+	if err = p.c.Call(ctx, "ListDocs", &_args, &_result); err != nil {
+		return
+	}
+	switch {
+	case _result.Err != nil:
+		return r, _result.Err
+	}
+	return _result.GetSuccess(), nil
+}
+
 func (p *kClient) Search(ctx context.Context, req *kb.SearchRequest) (r *kb.SearchResponse, err error) {
 	var _args kb.KBServiceSearchArgs
 	_args.Req = req
@@ -301,6 +484,20 @@ func (p *kClient) Search(ctx context.Context, req *kb.SearchRequest) (r *kb.Sear
 	return _result.GetSuccess(), nil
 }
 
+func (p *kClient) VectorSearch(ctx context.Context, req *kb.VectorSearchRequest) (r *kb.VectorSearchResponse, err error) {
+	var _args kb.KBServiceVectorSearchArgs
+	_args.Req = req
+	var _result kb.KBServiceVectorSearchResult
+	if err = p.c.Call(ctx, "VectorSearch", &_args, &_result); err != nil {
+		return
+	}
+	switch {
+	case _result.Err != nil:
+		return r, _result.Err
+	}
+	return _result.GetSuccess(), nil
+}
+
 func (p *kClient) Info(ctx context.Context) (r *kb.InfoResponse, err error) {
 	var _args kb.KBServiceInfoArgs
 	var _result kb.KBServiceInfoResult
@@ -313,3 +510,31 @@ func (p *kClient) Info(ctx context.Context) (r *kb.InfoResponse, err error) {
 	}
 	return _result.GetSuccess(), nil
 }
+
+func (p *kClient) ExportSubject(ctx context.Context, req *common.SubjectRequest) (r *kb.ExportSubjectResponse, err error) {
+	var _args kb.KBServiceExportSubjectArgs
+	_args.Req = req
+	var _result kb.KBServiceExportSubjectResult
+	if err = p.c.Call(ctx, "ExportSubject", &_args, &_result); err != nil {
+		return
+	}
+	switch {
+	case _result.Err != nil:
+		return r, _result.Err
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) EraseSubject(ctx context.Context, req *common.SubjectRequest) (r *common.ErasureSummary, err error) {
+	var _args kb.KBServiceEraseSubjectArgs
+	_args.Req = req
+	var _result kb.KBServiceEraseSubjectResult
+	if err = p.c.Call(ctx, "EraseSubject", &_args, &_result); err != nil {
+		return
+	}
+	switch {
+	case _result.Err != nil:
+		return r, _result.Err
+	}
+	return _result.GetSuccess(), nil
+}
@@ -8,8 +8,57 @@ import (
 	"github.com/gogogo1024/assist-fusion/kitex_gen/common"
 )
 
+type DuplicateCandidate struct {
+	Id         string  `thrift:"id,1" frugal:"1,default,string" json:"id"`
+	Title      string  `thrift:"title,2" frugal:"2,default,string" json:"title"`
+	Similarity float64 `thrift:"similarity,3" frugal:"3,default,double" json:"similarity"`
+}
+
+func NewDuplicateCandidate() *DuplicateCandidate {
+	return &DuplicateCandidate{}
+}
+
+func (p *DuplicateCandidate) InitDefault() {
+}
+
+func (p *DuplicateCandidate) GetId() (v string) {
+	return p.Id
+}
+
+func (p *DuplicateCandidate) GetTitle() (v string) {
+	return p.Title
+}
+
+func (p *DuplicateCandidate) GetSimilarity() (v float64) {
+	return p.Similarity
+}
+func (p *DuplicateCandidate) SetId(val string) {
+	p.Id = val
+}
+func (p *DuplicateCandidate) SetTitle(val string) {
+	p.Title = val
+}
+func (p *DuplicateCandidate) SetSimilarity(val float64) {
+	p.Similarity = val
+}
+
+func (p *DuplicateCandidate) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("DuplicateCandidate(%+v)", *p)
+}
+
+var fieldIDToName_DuplicateCandidate = map[int16]string{
+	1: "id",
+	2: "title",
+	3: "similarity",
+}
+
 type TicketResponse struct {
-	Ticket *common.Ticket `thrift:"ticket,1" frugal:"1,default,common.Ticket" json:"ticket"`
+	Ticket             *common.Ticket        `thrift:"ticket,1" frugal:"1,default,common.Ticket" json:"ticket"`
+	PossibleDuplicates []*DuplicateCandidate `thrift:"possible_duplicates,2,optional" frugal:"2,optional,list<DuplicateCandidate>" json:"possible_duplicates,omitempty"`
+	SurveyToken        *string               `thrift:"survey_token,3,optional" frugal:"3,optional,string" json:"survey_token,omitempty"`
 }
 
 func NewTicketResponse() *TicketResponse {
@@ -27,14 +76,46 @@ func (p *TicketResponse) GetTicket() (v *common.Ticket) {
 	}
 	return p.Ticket
 }
+
+var TicketResponse_PossibleDuplicates_DEFAULT []*DuplicateCandidate
+
+func (p *TicketResponse) GetPossibleDuplicates() (v []*DuplicateCandidate) {
+	if !p.IsSetPossibleDuplicates() {
+		return TicketResponse_PossibleDuplicates_DEFAULT
+	}
+	return p.PossibleDuplicates
+}
+
+var TicketResponse_SurveyToken_DEFAULT string
+
+func (p *TicketResponse) GetSurveyToken() (v string) {
+	if !p.IsSetSurveyToken() {
+		return TicketResponse_SurveyToken_DEFAULT
+	}
+	return *p.SurveyToken
+}
 func (p *TicketResponse) SetTicket(val *common.Ticket) {
 	p.Ticket = val
 }
+func (p *TicketResponse) SetPossibleDuplicates(val []*DuplicateCandidate) {
+	p.PossibleDuplicates = val
+}
+func (p *TicketResponse) SetSurveyToken(val *string) {
+	p.SurveyToken = val
+}
 
 func (p *TicketResponse) IsSetTicket() bool {
 	return p.Ticket != nil
 }
 
+func (p *TicketResponse) IsSetPossibleDuplicates() bool {
+	return p.PossibleDuplicates != nil
+}
+
+func (p *TicketResponse) IsSetSurveyToken() bool {
+	return p.SurveyToken != nil
+}
+
 func (p *TicketResponse) String() string {
 	if p == nil {
 		return "<nil>"
@@ -44,12 +125,22 @@ func (p *TicketResponse) String() string {
 
 var fieldIDToName_TicketResponse = map[int16]string{
 	1: "ticket",
+	2: "possible_duplicates",
+	3: "survey_token",
 }
 
 type CreateTicketRequest struct {
-	Title string  `thrift:"title,1" frugal:"1,default,string" json:"title"`
-	Desc  string  `thrift:"desc,2" frugal:"2,default,string" json:"desc"`
-	Note  *string `thrift:"note,3,optional" frugal:"3,optional,string" json:"note,omitempty"`
+	Title            string            `thrift:"title,1" frugal:"1,default,string" json:"title"`
+	Desc             string            `thrift:"desc,2" frugal:"2,default,string" json:"desc"`
+	Note             *string           `thrift:"note,3,optional" frugal:"3,optional,string" json:"note,omitempty"`
+	Category         *string           `thrift:"category,4,optional" frugal:"4,optional,string" json:"category,omitempty"`
+	Customer         *string           `thrift:"customer,5,optional" frugal:"5,optional,string" json:"customer,omitempty"`
+	StrictDuplicates *bool             `thrift:"strict_duplicates,6,optional" frugal:"6,optional,bool" json:"strict_duplicates,omitempty"`
+	IdempotencyKey   *string           `thrift:"idempotency_key,7,optional" frugal:"7,optional,string" json:"idempotency_key,omitempty"`
+	Priority         *string           `thrift:"priority,8,optional" frugal:"8,optional,string" json:"priority,omitempty"`
+	Tags             []string          `thrift:"tags,9,optional" frugal:"9,optional,list<string>" json:"tags,omitempty"`
+	CustomFields     map[string]string `thrift:"custom_fields,10,optional" frugal:"10,optional,map<string:string>" json:"custom_fields,omitempty"`
+	DueAt            *int64            `thrift:"due_at,11,optional" frugal:"11,optional,i64" json:"due_at,omitempty"`
 }
 
 func NewCreateTicketRequest() *CreateTicketRequest {
@@ -75,6 +166,78 @@ func (p *CreateTicketRequest) GetNote() (v string) {
 	}
 	return *p.Note
 }
+
+var CreateTicketRequest_Category_DEFAULT string
+
+func (p *CreateTicketRequest) GetCategory() (v string) {
+	if !p.IsSetCategory() {
+		return CreateTicketRequest_Category_DEFAULT
+	}
+	return *p.Category
+}
+
+var CreateTicketRequest_Customer_DEFAULT string
+
+func (p *CreateTicketRequest) GetCustomer() (v string) {
+	if !p.IsSetCustomer() {
+		return CreateTicketRequest_Customer_DEFAULT
+	}
+	return *p.Customer
+}
+
+var CreateTicketRequest_StrictDuplicates_DEFAULT bool
+
+func (p *CreateTicketRequest) GetStrictDuplicates() (v bool) {
+	if !p.IsSetStrictDuplicates() {
+		return CreateTicketRequest_StrictDuplicates_DEFAULT
+	}
+	return *p.StrictDuplicates
+}
+
+var CreateTicketRequest_IdempotencyKey_DEFAULT string
+
+func (p *CreateTicketRequest) GetIdempotencyKey() (v string) {
+	if !p.IsSetIdempotencyKey() {
+		return CreateTicketRequest_IdempotencyKey_DEFAULT
+	}
+	return *p.IdempotencyKey
+}
+
+var CreateTicketRequest_Priority_DEFAULT string
+
+func (p *CreateTicketRequest) GetPriority() (v string) {
+	if !p.IsSetPriority() {
+		return CreateTicketRequest_Priority_DEFAULT
+	}
+	return *p.Priority
+}
+
+var CreateTicketRequest_Tags_DEFAULT []string
+
+func (p *CreateTicketRequest) GetTags() (v []string) {
+	if !p.IsSetTags() {
+		return CreateTicketRequest_Tags_DEFAULT
+	}
+	return p.Tags
+}
+
+var CreateTicketRequest_CustomFields_DEFAULT map[string]string
+
+func (p *CreateTicketRequest) GetCustomFields() (v map[string]string) {
+	if !p.IsSetCustomFields() {
+		return CreateTicketRequest_CustomFields_DEFAULT
+	}
+	return p.CustomFields
+}
+
+var CreateTicketRequest_DueAt_DEFAULT int64
+
+func (p *CreateTicketRequest) GetDueAt() (v int64) {
+	if !p.IsSetDueAt() {
+		return CreateTicketRequest_DueAt_DEFAULT
+	}
+	return *p.DueAt
+}
 func (p *CreateTicketRequest) SetTitle(val string) {
 	p.Title = val
 }
@@ -84,11 +247,67 @@ func (p *CreateTicketRequest) SetDesc(val string) {
 func (p *CreateTicketRequest) SetNote(val *string) {
 	p.Note = val
 }
+func (p *CreateTicketRequest) SetCategory(val *string) {
+	p.Category = val
+}
+func (p *CreateTicketRequest) SetCustomer(val *string) {
+	p.Customer = val
+}
+func (p *CreateTicketRequest) SetStrictDuplicates(val *bool) {
+	p.StrictDuplicates = val
+}
+func (p *CreateTicketRequest) SetIdempotencyKey(val *string) {
+	p.IdempotencyKey = val
+}
+func (p *CreateTicketRequest) SetPriority(val *string) {
+	p.Priority = val
+}
+func (p *CreateTicketRequest) SetTags(val []string) {
+	p.Tags = val
+}
+func (p *CreateTicketRequest) SetCustomFields(val map[string]string) {
+	p.CustomFields = val
+}
+func (p *CreateTicketRequest) SetDueAt(val *int64) {
+	p.DueAt = val
+}
 
 func (p *CreateTicketRequest) IsSetNote() bool {
 	return p.Note != nil
 }
 
+func (p *CreateTicketRequest) IsSetCategory() bool {
+	return p.Category != nil
+}
+
+func (p *CreateTicketRequest) IsSetCustomer() bool {
+	return p.Customer != nil
+}
+
+func (p *CreateTicketRequest) IsSetStrictDuplicates() bool {
+	return p.StrictDuplicates != nil
+}
+
+func (p *CreateTicketRequest) IsSetIdempotencyKey() bool {
+	return p.IdempotencyKey != nil
+}
+
+func (p *CreateTicketRequest) IsSetPriority() bool {
+	return p.Priority != nil
+}
+
+func (p *CreateTicketRequest) IsSetTags() bool {
+	return p.Tags != nil
+}
+
+func (p *CreateTicketRequest) IsSetCustomFields() bool {
+	return p.CustomFields != nil
+}
+
+func (p *CreateTicketRequest) IsSetDueAt() bool {
+	return p.DueAt != nil
+}
+
 func (p *CreateTicketRequest) String() string {
 	if p == nil {
 		return "<nil>"
@@ -97,9 +316,17 @@ func (p *CreateTicketRequest) String() string {
 }
 
 var fieldIDToName_CreateTicketRequest = map[int16]string{
-	1: "title",
-	2: "desc",
-	3: "note",
+	1:  "title",
+	2:  "desc",
+	3:  "note",
+	4:  "category",
+	5:  "customer",
+	6:  "strict_duplicates",
+	7:  "idempotency_key",
+	8:  "priority",
+	9:  "tags",
+	10: "custom_fields",
+	11: "due_at",
 }
 
 type GetTicketRequest struct {
@@ -136,6 +363,8 @@ type ListTicketsRequest struct {
 	Statuses    []common.TicketStatus `thrift:"statuses,2,optional" frugal:"2,optional,list<TicketStatus>" json:"statuses,omitempty"`
 	CreatedFrom *int64                `thrift:"created_from,3,optional" frugal:"3,optional,i64" json:"created_from,omitempty"`
 	CreatedTo   *int64                `thrift:"created_to,4,optional" frugal:"4,optional,i64" json:"created_to,omitempty"`
+	Include     []string              `thrift:"include,5,optional" frugal:"5,optional,list<string>" json:"include,omitempty"`
+	Tier        *string               `thrift:"tier,6,optional" frugal:"6,optional,string" json:"tier,omitempty"`
 }
 
 func NewListTicketsRequest() *ListTicketsRequest {
@@ -180,6 +409,24 @@ func (p *ListTicketsRequest) GetCreatedTo() (v int64) {
 	}
 	return *p.CreatedTo
 }
+
+var ListTicketsRequest_Include_DEFAULT []string
+
+func (p *ListTicketsRequest) GetInclude() (v []string) {
+	if !p.IsSetInclude() {
+		return ListTicketsRequest_Include_DEFAULT
+	}
+	return p.Include
+}
+
+var ListTicketsRequest_Tier_DEFAULT string
+
+func (p *ListTicketsRequest) GetTier() (v string) {
+	if !p.IsSetTier() {
+		return ListTicketsRequest_Tier_DEFAULT
+	}
+	return *p.Tier
+}
 func (p *ListTicketsRequest) SetPagination(val *common.Pagination) {
 	p.Pagination = val
 }
@@ -192,6 +439,12 @@ func (p *ListTicketsRequest) SetCreatedFrom(val *int64) {
 func (p *ListTicketsRequest) SetCreatedTo(val *int64) {
 	p.CreatedTo = val
 }
+func (p *ListTicketsRequest) SetInclude(val []string) {
+	p.Include = val
+}
+func (p *ListTicketsRequest) SetTier(val *string) {
+	p.Tier = val
+}
 
 func (p *ListTicketsRequest) IsSetPagination() bool {
 	return p.Pagination != nil
@@ -209,6 +462,14 @@ func (p *ListTicketsRequest) IsSetCreatedTo() bool {
 	return p.CreatedTo != nil
 }
 
+func (p *ListTicketsRequest) IsSetInclude() bool {
+	return p.Include != nil
+}
+
+func (p *ListTicketsRequest) IsSetTier() bool {
+	return p.Tier != nil
+}
+
 func (p *ListTicketsRequest) String() string {
 	if p == nil {
 		return "<nil>"
@@ -221,6 +482,8 @@ var fieldIDToName_ListTicketsRequest = map[int16]string{
 	2: "statuses",
 	3: "created_from",
 	4: "created_to",
+	5: "include",
+	6: "tier",
 }
 
 type ListTicketsResponse struct {
@@ -271,8 +534,10 @@ var fieldIDToName_ListTicketsResponse = map[int16]string{
 }
 
 type TicketActionRequest struct {
-	Id   string  `thrift:"id,1" frugal:"1,default,string" json:"id"`
-	Note *string `thrift:"note,2,optional" frugal:"2,optional,string" json:"note,omitempty"`
+	Id       string  `thrift:"id,1" frugal:"1,default,string" json:"id"`
+	Note     *string `thrift:"note,2,optional" frugal:"2,optional,string" json:"note,omitempty"`
+	Assignee *string `thrift:"assignee,3,optional" frugal:"3,optional,string" json:"assignee,omitempty"`
+	Tier     *string `thrift:"tier,4,optional" frugal:"4,optional,string" json:"tier,omitempty"`
 }
 
 func NewTicketActionRequest() *TicketActionRequest {
@@ -294,17 +559,49 @@ func (p *TicketActionRequest) GetNote() (v string) {
 	}
 	return *p.Note
 }
+
+var TicketActionRequest_Assignee_DEFAULT string
+
+func (p *TicketActionRequest) GetAssignee() (v string) {
+	if !p.IsSetAssignee() {
+		return TicketActionRequest_Assignee_DEFAULT
+	}
+	return *p.Assignee
+}
+
+var TicketActionRequest_Tier_DEFAULT string
+
+func (p *TicketActionRequest) GetTier() (v string) {
+	if !p.IsSetTier() {
+		return TicketActionRequest_Tier_DEFAULT
+	}
+	return *p.Tier
+}
 func (p *TicketActionRequest) SetId(val string) {
 	p.Id = val
 }
 func (p *TicketActionRequest) SetNote(val *string) {
 	p.Note = val
 }
+func (p *TicketActionRequest) SetAssignee(val *string) {
+	p.Assignee = val
+}
+func (p *TicketActionRequest) SetTier(val *string) {
+	p.Tier = val
+}
 
 func (p *TicketActionRequest) IsSetNote() bool {
 	return p.Note != nil
 }
 
+func (p *TicketActionRequest) IsSetAssignee() bool {
+	return p.Assignee != nil
+}
+
+func (p *TicketActionRequest) IsSetTier() bool {
+	return p.Tier != nil
+}
+
 func (p *TicketActionRequest) String() string {
 	if p == nil {
 		return "<nil>"
@@ -315,6 +612,8 @@ func (p *TicketActionRequest) String() string {
 var fieldIDToName_TicketActionRequest = map[int16]string{
 	1: "id",
 	2: "note",
+	3: "assignee",
+	4: "tier",
 }
 
 type GetCyclesRequest struct {
@@ -347,7 +646,9 @@ var fieldIDToName_GetCyclesRequest = map[int16]string{
 }
 
 type GetEventsRequest struct {
-	Id string `thrift:"id,1" frugal:"1,default,string" json:"id"`
+	Id        string  `thrift:"id,1" frugal:"1,default,string" json:"id"`
+	ActorId   *string `thrift:"actor_id,2,optional" frugal:"2,optional,string" json:"actor_id,omitempty"`
+	ActorKind *string `thrift:"actor_kind,3,optional" frugal:"3,optional,string" json:"actor_kind,omitempty"`
 }
 
 func NewGetEventsRequest() *GetEventsRequest {
@@ -360,9 +661,41 @@ func (p *GetEventsRequest) InitDefault() {
 func (p *GetEventsRequest) GetId() (v string) {
 	return p.Id
 }
+
+var GetEventsRequest_ActorId_DEFAULT string
+
+func (p *GetEventsRequest) GetActorId() (v string) {
+	if !p.IsSetActorId() {
+		return GetEventsRequest_ActorId_DEFAULT
+	}
+	return *p.ActorId
+}
+
+var GetEventsRequest_ActorKind_DEFAULT string
+
+func (p *GetEventsRequest) GetActorKind() (v string) {
+	if !p.IsSetActorKind() {
+		return GetEventsRequest_ActorKind_DEFAULT
+	}
+	return *p.ActorKind
+}
 func (p *GetEventsRequest) SetId(val string) {
 	p.Id = val
 }
+func (p *GetEventsRequest) SetActorId(val *string) {
+	p.ActorId = val
+}
+func (p *GetEventsRequest) SetActorKind(val *string) {
+	p.ActorKind = val
+}
+
+func (p *GetEventsRequest) IsSetActorId() bool {
+	return p.ActorId != nil
+}
+
+func (p *GetEventsRequest) IsSetActorKind() bool {
+	return p.ActorKind != nil
+}
 
 func (p *GetEventsRequest) String() string {
 	if p == nil {
@@ -373,870 +706,6288 @@ func (p *GetEventsRequest) String() string {
 
 var fieldIDToName_GetEventsRequest = map[int16]string{
 	1: "id",
+	2: "actor_id",
+	3: "actor_kind",
 }
 
-type TicketService interface {
-	CreateTicket(ctx context.Context, req *CreateTicketRequest) (r *TicketResponse, err error)
+type AddCommentRequest struct {
+	Id          string               `thrift:"id,1" frugal:"1,default,string" json:"id"`
+	Body        string               `thrift:"body,2" frugal:"2,default,string" json:"body"`
+	Attachments []*common.Attachment `thrift:"attachments,3,optional" frugal:"3,optional,list<common.Attachment>" json:"attachments,omitempty"`
+}
 
-	GetTicket(ctx context.Context, req *GetTicketRequest) (r *TicketResponse, err error)
+func NewAddCommentRequest() *AddCommentRequest {
+	return &AddCommentRequest{}
+}
 
-	ListTickets(ctx context.Context, req *ListTicketsRequest) (r *ListTicketsResponse, err error)
+func (p *AddCommentRequest) InitDefault() {
+}
 
-	Assign(ctx context.Context, req *TicketActionRequest) (r *TicketResponse, err error)
+func (p *AddCommentRequest) GetId() (v string) {
+	return p.Id
+}
 
-	Resolve(ctx context.Context, req *TicketActionRequest) (r *TicketResponse, err error)
+func (p *AddCommentRequest) GetBody() (v string) {
+	return p.Body
+}
 
-	Escalate(ctx context.Context, req *TicketActionRequest) (r *TicketResponse, err error)
+var AddCommentRequest_Attachments_DEFAULT []*common.Attachment
 
-	Reopen(ctx context.Context, req *TicketActionRequest) (r *TicketResponse, err error)
+func (p *AddCommentRequest) GetAttachments() (v []*common.Attachment) {
+	if !p.IsSetAttachments() {
+		return AddCommentRequest_Attachments_DEFAULT
+	}
+	return p.Attachments
+}
+func (p *AddCommentRequest) SetId(val string) {
+	p.Id = val
+}
+func (p *AddCommentRequest) SetBody(val string) {
+	p.Body = val
+}
+func (p *AddCommentRequest) SetAttachments(val []*common.Attachment) {
+	p.Attachments = val
+}
 
-	GetCycles(ctx context.Context, req *GetCyclesRequest) (r []*common.TicketCycle, err error)
+func (p *AddCommentRequest) IsSetAttachments() bool {
+	return p.Attachments != nil
+}
 
-	GetEvents(ctx context.Context, req *GetEventsRequest) (r []*common.TicketEvent, err error)
+func (p *AddCommentRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("AddCommentRequest(%+v)", *p)
 }
 
-type TicketServiceCreateTicketArgs struct {
-	Req *CreateTicketRequest `thrift:"req,1" frugal:"1,default,CreateTicketRequest" json:"req"`
+var fieldIDToName_AddCommentRequest = map[int16]string{
+	1: "id",
+	2: "body",
+	3: "attachments",
 }
 
-func NewTicketServiceCreateTicketArgs() *TicketServiceCreateTicketArgs {
-	return &TicketServiceCreateTicketArgs{}
+type WatchRequest struct {
+	Id     string  `thrift:"id,1" frugal:"1,default,string" json:"id"`
+	UserId *string `thrift:"user_id,2,optional" frugal:"2,optional,string" json:"user_id,omitempty"`
 }
 
-func (p *TicketServiceCreateTicketArgs) InitDefault() {
+func NewWatchRequest() *WatchRequest {
+	return &WatchRequest{}
 }
 
-var TicketServiceCreateTicketArgs_Req_DEFAULT *CreateTicketRequest
+func (p *WatchRequest) InitDefault() {
+}
 
-func (p *TicketServiceCreateTicketArgs) GetReq() (v *CreateTicketRequest) {
-	if !p.IsSetReq() {
-		return TicketServiceCreateTicketArgs_Req_DEFAULT
+func (p *WatchRequest) GetId() (v string) {
+	return p.Id
+}
+
+var WatchRequest_UserId_DEFAULT string
+
+func (p *WatchRequest) GetUserId() (v string) {
+	if !p.IsSetUserId() {
+		return WatchRequest_UserId_DEFAULT
 	}
-	return p.Req
+	return *p.UserId
 }
-func (p *TicketServiceCreateTicketArgs) SetReq(val *CreateTicketRequest) {
-	p.Req = val
+func (p *WatchRequest) SetId(val string) {
+	p.Id = val
+}
+func (p *WatchRequest) SetUserId(val *string) {
+	p.UserId = val
 }
 
-func (p *TicketServiceCreateTicketArgs) IsSetReq() bool {
-	return p.Req != nil
+func (p *WatchRequest) IsSetUserId() bool {
+	return p.UserId != nil
 }
 
-func (p *TicketServiceCreateTicketArgs) String() string {
+func (p *WatchRequest) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("TicketServiceCreateTicketArgs(%+v)", *p)
+	return fmt.Sprintf("WatchRequest(%+v)", *p)
 }
 
-var fieldIDToName_TicketServiceCreateTicketArgs = map[int16]string{
-	1: "req",
+var fieldIDToName_WatchRequest = map[int16]string{
+	1: "id",
+	2: "user_id",
 }
 
-type TicketServiceCreateTicketResult struct {
-	Success *TicketResponse      `thrift:"success,0,optional" frugal:"0,optional,TicketResponse" json:"success,omitempty"`
-	Err     *common.ServiceError `thrift:"err,1,optional" frugal:"1,optional,common.ServiceError" json:"err,omitempty"`
+type Notification struct {
+	Id        string        `thrift:"id,1" frugal:"1,default,string" json:"id"`
+	TicketId  string        `thrift:"ticket_id,2" frugal:"2,default,string" json:"ticket_id"`
+	EventType string        `thrift:"event_type,3" frugal:"3,default,string" json:"event_type"`
+	Subject   string        `thrift:"subject,4" frugal:"4,default,string" json:"subject"`
+	Body      string        `thrift:"body,5" frugal:"5,default,string" json:"body"`
+	CreatedAt int64         `thrift:"created_at,6" frugal:"6,default,i64" json:"created_at"`
+	Read_     bool          `thrift:"read,7" frugal:"7,default,bool" json:"read"`
+	Actor     *common.Actor `thrift:"actor,8,optional" frugal:"8,optional,common.Actor" json:"actor,omitempty"`
+	Role      string        `thrift:"role,9" frugal:"9,default,string" json:"role"`
 }
 
-func NewTicketServiceCreateTicketResult() *TicketServiceCreateTicketResult {
-	return &TicketServiceCreateTicketResult{}
+func NewNotification() *Notification {
+	return &Notification{}
 }
 
-func (p *TicketServiceCreateTicketResult) InitDefault() {
+func (p *Notification) InitDefault() {
 }
 
-var TicketServiceCreateTicketResult_Success_DEFAULT *TicketResponse
+func (p *Notification) GetId() (v string) {
+	return p.Id
+}
 
-func (p *TicketServiceCreateTicketResult) GetSuccess() (v *TicketResponse) {
-	if !p.IsSetSuccess() {
-		return TicketServiceCreateTicketResult_Success_DEFAULT
-	}
-	return p.Success
+func (p *Notification) GetTicketId() (v string) {
+	return p.TicketId
 }
 
-var TicketServiceCreateTicketResult_Err_DEFAULT *common.ServiceError
+func (p *Notification) GetEventType() (v string) {
+	return p.EventType
+}
 
-func (p *TicketServiceCreateTicketResult) GetErr() (v *common.ServiceError) {
-	if !p.IsSetErr() {
-		return TicketServiceCreateTicketResult_Err_DEFAULT
-	}
-	return p.Err
+func (p *Notification) GetSubject() (v string) {
+	return p.Subject
 }
-func (p *TicketServiceCreateTicketResult) SetSuccess(x interface{}) {
-	p.Success = x.(*TicketResponse)
+
+func (p *Notification) GetBody() (v string) {
+	return p.Body
 }
-func (p *TicketServiceCreateTicketResult) SetErr(val *common.ServiceError) {
-	p.Err = val
+
+func (p *Notification) GetCreatedAt() (v int64) {
+	return p.CreatedAt
 }
 
-func (p *TicketServiceCreateTicketResult) IsSetSuccess() bool {
-	return p.Success != nil
+func (p *Notification) GetRead() (v bool) {
+	return p.Read_
 }
 
-func (p *TicketServiceCreateTicketResult) IsSetErr() bool {
-	return p.Err != nil
+var Notification_Actor_DEFAULT *common.Actor
+
+func (p *Notification) GetActor() (v *common.Actor) {
+	if !p.IsSetActor() {
+		return Notification_Actor_DEFAULT
+	}
+	return p.Actor
 }
 
-func (p *TicketServiceCreateTicketResult) String() string {
+func (p *Notification) GetRole() (v string) {
+	return p.Role
+}
+func (p *Notification) SetId(val string) {
+	p.Id = val
+}
+func (p *Notification) SetTicketId(val string) {
+	p.TicketId = val
+}
+func (p *Notification) SetEventType(val string) {
+	p.EventType = val
+}
+func (p *Notification) SetSubject(val string) {
+	p.Subject = val
+}
+func (p *Notification) SetBody(val string) {
+	p.Body = val
+}
+func (p *Notification) SetCreatedAt(val int64) {
+	p.CreatedAt = val
+}
+func (p *Notification) SetRead(val bool) {
+	p.Read_ = val
+}
+func (p *Notification) SetActor(val *common.Actor) {
+	p.Actor = val
+}
+func (p *Notification) SetRole(val string) {
+	p.Role = val
+}
+
+func (p *Notification) IsSetActor() bool {
+	return p.Actor != nil
+}
+
+func (p *Notification) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("TicketServiceCreateTicketResult(%+v)", *p)
+	return fmt.Sprintf("Notification(%+v)", *p)
 }
 
-var fieldIDToName_TicketServiceCreateTicketResult = map[int16]string{
-	0: "success",
-	1: "err",
+var fieldIDToName_Notification = map[int16]string{
+	1: "id",
+	2: "ticket_id",
+	3: "event_type",
+	4: "subject",
+	5: "body",
+	6: "created_at",
+	7: "read",
+	8: "actor",
+	9: "role",
 }
 
-type TicketServiceGetTicketArgs struct {
-	Req *GetTicketRequest `thrift:"req,1" frugal:"1,default,GetTicketRequest" json:"req"`
+type ListNotificationsRequest struct {
+	UnreadOnly *bool  `thrift:"unread_only,1,optional" frugal:"1,optional,bool" json:"unread_only,omitempty"`
+	Limit      *int32 `thrift:"limit,2,optional" frugal:"2,optional,i32" json:"limit,omitempty"`
+}
+
+func NewListNotificationsRequest() *ListNotificationsRequest {
+	return &ListNotificationsRequest{}
+}
+
+func (p *ListNotificationsRequest) InitDefault() {
+}
+
+var ListNotificationsRequest_UnreadOnly_DEFAULT bool
+
+func (p *ListNotificationsRequest) GetUnreadOnly() (v bool) {
+	if !p.IsSetUnreadOnly() {
+		return ListNotificationsRequest_UnreadOnly_DEFAULT
+	}
+	return *p.UnreadOnly
+}
+
+var ListNotificationsRequest_Limit_DEFAULT int32
+
+func (p *ListNotificationsRequest) GetLimit() (v int32) {
+	if !p.IsSetLimit() {
+		return ListNotificationsRequest_Limit_DEFAULT
+	}
+	return *p.Limit
+}
+func (p *ListNotificationsRequest) SetUnreadOnly(val *bool) {
+	p.UnreadOnly = val
+}
+func (p *ListNotificationsRequest) SetLimit(val *int32) {
+	p.Limit = val
+}
+
+func (p *ListNotificationsRequest) IsSetUnreadOnly() bool {
+	return p.UnreadOnly != nil
+}
+
+func (p *ListNotificationsRequest) IsSetLimit() bool {
+	return p.Limit != nil
+}
+
+func (p *ListNotificationsRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ListNotificationsRequest(%+v)", *p)
+}
+
+var fieldIDToName_ListNotificationsRequest = map[int16]string{
+	1: "unread_only",
+	2: "limit",
+}
+
+type ListNotificationsResponse struct {
+	Items  []*Notification `thrift:"items,1" frugal:"1,default,list<Notification>" json:"items"`
+	Unread int32           `thrift:"unread,2" frugal:"2,default,i32" json:"unread"`
+}
+
+func NewListNotificationsResponse() *ListNotificationsResponse {
+	return &ListNotificationsResponse{}
+}
+
+func (p *ListNotificationsResponse) InitDefault() {
+}
+
+func (p *ListNotificationsResponse) GetItems() (v []*Notification) {
+	return p.Items
+}
+
+func (p *ListNotificationsResponse) GetUnread() (v int32) {
+	return p.Unread
+}
+func (p *ListNotificationsResponse) SetItems(val []*Notification) {
+	p.Items = val
+}
+func (p *ListNotificationsResponse) SetUnread(val int32) {
+	p.Unread = val
+}
+
+func (p *ListNotificationsResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ListNotificationsResponse(%+v)", *p)
+}
+
+var fieldIDToName_ListNotificationsResponse = map[int16]string{
+	1: "items",
+	2: "unread",
+}
+
+type MarkNotificationsRequest struct {
+	Ids   []string `thrift:"ids,1" frugal:"1,default,list<string>" json:"ids"`
+	All   *bool    `thrift:"all,2,optional" frugal:"2,optional,bool" json:"all,omitempty"`
+	Read_ bool     `thrift:"read,3" frugal:"3,default,bool" json:"read"`
+}
+
+func NewMarkNotificationsRequest() *MarkNotificationsRequest {
+	return &MarkNotificationsRequest{}
+}
+
+func (p *MarkNotificationsRequest) InitDefault() {
+}
+
+func (p *MarkNotificationsRequest) GetIds() (v []string) {
+	return p.Ids
+}
+
+var MarkNotificationsRequest_All_DEFAULT bool
+
+func (p *MarkNotificationsRequest) GetAll() (v bool) {
+	if !p.IsSetAll() {
+		return MarkNotificationsRequest_All_DEFAULT
+	}
+	return *p.All
+}
+
+func (p *MarkNotificationsRequest) GetRead() (v bool) {
+	return p.Read_
+}
+func (p *MarkNotificationsRequest) SetIds(val []string) {
+	p.Ids = val
+}
+func (p *MarkNotificationsRequest) SetAll(val *bool) {
+	p.All = val
+}
+func (p *MarkNotificationsRequest) SetRead(val bool) {
+	p.Read_ = val
+}
+
+func (p *MarkNotificationsRequest) IsSetAll() bool {
+	return p.All != nil
+}
+
+func (p *MarkNotificationsRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("MarkNotificationsRequest(%+v)", *p)
+}
+
+var fieldIDToName_MarkNotificationsRequest = map[int16]string{
+	1: "ids",
+	2: "all",
+	3: "read",
+}
+
+type MarkNotificationsResponse struct {
+	Updated int32 `thrift:"updated,1" frugal:"1,default,i32" json:"updated"`
+	Unread  int32 `thrift:"unread,2" frugal:"2,default,i32" json:"unread"`
+}
+
+func NewMarkNotificationsResponse() *MarkNotificationsResponse {
+	return &MarkNotificationsResponse{}
+}
+
+func (p *MarkNotificationsResponse) InitDefault() {
+}
+
+func (p *MarkNotificationsResponse) GetUpdated() (v int32) {
+	return p.Updated
+}
+
+func (p *MarkNotificationsResponse) GetUnread() (v int32) {
+	return p.Unread
+}
+func (p *MarkNotificationsResponse) SetUpdated(val int32) {
+	p.Updated = val
+}
+func (p *MarkNotificationsResponse) SetUnread(val int32) {
+	p.Unread = val
+}
+
+func (p *MarkNotificationsResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("MarkNotificationsResponse(%+v)", *p)
+}
+
+var fieldIDToName_MarkNotificationsResponse = map[int16]string{
+	1: "updated",
+	2: "unread",
+}
+
+type NotificationPrefs struct {
+	Channels   []string `thrift:"channels,1" frugal:"1,default,list<string>" json:"channels"`
+	Email      string   `thrift:"email,2" frugal:"2,default,string" json:"email"`
+	WebhookUrl string   `thrift:"webhook_url,3" frugal:"3,default,string" json:"webhook_url"`
+}
+
+func NewNotificationPrefs() *NotificationPrefs {
+	return &NotificationPrefs{}
+}
+
+func (p *NotificationPrefs) InitDefault() {
+}
+
+func (p *NotificationPrefs) GetChannels() (v []string) {
+	return p.Channels
+}
+
+func (p *NotificationPrefs) GetEmail() (v string) {
+	return p.Email
+}
+
+func (p *NotificationPrefs) GetWebhookUrl() (v string) {
+	return p.WebhookUrl
+}
+func (p *NotificationPrefs) SetChannels(val []string) {
+	p.Channels = val
+}
+func (p *NotificationPrefs) SetEmail(val string) {
+	p.Email = val
+}
+func (p *NotificationPrefs) SetWebhookUrl(val string) {
+	p.WebhookUrl = val
+}
+
+func (p *NotificationPrefs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("NotificationPrefs(%+v)", *p)
+}
+
+var fieldIDToName_NotificationPrefs = map[int16]string{
+	1: "channels",
+	2: "email",
+	3: "webhook_url",
+}
+
+type GetNotificationPrefsRequest struct {
+}
+
+func NewGetNotificationPrefsRequest() *GetNotificationPrefsRequest {
+	return &GetNotificationPrefsRequest{}
+}
+
+func (p *GetNotificationPrefsRequest) InitDefault() {
+}
+
+func (p *GetNotificationPrefsRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("GetNotificationPrefsRequest(%+v)", *p)
+}
+
+var fieldIDToName_GetNotificationPrefsRequest = map[int16]string{}
+
+type SetNotificationPrefsRequest struct {
+	Prefs *NotificationPrefs `thrift:"prefs,1" frugal:"1,default,NotificationPrefs" json:"prefs"`
+}
+
+func NewSetNotificationPrefsRequest() *SetNotificationPrefsRequest {
+	return &SetNotificationPrefsRequest{}
+}
+
+func (p *SetNotificationPrefsRequest) InitDefault() {
+}
+
+var SetNotificationPrefsRequest_Prefs_DEFAULT *NotificationPrefs
+
+func (p *SetNotificationPrefsRequest) GetPrefs() (v *NotificationPrefs) {
+	if !p.IsSetPrefs() {
+		return SetNotificationPrefsRequest_Prefs_DEFAULT
+	}
+	return p.Prefs
+}
+func (p *SetNotificationPrefsRequest) SetPrefs(val *NotificationPrefs) {
+	p.Prefs = val
+}
+
+func (p *SetNotificationPrefsRequest) IsSetPrefs() bool {
+	return p.Prefs != nil
+}
+
+func (p *SetNotificationPrefsRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("SetNotificationPrefsRequest(%+v)", *p)
+}
+
+var fieldIDToName_SetNotificationPrefsRequest = map[int16]string{
+	1: "prefs",
+}
+
+type VerifyAuditRequest struct {
+	Id string `thrift:"id,1" frugal:"1,default,string" json:"id"`
+}
+
+func NewVerifyAuditRequest() *VerifyAuditRequest {
+	return &VerifyAuditRequest{}
+}
+
+func (p *VerifyAuditRequest) InitDefault() {
+}
+
+func (p *VerifyAuditRequest) GetId() (v string) {
+	return p.Id
+}
+func (p *VerifyAuditRequest) SetId(val string) {
+	p.Id = val
+}
+
+func (p *VerifyAuditRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("VerifyAuditRequest(%+v)", *p)
+}
+
+var fieldIDToName_VerifyAuditRequest = map[int16]string{
+	1: "id",
+}
+
+type AuditHead struct {
+	TicketId   string `thrift:"ticket_id,1" frugal:"1,default,string" json:"ticket_id"`
+	EventCount int32  `thrift:"event_count,2" frugal:"2,default,i32" json:"event_count"`
+	HeadHash   string `thrift:"head_hash,3" frugal:"3,default,string" json:"head_hash"`
+	ExportedAt int64  `thrift:"exported_at,4" frugal:"4,default,i64" json:"exported_at"`
+	KeyId      string `thrift:"key_id,5" frugal:"5,default,string" json:"key_id"`
+	Signature  string `thrift:"signature,6" frugal:"6,default,string" json:"signature"`
+}
+
+func NewAuditHead() *AuditHead {
+	return &AuditHead{}
+}
+
+func (p *AuditHead) InitDefault() {
+}
+
+func (p *AuditHead) GetTicketId() (v string) {
+	return p.TicketId
+}
+
+func (p *AuditHead) GetEventCount() (v int32) {
+	return p.EventCount
+}
+
+func (p *AuditHead) GetHeadHash() (v string) {
+	return p.HeadHash
+}
+
+func (p *AuditHead) GetExportedAt() (v int64) {
+	return p.ExportedAt
+}
+
+func (p *AuditHead) GetKeyId() (v string) {
+	return p.KeyId
+}
+
+func (p *AuditHead) GetSignature() (v string) {
+	return p.Signature
+}
+func (p *AuditHead) SetTicketId(val string) {
+	p.TicketId = val
+}
+func (p *AuditHead) SetEventCount(val int32) {
+	p.EventCount = val
+}
+func (p *AuditHead) SetHeadHash(val string) {
+	p.HeadHash = val
+}
+func (p *AuditHead) SetExportedAt(val int64) {
+	p.ExportedAt = val
+}
+func (p *AuditHead) SetKeyId(val string) {
+	p.KeyId = val
+}
+func (p *AuditHead) SetSignature(val string) {
+	p.Signature = val
+}
+
+func (p *AuditHead) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("AuditHead(%+v)", *p)
+}
+
+var fieldIDToName_AuditHead = map[int16]string{
+	1: "ticket_id",
+	2: "event_count",
+	3: "head_hash",
+	4: "exported_at",
+	5: "key_id",
+	6: "signature",
+}
+
+type VerifyAuditResponse struct {
+	TicketId    string     `thrift:"ticket_id,1" frugal:"1,default,string" json:"ticket_id"`
+	Ok          bool       `thrift:"ok,2" frugal:"2,default,bool" json:"ok"`
+	Events      int32      `thrift:"events,3" frugal:"3,default,i32" json:"events"`
+	BrokenIndex int32      `thrift:"broken_index,4" frugal:"4,default,i32" json:"broken_index"`
+	Reason      string     `thrift:"reason,5" frugal:"5,default,string" json:"reason"`
+	Head        *AuditHead `thrift:"head,6" frugal:"6,default,AuditHead" json:"head"`
+}
+
+func NewVerifyAuditResponse() *VerifyAuditResponse {
+	return &VerifyAuditResponse{}
+}
+
+func (p *VerifyAuditResponse) InitDefault() {
+}
+
+func (p *VerifyAuditResponse) GetTicketId() (v string) {
+	return p.TicketId
+}
+
+func (p *VerifyAuditResponse) GetOk() (v bool) {
+	return p.Ok
+}
+
+func (p *VerifyAuditResponse) GetEvents() (v int32) {
+	return p.Events
+}
+
+func (p *VerifyAuditResponse) GetBrokenIndex() (v int32) {
+	return p.BrokenIndex
+}
+
+func (p *VerifyAuditResponse) GetReason() (v string) {
+	return p.Reason
+}
+
+var VerifyAuditResponse_Head_DEFAULT *AuditHead
+
+func (p *VerifyAuditResponse) GetHead() (v *AuditHead) {
+	if !p.IsSetHead() {
+		return VerifyAuditResponse_Head_DEFAULT
+	}
+	return p.Head
+}
+func (p *VerifyAuditResponse) SetTicketId(val string) {
+	p.TicketId = val
+}
+func (p *VerifyAuditResponse) SetOk(val bool) {
+	p.Ok = val
+}
+func (p *VerifyAuditResponse) SetEvents(val int32) {
+	p.Events = val
+}
+func (p *VerifyAuditResponse) SetBrokenIndex(val int32) {
+	p.BrokenIndex = val
+}
+func (p *VerifyAuditResponse) SetReason(val string) {
+	p.Reason = val
+}
+func (p *VerifyAuditResponse) SetHead(val *AuditHead) {
+	p.Head = val
+}
+
+func (p *VerifyAuditResponse) IsSetHead() bool {
+	return p.Head != nil
+}
+
+func (p *VerifyAuditResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("VerifyAuditResponse(%+v)", *p)
+}
+
+var fieldIDToName_VerifyAuditResponse = map[int16]string{
+	1: "ticket_id",
+	2: "ok",
+	3: "events",
+	4: "broken_index",
+	5: "reason",
+	6: "head",
+}
+
+type ExportAuditHeadRequest struct {
+	Id string `thrift:"id,1" frugal:"1,default,string" json:"id"`
+}
+
+func NewExportAuditHeadRequest() *ExportAuditHeadRequest {
+	return &ExportAuditHeadRequest{}
+}
+
+func (p *ExportAuditHeadRequest) InitDefault() {
+}
+
+func (p *ExportAuditHeadRequest) GetId() (v string) {
+	return p.Id
+}
+func (p *ExportAuditHeadRequest) SetId(val string) {
+	p.Id = val
+}
+
+func (p *ExportAuditHeadRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ExportAuditHeadRequest(%+v)", *p)
+}
+
+var fieldIDToName_ExportAuditHeadRequest = map[int16]string{
+	1: "id",
+}
+
+type SubmitSurveyRequest struct {
+	Token   string  `thrift:"token,1" frugal:"1,default,string" json:"token"`
+	Rating  int32   `thrift:"rating,2" frugal:"2,default,i32" json:"rating"`
+	Comment *string `thrift:"comment,3,optional" frugal:"3,optional,string" json:"comment,omitempty"`
+}
+
+func NewSubmitSurveyRequest() *SubmitSurveyRequest {
+	return &SubmitSurveyRequest{}
+}
+
+func (p *SubmitSurveyRequest) InitDefault() {
+}
+
+func (p *SubmitSurveyRequest) GetToken() (v string) {
+	return p.Token
+}
+
+func (p *SubmitSurveyRequest) GetRating() (v int32) {
+	return p.Rating
+}
+
+var SubmitSurveyRequest_Comment_DEFAULT string
+
+func (p *SubmitSurveyRequest) GetComment() (v string) {
+	if !p.IsSetComment() {
+		return SubmitSurveyRequest_Comment_DEFAULT
+	}
+	return *p.Comment
+}
+func (p *SubmitSurveyRequest) SetToken(val string) {
+	p.Token = val
+}
+func (p *SubmitSurveyRequest) SetRating(val int32) {
+	p.Rating = val
+}
+func (p *SubmitSurveyRequest) SetComment(val *string) {
+	p.Comment = val
+}
+
+func (p *SubmitSurveyRequest) IsSetComment() bool {
+	return p.Comment != nil
+}
+
+func (p *SubmitSurveyRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("SubmitSurveyRequest(%+v)", *p)
+}
+
+var fieldIDToName_SubmitSurveyRequest = map[int16]string{
+	1: "token",
+	2: "rating",
+	3: "comment",
+}
+
+type SubmitSurveyResponse struct {
+	TicketId string `thrift:"ticket_id,1" frugal:"1,default,string" json:"ticket_id"`
+	Cycle    int32  `thrift:"cycle,2" frugal:"2,default,i32" json:"cycle"`
+	Reopened bool   `thrift:"reopened,3" frugal:"3,default,bool" json:"reopened"`
+}
+
+func NewSubmitSurveyResponse() *SubmitSurveyResponse {
+	return &SubmitSurveyResponse{}
+}
+
+func (p *SubmitSurveyResponse) InitDefault() {
+}
+
+func (p *SubmitSurveyResponse) GetTicketId() (v string) {
+	return p.TicketId
+}
+
+func (p *SubmitSurveyResponse) GetCycle() (v int32) {
+	return p.Cycle
+}
+
+func (p *SubmitSurveyResponse) GetReopened() (v bool) {
+	return p.Reopened
+}
+func (p *SubmitSurveyResponse) SetTicketId(val string) {
+	p.TicketId = val
+}
+func (p *SubmitSurveyResponse) SetCycle(val int32) {
+	p.Cycle = val
+}
+func (p *SubmitSurveyResponse) SetReopened(val bool) {
+	p.Reopened = val
+}
+
+func (p *SubmitSurveyResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("SubmitSurveyResponse(%+v)", *p)
+}
+
+var fieldIDToName_SubmitSurveyResponse = map[int16]string{
+	1: "ticket_id",
+	2: "cycle",
+	3: "reopened",
+}
+
+type CSATReportRequest struct {
+	GroupBy string  `thrift:"group_by,1" frugal:"1,default,string" json:"group_by"`
+	Period  *string `thrift:"period,2,optional" frugal:"2,optional,string" json:"period,omitempty"`
+	From    *int64  `thrift:"from,3,optional" frugal:"3,optional,i64" json:"from,omitempty"`
+	To      *int64  `thrift:"to,4,optional" frugal:"4,optional,i64" json:"to,omitempty"`
+}
+
+func NewCSATReportRequest() *CSATReportRequest {
+	return &CSATReportRequest{}
+}
+
+func (p *CSATReportRequest) InitDefault() {
+}
+
+func (p *CSATReportRequest) GetGroupBy() (v string) {
+	return p.GroupBy
+}
+
+var CSATReportRequest_Period_DEFAULT string
+
+func (p *CSATReportRequest) GetPeriod() (v string) {
+	if !p.IsSetPeriod() {
+		return CSATReportRequest_Period_DEFAULT
+	}
+	return *p.Period
+}
+
+var CSATReportRequest_From_DEFAULT int64
+
+func (p *CSATReportRequest) GetFrom() (v int64) {
+	if !p.IsSetFrom() {
+		return CSATReportRequest_From_DEFAULT
+	}
+	return *p.From
+}
+
+var CSATReportRequest_To_DEFAULT int64
+
+func (p *CSATReportRequest) GetTo() (v int64) {
+	if !p.IsSetTo() {
+		return CSATReportRequest_To_DEFAULT
+	}
+	return *p.To
+}
+func (p *CSATReportRequest) SetGroupBy(val string) {
+	p.GroupBy = val
+}
+func (p *CSATReportRequest) SetPeriod(val *string) {
+	p.Period = val
+}
+func (p *CSATReportRequest) SetFrom(val *int64) {
+	p.From = val
+}
+func (p *CSATReportRequest) SetTo(val *int64) {
+	p.To = val
+}
+
+func (p *CSATReportRequest) IsSetPeriod() bool {
+	return p.Period != nil
+}
+
+func (p *CSATReportRequest) IsSetFrom() bool {
+	return p.From != nil
+}
+
+func (p *CSATReportRequest) IsSetTo() bool {
+	return p.To != nil
+}
+
+func (p *CSATReportRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("CSATReportRequest(%+v)", *p)
+}
+
+var fieldIDToName_CSATReportRequest = map[int16]string{
+	1: "group_by",
+	2: "period",
+	3: "from",
+	4: "to",
+}
+
+type CSATReportRow struct {
+	Key            string          `thrift:"key,1" frugal:"1,default,string" json:"key"`
+	Responses      int32           `thrift:"responses,2" frugal:"2,default,i32" json:"responses"`
+	Average        float64         `thrift:"average,3" frugal:"3,default,double" json:"average"`
+	SatisfiedRatio float64         `thrift:"satisfied_ratio,4" frugal:"4,default,double" json:"satisfied_ratio"`
+	Distribution   map[int32]int32 `thrift:"distribution,5" frugal:"5,default,map<i32:i32>" json:"distribution"`
+}
+
+func NewCSATReportRow() *CSATReportRow {
+	return &CSATReportRow{}
+}
+
+func (p *CSATReportRow) InitDefault() {
+}
+
+func (p *CSATReportRow) GetKey() (v string) {
+	return p.Key
+}
+
+func (p *CSATReportRow) GetResponses() (v int32) {
+	return p.Responses
+}
+
+func (p *CSATReportRow) GetAverage() (v float64) {
+	return p.Average
+}
+
+func (p *CSATReportRow) GetSatisfiedRatio() (v float64) {
+	return p.SatisfiedRatio
+}
+
+func (p *CSATReportRow) GetDistribution() (v map[int32]int32) {
+	return p.Distribution
+}
+func (p *CSATReportRow) SetKey(val string) {
+	p.Key = val
+}
+func (p *CSATReportRow) SetResponses(val int32) {
+	p.Responses = val
+}
+func (p *CSATReportRow) SetAverage(val float64) {
+	p.Average = val
+}
+func (p *CSATReportRow) SetSatisfiedRatio(val float64) {
+	p.SatisfiedRatio = val
+}
+func (p *CSATReportRow) SetDistribution(val map[int32]int32) {
+	p.Distribution = val
+}
+
+func (p *CSATReportRow) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("CSATReportRow(%+v)", *p)
+}
+
+var fieldIDToName_CSATReportRow = map[int16]string{
+	1: "key",
+	2: "responses",
+	3: "average",
+	4: "satisfied_ratio",
+	5: "distribution",
+}
+
+type CSATReportResponse struct {
+	Rows           []*CSATReportRow `thrift:"rows,1" frugal:"1,default,list<CSATReportRow>" json:"rows"`
+	TotalResponses int32            `thrift:"total_responses,2" frugal:"2,default,i32" json:"total_responses"`
+	Average        float64          `thrift:"average,3" frugal:"3,default,double" json:"average"`
+}
+
+func NewCSATReportResponse() *CSATReportResponse {
+	return &CSATReportResponse{}
+}
+
+func (p *CSATReportResponse) InitDefault() {
+}
+
+func (p *CSATReportResponse) GetRows() (v []*CSATReportRow) {
+	return p.Rows
+}
+
+func (p *CSATReportResponse) GetTotalResponses() (v int32) {
+	return p.TotalResponses
+}
+
+func (p *CSATReportResponse) GetAverage() (v float64) {
+	return p.Average
+}
+func (p *CSATReportResponse) SetRows(val []*CSATReportRow) {
+	p.Rows = val
+}
+func (p *CSATReportResponse) SetTotalResponses(val int32) {
+	p.TotalResponses = val
+}
+func (p *CSATReportResponse) SetAverage(val float64) {
+	p.Average = val
+}
+
+func (p *CSATReportResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("CSATReportResponse(%+v)", *p)
+}
+
+var fieldIDToName_CSATReportResponse = map[int16]string{
+	1: "rows",
+	2: "total_responses",
+	3: "average",
+}
+
+type ViewFilter struct {
+	Statuses     []common.TicketStatus `thrift:"statuses,1" frugal:"1,default,list<TicketStatus>" json:"statuses"`
+	Assignees    []string              `thrift:"assignees,2" frugal:"2,default,list<string>" json:"assignees"`
+	Priorities   []string              `thrift:"priorities,3" frugal:"3,default,list<string>" json:"priorities"`
+	Tags         []string              `thrift:"tags,4" frugal:"4,default,list<string>" json:"tags"`
+	Sla          []string              `thrift:"sla,5" frugal:"5,default,list<string>" json:"sla"`
+	CustomFields map[string]string     `thrift:"custom_fields,6" frugal:"6,default,map<string:string>" json:"custom_fields"`
+	Text         string                `thrift:"text,7" frugal:"7,default,string" json:"text"`
+	Tiers        []string              `thrift:"tiers,8" frugal:"8,default,list<string>" json:"tiers"`
+}
+
+func NewViewFilter() *ViewFilter {
+	return &ViewFilter{}
+}
+
+func (p *ViewFilter) InitDefault() {
+}
+
+func (p *ViewFilter) GetStatuses() (v []common.TicketStatus) {
+	return p.Statuses
+}
+
+func (p *ViewFilter) GetAssignees() (v []string) {
+	return p.Assignees
+}
+
+func (p *ViewFilter) GetPriorities() (v []string) {
+	return p.Priorities
+}
+
+func (p *ViewFilter) GetTags() (v []string) {
+	return p.Tags
+}
+
+func (p *ViewFilter) GetSla() (v []string) {
+	return p.Sla
+}
+
+func (p *ViewFilter) GetCustomFields() (v map[string]string) {
+	return p.CustomFields
+}
+
+func (p *ViewFilter) GetText() (v string) {
+	return p.Text
+}
+
+func (p *ViewFilter) GetTiers() (v []string) {
+	return p.Tiers
+}
+func (p *ViewFilter) SetStatuses(val []common.TicketStatus) {
+	p.Statuses = val
+}
+func (p *ViewFilter) SetAssignees(val []string) {
+	p.Assignees = val
+}
+func (p *ViewFilter) SetPriorities(val []string) {
+	p.Priorities = val
+}
+func (p *ViewFilter) SetTags(val []string) {
+	p.Tags = val
+}
+func (p *ViewFilter) SetSla(val []string) {
+	p.Sla = val
+}
+func (p *ViewFilter) SetCustomFields(val map[string]string) {
+	p.CustomFields = val
+}
+func (p *ViewFilter) SetText(val string) {
+	p.Text = val
+}
+func (p *ViewFilter) SetTiers(val []string) {
+	p.Tiers = val
+}
+
+func (p *ViewFilter) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ViewFilter(%+v)", *p)
+}
+
+var fieldIDToName_ViewFilter = map[int16]string{
+	1: "statuses",
+	2: "assignees",
+	3: "priorities",
+	4: "tags",
+	5: "sla",
+	6: "custom_fields",
+	7: "text",
+	8: "tiers",
+}
+
+type SavedView struct {
+	Id        string      `thrift:"id,1" frugal:"1,default,string" json:"id"`
+	Name      string      `thrift:"name,2" frugal:"2,default,string" json:"name"`
+	Owner     string      `thrift:"owner,3" frugal:"3,default,string" json:"owner"`
+	Scope     string      `thrift:"scope,4" frugal:"4,default,string" json:"scope"`
+	Team      string      `thrift:"team,5" frugal:"5,default,string" json:"team"`
+	Filter    *ViewFilter `thrift:"filter,6" frugal:"6,default,ViewFilter" json:"filter"`
+	SortBy    string      `thrift:"sort_by,7" frugal:"7,default,string" json:"sort_by"`
+	SortDesc  bool        `thrift:"sort_desc,8" frugal:"8,default,bool" json:"sort_desc"`
+	CreatedAt int64       `thrift:"created_at,9" frugal:"9,default,i64" json:"created_at"`
+	UpdatedAt int64       `thrift:"updated_at,10" frugal:"10,default,i64" json:"updated_at"`
+}
+
+func NewSavedView() *SavedView {
+	return &SavedView{}
+}
+
+func (p *SavedView) InitDefault() {
+}
+
+func (p *SavedView) GetId() (v string) {
+	return p.Id
+}
+
+func (p *SavedView) GetName() (v string) {
+	return p.Name
+}
+
+func (p *SavedView) GetOwner() (v string) {
+	return p.Owner
+}
+
+func (p *SavedView) GetScope() (v string) {
+	return p.Scope
+}
+
+func (p *SavedView) GetTeam() (v string) {
+	return p.Team
+}
+
+var SavedView_Filter_DEFAULT *ViewFilter
+
+func (p *SavedView) GetFilter() (v *ViewFilter) {
+	if !p.IsSetFilter() {
+		return SavedView_Filter_DEFAULT
+	}
+	return p.Filter
+}
+
+func (p *SavedView) GetSortBy() (v string) {
+	return p.SortBy
+}
+
+func (p *SavedView) GetSortDesc() (v bool) {
+	return p.SortDesc
+}
+
+func (p *SavedView) GetCreatedAt() (v int64) {
+	return p.CreatedAt
+}
+
+func (p *SavedView) GetUpdatedAt() (v int64) {
+	return p.UpdatedAt
+}
+func (p *SavedView) SetId(val string) {
+	p.Id = val
+}
+func (p *SavedView) SetName(val string) {
+	p.Name = val
+}
+func (p *SavedView) SetOwner(val string) {
+	p.Owner = val
+}
+func (p *SavedView) SetScope(val string) {
+	p.Scope = val
+}
+func (p *SavedView) SetTeam(val string) {
+	p.Team = val
+}
+func (p *SavedView) SetFilter(val *ViewFilter) {
+	p.Filter = val
+}
+func (p *SavedView) SetSortBy(val string) {
+	p.SortBy = val
+}
+func (p *SavedView) SetSortDesc(val bool) {
+	p.SortDesc = val
+}
+func (p *SavedView) SetCreatedAt(val int64) {
+	p.CreatedAt = val
+}
+func (p *SavedView) SetUpdatedAt(val int64) {
+	p.UpdatedAt = val
+}
+
+func (p *SavedView) IsSetFilter() bool {
+	return p.Filter != nil
+}
+
+func (p *SavedView) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("SavedView(%+v)", *p)
+}
+
+var fieldIDToName_SavedView = map[int16]string{
+	1:  "id",
+	2:  "name",
+	3:  "owner",
+	4:  "scope",
+	5:  "team",
+	6:  "filter",
+	7:  "sort_by",
+	8:  "sort_desc",
+	9:  "created_at",
+	10: "updated_at",
+}
+
+type SaveViewRequest struct {
+	View *SavedView `thrift:"view,1" frugal:"1,default,SavedView" json:"view"`
+}
+
+func NewSaveViewRequest() *SaveViewRequest {
+	return &SaveViewRequest{}
+}
+
+func (p *SaveViewRequest) InitDefault() {
+}
+
+var SaveViewRequest_View_DEFAULT *SavedView
+
+func (p *SaveViewRequest) GetView() (v *SavedView) {
+	if !p.IsSetView() {
+		return SaveViewRequest_View_DEFAULT
+	}
+	return p.View
+}
+func (p *SaveViewRequest) SetView(val *SavedView) {
+	p.View = val
+}
+
+func (p *SaveViewRequest) IsSetView() bool {
+	return p.View != nil
+}
+
+func (p *SaveViewRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("SaveViewRequest(%+v)", *p)
+}
+
+var fieldIDToName_SaveViewRequest = map[int16]string{
+	1: "view",
+}
+
+type ViewRequest struct {
+	Id string `thrift:"id,1" frugal:"1,default,string" json:"id"`
+}
+
+func NewViewRequest() *ViewRequest {
+	return &ViewRequest{}
+}
+
+func (p *ViewRequest) InitDefault() {
+}
+
+func (p *ViewRequest) GetId() (v string) {
+	return p.Id
+}
+func (p *ViewRequest) SetId(val string) {
+	p.Id = val
+}
+
+func (p *ViewRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ViewRequest(%+v)", *p)
+}
+
+var fieldIDToName_ViewRequest = map[int16]string{
+	1: "id",
+}
+
+type DeleteViewResponse struct {
+	Ok bool `thrift:"ok,1" frugal:"1,default,bool" json:"ok"`
+}
+
+func NewDeleteViewResponse() *DeleteViewResponse {
+	return &DeleteViewResponse{}
+}
+
+func (p *DeleteViewResponse) InitDefault() {
+}
+
+func (p *DeleteViewResponse) GetOk() (v bool) {
+	return p.Ok
+}
+func (p *DeleteViewResponse) SetOk(val bool) {
+	p.Ok = val
+}
+
+func (p *DeleteViewResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("DeleteViewResponse(%+v)", *p)
+}
+
+var fieldIDToName_DeleteViewResponse = map[int16]string{
+	1: "ok",
+}
+
+type ListViewsRequest struct {
+	Counts bool `thrift:"counts,1" frugal:"1,default,bool" json:"counts"`
+}
+
+func NewListViewsRequest() *ListViewsRequest {
+	return &ListViewsRequest{}
+}
+
+func (p *ListViewsRequest) InitDefault() {
+}
+
+func (p *ListViewsRequest) GetCounts() (v bool) {
+	return p.Counts
+}
+func (p *ListViewsRequest) SetCounts(val bool) {
+	p.Counts = val
+}
+
+func (p *ListViewsRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ListViewsRequest(%+v)", *p)
+}
+
+var fieldIDToName_ListViewsRequest = map[int16]string{
+	1: "counts",
+}
+
+type ViewSummary struct {
+	View  *SavedView `thrift:"view,1" frugal:"1,default,SavedView" json:"view"`
+	Count int32      `thrift:"count,2" frugal:"2,default,i32" json:"count"`
+}
+
+func NewViewSummary() *ViewSummary {
+	return &ViewSummary{}
+}
+
+func (p *ViewSummary) InitDefault() {
+}
+
+var ViewSummary_View_DEFAULT *SavedView
+
+func (p *ViewSummary) GetView() (v *SavedView) {
+	if !p.IsSetView() {
+		return ViewSummary_View_DEFAULT
+	}
+	return p.View
+}
+
+func (p *ViewSummary) GetCount() (v int32) {
+	return p.Count
+}
+func (p *ViewSummary) SetView(val *SavedView) {
+	p.View = val
+}
+func (p *ViewSummary) SetCount(val int32) {
+	p.Count = val
+}
+
+func (p *ViewSummary) IsSetView() bool {
+	return p.View != nil
+}
+
+func (p *ViewSummary) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ViewSummary(%+v)", *p)
+}
+
+var fieldIDToName_ViewSummary = map[int16]string{
+	1: "view",
+	2: "count",
+}
+
+type ListViewsResponse struct {
+	Views []*ViewSummary `thrift:"views,1" frugal:"1,default,list<ViewSummary>" json:"views"`
+}
+
+func NewListViewsResponse() *ListViewsResponse {
+	return &ListViewsResponse{}
+}
+
+func (p *ListViewsResponse) InitDefault() {
+}
+
+func (p *ListViewsResponse) GetViews() (v []*ViewSummary) {
+	return p.Views
+}
+func (p *ListViewsResponse) SetViews(val []*ViewSummary) {
+	p.Views = val
+}
+
+func (p *ListViewsResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ListViewsResponse(%+v)", *p)
+}
+
+var fieldIDToName_ListViewsResponse = map[int16]string{
+	1: "views",
+}
+
+type ViewTicketsRequest struct {
+	Id         string             `thrift:"id,1" frugal:"1,default,string" json:"id"`
+	Pagination *common.Pagination `thrift:"pagination,2,optional" frugal:"2,optional,common.Pagination" json:"pagination,omitempty"`
+}
+
+func NewViewTicketsRequest() *ViewTicketsRequest {
+	return &ViewTicketsRequest{}
+}
+
+func (p *ViewTicketsRequest) InitDefault() {
+}
+
+func (p *ViewTicketsRequest) GetId() (v string) {
+	return p.Id
+}
+
+var ViewTicketsRequest_Pagination_DEFAULT *common.Pagination
+
+func (p *ViewTicketsRequest) GetPagination() (v *common.Pagination) {
+	if !p.IsSetPagination() {
+		return ViewTicketsRequest_Pagination_DEFAULT
+	}
+	return p.Pagination
+}
+func (p *ViewTicketsRequest) SetId(val string) {
+	p.Id = val
+}
+func (p *ViewTicketsRequest) SetPagination(val *common.Pagination) {
+	p.Pagination = val
+}
+
+func (p *ViewTicketsRequest) IsSetPagination() bool {
+	return p.Pagination != nil
+}
+
+func (p *ViewTicketsRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ViewTicketsRequest(%+v)", *p)
+}
+
+var fieldIDToName_ViewTicketsRequest = map[int16]string{
+	1: "id",
+	2: "pagination",
+}
+
+type LockRequest struct {
+	Id    string `thrift:"id,1" frugal:"1,default,string" json:"id"`
+	Force bool   `thrift:"force,2" frugal:"2,default,bool" json:"force"`
+}
+
+func NewLockRequest() *LockRequest {
+	return &LockRequest{}
+}
+
+func (p *LockRequest) InitDefault() {
+}
+
+func (p *LockRequest) GetId() (v string) {
+	return p.Id
+}
+
+func (p *LockRequest) GetForce() (v bool) {
+	return p.Force
+}
+func (p *LockRequest) SetId(val string) {
+	p.Id = val
+}
+func (p *LockRequest) SetForce(val bool) {
+	p.Force = val
+}
+
+func (p *LockRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("LockRequest(%+v)", *p)
+}
+
+var fieldIDToName_LockRequest = map[int16]string{
+	1: "id",
+	2: "force",
+}
+
+type LockResponse struct {
+	Lock *common.EditLock `thrift:"lock,1,optional" frugal:"1,optional,common.EditLock" json:"lock,omitempty"`
+}
+
+func NewLockResponse() *LockResponse {
+	return &LockResponse{}
+}
+
+func (p *LockResponse) InitDefault() {
+}
+
+var LockResponse_Lock_DEFAULT *common.EditLock
+
+func (p *LockResponse) GetLock() (v *common.EditLock) {
+	if !p.IsSetLock() {
+		return LockResponse_Lock_DEFAULT
+	}
+	return p.Lock
+}
+func (p *LockResponse) SetLock(val *common.EditLock) {
+	p.Lock = val
+}
+
+func (p *LockResponse) IsSetLock() bool {
+	return p.Lock != nil
+}
+
+func (p *LockResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("LockResponse(%+v)", *p)
+}
+
+var fieldIDToName_LockResponse = map[int16]string{
+	1: "lock",
+}
+
+type LockEvent struct {
+	Seq      int64            `thrift:"seq,1" frugal:"1,default,i64" json:"seq"`
+	Type     string           `thrift:"type,2" frugal:"2,default,string" json:"type"`
+	TicketId string           `thrift:"ticket_id,3" frugal:"3,default,string" json:"ticket_id"`
+	Lock     *common.EditLock `thrift:"lock,4,optional" frugal:"4,optional,common.EditLock" json:"lock,omitempty"`
+	Actor    *common.Actor    `thrift:"actor,5,optional" frugal:"5,optional,common.Actor" json:"actor,omitempty"`
+	At       int64            `thrift:"at,6" frugal:"6,default,i64" json:"at"`
+}
+
+func NewLockEvent() *LockEvent {
+	return &LockEvent{}
+}
+
+func (p *LockEvent) InitDefault() {
+}
+
+func (p *LockEvent) GetSeq() (v int64) {
+	return p.Seq
+}
+
+func (p *LockEvent) GetType() (v string) {
+	return p.Type
+}
+
+func (p *LockEvent) GetTicketId() (v string) {
+	return p.TicketId
+}
+
+var LockEvent_Lock_DEFAULT *common.EditLock
+
+func (p *LockEvent) GetLock() (v *common.EditLock) {
+	if !p.IsSetLock() {
+		return LockEvent_Lock_DEFAULT
+	}
+	return p.Lock
+}
+
+var LockEvent_Actor_DEFAULT *common.Actor
+
+func (p *LockEvent) GetActor() (v *common.Actor) {
+	if !p.IsSetActor() {
+		return LockEvent_Actor_DEFAULT
+	}
+	return p.Actor
+}
+
+func (p *LockEvent) GetAt() (v int64) {
+	return p.At
+}
+func (p *LockEvent) SetSeq(val int64) {
+	p.Seq = val
+}
+func (p *LockEvent) SetType(val string) {
+	p.Type = val
+}
+func (p *LockEvent) SetTicketId(val string) {
+	p.TicketId = val
+}
+func (p *LockEvent) SetLock(val *common.EditLock) {
+	p.Lock = val
+}
+func (p *LockEvent) SetActor(val *common.Actor) {
+	p.Actor = val
+}
+func (p *LockEvent) SetAt(val int64) {
+	p.At = val
+}
+
+func (p *LockEvent) IsSetLock() bool {
+	return p.Lock != nil
+}
+
+func (p *LockEvent) IsSetActor() bool {
+	return p.Actor != nil
+}
+
+func (p *LockEvent) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("LockEvent(%+v)", *p)
+}
+
+var fieldIDToName_LockEvent = map[int16]string{
+	1: "seq",
+	2: "type",
+	3: "ticket_id",
+	4: "lock",
+	5: "actor",
+	6: "at",
+}
+
+type ListLockEventsRequest struct {
+	After    int64   `thrift:"after,1" frugal:"1,default,i64" json:"after"`
+	TicketId *string `thrift:"ticket_id,2,optional" frugal:"2,optional,string" json:"ticket_id,omitempty"`
+}
+
+func NewListLockEventsRequest() *ListLockEventsRequest {
+	return &ListLockEventsRequest{}
+}
+
+func (p *ListLockEventsRequest) InitDefault() {
+}
+
+func (p *ListLockEventsRequest) GetAfter() (v int64) {
+	return p.After
+}
+
+var ListLockEventsRequest_TicketId_DEFAULT string
+
+func (p *ListLockEventsRequest) GetTicketId() (v string) {
+	if !p.IsSetTicketId() {
+		return ListLockEventsRequest_TicketId_DEFAULT
+	}
+	return *p.TicketId
+}
+func (p *ListLockEventsRequest) SetAfter(val int64) {
+	p.After = val
+}
+func (p *ListLockEventsRequest) SetTicketId(val *string) {
+	p.TicketId = val
+}
+
+func (p *ListLockEventsRequest) IsSetTicketId() bool {
+	return p.TicketId != nil
+}
+
+func (p *ListLockEventsRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ListLockEventsRequest(%+v)", *p)
+}
+
+var fieldIDToName_ListLockEventsRequest = map[int16]string{
+	1: "after",
+	2: "ticket_id",
+}
+
+type ListLockEventsResponse struct {
+	Events  []*LockEvent `thrift:"events,1" frugal:"1,default,list<LockEvent>" json:"events"`
+	LastSeq int64        `thrift:"last_seq,2" frugal:"2,default,i64" json:"last_seq"`
+}
+
+func NewListLockEventsResponse() *ListLockEventsResponse {
+	return &ListLockEventsResponse{}
+}
+
+func (p *ListLockEventsResponse) InitDefault() {
+}
+
+func (p *ListLockEventsResponse) GetEvents() (v []*LockEvent) {
+	return p.Events
+}
+
+func (p *ListLockEventsResponse) GetLastSeq() (v int64) {
+	return p.LastSeq
+}
+func (p *ListLockEventsResponse) SetEvents(val []*LockEvent) {
+	p.Events = val
+}
+func (p *ListLockEventsResponse) SetLastSeq(val int64) {
+	p.LastSeq = val
+}
+
+func (p *ListLockEventsResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ListLockEventsResponse(%+v)", *p)
+}
+
+var fieldIDToName_ListLockEventsResponse = map[int16]string{
+	1: "events",
+	2: "last_seq",
+}
+
+type PullNextRequest struct {
+	Skills []string `thrift:"skills,1" frugal:"1,default,list<string>" json:"skills"`
+	Tier   *string  `thrift:"tier,2,optional" frugal:"2,optional,string" json:"tier,omitempty"`
+}
+
+func NewPullNextRequest() *PullNextRequest {
+	return &PullNextRequest{}
+}
+
+func (p *PullNextRequest) InitDefault() {
+}
+
+func (p *PullNextRequest) GetSkills() (v []string) {
+	return p.Skills
+}
+
+var PullNextRequest_Tier_DEFAULT string
+
+func (p *PullNextRequest) GetTier() (v string) {
+	if !p.IsSetTier() {
+		return PullNextRequest_Tier_DEFAULT
+	}
+	return *p.Tier
+}
+func (p *PullNextRequest) SetSkills(val []string) {
+	p.Skills = val
+}
+func (p *PullNextRequest) SetTier(val *string) {
+	p.Tier = val
+}
+
+func (p *PullNextRequest) IsSetTier() bool {
+	return p.Tier != nil
+}
+
+func (p *PullNextRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("PullNextRequest(%+v)", *p)
+}
+
+var fieldIDToName_PullNextRequest = map[int16]string{
+	1: "skills",
+	2: "tier",
+}
+
+type PullNextResponse struct {
+	Ticket         *common.Ticket `thrift:"ticket,1,optional" frugal:"1,optional,common.Ticket" json:"ticket,omitempty"`
+	Score          float64        `thrift:"score,2" frugal:"2,default,double" json:"score"`
+	ClaimExpiresAt int64          `thrift:"claim_expires_at,3" frugal:"3,default,i64" json:"claim_expires_at"`
+}
+
+func NewPullNextResponse() *PullNextResponse {
+	return &PullNextResponse{}
+}
+
+func (p *PullNextResponse) InitDefault() {
+}
+
+var PullNextResponse_Ticket_DEFAULT *common.Ticket
+
+func (p *PullNextResponse) GetTicket() (v *common.Ticket) {
+	if !p.IsSetTicket() {
+		return PullNextResponse_Ticket_DEFAULT
+	}
+	return p.Ticket
+}
+
+func (p *PullNextResponse) GetScore() (v float64) {
+	return p.Score
+}
+
+func (p *PullNextResponse) GetClaimExpiresAt() (v int64) {
+	return p.ClaimExpiresAt
+}
+func (p *PullNextResponse) SetTicket(val *common.Ticket) {
+	p.Ticket = val
+}
+func (p *PullNextResponse) SetScore(val float64) {
+	p.Score = val
+}
+func (p *PullNextResponse) SetClaimExpiresAt(val int64) {
+	p.ClaimExpiresAt = val
+}
+
+func (p *PullNextResponse) IsSetTicket() bool {
+	return p.Ticket != nil
+}
+
+func (p *PullNextResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("PullNextResponse(%+v)", *p)
+}
+
+var fieldIDToName_PullNextResponse = map[int16]string{
+	1: "ticket",
+	2: "score",
+	3: "claim_expires_at",
+}
+
+type EscalationReportRequest struct {
+	From     *int64  `thrift:"from,1,optional" frugal:"1,optional,i64" json:"from,omitempty"`
+	To       *int64  `thrift:"to,2,optional" frugal:"2,optional,i64" json:"to,omitempty"`
+	Category *string `thrift:"category,3,optional" frugal:"3,optional,string" json:"category,omitempty"`
+}
+
+func NewEscalationReportRequest() *EscalationReportRequest {
+	return &EscalationReportRequest{}
+}
+
+func (p *EscalationReportRequest) InitDefault() {
+}
+
+var EscalationReportRequest_From_DEFAULT int64
+
+func (p *EscalationReportRequest) GetFrom() (v int64) {
+	if !p.IsSetFrom() {
+		return EscalationReportRequest_From_DEFAULT
+	}
+	return *p.From
+}
+
+var EscalationReportRequest_To_DEFAULT int64
+
+func (p *EscalationReportRequest) GetTo() (v int64) {
+	if !p.IsSetTo() {
+		return EscalationReportRequest_To_DEFAULT
+	}
+	return *p.To
+}
+
+var EscalationReportRequest_Category_DEFAULT string
+
+func (p *EscalationReportRequest) GetCategory() (v string) {
+	if !p.IsSetCategory() {
+		return EscalationReportRequest_Category_DEFAULT
+	}
+	return *p.Category
+}
+func (p *EscalationReportRequest) SetFrom(val *int64) {
+	p.From = val
+}
+func (p *EscalationReportRequest) SetTo(val *int64) {
+	p.To = val
+}
+func (p *EscalationReportRequest) SetCategory(val *string) {
+	p.Category = val
+}
+
+func (p *EscalationReportRequest) IsSetFrom() bool {
+	return p.From != nil
+}
+
+func (p *EscalationReportRequest) IsSetTo() bool {
+	return p.To != nil
+}
+
+func (p *EscalationReportRequest) IsSetCategory() bool {
+	return p.Category != nil
+}
+
+func (p *EscalationReportRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("EscalationReportRequest(%+v)", *p)
+}
+
+var fieldIDToName_EscalationReportRequest = map[int16]string{
+	1: "from",
+	2: "to",
+	3: "category",
+}
+
+type EscalationTierRow struct {
+	Tier           string  `thrift:"tier,1" frugal:"1,default,string" json:"tier"`
+	Entered        int32   `thrift:"entered,2" frugal:"2,default,i32" json:"entered"`
+	Escalated      int32   `thrift:"escalated,3" frugal:"3,default,i32" json:"escalated"`
+	Deescalated    int32   `thrift:"deescalated,4" frugal:"4,default,i32" json:"deescalated"`
+	Resolved       int32   `thrift:"resolved,5" frugal:"5,default,i32" json:"resolved"`
+	EscalationRate float64 `thrift:"escalation_rate,6" frugal:"6,default,double" json:"escalation_rate"`
+}
+
+func NewEscalationTierRow() *EscalationTierRow {
+	return &EscalationTierRow{}
+}
+
+func (p *EscalationTierRow) InitDefault() {
+}
+
+func (p *EscalationTierRow) GetTier() (v string) {
+	return p.Tier
+}
+
+func (p *EscalationTierRow) GetEntered() (v int32) {
+	return p.Entered
+}
+
+func (p *EscalationTierRow) GetEscalated() (v int32) {
+	return p.Escalated
+}
+
+func (p *EscalationTierRow) GetDeescalated() (v int32) {
+	return p.Deescalated
+}
+
+func (p *EscalationTierRow) GetResolved() (v int32) {
+	return p.Resolved
+}
+
+func (p *EscalationTierRow) GetEscalationRate() (v float64) {
+	return p.EscalationRate
+}
+func (p *EscalationTierRow) SetTier(val string) {
+	p.Tier = val
+}
+func (p *EscalationTierRow) SetEntered(val int32) {
+	p.Entered = val
+}
+func (p *EscalationTierRow) SetEscalated(val int32) {
+	p.Escalated = val
+}
+func (p *EscalationTierRow) SetDeescalated(val int32) {
+	p.Deescalated = val
+}
+func (p *EscalationTierRow) SetResolved(val int32) {
+	p.Resolved = val
+}
+func (p *EscalationTierRow) SetEscalationRate(val float64) {
+	p.EscalationRate = val
+}
+
+func (p *EscalationTierRow) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("EscalationTierRow(%+v)", *p)
+}
+
+var fieldIDToName_EscalationTierRow = map[int16]string{
+	1: "tier",
+	2: "entered",
+	3: "escalated",
+	4: "deescalated",
+	5: "resolved",
+	6: "escalation_rate",
+}
+
+type EscalationReportResponse struct {
+	Rows             []*EscalationTierRow `thrift:"rows,1" frugal:"1,default,list<EscalationTierRow>" json:"rows"`
+	TotalEscalations int32                `thrift:"total_escalations,2" frugal:"2,default,i32" json:"total_escalations"`
+}
+
+func NewEscalationReportResponse() *EscalationReportResponse {
+	return &EscalationReportResponse{}
+}
+
+func (p *EscalationReportResponse) InitDefault() {
+}
+
+func (p *EscalationReportResponse) GetRows() (v []*EscalationTierRow) {
+	return p.Rows
+}
+
+func (p *EscalationReportResponse) GetTotalEscalations() (v int32) {
+	return p.TotalEscalations
+}
+func (p *EscalationReportResponse) SetRows(val []*EscalationTierRow) {
+	p.Rows = val
+}
+func (p *EscalationReportResponse) SetTotalEscalations(val int32) {
+	p.TotalEscalations = val
+}
+
+func (p *EscalationReportResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("EscalationReportResponse(%+v)", *p)
+}
+
+var fieldIDToName_EscalationReportResponse = map[int16]string{
+	1: "rows",
+	2: "total_escalations",
+}
+
+type ApplyRetentionRequest struct {
+	DryRun bool `thrift:"dry_run,1" frugal:"1,default,bool" json:"dry_run"`
+}
+
+func NewApplyRetentionRequest() *ApplyRetentionRequest {
+	return &ApplyRetentionRequest{}
+}
+
+func (p *ApplyRetentionRequest) InitDefault() {
+}
+
+func (p *ApplyRetentionRequest) GetDryRun() (v bool) {
+	return p.DryRun
+}
+func (p *ApplyRetentionRequest) SetDryRun(val bool) {
+	p.DryRun = val
+}
+
+func (p *ApplyRetentionRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ApplyRetentionRequest(%+v)", *p)
+}
+
+var fieldIDToName_ApplyRetentionRequest = map[int16]string{
+	1: "dry_run",
+}
+
+type RetentionAction struct {
+	TicketId string `thrift:"ticket_id,1" frugal:"1,default,string" json:"ticket_id"`
+	Action   string `thrift:"action,2" frugal:"2,default,string" json:"action"`
+	Policy   string `thrift:"policy,3" frugal:"3,default,string" json:"policy"`
+	Since    int64  `thrift:"since,4" frugal:"4,default,i64" json:"since"`
+}
+
+func NewRetentionAction() *RetentionAction {
+	return &RetentionAction{}
+}
+
+func (p *RetentionAction) InitDefault() {
+}
+
+func (p *RetentionAction) GetTicketId() (v string) {
+	return p.TicketId
+}
+
+func (p *RetentionAction) GetAction() (v string) {
+	return p.Action
+}
+
+func (p *RetentionAction) GetPolicy() (v string) {
+	return p.Policy
+}
+
+func (p *RetentionAction) GetSince() (v int64) {
+	return p.Since
+}
+func (p *RetentionAction) SetTicketId(val string) {
+	p.TicketId = val
+}
+func (p *RetentionAction) SetAction(val string) {
+	p.Action = val
+}
+func (p *RetentionAction) SetPolicy(val string) {
+	p.Policy = val
+}
+func (p *RetentionAction) SetSince(val int64) {
+	p.Since = val
+}
+
+func (p *RetentionAction) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("RetentionAction(%+v)", *p)
+}
+
+var fieldIDToName_RetentionAction = map[int16]string{
+	1: "ticket_id",
+	2: "action",
+	3: "policy",
+	4: "since",
+}
+
+type RetentionReport struct {
+	DryRun  bool               `thrift:"dry_run,1" frugal:"1,default,bool" json:"dry_run"`
+	RanAt   int64              `thrift:"ran_at,2" frugal:"2,default,i64" json:"ran_at"`
+	Actions []*RetentionAction `thrift:"actions,3" frugal:"3,default,list<RetentionAction>" json:"actions"`
+}
+
+func NewRetentionReport() *RetentionReport {
+	return &RetentionReport{}
+}
+
+func (p *RetentionReport) InitDefault() {
+}
+
+func (p *RetentionReport) GetDryRun() (v bool) {
+	return p.DryRun
+}
+
+func (p *RetentionReport) GetRanAt() (v int64) {
+	return p.RanAt
+}
+
+func (p *RetentionReport) GetActions() (v []*RetentionAction) {
+	return p.Actions
+}
+func (p *RetentionReport) SetDryRun(val bool) {
+	p.DryRun = val
+}
+func (p *RetentionReport) SetRanAt(val int64) {
+	p.RanAt = val
+}
+func (p *RetentionReport) SetActions(val []*RetentionAction) {
+	p.Actions = val
+}
+
+func (p *RetentionReport) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("RetentionReport(%+v)", *p)
+}
+
+var fieldIDToName_RetentionReport = map[int16]string{
+	1: "dry_run",
+	2: "ran_at",
+	3: "actions",
+}
+
+type SubjectTicket struct {
+	Ticket *common.Ticket `thrift:"ticket,1" frugal:"1,default,common.Ticket" json:"ticket"`
+	Roles  []string       `thrift:"roles,2" frugal:"2,default,list<string>" json:"roles"`
+}
+
+func NewSubjectTicket() *SubjectTicket {
+	return &SubjectTicket{}
+}
+
+func (p *SubjectTicket) InitDefault() {
+}
+
+var SubjectTicket_Ticket_DEFAULT *common.Ticket
+
+func (p *SubjectTicket) GetTicket() (v *common.Ticket) {
+	if !p.IsSetTicket() {
+		return SubjectTicket_Ticket_DEFAULT
+	}
+	return p.Ticket
+}
+
+func (p *SubjectTicket) GetRoles() (v []string) {
+	return p.Roles
+}
+func (p *SubjectTicket) SetTicket(val *common.Ticket) {
+	p.Ticket = val
+}
+func (p *SubjectTicket) SetRoles(val []string) {
+	p.Roles = val
+}
+
+func (p *SubjectTicket) IsSetTicket() bool {
+	return p.Ticket != nil
+}
+
+func (p *SubjectTicket) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("SubjectTicket(%+v)", *p)
+}
+
+var fieldIDToName_SubjectTicket = map[int16]string{
+	1: "ticket",
+	2: "roles",
+}
+
+type ExportSubjectResponse struct {
+	Subject       string             `thrift:"subject,1" frugal:"1,default,string" json:"subject"`
+	Tickets       []*SubjectTicket   `thrift:"tickets,2" frugal:"2,default,list<SubjectTicket>" json:"tickets"`
+	Notifications []*Notification    `thrift:"notifications,3" frugal:"3,default,list<Notification>" json:"notifications"`
+	Prefs         *NotificationPrefs `thrift:"prefs,4,optional" frugal:"4,optional,NotificationPrefs" json:"prefs,omitempty"`
+}
+
+func NewExportSubjectResponse() *ExportSubjectResponse {
+	return &ExportSubjectResponse{}
+}
+
+func (p *ExportSubjectResponse) InitDefault() {
+}
+
+func (p *ExportSubjectResponse) GetSubject() (v string) {
+	return p.Subject
+}
+
+func (p *ExportSubjectResponse) GetTickets() (v []*SubjectTicket) {
+	return p.Tickets
+}
+
+func (p *ExportSubjectResponse) GetNotifications() (v []*Notification) {
+	return p.Notifications
+}
+
+var ExportSubjectResponse_Prefs_DEFAULT *NotificationPrefs
+
+func (p *ExportSubjectResponse) GetPrefs() (v *NotificationPrefs) {
+	if !p.IsSetPrefs() {
+		return ExportSubjectResponse_Prefs_DEFAULT
+	}
+	return p.Prefs
+}
+func (p *ExportSubjectResponse) SetSubject(val string) {
+	p.Subject = val
+}
+func (p *ExportSubjectResponse) SetTickets(val []*SubjectTicket) {
+	p.Tickets = val
+}
+func (p *ExportSubjectResponse) SetNotifications(val []*Notification) {
+	p.Notifications = val
+}
+func (p *ExportSubjectResponse) SetPrefs(val *NotificationPrefs) {
+	p.Prefs = val
+}
+
+func (p *ExportSubjectResponse) IsSetPrefs() bool {
+	return p.Prefs != nil
+}
+
+func (p *ExportSubjectResponse) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ExportSubjectResponse(%+v)", *p)
+}
+
+var fieldIDToName_ExportSubjectResponse = map[int16]string{
+	1: "subject",
+	2: "tickets",
+	3: "notifications",
+	4: "prefs",
+}
+
+type EraseSubjectRequest struct {
+	Subject string                   `thrift:"subject,1" frugal:"1,default,string" json:"subject"`
+	Related []*common.ErasureSummary `thrift:"related,2,optional" frugal:"2,optional,list<common.ErasureSummary>" json:"related,omitempty"`
+}
+
+func NewEraseSubjectRequest() *EraseSubjectRequest {
+	return &EraseSubjectRequest{}
+}
+
+func (p *EraseSubjectRequest) InitDefault() {
+}
+
+func (p *EraseSubjectRequest) GetSubject() (v string) {
+	return p.Subject
+}
+
+var EraseSubjectRequest_Related_DEFAULT []*common.ErasureSummary
+
+func (p *EraseSubjectRequest) GetRelated() (v []*common.ErasureSummary) {
+	if !p.IsSetRelated() {
+		return EraseSubjectRequest_Related_DEFAULT
+	}
+	return p.Related
+}
+func (p *EraseSubjectRequest) SetSubject(val string) {
+	p.Subject = val
+}
+func (p *EraseSubjectRequest) SetRelated(val []*common.ErasureSummary) {
+	p.Related = val
+}
+
+func (p *EraseSubjectRequest) IsSetRelated() bool {
+	return p.Related != nil
+}
+
+func (p *EraseSubjectRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("EraseSubjectRequest(%+v)", *p)
+}
+
+var fieldIDToName_EraseSubjectRequest = map[int16]string{
+	1: "subject",
+	2: "related",
+}
+
+type ErasureReceipt struct {
+	Id          string                   `thrift:"id,1" frugal:"1,default,string" json:"id"`
+	SubjectHash string                   `thrift:"subject_hash,2" frugal:"2,default,string" json:"subject_hash"`
+	Tenant      string                   `thrift:"tenant,3" frugal:"3,default,string" json:"tenant"`
+	ErasedAt    int64                    `thrift:"erased_at,4" frugal:"4,default,i64" json:"erased_at"`
+	Services    []*common.ErasureSummary `thrift:"services,5" frugal:"5,default,list<common.ErasureSummary>" json:"services"`
+	RequestedBy *common.Actor            `thrift:"requested_by,6,optional" frugal:"6,optional,common.Actor" json:"requested_by,omitempty"`
+	KeyId       string                   `thrift:"key_id,7" frugal:"7,default,string" json:"key_id"`
+	Signature   string                   `thrift:"signature,8" frugal:"8,default,string" json:"signature"`
+}
+
+func NewErasureReceipt() *ErasureReceipt {
+	return &ErasureReceipt{}
+}
+
+func (p *ErasureReceipt) InitDefault() {
+}
+
+func (p *ErasureReceipt) GetId() (v string) {
+	return p.Id
+}
+
+func (p *ErasureReceipt) GetSubjectHash() (v string) {
+	return p.SubjectHash
+}
+
+func (p *ErasureReceipt) GetTenant() (v string) {
+	return p.Tenant
+}
+
+func (p *ErasureReceipt) GetErasedAt() (v int64) {
+	return p.ErasedAt
+}
+
+func (p *ErasureReceipt) GetServices() (v []*common.ErasureSummary) {
+	return p.Services
+}
+
+var ErasureReceipt_RequestedBy_DEFAULT *common.Actor
+
+func (p *ErasureReceipt) GetRequestedBy() (v *common.Actor) {
+	if !p.IsSetRequestedBy() {
+		return ErasureReceipt_RequestedBy_DEFAULT
+	}
+	return p.RequestedBy
+}
+
+func (p *ErasureReceipt) GetKeyId() (v string) {
+	return p.KeyId
+}
+
+func (p *ErasureReceipt) GetSignature() (v string) {
+	return p.Signature
+}
+func (p *ErasureReceipt) SetId(val string) {
+	p.Id = val
+}
+func (p *ErasureReceipt) SetSubjectHash(val string) {
+	p.SubjectHash = val
+}
+func (p *ErasureReceipt) SetTenant(val string) {
+	p.Tenant = val
+}
+func (p *ErasureReceipt) SetErasedAt(val int64) {
+	p.ErasedAt = val
+}
+func (p *ErasureReceipt) SetServices(val []*common.ErasureSummary) {
+	p.Services = val
+}
+func (p *ErasureReceipt) SetRequestedBy(val *common.Actor) {
+	p.RequestedBy = val
+}
+func (p *ErasureReceipt) SetKeyId(val string) {
+	p.KeyId = val
+}
+func (p *ErasureReceipt) SetSignature(val string) {
+	p.Signature = val
+}
+
+func (p *ErasureReceipt) IsSetRequestedBy() bool {
+	return p.RequestedBy != nil
+}
+
+func (p *ErasureReceipt) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ErasureReceipt(%+v)", *p)
+}
+
+var fieldIDToName_ErasureReceipt = map[int16]string{
+	1: "id",
+	2: "subject_hash",
+	3: "tenant",
+	4: "erased_at",
+	5: "services",
+	6: "requested_by",
+	7: "key_id",
+	8: "signature",
+}
+
+type GetErasureReceiptRequest struct {
+	Id string `thrift:"id,1" frugal:"1,default,string" json:"id"`
+}
+
+func NewGetErasureReceiptRequest() *GetErasureReceiptRequest {
+	return &GetErasureReceiptRequest{}
+}
+
+func (p *GetErasureReceiptRequest) InitDefault() {
+}
+
+func (p *GetErasureReceiptRequest) GetId() (v string) {
+	return p.Id
+}
+func (p *GetErasureReceiptRequest) SetId(val string) {
+	p.Id = val
+}
+
+func (p *GetErasureReceiptRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("GetErasureReceiptRequest(%+v)", *p)
+}
+
+var fieldIDToName_GetErasureReceiptRequest = map[int16]string{
+	1: "id",
+}
+
+type TicketService interface {
+	CreateTicket(ctx context.Context, req *CreateTicketRequest) (r *TicketResponse, err error)
+
+	GetTicket(ctx context.Context, req *GetTicketRequest) (r *TicketResponse, err error)
+
+	ListTickets(ctx context.Context, req *ListTicketsRequest) (r *ListTicketsResponse, err error)
+
+	Assign(ctx context.Context, req *TicketActionRequest) (r *TicketResponse, err error)
+
+	Resolve(ctx context.Context, req *TicketActionRequest) (r *TicketResponse, err error)
+
+	Escalate(ctx context.Context, req *TicketActionRequest) (r *TicketResponse, err error)
+
+	Reopen(ctx context.Context, req *TicketActionRequest) (r *TicketResponse, err error)
+
+	Deescalate(ctx context.Context, req *TicketActionRequest) (r *TicketResponse, err error)
+
+	GetCycles(ctx context.Context, req *GetCyclesRequest) (r []*common.TicketCycle, err error)
+
+	GetEvents(ctx context.Context, req *GetEventsRequest) (r []*common.TicketEvent, err error)
+
+	AddComment(ctx context.Context, req *AddCommentRequest) (r *TicketResponse, err error)
+
+	Watch(ctx context.Context, req *WatchRequest) (r *TicketResponse, err error)
+
+	Unwatch(ctx context.Context, req *WatchRequest) (r *TicketResponse, err error)
+
+	ListNotifications(ctx context.Context, req *ListNotificationsRequest) (r *ListNotificationsResponse, err error)
+
+	MarkNotifications(ctx context.Context, req *MarkNotificationsRequest) (r *MarkNotificationsResponse, err error)
+
+	GetNotificationPrefs(ctx context.Context, req *GetNotificationPrefsRequest) (r *NotificationPrefs, err error)
+
+	SetNotificationPrefs(ctx context.Context, req *SetNotificationPrefsRequest) (r *NotificationPrefs, err error)
+
+	VerifyAudit(ctx context.Context, req *VerifyAuditRequest) (r *VerifyAuditResponse, err error)
+
+	ExportAuditHead(ctx context.Context, req *ExportAuditHeadRequest) (r *AuditHead, err error)
+
+	SubmitSurvey(ctx context.Context, req *SubmitSurveyRequest) (r *SubmitSurveyResponse, err error)
+
+	CSATReport(ctx context.Context, req *CSATReportRequest) (r *CSATReportResponse, err error)
+
+	EscalationReport(ctx context.Context, req *EscalationReportRequest) (r *EscalationReportResponse, err error)
+
+	CreateView(ctx context.Context, req *SaveViewRequest) (r *SavedView, err error)
+
+	UpdateView(ctx context.Context, req *SaveViewRequest) (r *SavedView, err error)
+
+	GetView(ctx context.Context, req *ViewRequest) (r *SavedView, err error)
+
+	DeleteView(ctx context.Context, req *ViewRequest) (r *DeleteViewResponse, err error)
+
+	ListViews(ctx context.Context, req *ListViewsRequest) (r *ListViewsResponse, err error)
+
+	ViewTickets(ctx context.Context, req *ViewTicketsRequest) (r *ListTicketsResponse, err error)
+
+	PullNext(ctx context.Context, req *PullNextRequest) (r *PullNextResponse, err error)
+
+	AcquireLock(ctx context.Context, req *LockRequest) (r *LockResponse, err error)
+
+	ReleaseLock(ctx context.Context, req *LockRequest) (r *LockResponse, err error)
+
+	ListLockEvents(ctx context.Context, req *ListLockEventsRequest) (r *ListLockEventsResponse, err error)
+
+	DeleteTicket(ctx context.Context, req *TicketActionRequest) (r *TicketResponse, err error)
+
+	RestoreTicket(ctx context.Context, req *TicketActionRequest) (r *TicketResponse, err error)
+
+	Archive(ctx context.Context, req *TicketActionRequest) (r *TicketResponse, err error)
+
+	Unarchive(ctx context.Context, req *TicketActionRequest) (r *TicketResponse, err error)
+
+	ApplyRetention(ctx context.Context, req *ApplyRetentionRequest) (r *RetentionReport, err error)
+
+	ExportSubject(ctx context.Context, req *common.SubjectRequest) (r *ExportSubjectResponse, err error)
+
+	EraseSubject(ctx context.Context, req *EraseSubjectRequest) (r *ErasureReceipt, err error)
+
+	GetErasureReceipt(ctx context.Context, req *GetErasureReceiptRequest) (r *ErasureReceipt, err error)
+}
+
+type TicketServiceCreateTicketArgs struct {
+	Req *CreateTicketRequest `thrift:"req,1" frugal:"1,default,CreateTicketRequest" json:"req"`
+}
+
+func NewTicketServiceCreateTicketArgs() *TicketServiceCreateTicketArgs {
+	return &TicketServiceCreateTicketArgs{}
+}
+
+func (p *TicketServiceCreateTicketArgs) InitDefault() {
+}
+
+var TicketServiceCreateTicketArgs_Req_DEFAULT *CreateTicketRequest
+
+func (p *TicketServiceCreateTicketArgs) GetReq() (v *CreateTicketRequest) {
+	if !p.IsSetReq() {
+		return TicketServiceCreateTicketArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *TicketServiceCreateTicketArgs) SetReq(val *CreateTicketRequest) {
+	p.Req = val
+}
+
+func (p *TicketServiceCreateTicketArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *TicketServiceCreateTicketArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("TicketServiceCreateTicketArgs(%+v)", *p)
+}
+
+var fieldIDToName_TicketServiceCreateTicketArgs = map[int16]string{
+	1: "req",
+}
+
+type TicketServiceCreateTicketResult struct {
+	Success *TicketResponse      `thrift:"success,0,optional" frugal:"0,optional,TicketResponse" json:"success,omitempty"`
+	Err     *common.ServiceError `thrift:"err,1,optional" frugal:"1,optional,common.ServiceError" json:"err,omitempty"`
+}
+
+func NewTicketServiceCreateTicketResult() *TicketServiceCreateTicketResult {
+	return &TicketServiceCreateTicketResult{}
+}
+
+func (p *TicketServiceCreateTicketResult) InitDefault() {
+}
+
+var TicketServiceCreateTicketResult_Success_DEFAULT *TicketResponse
+
+func (p *TicketServiceCreateTicketResult) GetSuccess() (v *TicketResponse) {
+	if !p.IsSetSuccess() {
+		return TicketServiceCreateTicketResult_Success_DEFAULT
+	}
+	return p.Success
+}
+
+var TicketServiceCreateTicketResult_Err_DEFAULT *common.ServiceError
+
+func (p *TicketServiceCreateTicketResult) GetErr() (v *common.ServiceError) {
+	if !p.IsSetErr() {
+		return TicketServiceCreateTicketResult_Err_DEFAULT
+	}
+	return p.Err
+}
+func (p *TicketServiceCreateTicketResult) SetSuccess(x interface{}) {
+	p.Success = x.(*TicketResponse)
+}
+func (p *TicketServiceCreateTicketResult) SetErr(val *common.ServiceError) {
+	p.Err = val
+}
+
+func (p *TicketServiceCreateTicketResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *TicketServiceCreateTicketResult) IsSetErr() bool {
+	return p.Err != nil
+}
+
+func (p *TicketServiceCreateTicketResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("TicketServiceCreateTicketResult(%+v)", *p)
+}
+
+var fieldIDToName_TicketServiceCreateTicketResult = map[int16]string{
+	0: "success",
+	1: "err",
+}
+
+type TicketServiceGetTicketArgs struct {
+	Req *GetTicketRequest `thrift:"req,1" frugal:"1,default,GetTicketRequest" json:"req"`
+}
+
+func NewTicketServiceGetTicketArgs() *TicketServiceGetTicketArgs {
+	return &TicketServiceGetTicketArgs{}
+}
+
+func (p *TicketServiceGetTicketArgs) InitDefault() {
+}
+
+var TicketServiceGetTicketArgs_Req_DEFAULT *GetTicketRequest
+
+func (p *TicketServiceGetTicketArgs) GetReq() (v *GetTicketRequest) {
+	if !p.IsSetReq() {
+		return TicketServiceGetTicketArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *TicketServiceGetTicketArgs) SetReq(val *GetTicketRequest) {
+	p.Req = val
+}
+
+func (p *TicketServiceGetTicketArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *TicketServiceGetTicketArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("TicketServiceGetTicketArgs(%+v)", *p)
+}
+
+var fieldIDToName_TicketServiceGetTicketArgs = map[int16]string{
+	1: "req",
+}
+
+type TicketServiceGetTicketResult struct {
+	Success *TicketResponse      `thrift:"success,0,optional" frugal:"0,optional,TicketResponse" json:"success,omitempty"`
+	Err     *common.ServiceError `thrift:"err,1,optional" frugal:"1,optional,common.ServiceError" json:"err,omitempty"`
+}
+
+func NewTicketServiceGetTicketResult() *TicketServiceGetTicketResult {
+	return &TicketServiceGetTicketResult{}
+}
+
+func (p *TicketServiceGetTicketResult) InitDefault() {
+}
+
+var TicketServiceGetTicketResult_Success_DEFAULT *TicketResponse
+
+func (p *TicketServiceGetTicketResult) GetSuccess() (v *TicketResponse) {
+	if !p.IsSetSuccess() {
+		return TicketServiceGetTicketResult_Success_DEFAULT
+	}
+	return p.Success
+}
+
+var TicketServiceGetTicketResult_Err_DEFAULT *common.ServiceError
+
+func (p *TicketServiceGetTicketResult) GetErr() (v *common.ServiceError) {
+	if !p.IsSetErr() {
+		return TicketServiceGetTicketResult_Err_DEFAULT
+	}
+	return p.Err
+}
+func (p *TicketServiceGetTicketResult) SetSuccess(x interface{}) {
+	p.Success = x.(*TicketResponse)
+}
+func (p *TicketServiceGetTicketResult) SetErr(val *common.ServiceError) {
+	p.Err = val
+}
+
+func (p *TicketServiceGetTicketResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *TicketServiceGetTicketResult) IsSetErr() bool {
+	return p.Err != nil
+}
+
+func (p *TicketServiceGetTicketResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("TicketServiceGetTicketResult(%+v)", *p)
+}
+
+var fieldIDToName_TicketServiceGetTicketResult = map[int16]string{
+	0: "success",
+	1: "err",
+}
+
+type TicketServiceListTicketsArgs struct {
+	Req *ListTicketsRequest `thrift:"req,1" frugal:"1,default,ListTicketsRequest" json:"req"`
+}
+
+func NewTicketServiceListTicketsArgs() *TicketServiceListTicketsArgs {
+	return &TicketServiceListTicketsArgs{}
+}
+
+func (p *TicketServiceListTicketsArgs) InitDefault() {
+}
+
+var TicketServiceListTicketsArgs_Req_DEFAULT *ListTicketsRequest
+
+func (p *TicketServiceListTicketsArgs) GetReq() (v *ListTicketsRequest) {
+	if !p.IsSetReq() {
+		return TicketServiceListTicketsArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *TicketServiceListTicketsArgs) SetReq(val *ListTicketsRequest) {
+	p.Req = val
+}
+
+func (p *TicketServiceListTicketsArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *TicketServiceListTicketsArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("TicketServiceListTicketsArgs(%+v)", *p)
+}
+
+var fieldIDToName_TicketServiceListTicketsArgs = map[int16]string{
+	1: "req",
+}
+
+type TicketServiceListTicketsResult struct {
+	Success *ListTicketsResponse `thrift:"success,0,optional" frugal:"0,optional,ListTicketsResponse" json:"success,omitempty"`
+	Err     *common.ServiceError `thrift:"err,1,optional" frugal:"1,optional,common.ServiceError" json:"err,omitempty"`
+}
+
+func NewTicketServiceListTicketsResult() *TicketServiceListTicketsResult {
+	return &TicketServiceListTicketsResult{}
+}
+
+func (p *TicketServiceListTicketsResult) InitDefault() {
+}
+
+var TicketServiceListTicketsResult_Success_DEFAULT *ListTicketsResponse
+
+func (p *TicketServiceListTicketsResult) GetSuccess() (v *ListTicketsResponse) {
+	if !p.IsSetSuccess() {
+		return TicketServiceListTicketsResult_Success_DEFAULT
+	}
+	return p.Success
+}
+
+var TicketServiceListTicketsResult_Err_DEFAULT *common.ServiceError
+
+func (p *TicketServiceListTicketsResult) GetErr() (v *common.ServiceError) {
+	if !p.IsSetErr() {
+		return TicketServiceListTicketsResult_Err_DEFAULT
+	}
+	return p.Err
+}
+func (p *TicketServiceListTicketsResult) SetSuccess(x interface{}) {
+	p.Success = x.(*ListTicketsResponse)
+}
+func (p *TicketServiceListTicketsResult) SetErr(val *common.ServiceError) {
+	p.Err = val
+}
+
+func (p *TicketServiceListTicketsResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *TicketServiceListTicketsResult) IsSetErr() bool {
+	return p.Err != nil
+}
+
+func (p *TicketServiceListTicketsResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("TicketServiceListTicketsResult(%+v)", *p)
+}
+
+var fieldIDToName_TicketServiceListTicketsResult = map[int16]string{
+	0: "success",
+	1: "err",
+}
+
+type TicketServiceAssignArgs struct {
+	Req *TicketActionRequest `thrift:"req,1" frugal:"1,default,TicketActionRequest" json:"req"`
+}
+
+func NewTicketServiceAssignArgs() *TicketServiceAssignArgs {
+	return &TicketServiceAssignArgs{}
+}
+
+func (p *TicketServiceAssignArgs) InitDefault() {
+}
+
+var TicketServiceAssignArgs_Req_DEFAULT *TicketActionRequest
+
+func (p *TicketServiceAssignArgs) GetReq() (v *TicketActionRequest) {
+	if !p.IsSetReq() {
+		return TicketServiceAssignArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *TicketServiceAssignArgs) SetReq(val *TicketActionRequest) {
+	p.Req = val
+}
+
+func (p *TicketServiceAssignArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *TicketServiceAssignArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("TicketServiceAssignArgs(%+v)", *p)
+}
+
+var fieldIDToName_TicketServiceAssignArgs = map[int16]string{
+	1: "req",
+}
+
+type TicketServiceAssignResult struct {
+	Success *TicketResponse      `thrift:"success,0,optional" frugal:"0,optional,TicketResponse" json:"success,omitempty"`
+	Err     *common.ServiceError `thrift:"err,1,optional" frugal:"1,optional,common.ServiceError" json:"err,omitempty"`
+}
+
+func NewTicketServiceAssignResult() *TicketServiceAssignResult {
+	return &TicketServiceAssignResult{}
+}
+
+func (p *TicketServiceAssignResult) InitDefault() {
+}
+
+var TicketServiceAssignResult_Success_DEFAULT *TicketResponse
+
+func (p *TicketServiceAssignResult) GetSuccess() (v *TicketResponse) {
+	if !p.IsSetSuccess() {
+		return TicketServiceAssignResult_Success_DEFAULT
+	}
+	return p.Success
+}
+
+var TicketServiceAssignResult_Err_DEFAULT *common.ServiceError
+
+func (p *TicketServiceAssignResult) GetErr() (v *common.ServiceError) {
+	if !p.IsSetErr() {
+		return TicketServiceAssignResult_Err_DEFAULT
+	}
+	return p.Err
+}
+func (p *TicketServiceAssignResult) SetSuccess(x interface{}) {
+	p.Success = x.(*TicketResponse)
+}
+func (p *TicketServiceAssignResult) SetErr(val *common.ServiceError) {
+	p.Err = val
+}
+
+func (p *TicketServiceAssignResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *TicketServiceAssignResult) IsSetErr() bool {
+	return p.Err != nil
+}
+
+func (p *TicketServiceAssignResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("TicketServiceAssignResult(%+v)", *p)
+}
+
+var fieldIDToName_TicketServiceAssignResult = map[int16]string{
+	0: "success",
+	1: "err",
+}
+
+type TicketServiceResolveArgs struct {
+	Req *TicketActionRequest `thrift:"req,1" frugal:"1,default,TicketActionRequest" json:"req"`
+}
+
+func NewTicketServiceResolveArgs() *TicketServiceResolveArgs {
+	return &TicketServiceResolveArgs{}
+}
+
+func (p *TicketServiceResolveArgs) InitDefault() {
+}
+
+var TicketServiceResolveArgs_Req_DEFAULT *TicketActionRequest
+
+func (p *TicketServiceResolveArgs) GetReq() (v *TicketActionRequest) {
+	if !p.IsSetReq() {
+		return TicketServiceResolveArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *TicketServiceResolveArgs) SetReq(val *TicketActionRequest) {
+	p.Req = val
+}
+
+func (p *TicketServiceResolveArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *TicketServiceResolveArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("TicketServiceResolveArgs(%+v)", *p)
+}
+
+var fieldIDToName_TicketServiceResolveArgs = map[int16]string{
+	1: "req",
+}
+
+type TicketServiceResolveResult struct {
+	Success *TicketResponse      `thrift:"success,0,optional" frugal:"0,optional,TicketResponse" json:"success,omitempty"`
+	Err     *common.ServiceError `thrift:"err,1,optional" frugal:"1,optional,common.ServiceError" json:"err,omitempty"`
+}
+
+func NewTicketServiceResolveResult() *TicketServiceResolveResult {
+	return &TicketServiceResolveResult{}
+}
+
+func (p *TicketServiceResolveResult) InitDefault() {
+}
+
+var TicketServiceResolveResult_Success_DEFAULT *TicketResponse
+
+func (p *TicketServiceResolveResult) GetSuccess() (v *TicketResponse) {
+	if !p.IsSetSuccess() {
+		return TicketServiceResolveResult_Success_DEFAULT
+	}
+	return p.Success
+}
+
+var TicketServiceResolveResult_Err_DEFAULT *common.ServiceError
+
+func (p *TicketServiceResolveResult) GetErr() (v *common.ServiceError) {
+	if !p.IsSetErr() {
+		return TicketServiceResolveResult_Err_DEFAULT
+	}
+	return p.Err
+}
+func (p *TicketServiceResolveResult) SetSuccess(x interface{}) {
+	p.Success = x.(*TicketResponse)
+}
+func (p *TicketServiceResolveResult) SetErr(val *common.ServiceError) {
+	p.Err = val
+}
+
+func (p *TicketServiceResolveResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *TicketServiceResolveResult) IsSetErr() bool {
+	return p.Err != nil
+}
+
+func (p *TicketServiceResolveResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("TicketServiceResolveResult(%+v)", *p)
+}
+
+var fieldIDToName_TicketServiceResolveResult = map[int16]string{
+	0: "success",
+	1: "err",
+}
+
+type TicketServiceEscalateArgs struct {
+	Req *TicketActionRequest `thrift:"req,1" frugal:"1,default,TicketActionRequest" json:"req"`
+}
+
+func NewTicketServiceEscalateArgs() *TicketServiceEscalateArgs {
+	return &TicketServiceEscalateArgs{}
+}
+
+func (p *TicketServiceEscalateArgs) InitDefault() {
+}
+
+var TicketServiceEscalateArgs_Req_DEFAULT *TicketActionRequest
+
+func (p *TicketServiceEscalateArgs) GetReq() (v *TicketActionRequest) {
+	if !p.IsSetReq() {
+		return TicketServiceEscalateArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *TicketServiceEscalateArgs) SetReq(val *TicketActionRequest) {
+	p.Req = val
+}
+
+func (p *TicketServiceEscalateArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *TicketServiceEscalateArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("TicketServiceEscalateArgs(%+v)", *p)
+}
+
+var fieldIDToName_TicketServiceEscalateArgs = map[int16]string{
+	1: "req",
+}
+
+type TicketServiceEscalateResult struct {
+	Success *TicketResponse      `thrift:"success,0,optional" frugal:"0,optional,TicketResponse" json:"success,omitempty"`
+	Err     *common.ServiceError `thrift:"err,1,optional" frugal:"1,optional,common.ServiceError" json:"err,omitempty"`
+}
+
+func NewTicketServiceEscalateResult() *TicketServiceEscalateResult {
+	return &TicketServiceEscalateResult{}
+}
+
+func (p *TicketServiceEscalateResult) InitDefault() {
+}
+
+var TicketServiceEscalateResult_Success_DEFAULT *TicketResponse
+
+func (p *TicketServiceEscalateResult) GetSuccess() (v *TicketResponse) {
+	if !p.IsSetSuccess() {
+		return TicketServiceEscalateResult_Success_DEFAULT
+	}
+	return p.Success
+}
+
+var TicketServiceEscalateResult_Err_DEFAULT *common.ServiceError
+
+func (p *TicketServiceEscalateResult) GetErr() (v *common.ServiceError) {
+	if !p.IsSetErr() {
+		return TicketServiceEscalateResult_Err_DEFAULT
+	}
+	return p.Err
+}
+func (p *TicketServiceEscalateResult) SetSuccess(x interface{}) {
+	p.Success = x.(*TicketResponse)
+}
+func (p *TicketServiceEscalateResult) SetErr(val *common.ServiceError) {
+	p.Err = val
+}
+
+func (p *TicketServiceEscalateResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *TicketServiceEscalateResult) IsSetErr() bool {
+	return p.Err != nil
+}
+
+func (p *TicketServiceEscalateResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("TicketServiceEscalateResult(%+v)", *p)
+}
+
+var fieldIDToName_TicketServiceEscalateResult = map[int16]string{
+	0: "success",
+	1: "err",
+}
+
+type TicketServiceReopenArgs struct {
+	Req *TicketActionRequest `thrift:"req,1" frugal:"1,default,TicketActionRequest" json:"req"`
+}
+
+func NewTicketServiceReopenArgs() *TicketServiceReopenArgs {
+	return &TicketServiceReopenArgs{}
+}
+
+func (p *TicketServiceReopenArgs) InitDefault() {
+}
+
+var TicketServiceReopenArgs_Req_DEFAULT *TicketActionRequest
+
+func (p *TicketServiceReopenArgs) GetReq() (v *TicketActionRequest) {
+	if !p.IsSetReq() {
+		return TicketServiceReopenArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *TicketServiceReopenArgs) SetReq(val *TicketActionRequest) {
+	p.Req = val
+}
+
+func (p *TicketServiceReopenArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *TicketServiceReopenArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("TicketServiceReopenArgs(%+v)", *p)
+}
+
+var fieldIDToName_TicketServiceReopenArgs = map[int16]string{
+	1: "req",
+}
+
+type TicketServiceReopenResult struct {
+	Success *TicketResponse      `thrift:"success,0,optional" frugal:"0,optional,TicketResponse" json:"success,omitempty"`
+	Err     *common.ServiceError `thrift:"err,1,optional" frugal:"1,optional,common.ServiceError" json:"err,omitempty"`
+}
+
+func NewTicketServiceReopenResult() *TicketServiceReopenResult {
+	return &TicketServiceReopenResult{}
+}
+
+func (p *TicketServiceReopenResult) InitDefault() {
+}
+
+var TicketServiceReopenResult_Success_DEFAULT *TicketResponse
+
+func (p *TicketServiceReopenResult) GetSuccess() (v *TicketResponse) {
+	if !p.IsSetSuccess() {
+		return TicketServiceReopenResult_Success_DEFAULT
+	}
+	return p.Success
+}
+
+var TicketServiceReopenResult_Err_DEFAULT *common.ServiceError
+
+func (p *TicketServiceReopenResult) GetErr() (v *common.ServiceError) {
+	if !p.IsSetErr() {
+		return TicketServiceReopenResult_Err_DEFAULT
+	}
+	return p.Err
+}
+func (p *TicketServiceReopenResult) SetSuccess(x interface{}) {
+	p.Success = x.(*TicketResponse)
+}
+func (p *TicketServiceReopenResult) SetErr(val *common.ServiceError) {
+	p.Err = val
+}
+
+func (p *TicketServiceReopenResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *TicketServiceReopenResult) IsSetErr() bool {
+	return p.Err != nil
+}
+
+func (p *TicketServiceReopenResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("TicketServiceReopenResult(%+v)", *p)
+}
+
+var fieldIDToName_TicketServiceReopenResult = map[int16]string{
+	0: "success",
+	1: "err",
+}
+
+type TicketServiceDeescalateArgs struct {
+	Req *TicketActionRequest `thrift:"req,1" frugal:"1,default,TicketActionRequest" json:"req"`
+}
+
+func NewTicketServiceDeescalateArgs() *TicketServiceDeescalateArgs {
+	return &TicketServiceDeescalateArgs{}
+}
+
+func (p *TicketServiceDeescalateArgs) InitDefault() {
+}
+
+var TicketServiceDeescalateArgs_Req_DEFAULT *TicketActionRequest
+
+func (p *TicketServiceDeescalateArgs) GetReq() (v *TicketActionRequest) {
+	if !p.IsSetReq() {
+		return TicketServiceDeescalateArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *TicketServiceDeescalateArgs) SetReq(val *TicketActionRequest) {
+	p.Req = val
+}
+
+func (p *TicketServiceDeescalateArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *TicketServiceDeescalateArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("TicketServiceDeescalateArgs(%+v)", *p)
+}
+
+var fieldIDToName_TicketServiceDeescalateArgs = map[int16]string{
+	1: "req",
+}
+
+type TicketServiceDeescalateResult struct {
+	Success *TicketResponse      `thrift:"success,0,optional" frugal:"0,optional,TicketResponse" json:"success,omitempty"`
+	Err     *common.ServiceError `thrift:"err,1,optional" frugal:"1,optional,common.ServiceError" json:"err,omitempty"`
+}
+
+func NewTicketServiceDeescalateResult() *TicketServiceDeescalateResult {
+	return &TicketServiceDeescalateResult{}
+}
+
+func (p *TicketServiceDeescalateResult) InitDefault() {
+}
+
+var TicketServiceDeescalateResult_Success_DEFAULT *TicketResponse
+
+func (p *TicketServiceDeescalateResult) GetSuccess() (v *TicketResponse) {
+	if !p.IsSetSuccess() {
+		return TicketServiceDeescalateResult_Success_DEFAULT
+	}
+	return p.Success
+}
+
+var TicketServiceDeescalateResult_Err_DEFAULT *common.ServiceError
+
+func (p *TicketServiceDeescalateResult) GetErr() (v *common.ServiceError) {
+	if !p.IsSetErr() {
+		return TicketServiceDeescalateResult_Err_DEFAULT
+	}
+	return p.Err
+}
+func (p *TicketServiceDeescalateResult) SetSuccess(x interface{}) {
+	p.Success = x.(*TicketResponse)
+}
+func (p *TicketServiceDeescalateResult) SetErr(val *common.ServiceError) {
+	p.Err = val
+}
+
+func (p *TicketServiceDeescalateResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *TicketServiceDeescalateResult) IsSetErr() bool {
+	return p.Err != nil
+}
+
+func (p *TicketServiceDeescalateResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("TicketServiceDeescalateResult(%+v)", *p)
+}
+
+var fieldIDToName_TicketServiceDeescalateResult = map[int16]string{
+	0: "success",
+	1: "err",
+}
+
+type TicketServiceGetCyclesArgs struct {
+	Req *GetCyclesRequest `thrift:"req,1" frugal:"1,default,GetCyclesRequest" json:"req"`
+}
+
+func NewTicketServiceGetCyclesArgs() *TicketServiceGetCyclesArgs {
+	return &TicketServiceGetCyclesArgs{}
+}
+
+func (p *TicketServiceGetCyclesArgs) InitDefault() {
+}
+
+var TicketServiceGetCyclesArgs_Req_DEFAULT *GetCyclesRequest
+
+func (p *TicketServiceGetCyclesArgs) GetReq() (v *GetCyclesRequest) {
+	if !p.IsSetReq() {
+		return TicketServiceGetCyclesArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *TicketServiceGetCyclesArgs) SetReq(val *GetCyclesRequest) {
+	p.Req = val
+}
+
+func (p *TicketServiceGetCyclesArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *TicketServiceGetCyclesArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("TicketServiceGetCyclesArgs(%+v)", *p)
+}
+
+var fieldIDToName_TicketServiceGetCyclesArgs = map[int16]string{
+	1: "req",
+}
+
+type TicketServiceGetCyclesResult struct {
+	Success []*common.TicketCycle `thrift:"success,0,optional" frugal:"0,optional,list<common.TicketCycle>" json:"success,omitempty"`
+	Err     *common.ServiceError  `thrift:"err,1,optional" frugal:"1,optional,common.ServiceError" json:"err,omitempty"`
+}
+
+func NewTicketServiceGetCyclesResult() *TicketServiceGetCyclesResult {
+	return &TicketServiceGetCyclesResult{}
+}
+
+func (p *TicketServiceGetCyclesResult) InitDefault() {
+}
+
+var TicketServiceGetCyclesResult_Success_DEFAULT []*common.TicketCycle
+
+func (p *TicketServiceGetCyclesResult) GetSuccess() (v []*common.TicketCycle) {
+	if !p.IsSetSuccess() {
+		return TicketServiceGetCyclesResult_Success_DEFAULT
+	}
+	return p.Success
+}
+
+var TicketServiceGetCyclesResult_Err_DEFAULT *common.ServiceError
+
+func (p *TicketServiceGetCyclesResult) GetErr() (v *common.ServiceError) {
+	if !p.IsSetErr() {
+		return TicketServiceGetCyclesResult_Err_DEFAULT
+	}
+	return p.Err
+}
+func (p *TicketServiceGetCyclesResult) SetSuccess(x interface{}) {
+	p.Success = x.([]*common.TicketCycle)
+}
+func (p *TicketServiceGetCyclesResult) SetErr(val *common.ServiceError) {
+	p.Err = val
+}
+
+func (p *TicketServiceGetCyclesResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *TicketServiceGetCyclesResult) IsSetErr() bool {
+	return p.Err != nil
+}
+
+func (p *TicketServiceGetCyclesResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("TicketServiceGetCyclesResult(%+v)", *p)
+}
+
+var fieldIDToName_TicketServiceGetCyclesResult = map[int16]string{
+	0: "success",
+	1: "err",
+}
+
+type TicketServiceGetEventsArgs struct {
+	Req *GetEventsRequest `thrift:"req,1" frugal:"1,default,GetEventsRequest" json:"req"`
+}
+
+func NewTicketServiceGetEventsArgs() *TicketServiceGetEventsArgs {
+	return &TicketServiceGetEventsArgs{}
+}
+
+func (p *TicketServiceGetEventsArgs) InitDefault() {
+}
+
+var TicketServiceGetEventsArgs_Req_DEFAULT *GetEventsRequest
+
+func (p *TicketServiceGetEventsArgs) GetReq() (v *GetEventsRequest) {
+	if !p.IsSetReq() {
+		return TicketServiceGetEventsArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *TicketServiceGetEventsArgs) SetReq(val *GetEventsRequest) {
+	p.Req = val
+}
+
+func (p *TicketServiceGetEventsArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *TicketServiceGetEventsArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("TicketServiceGetEventsArgs(%+v)", *p)
+}
+
+var fieldIDToName_TicketServiceGetEventsArgs = map[int16]string{
+	1: "req",
+}
+
+type TicketServiceGetEventsResult struct {
+	Success []*common.TicketEvent `thrift:"success,0,optional" frugal:"0,optional,list<common.TicketEvent>" json:"success,omitempty"`
+	Err     *common.ServiceError  `thrift:"err,1,optional" frugal:"1,optional,common.ServiceError" json:"err,omitempty"`
+}
+
+func NewTicketServiceGetEventsResult() *TicketServiceGetEventsResult {
+	return &TicketServiceGetEventsResult{}
+}
+
+func (p *TicketServiceGetEventsResult) InitDefault() {
+}
+
+var TicketServiceGetEventsResult_Success_DEFAULT []*common.TicketEvent
+
+func (p *TicketServiceGetEventsResult) GetSuccess() (v []*common.TicketEvent) {
+	if !p.IsSetSuccess() {
+		return TicketServiceGetEventsResult_Success_DEFAULT
+	}
+	return p.Success
+}
+
+var TicketServiceGetEventsResult_Err_DEFAULT *common.ServiceError
+
+func (p *TicketServiceGetEventsResult) GetErr() (v *common.ServiceError) {
+	if !p.IsSetErr() {
+		return TicketServiceGetEventsResult_Err_DEFAULT
+	}
+	return p.Err
+}
+func (p *TicketServiceGetEventsResult) SetSuccess(x interface{}) {
+	p.Success = x.([]*common.TicketEvent)
+}
+func (p *TicketServiceGetEventsResult) SetErr(val *common.ServiceError) {
+	p.Err = val
+}
+
+func (p *TicketServiceGetEventsResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *TicketServiceGetEventsResult) IsSetErr() bool {
+	return p.Err != nil
+}
+
+func (p *TicketServiceGetEventsResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("TicketServiceGetEventsResult(%+v)", *p)
+}
+
+var fieldIDToName_TicketServiceGetEventsResult = map[int16]string{
+	0: "success",
+	1: "err",
+}
+
+type TicketServiceAddCommentArgs struct {
+	Req *AddCommentRequest `thrift:"req,1" frugal:"1,default,AddCommentRequest" json:"req"`
+}
+
+func NewTicketServiceAddCommentArgs() *TicketServiceAddCommentArgs {
+	return &TicketServiceAddCommentArgs{}
+}
+
+func (p *TicketServiceAddCommentArgs) InitDefault() {
+}
+
+var TicketServiceAddCommentArgs_Req_DEFAULT *AddCommentRequest
+
+func (p *TicketServiceAddCommentArgs) GetReq() (v *AddCommentRequest) {
+	if !p.IsSetReq() {
+		return TicketServiceAddCommentArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *TicketServiceAddCommentArgs) SetReq(val *AddCommentRequest) {
+	p.Req = val
+}
+
+func (p *TicketServiceAddCommentArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *TicketServiceAddCommentArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("TicketServiceAddCommentArgs(%+v)", *p)
+}
+
+var fieldIDToName_TicketServiceAddCommentArgs = map[int16]string{
+	1: "req",
+}
+
+type TicketServiceAddCommentResult struct {
+	Success *TicketResponse      `thrift:"success,0,optional" frugal:"0,optional,TicketResponse" json:"success,omitempty"`
+	Err     *common.ServiceError `thrift:"err,1,optional" frugal:"1,optional,common.ServiceError" json:"err,omitempty"`
+}
+
+func NewTicketServiceAddCommentResult() *TicketServiceAddCommentResult {
+	return &TicketServiceAddCommentResult{}
+}
+
+func (p *TicketServiceAddCommentResult) InitDefault() {
+}
+
+var TicketServiceAddCommentResult_Success_DEFAULT *TicketResponse
+
+func (p *TicketServiceAddCommentResult) GetSuccess() (v *TicketResponse) {
+	if !p.IsSetSuccess() {
+		return TicketServiceAddCommentResult_Success_DEFAULT
+	}
+	return p.Success
+}
+
+var TicketServiceAddCommentResult_Err_DEFAULT *common.ServiceError
+
+func (p *TicketServiceAddCommentResult) GetErr() (v *common.ServiceError) {
+	if !p.IsSetErr() {
+		return TicketServiceAddCommentResult_Err_DEFAULT
+	}
+	return p.Err
+}
+func (p *TicketServiceAddCommentResult) SetSuccess(x interface{}) {
+	p.Success = x.(*TicketResponse)
+}
+func (p *TicketServiceAddCommentResult) SetErr(val *common.ServiceError) {
+	p.Err = val
+}
+
+func (p *TicketServiceAddCommentResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *TicketServiceAddCommentResult) IsSetErr() bool {
+	return p.Err != nil
+}
+
+func (p *TicketServiceAddCommentResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("TicketServiceAddCommentResult(%+v)", *p)
+}
+
+var fieldIDToName_TicketServiceAddCommentResult = map[int16]string{
+	0: "success",
+	1: "err",
+}
+
+type TicketServiceWatchArgs struct {
+	Req *WatchRequest `thrift:"req,1" frugal:"1,default,WatchRequest" json:"req"`
+}
+
+func NewTicketServiceWatchArgs() *TicketServiceWatchArgs {
+	return &TicketServiceWatchArgs{}
+}
+
+func (p *TicketServiceWatchArgs) InitDefault() {
+}
+
+var TicketServiceWatchArgs_Req_DEFAULT *WatchRequest
+
+func (p *TicketServiceWatchArgs) GetReq() (v *WatchRequest) {
+	if !p.IsSetReq() {
+		return TicketServiceWatchArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *TicketServiceWatchArgs) SetReq(val *WatchRequest) {
+	p.Req = val
+}
+
+func (p *TicketServiceWatchArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *TicketServiceWatchArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("TicketServiceWatchArgs(%+v)", *p)
+}
+
+var fieldIDToName_TicketServiceWatchArgs = map[int16]string{
+	1: "req",
+}
+
+type TicketServiceWatchResult struct {
+	Success *TicketResponse      `thrift:"success,0,optional" frugal:"0,optional,TicketResponse" json:"success,omitempty"`
+	Err     *common.ServiceError `thrift:"err,1,optional" frugal:"1,optional,common.ServiceError" json:"err,omitempty"`
+}
+
+func NewTicketServiceWatchResult() *TicketServiceWatchResult {
+	return &TicketServiceWatchResult{}
+}
+
+func (p *TicketServiceWatchResult) InitDefault() {
+}
+
+var TicketServiceWatchResult_Success_DEFAULT *TicketResponse
+
+func (p *TicketServiceWatchResult) GetSuccess() (v *TicketResponse) {
+	if !p.IsSetSuccess() {
+		return TicketServiceWatchResult_Success_DEFAULT
+	}
+	return p.Success
+}
+
+var TicketServiceWatchResult_Err_DEFAULT *common.ServiceError
+
+func (p *TicketServiceWatchResult) GetErr() (v *common.ServiceError) {
+	if !p.IsSetErr() {
+		return TicketServiceWatchResult_Err_DEFAULT
+	}
+	return p.Err
+}
+func (p *TicketServiceWatchResult) SetSuccess(x interface{}) {
+	p.Success = x.(*TicketResponse)
+}
+func (p *TicketServiceWatchResult) SetErr(val *common.ServiceError) {
+	p.Err = val
+}
+
+func (p *TicketServiceWatchResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *TicketServiceWatchResult) IsSetErr() bool {
+	return p.Err != nil
+}
+
+func (p *TicketServiceWatchResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("TicketServiceWatchResult(%+v)", *p)
+}
+
+var fieldIDToName_TicketServiceWatchResult = map[int16]string{
+	0: "success",
+	1: "err",
+}
+
+type TicketServiceUnwatchArgs struct {
+	Req *WatchRequest `thrift:"req,1" frugal:"1,default,WatchRequest" json:"req"`
+}
+
+func NewTicketServiceUnwatchArgs() *TicketServiceUnwatchArgs {
+	return &TicketServiceUnwatchArgs{}
+}
+
+func (p *TicketServiceUnwatchArgs) InitDefault() {
+}
+
+var TicketServiceUnwatchArgs_Req_DEFAULT *WatchRequest
+
+func (p *TicketServiceUnwatchArgs) GetReq() (v *WatchRequest) {
+	if !p.IsSetReq() {
+		return TicketServiceUnwatchArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *TicketServiceUnwatchArgs) SetReq(val *WatchRequest) {
+	p.Req = val
+}
+
+func (p *TicketServiceUnwatchArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *TicketServiceUnwatchArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("TicketServiceUnwatchArgs(%+v)", *p)
+}
+
+var fieldIDToName_TicketServiceUnwatchArgs = map[int16]string{
+	1: "req",
+}
+
+type TicketServiceUnwatchResult struct {
+	Success *TicketResponse      `thrift:"success,0,optional" frugal:"0,optional,TicketResponse" json:"success,omitempty"`
+	Err     *common.ServiceError `thrift:"err,1,optional" frugal:"1,optional,common.ServiceError" json:"err,omitempty"`
+}
+
+func NewTicketServiceUnwatchResult() *TicketServiceUnwatchResult {
+	return &TicketServiceUnwatchResult{}
+}
+
+func (p *TicketServiceUnwatchResult) InitDefault() {
+}
+
+var TicketServiceUnwatchResult_Success_DEFAULT *TicketResponse
+
+func (p *TicketServiceUnwatchResult) GetSuccess() (v *TicketResponse) {
+	if !p.IsSetSuccess() {
+		return TicketServiceUnwatchResult_Success_DEFAULT
+	}
+	return p.Success
+}
+
+var TicketServiceUnwatchResult_Err_DEFAULT *common.ServiceError
+
+func (p *TicketServiceUnwatchResult) GetErr() (v *common.ServiceError) {
+	if !p.IsSetErr() {
+		return TicketServiceUnwatchResult_Err_DEFAULT
+	}
+	return p.Err
+}
+func (p *TicketServiceUnwatchResult) SetSuccess(x interface{}) {
+	p.Success = x.(*TicketResponse)
+}
+func (p *TicketServiceUnwatchResult) SetErr(val *common.ServiceError) {
+	p.Err = val
+}
+
+func (p *TicketServiceUnwatchResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *TicketServiceUnwatchResult) IsSetErr() bool {
+	return p.Err != nil
+}
+
+func (p *TicketServiceUnwatchResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("TicketServiceUnwatchResult(%+v)", *p)
+}
+
+var fieldIDToName_TicketServiceUnwatchResult = map[int16]string{
+	0: "success",
+	1: "err",
+}
+
+type TicketServiceListNotificationsArgs struct {
+	Req *ListNotificationsRequest `thrift:"req,1" frugal:"1,default,ListNotificationsRequest" json:"req"`
+}
+
+func NewTicketServiceListNotificationsArgs() *TicketServiceListNotificationsArgs {
+	return &TicketServiceListNotificationsArgs{}
+}
+
+func (p *TicketServiceListNotificationsArgs) InitDefault() {
+}
+
+var TicketServiceListNotificationsArgs_Req_DEFAULT *ListNotificationsRequest
+
+func (p *TicketServiceListNotificationsArgs) GetReq() (v *ListNotificationsRequest) {
+	if !p.IsSetReq() {
+		return TicketServiceListNotificationsArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *TicketServiceListNotificationsArgs) SetReq(val *ListNotificationsRequest) {
+	p.Req = val
+}
+
+func (p *TicketServiceListNotificationsArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *TicketServiceListNotificationsArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("TicketServiceListNotificationsArgs(%+v)", *p)
+}
+
+var fieldIDToName_TicketServiceListNotificationsArgs = map[int16]string{
+	1: "req",
+}
+
+type TicketServiceListNotificationsResult struct {
+	Success *ListNotificationsResponse `thrift:"success,0,optional" frugal:"0,optional,ListNotificationsResponse" json:"success,omitempty"`
+	Err     *common.ServiceError       `thrift:"err,1,optional" frugal:"1,optional,common.ServiceError" json:"err,omitempty"`
+}
+
+func NewTicketServiceListNotificationsResult() *TicketServiceListNotificationsResult {
+	return &TicketServiceListNotificationsResult{}
+}
+
+func (p *TicketServiceListNotificationsResult) InitDefault() {
+}
+
+var TicketServiceListNotificationsResult_Success_DEFAULT *ListNotificationsResponse
+
+func (p *TicketServiceListNotificationsResult) GetSuccess() (v *ListNotificationsResponse) {
+	if !p.IsSetSuccess() {
+		return TicketServiceListNotificationsResult_Success_DEFAULT
+	}
+	return p.Success
+}
+
+var TicketServiceListNotificationsResult_Err_DEFAULT *common.ServiceError
+
+func (p *TicketServiceListNotificationsResult) GetErr() (v *common.ServiceError) {
+	if !p.IsSetErr() {
+		return TicketServiceListNotificationsResult_Err_DEFAULT
+	}
+	return p.Err
+}
+func (p *TicketServiceListNotificationsResult) SetSuccess(x interface{}) {
+	p.Success = x.(*ListNotificationsResponse)
+}
+func (p *TicketServiceListNotificationsResult) SetErr(val *common.ServiceError) {
+	p.Err = val
+}
+
+func (p *TicketServiceListNotificationsResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *TicketServiceListNotificationsResult) IsSetErr() bool {
+	return p.Err != nil
+}
+
+func (p *TicketServiceListNotificationsResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("TicketServiceListNotificationsResult(%+v)", *p)
+}
+
+var fieldIDToName_TicketServiceListNotificationsResult = map[int16]string{
+	0: "success",
+	1: "err",
+}
+
+type TicketServiceMarkNotificationsArgs struct {
+	Req *MarkNotificationsRequest `thrift:"req,1" frugal:"1,default,MarkNotificationsRequest" json:"req"`
+}
+
+func NewTicketServiceMarkNotificationsArgs() *TicketServiceMarkNotificationsArgs {
+	return &TicketServiceMarkNotificationsArgs{}
+}
+
+func (p *TicketServiceMarkNotificationsArgs) InitDefault() {
+}
+
+var TicketServiceMarkNotificationsArgs_Req_DEFAULT *MarkNotificationsRequest
+
+func (p *TicketServiceMarkNotificationsArgs) GetReq() (v *MarkNotificationsRequest) {
+	if !p.IsSetReq() {
+		return TicketServiceMarkNotificationsArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *TicketServiceMarkNotificationsArgs) SetReq(val *MarkNotificationsRequest) {
+	p.Req = val
+}
+
+func (p *TicketServiceMarkNotificationsArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *TicketServiceMarkNotificationsArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("TicketServiceMarkNotificationsArgs(%+v)", *p)
+}
+
+var fieldIDToName_TicketServiceMarkNotificationsArgs = map[int16]string{
+	1: "req",
+}
+
+type TicketServiceMarkNotificationsResult struct {
+	Success *MarkNotificationsResponse `thrift:"success,0,optional" frugal:"0,optional,MarkNotificationsResponse" json:"success,omitempty"`
+	Err     *common.ServiceError       `thrift:"err,1,optional" frugal:"1,optional,common.ServiceError" json:"err,omitempty"`
+}
+
+func NewTicketServiceMarkNotificationsResult() *TicketServiceMarkNotificationsResult {
+	return &TicketServiceMarkNotificationsResult{}
+}
+
+func (p *TicketServiceMarkNotificationsResult) InitDefault() {
+}
+
+var TicketServiceMarkNotificationsResult_Success_DEFAULT *MarkNotificationsResponse
+
+func (p *TicketServiceMarkNotificationsResult) GetSuccess() (v *MarkNotificationsResponse) {
+	if !p.IsSetSuccess() {
+		return TicketServiceMarkNotificationsResult_Success_DEFAULT
+	}
+	return p.Success
+}
+
+var TicketServiceMarkNotificationsResult_Err_DEFAULT *common.ServiceError
+
+func (p *TicketServiceMarkNotificationsResult) GetErr() (v *common.ServiceError) {
+	if !p.IsSetErr() {
+		return TicketServiceMarkNotificationsResult_Err_DEFAULT
+	}
+	return p.Err
+}
+func (p *TicketServiceMarkNotificationsResult) SetSuccess(x interface{}) {
+	p.Success = x.(*MarkNotificationsResponse)
+}
+func (p *TicketServiceMarkNotificationsResult) SetErr(val *common.ServiceError) {
+	p.Err = val
+}
+
+func (p *TicketServiceMarkNotificationsResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *TicketServiceMarkNotificationsResult) IsSetErr() bool {
+	return p.Err != nil
+}
+
+func (p *TicketServiceMarkNotificationsResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("TicketServiceMarkNotificationsResult(%+v)", *p)
+}
+
+var fieldIDToName_TicketServiceMarkNotificationsResult = map[int16]string{
+	0: "success",
+	1: "err",
+}
+
+type TicketServiceGetNotificationPrefsArgs struct {
+	Req *GetNotificationPrefsRequest `thrift:"req,1" frugal:"1,default,GetNotificationPrefsRequest" json:"req"`
+}
+
+func NewTicketServiceGetNotificationPrefsArgs() *TicketServiceGetNotificationPrefsArgs {
+	return &TicketServiceGetNotificationPrefsArgs{}
+}
+
+func (p *TicketServiceGetNotificationPrefsArgs) InitDefault() {
+}
+
+var TicketServiceGetNotificationPrefsArgs_Req_DEFAULT *GetNotificationPrefsRequest
+
+func (p *TicketServiceGetNotificationPrefsArgs) GetReq() (v *GetNotificationPrefsRequest) {
+	if !p.IsSetReq() {
+		return TicketServiceGetNotificationPrefsArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *TicketServiceGetNotificationPrefsArgs) SetReq(val *GetNotificationPrefsRequest) {
+	p.Req = val
+}
+
+func (p *TicketServiceGetNotificationPrefsArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *TicketServiceGetNotificationPrefsArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("TicketServiceGetNotificationPrefsArgs(%+v)", *p)
+}
+
+var fieldIDToName_TicketServiceGetNotificationPrefsArgs = map[int16]string{
+	1: "req",
+}
+
+type TicketServiceGetNotificationPrefsResult struct {
+	Success *NotificationPrefs   `thrift:"success,0,optional" frugal:"0,optional,NotificationPrefs" json:"success,omitempty"`
+	Err     *common.ServiceError `thrift:"err,1,optional" frugal:"1,optional,common.ServiceError" json:"err,omitempty"`
+}
+
+func NewTicketServiceGetNotificationPrefsResult() *TicketServiceGetNotificationPrefsResult {
+	return &TicketServiceGetNotificationPrefsResult{}
+}
+
+func (p *TicketServiceGetNotificationPrefsResult) InitDefault() {
+}
+
+var TicketServiceGetNotificationPrefsResult_Success_DEFAULT *NotificationPrefs
+
+func (p *TicketServiceGetNotificationPrefsResult) GetSuccess() (v *NotificationPrefs) {
+	if !p.IsSetSuccess() {
+		return TicketServiceGetNotificationPrefsResult_Success_DEFAULT
+	}
+	return p.Success
+}
+
+var TicketServiceGetNotificationPrefsResult_Err_DEFAULT *common.ServiceError
+
+func (p *TicketServiceGetNotificationPrefsResult) GetErr() (v *common.ServiceError) {
+	if !p.IsSetErr() {
+		return TicketServiceGetNotificationPrefsResult_Err_DEFAULT
+	}
+	return p.Err
+}
+func (p *TicketServiceGetNotificationPrefsResult) SetSuccess(x interface{}) {
+	p.Success = x.(*NotificationPrefs)
+}
+func (p *TicketServiceGetNotificationPrefsResult) SetErr(val *common.ServiceError) {
+	p.Err = val
+}
+
+func (p *TicketServiceGetNotificationPrefsResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *TicketServiceGetNotificationPrefsResult) IsSetErr() bool {
+	return p.Err != nil
+}
+
+func (p *TicketServiceGetNotificationPrefsResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("TicketServiceGetNotificationPrefsResult(%+v)", *p)
+}
+
+var fieldIDToName_TicketServiceGetNotificationPrefsResult = map[int16]string{
+	0: "success",
+	1: "err",
+}
+
+type TicketServiceSetNotificationPrefsArgs struct {
+	Req *SetNotificationPrefsRequest `thrift:"req,1" frugal:"1,default,SetNotificationPrefsRequest" json:"req"`
+}
+
+func NewTicketServiceSetNotificationPrefsArgs() *TicketServiceSetNotificationPrefsArgs {
+	return &TicketServiceSetNotificationPrefsArgs{}
+}
+
+func (p *TicketServiceSetNotificationPrefsArgs) InitDefault() {
+}
+
+var TicketServiceSetNotificationPrefsArgs_Req_DEFAULT *SetNotificationPrefsRequest
+
+func (p *TicketServiceSetNotificationPrefsArgs) GetReq() (v *SetNotificationPrefsRequest) {
+	if !p.IsSetReq() {
+		return TicketServiceSetNotificationPrefsArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *TicketServiceSetNotificationPrefsArgs) SetReq(val *SetNotificationPrefsRequest) {
+	p.Req = val
+}
+
+func (p *TicketServiceSetNotificationPrefsArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *TicketServiceSetNotificationPrefsArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("TicketServiceSetNotificationPrefsArgs(%+v)", *p)
+}
+
+var fieldIDToName_TicketServiceSetNotificationPrefsArgs = map[int16]string{
+	1: "req",
+}
+
+type TicketServiceSetNotificationPrefsResult struct {
+	Success *NotificationPrefs   `thrift:"success,0,optional" frugal:"0,optional,NotificationPrefs" json:"success,omitempty"`
+	Err     *common.ServiceError `thrift:"err,1,optional" frugal:"1,optional,common.ServiceError" json:"err,omitempty"`
+}
+
+func NewTicketServiceSetNotificationPrefsResult() *TicketServiceSetNotificationPrefsResult {
+	return &TicketServiceSetNotificationPrefsResult{}
+}
+
+func (p *TicketServiceSetNotificationPrefsResult) InitDefault() {
+}
+
+var TicketServiceSetNotificationPrefsResult_Success_DEFAULT *NotificationPrefs
+
+func (p *TicketServiceSetNotificationPrefsResult) GetSuccess() (v *NotificationPrefs) {
+	if !p.IsSetSuccess() {
+		return TicketServiceSetNotificationPrefsResult_Success_DEFAULT
+	}
+	return p.Success
+}
+
+var TicketServiceSetNotificationPrefsResult_Err_DEFAULT *common.ServiceError
+
+func (p *TicketServiceSetNotificationPrefsResult) GetErr() (v *common.ServiceError) {
+	if !p.IsSetErr() {
+		return TicketServiceSetNotificationPrefsResult_Err_DEFAULT
+	}
+	return p.Err
+}
+func (p *TicketServiceSetNotificationPrefsResult) SetSuccess(x interface{}) {
+	p.Success = x.(*NotificationPrefs)
+}
+func (p *TicketServiceSetNotificationPrefsResult) SetErr(val *common.ServiceError) {
+	p.Err = val
+}
+
+func (p *TicketServiceSetNotificationPrefsResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *TicketServiceSetNotificationPrefsResult) IsSetErr() bool {
+	return p.Err != nil
+}
+
+func (p *TicketServiceSetNotificationPrefsResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("TicketServiceSetNotificationPrefsResult(%+v)", *p)
+}
+
+var fieldIDToName_TicketServiceSetNotificationPrefsResult = map[int16]string{
+	0: "success",
+	1: "err",
+}
+
+type TicketServiceVerifyAuditArgs struct {
+	Req *VerifyAuditRequest `thrift:"req,1" frugal:"1,default,VerifyAuditRequest" json:"req"`
+}
+
+func NewTicketServiceVerifyAuditArgs() *TicketServiceVerifyAuditArgs {
+	return &TicketServiceVerifyAuditArgs{}
+}
+
+func (p *TicketServiceVerifyAuditArgs) InitDefault() {
+}
+
+var TicketServiceVerifyAuditArgs_Req_DEFAULT *VerifyAuditRequest
+
+func (p *TicketServiceVerifyAuditArgs) GetReq() (v *VerifyAuditRequest) {
+	if !p.IsSetReq() {
+		return TicketServiceVerifyAuditArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *TicketServiceVerifyAuditArgs) SetReq(val *VerifyAuditRequest) {
+	p.Req = val
+}
+
+func (p *TicketServiceVerifyAuditArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *TicketServiceVerifyAuditArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("TicketServiceVerifyAuditArgs(%+v)", *p)
+}
+
+var fieldIDToName_TicketServiceVerifyAuditArgs = map[int16]string{
+	1: "req",
+}
+
+type TicketServiceVerifyAuditResult struct {
+	Success *VerifyAuditResponse `thrift:"success,0,optional" frugal:"0,optional,VerifyAuditResponse" json:"success,omitempty"`
+	Err     *common.ServiceError `thrift:"err,1,optional" frugal:"1,optional,common.ServiceError" json:"err,omitempty"`
+}
+
+func NewTicketServiceVerifyAuditResult() *TicketServiceVerifyAuditResult {
+	return &TicketServiceVerifyAuditResult{}
+}
+
+func (p *TicketServiceVerifyAuditResult) InitDefault() {
+}
+
+var TicketServiceVerifyAuditResult_Success_DEFAULT *VerifyAuditResponse
+
+func (p *TicketServiceVerifyAuditResult) GetSuccess() (v *VerifyAuditResponse) {
+	if !p.IsSetSuccess() {
+		return TicketServiceVerifyAuditResult_Success_DEFAULT
+	}
+	return p.Success
+}
+
+var TicketServiceVerifyAuditResult_Err_DEFAULT *common.ServiceError
+
+func (p *TicketServiceVerifyAuditResult) GetErr() (v *common.ServiceError) {
+	if !p.IsSetErr() {
+		return TicketServiceVerifyAuditResult_Err_DEFAULT
+	}
+	return p.Err
+}
+func (p *TicketServiceVerifyAuditResult) SetSuccess(x interface{}) {
+	p.Success = x.(*VerifyAuditResponse)
+}
+func (p *TicketServiceVerifyAuditResult) SetErr(val *common.ServiceError) {
+	p.Err = val
+}
+
+func (p *TicketServiceVerifyAuditResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *TicketServiceVerifyAuditResult) IsSetErr() bool {
+	return p.Err != nil
+}
+
+func (p *TicketServiceVerifyAuditResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("TicketServiceVerifyAuditResult(%+v)", *p)
+}
+
+var fieldIDToName_TicketServiceVerifyAuditResult = map[int16]string{
+	0: "success",
+	1: "err",
+}
+
+type TicketServiceExportAuditHeadArgs struct {
+	Req *ExportAuditHeadRequest `thrift:"req,1" frugal:"1,default,ExportAuditHeadRequest" json:"req"`
+}
+
+func NewTicketServiceExportAuditHeadArgs() *TicketServiceExportAuditHeadArgs {
+	return &TicketServiceExportAuditHeadArgs{}
+}
+
+func (p *TicketServiceExportAuditHeadArgs) InitDefault() {
+}
+
+var TicketServiceExportAuditHeadArgs_Req_DEFAULT *ExportAuditHeadRequest
+
+func (p *TicketServiceExportAuditHeadArgs) GetReq() (v *ExportAuditHeadRequest) {
+	if !p.IsSetReq() {
+		return TicketServiceExportAuditHeadArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *TicketServiceExportAuditHeadArgs) SetReq(val *ExportAuditHeadRequest) {
+	p.Req = val
+}
+
+func (p *TicketServiceExportAuditHeadArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *TicketServiceExportAuditHeadArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("TicketServiceExportAuditHeadArgs(%+v)", *p)
+}
+
+var fieldIDToName_TicketServiceExportAuditHeadArgs = map[int16]string{
+	1: "req",
+}
+
+type TicketServiceExportAuditHeadResult struct {
+	Success *AuditHead           `thrift:"success,0,optional" frugal:"0,optional,AuditHead" json:"success,omitempty"`
+	Err     *common.ServiceError `thrift:"err,1,optional" frugal:"1,optional,common.ServiceError" json:"err,omitempty"`
+}
+
+func NewTicketServiceExportAuditHeadResult() *TicketServiceExportAuditHeadResult {
+	return &TicketServiceExportAuditHeadResult{}
+}
+
+func (p *TicketServiceExportAuditHeadResult) InitDefault() {
+}
+
+var TicketServiceExportAuditHeadResult_Success_DEFAULT *AuditHead
+
+func (p *TicketServiceExportAuditHeadResult) GetSuccess() (v *AuditHead) {
+	if !p.IsSetSuccess() {
+		return TicketServiceExportAuditHeadResult_Success_DEFAULT
+	}
+	return p.Success
+}
+
+var TicketServiceExportAuditHeadResult_Err_DEFAULT *common.ServiceError
+
+func (p *TicketServiceExportAuditHeadResult) GetErr() (v *common.ServiceError) {
+	if !p.IsSetErr() {
+		return TicketServiceExportAuditHeadResult_Err_DEFAULT
+	}
+	return p.Err
+}
+func (p *TicketServiceExportAuditHeadResult) SetSuccess(x interface{}) {
+	p.Success = x.(*AuditHead)
+}
+func (p *TicketServiceExportAuditHeadResult) SetErr(val *common.ServiceError) {
+	p.Err = val
+}
+
+func (p *TicketServiceExportAuditHeadResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *TicketServiceExportAuditHeadResult) IsSetErr() bool {
+	return p.Err != nil
+}
+
+func (p *TicketServiceExportAuditHeadResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("TicketServiceExportAuditHeadResult(%+v)", *p)
+}
+
+var fieldIDToName_TicketServiceExportAuditHeadResult = map[int16]string{
+	0: "success",
+	1: "err",
+}
+
+type TicketServiceSubmitSurveyArgs struct {
+	Req *SubmitSurveyRequest `thrift:"req,1" frugal:"1,default,SubmitSurveyRequest" json:"req"`
+}
+
+func NewTicketServiceSubmitSurveyArgs() *TicketServiceSubmitSurveyArgs {
+	return &TicketServiceSubmitSurveyArgs{}
+}
+
+func (p *TicketServiceSubmitSurveyArgs) InitDefault() {
+}
+
+var TicketServiceSubmitSurveyArgs_Req_DEFAULT *SubmitSurveyRequest
+
+func (p *TicketServiceSubmitSurveyArgs) GetReq() (v *SubmitSurveyRequest) {
+	if !p.IsSetReq() {
+		return TicketServiceSubmitSurveyArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *TicketServiceSubmitSurveyArgs) SetReq(val *SubmitSurveyRequest) {
+	p.Req = val
+}
+
+func (p *TicketServiceSubmitSurveyArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *TicketServiceSubmitSurveyArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("TicketServiceSubmitSurveyArgs(%+v)", *p)
+}
+
+var fieldIDToName_TicketServiceSubmitSurveyArgs = map[int16]string{
+	1: "req",
+}
+
+type TicketServiceSubmitSurveyResult struct {
+	Success *SubmitSurveyResponse `thrift:"success,0,optional" frugal:"0,optional,SubmitSurveyResponse" json:"success,omitempty"`
+	Err     *common.ServiceError  `thrift:"err,1,optional" frugal:"1,optional,common.ServiceError" json:"err,omitempty"`
+}
+
+func NewTicketServiceSubmitSurveyResult() *TicketServiceSubmitSurveyResult {
+	return &TicketServiceSubmitSurveyResult{}
+}
+
+func (p *TicketServiceSubmitSurveyResult) InitDefault() {
+}
+
+var TicketServiceSubmitSurveyResult_Success_DEFAULT *SubmitSurveyResponse
+
+func (p *TicketServiceSubmitSurveyResult) GetSuccess() (v *SubmitSurveyResponse) {
+	if !p.IsSetSuccess() {
+		return TicketServiceSubmitSurveyResult_Success_DEFAULT
+	}
+	return p.Success
+}
+
+var TicketServiceSubmitSurveyResult_Err_DEFAULT *common.ServiceError
+
+func (p *TicketServiceSubmitSurveyResult) GetErr() (v *common.ServiceError) {
+	if !p.IsSetErr() {
+		return TicketServiceSubmitSurveyResult_Err_DEFAULT
+	}
+	return p.Err
+}
+func (p *TicketServiceSubmitSurveyResult) SetSuccess(x interface{}) {
+	p.Success = x.(*SubmitSurveyResponse)
+}
+func (p *TicketServiceSubmitSurveyResult) SetErr(val *common.ServiceError) {
+	p.Err = val
+}
+
+func (p *TicketServiceSubmitSurveyResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *TicketServiceSubmitSurveyResult) IsSetErr() bool {
+	return p.Err != nil
+}
+
+func (p *TicketServiceSubmitSurveyResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("TicketServiceSubmitSurveyResult(%+v)", *p)
+}
+
+var fieldIDToName_TicketServiceSubmitSurveyResult = map[int16]string{
+	0: "success",
+	1: "err",
+}
+
+type TicketServiceCSATReportArgs struct {
+	Req *CSATReportRequest `thrift:"req,1" frugal:"1,default,CSATReportRequest" json:"req"`
+}
+
+func NewTicketServiceCSATReportArgs() *TicketServiceCSATReportArgs {
+	return &TicketServiceCSATReportArgs{}
+}
+
+func (p *TicketServiceCSATReportArgs) InitDefault() {
+}
+
+var TicketServiceCSATReportArgs_Req_DEFAULT *CSATReportRequest
+
+func (p *TicketServiceCSATReportArgs) GetReq() (v *CSATReportRequest) {
+	if !p.IsSetReq() {
+		return TicketServiceCSATReportArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *TicketServiceCSATReportArgs) SetReq(val *CSATReportRequest) {
+	p.Req = val
+}
+
+func (p *TicketServiceCSATReportArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *TicketServiceCSATReportArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("TicketServiceCSATReportArgs(%+v)", *p)
+}
+
+var fieldIDToName_TicketServiceCSATReportArgs = map[int16]string{
+	1: "req",
+}
+
+type TicketServiceCSATReportResult struct {
+	Success *CSATReportResponse  `thrift:"success,0,optional" frugal:"0,optional,CSATReportResponse" json:"success,omitempty"`
+	Err     *common.ServiceError `thrift:"err,1,optional" frugal:"1,optional,common.ServiceError" json:"err,omitempty"`
+}
+
+func NewTicketServiceCSATReportResult() *TicketServiceCSATReportResult {
+	return &TicketServiceCSATReportResult{}
+}
+
+func (p *TicketServiceCSATReportResult) InitDefault() {
+}
+
+var TicketServiceCSATReportResult_Success_DEFAULT *CSATReportResponse
+
+func (p *TicketServiceCSATReportResult) GetSuccess() (v *CSATReportResponse) {
+	if !p.IsSetSuccess() {
+		return TicketServiceCSATReportResult_Success_DEFAULT
+	}
+	return p.Success
+}
+
+var TicketServiceCSATReportResult_Err_DEFAULT *common.ServiceError
+
+func (p *TicketServiceCSATReportResult) GetErr() (v *common.ServiceError) {
+	if !p.IsSetErr() {
+		return TicketServiceCSATReportResult_Err_DEFAULT
+	}
+	return p.Err
+}
+func (p *TicketServiceCSATReportResult) SetSuccess(x interface{}) {
+	p.Success = x.(*CSATReportResponse)
+}
+func (p *TicketServiceCSATReportResult) SetErr(val *common.ServiceError) {
+	p.Err = val
+}
+
+func (p *TicketServiceCSATReportResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *TicketServiceCSATReportResult) IsSetErr() bool {
+	return p.Err != nil
+}
+
+func (p *TicketServiceCSATReportResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("TicketServiceCSATReportResult(%+v)", *p)
+}
+
+var fieldIDToName_TicketServiceCSATReportResult = map[int16]string{
+	0: "success",
+	1: "err",
+}
+
+type TicketServiceEscalationReportArgs struct {
+	Req *EscalationReportRequest `thrift:"req,1" frugal:"1,default,EscalationReportRequest" json:"req"`
+}
+
+func NewTicketServiceEscalationReportArgs() *TicketServiceEscalationReportArgs {
+	return &TicketServiceEscalationReportArgs{}
+}
+
+func (p *TicketServiceEscalationReportArgs) InitDefault() {
+}
+
+var TicketServiceEscalationReportArgs_Req_DEFAULT *EscalationReportRequest
+
+func (p *TicketServiceEscalationReportArgs) GetReq() (v *EscalationReportRequest) {
+	if !p.IsSetReq() {
+		return TicketServiceEscalationReportArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *TicketServiceEscalationReportArgs) SetReq(val *EscalationReportRequest) {
+	p.Req = val
+}
+
+func (p *TicketServiceEscalationReportArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *TicketServiceEscalationReportArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("TicketServiceEscalationReportArgs(%+v)", *p)
+}
+
+var fieldIDToName_TicketServiceEscalationReportArgs = map[int16]string{
+	1: "req",
+}
+
+type TicketServiceEscalationReportResult struct {
+	Success *EscalationReportResponse `thrift:"success,0,optional" frugal:"0,optional,EscalationReportResponse" json:"success,omitempty"`
+	Err     *common.ServiceError      `thrift:"err,1,optional" frugal:"1,optional,common.ServiceError" json:"err,omitempty"`
+}
+
+func NewTicketServiceEscalationReportResult() *TicketServiceEscalationReportResult {
+	return &TicketServiceEscalationReportResult{}
+}
+
+func (p *TicketServiceEscalationReportResult) InitDefault() {
+}
+
+var TicketServiceEscalationReportResult_Success_DEFAULT *EscalationReportResponse
+
+func (p *TicketServiceEscalationReportResult) GetSuccess() (v *EscalationReportResponse) {
+	if !p.IsSetSuccess() {
+		return TicketServiceEscalationReportResult_Success_DEFAULT
+	}
+	return p.Success
+}
+
+var TicketServiceEscalationReportResult_Err_DEFAULT *common.ServiceError
+
+func (p *TicketServiceEscalationReportResult) GetErr() (v *common.ServiceError) {
+	if !p.IsSetErr() {
+		return TicketServiceEscalationReportResult_Err_DEFAULT
+	}
+	return p.Err
+}
+func (p *TicketServiceEscalationReportResult) SetSuccess(x interface{}) {
+	p.Success = x.(*EscalationReportResponse)
+}
+func (p *TicketServiceEscalationReportResult) SetErr(val *common.ServiceError) {
+	p.Err = val
+}
+
+func (p *TicketServiceEscalationReportResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *TicketServiceEscalationReportResult) IsSetErr() bool {
+	return p.Err != nil
+}
+
+func (p *TicketServiceEscalationReportResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("TicketServiceEscalationReportResult(%+v)", *p)
+}
+
+var fieldIDToName_TicketServiceEscalationReportResult = map[int16]string{
+	0: "success",
+	1: "err",
+}
+
+type TicketServiceCreateViewArgs struct {
+	Req *SaveViewRequest `thrift:"req,1" frugal:"1,default,SaveViewRequest" json:"req"`
+}
+
+func NewTicketServiceCreateViewArgs() *TicketServiceCreateViewArgs {
+	return &TicketServiceCreateViewArgs{}
+}
+
+func (p *TicketServiceCreateViewArgs) InitDefault() {
+}
+
+var TicketServiceCreateViewArgs_Req_DEFAULT *SaveViewRequest
+
+func (p *TicketServiceCreateViewArgs) GetReq() (v *SaveViewRequest) {
+	if !p.IsSetReq() {
+		return TicketServiceCreateViewArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *TicketServiceCreateViewArgs) SetReq(val *SaveViewRequest) {
+	p.Req = val
+}
+
+func (p *TicketServiceCreateViewArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *TicketServiceCreateViewArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("TicketServiceCreateViewArgs(%+v)", *p)
+}
+
+var fieldIDToName_TicketServiceCreateViewArgs = map[int16]string{
+	1: "req",
+}
+
+type TicketServiceCreateViewResult struct {
+	Success *SavedView           `thrift:"success,0,optional" frugal:"0,optional,SavedView" json:"success,omitempty"`
+	Err     *common.ServiceError `thrift:"err,1,optional" frugal:"1,optional,common.ServiceError" json:"err,omitempty"`
+}
+
+func NewTicketServiceCreateViewResult() *TicketServiceCreateViewResult {
+	return &TicketServiceCreateViewResult{}
+}
+
+func (p *TicketServiceCreateViewResult) InitDefault() {
+}
+
+var TicketServiceCreateViewResult_Success_DEFAULT *SavedView
+
+func (p *TicketServiceCreateViewResult) GetSuccess() (v *SavedView) {
+	if !p.IsSetSuccess() {
+		return TicketServiceCreateViewResult_Success_DEFAULT
+	}
+	return p.Success
+}
+
+var TicketServiceCreateViewResult_Err_DEFAULT *common.ServiceError
+
+func (p *TicketServiceCreateViewResult) GetErr() (v *common.ServiceError) {
+	if !p.IsSetErr() {
+		return TicketServiceCreateViewResult_Err_DEFAULT
+	}
+	return p.Err
+}
+func (p *TicketServiceCreateViewResult) SetSuccess(x interface{}) {
+	p.Success = x.(*SavedView)
+}
+func (p *TicketServiceCreateViewResult) SetErr(val *common.ServiceError) {
+	p.Err = val
+}
+
+func (p *TicketServiceCreateViewResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *TicketServiceCreateViewResult) IsSetErr() bool {
+	return p.Err != nil
+}
+
+func (p *TicketServiceCreateViewResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("TicketServiceCreateViewResult(%+v)", *p)
+}
+
+var fieldIDToName_TicketServiceCreateViewResult = map[int16]string{
+	0: "success",
+	1: "err",
+}
+
+type TicketServiceUpdateViewArgs struct {
+	Req *SaveViewRequest `thrift:"req,1" frugal:"1,default,SaveViewRequest" json:"req"`
+}
+
+func NewTicketServiceUpdateViewArgs() *TicketServiceUpdateViewArgs {
+	return &TicketServiceUpdateViewArgs{}
+}
+
+func (p *TicketServiceUpdateViewArgs) InitDefault() {
+}
+
+var TicketServiceUpdateViewArgs_Req_DEFAULT *SaveViewRequest
+
+func (p *TicketServiceUpdateViewArgs) GetReq() (v *SaveViewRequest) {
+	if !p.IsSetReq() {
+		return TicketServiceUpdateViewArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *TicketServiceUpdateViewArgs) SetReq(val *SaveViewRequest) {
+	p.Req = val
+}
+
+func (p *TicketServiceUpdateViewArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *TicketServiceUpdateViewArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("TicketServiceUpdateViewArgs(%+v)", *p)
+}
+
+var fieldIDToName_TicketServiceUpdateViewArgs = map[int16]string{
+	1: "req",
+}
+
+type TicketServiceUpdateViewResult struct {
+	Success *SavedView           `thrift:"success,0,optional" frugal:"0,optional,SavedView" json:"success,omitempty"`
+	Err     *common.ServiceError `thrift:"err,1,optional" frugal:"1,optional,common.ServiceError" json:"err,omitempty"`
+}
+
+func NewTicketServiceUpdateViewResult() *TicketServiceUpdateViewResult {
+	return &TicketServiceUpdateViewResult{}
+}
+
+func (p *TicketServiceUpdateViewResult) InitDefault() {
+}
+
+var TicketServiceUpdateViewResult_Success_DEFAULT *SavedView
+
+func (p *TicketServiceUpdateViewResult) GetSuccess() (v *SavedView) {
+	if !p.IsSetSuccess() {
+		return TicketServiceUpdateViewResult_Success_DEFAULT
+	}
+	return p.Success
+}
+
+var TicketServiceUpdateViewResult_Err_DEFAULT *common.ServiceError
+
+func (p *TicketServiceUpdateViewResult) GetErr() (v *common.ServiceError) {
+	if !p.IsSetErr() {
+		return TicketServiceUpdateViewResult_Err_DEFAULT
+	}
+	return p.Err
+}
+func (p *TicketServiceUpdateViewResult) SetSuccess(x interface{}) {
+	p.Success = x.(*SavedView)
+}
+func (p *TicketServiceUpdateViewResult) SetErr(val *common.ServiceError) {
+	p.Err = val
+}
+
+func (p *TicketServiceUpdateViewResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *TicketServiceUpdateViewResult) IsSetErr() bool {
+	return p.Err != nil
+}
+
+func (p *TicketServiceUpdateViewResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("TicketServiceUpdateViewResult(%+v)", *p)
+}
+
+var fieldIDToName_TicketServiceUpdateViewResult = map[int16]string{
+	0: "success",
+	1: "err",
+}
+
+type TicketServiceGetViewArgs struct {
+	Req *ViewRequest `thrift:"req,1" frugal:"1,default,ViewRequest" json:"req"`
+}
+
+func NewTicketServiceGetViewArgs() *TicketServiceGetViewArgs {
+	return &TicketServiceGetViewArgs{}
+}
+
+func (p *TicketServiceGetViewArgs) InitDefault() {
+}
+
+var TicketServiceGetViewArgs_Req_DEFAULT *ViewRequest
+
+func (p *TicketServiceGetViewArgs) GetReq() (v *ViewRequest) {
+	if !p.IsSetReq() {
+		return TicketServiceGetViewArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *TicketServiceGetViewArgs) SetReq(val *ViewRequest) {
+	p.Req = val
+}
+
+func (p *TicketServiceGetViewArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *TicketServiceGetViewArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("TicketServiceGetViewArgs(%+v)", *p)
+}
+
+var fieldIDToName_TicketServiceGetViewArgs = map[int16]string{
+	1: "req",
+}
+
+type TicketServiceGetViewResult struct {
+	Success *SavedView           `thrift:"success,0,optional" frugal:"0,optional,SavedView" json:"success,omitempty"`
+	Err     *common.ServiceError `thrift:"err,1,optional" frugal:"1,optional,common.ServiceError" json:"err,omitempty"`
+}
+
+func NewTicketServiceGetViewResult() *TicketServiceGetViewResult {
+	return &TicketServiceGetViewResult{}
+}
+
+func (p *TicketServiceGetViewResult) InitDefault() {
+}
+
+var TicketServiceGetViewResult_Success_DEFAULT *SavedView
+
+func (p *TicketServiceGetViewResult) GetSuccess() (v *SavedView) {
+	if !p.IsSetSuccess() {
+		return TicketServiceGetViewResult_Success_DEFAULT
+	}
+	return p.Success
+}
+
+var TicketServiceGetViewResult_Err_DEFAULT *common.ServiceError
+
+func (p *TicketServiceGetViewResult) GetErr() (v *common.ServiceError) {
+	if !p.IsSetErr() {
+		return TicketServiceGetViewResult_Err_DEFAULT
+	}
+	return p.Err
+}
+func (p *TicketServiceGetViewResult) SetSuccess(x interface{}) {
+	p.Success = x.(*SavedView)
+}
+func (p *TicketServiceGetViewResult) SetErr(val *common.ServiceError) {
+	p.Err = val
+}
+
+func (p *TicketServiceGetViewResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *TicketServiceGetViewResult) IsSetErr() bool {
+	return p.Err != nil
+}
+
+func (p *TicketServiceGetViewResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("TicketServiceGetViewResult(%+v)", *p)
+}
+
+var fieldIDToName_TicketServiceGetViewResult = map[int16]string{
+	0: "success",
+	1: "err",
+}
+
+type TicketServiceDeleteViewArgs struct {
+	Req *ViewRequest `thrift:"req,1" frugal:"1,default,ViewRequest" json:"req"`
+}
+
+func NewTicketServiceDeleteViewArgs() *TicketServiceDeleteViewArgs {
+	return &TicketServiceDeleteViewArgs{}
+}
+
+func (p *TicketServiceDeleteViewArgs) InitDefault() {
+}
+
+var TicketServiceDeleteViewArgs_Req_DEFAULT *ViewRequest
+
+func (p *TicketServiceDeleteViewArgs) GetReq() (v *ViewRequest) {
+	if !p.IsSetReq() {
+		return TicketServiceDeleteViewArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *TicketServiceDeleteViewArgs) SetReq(val *ViewRequest) {
+	p.Req = val
+}
+
+func (p *TicketServiceDeleteViewArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *TicketServiceDeleteViewArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("TicketServiceDeleteViewArgs(%+v)", *p)
+}
+
+var fieldIDToName_TicketServiceDeleteViewArgs = map[int16]string{
+	1: "req",
+}
+
+type TicketServiceDeleteViewResult struct {
+	Success *DeleteViewResponse  `thrift:"success,0,optional" frugal:"0,optional,DeleteViewResponse" json:"success,omitempty"`
+	Err     *common.ServiceError `thrift:"err,1,optional" frugal:"1,optional,common.ServiceError" json:"err,omitempty"`
+}
+
+func NewTicketServiceDeleteViewResult() *TicketServiceDeleteViewResult {
+	return &TicketServiceDeleteViewResult{}
+}
+
+func (p *TicketServiceDeleteViewResult) InitDefault() {
+}
+
+var TicketServiceDeleteViewResult_Success_DEFAULT *DeleteViewResponse
+
+func (p *TicketServiceDeleteViewResult) GetSuccess() (v *DeleteViewResponse) {
+	if !p.IsSetSuccess() {
+		return TicketServiceDeleteViewResult_Success_DEFAULT
+	}
+	return p.Success
+}
+
+var TicketServiceDeleteViewResult_Err_DEFAULT *common.ServiceError
+
+func (p *TicketServiceDeleteViewResult) GetErr() (v *common.ServiceError) {
+	if !p.IsSetErr() {
+		return TicketServiceDeleteViewResult_Err_DEFAULT
+	}
+	return p.Err
+}
+func (p *TicketServiceDeleteViewResult) SetSuccess(x interface{}) {
+	p.Success = x.(*DeleteViewResponse)
+}
+func (p *TicketServiceDeleteViewResult) SetErr(val *common.ServiceError) {
+	p.Err = val
+}
+
+func (p *TicketServiceDeleteViewResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *TicketServiceDeleteViewResult) IsSetErr() bool {
+	return p.Err != nil
+}
+
+func (p *TicketServiceDeleteViewResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("TicketServiceDeleteViewResult(%+v)", *p)
+}
+
+var fieldIDToName_TicketServiceDeleteViewResult = map[int16]string{
+	0: "success",
+	1: "err",
+}
+
+type TicketServiceListViewsArgs struct {
+	Req *ListViewsRequest `thrift:"req,1" frugal:"1,default,ListViewsRequest" json:"req"`
+}
+
+func NewTicketServiceListViewsArgs() *TicketServiceListViewsArgs {
+	return &TicketServiceListViewsArgs{}
+}
+
+func (p *TicketServiceListViewsArgs) InitDefault() {
+}
+
+var TicketServiceListViewsArgs_Req_DEFAULT *ListViewsRequest
+
+func (p *TicketServiceListViewsArgs) GetReq() (v *ListViewsRequest) {
+	if !p.IsSetReq() {
+		return TicketServiceListViewsArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *TicketServiceListViewsArgs) SetReq(val *ListViewsRequest) {
+	p.Req = val
+}
+
+func (p *TicketServiceListViewsArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *TicketServiceListViewsArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("TicketServiceListViewsArgs(%+v)", *p)
+}
+
+var fieldIDToName_TicketServiceListViewsArgs = map[int16]string{
+	1: "req",
+}
+
+type TicketServiceListViewsResult struct {
+	Success *ListViewsResponse   `thrift:"success,0,optional" frugal:"0,optional,ListViewsResponse" json:"success,omitempty"`
+	Err     *common.ServiceError `thrift:"err,1,optional" frugal:"1,optional,common.ServiceError" json:"err,omitempty"`
+}
+
+func NewTicketServiceListViewsResult() *TicketServiceListViewsResult {
+	return &TicketServiceListViewsResult{}
+}
+
+func (p *TicketServiceListViewsResult) InitDefault() {
+}
+
+var TicketServiceListViewsResult_Success_DEFAULT *ListViewsResponse
+
+func (p *TicketServiceListViewsResult) GetSuccess() (v *ListViewsResponse) {
+	if !p.IsSetSuccess() {
+		return TicketServiceListViewsResult_Success_DEFAULT
+	}
+	return p.Success
+}
+
+var TicketServiceListViewsResult_Err_DEFAULT *common.ServiceError
+
+func (p *TicketServiceListViewsResult) GetErr() (v *common.ServiceError) {
+	if !p.IsSetErr() {
+		return TicketServiceListViewsResult_Err_DEFAULT
+	}
+	return p.Err
+}
+func (p *TicketServiceListViewsResult) SetSuccess(x interface{}) {
+	p.Success = x.(*ListViewsResponse)
+}
+func (p *TicketServiceListViewsResult) SetErr(val *common.ServiceError) {
+	p.Err = val
+}
+
+func (p *TicketServiceListViewsResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *TicketServiceListViewsResult) IsSetErr() bool {
+	return p.Err != nil
+}
+
+func (p *TicketServiceListViewsResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("TicketServiceListViewsResult(%+v)", *p)
+}
+
+var fieldIDToName_TicketServiceListViewsResult = map[int16]string{
+	0: "success",
+	1: "err",
+}
+
+type TicketServiceViewTicketsArgs struct {
+	Req *ViewTicketsRequest `thrift:"req,1" frugal:"1,default,ViewTicketsRequest" json:"req"`
+}
+
+func NewTicketServiceViewTicketsArgs() *TicketServiceViewTicketsArgs {
+	return &TicketServiceViewTicketsArgs{}
+}
+
+func (p *TicketServiceViewTicketsArgs) InitDefault() {
+}
+
+var TicketServiceViewTicketsArgs_Req_DEFAULT *ViewTicketsRequest
+
+func (p *TicketServiceViewTicketsArgs) GetReq() (v *ViewTicketsRequest) {
+	if !p.IsSetReq() {
+		return TicketServiceViewTicketsArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *TicketServiceViewTicketsArgs) SetReq(val *ViewTicketsRequest) {
+	p.Req = val
+}
+
+func (p *TicketServiceViewTicketsArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *TicketServiceViewTicketsArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("TicketServiceViewTicketsArgs(%+v)", *p)
+}
+
+var fieldIDToName_TicketServiceViewTicketsArgs = map[int16]string{
+	1: "req",
+}
+
+type TicketServiceViewTicketsResult struct {
+	Success *ListTicketsResponse `thrift:"success,0,optional" frugal:"0,optional,ListTicketsResponse" json:"success,omitempty"`
+	Err     *common.ServiceError `thrift:"err,1,optional" frugal:"1,optional,common.ServiceError" json:"err,omitempty"`
+}
+
+func NewTicketServiceViewTicketsResult() *TicketServiceViewTicketsResult {
+	return &TicketServiceViewTicketsResult{}
+}
+
+func (p *TicketServiceViewTicketsResult) InitDefault() {
+}
+
+var TicketServiceViewTicketsResult_Success_DEFAULT *ListTicketsResponse
+
+func (p *TicketServiceViewTicketsResult) GetSuccess() (v *ListTicketsResponse) {
+	if !p.IsSetSuccess() {
+		return TicketServiceViewTicketsResult_Success_DEFAULT
+	}
+	return p.Success
+}
+
+var TicketServiceViewTicketsResult_Err_DEFAULT *common.ServiceError
+
+func (p *TicketServiceViewTicketsResult) GetErr() (v *common.ServiceError) {
+	if !p.IsSetErr() {
+		return TicketServiceViewTicketsResult_Err_DEFAULT
+	}
+	return p.Err
+}
+func (p *TicketServiceViewTicketsResult) SetSuccess(x interface{}) {
+	p.Success = x.(*ListTicketsResponse)
+}
+func (p *TicketServiceViewTicketsResult) SetErr(val *common.ServiceError) {
+	p.Err = val
+}
+
+func (p *TicketServiceViewTicketsResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *TicketServiceViewTicketsResult) IsSetErr() bool {
+	return p.Err != nil
+}
+
+func (p *TicketServiceViewTicketsResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("TicketServiceViewTicketsResult(%+v)", *p)
+}
+
+var fieldIDToName_TicketServiceViewTicketsResult = map[int16]string{
+	0: "success",
+	1: "err",
+}
+
+type TicketServicePullNextArgs struct {
+	Req *PullNextRequest `thrift:"req,1" frugal:"1,default,PullNextRequest" json:"req"`
+}
+
+func NewTicketServicePullNextArgs() *TicketServicePullNextArgs {
+	return &TicketServicePullNextArgs{}
+}
+
+func (p *TicketServicePullNextArgs) InitDefault() {
+}
+
+var TicketServicePullNextArgs_Req_DEFAULT *PullNextRequest
+
+func (p *TicketServicePullNextArgs) GetReq() (v *PullNextRequest) {
+	if !p.IsSetReq() {
+		return TicketServicePullNextArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *TicketServicePullNextArgs) SetReq(val *PullNextRequest) {
+	p.Req = val
+}
+
+func (p *TicketServicePullNextArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *TicketServicePullNextArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("TicketServicePullNextArgs(%+v)", *p)
+}
+
+var fieldIDToName_TicketServicePullNextArgs = map[int16]string{
+	1: "req",
+}
+
+type TicketServicePullNextResult struct {
+	Success *PullNextResponse    `thrift:"success,0,optional" frugal:"0,optional,PullNextResponse" json:"success,omitempty"`
+	Err     *common.ServiceError `thrift:"err,1,optional" frugal:"1,optional,common.ServiceError" json:"err,omitempty"`
+}
+
+func NewTicketServicePullNextResult() *TicketServicePullNextResult {
+	return &TicketServicePullNextResult{}
+}
+
+func (p *TicketServicePullNextResult) InitDefault() {
+}
+
+var TicketServicePullNextResult_Success_DEFAULT *PullNextResponse
+
+func (p *TicketServicePullNextResult) GetSuccess() (v *PullNextResponse) {
+	if !p.IsSetSuccess() {
+		return TicketServicePullNextResult_Success_DEFAULT
+	}
+	return p.Success
+}
+
+var TicketServicePullNextResult_Err_DEFAULT *common.ServiceError
+
+func (p *TicketServicePullNextResult) GetErr() (v *common.ServiceError) {
+	if !p.IsSetErr() {
+		return TicketServicePullNextResult_Err_DEFAULT
+	}
+	return p.Err
+}
+func (p *TicketServicePullNextResult) SetSuccess(x interface{}) {
+	p.Success = x.(*PullNextResponse)
+}
+func (p *TicketServicePullNextResult) SetErr(val *common.ServiceError) {
+	p.Err = val
+}
+
+func (p *TicketServicePullNextResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *TicketServicePullNextResult) IsSetErr() bool {
+	return p.Err != nil
+}
+
+func (p *TicketServicePullNextResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("TicketServicePullNextResult(%+v)", *p)
+}
+
+var fieldIDToName_TicketServicePullNextResult = map[int16]string{
+	0: "success",
+	1: "err",
+}
+
+type TicketServiceAcquireLockArgs struct {
+	Req *LockRequest `thrift:"req,1" frugal:"1,default,LockRequest" json:"req"`
+}
+
+func NewTicketServiceAcquireLockArgs() *TicketServiceAcquireLockArgs {
+	return &TicketServiceAcquireLockArgs{}
+}
+
+func (p *TicketServiceAcquireLockArgs) InitDefault() {
+}
+
+var TicketServiceAcquireLockArgs_Req_DEFAULT *LockRequest
+
+func (p *TicketServiceAcquireLockArgs) GetReq() (v *LockRequest) {
+	if !p.IsSetReq() {
+		return TicketServiceAcquireLockArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *TicketServiceAcquireLockArgs) SetReq(val *LockRequest) {
+	p.Req = val
+}
+
+func (p *TicketServiceAcquireLockArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *TicketServiceAcquireLockArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("TicketServiceAcquireLockArgs(%+v)", *p)
+}
+
+var fieldIDToName_TicketServiceAcquireLockArgs = map[int16]string{
+	1: "req",
+}
+
+type TicketServiceAcquireLockResult struct {
+	Success *LockResponse        `thrift:"success,0,optional" frugal:"0,optional,LockResponse" json:"success,omitempty"`
+	Err     *common.ServiceError `thrift:"err,1,optional" frugal:"1,optional,common.ServiceError" json:"err,omitempty"`
+}
+
+func NewTicketServiceAcquireLockResult() *TicketServiceAcquireLockResult {
+	return &TicketServiceAcquireLockResult{}
+}
+
+func (p *TicketServiceAcquireLockResult) InitDefault() {
+}
+
+var TicketServiceAcquireLockResult_Success_DEFAULT *LockResponse
+
+func (p *TicketServiceAcquireLockResult) GetSuccess() (v *LockResponse) {
+	if !p.IsSetSuccess() {
+		return TicketServiceAcquireLockResult_Success_DEFAULT
+	}
+	return p.Success
+}
+
+var TicketServiceAcquireLockResult_Err_DEFAULT *common.ServiceError
+
+func (p *TicketServiceAcquireLockResult) GetErr() (v *common.ServiceError) {
+	if !p.IsSetErr() {
+		return TicketServiceAcquireLockResult_Err_DEFAULT
+	}
+	return p.Err
+}
+func (p *TicketServiceAcquireLockResult) SetSuccess(x interface{}) {
+	p.Success = x.(*LockResponse)
+}
+func (p *TicketServiceAcquireLockResult) SetErr(val *common.ServiceError) {
+	p.Err = val
+}
+
+func (p *TicketServiceAcquireLockResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *TicketServiceAcquireLockResult) IsSetErr() bool {
+	return p.Err != nil
+}
+
+func (p *TicketServiceAcquireLockResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("TicketServiceAcquireLockResult(%+v)", *p)
+}
+
+var fieldIDToName_TicketServiceAcquireLockResult = map[int16]string{
+	0: "success",
+	1: "err",
+}
+
+type TicketServiceReleaseLockArgs struct {
+	Req *LockRequest `thrift:"req,1" frugal:"1,default,LockRequest" json:"req"`
+}
+
+func NewTicketServiceReleaseLockArgs() *TicketServiceReleaseLockArgs {
+	return &TicketServiceReleaseLockArgs{}
+}
+
+func (p *TicketServiceReleaseLockArgs) InitDefault() {
+}
+
+var TicketServiceReleaseLockArgs_Req_DEFAULT *LockRequest
+
+func (p *TicketServiceReleaseLockArgs) GetReq() (v *LockRequest) {
+	if !p.IsSetReq() {
+		return TicketServiceReleaseLockArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *TicketServiceReleaseLockArgs) SetReq(val *LockRequest) {
+	p.Req = val
+}
+
+func (p *TicketServiceReleaseLockArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *TicketServiceReleaseLockArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("TicketServiceReleaseLockArgs(%+v)", *p)
+}
+
+var fieldIDToName_TicketServiceReleaseLockArgs = map[int16]string{
+	1: "req",
+}
+
+type TicketServiceReleaseLockResult struct {
+	Success *LockResponse        `thrift:"success,0,optional" frugal:"0,optional,LockResponse" json:"success,omitempty"`
+	Err     *common.ServiceError `thrift:"err,1,optional" frugal:"1,optional,common.ServiceError" json:"err,omitempty"`
+}
+
+func NewTicketServiceReleaseLockResult() *TicketServiceReleaseLockResult {
+	return &TicketServiceReleaseLockResult{}
+}
+
+func (p *TicketServiceReleaseLockResult) InitDefault() {
+}
+
+var TicketServiceReleaseLockResult_Success_DEFAULT *LockResponse
+
+func (p *TicketServiceReleaseLockResult) GetSuccess() (v *LockResponse) {
+	if !p.IsSetSuccess() {
+		return TicketServiceReleaseLockResult_Success_DEFAULT
+	}
+	return p.Success
+}
+
+var TicketServiceReleaseLockResult_Err_DEFAULT *common.ServiceError
+
+func (p *TicketServiceReleaseLockResult) GetErr() (v *common.ServiceError) {
+	if !p.IsSetErr() {
+		return TicketServiceReleaseLockResult_Err_DEFAULT
+	}
+	return p.Err
+}
+func (p *TicketServiceReleaseLockResult) SetSuccess(x interface{}) {
+	p.Success = x.(*LockResponse)
+}
+func (p *TicketServiceReleaseLockResult) SetErr(val *common.ServiceError) {
+	p.Err = val
+}
+
+func (p *TicketServiceReleaseLockResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *TicketServiceReleaseLockResult) IsSetErr() bool {
+	return p.Err != nil
+}
+
+func (p *TicketServiceReleaseLockResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("TicketServiceReleaseLockResult(%+v)", *p)
+}
+
+var fieldIDToName_TicketServiceReleaseLockResult = map[int16]string{
+	0: "success",
+	1: "err",
+}
+
+type TicketServiceListLockEventsArgs struct {
+	Req *ListLockEventsRequest `thrift:"req,1" frugal:"1,default,ListLockEventsRequest" json:"req"`
+}
+
+func NewTicketServiceListLockEventsArgs() *TicketServiceListLockEventsArgs {
+	return &TicketServiceListLockEventsArgs{}
+}
+
+func (p *TicketServiceListLockEventsArgs) InitDefault() {
+}
+
+var TicketServiceListLockEventsArgs_Req_DEFAULT *ListLockEventsRequest
+
+func (p *TicketServiceListLockEventsArgs) GetReq() (v *ListLockEventsRequest) {
+	if !p.IsSetReq() {
+		return TicketServiceListLockEventsArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *TicketServiceListLockEventsArgs) SetReq(val *ListLockEventsRequest) {
+	p.Req = val
+}
+
+func (p *TicketServiceListLockEventsArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *TicketServiceListLockEventsArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("TicketServiceListLockEventsArgs(%+v)", *p)
+}
+
+var fieldIDToName_TicketServiceListLockEventsArgs = map[int16]string{
+	1: "req",
+}
+
+type TicketServiceListLockEventsResult struct {
+	Success *ListLockEventsResponse `thrift:"success,0,optional" frugal:"0,optional,ListLockEventsResponse" json:"success,omitempty"`
+	Err     *common.ServiceError    `thrift:"err,1,optional" frugal:"1,optional,common.ServiceError" json:"err,omitempty"`
+}
+
+func NewTicketServiceListLockEventsResult() *TicketServiceListLockEventsResult {
+	return &TicketServiceListLockEventsResult{}
+}
+
+func (p *TicketServiceListLockEventsResult) InitDefault() {
+}
+
+var TicketServiceListLockEventsResult_Success_DEFAULT *ListLockEventsResponse
+
+func (p *TicketServiceListLockEventsResult) GetSuccess() (v *ListLockEventsResponse) {
+	if !p.IsSetSuccess() {
+		return TicketServiceListLockEventsResult_Success_DEFAULT
+	}
+	return p.Success
+}
+
+var TicketServiceListLockEventsResult_Err_DEFAULT *common.ServiceError
+
+func (p *TicketServiceListLockEventsResult) GetErr() (v *common.ServiceError) {
+	if !p.IsSetErr() {
+		return TicketServiceListLockEventsResult_Err_DEFAULT
+	}
+	return p.Err
+}
+func (p *TicketServiceListLockEventsResult) SetSuccess(x interface{}) {
+	p.Success = x.(*ListLockEventsResponse)
+}
+func (p *TicketServiceListLockEventsResult) SetErr(val *common.ServiceError) {
+	p.Err = val
+}
+
+func (p *TicketServiceListLockEventsResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *TicketServiceListLockEventsResult) IsSetErr() bool {
+	return p.Err != nil
+}
+
+func (p *TicketServiceListLockEventsResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("TicketServiceListLockEventsResult(%+v)", *p)
+}
+
+var fieldIDToName_TicketServiceListLockEventsResult = map[int16]string{
+	0: "success",
+	1: "err",
+}
+
+type TicketServiceDeleteTicketArgs struct {
+	Req *TicketActionRequest `thrift:"req,1" frugal:"1,default,TicketActionRequest" json:"req"`
 }
 
-func NewTicketServiceGetTicketArgs() *TicketServiceGetTicketArgs {
-	return &TicketServiceGetTicketArgs{}
+func NewTicketServiceDeleteTicketArgs() *TicketServiceDeleteTicketArgs {
+	return &TicketServiceDeleteTicketArgs{}
 }
 
-func (p *TicketServiceGetTicketArgs) InitDefault() {
+func (p *TicketServiceDeleteTicketArgs) InitDefault() {
 }
 
-var TicketServiceGetTicketArgs_Req_DEFAULT *GetTicketRequest
+var TicketServiceDeleteTicketArgs_Req_DEFAULT *TicketActionRequest
 
-func (p *TicketServiceGetTicketArgs) GetReq() (v *GetTicketRequest) {
+func (p *TicketServiceDeleteTicketArgs) GetReq() (v *TicketActionRequest) {
 	if !p.IsSetReq() {
-		return TicketServiceGetTicketArgs_Req_DEFAULT
+		return TicketServiceDeleteTicketArgs_Req_DEFAULT
 	}
 	return p.Req
 }
-func (p *TicketServiceGetTicketArgs) SetReq(val *GetTicketRequest) {
+func (p *TicketServiceDeleteTicketArgs) SetReq(val *TicketActionRequest) {
 	p.Req = val
 }
 
-func (p *TicketServiceGetTicketArgs) IsSetReq() bool {
+func (p *TicketServiceDeleteTicketArgs) IsSetReq() bool {
 	return p.Req != nil
 }
 
-func (p *TicketServiceGetTicketArgs) String() string {
+func (p *TicketServiceDeleteTicketArgs) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("TicketServiceGetTicketArgs(%+v)", *p)
+	return fmt.Sprintf("TicketServiceDeleteTicketArgs(%+v)", *p)
 }
 
-var fieldIDToName_TicketServiceGetTicketArgs = map[int16]string{
+var fieldIDToName_TicketServiceDeleteTicketArgs = map[int16]string{
 	1: "req",
 }
 
-type TicketServiceGetTicketResult struct {
+type TicketServiceDeleteTicketResult struct {
 	Success *TicketResponse      `thrift:"success,0,optional" frugal:"0,optional,TicketResponse" json:"success,omitempty"`
 	Err     *common.ServiceError `thrift:"err,1,optional" frugal:"1,optional,common.ServiceError" json:"err,omitempty"`
 }
 
-func NewTicketServiceGetTicketResult() *TicketServiceGetTicketResult {
-	return &TicketServiceGetTicketResult{}
+func NewTicketServiceDeleteTicketResult() *TicketServiceDeleteTicketResult {
+	return &TicketServiceDeleteTicketResult{}
 }
 
-func (p *TicketServiceGetTicketResult) InitDefault() {
+func (p *TicketServiceDeleteTicketResult) InitDefault() {
 }
 
-var TicketServiceGetTicketResult_Success_DEFAULT *TicketResponse
+var TicketServiceDeleteTicketResult_Success_DEFAULT *TicketResponse
 
-func (p *TicketServiceGetTicketResult) GetSuccess() (v *TicketResponse) {
+func (p *TicketServiceDeleteTicketResult) GetSuccess() (v *TicketResponse) {
 	if !p.IsSetSuccess() {
-		return TicketServiceGetTicketResult_Success_DEFAULT
+		return TicketServiceDeleteTicketResult_Success_DEFAULT
 	}
 	return p.Success
 }
 
-var TicketServiceGetTicketResult_Err_DEFAULT *common.ServiceError
+var TicketServiceDeleteTicketResult_Err_DEFAULT *common.ServiceError
 
-func (p *TicketServiceGetTicketResult) GetErr() (v *common.ServiceError) {
+func (p *TicketServiceDeleteTicketResult) GetErr() (v *common.ServiceError) {
 	if !p.IsSetErr() {
-		return TicketServiceGetTicketResult_Err_DEFAULT
+		return TicketServiceDeleteTicketResult_Err_DEFAULT
 	}
 	return p.Err
 }
-func (p *TicketServiceGetTicketResult) SetSuccess(x interface{}) {
+func (p *TicketServiceDeleteTicketResult) SetSuccess(x interface{}) {
 	p.Success = x.(*TicketResponse)
 }
-func (p *TicketServiceGetTicketResult) SetErr(val *common.ServiceError) {
+func (p *TicketServiceDeleteTicketResult) SetErr(val *common.ServiceError) {
 	p.Err = val
 }
 
-func (p *TicketServiceGetTicketResult) IsSetSuccess() bool {
+func (p *TicketServiceDeleteTicketResult) IsSetSuccess() bool {
 	return p.Success != nil
 }
 
-func (p *TicketServiceGetTicketResult) IsSetErr() bool {
+func (p *TicketServiceDeleteTicketResult) IsSetErr() bool {
 	return p.Err != nil
 }
 
-func (p *TicketServiceGetTicketResult) String() string {
+func (p *TicketServiceDeleteTicketResult) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("TicketServiceGetTicketResult(%+v)", *p)
+	return fmt.Sprintf("TicketServiceDeleteTicketResult(%+v)", *p)
 }
 
-var fieldIDToName_TicketServiceGetTicketResult = map[int16]string{
+var fieldIDToName_TicketServiceDeleteTicketResult = map[int16]string{
 	0: "success",
 	1: "err",
 }
 
-type TicketServiceListTicketsArgs struct {
-	Req *ListTicketsRequest `thrift:"req,1" frugal:"1,default,ListTicketsRequest" json:"req"`
+type TicketServiceRestoreTicketArgs struct {
+	Req *TicketActionRequest `thrift:"req,1" frugal:"1,default,TicketActionRequest" json:"req"`
 }
 
-func NewTicketServiceListTicketsArgs() *TicketServiceListTicketsArgs {
-	return &TicketServiceListTicketsArgs{}
+func NewTicketServiceRestoreTicketArgs() *TicketServiceRestoreTicketArgs {
+	return &TicketServiceRestoreTicketArgs{}
 }
 
-func (p *TicketServiceListTicketsArgs) InitDefault() {
+func (p *TicketServiceRestoreTicketArgs) InitDefault() {
 }
 
-var TicketServiceListTicketsArgs_Req_DEFAULT *ListTicketsRequest
+var TicketServiceRestoreTicketArgs_Req_DEFAULT *TicketActionRequest
 
-func (p *TicketServiceListTicketsArgs) GetReq() (v *ListTicketsRequest) {
+func (p *TicketServiceRestoreTicketArgs) GetReq() (v *TicketActionRequest) {
 	if !p.IsSetReq() {
-		return TicketServiceListTicketsArgs_Req_DEFAULT
+		return TicketServiceRestoreTicketArgs_Req_DEFAULT
 	}
 	return p.Req
 }
-func (p *TicketServiceListTicketsArgs) SetReq(val *ListTicketsRequest) {
+func (p *TicketServiceRestoreTicketArgs) SetReq(val *TicketActionRequest) {
 	p.Req = val
 }
 
-func (p *TicketServiceListTicketsArgs) IsSetReq() bool {
+func (p *TicketServiceRestoreTicketArgs) IsSetReq() bool {
 	return p.Req != nil
 }
 
-func (p *TicketServiceListTicketsArgs) String() string {
+func (p *TicketServiceRestoreTicketArgs) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("TicketServiceListTicketsArgs(%+v)", *p)
+	return fmt.Sprintf("TicketServiceRestoreTicketArgs(%+v)", *p)
 }
 
-var fieldIDToName_TicketServiceListTicketsArgs = map[int16]string{
+var fieldIDToName_TicketServiceRestoreTicketArgs = map[int16]string{
 	1: "req",
 }
 
-type TicketServiceListTicketsResult struct {
-	Success *ListTicketsResponse `thrift:"success,0,optional" frugal:"0,optional,ListTicketsResponse" json:"success,omitempty"`
+type TicketServiceRestoreTicketResult struct {
+	Success *TicketResponse      `thrift:"success,0,optional" frugal:"0,optional,TicketResponse" json:"success,omitempty"`
 	Err     *common.ServiceError `thrift:"err,1,optional" frugal:"1,optional,common.ServiceError" json:"err,omitempty"`
 }
 
-func NewTicketServiceListTicketsResult() *TicketServiceListTicketsResult {
-	return &TicketServiceListTicketsResult{}
+func NewTicketServiceRestoreTicketResult() *TicketServiceRestoreTicketResult {
+	return &TicketServiceRestoreTicketResult{}
 }
 
-func (p *TicketServiceListTicketsResult) InitDefault() {
+func (p *TicketServiceRestoreTicketResult) InitDefault() {
 }
 
-var TicketServiceListTicketsResult_Success_DEFAULT *ListTicketsResponse
+var TicketServiceRestoreTicketResult_Success_DEFAULT *TicketResponse
 
-func (p *TicketServiceListTicketsResult) GetSuccess() (v *ListTicketsResponse) {
+func (p *TicketServiceRestoreTicketResult) GetSuccess() (v *TicketResponse) {
 	if !p.IsSetSuccess() {
-		return TicketServiceListTicketsResult_Success_DEFAULT
+		return TicketServiceRestoreTicketResult_Success_DEFAULT
 	}
 	return p.Success
 }
 
-var TicketServiceListTicketsResult_Err_DEFAULT *common.ServiceError
+var TicketServiceRestoreTicketResult_Err_DEFAULT *common.ServiceError
 
-func (p *TicketServiceListTicketsResult) GetErr() (v *common.ServiceError) {
+func (p *TicketServiceRestoreTicketResult) GetErr() (v *common.ServiceError) {
 	if !p.IsSetErr() {
-		return TicketServiceListTicketsResult_Err_DEFAULT
+		return TicketServiceRestoreTicketResult_Err_DEFAULT
 	}
 	return p.Err
 }
-func (p *TicketServiceListTicketsResult) SetSuccess(x interface{}) {
-	p.Success = x.(*ListTicketsResponse)
+func (p *TicketServiceRestoreTicketResult) SetSuccess(x interface{}) {
+	p.Success = x.(*TicketResponse)
 }
-func (p *TicketServiceListTicketsResult) SetErr(val *common.ServiceError) {
+func (p *TicketServiceRestoreTicketResult) SetErr(val *common.ServiceError) {
 	p.Err = val
 }
 
-func (p *TicketServiceListTicketsResult) IsSetSuccess() bool {
+func (p *TicketServiceRestoreTicketResult) IsSetSuccess() bool {
 	return p.Success != nil
 }
 
-func (p *TicketServiceListTicketsResult) IsSetErr() bool {
+func (p *TicketServiceRestoreTicketResult) IsSetErr() bool {
 	return p.Err != nil
 }
 
-func (p *TicketServiceListTicketsResult) String() string {
+func (p *TicketServiceRestoreTicketResult) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("TicketServiceListTicketsResult(%+v)", *p)
+	return fmt.Sprintf("TicketServiceRestoreTicketResult(%+v)", *p)
 }
 
-var fieldIDToName_TicketServiceListTicketsResult = map[int16]string{
+var fieldIDToName_TicketServiceRestoreTicketResult = map[int16]string{
 	0: "success",
 	1: "err",
 }
 
-type TicketServiceAssignArgs struct {
+type TicketServiceArchiveArgs struct {
 	Req *TicketActionRequest `thrift:"req,1" frugal:"1,default,TicketActionRequest" json:"req"`
 }
 
-func NewTicketServiceAssignArgs() *TicketServiceAssignArgs {
-	return &TicketServiceAssignArgs{}
+func NewTicketServiceArchiveArgs() *TicketServiceArchiveArgs {
+	return &TicketServiceArchiveArgs{}
 }
 
-func (p *TicketServiceAssignArgs) InitDefault() {
+func (p *TicketServiceArchiveArgs) InitDefault() {
 }
 
-var TicketServiceAssignArgs_Req_DEFAULT *TicketActionRequest
+var TicketServiceArchiveArgs_Req_DEFAULT *TicketActionRequest
 
-func (p *TicketServiceAssignArgs) GetReq() (v *TicketActionRequest) {
+func (p *TicketServiceArchiveArgs) GetReq() (v *TicketActionRequest) {
 	if !p.IsSetReq() {
-		return TicketServiceAssignArgs_Req_DEFAULT
+		return TicketServiceArchiveArgs_Req_DEFAULT
 	}
 	return p.Req
 }
-func (p *TicketServiceAssignArgs) SetReq(val *TicketActionRequest) {
+func (p *TicketServiceArchiveArgs) SetReq(val *TicketActionRequest) {
 	p.Req = val
 }
 
-func (p *TicketServiceAssignArgs) IsSetReq() bool {
+func (p *TicketServiceArchiveArgs) IsSetReq() bool {
 	return p.Req != nil
 }
 
-func (p *TicketServiceAssignArgs) String() string {
+func (p *TicketServiceArchiveArgs) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("TicketServiceAssignArgs(%+v)", *p)
+	return fmt.Sprintf("TicketServiceArchiveArgs(%+v)", *p)
 }
 
-var fieldIDToName_TicketServiceAssignArgs = map[int16]string{
+var fieldIDToName_TicketServiceArchiveArgs = map[int16]string{
 	1: "req",
 }
 
-type TicketServiceAssignResult struct {
+type TicketServiceArchiveResult struct {
 	Success *TicketResponse      `thrift:"success,0,optional" frugal:"0,optional,TicketResponse" json:"success,omitempty"`
 	Err     *common.ServiceError `thrift:"err,1,optional" frugal:"1,optional,common.ServiceError" json:"err,omitempty"`
 }
 
-func NewTicketServiceAssignResult() *TicketServiceAssignResult {
-	return &TicketServiceAssignResult{}
+func NewTicketServiceArchiveResult() *TicketServiceArchiveResult {
+	return &TicketServiceArchiveResult{}
 }
 
-func (p *TicketServiceAssignResult) InitDefault() {
+func (p *TicketServiceArchiveResult) InitDefault() {
 }
 
-var TicketServiceAssignResult_Success_DEFAULT *TicketResponse
+var TicketServiceArchiveResult_Success_DEFAULT *TicketResponse
 
-func (p *TicketServiceAssignResult) GetSuccess() (v *TicketResponse) {
+func (p *TicketServiceArchiveResult) GetSuccess() (v *TicketResponse) {
 	if !p.IsSetSuccess() {
-		return TicketServiceAssignResult_Success_DEFAULT
+		return TicketServiceArchiveResult_Success_DEFAULT
 	}
 	return p.Success
 }
 
-var TicketServiceAssignResult_Err_DEFAULT *common.ServiceError
+var TicketServiceArchiveResult_Err_DEFAULT *common.ServiceError
 
-func (p *TicketServiceAssignResult) GetErr() (v *common.ServiceError) {
+func (p *TicketServiceArchiveResult) GetErr() (v *common.ServiceError) {
 	if !p.IsSetErr() {
-		return TicketServiceAssignResult_Err_DEFAULT
+		return TicketServiceArchiveResult_Err_DEFAULT
 	}
 	return p.Err
 }
-func (p *TicketServiceAssignResult) SetSuccess(x interface{}) {
+func (p *TicketServiceArchiveResult) SetSuccess(x interface{}) {
 	p.Success = x.(*TicketResponse)
 }
-func (p *TicketServiceAssignResult) SetErr(val *common.ServiceError) {
+func (p *TicketServiceArchiveResult) SetErr(val *common.ServiceError) {
 	p.Err = val
 }
 
-func (p *TicketServiceAssignResult) IsSetSuccess() bool {
+func (p *TicketServiceArchiveResult) IsSetSuccess() bool {
 	return p.Success != nil
 }
 
-func (p *TicketServiceAssignResult) IsSetErr() bool {
+func (p *TicketServiceArchiveResult) IsSetErr() bool {
 	return p.Err != nil
 }
 
-func (p *TicketServiceAssignResult) String() string {
+func (p *TicketServiceArchiveResult) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("TicketServiceAssignResult(%+v)", *p)
+	return fmt.Sprintf("TicketServiceArchiveResult(%+v)", *p)
 }
 
-var fieldIDToName_TicketServiceAssignResult = map[int16]string{
+var fieldIDToName_TicketServiceArchiveResult = map[int16]string{
 	0: "success",
 	1: "err",
 }
 
-type TicketServiceResolveArgs struct {
+type TicketServiceUnarchiveArgs struct {
 	Req *TicketActionRequest `thrift:"req,1" frugal:"1,default,TicketActionRequest" json:"req"`
 }
 
-func NewTicketServiceResolveArgs() *TicketServiceResolveArgs {
-	return &TicketServiceResolveArgs{}
+func NewTicketServiceUnarchiveArgs() *TicketServiceUnarchiveArgs {
+	return &TicketServiceUnarchiveArgs{}
 }
 
-func (p *TicketServiceResolveArgs) InitDefault() {
+func (p *TicketServiceUnarchiveArgs) InitDefault() {
 }
 
-var TicketServiceResolveArgs_Req_DEFAULT *TicketActionRequest
+var TicketServiceUnarchiveArgs_Req_DEFAULT *TicketActionRequest
 
-func (p *TicketServiceResolveArgs) GetReq() (v *TicketActionRequest) {
+func (p *TicketServiceUnarchiveArgs) GetReq() (v *TicketActionRequest) {
 	if !p.IsSetReq() {
-		return TicketServiceResolveArgs_Req_DEFAULT
+		return TicketServiceUnarchiveArgs_Req_DEFAULT
 	}
 	return p.Req
 }
-func (p *TicketServiceResolveArgs) SetReq(val *TicketActionRequest) {
+func (p *TicketServiceUnarchiveArgs) SetReq(val *TicketActionRequest) {
 	p.Req = val
 }
 
-func (p *TicketServiceResolveArgs) IsSetReq() bool {
+func (p *TicketServiceUnarchiveArgs) IsSetReq() bool {
 	return p.Req != nil
 }
 
-func (p *TicketServiceResolveArgs) String() string {
+func (p *TicketServiceUnarchiveArgs) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("TicketServiceResolveArgs(%+v)", *p)
+	return fmt.Sprintf("TicketServiceUnarchiveArgs(%+v)", *p)
 }
 
-var fieldIDToName_TicketServiceResolveArgs = map[int16]string{
+var fieldIDToName_TicketServiceUnarchiveArgs = map[int16]string{
 	1: "req",
 }
 
-type TicketServiceResolveResult struct {
+type TicketServiceUnarchiveResult struct {
 	Success *TicketResponse      `thrift:"success,0,optional" frugal:"0,optional,TicketResponse" json:"success,omitempty"`
 	Err     *common.ServiceError `thrift:"err,1,optional" frugal:"1,optional,common.ServiceError" json:"err,omitempty"`
 }
 
-func NewTicketServiceResolveResult() *TicketServiceResolveResult {
-	return &TicketServiceResolveResult{}
+func NewTicketServiceUnarchiveResult() *TicketServiceUnarchiveResult {
+	return &TicketServiceUnarchiveResult{}
 }
 
-func (p *TicketServiceResolveResult) InitDefault() {
+func (p *TicketServiceUnarchiveResult) InitDefault() {
 }
 
-var TicketServiceResolveResult_Success_DEFAULT *TicketResponse
+var TicketServiceUnarchiveResult_Success_DEFAULT *TicketResponse
 
-func (p *TicketServiceResolveResult) GetSuccess() (v *TicketResponse) {
+func (p *TicketServiceUnarchiveResult) GetSuccess() (v *TicketResponse) {
 	if !p.IsSetSuccess() {
-		return TicketServiceResolveResult_Success_DEFAULT
+		return TicketServiceUnarchiveResult_Success_DEFAULT
 	}
 	return p.Success
 }
 
-var TicketServiceResolveResult_Err_DEFAULT *common.ServiceError
+var TicketServiceUnarchiveResult_Err_DEFAULT *common.ServiceError
 
-func (p *TicketServiceResolveResult) GetErr() (v *common.ServiceError) {
+func (p *TicketServiceUnarchiveResult) GetErr() (v *common.ServiceError) {
 	if !p.IsSetErr() {
-		return TicketServiceResolveResult_Err_DEFAULT
+		return TicketServiceUnarchiveResult_Err_DEFAULT
 	}
 	return p.Err
 }
-func (p *TicketServiceResolveResult) SetSuccess(x interface{}) {
+func (p *TicketServiceUnarchiveResult) SetSuccess(x interface{}) {
 	p.Success = x.(*TicketResponse)
 }
-func (p *TicketServiceResolveResult) SetErr(val *common.ServiceError) {
+func (p *TicketServiceUnarchiveResult) SetErr(val *common.ServiceError) {
 	p.Err = val
 }
 
-func (p *TicketServiceResolveResult) IsSetSuccess() bool {
+func (p *TicketServiceUnarchiveResult) IsSetSuccess() bool {
 	return p.Success != nil
 }
 
-func (p *TicketServiceResolveResult) IsSetErr() bool {
+func (p *TicketServiceUnarchiveResult) IsSetErr() bool {
 	return p.Err != nil
 }
 
-func (p *TicketServiceResolveResult) String() string {
+func (p *TicketServiceUnarchiveResult) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("TicketServiceResolveResult(%+v)", *p)
+	return fmt.Sprintf("TicketServiceUnarchiveResult(%+v)", *p)
 }
 
-var fieldIDToName_TicketServiceResolveResult = map[int16]string{
+var fieldIDToName_TicketServiceUnarchiveResult = map[int16]string{
 	0: "success",
 	1: "err",
 }
 
-type TicketServiceEscalateArgs struct {
-	Req *TicketActionRequest `thrift:"req,1" frugal:"1,default,TicketActionRequest" json:"req"`
+type TicketServiceApplyRetentionArgs struct {
+	Req *ApplyRetentionRequest `thrift:"req,1" frugal:"1,default,ApplyRetentionRequest" json:"req"`
 }
 
-func NewTicketServiceEscalateArgs() *TicketServiceEscalateArgs {
-	return &TicketServiceEscalateArgs{}
+func NewTicketServiceApplyRetentionArgs() *TicketServiceApplyRetentionArgs {
+	return &TicketServiceApplyRetentionArgs{}
 }
 
-func (p *TicketServiceEscalateArgs) InitDefault() {
+func (p *TicketServiceApplyRetentionArgs) InitDefault() {
 }
 
-var TicketServiceEscalateArgs_Req_DEFAULT *TicketActionRequest
+var TicketServiceApplyRetentionArgs_Req_DEFAULT *ApplyRetentionRequest
 
-func (p *TicketServiceEscalateArgs) GetReq() (v *TicketActionRequest) {
+func (p *TicketServiceApplyRetentionArgs) GetReq() (v *ApplyRetentionRequest) {
 	if !p.IsSetReq() {
-		return TicketServiceEscalateArgs_Req_DEFAULT
+		return TicketServiceApplyRetentionArgs_Req_DEFAULT
 	}
 	return p.Req
 }
-func (p *TicketServiceEscalateArgs) SetReq(val *TicketActionRequest) {
+func (p *TicketServiceApplyRetentionArgs) SetReq(val *ApplyRetentionRequest) {
 	p.Req = val
 }
 
-func (p *TicketServiceEscalateArgs) IsSetReq() bool {
+func (p *TicketServiceApplyRetentionArgs) IsSetReq() bool {
 	return p.Req != nil
 }
 
-func (p *TicketServiceEscalateArgs) String() string {
+func (p *TicketServiceApplyRetentionArgs) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("TicketServiceEscalateArgs(%+v)", *p)
+	return fmt.Sprintf("TicketServiceApplyRetentionArgs(%+v)", *p)
 }
 
-var fieldIDToName_TicketServiceEscalateArgs = map[int16]string{
+var fieldIDToName_TicketServiceApplyRetentionArgs = map[int16]string{
 	1: "req",
 }
 
-type TicketServiceEscalateResult struct {
-	Success *TicketResponse      `thrift:"success,0,optional" frugal:"0,optional,TicketResponse" json:"success,omitempty"`
+type TicketServiceApplyRetentionResult struct {
+	Success *RetentionReport     `thrift:"success,0,optional" frugal:"0,optional,RetentionReport" json:"success,omitempty"`
 	Err     *common.ServiceError `thrift:"err,1,optional" frugal:"1,optional,common.ServiceError" json:"err,omitempty"`
 }
 
-func NewTicketServiceEscalateResult() *TicketServiceEscalateResult {
-	return &TicketServiceEscalateResult{}
+func NewTicketServiceApplyRetentionResult() *TicketServiceApplyRetentionResult {
+	return &TicketServiceApplyRetentionResult{}
 }
 
-func (p *TicketServiceEscalateResult) InitDefault() {
+func (p *TicketServiceApplyRetentionResult) InitDefault() {
 }
 
-var TicketServiceEscalateResult_Success_DEFAULT *TicketResponse
+var TicketServiceApplyRetentionResult_Success_DEFAULT *RetentionReport
 
-func (p *TicketServiceEscalateResult) GetSuccess() (v *TicketResponse) {
+func (p *TicketServiceApplyRetentionResult) GetSuccess() (v *RetentionReport) {
 	if !p.IsSetSuccess() {
-		return TicketServiceEscalateResult_Success_DEFAULT
+		return TicketServiceApplyRetentionResult_Success_DEFAULT
 	}
 	return p.Success
 }
 
-var TicketServiceEscalateResult_Err_DEFAULT *common.ServiceError
+var TicketServiceApplyRetentionResult_Err_DEFAULT *common.ServiceError
 
-func (p *TicketServiceEscalateResult) GetErr() (v *common.ServiceError) {
+func (p *TicketServiceApplyRetentionResult) GetErr() (v *common.ServiceError) {
 	if !p.IsSetErr() {
-		return TicketServiceEscalateResult_Err_DEFAULT
+		return TicketServiceApplyRetentionResult_Err_DEFAULT
 	}
 	return p.Err
 }
-func (p *TicketServiceEscalateResult) SetSuccess(x interface{}) {
-	p.Success = x.(*TicketResponse)
+func (p *TicketServiceApplyRetentionResult) SetSuccess(x interface{}) {
+	p.Success = x.(*RetentionReport)
 }
-func (p *TicketServiceEscalateResult) SetErr(val *common.ServiceError) {
+func (p *TicketServiceApplyRetentionResult) SetErr(val *common.ServiceError) {
 	p.Err = val
 }
 
-func (p *TicketServiceEscalateResult) IsSetSuccess() bool {
+func (p *TicketServiceApplyRetentionResult) IsSetSuccess() bool {
 	return p.Success != nil
 }
 
-func (p *TicketServiceEscalateResult) IsSetErr() bool {
+func (p *TicketServiceApplyRetentionResult) IsSetErr() bool {
 	return p.Err != nil
 }
 
-func (p *TicketServiceEscalateResult) String() string {
+func (p *TicketServiceApplyRetentionResult) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("TicketServiceEscalateResult(%+v)", *p)
+	return fmt.Sprintf("TicketServiceApplyRetentionResult(%+v)", *p)
 }
 
-var fieldIDToName_TicketServiceEscalateResult = map[int16]string{
+var fieldIDToName_TicketServiceApplyRetentionResult = map[int16]string{
 	0: "success",
 	1: "err",
 }
 
-type TicketServiceReopenArgs struct {
-	Req *TicketActionRequest `thrift:"req,1" frugal:"1,default,TicketActionRequest" json:"req"`
+type TicketServiceExportSubjectArgs struct {
+	Req *common.SubjectRequest `thrift:"req,1" frugal:"1,default,common.SubjectRequest" json:"req"`
 }
 
-func NewTicketServiceReopenArgs() *TicketServiceReopenArgs {
-	return &TicketServiceReopenArgs{}
+func NewTicketServiceExportSubjectArgs() *TicketServiceExportSubjectArgs {
+	return &TicketServiceExportSubjectArgs{}
 }
 
-func (p *TicketServiceReopenArgs) InitDefault() {
+func (p *TicketServiceExportSubjectArgs) InitDefault() {
 }
 
-var TicketServiceReopenArgs_Req_DEFAULT *TicketActionRequest
+var TicketServiceExportSubjectArgs_Req_DEFAULT *common.SubjectRequest
 
-func (p *TicketServiceReopenArgs) GetReq() (v *TicketActionRequest) {
+func (p *TicketServiceExportSubjectArgs) GetReq() (v *common.SubjectRequest) {
 	if !p.IsSetReq() {
-		return TicketServiceReopenArgs_Req_DEFAULT
+		return TicketServiceExportSubjectArgs_Req_DEFAULT
 	}
 	return p.Req
 }
-func (p *TicketServiceReopenArgs) SetReq(val *TicketActionRequest) {
+func (p *TicketServiceExportSubjectArgs) SetReq(val *common.SubjectRequest) {
 	p.Req = val
 }
 
-func (p *TicketServiceReopenArgs) IsSetReq() bool {
+func (p *TicketServiceExportSubjectArgs) IsSetReq() bool {
 	return p.Req != nil
 }
 
-func (p *TicketServiceReopenArgs) String() string {
+func (p *TicketServiceExportSubjectArgs) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("TicketServiceReopenArgs(%+v)", *p)
+	return fmt.Sprintf("TicketServiceExportSubjectArgs(%+v)", *p)
 }
 
-var fieldIDToName_TicketServiceReopenArgs = map[int16]string{
+var fieldIDToName_TicketServiceExportSubjectArgs = map[int16]string{
 	1: "req",
 }
 
-type TicketServiceReopenResult struct {
-	Success *TicketResponse      `thrift:"success,0,optional" frugal:"0,optional,TicketResponse" json:"success,omitempty"`
-	Err     *common.ServiceError `thrift:"err,1,optional" frugal:"1,optional,common.ServiceError" json:"err,omitempty"`
+type TicketServiceExportSubjectResult struct {
+	Success *ExportSubjectResponse `thrift:"success,0,optional" frugal:"0,optional,ExportSubjectResponse" json:"success,omitempty"`
+	Err     *common.ServiceError   `thrift:"err,1,optional" frugal:"1,optional,common.ServiceError" json:"err,omitempty"`
 }
 
-func NewTicketServiceReopenResult() *TicketServiceReopenResult {
-	return &TicketServiceReopenResult{}
+func NewTicketServiceExportSubjectResult() *TicketServiceExportSubjectResult {
+	return &TicketServiceExportSubjectResult{}
 }
 
-func (p *TicketServiceReopenResult) InitDefault() {
+func (p *TicketServiceExportSubjectResult) InitDefault() {
 }
 
-var TicketServiceReopenResult_Success_DEFAULT *TicketResponse
+var TicketServiceExportSubjectResult_Success_DEFAULT *ExportSubjectResponse
 
-func (p *TicketServiceReopenResult) GetSuccess() (v *TicketResponse) {
+func (p *TicketServiceExportSubjectResult) GetSuccess() (v *ExportSubjectResponse) {
 	if !p.IsSetSuccess() {
-		return TicketServiceReopenResult_Success_DEFAULT
+		return TicketServiceExportSubjectResult_Success_DEFAULT
 	}
 	return p.Success
 }
 
-var TicketServiceReopenResult_Err_DEFAULT *common.ServiceError
+var TicketServiceExportSubjectResult_Err_DEFAULT *common.ServiceError
 
-func (p *TicketServiceReopenResult) GetErr() (v *common.ServiceError) {
+func (p *TicketServiceExportSubjectResult) GetErr() (v *common.ServiceError) {
 	if !p.IsSetErr() {
-		return TicketServiceReopenResult_Err_DEFAULT
+		return TicketServiceExportSubjectResult_Err_DEFAULT
 	}
 	return p.Err
 }
-func (p *TicketServiceReopenResult) SetSuccess(x interface{}) {
-	p.Success = x.(*TicketResponse)
+func (p *TicketServiceExportSubjectResult) SetSuccess(x interface{}) {
+	p.Success = x.(*ExportSubjectResponse)
 }
-func (p *TicketServiceReopenResult) SetErr(val *common.ServiceError) {
+func (p *TicketServiceExportSubjectResult) SetErr(val *common.ServiceError) {
 	p.Err = val
 }
 
-func (p *TicketServiceReopenResult) IsSetSuccess() bool {
+func (p *TicketServiceExportSubjectResult) IsSetSuccess() bool {
 	return p.Success != nil
 }
 
-func (p *TicketServiceReopenResult) IsSetErr() bool {
+func (p *TicketServiceExportSubjectResult) IsSetErr() bool {
 	return p.Err != nil
 }
 
-func (p *TicketServiceReopenResult) String() string {
+func (p *TicketServiceExportSubjectResult) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("TicketServiceReopenResult(%+v)", *p)
+	return fmt.Sprintf("TicketServiceExportSubjectResult(%+v)", *p)
 }
 
-var fieldIDToName_TicketServiceReopenResult = map[int16]string{
+var fieldIDToName_TicketServiceExportSubjectResult = map[int16]string{
 	0: "success",
 	1: "err",
 }
 
-type TicketServiceGetCyclesArgs struct {
-	Req *GetCyclesRequest `thrift:"req,1" frugal:"1,default,GetCyclesRequest" json:"req"`
+type TicketServiceEraseSubjectArgs struct {
+	Req *EraseSubjectRequest `thrift:"req,1" frugal:"1,default,EraseSubjectRequest" json:"req"`
 }
 
-func NewTicketServiceGetCyclesArgs() *TicketServiceGetCyclesArgs {
-	return &TicketServiceGetCyclesArgs{}
+func NewTicketServiceEraseSubjectArgs() *TicketServiceEraseSubjectArgs {
+	return &TicketServiceEraseSubjectArgs{}
 }
 
-func (p *TicketServiceGetCyclesArgs) InitDefault() {
+func (p *TicketServiceEraseSubjectArgs) InitDefault() {
 }
 
-var TicketServiceGetCyclesArgs_Req_DEFAULT *GetCyclesRequest
+var TicketServiceEraseSubjectArgs_Req_DEFAULT *EraseSubjectRequest
 
-func (p *TicketServiceGetCyclesArgs) GetReq() (v *GetCyclesRequest) {
+func (p *TicketServiceEraseSubjectArgs) GetReq() (v *EraseSubjectRequest) {
 	if !p.IsSetReq() {
-		return TicketServiceGetCyclesArgs_Req_DEFAULT
+		return TicketServiceEraseSubjectArgs_Req_DEFAULT
 	}
 	return p.Req
 }
-func (p *TicketServiceGetCyclesArgs) SetReq(val *GetCyclesRequest) {
+func (p *TicketServiceEraseSubjectArgs) SetReq(val *EraseSubjectRequest) {
 	p.Req = val
 }
 
-func (p *TicketServiceGetCyclesArgs) IsSetReq() bool {
+func (p *TicketServiceEraseSubjectArgs) IsSetReq() bool {
 	return p.Req != nil
 }
 
-func (p *TicketServiceGetCyclesArgs) String() string {
+func (p *TicketServiceEraseSubjectArgs) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("TicketServiceGetCyclesArgs(%+v)", *p)
+	return fmt.Sprintf("TicketServiceEraseSubjectArgs(%+v)", *p)
 }
 
-var fieldIDToName_TicketServiceGetCyclesArgs = map[int16]string{
+var fieldIDToName_TicketServiceEraseSubjectArgs = map[int16]string{
 	1: "req",
 }
 
-type TicketServiceGetCyclesResult struct {
-	Success []*common.TicketCycle `thrift:"success,0,optional" frugal:"0,optional,list<common.TicketCycle>" json:"success,omitempty"`
-	Err     *common.ServiceError  `thrift:"err,1,optional" frugal:"1,optional,common.ServiceError" json:"err,omitempty"`
+type TicketServiceEraseSubjectResult struct {
+	Success *ErasureReceipt      `thrift:"success,0,optional" frugal:"0,optional,ErasureReceipt" json:"success,omitempty"`
+	Err     *common.ServiceError `thrift:"err,1,optional" frugal:"1,optional,common.ServiceError" json:"err,omitempty"`
 }
 
-func NewTicketServiceGetCyclesResult() *TicketServiceGetCyclesResult {
-	return &TicketServiceGetCyclesResult{}
+func NewTicketServiceEraseSubjectResult() *TicketServiceEraseSubjectResult {
+	return &TicketServiceEraseSubjectResult{}
 }
 
-func (p *TicketServiceGetCyclesResult) InitDefault() {
+func (p *TicketServiceEraseSubjectResult) InitDefault() {
 }
 
-var TicketServiceGetCyclesResult_Success_DEFAULT []*common.TicketCycle
+var TicketServiceEraseSubjectResult_Success_DEFAULT *ErasureReceipt
 
-func (p *TicketServiceGetCyclesResult) GetSuccess() (v []*common.TicketCycle) {
+func (p *TicketServiceEraseSubjectResult) GetSuccess() (v *ErasureReceipt) {
 	if !p.IsSetSuccess() {
-		return TicketServiceGetCyclesResult_Success_DEFAULT
+		return TicketServiceEraseSubjectResult_Success_DEFAULT
 	}
 	return p.Success
 }
 
-var TicketServiceGetCyclesResult_Err_DEFAULT *common.ServiceError
+var TicketServiceEraseSubjectResult_Err_DEFAULT *common.ServiceError
 
-func (p *TicketServiceGetCyclesResult) GetErr() (v *common.ServiceError) {
+func (p *TicketServiceEraseSubjectResult) GetErr() (v *common.ServiceError) {
 	if !p.IsSetErr() {
-		return TicketServiceGetCyclesResult_Err_DEFAULT
+		return TicketServiceEraseSubjectResult_Err_DEFAULT
 	}
 	return p.Err
 }
-func (p *TicketServiceGetCyclesResult) SetSuccess(x interface{}) {
-	p.Success = x.([]*common.TicketCycle)
+func (p *TicketServiceEraseSubjectResult) SetSuccess(x interface{}) {
+	p.Success = x.(*ErasureReceipt)
 }
-func (p *TicketServiceGetCyclesResult) SetErr(val *common.ServiceError) {
+func (p *TicketServiceEraseSubjectResult) SetErr(val *common.ServiceError) {
 	p.Err = val
 }
 
-func (p *TicketServiceGetCyclesResult) IsSetSuccess() bool {
+func (p *TicketServiceEraseSubjectResult) IsSetSuccess() bool {
 	return p.Success != nil
 }
 
-func (p *TicketServiceGetCyclesResult) IsSetErr() bool {
+func (p *TicketServiceEraseSubjectResult) IsSetErr() bool {
 	return p.Err != nil
 }
 
-func (p *TicketServiceGetCyclesResult) String() string {
+func (p *TicketServiceEraseSubjectResult) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("TicketServiceGetCyclesResult(%+v)", *p)
+	return fmt.Sprintf("TicketServiceEraseSubjectResult(%+v)", *p)
 }
 
-var fieldIDToName_TicketServiceGetCyclesResult = map[int16]string{
+var fieldIDToName_TicketServiceEraseSubjectResult = map[int16]string{
 	0: "success",
 	1: "err",
 }
 
-type TicketServiceGetEventsArgs struct {
-	Req *GetEventsRequest `thrift:"req,1" frugal:"1,default,GetEventsRequest" json:"req"`
+type TicketServiceGetErasureReceiptArgs struct {
+	Req *GetErasureReceiptRequest `thrift:"req,1" frugal:"1,default,GetErasureReceiptRequest" json:"req"`
 }
 
-func NewTicketServiceGetEventsArgs() *TicketServiceGetEventsArgs {
-	return &TicketServiceGetEventsArgs{}
+func NewTicketServiceGetErasureReceiptArgs() *TicketServiceGetErasureReceiptArgs {
+	return &TicketServiceGetErasureReceiptArgs{}
 }
 
-func (p *TicketServiceGetEventsArgs) InitDefault() {
+func (p *TicketServiceGetErasureReceiptArgs) InitDefault() {
 }
 
-var TicketServiceGetEventsArgs_Req_DEFAULT *GetEventsRequest
+var TicketServiceGetErasureReceiptArgs_Req_DEFAULT *GetErasureReceiptRequest
 
-func (p *TicketServiceGetEventsArgs) GetReq() (v *GetEventsRequest) {
+func (p *TicketServiceGetErasureReceiptArgs) GetReq() (v *GetErasureReceiptRequest) {
 	if !p.IsSetReq() {
-		return TicketServiceGetEventsArgs_Req_DEFAULT
+		return TicketServiceGetErasureReceiptArgs_Req_DEFAULT
 	}
 	return p.Req
 }
-func (p *TicketServiceGetEventsArgs) SetReq(val *GetEventsRequest) {
+func (p *TicketServiceGetErasureReceiptArgs) SetReq(val *GetErasureReceiptRequest) {
 	p.Req = val
 }
 
-func (p *TicketServiceGetEventsArgs) IsSetReq() bool {
+func (p *TicketServiceGetErasureReceiptArgs) IsSetReq() bool {
 	return p.Req != nil
 }
 
-func (p *TicketServiceGetEventsArgs) String() string {
+func (p *TicketServiceGetErasureReceiptArgs) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("TicketServiceGetEventsArgs(%+v)", *p)
+	return fmt.Sprintf("TicketServiceGetErasureReceiptArgs(%+v)", *p)
 }
 
-var fieldIDToName_TicketServiceGetEventsArgs = map[int16]string{
+var fieldIDToName_TicketServiceGetErasureReceiptArgs = map[int16]string{
 	1: "req",
 }
 
-type TicketServiceGetEventsResult struct {
-	Success []*common.TicketEvent `thrift:"success,0,optional" frugal:"0,optional,list<common.TicketEvent>" json:"success,omitempty"`
-	Err     *common.ServiceError  `thrift:"err,1,optional" frugal:"1,optional,common.ServiceError" json:"err,omitempty"`
+type TicketServiceGetErasureReceiptResult struct {
+	Success *ErasureReceipt      `thrift:"success,0,optional" frugal:"0,optional,ErasureReceipt" json:"success,omitempty"`
+	Err     *common.ServiceError `thrift:"err,1,optional" frugal:"1,optional,common.ServiceError" json:"err,omitempty"`
 }
 
-func NewTicketServiceGetEventsResult() *TicketServiceGetEventsResult {
-	return &TicketServiceGetEventsResult{}
+func NewTicketServiceGetErasureReceiptResult() *TicketServiceGetErasureReceiptResult {
+	return &TicketServiceGetErasureReceiptResult{}
 }
 
-func (p *TicketServiceGetEventsResult) InitDefault() {
+func (p *TicketServiceGetErasureReceiptResult) InitDefault() {
 }
 
-var TicketServiceGetEventsResult_Success_DEFAULT []*common.TicketEvent
+var TicketServiceGetErasureReceiptResult_Success_DEFAULT *ErasureReceipt
 
-func (p *TicketServiceGetEventsResult) GetSuccess() (v []*common.TicketEvent) {
+func (p *TicketServiceGetErasureReceiptResult) GetSuccess() (v *ErasureReceipt) {
 	if !p.IsSetSuccess() {
-		return TicketServiceGetEventsResult_Success_DEFAULT
+		return TicketServiceGetErasureReceiptResult_Success_DEFAULT
 	}
 	return p.Success
 }
 
-var TicketServiceGetEventsResult_Err_DEFAULT *common.ServiceError
+var TicketServiceGetErasureReceiptResult_Err_DEFAULT *common.ServiceError
 
-func (p *TicketServiceGetEventsResult) GetErr() (v *common.ServiceError) {
+func (p *TicketServiceGetErasureReceiptResult) GetErr() (v *common.ServiceError) {
 	if !p.IsSetErr() {
-		return TicketServiceGetEventsResult_Err_DEFAULT
+		return TicketServiceGetErasureReceiptResult_Err_DEFAULT
 	}
 	return p.Err
 }
-func (p *TicketServiceGetEventsResult) SetSuccess(x interface{}) {
-	p.Success = x.([]*common.TicketEvent)
+func (p *TicketServiceGetErasureReceiptResult) SetSuccess(x interface{}) {
+	p.Success = x.(*ErasureReceipt)
 }
-func (p *TicketServiceGetEventsResult) SetErr(val *common.ServiceError) {
+func (p *TicketServiceGetErasureReceiptResult) SetErr(val *common.ServiceError) {
 	p.Err = val
 }
 
-func (p *TicketServiceGetEventsResult) IsSetSuccess() bool {
+func (p *TicketServiceGetErasureReceiptResult) IsSetSuccess() bool {
 	return p.Success != nil
 }
 
-func (p *TicketServiceGetEventsResult) IsSetErr() bool {
+func (p *TicketServiceGetErasureReceiptResult) IsSetErr() bool {
 	return p.Err != nil
 }
 
-func (p *TicketServiceGetEventsResult) String() string {
+func (p *TicketServiceGetErasureReceiptResult) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("TicketServiceGetEventsResult(%+v)", *p)
+	return fmt.Sprintf("TicketServiceGetErasureReceiptResult(%+v)", *p)
 }
 
-var fieldIDToName_TicketServiceGetEventsResult = map[int16]string{
+var fieldIDToName_TicketServiceGetErasureReceiptResult = map[int16]string{
 	0: "success",
 	1: "err",
 }
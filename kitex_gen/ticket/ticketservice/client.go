@@ -19,8 +19,39 @@ type Client interface {
 	Resolve(ctx context.Context, req *ticket.TicketActionRequest, callOptions ...callopt.Option) (r *ticket.TicketResponse, err error)
 	Escalate(ctx context.Context, req *ticket.TicketActionRequest, callOptions ...callopt.Option) (r *ticket.TicketResponse, err error)
 	Reopen(ctx context.Context, req *ticket.TicketActionRequest, callOptions ...callopt.Option) (r *ticket.TicketResponse, err error)
+	Deescalate(ctx context.Context, req *ticket.TicketActionRequest, callOptions ...callopt.Option) (r *ticket.TicketResponse, err error)
 	GetCycles(ctx context.Context, req *ticket.GetCyclesRequest, callOptions ...callopt.Option) (r []*common.TicketCycle, err error)
 	GetEvents(ctx context.Context, req *ticket.GetEventsRequest, callOptions ...callopt.Option) (r []*common.TicketEvent, err error)
+	AddComment(ctx context.Context, req *ticket.AddCommentRequest, callOptions ...callopt.Option) (r *ticket.TicketResponse, err error)
+	Watch(ctx context.Context, req *ticket.WatchRequest, callOptions ...callopt.Option) (r *ticket.TicketResponse, err error)
+	Unwatch(ctx context.Context, req *ticket.WatchRequest, callOptions ...callopt.Option) (r *ticket.TicketResponse, err error)
+	ListNotifications(ctx context.Context, req *ticket.ListNotificationsRequest, callOptions ...callopt.Option) (r *ticket.ListNotificationsResponse, err error)
+	MarkNotifications(ctx context.Context, req *ticket.MarkNotificationsRequest, callOptions ...callopt.Option) (r *ticket.MarkNotificationsResponse, err error)
+	GetNotificationPrefs(ctx context.Context, req *ticket.GetNotificationPrefsRequest, callOptions ...callopt.Option) (r *ticket.NotificationPrefs, err error)
+	SetNotificationPrefs(ctx context.Context, req *ticket.SetNotificationPrefsRequest, callOptions ...callopt.Option) (r *ticket.NotificationPrefs, err error)
+	VerifyAudit(ctx context.Context, req *ticket.VerifyAuditRequest, callOptions ...callopt.Option) (r *ticket.VerifyAuditResponse, err error)
+	ExportAuditHead(ctx context.Context, req *ticket.ExportAuditHeadRequest, callOptions ...callopt.Option) (r *ticket.AuditHead, err error)
+	SubmitSurvey(ctx context.Context, req *ticket.SubmitSurveyRequest, callOptions ...callopt.Option) (r *ticket.SubmitSurveyResponse, err error)
+	CSATReport(ctx context.Context, req *ticket.CSATReportRequest, callOptions ...callopt.Option) (r *ticket.CSATReportResponse, err error)
+	EscalationReport(ctx context.Context, req *ticket.EscalationReportRequest, callOptions ...callopt.Option) (r *ticket.EscalationReportResponse, err error)
+	CreateView(ctx context.Context, req *ticket.SaveViewRequest, callOptions ...callopt.Option) (r *ticket.SavedView, err error)
+	UpdateView(ctx context.Context, req *ticket.SaveViewRequest, callOptions ...callopt.Option) (r *ticket.SavedView, err error)
+	GetView(ctx context.Context, req *ticket.ViewRequest, callOptions ...callopt.Option) (r *ticket.SavedView, err error)
+	DeleteView(ctx context.Context, req *ticket.ViewRequest, callOptions ...callopt.Option) (r *ticket.DeleteViewResponse, err error)
+	ListViews(ctx context.Context, req *ticket.ListViewsRequest, callOptions ...callopt.Option) (r *ticket.ListViewsResponse, err error)
+	ViewTickets(ctx context.Context, req *ticket.ViewTicketsRequest, callOptions ...callopt.Option) (r *ticket.ListTicketsResponse, err error)
+	PullNext(ctx context.Context, req *ticket.PullNextRequest, callOptions ...callopt.Option) (r *ticket.PullNextResponse, err error)
+	AcquireLock(ctx context.Context, req *ticket.LockRequest, callOptions ...callopt.Option) (r *ticket.LockResponse, err error)
+	ReleaseLock(ctx context.Context, req *ticket.LockRequest, callOptions ...callopt.Option) (r *ticket.LockResponse, err error)
+	ListLockEvents(ctx context.Context, req *ticket.ListLockEventsRequest, callOptions ...callopt.Option) (r *ticket.ListLockEventsResponse, err error)
+	DeleteTicket(ctx context.Context, req *ticket.TicketActionRequest, callOptions ...callopt.Option) (r *ticket.TicketResponse, err error)
+	RestoreTicket(ctx context.Context, req *ticket.TicketActionRequest, callOptions ...callopt.Option) (r *ticket.TicketResponse, err error)
+	Archive(ctx context.Context, req *ticket.TicketActionRequest, callOptions ...callopt.Option) (r *ticket.TicketResponse, err error)
+	Unarchive(ctx context.Context, req *ticket.TicketActionRequest, callOptions ...callopt.Option) (r *ticket.TicketResponse, err error)
+	ApplyRetention(ctx context.Context, req *ticket.ApplyRetentionRequest, callOptions ...callopt.Option) (r *ticket.RetentionReport, err error)
+	ExportSubject(ctx context.Context, req *common.SubjectRequest, callOptions ...callopt.Option) (r *ticket.ExportSubjectResponse, err error)
+	EraseSubject(ctx context.Context, req *ticket.EraseSubjectRequest, callOptions ...callopt.Option) (r *ticket.ErasureReceipt, err error)
+	GetErasureReceipt(ctx context.Context, req *ticket.GetErasureReceiptRequest, callOptions ...callopt.Option) (r *ticket.ErasureReceipt, err error)
 }
 
 // NewClient creates a client for the service defined in IDL.
@@ -87,6 +118,11 @@ func (p *kTicketServiceClient) Reopen(ctx context.Context, req *ticket.TicketAct
 	return p.kClient.Reopen(ctx, req)
 }
 
+func (p *kTicketServiceClient) Deescalate(ctx context.Context, req *ticket.TicketActionRequest, callOptions ...callopt.Option) (r *ticket.TicketResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.Deescalate(ctx, req)
+}
+
 func (p *kTicketServiceClient) GetCycles(ctx context.Context, req *ticket.GetCyclesRequest, callOptions ...callopt.Option) (r []*common.TicketCycle, err error) {
 	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
 	return p.kClient.GetCycles(ctx, req)
@@ -96,3 +132,153 @@ func (p *kTicketServiceClient) GetEvents(ctx context.Context, req *ticket.GetEve
 	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
 	return p.kClient.GetEvents(ctx, req)
 }
+
+func (p *kTicketServiceClient) AddComment(ctx context.Context, req *ticket.AddCommentRequest, callOptions ...callopt.Option) (r *ticket.TicketResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.AddComment(ctx, req)
+}
+
+func (p *kTicketServiceClient) Watch(ctx context.Context, req *ticket.WatchRequest, callOptions ...callopt.Option) (r *ticket.TicketResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.Watch(ctx, req)
+}
+
+func (p *kTicketServiceClient) Unwatch(ctx context.Context, req *ticket.WatchRequest, callOptions ...callopt.Option) (r *ticket.TicketResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.Unwatch(ctx, req)
+}
+
+func (p *kTicketServiceClient) ListNotifications(ctx context.Context, req *ticket.ListNotificationsRequest, callOptions ...callopt.Option) (r *ticket.ListNotificationsResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.ListNotifications(ctx, req)
+}
+
+func (p *kTicketServiceClient) MarkNotifications(ctx context.Context, req *ticket.MarkNotificationsRequest, callOptions ...callopt.Option) (r *ticket.MarkNotificationsResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.MarkNotifications(ctx, req)
+}
+
+func (p *kTicketServiceClient) GetNotificationPrefs(ctx context.Context, req *ticket.GetNotificationPrefsRequest, callOptions ...callopt.Option) (r *ticket.NotificationPrefs, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.GetNotificationPrefs(ctx, req)
+}
+
+func (p *kTicketServiceClient) SetNotificationPrefs(ctx context.Context, req *ticket.SetNotificationPrefsRequest, callOptions ...callopt.Option) (r *ticket.NotificationPrefs, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.SetNotificationPrefs(ctx, req)
+}
+
+func (p *kTicketServiceClient) VerifyAudit(ctx context.Context, req *ticket.VerifyAuditRequest, callOptions ...callopt.Option) (r *ticket.VerifyAuditResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.VerifyAudit(ctx, req)
+}
+
+func (p *kTicketServiceClient) ExportAuditHead(ctx context.Context, req *ticket.ExportAuditHeadRequest, callOptions ...callopt.Option) (r *ticket.AuditHead, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.ExportAuditHead(ctx, req)
+}
+
+func (p *kTicketServiceClient) SubmitSurvey(ctx context.Context, req *ticket.SubmitSurveyRequest, callOptions ...callopt.Option) (r *ticket.SubmitSurveyResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.SubmitSurvey(ctx, req)
+}
+
+func (p *kTicketServiceClient) CSATReport(ctx context.Context, req *ticket.CSATReportRequest, callOptions ...callopt.Option) (r *ticket.CSATReportResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.CSATReport(ctx, req)
+}
+
+func (p *kTicketServiceClient) EscalationReport(ctx context.Context, req *ticket.EscalationReportRequest, callOptions ...callopt.Option) (r *ticket.EscalationReportResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.EscalationReport(ctx, req)
+}
+
+func (p *kTicketServiceClient) CreateView(ctx context.Context, req *ticket.SaveViewRequest, callOptions ...callopt.Option) (r *ticket.SavedView, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.CreateView(ctx, req)
+}
+
+func (p *kTicketServiceClient) UpdateView(ctx context.Context, req *ticket.SaveViewRequest, callOptions ...callopt.Option) (r *ticket.SavedView, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.UpdateView(ctx, req)
+}
+
+func (p *kTicketServiceClient) GetView(ctx context.Context, req *ticket.ViewRequest, callOptions ...callopt.Option) (r *ticket.SavedView, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.GetView(ctx, req)
+}
+
+func (p *kTicketServiceClient) DeleteView(ctx context.Context, req *ticket.ViewRequest, callOptions ...callopt.Option) (r *ticket.DeleteViewResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.DeleteView(ctx, req)
+}
+
+func (p *kTicketServiceClient) ListViews(ctx context.Context, req *ticket.ListViewsRequest, callOptions ...callopt.Option) (r *ticket.ListViewsResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.ListViews(ctx, req)
+}
+
+func (p *kTicketServiceClient) ViewTickets(ctx context.Context, req *ticket.ViewTicketsRequest, callOptions ...callopt.Option) (r *ticket.ListTicketsResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.ViewTickets(ctx, req)
+}
+
+func (p *kTicketServiceClient) PullNext(ctx context.Context, req *ticket.PullNextRequest, callOptions ...callopt.Option) (r *ticket.PullNextResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.PullNext(ctx, req)
+}
+
+func (p *kTicketServiceClient) AcquireLock(ctx context.Context, req *ticket.LockRequest, callOptions ...callopt.Option) (r *ticket.LockResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.AcquireLock(ctx, req)
+}
+
+func (p *kTicketServiceClient) ReleaseLock(ctx context.Context, req *ticket.LockRequest, callOptions ...callopt.Option) (r *ticket.LockResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.ReleaseLock(ctx, req)
+}
+
+func (p *kTicketServiceClient) ListLockEvents(ctx context.Context, req *ticket.ListLockEventsRequest, callOptions ...callopt.Option) (r *ticket.ListLockEventsResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.ListLockEvents(ctx, req)
+}
+
+func (p *kTicketServiceClient) DeleteTicket(ctx context.Context, req *ticket.TicketActionRequest, callOptions ...callopt.Option) (r *ticket.TicketResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.DeleteTicket(ctx, req)
+}
+
+func (p *kTicketServiceClient) RestoreTicket(ctx context.Context, req *ticket.TicketActionRequest, callOptions ...callopt.Option) (r *ticket.TicketResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.RestoreTicket(ctx, req)
+}
+
+func (p *kTicketServiceClient) Archive(ctx context.Context, req *ticket.TicketActionRequest, callOptions ...callopt.Option) (r *ticket.TicketResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.Archive(ctx, req)
+}
+
+func (p *kTicketServiceClient) Unarchive(ctx context.Context, req *ticket.TicketActionRequest, callOptions ...callopt.Option) (r *ticket.TicketResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.Unarchive(ctx, req)
+}
+
+func (p *kTicketServiceClient) ApplyRetention(ctx context.Context, req *ticket.ApplyRetentionRequest, callOptions ...callopt.Option) (r *ticket.RetentionReport, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.ApplyRetention(ctx, req)
+}
+
+func (p *kTicketServiceClient) ExportSubject(ctx context.Context, req *common.SubjectRequest, callOptions ...callopt.Option) (r *ticket.ExportSubjectResponse, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.ExportSubject(ctx, req)
+}
+
+func (p *kTicketServiceClient) EraseSubject(ctx context.Context, req *ticket.EraseSubjectRequest, callOptions ...callopt.Option) (r *ticket.ErasureReceipt, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.EraseSubject(ctx, req)
+}
+
+func (p *kTicketServiceClient) GetErasureReceipt(ctx context.Context, req *ticket.GetErasureReceiptRequest, callOptions ...callopt.Option) (r *ticket.ErasureReceipt, err error) {
+	ctx = client.NewCtxWithCallOptions(ctx, callOptions)
+	return p.kClient.GetErasureReceipt(ctx, req)
+}
@@ -63,6 +63,13 @@ var serviceMethods = map[string]kitex.MethodInfo{
 		false,
 		kitex.WithStreamingMode(kitex.StreamingNone),
 	),
+	"Deescalate": kitex.NewMethodInfo(
+		deescalateHandler,
+		newTicketServiceDeescalateArgs,
+		newTicketServiceDeescalateResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
 	"GetCycles": kitex.NewMethodInfo(
 		getCyclesHandler,
 		newTicketServiceGetCyclesArgs,
@@ -77,6 +84,216 @@ var serviceMethods = map[string]kitex.MethodInfo{
 		false,
 		kitex.WithStreamingMode(kitex.StreamingNone),
 	),
+	"AddComment": kitex.NewMethodInfo(
+		addCommentHandler,
+		newTicketServiceAddCommentArgs,
+		newTicketServiceAddCommentResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
+	"Watch": kitex.NewMethodInfo(
+		watchHandler,
+		newTicketServiceWatchArgs,
+		newTicketServiceWatchResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
+	"Unwatch": kitex.NewMethodInfo(
+		unwatchHandler,
+		newTicketServiceUnwatchArgs,
+		newTicketServiceUnwatchResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
+	"ListNotifications": kitex.NewMethodInfo(
+		listNotificationsHandler,
+		newTicketServiceListNotificationsArgs,
+		newTicketServiceListNotificationsResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
+	"MarkNotifications": kitex.NewMethodInfo(
+		markNotificationsHandler,
+		newTicketServiceMarkNotificationsArgs,
+		newTicketServiceMarkNotificationsResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
+	"GetNotificationPrefs": kitex.NewMethodInfo(
+		getNotificationPrefsHandler,
+		newTicketServiceGetNotificationPrefsArgs,
+		newTicketServiceGetNotificationPrefsResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
+	"SetNotificationPrefs": kitex.NewMethodInfo(
+		setNotificationPrefsHandler,
+		newTicketServiceSetNotificationPrefsArgs,
+		newTicketServiceSetNotificationPrefsResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
+	"VerifyAudit": kitex.NewMethodInfo(
+		verifyAuditHandler,
+		newTicketServiceVerifyAuditArgs,
+		newTicketServiceVerifyAuditResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
+	"ExportAuditHead": kitex.NewMethodInfo(
+		exportAuditHeadHandler,
+		newTicketServiceExportAuditHeadArgs,
+		newTicketServiceExportAuditHeadResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
+	"SubmitSurvey": kitex.NewMethodInfo(
+		submitSurveyHandler,
+		newTicketServiceSubmitSurveyArgs,
+		newTicketServiceSubmitSurveyResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
+	"CSATReport": kitex.NewMethodInfo(
+		cSATReportHandler,
+		newTicketServiceCSATReportArgs,
+		newTicketServiceCSATReportResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
+	"EscalationReport": kitex.NewMethodInfo(
+		escalationReportHandler,
+		newTicketServiceEscalationReportArgs,
+		newTicketServiceEscalationReportResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
+	"CreateView": kitex.NewMethodInfo(
+		createViewHandler,
+		newTicketServiceCreateViewArgs,
+		newTicketServiceCreateViewResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
+	"UpdateView": kitex.NewMethodInfo(
+		updateViewHandler,
+		newTicketServiceUpdateViewArgs,
+		newTicketServiceUpdateViewResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
+	"GetView": kitex.NewMethodInfo(
+		getViewHandler,
+		newTicketServiceGetViewArgs,
+		newTicketServiceGetViewResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
+	"DeleteView": kitex.NewMethodInfo(
+		deleteViewHandler,
+		newTicketServiceDeleteViewArgs,
+		newTicketServiceDeleteViewResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
+	"ListViews": kitex.NewMethodInfo(
+		listViewsHandler,
+		newTicketServiceListViewsArgs,
+		newTicketServiceListViewsResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
+	"ViewTickets": kitex.NewMethodInfo(
+		viewTicketsHandler,
+		newTicketServiceViewTicketsArgs,
+		newTicketServiceViewTicketsResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
+	"PullNext": kitex.NewMethodInfo(
+		pullNextHandler,
+		newTicketServicePullNextArgs,
+		newTicketServicePullNextResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
+	"AcquireLock": kitex.NewMethodInfo(
+		acquireLockHandler,
+		newTicketServiceAcquireLockArgs,
+		newTicketServiceAcquireLockResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
+	"ReleaseLock": kitex.NewMethodInfo(
+		releaseLockHandler,
+		newTicketServiceReleaseLockArgs,
+		newTicketServiceReleaseLockResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
+	"ListLockEvents": kitex.NewMethodInfo(
+		listLockEventsHandler,
+		newTicketServiceListLockEventsArgs,
+		newTicketServiceListLockEventsResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
+	"DeleteTicket": kitex.NewMethodInfo(
+		deleteTicketHandler,
+		newTicketServiceDeleteTicketArgs,
+		newTicketServiceDeleteTicketResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
+	"RestoreTicket": kitex.NewMethodInfo(
+		restoreTicketHandler,
+		newTicketServiceRestoreTicketArgs,
+		newTicketServiceRestoreTicketResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
+	"Archive": kitex.NewMethodInfo(
+		archiveHandler,
+		newTicketServiceArchiveArgs,
+		newTicketServiceArchiveResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
+	"Unarchive": kitex.NewMethodInfo(
+		unarchiveHandler,
+		newTicketServiceUnarchiveArgs,
+		newTicketServiceUnarchiveResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
+	"ApplyRetention": kitex.NewMethodInfo(
+		applyRetentionHandler,
+		newTicketServiceApplyRetentionArgs,
+		newTicketServiceApplyRetentionResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
+	"ExportSubject": kitex.NewMethodInfo(
+		exportSubjectHandler,
+		newTicketServiceExportSubjectArgs,
+		newTicketServiceExportSubjectResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
+	"EraseSubject": kitex.NewMethodInfo(
+		eraseSubjectHandler,
+		newTicketServiceEraseSubjectArgs,
+		newTicketServiceEraseSubjectResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
+	"GetErasureReceipt": kitex.NewMethodInfo(
+		getErasureReceiptHandler,
+		newTicketServiceGetErasureReceiptArgs,
+		newTicketServiceGetErasureReceiptResult,
+		false,
+		kitex.WithStreamingMode(kitex.StreamingNone),
+	),
 }
 
 var (
@@ -311,6 +528,30 @@ func newTicketServiceReopenResult() interface{} {
 	return ticket.NewTicketServiceReopenResult()
 }
 
+func deescalateHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*ticket.TicketServiceDeescalateArgs)
+	realResult := result.(*ticket.TicketServiceDeescalateResult)
+	success, err := handler.(ticket.TicketService).Deescalate(ctx, realArg.Req)
+	if err != nil {
+		switch v := err.(type) {
+		case *common.ServiceError:
+			realResult.Err = v
+		default:
+			return err
+		}
+	} else {
+		realResult.Success = success
+	}
+	return nil
+}
+func newTicketServiceDeescalateArgs() interface{} {
+	return ticket.NewTicketServiceDeescalateArgs()
+}
+
+func newTicketServiceDeescalateResult() interface{} {
+	return ticket.NewTicketServiceDeescalateResult()
+}
+
 func getCyclesHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
 	realArg := arg.(*ticket.TicketServiceGetCyclesArgs)
 	realResult := result.(*ticket.TicketServiceGetCyclesResult)
@@ -359,119 +600,923 @@ func newTicketServiceGetEventsResult() interface{} {
 	return ticket.NewTicketServiceGetEventsResult()
 }
 
-type kClient struct {
-	c client.Client
+func addCommentHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*ticket.TicketServiceAddCommentArgs)
+	realResult := result.(*ticket.TicketServiceAddCommentResult)
+	success, err := handler.(ticket.TicketService).AddComment(ctx, realArg.Req)
+	if err != nil {
+		switch v := err.(type) {
+		case *common.ServiceError:
+			realResult.Err = v
+		default:
+			return err
+		}
+	} else {
+		realResult.Success = success
+	}
+	return nil
+}
+func newTicketServiceAddCommentArgs() interface{} {
+	return ticket.NewTicketServiceAddCommentArgs()
 }
 
-func newServiceClient(c client.Client) *kClient {
-	return &kClient{
-		c: c,
-	}
+func newTicketServiceAddCommentResult() interface{} {
+	return ticket.NewTicketServiceAddCommentResult()
 }
 
-func (p *kClient) CreateTicket(ctx context.Context, req *ticket.CreateTicketRequest) (r *ticket.TicketResponse, err error) {
-	var _args ticket.TicketServiceCreateTicketArgs
-	_args.Req = req
-	var _result ticket.TicketServiceCreateTicketResult
-	if err = p.c.Call(ctx, "CreateTicket", &_args, &_result); err != nil {
-		return
-	}
-	switch {
-	case _result.Err != nil:
-		return r, _result.Err
+func watchHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*ticket.TicketServiceWatchArgs)
+	realResult := result.(*ticket.TicketServiceWatchResult)
+	success, err := handler.(ticket.TicketService).Watch(ctx, realArg.Req)
+	if err != nil {
+		switch v := err.(type) {
+		case *common.ServiceError:
+			realResult.Err = v
+		default:
+			return err
+		}
+	} else {
+		realResult.Success = success
 	}
-	return _result.GetSuccess(), nil
+	return nil
+}
+func newTicketServiceWatchArgs() interface{} {
+	return ticket.NewTicketServiceWatchArgs()
 }
 
-func (p *kClient) GetTicket(ctx context.Context, req *ticket.GetTicketRequest) (r *ticket.TicketResponse, err error) {
-	var _args ticket.TicketServiceGetTicketArgs
-	_args.Req = req
-	var _result ticket.TicketServiceGetTicketResult
-	if err = p.c.Call(ctx, "GetTicket", &_args, &_result); err != nil {
-		return
-	}
-	switch {
-	case _result.Err != nil:
-		return r, _result.Err
-	}
-	return _result.GetSuccess(), nil
+func newTicketServiceWatchResult() interface{} {
+	return ticket.NewTicketServiceWatchResult()
 }
 
-func (p *kClient) ListTickets(ctx context.Context, req *ticket.ListTicketsRequest) (r *ticket.ListTicketsResponse, err error) {
-	var _args ticket.TicketServiceListTicketsArgs
-	_args.Req = req
-	var _result ticket.TicketServiceListTicketsResult
-	if err = p.c.Call(ctx, "ListTickets", &_args, &_result); err != nil {
-		return
-	}
-	switch {
-	case _result.Err != nil:
-		return r, _result.Err
+func unwatchHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*ticket.TicketServiceUnwatchArgs)
+	realResult := result.(*ticket.TicketServiceUnwatchResult)
+	success, err := handler.(ticket.TicketService).Unwatch(ctx, realArg.Req)
+	if err != nil {
+		switch v := err.(type) {
+		case *common.ServiceError:
+			realResult.Err = v
+		default:
+			return err
+		}
+	} else {
+		realResult.Success = success
 	}
-	return _result.GetSuccess(), nil
+	return nil
+}
+func newTicketServiceUnwatchArgs() interface{} {
+	return ticket.NewTicketServiceUnwatchArgs()
 }
 
-func (p *kClient) Assign(ctx context.Context, req *ticket.TicketActionRequest) (r *ticket.TicketResponse, err error) {
-	var _args ticket.TicketServiceAssignArgs
-	_args.Req = req
-	var _result ticket.TicketServiceAssignResult
-	if err = p.c.Call(ctx, "Assign", &_args, &_result); err != nil {
-		return
-	}
-	switch {
-	case _result.Err != nil:
-		return r, _result.Err
-	}
-	return _result.GetSuccess(), nil
+func newTicketServiceUnwatchResult() interface{} {
+	return ticket.NewTicketServiceUnwatchResult()
 }
 
-func (p *kClient) Resolve(ctx context.Context, req *ticket.TicketActionRequest) (r *ticket.TicketResponse, err error) {
-	var _args ticket.TicketServiceResolveArgs
-	_args.Req = req
-	var _result ticket.TicketServiceResolveResult
-	if err = p.c.Call(ctx, "Resolve", &_args, &_result); err != nil {
-		return
-	}
-	switch {
-	case _result.Err != nil:
-		return r, _result.Err
+func listNotificationsHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*ticket.TicketServiceListNotificationsArgs)
+	realResult := result.(*ticket.TicketServiceListNotificationsResult)
+	success, err := handler.(ticket.TicketService).ListNotifications(ctx, realArg.Req)
+	if err != nil {
+		switch v := err.(type) {
+		case *common.ServiceError:
+			realResult.Err = v
+		default:
+			return err
+		}
+	} else {
+		realResult.Success = success
 	}
-	return _result.GetSuccess(), nil
+	return nil
+}
+func newTicketServiceListNotificationsArgs() interface{} {
+	return ticket.NewTicketServiceListNotificationsArgs()
 }
 
-func (p *kClient) Escalate(ctx context.Context, req *ticket.TicketActionRequest) (r *ticket.TicketResponse, err error) {
-	var _args ticket.TicketServiceEscalateArgs
-	_args.Req = req
-	var _result ticket.TicketServiceEscalateResult
-	if err = p.c.Call(ctx, "Escalate", &_args, &_result); err != nil {
-		return
-	}
-	switch {
-	case _result.Err != nil:
-		return r, _result.Err
-	}
-	return _result.GetSuccess(), nil
+func newTicketServiceListNotificationsResult() interface{} {
+	return ticket.NewTicketServiceListNotificationsResult()
 }
 
-func (p *kClient) Reopen(ctx context.Context, req *ticket.TicketActionRequest) (r *ticket.TicketResponse, err error) {
-	var _args ticket.TicketServiceReopenArgs
-	_args.Req = req
-	var _result ticket.TicketServiceReopenResult
-	if err = p.c.Call(ctx, "Reopen", &_args, &_result); err != nil {
-		return
-	}
-	switch {
-	case _result.Err != nil:
-		return r, _result.Err
+func markNotificationsHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*ticket.TicketServiceMarkNotificationsArgs)
+	realResult := result.(*ticket.TicketServiceMarkNotificationsResult)
+	success, err := handler.(ticket.TicketService).MarkNotifications(ctx, realArg.Req)
+	if err != nil {
+		switch v := err.(type) {
+		case *common.ServiceError:
+			realResult.Err = v
+		default:
+			return err
+		}
+	} else {
+		realResult.Success = success
 	}
-	return _result.GetSuccess(), nil
+	return nil
+}
+func newTicketServiceMarkNotificationsArgs() interface{} {
+	return ticket.NewTicketServiceMarkNotificationsArgs()
 }
 
-func (p *kClient) GetCycles(ctx context.Context, req *ticket.GetCyclesRequest) (r []*common.TicketCycle, err error) {
-	var _args ticket.TicketServiceGetCyclesArgs
-	_args.Req = req
-	var _result ticket.TicketServiceGetCyclesResult
-	if err = p.c.Call(ctx, "GetCycles", &_args, &_result); err != nil {
+func newTicketServiceMarkNotificationsResult() interface{} {
+	return ticket.NewTicketServiceMarkNotificationsResult()
+}
+
+func getNotificationPrefsHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*ticket.TicketServiceGetNotificationPrefsArgs)
+	realResult := result.(*ticket.TicketServiceGetNotificationPrefsResult)
+	success, err := handler.(ticket.TicketService).GetNotificationPrefs(ctx, realArg.Req)
+	if err != nil {
+		switch v := err.(type) {
+		case *common.ServiceError:
+			realResult.Err = v
+		default:
+			return err
+		}
+	} else {
+		realResult.Success = success
+	}
+	return nil
+}
+func newTicketServiceGetNotificationPrefsArgs() interface{} {
+	return ticket.NewTicketServiceGetNotificationPrefsArgs()
+}
+
+func newTicketServiceGetNotificationPrefsResult() interface{} {
+	return ticket.NewTicketServiceGetNotificationPrefsResult()
+}
+
+func setNotificationPrefsHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*ticket.TicketServiceSetNotificationPrefsArgs)
+	realResult := result.(*ticket.TicketServiceSetNotificationPrefsResult)
+	success, err := handler.(ticket.TicketService).SetNotificationPrefs(ctx, realArg.Req)
+	if err != nil {
+		switch v := err.(type) {
+		case *common.ServiceError:
+			realResult.Err = v
+		default:
+			return err
+		}
+	} else {
+		realResult.Success = success
+	}
+	return nil
+}
+func newTicketServiceSetNotificationPrefsArgs() interface{} {
+	return ticket.NewTicketServiceSetNotificationPrefsArgs()
+}
+
+func newTicketServiceSetNotificationPrefsResult() interface{} {
+	return ticket.NewTicketServiceSetNotificationPrefsResult()
+}
+
+func verifyAuditHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*ticket.TicketServiceVerifyAuditArgs)
+	realResult := result.(*ticket.TicketServiceVerifyAuditResult)
+	success, err := handler.(ticket.TicketService).VerifyAudit(ctx, realArg.Req)
+	if err != nil {
+		switch v := err.(type) {
+		case *common.ServiceError:
+			realResult.Err = v
+		default:
+			return err
+		}
+	} else {
+		realResult.Success = success
+	}
+	return nil
+}
+func newTicketServiceVerifyAuditArgs() interface{} {
+	return ticket.NewTicketServiceVerifyAuditArgs()
+}
+
+func newTicketServiceVerifyAuditResult() interface{} {
+	return ticket.NewTicketServiceVerifyAuditResult()
+}
+
+func exportAuditHeadHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*ticket.TicketServiceExportAuditHeadArgs)
+	realResult := result.(*ticket.TicketServiceExportAuditHeadResult)
+	success, err := handler.(ticket.TicketService).ExportAuditHead(ctx, realArg.Req)
+	if err != nil {
+		switch v := err.(type) {
+		case *common.ServiceError:
+			realResult.Err = v
+		default:
+			return err
+		}
+	} else {
+		realResult.Success = success
+	}
+	return nil
+}
+func newTicketServiceExportAuditHeadArgs() interface{} {
+	return ticket.NewTicketServiceExportAuditHeadArgs()
+}
+
+func newTicketServiceExportAuditHeadResult() interface{} {
+	return ticket.NewTicketServiceExportAuditHeadResult()
+}
+
+func submitSurveyHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*ticket.TicketServiceSubmitSurveyArgs)
+	realResult := result.(*ticket.TicketServiceSubmitSurveyResult)
+	success, err := handler.(ticket.TicketService).SubmitSurvey(ctx, realArg.Req)
+	if err != nil {
+		switch v := err.(type) {
+		case *common.ServiceError:
+			realResult.Err = v
+		default:
+			return err
+		}
+	} else {
+		realResult.Success = success
+	}
+	return nil
+}
+func newTicketServiceSubmitSurveyArgs() interface{} {
+	return ticket.NewTicketServiceSubmitSurveyArgs()
+}
+
+func newTicketServiceSubmitSurveyResult() interface{} {
+	return ticket.NewTicketServiceSubmitSurveyResult()
+}
+
+func cSATReportHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*ticket.TicketServiceCSATReportArgs)
+	realResult := result.(*ticket.TicketServiceCSATReportResult)
+	success, err := handler.(ticket.TicketService).CSATReport(ctx, realArg.Req)
+	if err != nil {
+		switch v := err.(type) {
+		case *common.ServiceError:
+			realResult.Err = v
+		default:
+			return err
+		}
+	} else {
+		realResult.Success = success
+	}
+	return nil
+}
+func newTicketServiceCSATReportArgs() interface{} {
+	return ticket.NewTicketServiceCSATReportArgs()
+}
+
+func newTicketServiceCSATReportResult() interface{} {
+	return ticket.NewTicketServiceCSATReportResult()
+}
+
+func escalationReportHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*ticket.TicketServiceEscalationReportArgs)
+	realResult := result.(*ticket.TicketServiceEscalationReportResult)
+	success, err := handler.(ticket.TicketService).EscalationReport(ctx, realArg.Req)
+	if err != nil {
+		switch v := err.(type) {
+		case *common.ServiceError:
+			realResult.Err = v
+		default:
+			return err
+		}
+	} else {
+		realResult.Success = success
+	}
+	return nil
+}
+func newTicketServiceEscalationReportArgs() interface{} {
+	return ticket.NewTicketServiceEscalationReportArgs()
+}
+
+func newTicketServiceEscalationReportResult() interface{} {
+	return ticket.NewTicketServiceEscalationReportResult()
+}
+
+func createViewHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*ticket.TicketServiceCreateViewArgs)
+	realResult := result.(*ticket.TicketServiceCreateViewResult)
+	success, err := handler.(ticket.TicketService).CreateView(ctx, realArg.Req)
+	if err != nil {
+		switch v := err.(type) {
+		case *common.ServiceError:
+			realResult.Err = v
+		default:
+			return err
+		}
+	} else {
+		realResult.Success = success
+	}
+	return nil
+}
+func newTicketServiceCreateViewArgs() interface{} {
+	return ticket.NewTicketServiceCreateViewArgs()
+}
+
+func newTicketServiceCreateViewResult() interface{} {
+	return ticket.NewTicketServiceCreateViewResult()
+}
+
+func updateViewHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*ticket.TicketServiceUpdateViewArgs)
+	realResult := result.(*ticket.TicketServiceUpdateViewResult)
+	success, err := handler.(ticket.TicketService).UpdateView(ctx, realArg.Req)
+	if err != nil {
+		switch v := err.(type) {
+		case *common.ServiceError:
+			realResult.Err = v
+		default:
+			return err
+		}
+	} else {
+		realResult.Success = success
+	}
+	return nil
+}
+func newTicketServiceUpdateViewArgs() interface{} {
+	return ticket.NewTicketServiceUpdateViewArgs()
+}
+
+func newTicketServiceUpdateViewResult() interface{} {
+	return ticket.NewTicketServiceUpdateViewResult()
+}
+
+func getViewHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*ticket.TicketServiceGetViewArgs)
+	realResult := result.(*ticket.TicketServiceGetViewResult)
+	success, err := handler.(ticket.TicketService).GetView(ctx, realArg.Req)
+	if err != nil {
+		switch v := err.(type) {
+		case *common.ServiceError:
+			realResult.Err = v
+		default:
+			return err
+		}
+	} else {
+		realResult.Success = success
+	}
+	return nil
+}
+func newTicketServiceGetViewArgs() interface{} {
+	return ticket.NewTicketServiceGetViewArgs()
+}
+
+func newTicketServiceGetViewResult() interface{} {
+	return ticket.NewTicketServiceGetViewResult()
+}
+
+func deleteViewHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*ticket.TicketServiceDeleteViewArgs)
+	realResult := result.(*ticket.TicketServiceDeleteViewResult)
+	success, err := handler.(ticket.TicketService).DeleteView(ctx, realArg.Req)
+	if err != nil {
+		switch v := err.(type) {
+		case *common.ServiceError:
+			realResult.Err = v
+		default:
+			return err
+		}
+	} else {
+		realResult.Success = success
+	}
+	return nil
+}
+func newTicketServiceDeleteViewArgs() interface{} {
+	return ticket.NewTicketServiceDeleteViewArgs()
+}
+
+func newTicketServiceDeleteViewResult() interface{} {
+	return ticket.NewTicketServiceDeleteViewResult()
+}
+
+func listViewsHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*ticket.TicketServiceListViewsArgs)
+	realResult := result.(*ticket.TicketServiceListViewsResult)
+	success, err := handler.(ticket.TicketService).ListViews(ctx, realArg.Req)
+	if err != nil {
+		switch v := err.(type) {
+		case *common.ServiceError:
+			realResult.Err = v
+		default:
+			return err
+		}
+	} else {
+		realResult.Success = success
+	}
+	return nil
+}
+func newTicketServiceListViewsArgs() interface{} {
+	return ticket.NewTicketServiceListViewsArgs()
+}
+
+func newTicketServiceListViewsResult() interface{} {
+	return ticket.NewTicketServiceListViewsResult()
+}
+
+func viewTicketsHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*ticket.TicketServiceViewTicketsArgs)
+	realResult := result.(*ticket.TicketServiceViewTicketsResult)
+	success, err := handler.(ticket.TicketService).ViewTickets(ctx, realArg.Req)
+	if err != nil {
+		switch v := err.(type) {
+		case *common.ServiceError:
+			realResult.Err = v
+		default:
+			return err
+		}
+	} else {
+		realResult.Success = success
+	}
+	return nil
+}
+func newTicketServiceViewTicketsArgs() interface{} {
+	return ticket.NewTicketServiceViewTicketsArgs()
+}
+
+func newTicketServiceViewTicketsResult() interface{} {
+	return ticket.NewTicketServiceViewTicketsResult()
+}
+
+func pullNextHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*ticket.TicketServicePullNextArgs)
+	realResult := result.(*ticket.TicketServicePullNextResult)
+	success, err := handler.(ticket.TicketService).PullNext(ctx, realArg.Req)
+	if err != nil {
+		switch v := err.(type) {
+		case *common.ServiceError:
+			realResult.Err = v
+		default:
+			return err
+		}
+	} else {
+		realResult.Success = success
+	}
+	return nil
+}
+func newTicketServicePullNextArgs() interface{} {
+	return ticket.NewTicketServicePullNextArgs()
+}
+
+func newTicketServicePullNextResult() interface{} {
+	return ticket.NewTicketServicePullNextResult()
+}
+
+func acquireLockHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*ticket.TicketServiceAcquireLockArgs)
+	realResult := result.(*ticket.TicketServiceAcquireLockResult)
+	success, err := handler.(ticket.TicketService).AcquireLock(ctx, realArg.Req)
+	if err != nil {
+		switch v := err.(type) {
+		case *common.ServiceError:
+			realResult.Err = v
+		default:
+			return err
+		}
+	} else {
+		realResult.Success = success
+	}
+	return nil
+}
+func newTicketServiceAcquireLockArgs() interface{} {
+	return ticket.NewTicketServiceAcquireLockArgs()
+}
+
+func newTicketServiceAcquireLockResult() interface{} {
+	return ticket.NewTicketServiceAcquireLockResult()
+}
+
+func releaseLockHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*ticket.TicketServiceReleaseLockArgs)
+	realResult := result.(*ticket.TicketServiceReleaseLockResult)
+	success, err := handler.(ticket.TicketService).ReleaseLock(ctx, realArg.Req)
+	if err != nil {
+		switch v := err.(type) {
+		case *common.ServiceError:
+			realResult.Err = v
+		default:
+			return err
+		}
+	} else {
+		realResult.Success = success
+	}
+	return nil
+}
+func newTicketServiceReleaseLockArgs() interface{} {
+	return ticket.NewTicketServiceReleaseLockArgs()
+}
+
+func newTicketServiceReleaseLockResult() interface{} {
+	return ticket.NewTicketServiceReleaseLockResult()
+}
+
+func listLockEventsHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*ticket.TicketServiceListLockEventsArgs)
+	realResult := result.(*ticket.TicketServiceListLockEventsResult)
+	success, err := handler.(ticket.TicketService).ListLockEvents(ctx, realArg.Req)
+	if err != nil {
+		switch v := err.(type) {
+		case *common.ServiceError:
+			realResult.Err = v
+		default:
+			return err
+		}
+	} else {
+		realResult.Success = success
+	}
+	return nil
+}
+func newTicketServiceListLockEventsArgs() interface{} {
+	return ticket.NewTicketServiceListLockEventsArgs()
+}
+
+func newTicketServiceListLockEventsResult() interface{} {
+	return ticket.NewTicketServiceListLockEventsResult()
+}
+
+func deleteTicketHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*ticket.TicketServiceDeleteTicketArgs)
+	realResult := result.(*ticket.TicketServiceDeleteTicketResult)
+	success, err := handler.(ticket.TicketService).DeleteTicket(ctx, realArg.Req)
+	if err != nil {
+		switch v := err.(type) {
+		case *common.ServiceError:
+			realResult.Err = v
+		default:
+			return err
+		}
+	} else {
+		realResult.Success = success
+	}
+	return nil
+}
+func newTicketServiceDeleteTicketArgs() interface{} {
+	return ticket.NewTicketServiceDeleteTicketArgs()
+}
+
+func newTicketServiceDeleteTicketResult() interface{} {
+	return ticket.NewTicketServiceDeleteTicketResult()
+}
+
+func restoreTicketHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*ticket.TicketServiceRestoreTicketArgs)
+	realResult := result.(*ticket.TicketServiceRestoreTicketResult)
+	success, err := handler.(ticket.TicketService).RestoreTicket(ctx, realArg.Req)
+	if err != nil {
+		switch v := err.(type) {
+		case *common.ServiceError:
+			realResult.Err = v
+		default:
+			return err
+		}
+	} else {
+		realResult.Success = success
+	}
+	return nil
+}
+func newTicketServiceRestoreTicketArgs() interface{} {
+	return ticket.NewTicketServiceRestoreTicketArgs()
+}
+
+func newTicketServiceRestoreTicketResult() interface{} {
+	return ticket.NewTicketServiceRestoreTicketResult()
+}
+
+func archiveHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*ticket.TicketServiceArchiveArgs)
+	realResult := result.(*ticket.TicketServiceArchiveResult)
+	success, err := handler.(ticket.TicketService).Archive(ctx, realArg.Req)
+	if err != nil {
+		switch v := err.(type) {
+		case *common.ServiceError:
+			realResult.Err = v
+		default:
+			return err
+		}
+	} else {
+		realResult.Success = success
+	}
+	return nil
+}
+func newTicketServiceArchiveArgs() interface{} {
+	return ticket.NewTicketServiceArchiveArgs()
+}
+
+func newTicketServiceArchiveResult() interface{} {
+	return ticket.NewTicketServiceArchiveResult()
+}
+
+func unarchiveHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*ticket.TicketServiceUnarchiveArgs)
+	realResult := result.(*ticket.TicketServiceUnarchiveResult)
+	success, err := handler.(ticket.TicketService).Unarchive(ctx, realArg.Req)
+	if err != nil {
+		switch v := err.(type) {
+		case *common.ServiceError:
+			realResult.Err = v
+		default:
+			return err
+		}
+	} else {
+		realResult.Success = success
+	}
+	return nil
+}
+func newTicketServiceUnarchiveArgs() interface{} {
+	return ticket.NewTicketServiceUnarchiveArgs()
+}
+
+func newTicketServiceUnarchiveResult() interface{} {
+	return ticket.NewTicketServiceUnarchiveResult()
+}
+
+func applyRetentionHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*ticket.TicketServiceApplyRetentionArgs)
+	realResult := result.(*ticket.TicketServiceApplyRetentionResult)
+	success, err := handler.(ticket.TicketService).ApplyRetention(ctx, realArg.Req)
+	if err != nil {
+		switch v := err.(type) {
+		case *common.ServiceError:
+			realResult.Err = v
+		default:
+			return err
+		}
+	} else {
+		realResult.Success = success
+	}
+	return nil
+}
+func newTicketServiceApplyRetentionArgs() interface{} {
+	return ticket.NewTicketServiceApplyRetentionArgs()
+}
+
+func newTicketServiceApplyRetentionResult() interface{} {
+	return ticket.NewTicketServiceApplyRetentionResult()
+}
+
+func exportSubjectHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*ticket.TicketServiceExportSubjectArgs)
+	realResult := result.(*ticket.TicketServiceExportSubjectResult)
+	success, err := handler.(ticket.TicketService).ExportSubject(ctx, realArg.Req)
+	if err != nil {
+		switch v := err.(type) {
+		case *common.ServiceError:
+			realResult.Err = v
+		default:
+			return err
+		}
+	} else {
+		realResult.Success = success
+	}
+	return nil
+}
+func newTicketServiceExportSubjectArgs() interface{} {
+	return ticket.NewTicketServiceExportSubjectArgs()
+}
+
+func newTicketServiceExportSubjectResult() interface{} {
+	return ticket.NewTicketServiceExportSubjectResult()
+}
+
+func eraseSubjectHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*ticket.TicketServiceEraseSubjectArgs)
+	realResult := result.(*ticket.TicketServiceEraseSubjectResult)
+	success, err := handler.(ticket.TicketService).EraseSubject(ctx, realArg.Req)
+	if err != nil {
+		switch v := err.(type) {
+		case *common.ServiceError:
+			realResult.Err = v
+		default:
+			return err
+		}
+	} else {
+		realResult.Success = success
+	}
+	return nil
+}
+func newTicketServiceEraseSubjectArgs() interface{} {
+	return ticket.NewTicketServiceEraseSubjectArgs()
+}
+
+func newTicketServiceEraseSubjectResult() interface{} {
+	return ticket.NewTicketServiceEraseSubjectResult()
+}
+
+func getErasureReceiptHandler(ctx context.Context, handler interface{}, arg, result interface{}) error {
+	realArg := arg.(*ticket.TicketServiceGetErasureReceiptArgs)
+	realResult := result.(*ticket.TicketServiceGetErasureReceiptResult)
+	success, err := handler.(ticket.TicketService).GetErasureReceipt(ctx, realArg.Req)
+	if err != nil {
+		switch v := err.(type) {
+		case *common.ServiceError:
+			realResult.Err = v
+		default:
+			return err
+		}
+	} else {
+		realResult.Success = success
+	}
+	return nil
+}
+func newTicketServiceGetErasureReceiptArgs() interface{} {
+	return ticket.NewTicketServiceGetErasureReceiptArgs()
+}
+
+func newTicketServiceGetErasureReceiptResult() interface{} {
+	return ticket.NewTicketServiceGetErasureReceiptResult()
+}
+
+type kClient struct {
+	c client.Client
+}
+
+func newServiceClient(c client.Client) *kClient {
+	return &kClient{
+		c: c,
+	}
+}
+
+func (p *kClient) CreateTicket(ctx context.Context, req *ticket.CreateTicketRequest) (r *ticket.TicketResponse, err error) {
+	var _args ticket.TicketServiceCreateTicketArgs
+	_args.Req = req
+	var _result ticket.TicketServiceCreateTicketResult
+	if err = p.c.Call(ctx, "CreateTicket", &_args, &_result); err != nil {
+		return
+	}
+	switch {
+	case _result.Err != nil:
+		return r, _result.Err
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) GetTicket(ctx context.Context, req *ticket.GetTicketRequest) (r *ticket.TicketResponse, err error) {
+	var _args ticket.TicketServiceGetTicketArgs
+	_args.Req = req
+	var _result ticket.TicketServiceGetTicketResult
+	if err = p.c.Call(ctx, "GetTicket", &_args, &_result); err != nil {
+		return
+	}
+	switch {
+	case _result.Err != nil:
+		return r, _result.Err
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) ListTickets(ctx context.Context, req *ticket.ListTicketsRequest) (r *ticket.ListTicketsResponse, err error) {
+	var _args ticket.TicketServiceListTicketsArgs
+	_args.Req = req
+	var _result ticket.TicketServiceListTicketsResult
+	if err = p.c.Call(ctx, "ListTickets", &_args, &_result); err != nil {
+		return
+	}
+	switch {
+	case _result.Err != nil:
+		return r, _result.Err
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) Assign(ctx context.Context, req *ticket.TicketActionRequest) (r *ticket.TicketResponse, err error) {
+	var _args ticket.TicketServiceAssignArgs
+	_args.Req = req
+	var _result ticket.TicketServiceAssignResult
+	if err = p.c.Call(ctx, "Assign", &_args, &_result); err != nil {
+		return
+	}
+	switch {
+	case _result.Err != nil:
+		return r, _result.Err
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) Resolve(ctx context.Context, req *ticket.TicketActionRequest) (r *ticket.TicketResponse, err error) {
+	var _args ticket.TicketServiceResolveArgs
+	_args.Req = req
+	var _result ticket.TicketServiceResolveResult
+	if err = p.c.Call(ctx, "Resolve", &_args, &_result); err != nil {
+		return
+	}
+	switch {
+	case _result.Err != nil:
+		return r, _result.Err
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) Escalate(ctx context.Context, req *ticket.TicketActionRequest) (r *ticket.TicketResponse, err error) {
+	var _args ticket.TicketServiceEscalateArgs
+	_args.Req = req
+	var _result ticket.TicketServiceEscalateResult
+	if err = p.c.Call(ctx, "Escalate", &_args, &_result); err != nil {
+		return
+	}
+	switch {
+	case _result.Err != nil:
+		return r, _result.Err
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) Reopen(ctx context.Context, req *ticket.TicketActionRequest) (r *ticket.TicketResponse, err error) {
+	var _args ticket.TicketServiceReopenArgs
+	_args.Req = req
+	var _result ticket.TicketServiceReopenResult
+	if err = p.c.Call(ctx, "Reopen", &_args, &_result); err != nil {
+		return
+	}
+	switch {
+	case _result.Err != nil:
+		return r, _result.Err
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) Deescalate(ctx context.Context, req *ticket.TicketActionRequest) (r *ticket.TicketResponse, err error) {
+	var _args ticket.TicketServiceDeescalateArgs
+	_args.Req = req
+	var _result ticket.TicketServiceDeescalateResult
+	if err = p.c.Call(ctx, "Deescalate", &_args, &_result); err != nil {
+		return
+	}
+	switch {
+	case _result.Err != nil:
+		return r, _result.Err
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) GetCycles(ctx context.Context, req *ticket.GetCyclesRequest) (r []*common.TicketCycle, err error) {
+	var _args ticket.TicketServiceGetCyclesArgs
+	_args.Req = req
+	var _result ticket.TicketServiceGetCyclesResult
+	if err = p.c.Call(ctx, "GetCycles", &_args, &_result); err != nil {
+		return
+	}
+	switch {
+	case _result.Err != nil:
+		return r, _result.Err
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) GetEvents(ctx context.Context, req *ticket.GetEventsRequest) (r []*common.TicketEvent, err error) {
+	var _args ticket.TicketServiceGetEventsArgs
+	_args.Req = req
+	var _result ticket.TicketServiceGetEventsResult
+	if err = p.c.Call(ctx, "GetEvents", &_args, &_result); err != nil {
+		return
+	}
+	switch {
+	case _result.Err != nil:
+		return r, _result.Err
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) AddComment(ctx context.Context, req *ticket.AddCommentRequest) (r *ticket.TicketResponse, err error) {
+	var _args ticket.TicketServiceAddCommentArgs
+	_args.Req = req
+	var _result ticket.TicketServiceAddCommentResult
+	if err = p.c.Call(ctx, "AddComment", &_args, &_result); err != nil {
+		return
+	}
+	switch {
+	case _result.Err != nil:
+		return r, _result.Err
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) Watch(ctx context.Context, req *ticket.WatchRequest) (r *ticket.TicketResponse, err error) {
+	var _args ticket.TicketServiceWatchArgs
+	_args.Req = req
+	var _result ticket.TicketServiceWatchResult
+	if err = p.c.Call(ctx, "Watch", &_args, &_result); err != nil {
+		return
+	}
+	switch {
+	case _result.Err != nil:
+		return r, _result.Err
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) Unwatch(ctx context.Context, req *ticket.WatchRequest) (r *ticket.TicketResponse, err error) {
+	var _args ticket.TicketServiceUnwatchArgs
+	_args.Req = req
+	var _result ticket.TicketServiceUnwatchResult
+	if err = p.c.Call(ctx, "Unwatch", &_args, &_result); err != nil {
+		return
+	}
+	switch {
+	case _result.Err != nil:
+		return r, _result.Err
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) ListNotifications(ctx context.Context, req *ticket.ListNotificationsRequest) (r *ticket.ListNotificationsResponse, err error) {
+	var _args ticket.TicketServiceListNotificationsArgs
+	_args.Req = req
+	var _result ticket.TicketServiceListNotificationsResult
+	if err = p.c.Call(ctx, "ListNotifications", &_args, &_result); err != nil {
 		return
 	}
 	switch {
@@ -481,11 +1526,361 @@ func (p *kClient) GetCycles(ctx context.Context, req *ticket.GetCyclesRequest) (
 	return _result.GetSuccess(), nil
 }
 
-func (p *kClient) GetEvents(ctx context.Context, req *ticket.GetEventsRequest) (r []*common.TicketEvent, err error) {
-	var _args ticket.TicketServiceGetEventsArgs
+func (p *kClient) MarkNotifications(ctx context.Context, req *ticket.MarkNotificationsRequest) (r *ticket.MarkNotificationsResponse, err error) {
+	var _args ticket.TicketServiceMarkNotificationsArgs
 	_args.Req = req
-	var _result ticket.TicketServiceGetEventsResult
-	if err = p.c.Call(ctx, "GetEvents", &_args, &_result); err != nil {
+	var _result ticket.TicketServiceMarkNotificationsResult
+	if err = p.c.Call(ctx, "MarkNotifications", &_args, &_result); err != nil {
+		return
+	}
+	switch {
+	case _result.Err != nil:
+		return r, _result.Err
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) GetNotificationPrefs(ctx context.Context, req *ticket.GetNotificationPrefsRequest) (r *ticket.NotificationPrefs, err error) {
+	var _args ticket.TicketServiceGetNotificationPrefsArgs
+	_args.Req = req
+	var _result ticket.TicketServiceGetNotificationPrefsResult
+	if err = p.c.Call(ctx, "GetNotificationPrefs", &_args, &_result); err != nil {
+		return
+	}
+	switch {
+	case _result.Err != nil:
+		return r, _result.Err
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) SetNotificationPrefs(ctx context.Context, req *ticket.SetNotificationPrefsRequest) (r *ticket.NotificationPrefs, err error) {
+	var _args ticket.TicketServiceSetNotificationPrefsArgs
+	_args.Req = req
+	var _result ticket.TicketServiceSetNotificationPrefsResult
+	if err = p.c.Call(ctx, "SetNotificationPrefs", &_args, &_result); err != nil {
+		return
+	}
+	switch {
+	case _result.Err != nil:
+		return r, _result.Err
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) VerifyAudit(ctx context.Context, req *ticket.VerifyAuditRequest) (r *ticket.VerifyAuditResponse, err error) {
+	var _args ticket.TicketServiceVerifyAuditArgs
+	_args.Req = req
+	var _result ticket.TicketServiceVerifyAuditResult
+	if err = p.c.Call(ctx, "VerifyAudit", &_args, &_result); err != nil {
+		return
+	}
+	switch {
+	case _result.Err != nil:
+		return r, _result.Err
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) ExportAuditHead(ctx context.Context, req *ticket.ExportAuditHeadRequest) (r *ticket.AuditHead, err error) {
+	var _args ticket.TicketServiceExportAuditHeadArgs
+	_args.Req = req
+	var _result ticket.TicketServiceExportAuditHeadResult
+	if err = p.c.Call(ctx, "ExportAuditHead", &_args, &_result); err != nil {
+		return
+	}
+	switch {
+	case _result.Err != nil:
+		return r, _result.Err
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) SubmitSurvey(ctx context.Context, req *ticket.SubmitSurveyRequest) (r *ticket.SubmitSurveyResponse, err error) {
+	var _args ticket.TicketServiceSubmitSurveyArgs
+	_args.Req = req
+	var _result ticket.TicketServiceSubmitSurveyResult
+	if err = p.c.Call(ctx, "SubmitSurvey", &_args, &_result); err != nil {
+		return
+	}
+	switch {
+	case _result.Err != nil:
+		return r, _result.Err
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) CSATReport(ctx context.Context, req *ticket.CSATReportRequest) (r *ticket.CSATReportResponse, err error) {
+	var _args ticket.TicketServiceCSATReportArgs
+	_args.Req = req
+	var _result ticket.TicketServiceCSATReportResult
+	if err = p.c.Call(ctx, "CSATReport", &_args, &_result); err != nil {
+		return
+	}
+	switch {
+	case _result.Err != nil:
+		return r, _result.Err
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) EscalationReport(ctx context.Context, req *ticket.EscalationReportRequest) (r *ticket.EscalationReportResponse, err error) {
+	var _args ticket.TicketServiceEscalationReportArgs
+	_args.Req = req
+	var _result ticket.TicketServiceEscalationReportResult
+	if err = p.c.Call(ctx, "EscalationReport", &_args, &_result); err != nil {
+		return
+	}
+	switch {
+	case _result.Err != nil:
+		return r, _result.Err
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) CreateView(ctx context.Context, req *ticket.SaveViewRequest) (r *ticket.SavedView, err error) {
+	var _args ticket.TicketServiceCreateViewArgs
+	_args.Req = req
+	var _result ticket.TicketServiceCreateViewResult
+	if err = p.c.Call(ctx, "CreateView", &_args, &_result); err != nil {
+		return
+	}
+	switch {
+	case _result.Err != nil:
+		return r, _result.Err
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) UpdateView(ctx context.Context, req *ticket.SaveViewRequest) (r *ticket.SavedView, err error) {
+	var _args ticket.TicketServiceUpdateViewArgs
+	_args.Req = req
+	var _result ticket.TicketServiceUpdateViewResult
+	if err = p.c.Call(ctx, "UpdateView", &_args, &_result); err != nil {
+		return
+	}
+	switch {
+	case _result.Err != nil:
+		return r, _result.Err
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) GetView(ctx context.Context, req *ticket.ViewRequest) (r *ticket.SavedView, err error) {
+	var _args ticket.TicketServiceGetViewArgs
+	_args.Req = req
+	var _result ticket.TicketServiceGetViewResult
+	if err = p.c.Call(ctx, "GetView", &_args, &_result); err != nil {
+		return
+	}
+	switch {
+	case _result.Err != nil:
+		return r, _result.Err
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) DeleteView(ctx context.Context, req *ticket.ViewRequest) (r *ticket.DeleteViewResponse, err error) {
+	var _args ticket.TicketServiceDeleteViewArgs
+	_args.Req = req
+	var _result ticket.TicketServiceDeleteViewResult
+	if err = p.c.Call(ctx, "DeleteView", &_args, &_result); err != nil {
+		return
+	}
+	switch {
+	case _result.Err != nil:
+		return r, _result.Err
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) ListViews(ctx context.Context, req *ticket.ListViewsRequest) (r *ticket.ListViewsResponse, err error) {
+	var _args ticket.TicketServiceListViewsArgs
+	_args.Req = req
+	var _result ticket.TicketServiceListViewsResult
+	if err = p.c.Call(ctx, "ListViews", &_args, &_result); err != nil {
+		return
+	}
+	switch {
+	case _result.Err != nil:
+		return r, _result.Err
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) ViewTickets(ctx context.Context, req *ticket.ViewTicketsRequest) (r *ticket.ListTicketsResponse, err error) {
+	var _args ticket.TicketServiceViewTicketsArgs
+	_args.Req = req
+	var _result ticket.TicketServiceViewTicketsResult
+	if err = p.c.Call(ctx, "ViewTickets", &_args, &_result); err != nil {
+		return
+	}
+	switch {
+	case _result.Err != nil:
+		return r, _result.Err
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) PullNext(ctx context.Context, req *ticket.PullNextRequest) (r *ticket.PullNextResponse, err error) {
+	var _args ticket.TicketServicePullNextArgs
+	_args.Req = req
+	var _result ticket.TicketServicePullNextResult
+	if err = p.c.Call(ctx, "PullNext", &_args, &_result); err != nil {
+		return
+	}
+	switch {
+	case _result.Err != nil:
+		return r, _result.Err
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) AcquireLock(ctx context.Context, req *ticket.LockRequest) (r *ticket.LockResponse, err error) {
+	var _args ticket.TicketServiceAcquireLockArgs
+	_args.Req = req
+	var _result ticket.TicketServiceAcquireLockResult
+	if err = p.c.Call(ctx, "AcquireLock", &_args, &_result); err != nil {
+		return
+	}
+	switch {
+	case _result.Err != nil:
+		return r, _result.Err
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) ReleaseLock(ctx context.Context, req *ticket.LockRequest) (r *ticket.LockResponse, err error) {
+	var _args ticket.TicketServiceReleaseLockArgs
+	_args.Req = req
+	var _result ticket.TicketServiceReleaseLockResult
+	if err = p.c.Call(ctx, "ReleaseLock", &_args, &_result); err != nil {
+		return
+	}
+	switch {
+	case _result.Err != nil:
+		return r, _result.Err
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) ListLockEvents(ctx context.Context, req *ticket.ListLockEventsRequest) (r *ticket.ListLockEventsResponse, err error) {
+	var _args ticket.TicketServiceListLockEventsArgs
+	_args.Req = req
+	var _result ticket.TicketServiceListLockEventsResult
+	if err = p.c.Call(ctx, "ListLockEvents", &_args, &_result); err != nil {
+		return
+	}
+	switch {
+	case _result.Err != nil:
+		return r, _result.Err
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) DeleteTicket(ctx context.Context, req *ticket.TicketActionRequest) (r *ticket.TicketResponse, err error) {
+	var _args ticket.TicketServiceDeleteTicketArgs
+	_args.Req = req
+	var _result ticket.TicketServiceDeleteTicketResult
+	if err = p.c.Call(ctx, "DeleteTicket", &_args, &_result); err != nil {
+		return
+	}
+	switch {
+	case _result.Err != nil:
+		return r, _result.Err
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) RestoreTicket(ctx context.Context, req *ticket.TicketActionRequest) (r *ticket.TicketResponse, err error) {
+	var _args ticket.TicketServiceRestoreTicketArgs
+	_args.Req = req
+	var _result ticket.TicketServiceRestoreTicketResult
+	if err = p.c.Call(ctx, "RestoreTicket", &_args, &_result); err != nil {
+		return
+	}
+	switch {
+	case _result.Err != nil:
+		return r, _result.Err
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) Archive(ctx context.Context, req *ticket.TicketActionRequest) (r *ticket.TicketResponse, err error) {
+	var _args ticket.TicketServiceArchiveArgs
+	_args.Req = req
+	var _result ticket.TicketServiceArchiveResult
+	if err = p.c.Call(ctx, "Archive", &_args, &_result); err != nil {
+		return
+	}
+	switch {
+	case _result.Err != nil:
+		return r, _result.Err
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) Unarchive(ctx context.Context, req *ticket.TicketActionRequest) (r *ticket.TicketResponse, err error) {
+	var _args ticket.TicketServiceUnarchiveArgs
+	_args.Req = req
+	var _result ticket.TicketServiceUnarchiveResult
+	if err = p.c.Call(ctx, "Unarchive", &_args, &_result); err != nil {
+		return
+	}
+	switch {
+	case _result.Err != nil:
+		return r, _result.Err
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) ApplyRetention(ctx context.Context, req *ticket.ApplyRetentionRequest) (r *ticket.RetentionReport, err error) {
+	var _args ticket.TicketServiceApplyRetentionArgs
+	_args.Req = req
+	var _result ticket.TicketServiceApplyRetentionResult
+	if err = p.c.Call(ctx, "ApplyRetention", &_args, &_result); err != nil {
+		return
+	}
+	switch {
+	case _result.Err != nil:
+		return r, _result.Err
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) ExportSubject(ctx context.Context, req *common.SubjectRequest) (r *ticket.ExportSubjectResponse, err error) {
+	var _args ticket.TicketServiceExportSubjectArgs
+	_args.Req = req
+	var _result ticket.TicketServiceExportSubjectResult
+	if err = p.c.Call(ctx, "ExportSubject", &_args, &_result); err != nil {
+		return
+	}
+	switch {
+	case _result.Err != nil:
+		return r, _result.Err
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) EraseSubject(ctx context.Context, req *ticket.EraseSubjectRequest) (r *ticket.ErasureReceipt, err error) {
+	var _args ticket.TicketServiceEraseSubjectArgs
+	_args.Req = req
+	var _result ticket.TicketServiceEraseSubjectResult
+	if err = p.c.Call(ctx, "EraseSubject", &_args, &_result); err != nil {
+		return
+	}
+	switch {
+	case _result.Err != nil:
+		return r, _result.Err
+	}
+	return _result.GetSuccess(), nil
+}
+
+func (p *kClient) GetErasureReceipt(ctx context.Context, req *ticket.GetErasureReceiptRequest) (r *ticket.ErasureReceipt, err error) {
+	var _args ticket.TicketServiceGetErasureReceiptArgs
+	_args.Req = req
+	var _result ticket.TicketServiceGetErasureReceiptResult
+	if err = p.c.Call(ctx, "GetErasureReceipt", &_args, &_result); err != nil {
 		return
 	}
 	switch {
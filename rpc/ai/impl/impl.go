@@ -4,6 +4,7 @@ import (
 	"context"
 	"os"
 	"sync"
+	"time"
 
 	"github.com/gogogo1024/assist-fusion/internal/ai"
 	aichain "github.com/gogogo1024/assist-fusion/internal/ai/chain"
@@ -18,9 +19,22 @@ type AIServiceImpl struct {
 	chatOnce  sync.Once
 	embed     aichain.EmbeddingChain
 	chat      aichain.ChatChain
+
+	tenants map[string]TenantConfig
+	mu      sync.Mutex // guards chains and quota
+	chains  map[string]*tenantChains
+	quota   map[string]*quotaWindow
 }
 
-func NewAIService() *AIServiceImpl { return &AIServiceImpl{} }
+type Option func(*AIServiceImpl)
+
+func NewAIService(opts ...Option) *AIServiceImpl {
+	s := &AIServiceImpl{chains: map[string]*tenantChains{}, quota: map[string]*quotaWindow{}}
+	for _, o := range opts {
+		o(s)
+	}
+	return s
+}
 
 func (s *AIServiceImpl) initEmbed() {
 	s.embedOnce.Do(func() {
@@ -52,17 +66,20 @@ func (s *AIServiceImpl) Embeddings(ctx context.Context, req *kcommon.EmbeddingRe
 	if req == nil || len(req.Texts) == 0 {
 		return nil, &kcommon.ServiceError{Code: "bad_request", Message: "texts required"}
 	}
-	s.initEmbed()
+	if err := s.takeQuota(ctx, quotaEmbed, time.Now()); err != nil {
+		return nil, err
+	}
+	embed := s.embedChain(ctx)
 
 	var vecs [][]float64
 	var err error
 	provider := "mock"
-	if s.embed == nil { // forced mock fallback
+	if embed == nil { // forced mock fallback
 		vecs = ai.MockEmbeddings(req.Texts, int(req.Dim))
 		observability.AIEmbeddingFallbackMock.Add(1)
 	} else {
-		provider = s.embed.Provider()
-		vecs, err = s.embed.Embed(ctx, req.Texts, int(req.Dim))
+		provider = embed.Provider()
+		vecs, err = embed.Embed(ctx, req.Texts, int(req.Dim))
 		if err != nil || len(vecs) == 0 {
 			// provider error path
 			switch provider {
@@ -102,14 +119,17 @@ func (s *AIServiceImpl) Chat(ctx context.Context, req *aidl.ChatRequest) (*aidl.
 	if req == nil || len(req.Messages) == 0 {
 		return nil, &kcommon.ServiceError{Code: "bad_request", Message: "messages required"}
 	}
-	s.initChat()
+	if err := s.takeQuota(ctx, quotaChat, time.Now()); err != nil {
+		return nil, err
+	}
+	chat := s.chatChain(ctx)
 
 	// Convert messages
 	msgs := make([]aichain.ChatMessage, 0, len(req.Messages))
 	for _, m := range req.Messages {
 		msgs = append(msgs, aichain.ChatMessage{Role: m.Role, Content: m.Content})
 	}
-	if s.chat == nil { // fallback
+	if chat == nil { // fallback
 		last := msgs[len(msgs)-1]
 		observability.AIChatFallbackMock.Add(1)
 		return &aidl.ChatResponse{Message: &aidl.ChatMessage{Role: "assistant", Content: "echo:" + last.Content}}, nil
@@ -118,8 +138,8 @@ func (s *AIServiceImpl) Chat(ctx context.Context, req *aidl.ChatRequest) (*aidl.
 	if req.MaxTokens != nil {
 		maxTokens = int(*req.MaxTokens)
 	}
-	resp, err := s.chat.Chat(ctx, msgs, maxTokens)
-	provider := s.chat.Provider()
+	resp, err := chat.Chat(ctx, msgs, maxTokens)
+	provider := chat.Provider()
 	if err != nil || resp.Content == "" {
 		switch provider {
 		case aichain.ProviderOpenAI:
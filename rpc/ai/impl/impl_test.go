@@ -2,9 +2,13 @@ package impl
 
 import (
 	"context"
+	"errors"
 	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/gogogo1024/assist-fusion/internal/common"
 	"github.com/gogogo1024/assist-fusion/internal/observability"
 	aidl "github.com/gogogo1024/assist-fusion/kitex_gen/ai"
 	kcommon "github.com/gogogo1024/assist-fusion/kitex_gen/common"
@@ -57,3 +61,45 @@ func TestChatFallbackEcho(t *testing.T) {
 		t.Fatalf("expected chat fallback=1")
 	}
 }
+
+func TestTenantQuota(t *testing.T) {
+	os.Setenv("AI_CHAIN_DISABLE", "1")
+	t.Cleanup(func() { os.Unsetenv("AI_CHAIN_DISABLE") })
+	svc := NewAIService(WithTenantConfigs(map[string]TenantConfig{"acme": {EmbedPerMinute: 2}}))
+	acme := common.WithTenant(context.Background(), "acme")
+	req := &kcommon.EmbeddingRequest{Texts: []string{"q"}, Dim: 4}
+	for i := 0; i < 2; i++ {
+		if _, err := svc.Embeddings(acme, req); err != nil {
+			t.Fatalf("call %d within quota: %v", i, err)
+		}
+	}
+	_, err := svc.Embeddings(acme, req)
+	var se *kcommon.ServiceError
+	if !errors.As(err, &se) || se.Code != common.ErrCodeQuotaExceeded {
+		t.Fatalf("expected quota_exceeded, got %v", err)
+	}
+	// other tenants and other kinds are unaffected
+	if _, err := svc.Embeddings(context.Background(), req); err != nil {
+		t.Fatalf("default tenant: %v", err)
+	}
+	if _, err := svc.Chat(acme, &aidl.ChatRequest{Messages: []*aidl.ChatMessage{{Role: "user", Content: "hi"}}}); err != nil {
+		t.Fatalf("acme chat: %v", err)
+	}
+	// the budget refills in the next minute
+	if err := svc.takeQuota(acme, quotaEmbed, time.Now().Add(time.Minute)); err != nil {
+		t.Fatalf("next window: %v", err)
+	}
+}
+
+func TestLoadTenantConfigs(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tenants.json")
+	os.WriteFile(path, []byte(`{"acme":{"provider":"openai","openai_key":"k","chat_per_minute":5}}`), 0o600)
+	got, err := LoadTenantConfigs(path)
+	if err != nil || got["acme"].ChatPerMinute != 5 || !got["acme"].customProvider() {
+		t.Fatalf("unexpected %#v err=%v", got, err)
+	}
+	os.WriteFile(path, []byte(`{"Bad Tenant":{}}`), 0o600)
+	if _, err := LoadTenantConfigs(path); err == nil {
+		t.Fatalf("expected invalid tenant id error")
+	}
+}
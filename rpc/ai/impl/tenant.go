@@ -0,0 +1,151 @@
+package impl
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	aichain "github.com/gogogo1024/assist-fusion/internal/ai/chain"
+	"github.com/gogogo1024/assist-fusion/internal/common"
+	"github.com/gogogo1024/assist-fusion/internal/observability"
+	kcommon "github.com/gogogo1024/assist-fusion/kitex_gen/common"
+)
+
+// TenantConfig overrides the provider and sets quotas for one tenant. Empty provider
+// fields inherit the process-wide env configuration; a zero quota means unlimited.
+type TenantConfig struct {
+	Provider         string `json:"provider"`
+	OpenAIKey        string `json:"openai_key"`
+	OpenAIEmbedModel string `json:"openai_embed_model"`
+	OpenAIChatModel  string `json:"openai_chat_model"`
+	OpenAIBaseURL    string `json:"openai_base_url"`
+	EmbedPerMinute   int    `json:"embed_per_minute"`
+	ChatPerMinute    int    `json:"chat_per_minute"`
+}
+
+// LoadTenantConfigs reads a JSON object of tenant id -> TenantConfig.
+func LoadTenantConfigs(path string) (map[string]TenantConfig, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var out map[string]TenantConfig
+	if err := json.Unmarshal(b, &out); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	for id := range out {
+		if !common.ValidTenantID(id) {
+			return nil, fmt.Errorf("parse %s: invalid tenant id %q", path, id)
+		}
+	}
+	return out, nil
+}
+
+// WithTenantConfigs installs per-tenant provider overrides and quotas.
+func WithTenantConfigs(m map[string]TenantConfig) Option {
+	return func(s *AIServiceImpl) { s.tenants = m }
+}
+
+// customProvider reports whether the tenant overrides any provider setting.
+func (c TenantConfig) customProvider() bool {
+	return c.Provider != "" || c.OpenAIKey != "" || c.OpenAIEmbedModel != "" || c.OpenAIChatModel != "" || c.OpenAIBaseURL != ""
+}
+
+func (c TenantConfig) aiConfig() aichain.AIConfig {
+	cfg := aichain.LoadAIConfigFromEnv()
+	if c.Provider != "" {
+		cfg.Provider = c.Provider
+	}
+	if c.OpenAIKey != "" {
+		cfg.OpenAIKey = c.OpenAIKey
+	}
+	if c.OpenAIEmbedModel != "" {
+		cfg.OpenAIEmbedModel = c.OpenAIEmbedModel
+	}
+	if c.OpenAIChatModel != "" {
+		cfg.OpenAIChatModel = c.OpenAIChatModel
+	}
+	if c.OpenAIBaseURL != "" {
+		cfg.OpenAIBaseURL = c.OpenAIBaseURL
+	}
+	return cfg
+}
+
+type tenantChains struct {
+	embed aichain.EmbeddingChain
+	chat  aichain.ChatChain
+}
+
+// chainsFor returns the tenant's own chains, or nil when it uses the shared ones.
+func (s *AIServiceImpl) chainsFor(tenant string) *tenantChains {
+	cfg, ok := s.tenants[tenant]
+	if !ok || !cfg.customProvider() || os.Getenv("AI_CHAIN_DISABLE") == "1" {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if tc, ok := s.chains[tenant]; ok {
+		return tc
+	}
+	ac := cfg.aiConfig()
+	tc := &tenantChains{embed: aichain.NewEmbeddingChainFromConfig(ac), chat: aichain.NewChatChainFromConfig(ac)}
+	s.chains[tenant] = tc
+	return tc
+}
+
+func (s *AIServiceImpl) embedChain(ctx context.Context) aichain.EmbeddingChain {
+	if tc := s.chainsFor(common.TenantFromContext(ctx)); tc != nil {
+		return tc.embed
+	}
+	s.initEmbed()
+	return s.embed
+}
+
+func (s *AIServiceImpl) chatChain(ctx context.Context) aichain.ChatChain {
+	if tc := s.chainsFor(common.TenantFromContext(ctx)); tc != nil {
+		return tc.chat
+	}
+	s.initChat()
+	return s.chat
+}
+
+const (
+	quotaEmbed = "embed"
+	quotaChat  = "chat"
+)
+
+// quotaWindow counts requests of one tenant and kind within the current minute.
+type quotaWindow struct {
+	minute int64
+	used   int
+}
+
+// takeQuota consumes one request from the tenant's per-minute budget.
+func (s *AIServiceImpl) takeQuota(ctx context.Context, kind string, now time.Time) error {
+	tenant := common.TenantFromContext(ctx)
+	cfg := s.tenants[tenant]
+	limit := cfg.EmbedPerMinute
+	if kind == quotaChat {
+		limit = cfg.ChatPerMinute
+	}
+	if limit <= 0 {
+		return nil
+	}
+	minute := now.Unix() / 60
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := tenant + "/" + kind
+	w := s.quota[key]
+	if w == nil || w.minute != minute {
+		w = &quotaWindow{minute: minute}
+		s.quota[key] = w
+	}
+	if w.used >= limit {
+		observability.AIQuotaRejected.Add(1)
+		return &kcommon.ServiceError{Code: common.ErrCodeQuotaExceeded, Message: fmt.Sprintf("%s quota of %d/min exceeded for tenant %s", kind, limit, tenant)}
+	}
+	w.used++
+	return nil
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"log"
+	"os"
 
 	"github.com/cloudwego/kitex/pkg/klog"
 	"github.com/gogogo1024/assist-fusion/internal/kitexconf"
@@ -18,14 +19,23 @@ func main() {
 	if err := kitexconf.InitLogger(cfg); err != nil {
 		log.Printf("init logger failed (fallback std log only): %v", err)
 	}
-	h := aiimpl.NewAIService()
-	opts, err := kitexconf.BuildServerOptions(cfg)
+	var opts []aiimpl.Option
+	// AI_TENANTS_FILE: JSON of tenant id -> provider overrides and per-minute quotas
+	if path := os.Getenv("AI_TENANTS_FILE"); path != "" {
+		tenants, err := aiimpl.LoadTenantConfigs(path)
+		if err != nil {
+			log.Fatalf("load tenant configs: %v", err)
+		}
+		opts = append(opts, aiimpl.WithTenantConfigs(tenants))
+	}
+	h := aiimpl.NewAIService(opts...)
+	svrOpts, err := kitexconf.BuildServerOptions(cfg)
 	if err != nil {
 		klog.Fatalf("build opts: %v", err)
 	}
 	hooks := kitexconf.InitRuntime(context.Background(), cfg)
 	defer hooks.Shutdown(context.Background())
-	svr := aiservice.NewServer(h, opts...)
+	svr := aiservice.NewServer(h, svrOpts...)
 	klog.Infof("ai service starting env=%s addr=%s config=%s", cfg.Env, cfg.Kitex.Address, cfg.RawPath)
 	if err := svr.Run(); err != nil {
 		klog.Errorf("server stopped: %v", err)
@@ -2,9 +2,18 @@ package impl
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync/atomic"
+	"time"
 
+	"github.com/gogogo1024/assist-fusion/internal/ai"
+	"github.com/gogogo1024/assist-fusion/internal/common"
+	"github.com/gogogo1024/assist-fusion/internal/idempotency"
 	"github.com/gogogo1024/assist-fusion/internal/kb"
 	"github.com/gogogo1024/assist-fusion/internal/observability"
+	"github.com/gogogo1024/assist-fusion/internal/privacy"
 	kcommon "github.com/gogogo1024/assist-fusion/kitex_gen/common"
 	kbidl "github.com/gogogo1024/assist-fusion/kitex_gen/kb"
 	"github.com/google/uuid"
@@ -15,6 +24,15 @@ type KBServiceImpl struct {
 	Repo         kb.Repo
 	backend      string
 	analyzerMode string
+	idemTTL      time.Duration
+	idem         *idempotency.Store
+	chunker      kb.Chunker
+	embedder     ai.Embedder
+	fusion       kb.FusionOptions
+	hnsw         *kb.HNSWConfig
+	vectors      *kb.VectorSet
+	snapshotPath string
+	savedGen     atomic.Uint64 // vector generation of the last snapshot
 }
 
 type Option func(*KBServiceImpl)
@@ -22,11 +40,21 @@ type Option func(*KBServiceImpl)
 func WithBackend(b string) Option      { return func(s *KBServiceImpl) { s.backend = b } }
 func WithAnalyzerMode(m string) Option { return func(s *KBServiceImpl) { s.analyzerMode = m } }
 
+// WithIdempotencyTTL sets how long an AddDoc idempotency key replays the original doc.
+func WithIdempotencyTTL(d time.Duration) Option { return func(s *KBServiceImpl) { s.idemTTL = d } }
+
+// WithChunking sets the chunk size and overlap (in kb.Measure units) used on ingest.
+func WithChunking(size, overlap int) Option {
+	return func(s *KBServiceImpl) { s.chunker = kb.NewChunker(size, overlap) }
+}
+
 func NewKBService(repo kb.Repo, opts ...Option) *KBServiceImpl {
-	s := &KBServiceImpl{Repo: repo, backend: "memory"}
+	s := &KBServiceImpl{Repo: repo, backend: "memory", idemTTL: 24 * time.Hour, chunker: kb.NewChunker(kb.DefaultChunkSize, kb.DefaultChunkOverlap), fusion: kb.DefaultFusion()}
 	for _, o := range opts {
 		o(s)
 	}
+	s.idem = idempotency.NewStore(s.idemTTL)
+	s.vectors = s.newVectorSet()
 	return s
 }
 
@@ -34,29 +62,90 @@ const (
 	errMsgKBUnavailable = "kb backend unavailable"
 	errMsgTitleRequired = "title required"
 	errMsgIDRequired    = "id required"
+	errMsgInvalidTags   = "tags: at most 20, keys non-empty without ':', keys and values up to 64 chars"
 )
 
+// Tag filter modes for SearchRequest.tag_mode.
+const (
+	tagModeAll = "all"
+	tagModeAny = "any"
+)
+
+func invalidTags() error {
+	return &kcommon.ServiceError{Code: "bad_request", Message: errMsgInvalidTags}
+}
+
+func toThriftDoc(d *kb.Doc) *kcommon.KBDoc {
+	return &kcommon.KBDoc{Id: d.ID, Title: d.Title, Content: d.Content, Tags: d.Tags, CreatedAt: d.CreatedAt, UpdatedAt: d.UpdatedAt}
+}
+
 // AddDoc implements new request struct contract.
 func (s *KBServiceImpl) AddDoc(ctx context.Context, req *kbidl.AddDocRequest) (*kcommon.KBDoc, error) {
 	if req == nil || req.Title == "" {
 		return nil, &kcommon.ServiceError{Code: "bad_request", Message: errMsgTitleRequired}
 	}
-	d := &kb.Doc{ID: uuid.NewString(), Title: req.Title, Content: req.Content}
+	if _, err := kb.NormalizeTags(req.Tags); err != nil {
+		return nil, invalidTags()
+	}
+	key := req.GetIdempotencyKey()
+	if key == "" {
+		return s.addDoc(ctx, req)
+	}
+	if len(key) > idempotency.MaxKeyLen {
+		return nil, &kcommon.ServiceError{Code: "bad_request", Message: "idempotency key too long"}
+	}
+	fields := []string{req.Title, req.Content}
+	for k, v := range req.GetTags() {
+		fields = append(fields, k+"="+v)
+	}
+	sort.Strings(fields[2:])
+	// keys are private to the caller, as for tickets
+	actor, _ := common.ActorFromContext(ctx)
+	scoped := idempotency.Key(key, common.TenantFromContext(ctx), actor.ID, "kb.add")
+	doc, err := s.idem.Do(scoped, idempotency.Fingerprint(fields...), func() (any, error) { return s.addDoc(ctx, req) })
+	if errors.Is(err, idempotency.ErrKeyReused) {
+		return nil, &kcommon.ServiceError{Code: "conflict", Message: err.Error()}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return doc.(*kcommon.KBDoc), nil
+}
+
+func (s *KBServiceImpl) addDoc(ctx context.Context, req *kbidl.AddDocRequest) (*kcommon.KBDoc, error) {
+	tags, _ := kb.NormalizeTags(req.Tags)
+	now := time.Now().Unix()
+	d := &kb.Doc{ID: uuid.NewString(), Title: req.Title, Content: req.Content, Tags: tags, CreatedAt: now, UpdatedAt: now}
+	d.Chunks = s.chunker.Split(d.Content)
 	if err := s.Repo.Add(ctx, d); err != nil {
 		return nil, &kcommon.ServiceError{Code: "kb_unavailable", Message: errMsgKBUnavailable}
 	}
+	s.indexVectors(ctx, d)
 	observability.KBDocCreated.Add(1)
-	return &kcommon.KBDoc{Id: d.ID, Title: d.Title, Content: d.Content}, nil
+	return toThriftDoc(d), nil
 }
 
 // UpdateDoc performs partial update (empty strings are ignored when optional absent semantics not visible here).
+// Tags, when present, replace the stored set; an empty map clears it.
 func (s *KBServiceImpl) UpdateDoc(ctx context.Context, req *kbidl.UpdateDocRequest) (*kcommon.KBDoc, error) {
 	if req == nil || req.Id == "" {
 		return nil, &kcommon.ServiceError{Code: "bad_request", Message: errMsgIDRequired}
 	}
-	d, ok := s.Repo.Get(ctx, req.Id)
-	if !ok {
-		d = &kb.Doc{ID: req.Id}
+	tags, err := kb.NormalizeTags(req.Tags)
+	if err != nil {
+		return nil, invalidTags()
+	}
+	d := &kb.Doc{ID: req.Id}
+	if cur, ok := s.Repo.Get(ctx, req.Id); ok {
+		cp := *cur
+		d = &cp
+	}
+	if req.IsSetTags() {
+		d.Tags = tags
+	}
+	d.UpdatedAt = time.Now().Unix()
+	if d.CreatedAt == 0 {
+		d.CreatedAt = d.UpdatedAt
 	}
 	if req.Title != nil && *req.Title != "" {
 		d.Title = *req.Title
@@ -64,14 +153,16 @@ func (s *KBServiceImpl) UpdateDoc(ctx context.Context, req *kbidl.UpdateDocReque
 	if req.Content != nil && *req.Content != "" {
 		d.Content = *req.Content
 	}
+	d.Chunks = s.chunker.Split(d.Content)
 	if d.Title == "" {
 		return nil, &kcommon.ServiceError{Code: "bad_request", Message: errMsgTitleRequired}
 	}
 	if err := s.Repo.Update(ctx, d); err != nil {
 		return nil, &kcommon.ServiceError{Code: "internal_error", Message: "internal"}
 	}
+	s.indexVectors(ctx, d)
 	observability.KBDocUpdated.Add(1)
-	return &kcommon.KBDoc{Id: d.ID, Title: d.Title, Content: d.Content}, nil
+	return toThriftDoc(d), nil
 }
 
 func (s *KBServiceImpl) DeleteDoc(ctx context.Context, req *kbidl.DeleteDocRequest) (*kbidl.DeleteDocResponse, error) {
@@ -81,10 +172,63 @@ func (s *KBServiceImpl) DeleteDoc(ctx context.Context, req *kbidl.DeleteDocReque
 	if err := s.Repo.Delete(ctx, req.Id); err != nil {
 		return nil, &kcommon.ServiceError{Code: "kb_unavailable", Message: errMsgKBUnavailable}
 	}
+	s.dropVectors(ctx, req.Id)
 	observability.KBDocDeleted.Add(1)
 	return &kbidl.DeleteDocResponse{Ok: true}, nil
 }
 
+// GetDoc returns one document of the caller's tenant.
+func (s *KBServiceImpl) GetDoc(ctx context.Context, req *kbidl.GetDocRequest) (*kcommon.KBDoc, error) {
+	if req == nil || req.Id == "" {
+		return nil, &kcommon.ServiceError{Code: "bad_request", Message: errMsgIDRequired}
+	}
+	d, ok := s.Repo.Get(ctx, req.Id)
+	if !ok {
+		return nil, &kcommon.ServiceError{Code: "not_found", Message: "doc not found"}
+	}
+	return toThriftDoc(d), nil
+}
+
+// ListDocs pages through documents by updated time, newest first unless order is "asc".
+func (s *KBServiceImpl) ListDocs(ctx context.Context, req *kbidl.ListDocsRequest) (*kbidl.ListDocsResponse, error) {
+	if req == nil {
+		req = &kbidl.ListDocsRequest{}
+	}
+	opts := kb.ListOptions{Limit: 20, Tags: req.Tags, After: req.GetCursor()}
+	if n := req.GetLimit(); n > 0 {
+		opts.Limit = int(min(n, 100))
+	}
+	switch req.GetTagMode() {
+	case "", tagModeAll:
+	case tagModeAny:
+		opts.AnyTag = true
+	default:
+		return nil, &kcommon.ServiceError{Code: "bad_request", Message: "tag_mode must be all or any"}
+	}
+	switch req.GetOrder() {
+	case "", "desc":
+	case "asc":
+		opts.Asc = true
+	default:
+		return nil, &kcommon.ServiceError{Code: "bad_request", Message: "order must be asc or desc"}
+	}
+	page, err := s.Repo.List(ctx, opts)
+	if errors.Is(err, kb.ErrInvalidCursor) {
+		return nil, &kcommon.ServiceError{Code: "bad_request", Message: "invalid or expired cursor"}
+	}
+	if err != nil {
+		return nil, &kcommon.ServiceError{Code: "kb_unavailable", Message: errMsgKBUnavailable}
+	}
+	out := &kbidl.ListDocsResponse{Docs: make([]*kcommon.KBDoc, 0, len(page.Docs)), Total: int32(page.Total)}
+	for _, d := range page.Docs {
+		out.Docs = append(out.Docs, toThriftDoc(d))
+	}
+	if page.Next != "" {
+		out.NextCursor = &page.Next
+	}
+	return out, nil
+}
+
 func (s *KBServiceImpl) Search(ctx context.Context, req *kbidl.SearchRequest) (*kbidl.SearchResponse, error) {
 	if req == nil {
 		return nil, &kcommon.ServiceError{Code: "bad_request", Message: "request required"}
@@ -99,33 +243,232 @@ func (s *KBServiceImpl) Search(ctx context.Context, req *kbidl.SearchRequest) (*
 	if limit > 50 {
 		limit = 50
 	}
-	items, total, err := s.Repo.Search(ctx, req.Query, int(limit))
+	off := int32(0)
+	if req.Offset != nil && *req.Offset > 0 {
+		off = *req.Offset
+	}
+	opts := kb.SearchOptions{Limit: int(limit), Offset: int(off), Tags: req.Tags}
+	switch req.GetTagMode() {
+	case "", tagModeAll:
+	case tagModeAny:
+		opts.AnyTag = true
+	default:
+		return nil, &kcommon.ServiceError{Code: "bad_request", Message: "tag_mode must be all or any"}
+	}
+	snippet, err := snippetOptions(req)
+	if err != nil {
+		return nil, err
+	}
+	opts.Snippet = snippet
+	mode := req.GetMode()
+	switch mode {
+	case "", modeLexical:
+		mode = modeLexical
+	case modeVector, modeHybrid:
+		if req.Query == "" {
+			return nil, &kcommon.ServiceError{Code: "bad_request", Message: "query required in " + mode + " mode"}
+		}
+	default:
+		return nil, &kcommon.ServiceError{Code: "bad_request", Message: "mode must be lexical, vector or hybrid"}
+	}
+	cursorMode := req.Cursor != nil
+	switch {
+	case cursorMode && mode != modeLexical:
+		return nil, &kcommon.ServiceError{Code: "bad_request", Message: "cursor pagination is lexical only"}
+	case cursorMode && off > 0:
+		return nil, &kcommon.ServiceError{Code: "bad_request", Message: "offset and cursor cannot be combined"}
+	case cursorMode:
+		// one extra hit tells whether another page follows
+		opts.Cursor, opts.After, opts.Limit = true, *req.Cursor, int(limit)+1
+	case int(off)+int(limit) > kb.MaxOffsetWindow:
+		return nil, &kcommon.ServiceError{Code: "bad_request", Message: fmt.Sprintf("offset+limit beyond %d, use cursor pagination", kb.MaxOffsetWindow)}
+	}
+	var items []*kb.Item
+	var total int
+	if mode == modeLexical {
+		items, total, err = s.Repo.Search(ctx, req.Query, opts)
+	} else {
+		items, total, err = s.rankedSearch(ctx, req, mode, opts)
+	}
+	var se *kcommon.ServiceError
+	if errors.As(err, &se) {
+		return nil, se
+	}
+	if errors.Is(err, kb.ErrInvalidCursor) {
+		return nil, &kcommon.ServiceError{Code: "bad_request", Message: "invalid or expired cursor"}
+	}
 	if err != nil {
 		return nil, &kcommon.ServiceError{Code: "kb_unavailable", Message: errMsgKBUnavailable}
 	}
 	observability.KBSearchRequests.Add(1)
+	resp := &kbidl.SearchResponse{}
+	if cursorMode && len(items) > int(limit) {
+		items = items[:limit]
+		resp.NextCursor = &items[limit-1].Cursor
+	}
 	observability.KBSearchHits.Add(int64(len(items)))
 	out := make([]*kcommon.SearchItem, 0, len(items))
 	for _, it := range items {
-		out = append(out, &kcommon.SearchItem{Id: it.ID, Title: it.Title, Score: it.Score, Snippet: it.Snippet})
-	}
-	var next *int32
-	if req.Offset != nil {
-		off := *req.Offset
-		consumed := off + int32(len(out))
-		if int(consumed) < total {
-			n := consumed
-			next = &n
-		}
+		out = append(out, toThriftItem(it))
+	}
+	if consumed := off + int32(len(out)); !cursorMode && len(out) > 0 && int(consumed) < total {
+		resp.NextOffset = &consumed
 	}
 	total32 := int32(total)
-	return &kbidl.SearchResponse{Items: out, Returned: int32(len(out)), NextOffset: next, Total: &total32}, nil
+	resp.Items, resp.Returned, resp.Total = out, int32(len(out)), &total32
+	return resp, nil
+}
+
+// Snippet limits of SearchRequest.
+const (
+	maxSnippetLength = 1000
+	maxFragments     = 5
+	maxTagLen        = 32
+)
+
+// snippetOptions validates the snippet parameters of req.
+func snippetOptions(req *kbidl.SearchRequest) (kb.SnippetOptions, error) {
+	so := kb.SnippetOptions{Length: int(req.GetSnippetLength()), Fragments: int(req.GetFragments()), PreTag: req.GetPreTag(), PostTag: req.GetPostTag()}
+	switch {
+	case req.SnippetLength != nil && (so.Length < 1 || so.Length > maxSnippetLength):
+		return so, &kcommon.ServiceError{Code: "bad_request", Message: fmt.Sprintf("snippet_length must be 1..%d", maxSnippetLength)}
+	case req.Fragments != nil && (so.Fragments < 1 || so.Fragments > maxFragments):
+		return so, &kcommon.ServiceError{Code: "bad_request", Message: fmt.Sprintf("fragments must be 1..%d", maxFragments)}
+	case (so.PreTag == "") != (so.PostTag == ""):
+		return so, &kcommon.ServiceError{Code: "bad_request", Message: "pre_tag and post_tag go together"}
+	case len(so.PreTag) > maxTagLen || len(so.PostTag) > maxTagLen:
+		return so, &kcommon.ServiceError{Code: "bad_request", Message: fmt.Sprintf("pre_tag and post_tag: up to %d bytes", maxTagLen)}
+	}
+	return so, nil
+}
+
+func toThriftItem(it *kb.Item) *kcommon.SearchItem {
+	chunk := int32(it.Chunk)
+	out := &kcommon.SearchItem{Id: it.ID, Title: it.Title, Score: it.Score, Snippet: it.Snippet, Tags: it.Tags, ChunkIndex: &chunk, HeadingPath: it.Heading}
+	if it.LexicalRank > 0 {
+		score, rank := it.LexicalScore, int32(it.LexicalRank)
+		out.LexicalScore, out.LexicalRank = &score, &rank
+	}
+	if it.VectorRank > 0 {
+		score, rank := it.VectorScore, int32(it.VectorRank)
+		out.VectorScore, out.VectorRank = &score, &rank
+	}
+	for _, h := range it.Highlights {
+		out.Highlights = append(out.Highlights, &kcommon.HighlightSpan{Start: int32(h.Start), End: int32(h.End)})
+	}
+	return out
 }
 
 func (s *KBServiceImpl) Info(ctx context.Context) (*kbidl.InfoResponse, error) {
-	stats := map[string]string{"backend": s.backend}
+	stats := map[string]string{"backend": s.backend, "vector_search": "off"}
+	if s.embedder != nil {
+		stats["vector_search"] = "on"
+		stats["fusion"] = s.fusion.Method
+		stats["vector_index"] = "exact"
+		if s.hnsw != nil {
+			stats["vector_index"] = "hnsw"
+		}
+	}
 	if s.backend == "es" && s.analyzerMode != "" {
 		stats["analyzer_mode"] = s.analyzerMode
 	}
 	return &kbidl.InfoResponse{Stats: stats}, nil
 }
+
+// Subject scans walk every document of the tenant page by page (a search could miss a
+// mention the index tokenizes differently). Beyond subjectScanMax documents the request
+// fails rather than exporting or erasing a partial set. Vars so tests can shrink them.
+var (
+	subjectPageSize = 500
+	subjectScanMax  = 100000
+)
+
+// subjectDocs returns the docs whose title or content mentions sub.
+func (s *KBServiceImpl) subjectDocs(ctx context.Context, sub *privacy.Subject) ([]*kb.Doc, error) {
+	// oldest first: a document updated during the scan moves behind the cursor, so it is
+	// seen again rather than skipped
+	opts := kb.ListOptions{Limit: subjectPageSize, Asc: true}
+	seen := map[string]bool{}
+	var out []*kb.Doc
+	for {
+		page, err := s.Repo.List(ctx, opts)
+		if err != nil {
+			return nil, &kcommon.ServiceError{Code: "kb_unavailable", Message: errMsgKBUnavailable}
+		}
+		for _, d := range page.Docs {
+			if seen[d.ID] {
+				continue
+			}
+			seen[d.ID] = true
+			if len(seen) > subjectScanMax {
+				return nil, &kcommon.ServiceError{Code: common.ErrCodeInternal, Message: fmt.Sprintf("subject scan stopped at %d documents; nothing was changed", subjectScanMax)}
+			}
+			if sub.Mentions(d.Title) || sub.Mentions(d.Content) {
+				out = append(out, d)
+			}
+		}
+		if page.Next == "" {
+			return out, nil
+		}
+		opts.After = page.Next
+	}
+}
+
+func parseSubject(req *kcommon.SubjectRequest) (*privacy.Subject, error) {
+	if req == nil {
+		return nil, &kcommon.ServiceError{Code: "bad_request", Message: "subject required"}
+	}
+	sub, err := privacy.NewSubject(req.Subject)
+	if err != nil {
+		return nil, &kcommon.ServiceError{Code: "bad_request", Message: err.Error()}
+	}
+	return sub, nil
+}
+
+// ExportSubject lists the documents mentioning the subject.
+func (s *KBServiceImpl) ExportSubject(ctx context.Context, req *kcommon.SubjectRequest) (*kbidl.ExportSubjectResponse, error) {
+	sub, err := parseSubject(req)
+	if err != nil {
+		return nil, err
+	}
+	docs, err := s.subjectDocs(ctx, sub)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*kcommon.KBDoc, 0, len(docs))
+	for _, d := range docs {
+		out = append(out, toThriftDoc(d))
+	}
+	return &kbidl.ExportSubjectResponse{Docs: out}, nil
+}
+
+// EraseSubject replaces every mention of the subject in titles and contents.
+func (s *KBServiceImpl) EraseSubject(ctx context.Context, req *kcommon.SubjectRequest) (*kcommon.ErasureSummary, error) {
+	sub, err := parseSubject(req)
+	if err != nil {
+		return nil, err
+	}
+	docs, err := s.subjectDocs(ctx, sub)
+	if err != nil {
+		return nil, err
+	}
+	out := &kcommon.ErasureSummary{Service: "kb", Records: []string{}}
+	for _, d := range docs {
+		title, n1 := sub.Redact(d.Title)
+		content, n2 := sub.Redact(d.Content)
+		erased := *d
+		erased.Title, erased.Content, erased.UpdatedAt = title, content, time.Now().Unix()
+		erased.Chunks = s.chunker.Split(content)
+		if err := s.Repo.Update(ctx, &erased); err != nil {
+			return nil, &kcommon.ServiceError{Code: "kb_unavailable", Message: errMsgKBUnavailable}
+		}
+		s.indexVectors(ctx, &erased)
+		out.Records = append(out.Records, d.ID)
+		for _, n := range []int{n1, n2} {
+			if n > 0 {
+				out.Fields++
+			}
+		}
+	}
+	return out, nil
+}
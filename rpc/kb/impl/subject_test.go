@@ -0,0 +1,51 @@
+package impl
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/gogogo1024/assist-fusion/internal/kb"
+	kcommon "github.com/gogogo1024/assist-fusion/kitex_gen/common"
+	kbidl "github.com/gogogo1024/assist-fusion/kitex_gen/kb"
+)
+
+func TestSubjectScanCoversEveryDoc(t *testing.T) {
+	defer func(page, limit int) { subjectPageSize, subjectScanMax = page, limit }(subjectPageSize, subjectScanMax)
+	subjectPageSize = 2
+	s := NewKBService(kb.NewMemoryRepo())
+	ctx := context.Background()
+	for _, c := range []string{"mail alice@example.com", "unrelated", "ALICE@example.com knows", "also unrelated", "cc: alice@example.com."} {
+		if _, err := s.AddDoc(ctx, &kbidl.AddDocRequest{Title: "doc", Content: c}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	req := &kcommon.SubjectRequest{Subject: "alice@example.com"}
+	exp, err := s.ExportSubject(ctx, req)
+	if err != nil || len(exp.Docs) != 3 {
+		t.Fatalf("export over pages = %d docs, %v", len(exp.Docs), err)
+	}
+
+	// a scan that cannot see every document erases nothing
+	subjectScanMax = 4
+	if _, err := s.EraseSubject(ctx, req); err == nil {
+		t.Fatal("truncated scan must fail the erase")
+	}
+	if _, err := s.ExportSubject(ctx, req); err == nil {
+		t.Fatal("truncated scan must fail the export")
+	}
+	subjectScanMax = 100
+	if exp, _ := s.ExportSubject(ctx, req); len(exp.Docs) != 3 {
+		t.Fatalf("failed erase changed documents: %d left", len(exp.Docs))
+	}
+	sum, err := s.EraseSubject(ctx, req)
+	if err != nil || len(sum.Records) != 3 {
+		t.Fatalf("erase = %+v, %v", sum, err)
+	}
+	for _, id := range sum.Records {
+		d, _ := s.GetDoc(ctx, &kbidl.GetDocRequest{Id: id})
+		if strings.Contains(strings.ToLower(d.Content), "alice") {
+			t.Fatalf("mention left in %q", d.Content)
+		}
+	}
+}
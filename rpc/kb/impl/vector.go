@@ -0,0 +1,410 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cloudwego/kitex/pkg/klog"
+
+	"github.com/gogogo1024/assist-fusion/internal/ai"
+	"github.com/gogogo1024/assist-fusion/internal/kb"
+	kcommon "github.com/gogogo1024/assist-fusion/kitex_gen/common"
+	kbidl "github.com/gogogo1024/assist-fusion/kitex_gen/kb"
+)
+
+// Search modes for SearchRequest.mode.
+const (
+	modeLexical = "lexical"
+	modeVector  = "vector"
+	modeHybrid  = "hybrid"
+)
+
+const (
+	embedTimeout = 5 * time.Second
+	embedBatch   = 32 // texts per Embeddings call
+	// fusionDepth is how many hits each retriever contributes in vector and hybrid modes;
+	// it is fixed so page boundaries stay put while paging by offset.
+	fusionDepth = 100
+)
+
+// WithEmbedder enables the vector and hybrid search modes: chunks are embedded on ingest
+// and queries at search time.
+func WithEmbedder(e ai.Embedder) Option { return func(s *KBServiceImpl) { s.embedder = e } }
+
+// WithFusion sets the default fusion of hybrid searches; requests may override every field.
+func WithFusion(f kb.FusionOptions) Option { return func(s *KBServiceImpl) { s.fusion = f } }
+
+// WithVectorSnapshot keeps the vectors in a snapshot file at path: it is read by
+// NewKBService and written by SaveVectorSnapshot. Meant for the memory backend; repos that
+// implement kb.VectorRepo persist vectors with the documents.
+func WithVectorSnapshot(path string) Option { return func(s *KBServiceImpl) { s.snapshotPath = path } }
+
+// WithHNSW answers vector searches from an HNSW graph per tenant instead of scanning
+// every chunk vector.
+func WithHNSW(cfg kb.HNSWConfig) Option { return func(s *KBServiceImpl) { s.hnsw = &cfg } }
+
+// vectorLoadTimeout bounds rebuilding one tenant's vector index from the repo.
+const vectorLoadTimeout = time.Minute
+
+// newVectorSet returns the service's vector indexes, rebuilt per tenant from the repo
+// when it stores vectors, and restores the snapshot file if one is configured.
+func (s *KBServiceImpl) newVectorSet() *kb.VectorSet {
+	var load func(ctx context.Context, vi *kb.VectorIndex) error
+	if vr, ok := s.Repo.(kb.VectorRepo); ok {
+		load = func(ctx context.Context, vi *kb.VectorIndex) error {
+			// the first search of a tenant pays for the rebuild; do not let its deadline cut it short
+			ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), vectorLoadTimeout)
+			defer cancel()
+			err := vr.ScanVectors(ctx, func(id string, vecs [][]float64) error {
+				if err := vi.Put(id, vecs); err != nil {
+					klog.Warnf("kb vectors: skip stored vectors of doc %s: %v", id, err)
+				}
+				return nil
+			})
+			if errors.Is(err, kb.ErrVectorsNotStored) {
+				return nil
+			}
+			return err
+		}
+	}
+	newIndex := kb.NewVectorIndex
+	if s.hnsw != nil {
+		cfg := *s.hnsw
+		newIndex = func() *kb.VectorIndex { return kb.NewHNSWVectorIndex(cfg) }
+	}
+	set := kb.NewVectorSet(newIndex, load)
+	if s.snapshotPath != "" {
+		if err := set.LoadFile(s.snapshotPath); err != nil {
+			klog.Warnf("kb vectors: read snapshot %s: %v", s.snapshotPath, err)
+		}
+		s.savedGen.Store(set.Generation())
+	}
+	return set
+}
+
+// SaveVectorSnapshot writes the snapshot file when vectors changed since the last save.
+func (s *KBServiceImpl) SaveVectorSnapshot() error {
+	if s.snapshotPath == "" {
+		return nil
+	}
+	gen := s.vectors.Generation()
+	if gen == s.savedGen.Load() {
+		return nil
+	}
+	if err := s.vectors.SaveFile(s.snapshotPath); err != nil {
+		return err
+	}
+	s.savedGen.Store(gen)
+	return nil
+}
+
+// RunVectorSnapshots saves the snapshot every interval until ctx is done.
+func (s *KBServiceImpl) RunVectorSnapshots(ctx context.Context, interval time.Duration) {
+	if s.snapshotPath == "" || interval <= 0 {
+		return
+	}
+	tick := time.NewTicker(interval)
+	defer tick.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-tick.C:
+			if err := s.SaveVectorSnapshot(); err != nil {
+				klog.Warnf("kb vectors: write snapshot %s: %v", s.snapshotPath, err)
+			}
+		}
+	}
+}
+
+// embed embeds texts in batches of embedBatch.
+func (s *KBServiceImpl) embed(ctx context.Context, texts []string) ([][]float64, error) {
+	ctx, cancel := context.WithTimeout(ctx, embedTimeout)
+	defer cancel()
+	out := make([][]float64, 0, len(texts))
+	for start := 0; start < len(texts); start += embedBatch {
+		batch := texts[start:min(start+embedBatch, len(texts))]
+		vecs, err := s.embedder.Embed(ctx, batch)
+		if err != nil {
+			return nil, err
+		}
+		if len(vecs) != len(batch) {
+			return nil, fmt.Errorf("embedder returned %d vectors for %d texts", len(vecs), len(batch))
+		}
+		out = append(out, vecs...)
+	}
+	return out, nil
+}
+
+// indexVectors (re)embeds the chunks of d and stores the vectors with the repo when it
+// keeps them. Vector search is best-effort: on failure the document keeps only its
+// lexical index until its next update.
+func (s *KBServiceImpl) indexVectors(ctx context.Context, d *kb.Doc) {
+	if s.embedder == nil {
+		return
+	}
+	chunks := d.IndexedChunks()
+	texts := make([]string, len(chunks))
+	for i, c := range chunks {
+		texts[i] = kb.EmbedText(d.Title, c)
+	}
+	if len(texts) == 0 {
+		texts = []string{d.Title}
+	}
+	vecs, err := s.embed(ctx, texts)
+	if err != nil {
+		klog.Warnf("kb vectors: embed doc %s failed: %v", d.ID, err)
+		s.dropVectors(ctx, d.ID)
+		return
+	}
+	stored := false
+	if vr, ok := s.Repo.(kb.VectorRepo); ok {
+		err := vr.PutVectors(ctx, d.ID, vecs)
+		if err != nil && !errors.Is(err, kb.ErrVectorsNotStored) {
+			klog.Warnf("kb vectors: store vectors of doc %s: %v", d.ID, err)
+		}
+		stored = err == nil
+	}
+	// stored vectors are read back when a tenant's index is first loaded, so only an index
+	// already in memory needs them now
+	vi, loaded := s.vectors.Loaded(ctx)
+	if !stored && !loaded {
+		if vi, err = s.vectors.ForTenant(ctx); err != nil {
+			klog.Warnf("kb vectors: load tenant vectors: %v", err)
+		}
+	}
+	if vi == nil {
+		return
+	}
+	if err := vi.Put(d.ID, vecs); err != nil {
+		klog.Warnf("kb vectors: index doc %s: %v", d.ID, err)
+		vi.Delete(d.ID)
+	}
+}
+
+// dropVectors forgets a document in memory; stored vectors go with the document itself.
+func (s *KBServiceImpl) dropVectors(ctx context.Context, id string) {
+	if s.embedder == nil {
+		return
+	}
+	if vi, ok := s.vectors.Loaded(ctx); ok {
+		vi.Delete(id)
+	}
+}
+
+// fusionOptions applies the request's overrides to the configured fusion.
+func (s *KBServiceImpl) fusionOptions(req *kbidl.SearchRequest) (kb.FusionOptions, error) {
+	f := s.fusion
+	switch req.GetFusion() {
+	case "":
+	case kb.FusionRRF, kb.FusionWeighted, kb.FusionNative:
+		f.Method = req.GetFusion()
+	default:
+		return f, &kcommon.ServiceError{Code: "bad_request", Message: "fusion must be rrf, weighted or native"}
+	}
+	if req.LexicalWeight != nil {
+		f.LexicalWeight = *req.LexicalWeight
+	}
+	if req.VectorWeight != nil {
+		f.VectorWeight = *req.VectorWeight
+	}
+	if req.RrfK != nil {
+		f.RRFK = int(*req.RrfK)
+	}
+	switch {
+	case f.LexicalWeight < 0 || f.VectorWeight < 0 || f.LexicalWeight+f.VectorWeight == 0:
+		return f, &kcommon.ServiceError{Code: "bad_request", Message: "weights must be non-negative and not both zero"}
+	case f.RRFK <= 0:
+		return f, &kcommon.ServiceError{Code: "bad_request", Message: "rrf_k must be positive"}
+	}
+	return f, nil
+}
+
+// rankedSearch serves the vector and hybrid modes: both retrievers fetch fusionDepth
+// hits, the rankings are fused (hybrid) and the page is cut by offset. total counts the
+// ranked hits. Native fusion asks the repo for the combined ranking and falls back to
+// weighted fusion when the repo cannot search vectors.
+func (s *KBServiceImpl) rankedSearch(ctx context.Context, req *kbidl.SearchRequest, mode string, opts kb.SearchOptions) ([]*kb.Item, int, error) {
+	if s.embedder == nil {
+		return nil, 0, &kcommon.ServiceError{Code: "kb_unavailable", Message: "vector search is not enabled"}
+	}
+	fusion, err := s.fusionOptions(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	if opts.Offset+opts.Limit > fusionDepth {
+		return nil, 0, &kcommon.ServiceError{Code: "bad_request", Message: fmt.Sprintf("offset+limit beyond %d in %s mode", fusionDepth, mode)}
+	}
+	qv, err := s.embedQuery(ctx, req.Query)
+	if err != nil {
+		return nil, 0, err
+	}
+	// a zero weight switches a retriever off, which boosts cannot express
+	if mode == modeHybrid && fusion.Method == kb.FusionNative && fusion.LexicalWeight > 0 && fusion.VectorWeight > 0 {
+		ranked, err := s.repoKNN(ctx, kb.KNNOptions{Vector: qv, K: fusionDepth, Tags: opts.Tags, AnyTag: opts.AnyTag,
+			Query: req.Query, QueryBoost: fusion.LexicalWeight, VectorBoost: fusion.VectorWeight, Snippet: opts.Snippet})
+		if err == nil {
+			return pageItems(ranked, opts), len(ranked), nil
+		}
+		if !errors.Is(err, kb.ErrKNNUnsupported) {
+			return nil, 0, err
+		}
+	}
+	ranked, err := s.vectorItems(ctx, kb.KNNOptions{Vector: qv, K: fusionDepth, Tags: opts.Tags, AnyTag: opts.AnyTag, Snippet: opts.Snippet})
+	if err != nil {
+		return nil, 0, err
+	}
+	if mode == modeHybrid {
+		lexOpts := opts
+		lexOpts.Offset, lexOpts.Limit = 0, fusionDepth
+		lexical, _, err := s.Repo.Search(ctx, req.Query, lexOpts)
+		if err != nil {
+			return nil, 0, &kcommon.ServiceError{Code: "kb_unavailable", Message: errMsgKBUnavailable}
+		}
+		ranked = kb.Fuse(lexical, ranked, fusion)
+	}
+	return pageItems(ranked, opts), len(ranked), nil
+}
+
+// pageItems cuts the page at opts.Offset out of ranked.
+func pageItems(ranked []*kb.Item, opts kb.SearchOptions) []*kb.Item {
+	if opts.Offset >= len(ranked) {
+		return nil
+	}
+	return ranked[opts.Offset:min(opts.Offset+opts.Limit, len(ranked))]
+}
+
+// embedQuery embeds a search query.
+func (s *KBServiceImpl) embedQuery(ctx context.Context, query string) ([]float64, error) {
+	qv, err := s.embed(ctx, []string{query})
+	if err != nil {
+		return nil, &kcommon.ServiceError{Code: "kb_unavailable", Message: "embedding service unavailable"}
+	}
+	return qv[0], nil
+}
+
+// vectorItems returns the opts.K nearest documents of the caller's tenant that match the
+// tag filter, best first: from the repo when it searches its vectors, else from memory.
+func (s *KBServiceImpl) vectorItems(ctx context.Context, opts kb.KNNOptions) ([]*kb.Item, error) {
+	items, err := s.repoKNN(ctx, opts)
+	if errors.Is(err, kb.ErrKNNUnsupported) {
+		return s.memoryKNN(ctx, opts)
+	}
+	return items, err
+}
+
+// repoKNN runs opts on a kb.KNNRepo. It returns kb.ErrKNNUnsupported (unwrapped) when
+// the repo cannot serve it and a ServiceError on other failures. Pure vector results get
+// their vector score and rank.
+func (s *KBServiceImpl) repoKNN(ctx context.Context, opts kb.KNNOptions) ([]*kb.Item, error) {
+	kr, ok := s.Repo.(kb.KNNRepo)
+	if !ok {
+		return nil, kb.ErrKNNUnsupported
+	}
+	items, err := kr.KNNSearch(ctx, opts)
+	if errors.Is(err, kb.ErrKNNUnsupported) {
+		if err != kb.ErrKNNUnsupported {
+			// wrapped: the cluster rejected the query, say why
+			klog.Debugf("kb vectors: repo knn unavailable, searching in memory: %v", err)
+		}
+		return nil, kb.ErrKNNUnsupported
+	}
+	if err != nil {
+		klog.Errorf("kb vectors: knn search: %v", err)
+		return nil, &kcommon.ServiceError{Code: "kb_unavailable", Message: errMsgKBUnavailable}
+	}
+	if opts.Query == "" {
+		for i, it := range items {
+			it.VectorScore, it.VectorRank = it.Score, i+1
+		}
+	}
+	return items, nil
+}
+
+// memoryKNN searches the caller's tenant index in memory.
+func (s *KBServiceImpl) memoryKNN(ctx context.Context, opts kb.KNNOptions) ([]*kb.Item, error) {
+	vi, err := s.vectors.ForTenant(ctx)
+	if err != nil {
+		klog.Errorf("kb vectors: load tenant vectors: %v", err)
+		return nil, &kcommon.ServiceError{Code: "kb_unavailable", Message: errMsgKBUnavailable}
+	}
+	docs := map[string]*kb.Doc{}
+	keep := func(id string) bool {
+		d, ok := s.Repo.Get(ctx, id)
+		if ok {
+			docs[id] = d
+		}
+		return ok && kb.MatchTags(d.Tags, opts.Tags, opts.AnyTag)
+	}
+	hits := vi.Search(opts.Vector, opts.K, keep)
+	items := make([]*kb.Item, 0, len(hits))
+	for i, h := range hits {
+		it := kb.VectorItem(docs[h.ID], s.docChunks(docs[h.ID]), h, opts.Snippet)
+		it.VectorScore, it.VectorRank = h.Score, i+1
+		items = append(items, it)
+	}
+	return items, nil
+}
+
+// indexedVectors counts the caller's documents with vectors.
+func (s *KBServiceImpl) indexedVectors(ctx context.Context) int {
+	if kr, ok := s.Repo.(kb.KNNRepo); ok {
+		if n, err := kr.CountVectors(ctx); err == nil {
+			return n
+		}
+	}
+	if vi, ok := s.vectors.Loaded(ctx); ok {
+		return vi.Len()
+	}
+	return 0
+}
+
+// VectorSearch returns the documents nearest to the query by chunk embeddings.
+func (s *KBServiceImpl) VectorSearch(ctx context.Context, req *kbidl.VectorSearchRequest) (*kbidl.VectorSearchResponse, error) {
+	if req == nil || req.Query == "" {
+		return nil, &kcommon.ServiceError{Code: "bad_request", Message: "query required"}
+	}
+	if s.embedder == nil {
+		return nil, &kcommon.ServiceError{Code: "kb_unavailable", Message: "vector search is not enabled"}
+	}
+	limit := 10
+	if n := req.GetLimit(); n > 0 {
+		limit = int(min(n, 50))
+	}
+	anyTag := false
+	switch req.GetTagMode() {
+	case "", tagModeAll:
+	case tagModeAny:
+		anyTag = true
+	default:
+		return nil, &kcommon.ServiceError{Code: "bad_request", Message: "tag_mode must be all or any"}
+	}
+	qv, err := s.embedQuery(ctx, req.Query)
+	if err != nil {
+		return nil, err
+	}
+	items, err := s.vectorItems(ctx, kb.KNNOptions{Vector: qv, K: limit, Tags: req.Tags, AnyTag: anyTag})
+	if err != nil {
+		return nil, err
+	}
+	indexed := s.indexedVectors(ctx)
+	out := make([]*kcommon.SearchItem, 0, len(items))
+	for _, it := range items {
+		out = append(out, toThriftItem(it))
+	}
+	return &kbidl.VectorSearchResponse{Items: out, Returned: int32(len(out)), Indexed: int32(indexed)}, nil
+}
+
+// docChunks returns d's chunks as indexed; repos that do not return chunks get them
+// re-split, which yields the same chunks while the chunker settings are unchanged.
+func (s *KBServiceImpl) docChunks(d *kb.Doc) []kb.Chunk {
+	if len(d.Chunks) > 0 {
+		return d.Chunks
+	}
+	if cs := s.chunker.Split(d.Content); len(cs) > 0 {
+		return cs
+	}
+	return d.IndexedChunks()
+}
@@ -4,12 +4,18 @@ import (
 	"context"
 	"log"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/cloudwego/kitex/client"
 	"github.com/cloudwego/kitex/pkg/klog"
+	"github.com/cloudwego/kitex/transport"
+	"github.com/gogogo1024/assist-fusion/internal/ai"
 	"github.com/gogogo1024/assist-fusion/internal/kb"
 	"github.com/gogogo1024/assist-fusion/internal/kb/esrepo"
 	"github.com/gogogo1024/assist-fusion/internal/kitexconf"
+	"github.com/gogogo1024/assist-fusion/kitex_gen/ai/aiservice"
 	kbservice "github.com/gogogo1024/assist-fusion/kitex_gen/kb/kbservice"
 	kbimpl "github.com/gogogo1024/assist-fusion/rpc/kb/impl"
 )
@@ -26,6 +32,8 @@ func main() {
 	var repo kb.Repo
 	var backendLabel string
 	var analyzerMode string
+	var durable *kb.DurableStore
+	dataDir := os.Getenv("KB_DATA_DIR")
 	if backend == "es" {
 		// Build ES repo
 		addrsEnv := os.Getenv("ES_ADDRS")
@@ -39,7 +47,10 @@ func main() {
 			}
 		}
 		index := os.Getenv("ES_INDEX")
-		r, err := esrepo.New(esrepo.Config{Addresses: addrs, Index: index, Username: os.Getenv("ES_USERNAME"), Password: os.Getenv("ES_PASSWORD")})
+		// chunk vectors go to a dense_vector field searched with kNN (ES 8.11+)
+		vectorDims, _ := strconv.Atoi(os.Getenv("KB_ES_VECTOR_DIMS"))
+		r, err := esrepo.New(esrepo.Config{Addresses: addrs, Index: index, Username: os.Getenv("ES_USERNAME"), Password: os.Getenv("ES_PASSWORD"),
+			VectorDims: vectorDims, VectorSimilarity: os.Getenv("KB_ES_VECTOR_SIMILARITY")})
 		if err != nil {
 			klog.Fatalf("init es repo failed: %v", err)
 		}
@@ -49,13 +60,99 @@ func main() {
 				analyzerMode = m
 			}
 		}
-		repo = r
+		// one index per tenant
+		repo = kb.PerTenant(func(tenant string) (kb.Repo, error) { return r.ForTenant(tenant), nil })
 		backendLabel = "es"
+	} else if dataDir != "" {
+		// memory backend persisted to a write-ahead log and snapshots per tenant
+		policy := os.Getenv("KB_WAL_SYNC")
+		switch policy {
+		case "", kb.SyncAlways, kb.SyncInterval, kb.SyncNever:
+		default:
+			log.Printf("unknown KB_WAL_SYNC %q, using %s", policy, kb.SyncAlways)
+			policy = kb.SyncAlways
+		}
+		durable = kb.NewDurableStore(dataDir, kb.DurableOptions{Sync: policy,
+			SyncEvery:     envDuration("KB_WAL_SYNC_INTERVAL", kb.DefaultWALSyncInterval),
+			SnapshotEvery: envDuration("KB_SNAPSHOT_INTERVAL", kb.DefaultSnapshotInterval),
+			SnapshotBytes: int64(envInt("KB_SNAPSHOT_WAL_BYTES", kb.DefaultSnapshotWALBytes))})
+		if err := durable.Recover(); err != nil {
+			klog.Fatalf("recover kb data dir %s: %v", dataDir, err)
+		}
+		repo = kb.PerTenant(durable.Open)
+		backendLabel = "memory"
 	} else {
-		repo = kb.NewMemoryRepo()
+		repo = kb.PerTenant(func(string) (kb.Repo, error) { return kb.NewMemoryRepo(), nil })
 		backendLabel = "memory"
 	}
-	h := kbimpl.NewKBService(repo, kbimpl.WithBackend(backendLabel), kbimpl.WithAnalyzerMode(analyzerMode))
+	chunkSize, _ := strconv.Atoi(os.Getenv("KB_CHUNK_SIZE"))
+	chunkOverlap := kb.DefaultChunkOverlap
+	if v, err := strconv.Atoi(os.Getenv("KB_CHUNK_OVERLAP")); err == nil {
+		chunkOverlap = v
+	}
+	svcOpts := []kbimpl.Option{kbimpl.WithBackend(backendLabel), kbimpl.WithAnalyzerMode(analyzerMode), kbimpl.WithChunking(chunkSize, chunkOverlap)}
+	// AI_RPC_ADDR enables vector and hybrid search (chunk embeddings from ai-rpc);
+	// TTHeader forwards the tenant so ai-rpc applies that tenant's provider and quota
+	if addr := os.Getenv("AI_RPC_ADDR"); addr != "" {
+		if cli, err := aiservice.NewClient("ai", client.WithHostPorts(addr), client.WithTransportProtocol(transport.TTHeaderFramed)); err == nil {
+			fusion := kb.DefaultFusion()
+			switch v := os.Getenv("KB_FUSION"); v {
+			case "":
+			case kb.FusionRRF, kb.FusionWeighted, kb.FusionNative:
+				fusion.Method = v
+			default:
+				log.Printf("unknown KB_FUSION %q, using %s", v, fusion.Method)
+			}
+			fusion.LexicalWeight = envFloat("KB_FUSION_LEXICAL_WEIGHT", fusion.LexicalWeight)
+			fusion.VectorWeight = envFloat("KB_FUSION_VECTOR_WEIGHT", fusion.VectorWeight)
+			if v, err := strconv.Atoi(os.Getenv("KB_FUSION_RRF_K")); err == nil && v > 0 {
+				fusion.RRFK = v
+			}
+			svcOpts = append(svcOpts, kbimpl.WithEmbedder(ai.NewRPCEmbedder(cli, 0)), kbimpl.WithFusion(fusion))
+			switch v := os.Getenv("KB_VECTOR_INDEX"); v {
+			case "", "hnsw":
+				hnsw := kb.DefaultHNSWConfig()
+				hnsw.M = envInt("KB_HNSW_M", hnsw.M)
+				hnsw.EfConstruction = envInt("KB_HNSW_EF_CONSTRUCTION", hnsw.EfConstruction)
+				hnsw.EfSearch = envInt("KB_HNSW_EF_SEARCH", hnsw.EfSearch)
+				svcOpts = append(svcOpts, kbimpl.WithHNSW(hnsw))
+			case "exact":
+			default:
+				log.Printf("unknown KB_VECTOR_INDEX %q, using hnsw", v)
+				svcOpts = append(svcOpts, kbimpl.WithHNSW(kb.DefaultHNSWConfig()))
+			}
+			// ES and the memory backend with KB_DATA_DIR keep vectors with the documents;
+			// otherwise a snapshot file carries them across restarts
+			if path := os.Getenv("KB_VECTOR_SNAPSHOT"); path != "" {
+				svcOpts = append(svcOpts, kbimpl.WithVectorSnapshot(path))
+			}
+		} else {
+			log.Printf("ai client init failed, vector search disabled: %v", err)
+		}
+	}
+	h := kbimpl.NewKBService(repo, svcOpts...)
+	if durable != nil {
+		durableCtx, stopDurable := context.WithCancel(context.Background())
+		go durable.Run(durableCtx)
+		defer func() {
+			stopDurable()
+			if err := durable.Close(); err != nil {
+				klog.Errorf("close kb data dir: %v", err)
+			}
+		}()
+	}
+	snapshotEvery := time.Minute
+	if d, err := time.ParseDuration(os.Getenv("KB_VECTOR_SNAPSHOT_INTERVAL")); err == nil && d > 0 {
+		snapshotEvery = d
+	}
+	snapshotCtx, stopSnapshots := context.WithCancel(context.Background())
+	go h.RunVectorSnapshots(snapshotCtx, snapshotEvery)
+	defer func() {
+		stopSnapshots()
+		if err := h.SaveVectorSnapshot(); err != nil {
+			klog.Errorf("save vector snapshot: %v", err)
+		}
+	}()
 	opts, err := kitexconf.BuildServerOptions(cfg)
 	if err != nil {
 		klog.Fatalf("build opts: %v", err)
@@ -68,3 +165,24 @@ func main() {
 		klog.Errorf("server stopped: %v", err)
 	}
 }
+
+func envFloat(key string, def float64) float64 {
+	if v, err := strconv.ParseFloat(os.Getenv(key), 64); err == nil {
+		return v
+	}
+	return def
+}
+
+func envDuration(key string, def time.Duration) time.Duration {
+	if d, err := time.ParseDuration(os.Getenv(key)); err == nil && d > 0 {
+		return d
+	}
+	return def
+}
+
+func envInt(key string, def int) int {
+	if v, err := strconv.Atoi(os.Getenv(key)); err == nil && v > 0 {
+		return v
+	}
+	return def
+}
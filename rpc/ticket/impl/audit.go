@@ -0,0 +1,57 @@
+package impl
+
+import (
+	"context"
+	"time"
+
+	"github.com/gogogo1024/assist-fusion/internal/audit"
+	"github.com/gogogo1024/assist-fusion/internal/common"
+	kcommon "github.com/gogogo1024/assist-fusion/kitex_gen/common"
+	"github.com/gogogo1024/assist-fusion/kitex_gen/ticket"
+)
+
+func (s *TicketServiceImpl) getForAudit(ctx context.Context, id string) (*common.Ticket, error) {
+	if id == "" {
+		return nil, &kcommon.ServiceError{Code: common.ErrCodeBadRequest, Message: idRequiredMsg}
+	}
+	t, _ := s.Repo.Get(ctx, id)
+	if t == nil {
+		return nil, &kcommon.ServiceError{Code: common.ErrCodeNotFound, Message: notFoundMsg}
+	}
+	return t, nil
+}
+
+// exportHead snapshots the chain head of t, signed when a signer is configured.
+func (s *TicketServiceImpl) exportHead(t *common.Ticket) *ticket.AuditHead {
+	h := &ticket.AuditHead{TicketId: t.ID, EventCount: int32(len(t.Events)), HeadHash: audit.Head(t.Events), ExportedAt: time.Now().Unix()}
+	if s.auditSigner != nil {
+		h.KeyId = s.auditSigner.KeyID
+		h.Signature = s.auditSigner.Sign(audit.HeadPayload(h.TicketId, int(h.EventCount), h.HeadHash, h.ExportedAt))
+	}
+	return h
+}
+
+// VerifyAudit recomputes the ticket's event hash chain and reports the first broken link.
+func (s *TicketServiceImpl) VerifyAudit(ctx context.Context, req *ticket.VerifyAuditRequest) (*ticket.VerifyAuditResponse, error) {
+	if req == nil {
+		return nil, &kcommon.ServiceError{Code: common.ErrCodeBadRequest, Message: idRequiredMsg}
+	}
+	t, err := s.getForAudit(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	r := audit.Verify(t.ID, t.Events)
+	return &ticket.VerifyAuditResponse{TicketId: t.ID, Ok: r.OK, Events: int32(len(t.Events)), BrokenIndex: int32(r.BrokenIndex), Reason: r.Reason, Head: s.exportHead(t)}, nil
+}
+
+// ExportAuditHead returns the current chain head for external anchoring.
+func (s *TicketServiceImpl) ExportAuditHead(ctx context.Context, req *ticket.ExportAuditHeadRequest) (*ticket.AuditHead, error) {
+	if req == nil {
+		return nil, &kcommon.ServiceError{Code: common.ErrCodeBadRequest, Message: idRequiredMsg}
+	}
+	t, err := s.getForAudit(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return s.exportHead(t), nil
+}
@@ -0,0 +1,50 @@
+package impl
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/gogogo1024/assist-fusion/internal/common"
+	kcommon "github.com/gogogo1024/assist-fusion/kitex_gen/common"
+	"github.com/gogogo1024/assist-fusion/kitex_gen/ticket"
+)
+
+func toThriftComment(c common.TicketComment) *kcommon.TicketComment {
+	atts := make([]*kcommon.Attachment, 0, len(c.Attachments))
+	for _, a := range c.Attachments {
+		atts = append(atts, &kcommon.Attachment{Name: a.Name, ContentType: a.ContentType, Size: a.Size})
+	}
+	return &kcommon.TicketComment{Id: c.ID, Body: c.Body, CreatedAt: c.At, Actor: &kcommon.Actor{Id: c.Actor.ID, Kind: c.Actor.Kind, DisplayName: c.Actor.Name}, Source: c.Source, Attachments: atts}
+}
+
+// AddComment appends a reply to the ticket. The "commented" event carries the comment id as its note.
+func (s *TicketServiceImpl) AddComment(ctx context.Context, req *ticket.AddCommentRequest) (*ticket.TicketResponse, error) {
+	if req == nil || req.Id == "" {
+		return nil, &kcommon.ServiceError{Code: common.ErrCodeBadRequest, Message: idRequiredMsg}
+	}
+	if strings.TrimSpace(req.Body) == "" && len(req.Attachments) == 0 {
+		return nil, &kcommon.ServiceError{Code: common.ErrCodeBadRequest, Message: "body required"}
+	}
+	defer s.lockTicket(ctx, req.Id)()
+	t := s.lookup(ctx, req.Id)
+	if t == nil {
+		return nil, &kcommon.ServiceError{Code: common.ErrCodeNotFound, Message: notFoundMsg}
+	}
+	if err := s.checkLock(ctx, t); err != nil {
+		return nil, err
+	}
+	ev := newEvent(ctx, "commented", time.Now().Unix(), "")
+	c := common.TicketComment{ID: uuid.NewString(), Body: req.Body, At: ev.At, Actor: ev.Actor, Source: ev.Source}
+	for _, a := range req.Attachments {
+		c.Attachments = append(c.Attachments, common.Attachment{Name: a.Name, ContentType: a.ContentType, Size: a.Size})
+	}
+	ev.Note = c.ID
+	t.Comments = append(t.Comments, c)
+	appendEvent(t, ev)
+	_ = s.Repo.Update(ctx, t)
+	s.publish(ctx, t, len(t.Events)-1)
+	return &ticket.TicketResponse{Ticket: s.toThriftTicket(t)}, nil
+}
@@ -0,0 +1,222 @@
+package impl
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gogogo1024/assist-fusion/internal/common"
+	"github.com/gogogo1024/assist-fusion/internal/observability"
+	kcommon "github.com/gogogo1024/assist-fusion/kitex_gen/common"
+	"github.com/gogogo1024/assist-fusion/kitex_gen/ticket"
+)
+
+// defaultSurveyTTL keeps a survey link valid for two weeks after resolve.
+const defaultSurveyTTL = 14 * 24 * time.Hour
+
+// csatRuleActor attributes automatic reopens triggered by low survey scores.
+var csatRuleActor = common.Actor{ID: "csat-reopen-rule", Kind: common.ActorKindAutomation, Name: "CSAT reopen rule"}
+
+const (
+	groupByAssignee = "assignee"
+	groupByCategory = "category"
+	groupByPeriod   = "period"
+	unassignedKey   = "(none)"
+)
+
+// newSurveyToken returns an unguessable token; it is the only credential for the public survey endpoint.
+// Tokens of non-default tenants are prefixed with "<tenant>." because survey links are opened
+// without any tenant header.
+func newSurveyToken(tenant string) string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(fmt.Sprintf("survey token: %v", err))
+	}
+	if tenant == "" || tenant == common.DefaultTenant {
+		return hex.EncodeToString(b)
+	}
+	return tenant + "." + hex.EncodeToString(b)
+}
+
+// surveyTokenTenant returns the tenant a survey token was issued in.
+func surveyTokenTenant(token string) string {
+	if tenant, _, ok := strings.Cut(token, "."); ok && common.ValidTenantID(tenant) {
+		return tenant
+	}
+	return common.DefaultTenant
+}
+
+// findSurvey locates the ticket and cycle index owning token.
+func (s *TicketServiceImpl) findSurvey(ctx context.Context, token string) (*common.Ticket, int) {
+	ts, _ := s.Repo.List(ctx)
+	for _, t := range ts {
+		if t.DeletedAt != 0 {
+			continue
+		}
+		for i := range t.Cycles {
+			if t.Cycles[i].SurveyToken == token {
+				return t, i
+			}
+		}
+	}
+	return nil, -1
+}
+
+// SubmitSurvey records a CSAT answer for the cycle identified by the survey token.
+// A token is single-use; a rating below the configured threshold reopens the ticket.
+func (s *TicketServiceImpl) SubmitSurvey(ctx context.Context, req *ticket.SubmitSurveyRequest) (*ticket.SubmitSurveyResponse, error) {
+	if req == nil || req.Token == "" {
+		return nil, &kcommon.ServiceError{Code: common.ErrCodeBadRequest, Message: "token required"}
+	}
+	if req.Rating < 1 || req.Rating > 5 {
+		return nil, &kcommon.ServiceError{Code: common.ErrCodeBadRequest, Message: "rating must be 1-5"}
+	}
+	ctx = common.WithTenant(ctx, surveyTokenTenant(req.Token))
+	t, idx := s.findSurvey(ctx, req.Token)
+	if t == nil {
+		return nil, &kcommon.ServiceError{Code: common.ErrCodeNotFound, Message: notFoundMsg}
+	}
+	// the scan ran unlocked; reload under the ticket's lock before changing it
+	defer s.lockTicket(ctx, t.ID)()
+	if t = s.lookup(ctx, t.ID); t == nil {
+		return nil, &kcommon.ServiceError{Code: common.ErrCodeNotFound, Message: notFoundMsg}
+	}
+	cyc := &t.Cycles[idx]
+	now := time.Now()
+	if s.surveyTTL > 0 && now.After(time.Unix(cyc.SurveyIssuedAt, 0).Add(s.surveyTTL)) {
+		return nil, &kcommon.ServiceError{Code: common.ErrCodeNotFound, Message: "survey expired"}
+	}
+	if cyc.CSAT != nil {
+		return nil, &kcommon.ServiceError{Code: common.ErrCodeConflict, Message: "survey already submitted"}
+	}
+	from := len(t.Events)
+	cyc.CSAT = &common.CSATResponse{Rating: int(req.Rating), Comment: req.GetComment(), SubmittedAt: now.Unix()}
+	appendEvent(t, newEvent(ctx, "csat_submitted", now.Unix(), fmt.Sprintf("rating=%d", req.Rating)))
+	// only the latest cycle can be reopened; answers for older cycles are recorded as-is
+	reopened := false
+	if s.csatReopenBelow > 0 && int(req.Rating) < s.csatReopenBelow && idx == t.CurrentCycle && t.Status == "resolved" {
+		ev := common.TicketEvent{Type: "reopened", At: now.Unix(), Note: fmt.Sprintf("csat rating %d below %d", req.Rating, s.csatReopenBelow), Actor: csatRuleActor, Source: common.SourceRule}
+		reopenTicket(t, ev)
+		s.reindexTicket(t)
+		observability.TicketReopened.Add(1)
+		reopened = true
+	}
+	_ = s.Repo.Update(ctx, t)
+	s.publish(ctx, t, from)
+	observability.TicketCSATSubmitted.Add(1)
+	return &ticket.SubmitSurveyResponse{TicketId: t.ID, Cycle: int32(idx), Reopened: reopened}, nil
+}
+
+type csatAgg struct {
+	n     int
+	sum   int
+	happy int
+	dist  map[int32]int32
+}
+
+func (a *csatAgg) add(rating int) {
+	a.n++
+	a.sum += rating
+	if rating >= 4 {
+		a.happy++
+	}
+	a.dist[int32(rating)]++
+}
+
+// CSATReport aggregates submitted answers by assignee, category or time period.
+func (s *TicketServiceImpl) CSATReport(ctx context.Context, req *ticket.CSATReportRequest) (*ticket.CSATReportResponse, error) {
+	if req == nil {
+		return nil, &kcommon.ServiceError{Code: common.ErrCodeBadRequest, Message: "request required"}
+	}
+	keyFn, err := csatKeyFunc(req.GroupBy, req.GetPeriod())
+	if err != nil {
+		return nil, &kcommon.ServiceError{Code: common.ErrCodeBadRequest, Message: err.Error()}
+	}
+	ts, _ := s.Repo.List(ctx)
+	groups := map[string]*csatAgg{}
+	overall := &csatAgg{dist: map[int32]int32{}}
+	for _, t := range ts {
+		for _, c := range t.Cycles {
+			if c.CSAT == nil {
+				continue
+			}
+			if req.From != nil && c.CSAT.SubmittedAt < *req.From {
+				continue
+			}
+			if req.To != nil && c.CSAT.SubmittedAt >= *req.To {
+				continue
+			}
+			k := keyFn(t, &c)
+			g := groups[k]
+			if g == nil {
+				g = &csatAgg{dist: map[int32]int32{}}
+				groups[k] = g
+			}
+			g.add(c.CSAT.Rating)
+			overall.add(c.CSAT.Rating)
+		}
+	}
+	rows := make([]*ticket.CSATReportRow, 0, len(groups))
+	for k, g := range groups {
+		rows = append(rows, &ticket.CSATReportRow{
+			Key:            k,
+			Responses:      int32(g.n),
+			Average:        float64(g.sum) / float64(g.n),
+			SatisfiedRatio: float64(g.happy) / float64(g.n),
+			Distribution:   g.dist,
+		})
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].Key < rows[j].Key })
+	resp := &ticket.CSATReportResponse{Rows: rows, TotalResponses: int32(overall.n)}
+	if overall.n > 0 {
+		resp.Average = float64(overall.sum) / float64(overall.n)
+	}
+	return resp, nil
+}
+
+// csatKeyFunc returns the grouping key of an answered cycle. Answers are credited to the
+// agent who resolved the cycle, not to whoever holds the ticket now.
+func csatKeyFunc(groupBy, period string) (func(*common.Ticket, *common.TicketCycle) string, error) {
+	switch groupBy {
+	case groupByAssignee:
+		return func(_ *common.Ticket, c *common.TicketCycle) string { return orNone(c.ResolvedBy) }, nil
+	case groupByCategory:
+		return func(t *common.Ticket, _ *common.TicketCycle) string { return orNone(t.Category) }, nil
+	case groupByPeriod:
+		layout, err := periodLayout(period)
+		if err != nil {
+			return nil, err
+		}
+		return func(_ *common.Ticket, c *common.TicketCycle) string {
+			return layout(time.Unix(c.CSAT.SubmittedAt, 0).UTC())
+		}, nil
+	}
+	return nil, fmt.Errorf("group_by must be %s|%s|%s", groupByAssignee, groupByCategory, groupByPeriod)
+}
+
+// periodLayout buckets timestamps (UTC) into day, ISO week or month keys.
+func periodLayout(period string) (func(time.Time) string, error) {
+	switch period {
+	case "", "day":
+		return func(t time.Time) string { return t.Format("2006-01-02") }, nil
+	case "week":
+		return func(t time.Time) string {
+			y, w := t.ISOWeek()
+			return fmt.Sprintf("%04d-W%02d", y, w)
+		}, nil
+	case "month":
+		return func(t time.Time) string { return t.Format("2006-01") }, nil
+	}
+	return nil, fmt.Errorf("period must be day|week|month")
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return unassignedKey
+	}
+	return s
+}
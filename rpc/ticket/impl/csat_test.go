@@ -0,0 +1,42 @@
+package impl
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gogogo1024/assist-fusion/internal/common"
+	"github.com/gogogo1024/assist-fusion/kitex_gen/ticket"
+)
+
+func TestCSATReportCreditsResolver(t *testing.T) {
+	s := NewTicketService(common.NewMemoryTicketRepo())
+	ctx := context.Background()
+	r, _ := s.CreateTicket(ctx, &ticket.CreateTicketRequest{Title: "credit", Desc: "x"})
+	id := r.Ticket.Id
+	alice, bob := "alice", "bob"
+	if _, err := s.Assign(ctx, &ticket.TicketActionRequest{Id: id, Assignee: &alice}); err != nil {
+		t.Fatal(err)
+	}
+	res, err := s.Resolve(ctx, &ticket.TicketActionRequest{Id: id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.SubmitSurvey(ctx, &ticket.SubmitSurveyRequest{Token: res.GetSurveyToken(), Rating: 5}); err != nil {
+		t.Fatal(err)
+	}
+
+	// the ticket moves on to bob; alice keeps the answer for the cycle she resolved
+	if _, err := s.Reopen(ctx, &ticket.TicketActionRequest{Id: id}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Assign(ctx, &ticket.TicketActionRequest{Id: id, Assignee: &bob}); err != nil {
+		t.Fatal(err)
+	}
+	rep, err := s.CSATReport(ctx, &ticket.CSATReportRequest{GroupBy: groupByAssignee})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rep.Rows) != 1 || rep.Rows[0].Key != alice || rep.Rows[0].Responses != 1 {
+		t.Fatalf("rows = %+v", rep.Rows)
+	}
+}
@@ -0,0 +1,175 @@
+package impl
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cloudwego/kitex/pkg/klog"
+
+	"github.com/gogogo1024/assist-fusion/internal/ai"
+	"github.com/gogogo1024/assist-fusion/internal/common"
+	kcommon "github.com/gogogo1024/assist-fusion/kitex_gen/common"
+	"github.com/gogogo1024/assist-fusion/kitex_gen/ticket"
+)
+
+// Duplicate detection defaults.
+const (
+	defaultDupThreshold    = 0.85
+	defaultDupStrict       = 0.97
+	defaultDupWindow       = 30 * 24 * time.Hour
+	maxDuplicateCandidates = 5
+	embedTimeout           = 2 * time.Second
+)
+
+// dedupConfig holds duplicate detection settings; detection is off while embedder is nil.
+type dedupConfig struct {
+	embedder  ai.Embedder
+	threshold float64       // report candidates at or above this similarity
+	strict    float64       // strict mode rejects at or above this similarity
+	window    time.Duration // only tickets created within the window are compared
+}
+
+// WithEmbedder enables duplicate detection on CreateTicket.
+func WithEmbedder(e ai.Embedder) Option { return func(s *TicketServiceImpl) { s.dedup.embedder = e } }
+
+// WithDuplicateThresholds overrides the report and strict-reject similarity thresholds.
+func WithDuplicateThresholds(report, strict float64) Option {
+	return func(s *TicketServiceImpl) { s.dedup.threshold, s.dedup.strict = report, strict }
+}
+
+// WithDuplicateWindow bounds how old a ticket may be to count as a duplicate candidate.
+func WithDuplicateWindow(d time.Duration) Option {
+	return func(s *TicketServiceImpl) { s.dedup.window = d }
+}
+
+// ticketVectors keeps unit-normalized embeddings of open tickets. Candidates are re-read
+// through the tenant-scoped repo, so vectors of other tenants never match.
+type ticketVectors struct {
+	mu   sync.RWMutex
+	vecs map[string][]float64
+}
+
+func newTicketVectors() *ticketVectors { return &ticketVectors{vecs: map[string][]float64{}} }
+
+func (v *ticketVectors) put(id string, vec []float64) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.vecs[id] = vec
+}
+
+func (v *ticketVectors) drop(id string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	delete(v.vecs, id)
+}
+
+type scoredTicket struct {
+	id  string
+	sim float64
+}
+
+// nearest returns ids with similarity >= min, best first, filtered by keep.
+func (v *ticketVectors) nearest(q []float64, min float64, keep func(id string) bool) []scoredTicket {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	var out []scoredTicket
+	for id, vec := range v.vecs {
+		if len(vec) != len(q) {
+			continue
+		}
+		sim := 0.0
+		for i := range q {
+			sim += q[i] * vec[i]
+		}
+		if sim >= min && keep(id) {
+			out = append(out, scoredTicket{id: id, sim: sim})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].sim > out[j].sim })
+	return out
+}
+
+func unit(v []float64) []float64 {
+	var n float64
+	for _, x := range v {
+		n += x * x
+	}
+	if n == 0 {
+		return nil
+	}
+	n = math.Sqrt(n)
+	out := make([]float64, len(v))
+	for i, x := range v {
+		out[i] = x / n
+	}
+	return out
+}
+
+func dedupText(title, desc string) string { return title + "\n" + desc }
+
+// embedTicketText returns the unit vector for title+desc, or nil when detection is off or the embedder fails.
+func (s *TicketServiceImpl) embedTicketText(ctx context.Context, title, desc string) []float64 {
+	if s.dedup.embedder == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, embedTimeout)
+	defer cancel()
+	vecs, err := s.dedup.embedder.Embed(ctx, []string{dedupText(title, desc)})
+	if err != nil || len(vecs) == 0 {
+		// duplicate detection is best-effort; ticket creation must not depend on the AI service
+		klog.Warnf("ticket dedup: embed failed: %v", err)
+		return nil
+	}
+	return unit(vecs[0])
+}
+
+// findDuplicates compares vec against recent open tickets.
+func (s *TicketServiceImpl) findDuplicates(ctx context.Context, vec []float64, now time.Time) []*ticket.DuplicateCandidate {
+	if vec == nil {
+		return nil
+	}
+	cutoff := now.Add(-s.dedup.window).Unix()
+	titles := map[string]string{}
+	hits := s.vectors.nearest(vec, s.dedup.threshold, func(id string) bool {
+		t, _ := s.Repo.Get(ctx, id)
+		if t == nil || t.Status == "resolved" || (s.dedup.window > 0 && t.CreatedAt < cutoff) {
+			return false
+		}
+		titles[id] = t.Title
+		return true
+	})
+	if len(hits) > maxDuplicateCandidates {
+		hits = hits[:maxDuplicateCandidates]
+	}
+	out := make([]*ticket.DuplicateCandidate, 0, len(hits))
+	for _, h := range hits {
+		out = append(out, &ticket.DuplicateCandidate{Id: h.id, Title: titles[h.id], Similarity: math.Round(h.sim*1000) / 1000})
+	}
+	return out
+}
+
+// strictDuplicate returns the error for strict mode when the best candidate is near-identical.
+func (s *TicketServiceImpl) strictDuplicate(dups []*ticket.DuplicateCandidate) error {
+	if len(dups) == 0 || dups[0].Similarity < s.dedup.strict {
+		return nil
+	}
+	return &kcommon.ServiceError{Code: common.ErrCodeConflict, Message: fmt.Sprintf("duplicate of ticket %s (similarity %.3f)", dups[0].Id, dups[0].Similarity)}
+}
+
+// reindexTicket (re)embeds an open ticket in the background, e.g. after reopen.
+func (s *TicketServiceImpl) reindexTicket(t *common.Ticket) {
+	if s.dedup.embedder == nil {
+		return
+	}
+	id, title, desc := t.ID, t.Title, t.Desc
+	ctx := common.WithTenant(context.Background(), t.Tenant)
+	go func() {
+		if vec := s.embedTicketText(ctx, title, desc); vec != nil {
+			s.vectors.put(id, vec)
+		}
+	}()
+}
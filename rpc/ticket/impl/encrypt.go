@@ -0,0 +1,241 @@
+package impl
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/cloudwego/kitex/pkg/klog"
+
+	"github.com/gogogo1024/assist-fusion/internal/common"
+	"github.com/gogogo1024/assist-fusion/internal/fieldcrypt"
+)
+
+// WithFieldEncryption stores ticket descriptions, event notes and comment bodies sealed
+// with env. The service keeps working on plaintext; only the repo sees ciphertext.
+func WithFieldEncryption(env *fieldcrypt.Envelope) Option {
+	return func(s *TicketServiceImpl) { s.Repo = &encryptedRepo{inner: s.Repo, env: env} }
+}
+
+// encryptedRepo seals fields on the way into the wrapped repo and opens them on the way
+// out. Both directions work on copies, so callers never see (or keep) ciphertext.
+type encryptedRepo struct {
+	inner common.TicketRepo
+	env   *fieldcrypt.Envelope
+	// mu serializes writes with re-encryption, which must not overwrite a newer update
+	mu sync.Mutex
+}
+
+// crypt applies fn to every encrypted field of t. The AAD ties each value to its ticket
+// and field, so ciphertext copied onto another record does not open.
+func crypt(t *common.Ticket, fn func(v *string, aad string) error) error {
+	if err := fn(&t.Desc, t.ID+"/desc"); err != nil {
+		return err
+	}
+	for i := range t.Events {
+		if err := fn(&t.Events[i].Note, t.ID+"/event.note"); err != nil {
+			return err
+		}
+	}
+	for i := range t.Comments {
+		if err := fn(&t.Comments[i].Body, t.ID+"/comment.body"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// cloneTicket copies t deeply enough that mutating the copy never touches the original.
+func cloneTicket(t *common.Ticket) *common.Ticket {
+	c := *t
+	c.Tags = append([]string(nil), t.Tags...)
+	c.Watchers = append([]string(nil), t.Watchers...)
+	c.Events = append([]common.TicketEvent(nil), t.Events...)
+	c.Comments = append([]common.TicketComment(nil), t.Comments...)
+	c.Cycles = append([]common.TicketCycle(nil), t.Cycles...)
+	if t.CustomFields != nil {
+		c.CustomFields = make(map[string]string, len(t.CustomFields))
+		for k, v := range t.CustomFields {
+			c.CustomFields[k] = v
+		}
+	}
+	for i := range c.Cycles {
+		if cs := c.Cycles[i].CSAT; cs != nil {
+			cp := *cs
+			c.Cycles[i].CSAT = &cp
+		}
+	}
+	return &c
+}
+
+func (r *encryptedRepo) seal(t *common.Ticket) (*common.Ticket, error) {
+	c := cloneTicket(t)
+	err := crypt(c, func(v *string, aad string) (err error) {
+		*v, err = r.env.Seal(*v, aad)
+		return err
+	})
+	return c, err
+}
+
+func (r *encryptedRepo) open(t *common.Ticket) (*common.Ticket, error) {
+	if t == nil {
+		return nil, nil
+	}
+	c := cloneTicket(t)
+	err := crypt(c, func(v *string, aad string) (err error) {
+		*v, err = r.env.Open(*v, aad)
+		return err
+	})
+	if err != nil {
+		klog.Errorf("ticket %s: decrypt: %v", t.ID, err)
+		return nil, err
+	}
+	return c, nil
+}
+
+func (r *encryptedRepo) Create(ctx context.Context, t *common.Ticket) error {
+	c, err := r.seal(t)
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	err = r.inner.Create(ctx, c)
+	t.Tenant = c.Tenant
+	return err
+}
+
+func (r *encryptedRepo) Get(ctx context.Context, id string) (*common.Ticket, error) {
+	t, err := r.inner.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return r.open(t)
+}
+
+// List skips tickets that cannot be decrypted (logged) instead of failing the whole list.
+func (r *encryptedRepo) List(ctx context.Context) ([]*common.Ticket, error) {
+	ts, err := r.inner.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*common.Ticket, 0, len(ts))
+	for _, t := range ts {
+		if o, err := r.open(t); err == nil {
+			out = append(out, o)
+		}
+	}
+	return out, nil
+}
+
+func (r *encryptedRepo) Update(ctx context.Context, t *common.Ticket) error {
+	c, err := r.seal(t)
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.inner.Update(ctx, c)
+}
+
+func (r *encryptedRepo) Delete(ctx context.Context, id string) error {
+	return r.inner.Delete(ctx, id)
+}
+
+func (r *encryptedRepo) Tenants() []string {
+	if tl, ok := r.inner.(common.TenantLister); ok {
+		return tl.Tenants()
+	}
+	return []string{common.DefaultTenant}
+}
+
+// stale reports whether any field of the stored ticket is plain or sealed under an old key.
+func (r *encryptedRepo) stale(t *common.Ticket) bool {
+	found := false
+	_ = crypt(t, func(v *string, _ string) error {
+		found = found || r.env.Stale(*v)
+		return nil
+	})
+	return found
+}
+
+// reencrypt rewrites the caller tenant's stale tickets under the primary key.
+func (r *encryptedRepo) reencrypt(ctx context.Context) (int, error) {
+	ts, err := r.inner.List(ctx)
+	if err != nil {
+		return 0, err
+	}
+	n := 0
+	for _, t := range ts {
+		if !r.stale(t) {
+			continue
+		}
+		r.mu.Lock()
+		// re-read under the lock: the ticket may have been updated (or purged) meanwhile
+		cur, _ := r.inner.Get(ctx, t.ID)
+		var c *common.Ticket
+		plain, err := r.open(cur)
+		if err == nil && plain != nil {
+			c, err = r.seal(plain)
+		}
+		if err == nil && c != nil {
+			err = r.inner.Update(ctx, c)
+		}
+		r.mu.Unlock()
+		if err != nil {
+			return n, err
+		}
+		if c != nil {
+			n++
+		}
+	}
+	return n, nil
+}
+
+// keyReloader is implemented by key providers backed by a file (fieldcrypt.FileKeyProvider).
+type keyReloader interface{ Reload() error }
+
+// ReencryptTickets reloads the keys and rewrites every tenant's tickets that are not yet
+// sealed under the primary key (rotation, or encryption enabled on existing data).
+func (s *TicketServiceImpl) ReencryptTickets(ctx context.Context) (int, error) {
+	r, ok := s.Repo.(*encryptedRepo)
+	if !ok {
+		return 0, nil
+	}
+	if kr, ok := r.env.Keys().(keyReloader); ok {
+		if err := kr.Reload(); err != nil {
+			klog.Warnf("reload field keys: %v (keeping loaded keys)", err)
+		}
+	}
+	total := 0
+	for _, tenant := range r.Tenants() {
+		n, err := r.reencrypt(common.WithTenant(ctx, tenant))
+		total += n
+		if err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
+
+// RunReencrypt runs ReencryptTickets every interval until ctx is done.
+func (s *TicketServiceImpl) RunReencrypt(ctx context.Context, interval time.Duration) {
+	if _, ok := s.Repo.(*encryptedRepo); !ok {
+		return
+	}
+	tick := time.NewTicker(interval)
+	defer tick.Stop()
+	for {
+		n, err := s.ReencryptTickets(ctx)
+		if err != nil {
+			klog.Errorf("re-encrypt tickets: %v (rewrote %d)", err, n)
+		} else if n > 0 {
+			klog.Infof("re-encrypted %d tickets", n)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-tick.C:
+		}
+	}
+}
@@ -0,0 +1,285 @@
+package impl
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gogogo1024/assist-fusion/internal/common"
+	"github.com/gogogo1024/assist-fusion/internal/observability"
+	kcommon "github.com/gogogo1024/assist-fusion/kitex_gen/common"
+	"github.com/gogogo1024/assist-fusion/kitex_gen/ticket"
+)
+
+// TierStep is one tier on an escalation path. Assignee, when set, takes over tickets
+// escalated (or de-escalated) into the tier unless the request names someone else.
+type TierStep struct {
+	Tier     string
+	Assignee string
+}
+
+// anyCategory keys the path used for categories without their own.
+const anyCategory = "*"
+
+// defaultEscalationPath applies when no paths are configured.
+var defaultEscalationPath = []TierStep{{Tier: "L1"}, {Tier: "L2"}, {Tier: "L3"}, {Tier: "vendor"}}
+
+// ParseEscalationPaths parses ";" separated "category:tier>tier=assignee>..." entries, e.g.
+// "*:L1>L2>L3>vendor;billing:L1>L2=billing-team>vendor=acme-support". Category "*" is
+// the fallback path; without it the default L1>L2>L3>vendor path is used.
+func ParseEscalationPaths(spec string) (map[string][]TierStep, error) {
+	out := map[string][]TierStep{}
+	for _, entry := range strings.Split(spec, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		category, steps, ok := strings.Cut(entry, ":")
+		category = strings.TrimSpace(category)
+		if !ok || category == "" {
+			return nil, fmt.Errorf("escalation path %q: want category:tier>tier", entry)
+		}
+		if _, dup := out[category]; dup {
+			return nil, fmt.Errorf("escalation path %q: duplicate category", entry)
+		}
+		var path []TierStep
+		seen := map[string]bool{}
+		for _, step := range strings.Split(steps, ">") {
+			tier, assignee, _ := strings.Cut(strings.TrimSpace(step), "=")
+			tier = strings.TrimSpace(tier)
+			if tier == "" || seen[tier] {
+				return nil, fmt.Errorf("escalation path %q: empty or repeated tier", entry)
+			}
+			seen[tier] = true
+			path = append(path, TierStep{Tier: tier, Assignee: strings.TrimSpace(assignee)})
+		}
+		if len(path) < 2 {
+			return nil, fmt.Errorf("escalation path %q: needs at least two tiers", entry)
+		}
+		out[category] = path
+	}
+	return out, nil
+}
+
+// WithEscalationPaths sets the per-category escalation paths (see ParseEscalationPaths).
+func WithEscalationPaths(paths map[string][]TierStep) Option {
+	return func(s *TicketServiceImpl) { s.escalation = paths }
+}
+
+func (s *TicketServiceImpl) escalationPath(category string) []TierStep {
+	if p, ok := s.escalation[category]; ok {
+		return p
+	}
+	if p, ok := s.escalation[anyCategory]; ok {
+		return p
+	}
+	return defaultEscalationPath
+}
+
+// ticketTier is t's tier; tickets created before tiers existed sit in their path's first tier.
+func (s *TicketServiceImpl) ticketTier(t *common.Ticket) string {
+	if t.Tier != "" {
+		return t.Tier
+	}
+	return s.escalationPath(t.Category)[0].Tier
+}
+
+func tierIndex(path []TierStep, tier string) int {
+	for i, st := range path {
+		if st.Tier == tier {
+			return i
+		}
+	}
+	return -1
+}
+
+// moveTarget picks the destination step for an escalation (up) or de-escalation: the
+// requested tier, which must lie in that direction on the path, or the adjacent one.
+func (s *TicketServiceImpl) moveTarget(t *common.Ticket, req *ticket.TicketActionRequest, up bool) (TierStep, error) {
+	path := s.escalationPath(t.Category)
+	cur := tierIndex(path, s.ticketTier(t))
+	if cur < 0 {
+		// the path changed under the ticket: treat it as sitting in the first tier
+		cur = 0
+	}
+	next := cur - 1
+	if up {
+		next = cur + 1
+	}
+	if want := req.GetTier(); want != "" {
+		next = tierIndex(path, want)
+		if next < 0 {
+			return TierStep{}, &kcommon.ServiceError{Code: common.ErrCodeBadRequest, Message: fmt.Sprintf("tier %q is not on the escalation path", want)}
+		}
+		if (up && next <= cur) || (!up && next >= cur) {
+			return TierStep{}, &kcommon.ServiceError{Code: common.ErrCodeConflict, Message: fmt.Sprintf("ticket is already at tier %s", path[cur].Tier)}
+		}
+	}
+	if next < 0 {
+		return TierStep{}, &kcommon.ServiceError{Code: common.ErrCodeConflict, Message: "ticket is already at the first tier"}
+	}
+	if next >= len(path) {
+		return TierStep{}, &kcommon.ServiceError{Code: common.ErrCodeConflict, Message: "ticket is already at the highest tier"}
+	}
+	return path[next], nil
+}
+
+// moveTier moves t into step's queue, reassigning it when the request or the step names
+// an assignee, and records the move as an event of type typ.
+func (s *TicketServiceImpl) moveTier(ctx context.Context, t *common.Ticket, req *ticket.TicketActionRequest, step TierStep, typ string) {
+	now := time.Now().Unix()
+	ev := newEvent(ctx, typ, now, actionNote(req))
+	ev.FromTier, ev.ToTier = s.ticketTier(t), step.Tier
+	if a := req.GetAssignee(); a != "" {
+		t.Assignee = a
+	} else if step.Assignee != "" {
+		t.Assignee = step.Assignee
+	}
+	t.Tier = step.Tier
+	// the ticket moves to another queue; any claim from the old one lapses
+	releaseClaim(t)
+	var cyc *common.TicketCycle
+	if t.CurrentCycle >= 0 && t.CurrentCycle < len(t.Cycles) {
+		cyc = &t.Cycles[t.CurrentCycle]
+		cyc.Tier = step.Tier
+	}
+	status := "escalated"
+	if typ == "deescalated" && tierIndex(s.escalationPath(t.Category), step.Tier) == 0 {
+		// back in the first tier: the ticket is ordinary work again
+		status = "created"
+		if t.Assignee != "" {
+			status = "assigned"
+		}
+	}
+	t.Status = status
+	if typ == "escalated" {
+		t.EscalatedAt = now
+	}
+	if cyc != nil {
+		cyc.Status = status
+		if typ == "escalated" {
+			cyc.EscalatedAt = now
+		}
+	}
+	appendEvent(t, ev)
+}
+
+func (s *TicketServiceImpl) changeTier(ctx context.Context, req *ticket.TicketActionRequest, up bool) (*ticket.TicketResponse, error) {
+	if req == nil || req.Id == "" {
+		return nil, &kcommon.ServiceError{Code: common.ErrCodeBadRequest, Message: idRequiredMsg}
+	}
+	defer s.lockTicket(ctx, req.Id)()
+	t := s.lookup(ctx, req.Id)
+	if t == nil {
+		return nil, &kcommon.ServiceError{Code: common.ErrCodeNotFound, Message: notFoundMsg}
+	}
+	if err := s.checkLock(ctx, t); err != nil {
+		return nil, err
+	}
+	// Business rule: tiers only change while the ticket is being worked on
+	if t.Status == "resolved" {
+		return nil, &kcommon.ServiceError{Code: common.ErrCodeConflict, Message: "cannot change the tier of a resolved ticket"}
+	}
+	step, err := s.moveTarget(t, req, up)
+	if err != nil {
+		return nil, err
+	}
+	typ := "deescalated"
+	if up {
+		typ = "escalated"
+	}
+	s.moveTier(ctx, t, req, step, typ)
+	_ = s.Repo.Update(ctx, t)
+	s.publish(ctx, t, len(t.Events)-1)
+	if up {
+		observability.TicketEscalated.Add(1)
+	} else {
+		observability.TicketDeescalated.Add(1)
+	}
+	return &ticket.TicketResponse{Ticket: s.toThriftTicket(t)}, nil
+}
+
+// Escalate moves the ticket to the next tier on its category's path (or req.Tier further up).
+func (s *TicketServiceImpl) Escalate(ctx context.Context, req *ticket.TicketActionRequest) (*ticket.TicketResponse, error) {
+	return s.changeTier(ctx, req, true)
+}
+
+// Deescalate hands the ticket back to the previous tier (or req.Tier further down).
+func (s *TicketServiceImpl) Deescalate(ctx context.Context, req *ticket.TicketActionRequest) (*ticket.TicketResponse, error) {
+	return s.changeTier(ctx, req, false)
+}
+
+type tierAgg struct {
+	entered, escalated, deescalated, resolved int
+}
+
+// EscalationReport replays ticket events to count, per tier, how many tickets entered its
+// queue and how many left it upwards (escalation rate), downwards or by being resolved.
+func (s *TicketServiceImpl) EscalationReport(ctx context.Context, req *ticket.EscalationReportRequest) (*ticket.EscalationReportResponse, error) {
+	if req == nil {
+		req = &ticket.EscalationReportRequest{}
+	}
+	in := func(at int64) bool {
+		return (req.From == nil || at >= *req.From) && (req.To == nil || at < *req.To)
+	}
+	ts, _ := s.Repo.List(ctx)
+	tiers := map[string]*tierAgg{}
+	agg := func(tier string) *tierAgg {
+		a := tiers[tier]
+		if a == nil {
+			a = &tierAgg{}
+			tiers[tier] = a
+		}
+		return a
+	}
+	total := 0
+	for _, t := range ts {
+		if req.Category != nil && t.Category != *req.Category {
+			continue
+		}
+		cur := s.escalationPath(t.Category)[0].Tier
+		for _, e := range t.Events {
+			counted := in(e.At)
+			switch e.Type {
+			case "created", "reopened":
+				if e.ToTier != "" {
+					cur = e.ToTier
+				}
+				if counted {
+					agg(cur).entered++
+				}
+			case "escalated", "deescalated":
+				if e.ToTier == "" {
+					// escalation from before tiers existed: status change only
+					continue
+				}
+				if counted {
+					if e.Type == "escalated" {
+						agg(e.FromTier).escalated++
+						total++
+					} else {
+						agg(e.FromTier).deescalated++
+					}
+					agg(e.ToTier).entered++
+				}
+				cur = e.ToTier
+			case "resolved":
+				if counted {
+					agg(cur).resolved++
+				}
+			}
+		}
+	}
+	rows := make([]*ticket.EscalationTierRow, 0, len(tiers))
+	for tier, a := range tiers {
+		row := &ticket.EscalationTierRow{Tier: tier, Entered: int32(a.entered), Escalated: int32(a.escalated), Deescalated: int32(a.deescalated), Resolved: int32(a.resolved)}
+		if a.entered > 0 {
+			row.EscalationRate = float64(a.escalated) / float64(a.entered)
+		}
+		rows = append(rows, row)
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].Tier < rows[j].Tier })
+	return &ticket.EscalationReportResponse{Rows: rows, TotalEscalations: int32(total)}, nil
+}
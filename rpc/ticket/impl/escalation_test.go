@@ -0,0 +1,110 @@
+package impl
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gogogo1024/assist-fusion/internal/common"
+	"github.com/gogogo1024/assist-fusion/kitex_gen/ticket"
+)
+
+func TestParseEscalationPaths(t *testing.T) {
+	paths, err := ParseEscalationPaths("*:L1>L2>L3>vendor; billing:L1>L2=billing-team>vendor=acme@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := paths["billing"]
+	if len(paths["*"]) != 4 || len(b) != 3 || b[1].Assignee != "billing-team" || b[2].Assignee != "acme@example.com" {
+		t.Fatalf("unexpected paths: %+v", paths)
+	}
+	for _, bad := range []string{"billing", "billing:L1", "billing:L1>L1", "x:L1>>L2", "a:L1>L2;a:L1>L3"} {
+		if _, err := ParseEscalationPaths(bad); err == nil {
+			t.Errorf("%q: expected error", bad)
+		}
+	}
+}
+
+func TestEscalationTiers(t *testing.T) {
+	paths, _ := ParseEscalationPaths("billing:L1>L2=billing-team>vendor=acme")
+	s := NewTicketService(common.NewMemoryTicketRepo(), WithEscalationPaths(paths))
+	ctx := context.Background()
+	category := "billing"
+	r, _ := s.CreateTicket(ctx, &ticket.CreateTicketRequest{Title: "refund", Desc: "x", Category: &category})
+	id := r.Ticket.Id
+	if r.Ticket.Tier != "L1" {
+		t.Fatalf("initial tier = %q", r.Ticket.Tier)
+	}
+	other, _ := s.CreateTicket(ctx, &ticket.CreateTicketRequest{Title: "vpn", Desc: "x"})
+
+	up, err := s.Escalate(ctx, &ticket.TicketActionRequest{Id: id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tk := up.Ticket
+	if tk.Tier != "L2" || tk.Assignee != "billing-team" || tk.Cycles[0].Tier != "L2" {
+		t.Fatalf("after escalate: tier=%q assignee=%q", tk.Tier, tk.Assignee)
+	}
+	ev := tk.Events[len(tk.Events)-1]
+	if ev.Type != "escalated" || ev.FromTier != "L1" || ev.ToTier != "L2" {
+		t.Fatalf("event = %+v", ev)
+	}
+	list, _ := s.ListTickets(ctx, &ticket.ListTicketsRequest{Tier: strPtr("L2")})
+	if len(list.Tickets) != 1 || list.Tickets[0].Id != id {
+		t.Fatalf("L2 queue = %+v", list.Tickets)
+	}
+
+	// explicit assignee wins over the tier's default; no tier above vendor
+	up, _ = s.Escalate(ctx, &ticket.TicketActionRequest{Id: id, Assignee: strPtr("carol")})
+	if up.Ticket.Tier != "vendor" || up.Ticket.Assignee != "carol" {
+		t.Fatalf("after second escalate: %+v", up.Ticket)
+	}
+	if _, err := s.Escalate(ctx, &ticket.TicketActionRequest{Id: id}); err == nil {
+		t.Fatal("escalated past the last tier")
+	}
+	if _, err := s.Escalate(ctx, &ticket.TicketActionRequest{Id: id, Tier: strPtr("L2")}); err == nil {
+		t.Fatal("escalate to a lower tier accepted")
+	}
+
+	// de-escalate straight back to L1: ordinary assigned work again
+	down, err := s.Deescalate(ctx, &ticket.TicketActionRequest{Id: id, Tier: strPtr("L1")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if down.Ticket.Tier != "L1" || down.Ticket.Status.String() != "ASSIGNED" {
+		t.Fatalf("after deescalate: tier=%q status=%v", down.Ticket.Tier, down.Ticket.Status)
+	}
+	if _, err := s.Deescalate(ctx, &ticket.TicketActionRequest{Id: id}); err == nil {
+		t.Fatal("de-escalated below the first tier")
+	}
+	s.Resolve(ctx, &ticket.TicketActionRequest{Id: id})
+	if _, err := s.Escalate(ctx, &ticket.TicketActionRequest{Id: id}); err == nil {
+		t.Fatal("resolved ticket escalated")
+	}
+	s.Escalate(ctx, &ticket.TicketActionRequest{Id: other.Ticket.Id})
+
+	if v, _ := s.VerifyAudit(ctx, &ticket.VerifyAuditRequest{Id: id}); !v.Ok {
+		t.Fatalf("audit: %s", v.Reason)
+	}
+
+	rep, _ := s.EscalationReport(ctx, &ticket.EscalationReportRequest{})
+	rows := map[string]*ticket.EscalationTierRow{}
+	for _, r := range rep.Rows {
+		rows[r.Tier] = r
+	}
+	// L1: two created + one de-escalated back, two escalated out, one resolved
+	if l1 := rows["L1"]; l1.Entered != 3 || l1.Escalated != 2 || l1.Resolved != 1 || l1.EscalationRate < 0.66 || l1.EscalationRate > 0.67 {
+		t.Fatalf("L1 row = %+v", l1)
+	}
+	if v := rows["vendor"]; v.Entered != 1 || v.Deescalated != 1 || v.Escalated != 0 {
+		t.Fatalf("vendor row = %+v", v)
+	}
+	if rep.TotalEscalations != 3 {
+		t.Fatalf("total escalations = %d", rep.TotalEscalations)
+	}
+	billing, _ := s.EscalationReport(ctx, &ticket.EscalationReportRequest{Category: &category})
+	if billing.TotalEscalations != 2 {
+		t.Fatalf("billing escalations = %d", billing.TotalEscalations)
+	}
+}
+
+func strPtr(s string) *string { return &s }
@@ -2,88 +2,261 @@ package impl
 
 import (
 	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/gogogo1024/assist-fusion/internal/audit"
 	"github.com/gogogo1024/assist-fusion/internal/common"
+	"github.com/gogogo1024/assist-fusion/internal/idempotency"
+	"github.com/gogogo1024/assist-fusion/internal/notify"
 	"github.com/gogogo1024/assist-fusion/internal/observability"
 	kcommon "github.com/gogogo1024/assist-fusion/kitex_gen/common"
 	"github.com/gogogo1024/assist-fusion/kitex_gen/ticket"
 	"github.com/google/uuid"
 )
 
-type TicketServiceImpl struct{ Repo common.TicketRepo }
+type TicketServiceImpl struct {
+	Repo common.TicketRepo
+	// csatReopenBelow reopens the ticket when a survey rating is below this value (0 disables).
+	csatReopenBelow int
+	surveyTTL       time.Duration
+	// auditSigner signs exported audit chain heads (nil leaves exports unsigned).
+	auditSigner *audit.Signer
+	notifier    *notify.Dispatcher
+	dedup       dedupConfig
+	vectors     *ticketVectors
+	idemTTL     time.Duration
+	idem        *idempotency.Store
+	retention   []RetentionPolicy
+	receipts    erasureReceipts
+	// escalation holds per-category tier paths ("*" is the fallback)
+	escalation map[string][]TierStep
+	views      viewStore
+	// pull queue: ranking weights, claim lifetime and the lock that makes claims atomic
+	pullWeights PullWeights
+	claimTTL    time.Duration
+	claimMu     sync.Mutex
+	// edit locks (in memory only) and how long they last between heartbeats
+	locks   lockTable
+	lockTTL time.Duration
+	// ticketMu serializes updates per ticket so the audit chain stays linear
+	ticketMu ticketMutexes
+}
+
+type Option func(*TicketServiceImpl)
+
+// WithCSATReopenBelow enables automatic reopen for survey ratings strictly below n.
+func WithCSATReopenBelow(n int) Option { return func(s *TicketServiceImpl) { s.csatReopenBelow = n } }
 
-func NewTicketService(repo common.TicketRepo) *TicketServiceImpl {
-	return &TicketServiceImpl{Repo: repo}
+// WithSurveyTTL bounds how long a survey token stays redeemable after resolve.
+func WithSurveyTTL(d time.Duration) Option { return func(s *TicketServiceImpl) { s.surveyTTL = d } }
+
+// WithIdempotencyTTL sets how long an Idempotency-Key replays its first response.
+func WithIdempotencyTTL(d time.Duration) Option { return func(s *TicketServiceImpl) { s.idemTTL = d } }
+
+// WithAuditSigner signs ExportAuditHead responses with signer.
+func WithAuditSigner(signer *audit.Signer) Option {
+	return func(s *TicketServiceImpl) { s.auditSigner = signer }
 }
 
+// WithNotifier routes ticket events to watchers, assignee and customer through d.
+func WithNotifier(d *notify.Dispatcher) Option {
+	return func(s *TicketServiceImpl) { s.notifier = d }
+}
+
+func NewTicketService(repo common.TicketRepo, opts ...Option) *TicketServiceImpl {
+	s := &TicketServiceImpl{
+		Repo:      repo,
+		surveyTTL: defaultSurveyTTL,
+		dedup:     dedupConfig{threshold: defaultDupThreshold, strict: defaultDupStrict, window: defaultDupWindow},
+		vectors:   newTicketVectors(),
+		idemTTL:   defaultIdempotencyTTL,
+
+		pullWeights: defaultPullWeights,
+		claimTTL:    defaultClaimTTL,
+		lockTTL:     defaultLockTTL,
+	}
+	for _, o := range opts {
+		o(s)
+	}
+	s.idem = idempotency.NewStore(s.idemTTL)
+	if s.notifier == nil {
+		s.notifier = notify.NewDispatcher()
+	}
+	return s
+}
+
+// defaultIdempotencyTTL matches the 24h replay window clients commonly assume.
+const defaultIdempotencyTTL = 24 * time.Hour
+
 const (
 	notFoundMsg   = "not found"
 	idRequiredMsg = "id required"
 )
 
-func toThriftTicket(t *common.Ticket) *kcommon.Ticket {
-	if t == nil {
-		return nil
-	}
-	statusEnum := kcommon.TicketStatus_CREATED
-	switch t.Status {
+func toThriftStatus(status string) kcommon.TicketStatus {
+	switch status {
 	case "assigned":
-		statusEnum = kcommon.TicketStatus_ASSIGNED
+		return kcommon.TicketStatus_ASSIGNED
 	case "escalated":
-		statusEnum = kcommon.TicketStatus_ESCALATED
+		return kcommon.TicketStatus_ESCALATED
 	case "resolved":
-		statusEnum = kcommon.TicketStatus_RESOLVED
+		return kcommon.TicketStatus_RESOLVED
+	}
+	return kcommon.TicketStatus_CREATED
+}
+
+func toThriftCycle(c common.TicketCycle) *kcommon.TicketCycle {
+	out := &kcommon.TicketCycle{CreatedAt: c.CreatedAt, AssignedAt: c.AssignedAt, ResolvedAt: c.ResolvedAt, EscalatedAt: c.EscalatedAt, Status: toThriftStatus(c.Status), Tier: c.Tier}
+	if c.CSAT != nil {
+		out.Csat = &kcommon.CSATResponse{Rating: int32(c.CSAT.Rating), Comment: c.CSAT.Comment, SubmittedAt: c.CSAT.SubmittedAt}
+	}
+	return out
+}
+
+func toThriftEvent(e common.TicketEvent) *kcommon.TicketEvent {
+	return &kcommon.TicketEvent{Type: e.Type, At: e.At, Note: e.Note, Actor: &kcommon.Actor{Id: e.Actor.ID, Kind: e.Actor.Kind, DisplayName: e.Actor.Name}, Source: e.Source, PrevHash: e.PrevHash, Hash: e.Hash, Redacted: e.RedactedDigest != "", FromTier: e.FromTier, ToTier: e.ToTier}
+}
+
+// appendEvent chains ev onto the ticket's audit log.
+func appendEvent(t *common.Ticket, ev common.TicketEvent) {
+	t.Events = audit.Append(t.ID, t.Events, ev)
+}
+
+// publish notifies recipients about the events recorded from index from onward (call after persisting).
+func (s *TicketServiceImpl) publish(ctx context.Context, t *common.Ticket, from int) {
+	for _, ev := range t.Events[from:] {
+		s.notifier.Notify(ctx, t, ev)
+	}
+}
+
+// newEvent stamps an audit event with the principal propagated in ctx.
+func newEvent(ctx context.Context, typ string, at int64, note string) common.TicketEvent {
+	a, source := common.ActorFromContext(ctx)
+	return common.TicketEvent{Type: typ, At: at, Note: note, Actor: a, Source: source}
+}
+
+func (s *TicketServiceImpl) toThriftTicket(t *common.Ticket) *kcommon.Ticket {
+	if t == nil {
+		return nil
 	}
 	cycles := make([]*kcommon.TicketCycle, 0, len(t.Cycles))
 	for _, c := range t.Cycles {
-		sc := kcommon.TicketStatus_CREATED
-		switch c.Status {
-		case "assigned":
-			sc = kcommon.TicketStatus_ASSIGNED
-		case "escalated":
-			sc = kcommon.TicketStatus_ESCALATED
-		case "resolved":
-			sc = kcommon.TicketStatus_RESOLVED
-		}
-		cycles = append(cycles, &kcommon.TicketCycle{CreatedAt: c.CreatedAt, AssignedAt: c.AssignedAt, ResolvedAt: c.ResolvedAt, EscalatedAt: c.EscalatedAt, Status: sc})
+		cycles = append(cycles, toThriftCycle(c))
 	}
 	events := make([]*kcommon.TicketEvent, 0, len(t.Events))
 	for _, e := range t.Events {
-		events = append(events, &kcommon.TicketEvent{Type: e.Type, At: e.At, Note: e.Note})
+		events = append(events, toThriftEvent(e))
+	}
+	comments := make([]*kcommon.TicketComment, 0, len(t.Comments))
+	for _, c := range t.Comments {
+		comments = append(comments, toThriftComment(c))
 	}
-	return &kcommon.Ticket{Id: t.ID, Title: t.Title, Desc: t.Desc, Status: statusEnum, CreatedAt: t.CreatedAt, AssignedAt: t.AssignedAt, ResolvedAt: t.ResolvedAt, EscalatedAt: t.EscalatedAt, ReopenedAt: t.ReopenedAt, Cycles: cycles, CurrentCycle: int32(t.CurrentCycle), Events: events}
+	out := &kcommon.Ticket{Id: t.ID, Title: t.Title, Desc: t.Desc, Status: toThriftStatus(t.Status), CreatedAt: t.CreatedAt, AssignedAt: t.AssignedAt, ResolvedAt: t.ResolvedAt, EscalatedAt: t.EscalatedAt, ReopenedAt: t.ReopenedAt, Cycles: cycles, CurrentCycle: int32(t.CurrentCycle), Events: events, Assignee: t.Assignee, Category: t.Category, Customer: t.Customer, Watchers: t.Watchers, Comments: comments, ArchivedAt: t.ArchivedAt, DeletedAt: t.DeletedAt, Anonymized: t.Anonymized, Tier: t.Tier,
+		Priority: t.Priority, Tags: t.Tags, CustomFields: t.CustomFields, DueAt: t.DueAt, SlaStatus: slaStatus(t, time.Now())}
+	// an expired claim is just history; the ticket is back in the queue
+	if claimActive(t, time.Now()) {
+		out.ClaimedBy, out.ClaimExpiresAt = t.ClaimedBy, t.ClaimExpiresAt
+	}
+	if l := s.locks.current(t.Tenant, t.ID); l != nil {
+		out.Lock = l.thrift(t.ID)
+	}
+	return out
 }
 
 func (s *TicketServiceImpl) CreateTicket(ctx context.Context, req *ticket.CreateTicketRequest) (*ticket.TicketResponse, error) {
 	if req == nil || req.Title == "" {
 		return nil, &kcommon.ServiceError{Code: common.ErrCodeBadRequest, Message: "title required"}
 	}
+	key := req.GetIdempotencyKey()
+	if key == "" {
+		return s.createTicket(ctx, req)
+	}
+	if len(key) > idempotency.MaxKeyLen {
+		return nil, &kcommon.ServiceError{Code: common.ErrCodeBadRequest, Message: "idempotency key too long"}
+	}
+	// keys are private to the caller: the same key from another user or tenant is a different request
+	actor, _ := common.ActorFromContext(ctx)
+	scoped := idempotency.Key(key, common.TenantFromContext(ctx), actor.ID, "ticket.create")
+	fp := idempotency.Fingerprint(req.Title, req.Desc, req.GetNote(), req.GetCategory(), req.GetCustomer(), strconv.FormatBool(req.GetStrictDuplicates()),
+		req.GetPriority(), strings.Join(req.GetTags(), ","), fieldsFingerprint(req.GetCustomFields()), strconv.FormatInt(req.GetDueAt(), 10))
+	resp, err := s.idem.Do(scoped, fp, func() (any, error) { return s.createTicket(ctx, req) })
+	if errors.Is(err, idempotency.ErrKeyReused) {
+		return nil, &kcommon.ServiceError{Code: common.ErrCodeConflict, Message: err.Error()}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*ticket.TicketResponse), nil
+}
+
+func (s *TicketServiceImpl) createTicket(ctx context.Context, req *ticket.CreateTicketRequest) (*ticket.TicketResponse, error) {
 	note := ""
 	if req.Note != nil {
 		note = *req.Note
 	}
-	now := time.Now().Unix()
-	t := &common.Ticket{ID: uuid.NewString(), Title: req.Title, Desc: req.Desc, Status: "created", CreatedAt: now, Cycles: []common.TicketCycle{{CreatedAt: now, Status: "created"}}, CurrentCycle: 0, Events: []common.TicketEvent{{Type: "created", At: now, Note: note}}}
+	if !validPriority(req.GetPriority()) {
+		return nil, &kcommon.ServiceError{Code: common.ErrCodeBadRequest, Message: "priority must be low|normal|high|urgent"}
+	}
+	created := time.Now()
+	vec := s.embedTicketText(ctx, req.Title, req.Desc)
+	dups := s.findDuplicates(ctx, vec, created)
+	if req.GetStrictDuplicates() {
+		if err := s.strictDuplicate(dups); err != nil {
+			return nil, err
+		}
+	}
+	now := created.Unix()
+	ev := newEvent(ctx, "created", now, note)
+	customer := req.GetCustomer()
+	// only an inbound email's sender is known to be the customer; an agent filing
+	// a ticket on someone's behalf must name them
+	if _, source := common.ActorFromContext(ctx); customer == "" && source == common.SourceEmail && ev.Actor.Kind == common.ActorKindUser {
+		customer = ev.Actor.ID
+	}
+	// new tickets enter the queue of the first tier on their category's escalation path
+	tier := s.escalationPath(req.GetCategory())[0].Tier
+	ev.ToTier = tier
+	t := &common.Ticket{ID: uuid.NewString(), Title: req.Title, Desc: req.Desc, Category: req.GetCategory(), Customer: customer, Status: "created", CreatedAt: now, Tier: tier, Cycles: []common.TicketCycle{{CreatedAt: now, Status: "created", Tier: tier}}, CurrentCycle: 0,
+		Priority: req.GetPriority(), Tags: normalizeTags(req.GetTags()), CustomFields: req.GetCustomFields(), DueAt: req.GetDueAt()}
+	appendEvent(t, ev)
 	_ = s.Repo.Create(ctx, t)
+	if vec != nil {
+		s.vectors.put(t.ID, vec)
+	}
+	s.publish(ctx, t, 0)
 	observability.TicketCreated.Add(1)
-	return &ticket.TicketResponse{Ticket: toThriftTicket(t)}, nil
+	resp := &ticket.TicketResponse{Ticket: s.toThriftTicket(t)}
+	if s.dedup.embedder != nil {
+		resp.PossibleDuplicates = dups
+	}
+	return resp, nil
 }
 func (s *TicketServiceImpl) GetTicket(ctx context.Context, req *ticket.GetTicketRequest) (*ticket.TicketResponse, error) {
 	if req == nil || req.Id == "" {
 		return nil, &kcommon.ServiceError{Code: common.ErrCodeBadRequest, Message: idRequiredMsg}
 	}
-	t, _ := s.Repo.Get(ctx, req.Id)
+	t := s.lookup(ctx, req.Id)
 	if t == nil {
 		return nil, &kcommon.ServiceError{Code: common.ErrCodeNotFound, Message: notFoundMsg}
 	}
-	return &ticket.TicketResponse{Ticket: toThriftTicket(t)}, nil
+	return &ticket.TicketResponse{Ticket: s.toThriftTicket(t)}, nil
 }
 func (s *TicketServiceImpl) ListTickets(ctx context.Context, req *ticket.ListTicketsRequest) (*ticket.ListTicketsResponse, error) {
+	var include []string
+	var tier string
+	if req != nil {
+		include, tier = req.Include, req.GetTier()
+	}
 	ts, _ := s.Repo.List(ctx)
 	out := make([]*kcommon.Ticket, 0, len(ts))
 	for _, t := range ts {
-		out = append(out, toThriftTicket(t))
+		if listed(t, include) && (tier == "" || s.ticketTier(t) == tier) {
+			out = append(out, s.toThriftTicket(t))
+		}
 	}
 	return &ticket.ListTicketsResponse{Tickets: out}, nil
 }
@@ -91,10 +264,14 @@ func (s *TicketServiceImpl) Assign(ctx context.Context, req *ticket.TicketAction
 	if req == nil || req.Id == "" {
 		return nil, &kcommon.ServiceError{Code: common.ErrCodeBadRequest, Message: idRequiredMsg}
 	}
-	t, _ := s.Repo.Get(ctx, req.Id)
+	defer s.lockTicket(ctx, req.Id)()
+	t := s.lookup(ctx, req.Id)
 	if t == nil {
 		return nil, &kcommon.ServiceError{Code: common.ErrCodeNotFound, Message: notFoundMsg}
 	}
+	if err := s.checkLock(ctx, t); err != nil {
+		return nil, err
+	}
 	note := ""
 	if req.Note != nil {
 		note = *req.Note
@@ -102,24 +279,34 @@ func (s *TicketServiceImpl) Assign(ctx context.Context, req *ticket.TicketAction
 	now := time.Now().Unix()
 	t.AssignedAt = now
 	t.Status = "assigned"
+	// assignment starts the work a pull-queue claim reserved
+	releaseClaim(t)
+	if req.Assignee != nil && *req.Assignee != "" {
+		t.Assignee = *req.Assignee
+	}
 	if t.CurrentCycle >= 0 && t.CurrentCycle < len(t.Cycles) {
 		cyc := &t.Cycles[t.CurrentCycle]
 		cyc.AssignedAt = now
 		cyc.Status = "assigned"
 	}
-	t.Events = append(t.Events, common.TicketEvent{Type: "assigned", At: now, Note: note})
+	appendEvent(t, newEvent(ctx, "assigned", now, note))
 	_ = s.Repo.Update(ctx, t)
+	s.publish(ctx, t, len(t.Events)-1)
 	observability.TicketAssigned.Add(1)
-	return &ticket.TicketResponse{Ticket: toThriftTicket(t)}, nil
+	return &ticket.TicketResponse{Ticket: s.toThriftTicket(t)}, nil
 }
 func (s *TicketServiceImpl) Resolve(ctx context.Context, req *ticket.TicketActionRequest) (*ticket.TicketResponse, error) {
 	if req == nil || req.Id == "" {
 		return nil, &kcommon.ServiceError{Code: common.ErrCodeBadRequest, Message: idRequiredMsg}
 	}
-	t, _ := s.Repo.Get(ctx, req.Id)
+	defer s.lockTicket(ctx, req.Id)()
+	t := s.lookup(ctx, req.Id)
 	if t == nil {
 		return nil, &kcommon.ServiceError{Code: common.ErrCodeNotFound, Message: notFoundMsg}
 	}
+	if err := s.checkLock(ctx, t); err != nil {
+		return nil, err
+	}
 	note := ""
 	if req.Note != nil {
 		note = *req.Note
@@ -127,90 +314,80 @@ func (s *TicketServiceImpl) Resolve(ctx context.Context, req *ticket.TicketActio
 	now := time.Now().Unix()
 	t.ResolvedAt = now
 	t.Status = "resolved"
+	releaseClaim(t)
 	if t.CurrentCycle >= 0 && t.CurrentCycle < len(t.Cycles) {
 		cyc := &t.Cycles[t.CurrentCycle]
 		cyc.ResolvedAt = now
 		cyc.Status = "resolved"
+		cyc.ResolvedBy = t.Assignee
+		// each resolution issues a fresh survey; a reopened cycle gets its own token
+		cyc.SurveyToken = newSurveyToken(t.Tenant)
+		cyc.SurveyIssuedAt = now
 	}
-	t.Events = append(t.Events, common.TicketEvent{Type: "resolved", At: now, Note: note})
+	appendEvent(t, newEvent(ctx, "resolved", now, note))
 	_ = s.Repo.Update(ctx, t)
+	// resolved tickets are no longer duplicate candidates
+	s.vectors.drop(t.ID)
+	s.publish(ctx, t, len(t.Events)-1)
 	observability.TicketResolved.Add(1)
-	return &ticket.TicketResponse{Ticket: toThriftTicket(t)}, nil
+	resp := &ticket.TicketResponse{Ticket: s.toThriftTicket(t)}
+	// the token is the survey's only credential: it goes out once, to the resolver who sends the link
+	if cyc := t.CurrentCycle; cyc >= 0 && cyc < len(t.Cycles) {
+		tok := t.Cycles[cyc].SurveyToken
+		resp.SurveyToken = &tok
+	}
+	return resp, nil
 }
-func (s *TicketServiceImpl) Escalate(ctx context.Context, req *ticket.TicketActionRequest) (*ticket.TicketResponse, error) {
+func (s *TicketServiceImpl) Reopen(ctx context.Context, req *ticket.TicketActionRequest) (*ticket.TicketResponse, error) {
 	if req == nil || req.Id == "" {
 		return nil, &kcommon.ServiceError{Code: common.ErrCodeBadRequest, Message: idRequiredMsg}
 	}
-	t, _ := s.Repo.Get(ctx, req.Id)
+	defer s.lockTicket(ctx, req.Id)()
+	t := s.lookup(ctx, req.Id)
 	if t == nil {
 		return nil, &kcommon.ServiceError{Code: common.ErrCodeNotFound, Message: notFoundMsg}
 	}
-	// Business rule: cannot escalate after resolved
-	if t.Status == "resolved" {
-		return nil, &kcommon.ServiceError{Code: common.ErrCodeConflict, Message: "cannot escalate resolved ticket"}
+	if err := s.checkLock(ctx, t); err != nil {
+		return nil, err
 	}
 	note := ""
 	if req.Note != nil {
 		note = *req.Note
 	}
-	now := time.Now().Unix()
-	t.EscalatedAt = now
-	t.Status = "escalated"
-	if t.CurrentCycle >= 0 && t.CurrentCycle < len(t.Cycles) {
-		cyc := &t.Cycles[t.CurrentCycle]
-		cyc.EscalatedAt = now
-		cyc.Status = "escalated"
-	}
-	t.Events = append(t.Events, common.TicketEvent{Type: "escalated", At: now, Note: note})
+	reopenTicket(t, newEvent(ctx, "reopened", time.Now().Unix(), note))
 	_ = s.Repo.Update(ctx, t)
-	observability.TicketEscalated.Add(1)
-	return &ticket.TicketResponse{Ticket: toThriftTicket(t)}, nil
+	s.reindexTicket(t)
+	s.publish(ctx, t, len(t.Events)-1)
+	observability.TicketReopened.Add(1)
+	return &ticket.TicketResponse{Ticket: s.toThriftTicket(t)}, nil
 }
-func (s *TicketServiceImpl) Reopen(ctx context.Context, req *ticket.TicketActionRequest) (*ticket.TicketResponse, error) {
-	if req == nil || req.Id == "" {
-		return nil, &kcommon.ServiceError{Code: common.ErrCodeBadRequest, Message: idRequiredMsg}
-	}
-	t, _ := s.Repo.Get(ctx, req.Id)
-	if t == nil {
-		return nil, &kcommon.ServiceError{Code: common.ErrCodeNotFound, Message: notFoundMsg}
-	}
-	note := ""
-	if req.Note != nil {
-		note = *req.Note
-	}
-	now := time.Now().Unix()
+
+// reopenTicket starts a fresh cycle on t and records ev (a "reopened" event).
+func reopenTicket(t *common.Ticket, ev common.TicketEvent) {
+	now := ev.At
 	t.ReopenedAt = now
+	// a reopened ticket is active again, so it leaves the archive
+	t.ArchivedAt = 0
 	// start a fresh cycle; status returns to created per integration test expectations
-	t.Cycles = append(t.Cycles, common.TicketCycle{CreatedAt: now, Status: "created"})
+	// the new cycle starts in the queue the ticket was last handled in
+	t.Cycles = append(t.Cycles, common.TicketCycle{CreatedAt: now, Status: "created", Tier: t.Tier})
 	t.CurrentCycle = len(t.Cycles) - 1
 	t.Status = "created"
 	// reset transient timestamps while retaining historical ones in previous cycles
 	t.AssignedAt, t.ResolvedAt, t.EscalatedAt = 0, 0, 0
-	t.Events = append(t.Events, common.TicketEvent{Type: "reopened", At: now, Note: note})
-	_ = s.Repo.Update(ctx, t)
-	observability.TicketReopened.Add(1)
-	return &ticket.TicketResponse{Ticket: toThriftTicket(t)}, nil
+	appendEvent(t, ev)
 }
 func (s *TicketServiceImpl) GetCycles(ctx context.Context, req *ticket.GetCyclesRequest) ([]*kcommon.TicketCycle, error) {
 	if req == nil || req.Id == "" {
 		return nil, &kcommon.ServiceError{Code: common.ErrCodeBadRequest, Message: idRequiredMsg}
 	}
-	t, _ := s.Repo.Get(ctx, req.Id)
+	t := s.lookup(ctx, req.Id)
 	if t == nil {
 		return nil, &kcommon.ServiceError{Code: common.ErrCodeNotFound, Message: notFoundMsg}
 	}
 	out := make([]*kcommon.TicketCycle, 0, len(t.Cycles))
 	for _, c := range t.Cycles {
-		sc := kcommon.TicketStatus_CREATED
-		switch c.Status {
-		case "assigned":
-			sc = kcommon.TicketStatus_ASSIGNED
-		case "escalated":
-			sc = kcommon.TicketStatus_ESCALATED
-		case "resolved":
-			sc = kcommon.TicketStatus_RESOLVED
-		}
-		out = append(out, &kcommon.TicketCycle{CreatedAt: c.CreatedAt, AssignedAt: c.AssignedAt, ResolvedAt: c.ResolvedAt, EscalatedAt: c.EscalatedAt, Status: sc})
+		out = append(out, toThriftCycle(c))
 	}
 	return out, nil
 }
@@ -218,13 +395,19 @@ func (s *TicketServiceImpl) GetEvents(ctx context.Context, req *ticket.GetEvents
 	if req == nil || req.Id == "" {
 		return nil, &kcommon.ServiceError{Code: common.ErrCodeBadRequest, Message: idRequiredMsg}
 	}
-	t, _ := s.Repo.Get(ctx, req.Id)
+	t := s.lookup(ctx, req.Id)
 	if t == nil {
 		return nil, &kcommon.ServiceError{Code: common.ErrCodeNotFound, Message: notFoundMsg}
 	}
 	out := make([]*kcommon.TicketEvent, 0, len(t.Events))
 	for _, e := range t.Events {
-		out = append(out, &kcommon.TicketEvent{Type: e.Type, At: e.At, Note: e.Note})
+		if req.ActorId != nil && e.Actor.ID != *req.ActorId {
+			continue
+		}
+		if req.ActorKind != nil && e.Actor.Kind != *req.ActorKind {
+			continue
+		}
+		out = append(out, toThriftEvent(e))
 	}
 	return out, nil
 }
@@ -0,0 +1,151 @@
+package impl
+
+import (
+	"context"
+	"time"
+
+	"github.com/gogogo1024/assist-fusion/internal/common"
+	"github.com/gogogo1024/assist-fusion/internal/observability"
+	kcommon "github.com/gogogo1024/assist-fusion/kitex_gen/common"
+	"github.com/gogogo1024/assist-fusion/kitex_gen/ticket"
+)
+
+// List include values revealing hidden tickets.
+const (
+	includeArchived = "archived"
+	includeDeleted  = "deleted"
+)
+
+// lookup returns the ticket unless it is missing or soft-deleted; deleted tickets are
+// invisible to every RPC except RestoreTicket (and audit verification).
+func (s *TicketServiceImpl) lookup(ctx context.Context, id string) *common.Ticket {
+	t, _ := s.Repo.Get(ctx, id)
+	if t == nil || t.DeletedAt != 0 {
+		return nil
+	}
+	return t
+}
+
+// listed reports whether ListTickets shows t for the requested include set.
+func listed(t *common.Ticket, include []string) bool {
+	var archived, deleted bool
+	for _, v := range include {
+		switch v {
+		case includeArchived:
+			archived = true
+		case includeDeleted:
+			deleted = true
+		}
+	}
+	if t.DeletedAt != 0 {
+		return deleted
+	}
+	return t.ArchivedAt == 0 || archived
+}
+
+func actionNote(req *ticket.TicketActionRequest) string {
+	if req.Note != nil {
+		return *req.Note
+	}
+	return ""
+}
+
+// DeleteTicket soft-deletes a ticket: it disappears from reads and lists until restored,
+// and is purged for good by a "deleted" retention policy.
+func (s *TicketServiceImpl) DeleteTicket(ctx context.Context, req *ticket.TicketActionRequest) (*ticket.TicketResponse, error) {
+	if req == nil || req.Id == "" {
+		return nil, &kcommon.ServiceError{Code: common.ErrCodeBadRequest, Message: idRequiredMsg}
+	}
+	defer s.lockTicket(ctx, req.Id)()
+	t := s.lookup(ctx, req.Id)
+	if t == nil {
+		return nil, &kcommon.ServiceError{Code: common.ErrCodeNotFound, Message: notFoundMsg}
+	}
+	if err := s.checkLock(ctx, t); err != nil {
+		return nil, err
+	}
+	now := time.Now().Unix()
+	t.DeletedAt = now
+	appendEvent(t, newEvent(ctx, "deleted", now, actionNote(req)))
+	_ = s.Repo.Update(ctx, t)
+	s.vectors.drop(t.ID)
+	observability.TicketDeleted.Add(1)
+	return &ticket.TicketResponse{Ticket: s.toThriftTicket(t)}, nil
+}
+
+// RestoreTicket undoes a soft delete.
+func (s *TicketServiceImpl) RestoreTicket(ctx context.Context, req *ticket.TicketActionRequest) (*ticket.TicketResponse, error) {
+	if req == nil || req.Id == "" {
+		return nil, &kcommon.ServiceError{Code: common.ErrCodeBadRequest, Message: idRequiredMsg}
+	}
+	defer s.lockTicket(ctx, req.Id)()
+	t, _ := s.Repo.Get(ctx, req.Id)
+	if t == nil {
+		return nil, &kcommon.ServiceError{Code: common.ErrCodeNotFound, Message: notFoundMsg}
+	}
+	if t.DeletedAt == 0 {
+		return nil, &kcommon.ServiceError{Code: common.ErrCodeConflict, Message: "ticket is not deleted"}
+	}
+	if err := s.checkLock(ctx, t); err != nil {
+		return nil, err
+	}
+	t.DeletedAt = 0
+	appendEvent(t, newEvent(ctx, "restored", time.Now().Unix(), actionNote(req)))
+	_ = s.Repo.Update(ctx, t)
+	if t.Status != "resolved" && t.ArchivedAt == 0 {
+		s.reindexTicket(t)
+	}
+	return &ticket.TicketResponse{Ticket: s.toThriftTicket(t)}, nil
+}
+
+// Archive hides a resolved ticket from default lists; it stays readable by id.
+func (s *TicketServiceImpl) Archive(ctx context.Context, req *ticket.TicketActionRequest) (*ticket.TicketResponse, error) {
+	if req == nil || req.Id == "" {
+		return nil, &kcommon.ServiceError{Code: common.ErrCodeBadRequest, Message: idRequiredMsg}
+	}
+	defer s.lockTicket(ctx, req.Id)()
+	t := s.lookup(ctx, req.Id)
+	if t == nil {
+		return nil, &kcommon.ServiceError{Code: common.ErrCodeNotFound, Message: notFoundMsg}
+	}
+	if err := s.checkLock(ctx, t); err != nil {
+		return nil, err
+	}
+	if t.Status != "resolved" {
+		return nil, &kcommon.ServiceError{Code: common.ErrCodeConflict, Message: "only resolved tickets can be archived"}
+	}
+	if t.ArchivedAt != 0 {
+		return &ticket.TicketResponse{Ticket: s.toThriftTicket(t)}, nil
+	}
+	archiveTicket(t, newEvent(ctx, "archived", time.Now().Unix(), actionNote(req)))
+	_ = s.Repo.Update(ctx, t)
+	observability.TicketArchived.Add(1)
+	return &ticket.TicketResponse{Ticket: s.toThriftTicket(t)}, nil
+}
+
+func archiveTicket(t *common.Ticket, ev common.TicketEvent) {
+	t.ArchivedAt = ev.At
+	appendEvent(t, ev)
+}
+
+// Unarchive returns an archived ticket to default lists (reopen does so implicitly).
+func (s *TicketServiceImpl) Unarchive(ctx context.Context, req *ticket.TicketActionRequest) (*ticket.TicketResponse, error) {
+	if req == nil || req.Id == "" {
+		return nil, &kcommon.ServiceError{Code: common.ErrCodeBadRequest, Message: idRequiredMsg}
+	}
+	defer s.lockTicket(ctx, req.Id)()
+	t := s.lookup(ctx, req.Id)
+	if t == nil {
+		return nil, &kcommon.ServiceError{Code: common.ErrCodeNotFound, Message: notFoundMsg}
+	}
+	if err := s.checkLock(ctx, t); err != nil {
+		return nil, err
+	}
+	if t.ArchivedAt == 0 {
+		return &ticket.TicketResponse{Ticket: s.toThriftTicket(t)}, nil
+	}
+	t.ArchivedAt = 0
+	appendEvent(t, newEvent(ctx, "unarchived", time.Now().Unix(), actionNote(req)))
+	_ = s.Repo.Update(ctx, t)
+	return &ticket.TicketResponse{Ticket: s.toThriftTicket(t)}, nil
+}
@@ -0,0 +1,217 @@
+package impl
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gogogo1024/assist-fusion/internal/common"
+	kcommon "github.com/gogogo1024/assist-fusion/kitex_gen/common"
+	"github.com/gogogo1024/assist-fusion/kitex_gen/ticket"
+)
+
+// defaultLockTTL is how long an edit lock survives without a heartbeat.
+const defaultLockTTL = time.Minute
+
+// lockBacklog is how many lock events each tenant keeps for stream consumers.
+const lockBacklog = 512
+
+// Lock event types.
+const (
+	lockAcquired      = "acquired"
+	lockRenewed       = "renewed"
+	lockReleased      = "released"
+	lockForceReleased = "force_released"
+	lockExpired       = "expired"
+)
+
+// WithLockTTL sets how long an edit lock lasts between heartbeats.
+func WithLockTTL(d time.Duration) Option { return func(s *TicketServiceImpl) { s.lockTTL = d } }
+
+type editLock struct {
+	holder     common.Actor
+	acquiredAt int64
+	expiresAt  int64
+}
+
+// tenantLocks is one tenant's locks and its recent lock events (seq increases by one).
+type tenantLocks struct {
+	locks  map[string]*editLock
+	events []*ticket.LockEvent
+	seq    int64
+}
+
+// lockTable keeps edit locks in memory: they are short-lived and renewed every few
+// seconds, so they never touch the repo or the audit chain.
+type lockTable struct {
+	mu sync.Mutex
+	m  map[string]*tenantLocks
+}
+
+func (lt *lockTable) tenant(tenant string) *tenantLocks {
+	if lt.m == nil {
+		lt.m = map[string]*tenantLocks{}
+	}
+	tl := lt.m[tenant]
+	if tl == nil {
+		tl = &tenantLocks{locks: map[string]*editLock{}}
+		lt.m[tenant] = tl
+	}
+	return tl
+}
+
+func (l *editLock) thrift(ticketID string) *kcommon.EditLock {
+	return &kcommon.EditLock{TicketId: ticketID, Holder: thriftActor(l.holder), AcquiredAt: l.acquiredAt, ExpiresAt: l.expiresAt}
+}
+
+func thriftActor(a common.Actor) *kcommon.Actor {
+	return &kcommon.Actor{Id: a.ID, Kind: a.Kind, DisplayName: a.Name}
+}
+
+func (tl *tenantLocks) emit(typ, ticketID string, l *editLock, by *common.Actor, at int64) {
+	tl.seq++
+	ev := &ticket.LockEvent{Seq: tl.seq, Type: typ, TicketId: ticketID, Lock: l.thrift(ticketID), At: at}
+	if by != nil {
+		ev.Actor = thriftActor(*by)
+	}
+	tl.events = append(tl.events, ev)
+	if len(tl.events) > lockBacklog {
+		tl.events = append([]*ticket.LockEvent(nil), tl.events[len(tl.events)-lockBacklog:]...)
+	}
+}
+
+// expire drops lapsed locks, recording an event for each.
+func (tl *tenantLocks) expire(now int64) {
+	for id, l := range tl.locks {
+		if l.expiresAt <= now {
+			delete(tl.locks, id)
+			tl.emit(lockExpired, id, l, nil, l.expiresAt)
+		}
+	}
+}
+
+// current returns the live lock on a ticket, if any.
+func (lt *lockTable) current(tenant, ticketID string) *editLock {
+	lt.mu.Lock()
+	defer lt.mu.Unlock()
+	tl := lt.tenant(tenant)
+	tl.expire(time.Now().Unix())
+	if l := tl.locks[ticketID]; l != nil {
+		cp := *l
+		return &cp
+	}
+	return nil
+}
+
+func lockConflict(l *editLock) error {
+	return &kcommon.ServiceError{Code: common.ErrCodeConflict, Message: fmt.Sprintf("ticket is being edited by %s until %s", l.holder.Name, time.Unix(l.expiresAt, 0).UTC().Format(time.RFC3339))}
+}
+
+// checkLock rejects changes by other agents while someone holds the ticket's edit lock.
+// Customers (email), automation and system actors are never blocked.
+func (s *TicketServiceImpl) checkLock(ctx context.Context, t *common.Ticket) error {
+	a, source := common.ActorFromContext(ctx)
+	if a.Kind != common.ActorKindUser || source == common.SourceEmail {
+		return nil
+	}
+	if l := s.locks.current(t.Tenant, t.ID); l != nil && l.holder.ID != a.ID {
+		return lockConflict(l)
+	}
+	return nil
+}
+
+func (s *TicketServiceImpl) lockTarget(ctx context.Context, req *ticket.LockRequest) (*common.Ticket, common.Actor, error) {
+	if req == nil || req.Id == "" {
+		return nil, common.Actor{}, &kcommon.ServiceError{Code: common.ErrCodeBadRequest, Message: idRequiredMsg}
+	}
+	a, _ := common.ActorFromContext(ctx)
+	if a.Kind != common.ActorKindUser || a.ID == "" {
+		return nil, a, &kcommon.ServiceError{Code: common.ErrCodeUnauthorized, Message: "user identity required"}
+	}
+	t := s.lookup(ctx, req.Id)
+	if t == nil {
+		return nil, a, &kcommon.ServiceError{Code: common.ErrCodeNotFound, Message: notFoundMsg}
+	}
+	return t, a, nil
+}
+
+// AcquireLock takes the ticket's edit lock for the caller, or renews it when the caller
+// already holds it (heartbeat). A lock held by someone else is a conflict.
+func (s *TicketServiceImpl) AcquireLock(ctx context.Context, req *ticket.LockRequest) (*ticket.LockResponse, error) {
+	t, a, err := s.lockTarget(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	s.locks.mu.Lock()
+	defer s.locks.mu.Unlock()
+	now := time.Now()
+	tl := s.locks.tenant(t.Tenant)
+	tl.expire(now.Unix())
+	l := tl.locks[t.ID]
+	switch {
+	case l == nil:
+		l = &editLock{holder: a, acquiredAt: now.Unix(), expiresAt: now.Add(s.lockTTL).Unix()}
+		tl.locks[t.ID] = l
+		tl.emit(lockAcquired, t.ID, l, &a, now.Unix())
+	case l.holder.ID == a.ID:
+		l.expiresAt = now.Add(s.lockTTL).Unix()
+		tl.emit(lockRenewed, t.ID, l, &a, now.Unix())
+	default:
+		return nil, lockConflict(l)
+	}
+	return &ticket.LockResponse{Lock: l.thrift(t.ID)}, nil
+}
+
+// ReleaseLock drops the caller's lock. With Force, a supervisor drops anyone's lock.
+// Releasing a ticket that is not locked is a no-op.
+func (s *TicketServiceImpl) ReleaseLock(ctx context.Context, req *ticket.LockRequest) (*ticket.LockResponse, error) {
+	t, a, err := s.lockTarget(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	s.locks.mu.Lock()
+	defer s.locks.mu.Unlock()
+	now := time.Now().Unix()
+	tl := s.locks.tenant(t.Tenant)
+	tl.expire(now)
+	l := tl.locks[t.ID]
+	if l == nil {
+		return &ticket.LockResponse{}, nil
+	}
+	typ := lockReleased
+	if l.holder.ID != a.ID {
+		if !req.Force {
+			return nil, lockConflict(l)
+		}
+		if !common.HasRole(ctx, common.RoleSupervisor) {
+			return nil, &kcommon.ServiceError{Code: common.ErrCodeUnauthorized, Message: "supervisor role required to force-release a lock"}
+		}
+		typ = lockForceReleased
+	}
+	delete(tl.locks, t.ID)
+	tl.emit(typ, t.ID, l, &a, now)
+	return &ticket.LockResponse{}, nil
+}
+
+// ListLockEvents returns the caller tenant's lock events after req.After, oldest first.
+// Lapsed locks are expired first, so polling this also surfaces expiries promptly.
+func (s *TicketServiceImpl) ListLockEvents(ctx context.Context, req *ticket.ListLockEventsRequest) (*ticket.ListLockEventsResponse, error) {
+	if req == nil {
+		req = &ticket.ListLockEventsRequest{}
+	}
+	s.locks.mu.Lock()
+	defer s.locks.mu.Unlock()
+	tl := s.locks.tenant(common.TenantFromContext(ctx))
+	tl.expire(time.Now().Unix())
+	out := &ticket.ListLockEventsResponse{Events: []*ticket.LockEvent{}, LastSeq: tl.seq}
+	if req.After < 0 {
+		return out, nil
+	}
+	for _, ev := range tl.events {
+		if ev.Seq > req.After && (req.TicketId == nil || ev.TicketId == *req.TicketId) {
+			out.Events = append(out.Events, ev)
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,124 @@
+package impl
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/gogogo1024/assist-fusion/internal/common"
+	"github.com/gogogo1024/assist-fusion/kitex_gen/ticket"
+)
+
+func TestEditLocks(t *testing.T) {
+	s := NewTicketService(common.NewMemoryTicketRepo())
+	user := func(id, source string) context.Context {
+		return common.WithActor(context.Background(), common.Actor{ID: id, Kind: common.ActorKindUser, Name: id}, source)
+	}
+	ann, bob := user("ann", common.SourceGateway), user("bob", common.SourceGateway)
+	boss := common.WithRoles(user("boss", common.SourceGateway), []string{common.RoleSupervisor})
+	r, _ := s.CreateTicket(ann, &ticket.CreateTicketRequest{Title: "locked", Desc: "x"})
+	id := r.Ticket.Id
+
+	if _, err := s.AcquireLock(context.Background(), &ticket.LockRequest{Id: id}); err == nil {
+		t.Fatal("anonymous caller took a lock")
+	}
+	lr, err := s.AcquireLock(ann, &ticket.LockRequest{Id: id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lr.Lock.Holder.Id != "ann" || lr.Lock.ExpiresAt <= lr.Lock.AcquiredAt {
+		t.Fatalf("lock = %+v", lr.Lock)
+	}
+	if _, err := s.AcquireLock(ann, &ticket.LockRequest{Id: id}); err != nil {
+		t.Fatalf("heartbeat: %v", err)
+	}
+	if _, err := s.AcquireLock(bob, &ticket.LockRequest{Id: id}); err == nil {
+		t.Fatal("second agent took a held lock")
+	}
+
+	// the lock shows on reads; other agents cannot act, the holder and the customer can
+	got, _ := s.GetTicket(bob, &ticket.GetTicketRequest{Id: id})
+	if got.Ticket.Lock == nil || got.Ticket.Lock.Holder.Id != "ann" {
+		t.Fatalf("ticket lock = %+v", got.Ticket.Lock)
+	}
+	if _, err := s.AddComment(bob, &ticket.AddCommentRequest{Id: id, Body: "me too"}); err == nil {
+		t.Fatal("bob replied while ann holds the lock")
+	}
+	if _, err := s.Assign(bob, &ticket.TicketActionRequest{Id: id, Assignee: strPtr("bob")}); err == nil {
+		t.Fatal("bob assigned while ann holds the lock")
+	}
+	for name, act := range map[string]func(context.Context, *ticket.TicketActionRequest) (*ticket.TicketResponse, error){
+		"delete": s.DeleteTicket, "archive": s.Archive, "unarchive": s.Unarchive,
+	} {
+		if _, err := act(bob, &ticket.TicketActionRequest{Id: id}); err == nil || !strings.Contains(err.Error(), "being edited") {
+			t.Fatalf("bob's %s while ann holds the lock: %v", name, err)
+		}
+	}
+	if pr, _ := s.PullNext(bob, &ticket.PullNextRequest{}); pr.Ticket != nil {
+		t.Fatalf("bob pulled a ticket ann is editing: %s", pr.Ticket.Id)
+	}
+	if _, err := s.AddComment(user("customer@example.com", common.SourceEmail), &ticket.AddCommentRequest{Id: id, Body: "any news?"}); err != nil {
+		t.Fatalf("customer reply blocked: %v", err)
+	}
+	if _, err := s.AddComment(ann, &ticket.AddCommentRequest{Id: id, Body: "on it"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := s.ReleaseLock(bob, &ticket.LockRequest{Id: id, Force: true}); err == nil {
+		t.Fatal("non-supervisor force-released a lock")
+	}
+	if _, err := s.ReleaseLock(boss, &ticket.LockRequest{Id: id, Force: true}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Assign(bob, &ticket.TicketActionRequest{Id: id, Assignee: strPtr("bob")}); err != nil {
+		t.Fatalf("assign after release: %v", err)
+	}
+
+	// a lock without heartbeats lapses
+	s.AcquireLock(bob, &ticket.LockRequest{Id: id})
+	s.locks.mu.Lock()
+	s.locks.m[common.DefaultTenant].locks[id].expiresAt = 1
+	s.locks.mu.Unlock()
+	if _, err := s.AcquireLock(ann, &ticket.LockRequest{Id: id}); err != nil {
+		t.Fatalf("acquire after expiry: %v", err)
+	}
+
+	evs, _ := s.ListLockEvents(ann, &ticket.ListLockEventsRequest{After: 0, TicketId: &id})
+	var types []string
+	for _, ev := range evs.Events {
+		types = append(types, ev.Type)
+	}
+	want := []string{"acquired", "renewed", "force_released", "acquired", "expired", "acquired"}
+	if len(types) != len(want) {
+		t.Fatalf("events = %v", types)
+	}
+	for i := range want {
+		if types[i] != want[i] {
+			t.Fatalf("events = %v", types)
+		}
+	}
+	if evs.Events[2].Actor.Id != "boss" || evs.LastSeq != evs.Events[5].Seq {
+		t.Fatalf("force release event = %+v, last seq %d", evs.Events[2], evs.LastSeq)
+	}
+	if cur, _ := s.ListLockEvents(ann, &ticket.ListLockEventsRequest{After: -1}); len(cur.Events) != 0 || cur.LastSeq != evs.LastSeq {
+		t.Fatalf("cursor = %+v", cur)
+	}
+}
+
+func TestCreateTicketCustomer(t *testing.T) {
+	s := NewTicketService(common.NewMemoryTicketRepo())
+	agent := common.WithActor(context.Background(), common.Actor{ID: "agent1", Kind: common.ActorKindUser, Name: "agent1"}, common.SourceGateway)
+	sender := common.WithActor(context.Background(), common.Actor{ID: "bob@example.com", Kind: common.ActorKindUser, Name: "Bob"}, common.SourceEmail)
+
+	// an agent filing a ticket does not become its customer
+	r, err := s.CreateTicket(agent, &ticket.CreateTicketRequest{Title: "on behalf", Desc: "x"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Ticket.Customer != "" {
+		t.Fatalf("agent recorded as customer %q", r.Ticket.Customer)
+	}
+	if r, _ = s.CreateTicket(sender, &ticket.CreateTicketRequest{Title: "by mail", Desc: "x"}); r.Ticket.Customer != "bob@example.com" {
+		t.Fatalf("email sender not recorded as customer: %q", r.Ticket.Customer)
+	}
+}
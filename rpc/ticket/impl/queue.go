@@ -0,0 +1,177 @@
+package impl
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gogogo1024/assist-fusion/internal/common"
+	"github.com/gogogo1024/assist-fusion/internal/observability"
+	kcommon "github.com/gogogo1024/assist-fusion/kitex_gen/common"
+	"github.com/gogogo1024/assist-fusion/kitex_gen/ticket"
+)
+
+// PullWeights weighs the components of a ticket's pull-queue score. Each component is
+// normalized to [0,1] before weighting.
+type PullWeights struct {
+	Priority float64 // urgent 1 … low 0.25; unset counts as normal
+	SLA      float64 // 1 once breached, rising linearly over the last slaHorizon before due
+	Age      float64 // rises linearly to 1 over ageHorizon
+	Skill    float64 // share of the ticket's category and tags among the agent's skills
+}
+
+var defaultPullWeights = PullWeights{Priority: 4, SLA: 3, Age: 1, Skill: 2}
+
+const (
+	defaultClaimTTL = 5 * time.Minute
+	slaHorizon      = 24 * time.Hour
+	ageHorizon      = 24 * time.Hour
+)
+
+// ParsePullWeights parses "priority=4,sla=3,age=1,skill=2"; omitted components keep
+// their default weight.
+func ParsePullWeights(spec string) (PullWeights, error) {
+	w := defaultPullWeights
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, val, ok := strings.Cut(part, "=")
+		f, err := strconv.ParseFloat(strings.TrimSpace(val), 64)
+		if !ok || err != nil || f < 0 {
+			return w, fmt.Errorf("pull weight %q: want name=non-negative number", part)
+		}
+		switch strings.TrimSpace(name) {
+		case "priority":
+			w.Priority = f
+		case "sla":
+			w.SLA = f
+		case "age":
+			w.Age = f
+		case "skill":
+			w.Skill = f
+		default:
+			return w, fmt.Errorf("pull weight %q: unknown component (priority|sla|age|skill)", part)
+		}
+	}
+	return w, nil
+}
+
+// WithPullWeights sets how PullNext ranks eligible tickets.
+func WithPullWeights(w PullWeights) Option { return func(s *TicketServiceImpl) { s.pullWeights = w } }
+
+// WithClaimTTL bounds how long a pulled ticket stays reserved before returning to the queue.
+func WithClaimTTL(d time.Duration) Option { return func(s *TicketServiceImpl) { s.claimTTL = d } }
+
+func clamp01(f float64) float64 { return max(0, min(1, f)) }
+
+// pullScore ranks t for an agent with skills (a set) at time now.
+func (w PullWeights) pullScore(t *common.Ticket, skills map[string]bool, now time.Time) float64 {
+	prio := 0.5
+	if r, ok := priorityRank[t.Priority]; ok {
+		prio = float64(r) / float64(len(priorityRank))
+	}
+	sla := 0.0
+	if t.DueAt != 0 {
+		left := time.Unix(t.DueAt, 0).Sub(now)
+		sla = clamp01(1 - float64(left)/float64(slaHorizon))
+	}
+	age := clamp01(float64(now.Sub(time.Unix(t.CreatedAt, 0))) / float64(ageHorizon))
+	skill := 0.0
+	if len(skills) > 0 {
+		var need []string
+		if t.Category != "" {
+			need = append(need, strings.ToLower(t.Category))
+		}
+		need = append(need, t.Tags...)
+		hit := 0
+		for _, n := range need {
+			if skills[n] {
+				hit++
+			}
+		}
+		if len(need) > 0 {
+			skill = float64(hit) / float64(len(need))
+		}
+	}
+	return w.Priority*prio + w.SLA*sla + w.Age*age + w.Skill*skill
+}
+
+// claimActive reports whether t is reserved by an unexpired claim.
+func claimActive(t *common.Ticket, now time.Time) bool {
+	return t.ClaimedBy != "" && t.ClaimExpiresAt > now.Unix()
+}
+
+// releaseClaim drops t's claim once work starts (or the ticket leaves the queue).
+func releaseClaim(t *common.Ticket) { t.ClaimedBy, t.ClaimExpiresAt = "", 0 }
+
+// pullable: open, unassigned, visible tickets that nobody else holds.
+func (s *TicketServiceImpl) pullable(t *common.Ticket, tier string, now time.Time) bool {
+	if !listed(t, nil) || t.Status == "resolved" || t.Assignee != "" || claimActive(t, now) {
+		return false
+	}
+	return tier == "" || s.ticketTier(t) == tier
+}
+
+// PullNext atomically claims the highest-scoring eligible ticket for the calling agent.
+// An agent already holding a live claim gets that ticket back instead of a second one.
+func (s *TicketServiceImpl) PullNext(ctx context.Context, req *ticket.PullNextRequest) (*ticket.PullNextResponse, error) {
+	a, _ := common.ActorFromContext(ctx)
+	if a.Kind != common.ActorKindUser || a.ID == "" {
+		return nil, &kcommon.ServiceError{Code: common.ErrCodeUnauthorized, Message: "user identity required"}
+	}
+	if req == nil {
+		req = &ticket.PullNextRequest{}
+	}
+	skills := map[string]bool{}
+	for _, sk := range normalizeTags(req.Skills) {
+		skills[sk] = true
+	}
+	// claims are check-then-set on the repo, so the whole selection runs under one lock
+	s.claimMu.Lock()
+	defer s.claimMu.Unlock()
+	now := time.Now()
+	ts, _ := s.Repo.List(ctx)
+	var best *common.Ticket
+	bestScore := 0.0
+	for _, t := range ts {
+		if t.ClaimedBy == a.ID && claimActive(t, now) && listed(t, nil) {
+			return &ticket.PullNextResponse{Ticket: s.toThriftTicket(t), Score: s.pullWeights.pullScore(t, skills, now), ClaimExpiresAt: t.ClaimExpiresAt}, nil
+		}
+		// tickets someone else is editing are left to them
+		if !s.pullable(t, req.GetTier(), now) || s.checkLock(ctx, t) != nil {
+			continue
+		}
+		score := s.pullWeights.pullScore(t, skills, now)
+		// ties go to the oldest ticket
+		if best == nil || score > bestScore || (score == bestScore && t.CreatedAt < best.CreatedAt) {
+			best, bestScore = t, score
+		}
+	}
+	if best == nil {
+		return &ticket.PullNextResponse{}, nil
+	}
+	// other updates are serialized per ticket, not by claimMu: reload under the ticket's lock
+	// and give up (the caller pulls again) if it stopped being eligible since the scan
+	defer s.lockTicket(ctx, best.ID)()
+	if best = s.lookup(ctx, best.ID); best == nil || !s.pullable(best, req.GetTier(), now) || s.checkLock(ctx, best) != nil {
+		return &ticket.PullNextResponse{}, nil
+	}
+	at := now.Unix()
+	if best.ClaimedBy != "" {
+		// the previous holder never started work; record the lapse before re-claiming
+		ev := newEvent(common.WithActor(ctx, common.SystemActor, common.SourceScheduler), "claim_expired", best.ClaimExpiresAt, best.ClaimedBy)
+		appendEvent(best, ev)
+		observability.TicketClaimExpired.Add(1)
+	}
+	best.ClaimedBy, best.ClaimExpiresAt = a.ID, now.Add(s.claimTTL).Unix()
+	appendEvent(best, newEvent(ctx, "claimed", at, ""))
+	if err := s.Repo.Update(ctx, best); err != nil {
+		return nil, err
+	}
+	observability.TicketClaimed.Add(1)
+	return &ticket.PullNextResponse{Ticket: s.toThriftTicket(best), Score: bestScore, ClaimExpiresAt: best.ClaimExpiresAt}, nil
+}
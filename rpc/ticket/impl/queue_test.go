@@ -0,0 +1,124 @@
+package impl
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gogogo1024/assist-fusion/internal/common"
+	"github.com/gogogo1024/assist-fusion/kitex_gen/ticket"
+)
+
+func TestParsePullWeights(t *testing.T) {
+	w, err := ParsePullWeights("priority=1, skill=5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w.Priority != 1 || w.Skill != 5 || w.SLA != defaultPullWeights.SLA {
+		t.Fatalf("weights = %+v", w)
+	}
+	for _, bad := range []string{"priority", "speed=1", "age=-1", "sla=x"} {
+		if _, err := ParsePullWeights(bad); err == nil {
+			t.Errorf("%q: expected error", bad)
+		}
+	}
+}
+
+func TestPullNext(t *testing.T) {
+	s := NewTicketService(common.NewMemoryTicketRepo())
+	agent := func(id string) context.Context {
+		return common.WithActor(context.Background(), common.Actor{ID: id, Kind: common.ActorKindUser, Name: id}, common.SourceGateway)
+	}
+	ann, bob := agent("ann"), agent("bob")
+	mk := func(title, prio, category string, due int64) string {
+		r, err := s.CreateTicket(ann, &ticket.CreateTicketRequest{Title: title, Desc: "x", Priority: &prio, Category: &category, DueAt: &due})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return r.Ticket.Id
+	}
+	low := mk("low", "low", "", 0)
+	breached := mk("breached", "low", "", time.Now().Add(-time.Hour).Unix())
+	billing := mk("billing", "high", "billing", 0)
+	taken := mk("taken", "urgent", "", 0)
+	s.Assign(ann, &ticket.TicketActionRequest{Id: taken, Assignee: strPtr("carol")})
+
+	if _, err := s.PullNext(context.Background(), &ticket.PullNextRequest{}); err == nil {
+		t.Fatal("anonymous pull accepted")
+	}
+
+	// skill match lifts the billing ticket over the breached one for ann
+	r, err := s.PullNext(ann, &ticket.PullNextRequest{Skills: []string{"Billing"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Ticket.Id != billing || r.Ticket.ClaimedBy != "ann" || r.ClaimExpiresAt <= time.Now().Unix() {
+		t.Fatalf("ann pulled %s (claimed by %q)", r.Ticket.Title, r.Ticket.ClaimedBy)
+	}
+	// pulling again returns the same claim instead of hoarding another ticket
+	if again, _ := s.PullNext(ann, &ticket.PullNextRequest{}); again.Ticket.Id != billing {
+		t.Fatalf("re-pull returned %s", again.Ticket.Title)
+	}
+	r, _ = s.PullNext(bob, &ticket.PullNextRequest{})
+	if r.Ticket.Id != breached {
+		t.Fatalf("bob pulled %s", r.Ticket.Title)
+	}
+
+	// ann starts work on her claim; bob's claim lapses and the ticket is pulled by someone else
+	s.Assign(ann, &ticket.TicketActionRequest{Id: billing, Assignee: strPtr("ann")})
+	tk, _ := s.Repo.Get(bob, breached)
+	tk.ClaimExpiresAt = time.Now().Add(-time.Second).Unix()
+	_ = s.Repo.Update(bob, tk)
+	r, _ = s.PullNext(agent("dan"), &ticket.PullNextRequest{})
+	if r.Ticket.Id != breached || r.Ticket.ClaimedBy != "dan" {
+		t.Fatalf("dan pulled %s", r.Ticket.Title)
+	}
+	evs := r.Ticket.Events
+	if evs[len(evs)-2].Type != "claim_expired" || evs[len(evs)-2].Note != "bob" || evs[len(evs)-1].Type != "claimed" {
+		t.Fatalf("events = %v, %v", evs[len(evs)-2], evs[len(evs)-1])
+	}
+	if v, _ := s.VerifyAudit(ann, &ticket.VerifyAuditRequest{Id: breached}); !v.Ok {
+		t.Fatalf("audit: %s", v.Reason)
+	}
+
+	r, _ = s.PullNext(bob, &ticket.PullNextRequest{})
+	if r.Ticket.Id != low {
+		t.Fatalf("bob pulled %s", r.Ticket.Title)
+	}
+	if r, _ := s.PullNext(agent("eve"), &ticket.PullNextRequest{}); r.Ticket != nil {
+		t.Fatalf("empty queue returned %s", r.Ticket.Title)
+	}
+}
+
+func TestPullNextConcurrentClaims(t *testing.T) {
+	s := NewTicketService(common.NewMemoryTicketRepo())
+	ctx := context.Background()
+	for i := 0; i < 5; i++ {
+		s.CreateTicket(ctx, &ticket.CreateTicketRequest{Title: "t", Desc: "x"})
+	}
+	var mu sync.Mutex
+	got := map[string]string{}
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+			c := common.WithActor(ctx, common.Actor{ID: id, Kind: common.ActorKindUser, Name: id}, common.SourceGateway)
+			r, err := s.PullNext(c, &ticket.PullNextRequest{})
+			if err != nil || r.Ticket == nil {
+				return
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if prev, dup := got[r.Ticket.Id]; dup {
+				t.Errorf("ticket %s claimed by %s and %s", r.Ticket.Id, prev, id)
+			}
+			got[r.Ticket.Id] = id
+		}(string(rune('a' + i)))
+	}
+	wg.Wait()
+	if len(got) != 5 {
+		t.Fatalf("claimed %d of 5 tickets", len(got))
+	}
+}
@@ -0,0 +1,242 @@
+package impl
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/kitex/pkg/klog"
+
+	"github.com/gogogo1024/assist-fusion/internal/audit"
+	"github.com/gogogo1024/assist-fusion/internal/common"
+	"github.com/gogogo1024/assist-fusion/internal/observability"
+	"github.com/gogogo1024/assist-fusion/kitex_gen/ticket"
+)
+
+// Retention actions.
+const (
+	retainArchive   = "archive"
+	retainAnonymize = "anonymize"
+	retainPurge     = "purge"
+)
+
+// anonymizedLabel replaces scrubbed titles and comment authors.
+const anonymizedLabel = "[anonymized]"
+
+// RetentionPolicy ages out tickets that have been in one state for longer than After.
+type RetentionPolicy struct {
+	// State is a ticket status (e.g. "resolved") or "archived" / "deleted".
+	State string
+	After time.Duration
+	// Action is archive, anonymize or purge.
+	Action string
+}
+
+func (p RetentionPolicy) String() string {
+	age := p.After.String()
+	if p.After%(24*time.Hour) == 0 {
+		age = strconv.Itoa(int(p.After/(24*time.Hour))) + "d"
+	}
+	return p.State + ":" + age + ":" + p.Action
+}
+
+// ParseRetentionPolicies parses comma separated "state:age:action" entries, e.g.
+// "resolved:90d:archive,archived:365d:anonymize,deleted:30d:purge". Ages take a "d" suffix
+// for days or any time.ParseDuration value. The first matching policy wins per ticket.
+func ParseRetentionPolicies(spec string) ([]RetentionPolicy, error) {
+	var out []RetentionPolicy
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		parts := strings.Split(entry, ":")
+		if len(parts) != 3 {
+			return nil, fmt.Errorf("retention policy %q: want state:age:action", entry)
+		}
+		after, err := parseAge(parts[1])
+		if err != nil || after <= 0 {
+			return nil, fmt.Errorf("retention policy %q: invalid age", entry)
+		}
+		p := RetentionPolicy{State: parts[0], After: after, Action: parts[2]}
+		switch p.Action {
+		case retainArchive:
+			if p.State == includeArchived || p.State == includeDeleted {
+				return nil, fmt.Errorf("retention policy %q: %s tickets cannot be archived", entry, p.State)
+			}
+		case retainAnonymize, retainPurge:
+		default:
+			return nil, fmt.Errorf("retention policy %q: unknown action %q", entry, p.Action)
+		}
+		out = append(out, p)
+	}
+	return out, nil
+}
+
+func parseAge(s string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		return time.Duration(n) * 24 * time.Hour, err
+	}
+	return time.ParseDuration(s)
+}
+
+// WithRetentionPolicies sets the policies applied by ApplyRetention and RunRetention.
+func WithRetentionPolicies(ps []RetentionPolicy) Option {
+	return func(s *TicketServiceImpl) { s.retention = ps }
+}
+
+// retentionState returns the state policies match on and when the ticket entered it.
+func retentionState(t *common.Ticket) (string, int64) {
+	switch {
+	case t.DeletedAt != 0:
+		return includeDeleted, t.DeletedAt
+	case t.ArchivedAt != 0:
+		return includeArchived, t.ArchivedAt
+	case t.Status == "resolved":
+		return t.Status, t.ResolvedAt
+	}
+	since := t.CreatedAt
+	if n := len(t.Events); n > 0 {
+		since = t.Events[n-1].At
+	}
+	return t.Status, since
+}
+
+// applyRetention evaluates the policies against the tenant in ctx and, unless dryRun,
+// carries out the resulting actions. The report lists actions in ticket id order.
+func (s *TicketServiceImpl) applyRetention(ctx context.Context, now time.Time, dryRun bool) []*ticket.RetentionAction {
+	if len(s.retention) == 0 {
+		return nil
+	}
+	ts, _ := s.Repo.List(ctx)
+	sort.Slice(ts, func(i, j int) bool { return ts[i].ID < ts[j].ID })
+	var out []*ticket.RetentionAction
+	for _, t := range ts {
+		if a := s.retainOne(ctx, t.ID, now, dryRun); a != nil {
+			out = append(out, a)
+		}
+	}
+	return out
+}
+
+// retainOne applies the first matching policy to ticket id, re-read under its update lock
+// (nil when no policy applies).
+func (s *TicketServiceImpl) retainOne(ctx context.Context, id string, now time.Time, dryRun bool) *ticket.RetentionAction {
+	defer s.lockTicket(ctx, id)()
+	t, _ := s.Repo.Get(ctx, id)
+	if t == nil {
+		return nil
+	}
+	state, since := retentionState(t)
+	for _, p := range s.retention {
+		if p.State != state || now.Sub(time.Unix(since, 0)) < p.After {
+			continue
+		}
+		if p.Action == retainAnonymize && t.Anonymized {
+			continue
+		}
+		if !dryRun {
+			s.retain(ctx, t, p.Action, now)
+		}
+		return &ticket.RetentionAction{TicketId: t.ID, Action: p.Action, Policy: p.String(), Since: since}
+	}
+	return nil
+}
+
+func (s *TicketServiceImpl) retain(ctx context.Context, t *common.Ticket, action string, now time.Time) {
+	switch action {
+	case retainArchive:
+		archiveTicket(t, newEvent(ctx, "archived", now.Unix(), "retention"))
+		_ = s.Repo.Update(ctx, t)
+		observability.TicketArchived.Add(1)
+	case retainAnonymize:
+		anonymizeTicket(t)
+		appendEvent(t, newEvent(ctx, "anonymized", now.Unix(), "retention"))
+		_ = s.Repo.Update(ctx, t)
+		s.vectors.drop(t.ID)
+		observability.TicketAnonymized.Add(1)
+	case retainPurge:
+		_ = s.Repo.Delete(ctx, t.ID)
+		s.vectors.drop(t.ID)
+		observability.TicketPurged.Add(1)
+	}
+}
+
+// anonymizeTicket scrubs personal data from t. Event notes and the customer's identity on
+// events are redacted through audit.Redact so the hash chain keeps verifying.
+func anonymizeTicket(t *common.Ticket) {
+	customer := t.Customer
+	t.Title = anonymizedLabel
+	t.Desc = ""
+	t.Customer = ""
+	t.Watchers = nil
+	for i := range t.Comments {
+		c := &t.Comments[i]
+		c.Body = ""
+		c.Attachments = nil
+		c.Actor = common.Actor{ID: anonymizedLabel, Kind: c.Actor.Kind, Name: anonymizedLabel}
+	}
+	for i := range t.Events {
+		e := &t.Events[i]
+		byCustomer := customer != "" && e.Actor.ID == customer
+		if !byCustomer && e.Note == "" {
+			continue
+		}
+		audit.Redact(t.ID, e, func(e *common.TicketEvent) {
+			if byCustomer {
+				e.Actor.ID, e.Actor.Name = anonymizedLabel, anonymizedLabel
+			}
+			if e.Note != "" {
+				e.Note = anonymizedLabel
+			}
+		})
+	}
+	for i := range t.Cycles {
+		if c := t.Cycles[i].CSAT; c != nil {
+			c.Comment = ""
+		}
+	}
+	t.Anonymized = true
+}
+
+// ApplyRetention runs the configured policies against the caller's tenant.
+func (s *TicketServiceImpl) ApplyRetention(ctx context.Context, req *ticket.ApplyRetentionRequest) (*ticket.RetentionReport, error) {
+	dryRun := req != nil && req.DryRun
+	now := time.Now()
+	actions := s.applyRetention(ctx, now, dryRun)
+	if actions == nil {
+		actions = []*ticket.RetentionAction{}
+	}
+	return &ticket.RetentionReport{DryRun: dryRun, RanAt: now.Unix(), Actions: actions}, nil
+}
+
+// RunRetention applies the policies to every tenant each interval until ctx is done.
+// With dryRun the job only logs what it would do.
+func (s *TicketServiceImpl) RunRetention(ctx context.Context, interval time.Duration, dryRun bool) {
+	if len(s.retention) == 0 {
+		return
+	}
+	tick := time.NewTicker(interval)
+	defer tick.Stop()
+	for {
+		tenants := []string{common.DefaultTenant}
+		if tl, ok := s.Repo.(common.TenantLister); ok {
+			tenants = tl.Tenants()
+		}
+		for _, tenant := range tenants {
+			tctx := common.WithActor(common.WithTenant(ctx, tenant), common.SystemActor, common.SourceScheduler)
+			for _, a := range s.applyRetention(tctx, time.Now(), dryRun) {
+				klog.Infof("retention tenant=%s dry_run=%v ticket=%s action=%s policy=%s", tenant, dryRun, a.TicketId, a.Action, a.Policy)
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-tick.C:
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package impl
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gogogo1024/assist-fusion/internal/common"
+	"github.com/gogogo1024/assist-fusion/kitex_gen/ticket"
+)
+
+func TestParseRetentionPolicies(t *testing.T) {
+	ps, err := ParseRetentionPolicies("resolved:90d:archive, archived:365d:anonymize,deleted:12h:purge")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ps) != 3 || ps[0].After != 90*24*time.Hour || ps[2].After != 12*time.Hour {
+		t.Fatalf("unexpected policies: %+v", ps)
+	}
+	if got := ps[0].String(); got != "resolved:90d:archive" {
+		t.Fatalf("String() = %q", got)
+	}
+	for _, bad := range []string{"resolved:90d", "resolved:xd:archive", "resolved:90d:shred", "deleted:1d:archive"} {
+		if _, err := ParseRetentionPolicies(bad); err == nil {
+			t.Errorf("%q: expected error", bad)
+		}
+	}
+}
+
+func TestApplyRetention(t *testing.T) {
+	ps, _ := ParseRetentionPolicies("resolved:30d:archive,archived:90d:anonymize,deleted:7d:purge")
+	s := NewTicketService(common.NewMemoryTicketRepo(), WithRetentionPolicies(ps))
+	ctx := context.Background()
+	create := func(title string) string {
+		r, err := s.CreateTicket(ctx, &ticket.CreateTicketRequest{Title: title, Desc: "customer phone 555-0100"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return r.Ticket.Id
+	}
+	resolved, open, deleted := create("old resolved"), create("still open"), create("deleted")
+	// the customer's own note on the resolve event is personal data too
+	if tk, _ := s.Repo.Get(ctx, resolved); tk != nil {
+		tk.Customer = "cust-1"
+		_ = s.Repo.Update(ctx, tk)
+	}
+	custCtx := common.WithActor(ctx, common.Actor{ID: "cust-1", Kind: common.ActorKindUser, Name: "Jane Roe"}, common.SourceGateway)
+	note := "call me on 555-0100"
+	s.Resolve(custCtx, &ticket.TicketActionRequest{Id: resolved, Note: &note})
+	s.DeleteTicket(ctx, &ticket.TicketActionRequest{Id: deleted})
+
+	day := 24 * time.Hour
+	now := time.Now()
+	if got := s.applyRetention(ctx, now.Add(day), false); len(got) != 0 {
+		t.Fatalf("nothing is old enough yet: %+v", got)
+	}
+
+	// dry run reports without changing anything
+	report := s.applyRetention(ctx, now.Add(31*day), true)
+	if len(report) != 2 {
+		t.Fatalf("dry run actions = %+v", report)
+	}
+	if tk, _ := s.Repo.Get(ctx, resolved); tk.ArchivedAt != 0 {
+		t.Fatal("dry run archived the ticket")
+	}
+
+	s.applyRetention(ctx, now.Add(31*day), false)
+	if tk, _ := s.Repo.Get(ctx, deleted); tk != nil {
+		t.Fatal("deleted ticket was not purged")
+	}
+	tk, _ := s.Repo.Get(ctx, resolved)
+	if tk.ArchivedAt == 0 {
+		t.Fatal("resolved ticket was not archived")
+	}
+	if tk, _ := s.Repo.Get(ctx, open); tk.ArchivedAt != 0 {
+		t.Fatal("open ticket must not be touched")
+	}
+	list, _ := s.ListTickets(ctx, &ticket.ListTicketsRequest{})
+	if len(list.Tickets) != 1 || list.Tickets[0].Id != open {
+		t.Fatalf("default list should only show the open ticket: %+v", list.Tickets)
+	}
+
+	// archived long enough: anonymized once, audit chain still intact
+	later := time.Unix(tk.ArchivedAt, 0).Add(91 * day)
+	if got := s.applyRetention(ctx, later, false); len(got) != 1 || got[0].Action != "anonymize" {
+		t.Fatalf("anonymize actions = %+v", got)
+	}
+	if tk.Title != anonymizedLabel || tk.Desc != "" || !tk.Anonymized {
+		t.Fatalf("ticket not scrubbed: %+v", tk)
+	}
+	for _, e := range tk.Events {
+		if e.Actor.ID == "cust-1" || e.Actor.Name == "Jane Roe" || e.Note == note {
+			t.Fatalf("event keeps personal data: %+v", e)
+		}
+	}
+	if got := s.applyRetention(ctx, later, false); len(got) != 0 {
+		t.Fatalf("anonymize should run once: %+v", got)
+	}
+	v, _ := s.VerifyAudit(ctx, &ticket.VerifyAuditRequest{Id: resolved})
+	if !v.Ok {
+		t.Fatalf("audit chain broken after anonymize: %s", v.Reason)
+	}
+}
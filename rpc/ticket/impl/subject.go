@@ -0,0 +1,276 @@
+package impl
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cloudwego/kitex/pkg/klog"
+	"github.com/google/uuid"
+
+	"github.com/gogogo1024/assist-fusion/internal/audit"
+	"github.com/gogogo1024/assist-fusion/internal/common"
+	"github.com/gogogo1024/assist-fusion/internal/privacy"
+	kcommon "github.com/gogogo1024/assist-fusion/kitex_gen/common"
+	"github.com/gogogo1024/assist-fusion/kitex_gen/ticket"
+)
+
+// Roles a data subject can have on a ticket.
+const (
+	roleCustomer  = "customer"
+	roleAssignee  = "assignee"
+	roleWatcher   = "watcher"
+	roleCommenter = "commenter"
+	roleActor     = "actor"
+	roleMentioned = "mentioned"
+)
+
+// erasureReceipts keeps issued receipts per tenant so they can be fetched again later.
+type erasureReceipts struct {
+	mu sync.RWMutex
+	m  map[string]map[string]*ticket.ErasureReceipt
+}
+
+func (r *erasureReceipts) put(tenant string, rc *ticket.ErasureReceipt) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.m == nil {
+		r.m = map[string]map[string]*ticket.ErasureReceipt{}
+	}
+	if r.m[tenant] == nil {
+		r.m[tenant] = map[string]*ticket.ErasureReceipt{}
+	}
+	r.m[tenant][rc.Id] = rc
+}
+
+func (r *erasureReceipts) get(tenant, id string) *ticket.ErasureReceipt {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.m[tenant][id]
+}
+
+func parseSubject(subject string) (*privacy.Subject, error) {
+	sub, err := privacy.NewSubject(subject)
+	if err != nil {
+		return nil, &kcommon.ServiceError{Code: common.ErrCodeBadRequest, Message: err.Error()}
+	}
+	return sub, nil
+}
+
+// subjectRoles lists how sub appears on t (nil when it does not).
+func subjectRoles(t *common.Ticket, sub *privacy.Subject) []string {
+	var roles []string
+	add := func(role string, ok bool) {
+		if ok {
+			roles = append(roles, role)
+		}
+	}
+	add(roleCustomer, sub.Is(t.Customer))
+	assignee := sub.Is(t.Assignee)
+	for _, c := range t.Cycles {
+		assignee = assignee || sub.Is(c.ResolvedBy)
+	}
+	add(roleAssignee, assignee)
+	watcher := false
+	for _, w := range t.Watchers {
+		watcher = watcher || sub.Is(w)
+	}
+	add(roleWatcher, watcher)
+	commenter, mentioned := false, sub.Mentions(t.Title) || sub.Mentions(t.Desc)
+	for _, c := range t.Comments {
+		commenter = commenter || sub.Is(c.Actor.ID)
+		mentioned = mentioned || sub.Mentions(c.Body)
+	}
+	add(roleCommenter, commenter)
+	actor := false
+	for _, e := range t.Events {
+		actor = actor || sub.Is(e.Actor.ID)
+		mentioned = mentioned || sub.Mentions(e.Note)
+	}
+	add(roleActor, actor)
+	add(roleMentioned, mentioned)
+	return roles
+}
+
+// ExportSubject collects everything ticket-rpc holds about one subject in the caller's
+// tenant: matching tickets (archived and soft-deleted included), their comments, events
+// and CSAT answers, plus the subject's own notifications and preferences.
+func (s *TicketServiceImpl) ExportSubject(ctx context.Context, req *kcommon.SubjectRequest) (*ticket.ExportSubjectResponse, error) {
+	if req == nil {
+		return nil, &kcommon.ServiceError{Code: common.ErrCodeBadRequest, Message: "subject required"}
+	}
+	sub, err := parseSubject(req.Subject)
+	if err != nil {
+		return nil, err
+	}
+	ts, _ := s.Repo.List(ctx)
+	sort.Slice(ts, func(i, j int) bool { return ts[i].CreatedAt < ts[j].CreatedAt })
+	out := &ticket.ExportSubjectResponse{Subject: sub.Key(), Tickets: []*ticket.SubjectTicket{}}
+	for _, t := range ts {
+		if roles := subjectRoles(t, sub); roles != nil {
+			out.Tickets = append(out.Tickets, &ticket.SubjectTicket{Ticket: s.toThriftTicket(t), Roles: roles})
+		}
+	}
+	items, _ := s.notifier.Inbox().List(common.TenantFromContext(ctx), sub.Key(), false, 0)
+	out.Notifications = make([]*ticket.Notification, 0, len(items))
+	for _, n := range items {
+		out.Notifications = append(out.Notifications, toThriftNotification(n))
+	}
+	if p, err := s.notifier.Prefs().Get(ctx, sub.Key()); err == nil {
+		out.Prefs = toThriftPrefs(p)
+	}
+	return out, nil
+}
+
+// eraseFromTicket redacts sub's personal fields on t and returns how many fields changed.
+// Event content is redacted through audit.Redact so the hash chain keeps verifying.
+func eraseFromTicket(t *common.Ticket, sub *privacy.Subject) int {
+	fields := 0
+	set := func(f *string, v string) {
+		if *f != v {
+			*f = v
+			fields++
+		}
+	}
+	redact := func(f *string) {
+		v, _ := sub.Redact(*f)
+		set(f, v)
+	}
+	customer := sub.Is(t.Customer)
+	if customer {
+		set(&t.Customer, privacy.Redacted)
+	}
+	if sub.Is(t.Assignee) {
+		set(&t.Assignee, privacy.Redacted)
+	}
+	watchers := t.Watchers[:0]
+	for _, w := range t.Watchers {
+		if sub.Is(w) {
+			fields++
+			continue
+		}
+		watchers = append(watchers, w)
+	}
+	t.Watchers = watchers
+	redact(&t.Title)
+	redact(&t.Desc)
+	for i := range t.Comments {
+		c := &t.Comments[i]
+		if sub.Is(c.Actor.ID) {
+			set(&c.Body, privacy.Redacted)
+			set(&c.Actor.ID, privacy.Redacted)
+			set(&c.Actor.Name, privacy.Redacted)
+			if len(c.Attachments) > 0 {
+				c.Attachments = nil
+				fields++
+			}
+			continue
+		}
+		redact(&c.Body)
+	}
+	// the survey answers of a ticket's customer are theirs; ratings stay for statistics
+	for i := range t.Cycles {
+		if c := t.Cycles[i].CSAT; c != nil && customer {
+			set(&c.Comment, "")
+		}
+		if sub.Is(t.Cycles[i].ResolvedBy) {
+			set(&t.Cycles[i].ResolvedBy, privacy.Redacted)
+		}
+	}
+	for i := range t.Events {
+		e := &t.Events[i]
+		byActor := sub.Is(e.Actor.ID) || sub.Is(e.Actor.Name)
+		if !byActor && !sub.Mentions(e.Note) {
+			continue
+		}
+		audit.Redact(t.ID, e, func(e *common.TicketEvent) {
+			if byActor {
+				set(&e.Actor.ID, privacy.Redacted)
+				set(&e.Actor.Name, privacy.Redacted)
+			}
+			redact(&e.Note)
+		})
+	}
+	return fields
+}
+
+// eraseTicket erases sub from ticket id under its update lock and reports how many
+// fields changed (ok is false when the subject does not appear on the ticket).
+func (s *TicketServiceImpl) eraseTicket(ctx context.Context, id string, sub *privacy.Subject, rc *ticket.ErasureReceipt) (int, bool) {
+	defer s.lockTicket(ctx, id)()
+	t, _ := s.Repo.Get(ctx, id)
+	if t == nil || subjectRoles(t, sub) == nil {
+		return 0, false
+	}
+	n := eraseFromTicket(t, sub)
+	appendEvent(t, newEvent(ctx, "subject_erased", rc.ErasedAt, rc.Id))
+	_ = s.Repo.Update(ctx, t)
+	s.vectors.drop(t.ID)
+	if t.Status != "resolved" && t.ArchivedAt == 0 && t.DeletedAt == 0 {
+		s.reindexTicket(t)
+	}
+	return n, true
+}
+
+// EraseSubject redacts the subject from every ticket of the caller's tenant, drops their
+// notifications and preferences, and issues a signed receipt that also covers the
+// summaries of other services passed in req.Related.
+func (s *TicketServiceImpl) EraseSubject(ctx context.Context, req *ticket.EraseSubjectRequest) (*ticket.ErasureReceipt, error) {
+	if req == nil {
+		return nil, &kcommon.ServiceError{Code: common.ErrCodeBadRequest, Message: "subject required"}
+	}
+	sub, err := parseSubject(req.Subject)
+	if err != nil {
+		return nil, err
+	}
+	tenant := common.TenantFromContext(ctx)
+	rc := &ticket.ErasureReceipt{Id: uuid.NewString(), SubjectHash: sub.Hash(), Tenant: tenant, ErasedAt: time.Now().Unix()}
+	own := &kcommon.ErasureSummary{Service: "ticket", Records: []string{}}
+	ts, _ := s.Repo.List(ctx)
+	for _, t := range ts {
+		if n, ok := s.eraseTicket(ctx, t.ID, sub, rc); ok {
+			own.Records = append(own.Records, t.ID)
+			own.Fields += int32(n)
+		}
+	}
+	own.Fields += int32(s.notifier.Inbox().Erase(tenant, sub.Key()))
+	own.Fields += int32(s.notifier.Inbox().Rewrite(tenant, func(v string) string { v, _ = sub.Redact(v); return v }))
+	_ = s.notifier.Prefs().Delete(ctx, sub.Key())
+
+	rc.Services = append([]*kcommon.ErasureSummary{own}, req.Related...)
+	for _, svc := range rc.Services {
+		sort.Strings(svc.Records)
+	}
+	sort.SliceStable(rc.Services, func(i, j int) bool { return rc.Services[i].Service < rc.Services[j].Service })
+	if a, _ := common.ActorFromContext(ctx); a.ID != "" {
+		rc.RequestedBy = &kcommon.Actor{Id: a.ID, Kind: a.Kind, DisplayName: a.Name}
+	}
+	if s.auditSigner != nil {
+		rc.KeyId = s.auditSigner.KeyID
+		rc.Signature = s.auditSigner.Sign(receiptPayload(rc))
+	}
+	s.receipts.put(tenant, rc)
+	klog.Infof("subject erased tenant=%s receipt=%s subject_hash=%s tickets=%d", tenant, rc.Id, rc.SubjectHash, len(own.Records))
+	return rc, nil
+}
+
+func receiptPayload(rc *ticket.ErasureReceipt) []byte {
+	services := make([]audit.ErasureService, 0, len(rc.Services))
+	for _, s := range rc.Services {
+		services = append(services, audit.ErasureService{Service: s.Service, Records: s.Records, Fields: int(s.Fields)})
+	}
+	return audit.ReceiptPayload(rc.Id, rc.SubjectHash, rc.Tenant, rc.ErasedAt, services)
+}
+
+// GetErasureReceipt returns a receipt issued to the caller's tenant.
+func (s *TicketServiceImpl) GetErasureReceipt(ctx context.Context, req *ticket.GetErasureReceiptRequest) (*ticket.ErasureReceipt, error) {
+	if req == nil || req.Id == "" {
+		return nil, &kcommon.ServiceError{Code: common.ErrCodeBadRequest, Message: idRequiredMsg}
+	}
+	rc := s.receipts.get(common.TenantFromContext(ctx), req.Id)
+	if rc == nil {
+		return nil, &kcommon.ServiceError{Code: common.ErrCodeNotFound, Message: notFoundMsg}
+	}
+	return rc, nil
+}
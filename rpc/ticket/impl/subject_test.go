@@ -0,0 +1,89 @@
+package impl
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/gogogo1024/assist-fusion/internal/audit"
+	"github.com/gogogo1024/assist-fusion/internal/common"
+	"github.com/gogogo1024/assist-fusion/internal/privacy"
+	kcommon "github.com/gogogo1024/assist-fusion/kitex_gen/common"
+	"github.com/gogogo1024/assist-fusion/kitex_gen/ticket"
+)
+
+func TestEraseSubject(t *testing.T) {
+	signer := audit.NewSigner("k1", []byte("secret"))
+	s := NewTicketService(common.NewMemoryTicketRepo(), WithAuditSigner(signer))
+	agent := common.WithActor(context.Background(), common.Actor{ID: "agent1", Kind: common.ActorKindUser, Name: "Agent"}, common.SourceGateway)
+	alice := common.WithActor(context.Background(), common.Actor{ID: "alice@example.com", Kind: common.ActorKindUser, Name: "Alice"}, common.SourceEmail)
+
+	customer := "alice@example.com"
+	r, err := s.CreateTicket(agent, &ticket.CreateTicketRequest{Title: "refund for alice@example.com", Desc: "card ends 4242", Customer: &customer})
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := r.Ticket.Id
+	other, _ := s.CreateTicket(agent, &ticket.CreateTicketRequest{Title: "unrelated", Desc: "x"})
+	if _, err := s.AddComment(alice, &ticket.AddCommentRequest{Id: id, Body: "my address is 1 Main St"}); err != nil {
+		t.Fatal(err)
+	}
+	s.AddComment(agent, &ticket.AddCommentRequest{Id: id, Body: "replied to alice@example.com"})
+
+	exp, err := s.ExportSubject(agent, &kcommon.SubjectRequest{Subject: "Alice@Example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(exp.Tickets) != 1 || exp.Tickets[0].Ticket.Id != id {
+		t.Fatalf("export tickets = %+v", exp.Tickets)
+	}
+	if roles := strings.Join(exp.Tickets[0].Roles, ","); roles != "customer,commenter,actor,mentioned" {
+		t.Fatalf("roles = %s", roles)
+	}
+
+	kbSum := &kcommon.ErasureSummary{Service: "kb", Records: []string{"d1"}, Fields: 1}
+	rc, err := s.EraseSubject(agent, &ticket.EraseSubjectRequest{Subject: customer, Related: []*kcommon.ErasureSummary{kbSum}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rc.Services) != 2 || rc.Services[0].Service != "kb" || rc.Services[1].Records[0] != id {
+		t.Fatalf("receipt services = %+v", rc.Services)
+	}
+	sub, _ := privacy.NewSubject(customer)
+	if rc.SubjectHash != sub.Hash() || rc.RequestedBy.GetId() != "agent1" {
+		t.Fatalf("unexpected receipt: %+v", rc)
+	}
+	if rc.KeyId != "k1" || !signer.Check(receiptPayload(rc), rc.Signature) {
+		t.Fatal("receipt signature does not verify")
+	}
+
+	tk, _ := s.Repo.Get(context.Background(), id)
+	if tk.Customer != privacy.Redacted || tk.Title != "refund for [redacted]" {
+		t.Fatalf("ticket not redacted: %q %q", tk.Customer, tk.Title)
+	}
+	if c := tk.Comments[0]; c.Body != privacy.Redacted || c.Actor.ID != privacy.Redacted {
+		t.Fatalf("subject's comment kept: %+v", c)
+	}
+	if tk.Comments[1].Body != "replied to [redacted]" {
+		t.Fatalf("mention kept: %q", tk.Comments[1].Body)
+	}
+	if ut, _ := s.Repo.Get(context.Background(), other.Ticket.Id); ut.Title != "unrelated" || len(ut.Events) != 1 {
+		t.Fatal("unrelated ticket touched")
+	}
+	v, _ := s.VerifyAudit(agent, &ticket.VerifyAuditRequest{Id: id})
+	if !v.Ok {
+		t.Fatalf("audit chain broken after erasure: %s", v.Reason)
+	}
+
+	again, _ := s.ExportSubject(agent, &kcommon.SubjectRequest{Subject: customer})
+	if len(again.Tickets) != 0 {
+		t.Fatalf("subject still found after erasure: %+v", again.Tickets)
+	}
+	got, err := s.GetErasureReceipt(agent, &ticket.GetErasureReceiptRequest{Id: rc.Id})
+	if err != nil || got.Signature != rc.Signature {
+		t.Fatalf("GetErasureReceipt = %+v, %v", got, err)
+	}
+	if _, err := s.ExportSubject(agent, &kcommon.SubjectRequest{Subject: "al"}); err == nil {
+		t.Fatal("short subject accepted")
+	}
+}
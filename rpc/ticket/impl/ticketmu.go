@@ -0,0 +1,51 @@
+package impl
+
+import (
+	"context"
+	"sync"
+
+	"github.com/gogogo1024/assist-fusion/internal/common"
+)
+
+// ticketMutexes serializes the read-modify-write of each ticket. Every change appends to
+// the ticket's audit hash chain, so two unserialized updates would both link to the same
+// head and fork it (and, behind a copying repo, the later write would drop the earlier).
+type ticketMutexes struct {
+	mu sync.Mutex
+	m  map[string]*ticketMutex
+}
+
+type ticketMutex struct {
+	sync.Mutex
+	refs int // holders and waiters; the entry is dropped when it reaches zero
+}
+
+// lock blocks until key is free and returns the matching unlock.
+func (l *ticketMutexes) lock(key string) func() {
+	l.mu.Lock()
+	if l.m == nil {
+		l.m = make(map[string]*ticketMutex)
+	}
+	tm := l.m[key]
+	if tm == nil {
+		tm = &ticketMutex{}
+		l.m[key] = tm
+	}
+	tm.refs++
+	l.mu.Unlock()
+	tm.Lock()
+	return func() {
+		tm.Unlock()
+		l.mu.Lock()
+		if tm.refs--; tm.refs == 0 {
+			delete(l.m, key)
+		}
+		l.mu.Unlock()
+	}
+}
+
+// lockTicket serializes changes to ticket id in the caller's tenant. Take it before the
+// ticket is read and release it after the update is stored: defer s.lockTicket(ctx, id)().
+func (s *TicketServiceImpl) lockTicket(ctx context.Context, id string) func() {
+	return s.ticketMu.lock(common.TenantFromContext(ctx) + "/" + id)
+}
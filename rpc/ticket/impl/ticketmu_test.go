@@ -0,0 +1,51 @@
+package impl
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/gogogo1024/assist-fusion/internal/common"
+	"github.com/gogogo1024/assist-fusion/internal/fieldcrypt"
+	"github.com/gogogo1024/assist-fusion/kitex_gen/ticket"
+)
+
+// Run with -race: concurrent updates must neither fork the audit chain nor drop a change.
+func TestConcurrentUpdatesKeepChain(t *testing.T) {
+	keys := fieldcrypt.StaticKeys{{ID: "k1", Secret: make([]byte, 32)}}
+	for name, opts := range map[string][]Option{
+		"memory":    nil,
+		"encrypted": {WithFieldEncryption(fieldcrypt.New(keys))},
+	} {
+		t.Run(name, func(t *testing.T) {
+			s := NewTicketService(common.NewMemoryTicketRepo(), opts...)
+			ctx := context.Background()
+			r, _ := s.CreateTicket(ctx, &ticket.CreateTicketRequest{Title: "busy", Desc: "x"})
+			id := r.Ticket.Id
+			const n = 16
+			var wg sync.WaitGroup
+			for i := 0; i < n; i++ {
+				wg.Add(2)
+				go func() {
+					defer wg.Done()
+					s.AddComment(ctx, &ticket.AddCommentRequest{Id: id, Body: fmt.Sprintf("comment %d", i)})
+				}()
+				go func() {
+					defer wg.Done()
+					who := fmt.Sprintf("agent-%d", i)
+					s.Assign(ctx, &ticket.TicketActionRequest{Id: id, Assignee: &who})
+				}()
+			}
+			wg.Wait()
+
+			got, _ := s.GetTicket(ctx, &ticket.GetTicketRequest{Id: id})
+			if len(got.Ticket.Comments) != n || len(got.Ticket.Events) != 1+2*n {
+				t.Fatalf("lost updates: %d comments, %d events", len(got.Ticket.Comments), len(got.Ticket.Events))
+			}
+			if v, _ := s.VerifyAudit(ctx, &ticket.VerifyAuditRequest{Id: id}); !v.Ok {
+				t.Fatalf("audit chain forked at %d: %s", v.BrokenIndex, v.Reason)
+			}
+		})
+	}
+}
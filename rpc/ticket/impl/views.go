@@ -0,0 +1,477 @@
+package impl
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/gogogo1024/assist-fusion/internal/common"
+	kcommon "github.com/gogogo1024/assist-fusion/kitex_gen/common"
+	"github.com/gogogo1024/assist-fusion/kitex_gen/ticket"
+)
+
+// Ticket priorities, lowest first.
+var priorityRank = map[string]int{"low": 1, "normal": 2, "high": 3, "urgent": 4}
+
+func validPriority(p string) bool {
+	_, ok := priorityRank[p]
+	return p == "" || ok
+}
+
+// normalizeTags trims, lowercases and de-duplicates tags, keeping their order.
+func normalizeTags(tags []string) []string {
+	var out []string
+	seen := map[string]bool{}
+	for _, t := range tags {
+		t = strings.ToLower(strings.TrimSpace(t))
+		if t != "" && !seen[t] {
+			seen[t] = true
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// fieldsFingerprint renders custom fields in key order for idempotency fingerprints.
+func fieldsFingerprint(m map[string]string) string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var b strings.Builder
+	for _, k := range keys {
+		b.WriteString(k + "=" + m[k] + "\n")
+	}
+	return b.String()
+}
+
+// SLA states derived from DueAt.
+const (
+	slaNone     = "none"
+	slaOnTrack  = "on_track"
+	slaAtRisk   = "at_risk"
+	slaBreached = "breached"
+)
+
+// slaAtRiskWindow is how long before DueAt an open ticket counts as at risk.
+const slaAtRiskWindow = time.Hour
+
+func slaStatus(t *common.Ticket, now time.Time) string {
+	if t.DueAt == 0 {
+		return slaNone
+	}
+	if t.Status == "resolved" {
+		if t.ResolvedAt > t.DueAt {
+			return slaBreached
+		}
+		return slaOnTrack
+	}
+	switch due := time.Unix(t.DueAt, 0); {
+	case now.After(due):
+		return slaBreached
+	case due.Sub(now) <= slaAtRiskWindow:
+		return slaAtRisk
+	}
+	return slaOnTrack
+}
+
+// View scopes and sort keys.
+const (
+	viewPersonal = "personal"
+	viewTeam     = "team"
+
+	sortCreatedAt = "created_at"
+	sortUpdatedAt = "updated_at"
+	sortPriority  = "priority"
+	sortDueAt     = "due_at"
+)
+
+const (
+	defaultViewPageSize = 20
+	maxViewPageSize     = 100
+)
+
+// viewStore keeps saved views per tenant.
+type viewStore struct {
+	mu sync.RWMutex
+	m  map[string]map[string]*ticket.SavedView
+}
+
+func (vs *viewStore) put(tenant string, v *ticket.SavedView) {
+	vs.mu.Lock()
+	defer vs.mu.Unlock()
+	if vs.m == nil {
+		vs.m = map[string]map[string]*ticket.SavedView{}
+	}
+	if vs.m[tenant] == nil {
+		vs.m[tenant] = map[string]*ticket.SavedView{}
+	}
+	vs.m[tenant][v.Id] = v
+}
+
+func (vs *viewStore) get(tenant, id string) *ticket.SavedView {
+	vs.mu.RLock()
+	defer vs.mu.RUnlock()
+	return vs.m[tenant][id]
+}
+
+func (vs *viewStore) delete(tenant, id string) {
+	vs.mu.Lock()
+	defer vs.mu.Unlock()
+	delete(vs.m[tenant], id)
+}
+
+func (vs *viewStore) list(tenant string) []*ticket.SavedView {
+	vs.mu.RLock()
+	defer vs.mu.RUnlock()
+	out := make([]*ticket.SavedView, 0, len(vs.m[tenant]))
+	for _, v := range vs.m[tenant] {
+		out = append(out, v)
+	}
+	return out
+}
+
+// viewer is the calling user and the teams they belong to.
+type viewer struct {
+	id    string
+	teams map[string]bool
+}
+
+func viewerFromContext(ctx context.Context) (viewer, error) {
+	a, _ := common.ActorFromContext(ctx)
+	if a.Kind != common.ActorKindUser || a.ID == "" {
+		return viewer{}, &kcommon.ServiceError{Code: common.ErrCodeUnauthorized, Message: "user identity required"}
+	}
+	v := viewer{id: a.ID, teams: map[string]bool{}}
+	for _, t := range common.TeamsFromContext(ctx) {
+		v.teams[t] = true
+	}
+	return v, nil
+}
+
+func (v viewer) sees(sv *ticket.SavedView) bool {
+	if sv.Scope == viewTeam {
+		return v.teams[sv.Team]
+	}
+	return sv.Owner == v.id
+}
+
+// canEdit: personal views belong to their owner; any member maintains a team's views.
+func (v viewer) canEdit(sv *ticket.SavedView) bool { return v.sees(sv) }
+
+// checkView validates and normalizes a view definition in place.
+func checkView(v *ticket.SavedView, who viewer) error {
+	bad := func(msg string) error { return &kcommon.ServiceError{Code: common.ErrCodeBadRequest, Message: msg} }
+	v.Name = strings.TrimSpace(v.Name)
+	if v.Name == "" {
+		return bad("view name required")
+	}
+	switch v.Scope {
+	case "", viewPersonal:
+		v.Scope, v.Team = viewPersonal, ""
+	case viewTeam:
+		if v.Team == "" || !who.teams[v.Team] {
+			return bad("team views need a team the caller belongs to")
+		}
+	default:
+		return bad("scope must be personal|team")
+	}
+	switch v.SortBy {
+	case "":
+		v.SortBy = sortCreatedAt
+	case sortCreatedAt, sortUpdatedAt, sortPriority, sortDueAt:
+	default:
+		return bad("sort_by must be created_at|updated_at|priority|due_at")
+	}
+	if v.Filter == nil {
+		v.Filter = &ticket.ViewFilter{}
+	}
+	f := v.Filter
+	for _, p := range f.Priorities {
+		if !validPriority(p) || p == "" {
+			return bad("unknown priority " + p)
+		}
+	}
+	for _, st := range f.Sla {
+		switch st {
+		case slaNone, slaOnTrack, slaAtRisk, slaBreached:
+		default:
+			return bad("sla must be none|on_track|at_risk|breached")
+		}
+	}
+	f.Tags = normalizeTags(f.Tags)
+	return nil
+}
+
+// match reports whether t passes f for the user me at time now.
+func (s *TicketServiceImpl) match(f *ticket.ViewFilter, t *common.Ticket, me string, now time.Time) bool {
+	if !listed(t, nil) {
+		return false
+	}
+	if len(f.Statuses) > 0 && !containsStatus(f.Statuses, toThriftStatus(t.Status)) {
+		return false
+	}
+	if len(f.Assignees) > 0 {
+		ok := false
+		for _, a := range f.Assignees {
+			switch a {
+			case "me":
+				ok = ok || t.Assignee == me
+			case "none":
+				ok = ok || t.Assignee == ""
+			default:
+				ok = ok || t.Assignee == a
+			}
+		}
+		if !ok {
+			return false
+		}
+	}
+	if len(f.Priorities) > 0 && !contains(f.Priorities, t.Priority) {
+		return false
+	}
+	for _, tag := range f.Tags {
+		if !contains(t.Tags, tag) {
+			return false
+		}
+	}
+	if len(f.Sla) > 0 && !contains(f.Sla, slaStatus(t, now)) {
+		return false
+	}
+	for k, v := range f.CustomFields {
+		if t.CustomFields[k] != v {
+			return false
+		}
+	}
+	if len(f.Tiers) > 0 && !contains(f.Tiers, s.ticketTier(t)) {
+		return false
+	}
+	if q := strings.ToLower(strings.TrimSpace(f.Text)); q != "" &&
+		!strings.Contains(strings.ToLower(t.Title), q) && !strings.Contains(strings.ToLower(t.Desc), q) {
+		return false
+	}
+	return true
+}
+
+func contains(list []string, v string) bool {
+	for _, x := range list {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
+
+func containsStatus(list []kcommon.TicketStatus, v kcommon.TicketStatus) bool {
+	for _, x := range list {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
+
+// updatedAt is the time of the last recorded event.
+func updatedAt(t *common.Ticket) int64 {
+	if n := len(t.Events); n > 0 {
+		return t.Events[n-1].At
+	}
+	return t.CreatedAt
+}
+
+// sortTickets orders ts by the view's key; ties (and missing due dates) fall back to
+// creation time, newest last, so pages stay stable.
+func sortTickets(ts []*common.Ticket, by string, desc bool) {
+	key := func(t *common.Ticket) int64 {
+		switch by {
+		case sortUpdatedAt:
+			return updatedAt(t)
+		case sortPriority:
+			return int64(priorityRank[t.Priority])
+		case sortDueAt:
+			return t.DueAt
+		}
+		return t.CreatedAt
+	}
+	sort.SliceStable(ts, func(i, j int) bool {
+		a, b := key(ts[i]), key(ts[j])
+		if by == sortDueAt && (a == 0) != (b == 0) {
+			return b == 0 // tickets without a due date always come last
+		}
+		if a != b {
+			if desc {
+				return a > b
+			}
+			return a < b
+		}
+		if ts[i].CreatedAt != ts[j].CreatedAt {
+			return ts[i].CreatedAt < ts[j].CreatedAt
+		}
+		return ts[i].ID < ts[j].ID
+	})
+}
+
+func (s *TicketServiceImpl) evaluate(ctx context.Context, v *ticket.SavedView, me string) []*common.Ticket {
+	ts, _ := s.Repo.List(ctx)
+	now := time.Now()
+	out := ts[:0]
+	for _, t := range ts {
+		if s.match(v.Filter, t, me, now) {
+			out = append(out, t)
+		}
+	}
+	sortTickets(out, v.SortBy, v.SortDesc)
+	return out
+}
+
+// visibleView returns the view when the caller may see it; hidden views read as missing.
+func (s *TicketServiceImpl) visibleView(ctx context.Context, id string) (*ticket.SavedView, viewer, error) {
+	who, err := viewerFromContext(ctx)
+	if err != nil {
+		return nil, who, err
+	}
+	v := s.views.get(common.TenantFromContext(ctx), id)
+	if v == nil || !who.sees(v) {
+		return nil, who, &kcommon.ServiceError{Code: common.ErrCodeNotFound, Message: notFoundMsg}
+	}
+	return v, who, nil
+}
+
+// CreateView saves a personal view, or a team view for a team the caller belongs to.
+func (s *TicketServiceImpl) CreateView(ctx context.Context, req *ticket.SaveViewRequest) (*ticket.SavedView, error) {
+	who, err := viewerFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req == nil || req.View == nil {
+		return nil, &kcommon.ServiceError{Code: common.ErrCodeBadRequest, Message: "view required"}
+	}
+	v := req.View
+	if err := checkView(v, who); err != nil {
+		return nil, err
+	}
+	now := time.Now().Unix()
+	v.Id, v.Owner, v.CreatedAt, v.UpdatedAt = uuid.NewString(), who.id, now, now
+	s.views.put(common.TenantFromContext(ctx), v)
+	return v, nil
+}
+
+// UpdateView replaces a view's definition; id, owner and creation time are kept.
+func (s *TicketServiceImpl) UpdateView(ctx context.Context, req *ticket.SaveViewRequest) (*ticket.SavedView, error) {
+	if req == nil || req.View == nil || req.View.Id == "" {
+		return nil, &kcommon.ServiceError{Code: common.ErrCodeBadRequest, Message: idRequiredMsg}
+	}
+	cur, who, err := s.visibleView(ctx, req.View.Id)
+	if err != nil {
+		return nil, err
+	}
+	if !who.canEdit(cur) {
+		return nil, &kcommon.ServiceError{Code: common.ErrCodeNotFound, Message: notFoundMsg}
+	}
+	v := req.View
+	if err := checkView(v, who); err != nil {
+		return nil, err
+	}
+	v.Owner, v.CreatedAt, v.UpdatedAt = cur.Owner, cur.CreatedAt, time.Now().Unix()
+	s.views.put(common.TenantFromContext(ctx), v)
+	return v, nil
+}
+
+func (s *TicketServiceImpl) GetView(ctx context.Context, req *ticket.ViewRequest) (*ticket.SavedView, error) {
+	if req == nil || req.Id == "" {
+		return nil, &kcommon.ServiceError{Code: common.ErrCodeBadRequest, Message: idRequiredMsg}
+	}
+	v, _, err := s.visibleView(ctx, req.Id)
+	return v, err
+}
+
+func (s *TicketServiceImpl) DeleteView(ctx context.Context, req *ticket.ViewRequest) (*ticket.DeleteViewResponse, error) {
+	if req == nil || req.Id == "" {
+		return nil, &kcommon.ServiceError{Code: common.ErrCodeBadRequest, Message: idRequiredMsg}
+	}
+	v, who, err := s.visibleView(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	if !who.canEdit(v) {
+		return nil, &kcommon.ServiceError{Code: common.ErrCodeNotFound, Message: notFoundMsg}
+	}
+	s.views.delete(common.TenantFromContext(ctx), v.Id)
+	return &ticket.DeleteViewResponse{Ok: true}, nil
+}
+
+// ListViews returns the caller's personal views and their teams' views (personal first,
+// then by name), optionally with the number of tickets each view currently matches.
+func (s *TicketServiceImpl) ListViews(ctx context.Context, req *ticket.ListViewsRequest) (*ticket.ListViewsResponse, error) {
+	who, err := viewerFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	counts := req != nil && req.Counts
+	var ts []*common.Ticket
+	if counts {
+		ts, _ = s.Repo.List(ctx)
+	}
+	now := time.Now()
+	out := &ticket.ListViewsResponse{Views: []*ticket.ViewSummary{}}
+	for _, v := range s.views.list(common.TenantFromContext(ctx)) {
+		if !who.sees(v) {
+			continue
+		}
+		sum := &ticket.ViewSummary{View: v, Count: -1}
+		if counts {
+			sum.Count = 0
+			for _, t := range ts {
+				if s.match(v.Filter, t, who.id, now) {
+					sum.Count++
+				}
+			}
+		}
+		out.Views = append(out.Views, sum)
+	}
+	sort.Slice(out.Views, func(i, j int) bool {
+		a, b := out.Views[i].View, out.Views[j].View
+		if a.Scope != b.Scope {
+			return a.Scope == viewPersonal
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.Id < b.Id
+	})
+	return out, nil
+}
+
+// ViewTickets evaluates a view and returns one page of its tickets.
+func (s *TicketServiceImpl) ViewTickets(ctx context.Context, req *ticket.ViewTicketsRequest) (*ticket.ListTicketsResponse, error) {
+	if req == nil || req.Id == "" {
+		return nil, &kcommon.ServiceError{Code: common.ErrCodeBadRequest, Message: idRequiredMsg}
+	}
+	v, who, err := s.visibleView(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	page, size := int32(1), int32(defaultViewPageSize)
+	if p := req.Pagination; p != nil {
+		if p.Page > 0 {
+			page = p.Page
+		}
+		if p.PageSize > 0 {
+			size = min(p.PageSize, maxViewPageSize)
+		}
+	}
+	ts := s.evaluate(ctx, v, who.id)
+	total := int32(len(ts))
+	from, to := min((page-1)*size, total), min(page*size, total)
+	out := &ticket.ListTicketsResponse{Tickets: make([]*kcommon.Ticket, 0, to-from)}
+	for _, t := range ts[from:to] {
+		out.Tickets = append(out.Tickets, s.toThriftTicket(t))
+	}
+	out.PageInfo = &kcommon.PageInfo{Page: page, PageSize: size, TotalItems: total, TotalPages: (total + size - 1) / size}
+	return out, nil
+}
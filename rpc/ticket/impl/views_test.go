@@ -0,0 +1,113 @@
+package impl
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gogogo1024/assist-fusion/internal/common"
+	kcommon "github.com/gogogo1024/assist-fusion/kitex_gen/common"
+	"github.com/gogogo1024/assist-fusion/kitex_gen/ticket"
+)
+
+func TestSavedViews(t *testing.T) {
+	s := NewTicketService(common.NewMemoryTicketRepo())
+	user := func(id string, teams ...string) context.Context {
+		ctx := common.WithActor(context.Background(), common.Actor{ID: id, Kind: common.ActorKindUser, Name: id}, common.SourceGateway)
+		return common.WithTeams(ctx, teams)
+	}
+	ann, bob, eve := user("ann", "billing"), user("bob", "billing"), user("eve")
+
+	soon := time.Now().Add(30 * time.Minute).Unix()
+	mk := func(title, prio string, tags []string, fields map[string]string, due int64) string {
+		r, err := s.CreateTicket(ann, &ticket.CreateTicketRequest{Title: title, Desc: "x", Priority: &prio, Tags: tags, CustomFields: fields, DueAt: &due})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return r.Ticket.Id
+	}
+	urgent := mk("Refund stuck", "urgent", []string{"Refund", "vip"}, map[string]string{"plan": "pro"}, soon)
+	mk("Refund question", "low", []string{"refund"}, map[string]string{"plan": "free"}, 0)
+	mk("VPN down", "high", nil, nil, 0)
+	if _, err := s.CreateTicket(ann, &ticket.CreateTicketRequest{Title: "bad", Desc: "x", Priority: strPtr("meh")}); err == nil {
+		t.Fatal("unknown priority accepted")
+	}
+	if _, err := s.Assign(ann, &ticket.TicketActionRequest{Id: urgent, Assignee: strPtr("bob")}); err != nil {
+		t.Fatal(err)
+	}
+
+	team, err := s.CreateView(ann, &ticket.SaveViewRequest{View: &ticket.SavedView{
+		Name: "Refunds", Scope: "team", Team: "billing", SortBy: "priority", SortDesc: true,
+		Filter: &ticket.ViewFilter{Tags: []string{"refund"}, Text: "REFUND"},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	mine, err := s.CreateView(bob, &ticket.SaveViewRequest{View: &ticket.SavedView{
+		Name: "My at-risk", Filter: &ticket.ViewFilter{
+			Assignees: []string{"me"}, Sla: []string{"at_risk"}, CustomFields: map[string]string{"plan": "pro"},
+			Statuses: []kcommon.TicketStatus{kcommon.TicketStatus_ASSIGNED},
+		},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.CreateView(eve, &ticket.SaveViewRequest{View: &ticket.SavedView{Name: "x", Scope: "team", Team: "billing"}}); err == nil {
+		t.Fatal("non-member created a team view")
+	}
+	if _, err := s.CreateView(context.Background(), &ticket.SaveViewRequest{View: &ticket.SavedView{Name: "x"}}); err == nil {
+		t.Fatal("anonymous caller created a view")
+	}
+
+	list, _ := s.ListViews(bob, &ticket.ListViewsRequest{Counts: true})
+	if len(list.Views) != 2 || list.Views[0].View.Id != mine.Id || list.Views[0].Count != 1 || list.Views[1].Count != 2 {
+		t.Fatalf("bob's views = %+v", list.Views)
+	}
+	if list, _ := s.ListViews(eve, &ticket.ListViewsRequest{}); len(list.Views) != 0 {
+		t.Fatalf("eve sees %d views", len(list.Views))
+	}
+	if _, err := s.GetView(ann, &ticket.ViewRequest{Id: mine.Id}); err == nil {
+		t.Fatal("personal view visible to another user")
+	}
+
+	page, err := s.ViewTickets(bob, &ticket.ViewTicketsRequest{Id: team.Id, Pagination: &kcommon.Pagination{Page: 1, PageSize: 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Tickets) != 1 || page.Tickets[0].Id != urgent || page.PageInfo.TotalItems != 2 || page.PageInfo.TotalPages != 2 {
+		t.Fatalf("page 1 = %+v %+v", page.Tickets, page.PageInfo)
+	}
+	if tk := page.Tickets[0]; tk.SlaStatus != "at_risk" || tk.CustomFields["plan"] != "pro" || len(tk.Tags) != 2 || tk.Tags[0] != "refund" {
+		t.Fatalf("ticket fields = %+v", tk)
+	}
+
+	if _, err := s.DeleteView(eve, &ticket.ViewRequest{Id: team.Id}); err == nil {
+		t.Fatal("non-member deleted a team view")
+	}
+	if _, err := s.DeleteView(bob, &ticket.ViewRequest{Id: team.Id}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.GetView(ann, &ticket.ViewRequest{Id: team.Id}); err == nil {
+		t.Fatal("deleted view still readable")
+	}
+}
+
+func TestSLAStatus(t *testing.T) {
+	now := time.Unix(10_000, 0)
+	cases := []struct {
+		t    common.Ticket
+		want string
+	}{
+		{common.Ticket{}, "none"},
+		{common.Ticket{Status: "created", DueAt: 9_000}, "breached"},
+		{common.Ticket{Status: "created", DueAt: 11_000}, "at_risk"},
+		{common.Ticket{Status: "created", DueAt: 20_000}, "on_track"},
+		{common.Ticket{Status: "resolved", DueAt: 9_000, ResolvedAt: 8_000}, "on_track"},
+		{common.Ticket{Status: "resolved", DueAt: 9_000, ResolvedAt: 9_500}, "breached"},
+	}
+	for i, c := range cases {
+		if got := slaStatus(&c.t, now); got != c.want {
+			t.Errorf("case %d: got %s, want %s", i, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package impl
+
+import (
+	"context"
+	"time"
+
+	"github.com/gogogo1024/assist-fusion/internal/common"
+	"github.com/gogogo1024/assist-fusion/internal/notify"
+	kcommon "github.com/gogogo1024/assist-fusion/kitex_gen/common"
+	"github.com/gogogo1024/assist-fusion/kitex_gen/ticket"
+)
+
+const identityRequiredMsg = "user identity required"
+
+// callerID returns the propagated user id; notification RPCs are scoped to it.
+func callerID(ctx context.Context) (string, error) {
+	a, _ := common.ActorFromContext(ctx)
+	if a.Kind == common.ActorKindSystem {
+		return "", &kcommon.ServiceError{Code: common.ErrCodeUnauthorized, Message: identityRequiredMsg}
+	}
+	return a.ID, nil
+}
+
+// watchTarget resolves the ticket and the user to (un)subscribe, defaulting to the caller.
+// watchTarget loads the ticket under its update lock; callers release it with the returned func.
+func (s *TicketServiceImpl) watchTarget(ctx context.Context, req *ticket.WatchRequest) (*common.Ticket, string, func(), error) {
+	if req == nil || req.Id == "" {
+		return nil, "", nil, &kcommon.ServiceError{Code: common.ErrCodeBadRequest, Message: idRequiredMsg}
+	}
+	user := req.GetUserId()
+	if user == "" {
+		id, err := callerID(ctx)
+		if err != nil {
+			return nil, "", nil, err
+		}
+		user = id
+	}
+	unlock := s.lockTicket(ctx, req.Id)
+	t := s.lookup(ctx, req.Id)
+	if t == nil {
+		unlock()
+		return nil, "", nil, &kcommon.ServiceError{Code: common.ErrCodeNotFound, Message: notFoundMsg}
+	}
+	return t, user, unlock, nil
+}
+
+// Watch subscribes a user to a ticket's notifications. Subscribing twice is a no-op.
+func (s *TicketServiceImpl) Watch(ctx context.Context, req *ticket.WatchRequest) (*ticket.TicketResponse, error) {
+	t, user, unlock, err := s.watchTarget(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	for _, w := range t.Watchers {
+		if w == user {
+			return &ticket.TicketResponse{Ticket: s.toThriftTicket(t)}, nil
+		}
+	}
+	t.Watchers = append(t.Watchers, user)
+	appendEvent(t, newEvent(ctx, "watcher_added", time.Now().Unix(), user))
+	_ = s.Repo.Update(ctx, t)
+	s.publish(ctx, t, len(t.Events)-1)
+	return &ticket.TicketResponse{Ticket: s.toThriftTicket(t)}, nil
+}
+
+// Unwatch removes a subscription. Removing a non-watcher is a no-op.
+func (s *TicketServiceImpl) Unwatch(ctx context.Context, req *ticket.WatchRequest) (*ticket.TicketResponse, error) {
+	t, user, unlock, err := s.watchTarget(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	kept := t.Watchers[:0]
+	for _, w := range t.Watchers {
+		if w != user {
+			kept = append(kept, w)
+		}
+	}
+	if len(kept) == len(t.Watchers) {
+		return &ticket.TicketResponse{Ticket: s.toThriftTicket(t)}, nil
+	}
+	t.Watchers = kept
+	appendEvent(t, newEvent(ctx, "watcher_removed", time.Now().Unix(), user))
+	_ = s.Repo.Update(ctx, t)
+	return &ticket.TicketResponse{Ticket: s.toThriftTicket(t)}, nil
+}
+
+// ListNotifications returns the caller's in-app inbox, newest first.
+func (s *TicketServiceImpl) ListNotifications(ctx context.Context, req *ticket.ListNotificationsRequest) (*ticket.ListNotificationsResponse, error) {
+	user, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var unreadOnly bool
+	var limit int
+	if req != nil {
+		unreadOnly = req.GetUnreadOnly()
+		limit = int(req.GetLimit())
+	}
+	list, unread := s.notifier.Inbox().List(common.TenantFromContext(ctx), user, unreadOnly, limit)
+	items := make([]*ticket.Notification, 0, len(list))
+	for _, n := range list {
+		items = append(items, toThriftNotification(n))
+	}
+	return &ticket.ListNotificationsResponse{Items: items, Unread: int32(unread)}, nil
+}
+
+func toThriftNotification(n notify.Notification) *ticket.Notification {
+	return &ticket.Notification{
+		Id:        n.ID,
+		TicketId:  n.TicketID,
+		EventType: n.EventType,
+		Subject:   n.Subject,
+		Body:      n.Body,
+		CreatedAt: n.At,
+		Read_:     n.Read,
+		Actor:     &kcommon.Actor{Id: n.Actor.ID, Kind: n.Actor.Kind, DisplayName: n.Actor.Name},
+		Role:      n.Role,
+	}
+}
+
+// MarkNotifications sets read/unread state on the caller's notifications.
+func (s *TicketServiceImpl) MarkNotifications(ctx context.Context, req *ticket.MarkNotificationsRequest) (*ticket.MarkNotificationsResponse, error) {
+	user, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req == nil || (len(req.Ids) == 0 && !req.GetAll()) {
+		return nil, &kcommon.ServiceError{Code: common.ErrCodeBadRequest, Message: "ids or all required"}
+	}
+	updated, unread := s.notifier.Inbox().Mark(common.TenantFromContext(ctx), user, req.Ids, req.GetAll(), req.Read_)
+	return &ticket.MarkNotificationsResponse{Updated: int32(updated), Unread: int32(unread)}, nil
+}
+
+func toThriftPrefs(p notify.Preferences) *ticket.NotificationPrefs {
+	return &ticket.NotificationPrefs{Channels: p.Channels, Email: p.Email, WebhookUrl: p.WebhookURL}
+}
+
+// GetNotificationPrefs returns the caller's delivery preferences.
+func (s *TicketServiceImpl) GetNotificationPrefs(ctx context.Context, _ *ticket.GetNotificationPrefsRequest) (*ticket.NotificationPrefs, error) {
+	user, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	p, err := s.notifier.Prefs().Get(ctx, user)
+	if err != nil {
+		return nil, &kcommon.ServiceError{Code: common.ErrCodeInternal, Message: err.Error()}
+	}
+	return toThriftPrefs(p), nil
+}
+
+// SetNotificationPrefs replaces the caller's delivery preferences.
+func (s *TicketServiceImpl) SetNotificationPrefs(ctx context.Context, req *ticket.SetNotificationPrefsRequest) (*ticket.NotificationPrefs, error) {
+	user, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req == nil || req.Prefs == nil {
+		return nil, &kcommon.ServiceError{Code: common.ErrCodeBadRequest, Message: "prefs required"}
+	}
+	p := notify.Preferences{Channels: req.Prefs.Channels, Email: req.Prefs.Email, WebhookURL: req.Prefs.WebhookUrl}
+	if err := p.Validate(); err != nil {
+		return nil, &kcommon.ServiceError{Code: common.ErrCodeBadRequest, Message: err.Error()}
+	}
+	if err := s.notifier.Prefs().Set(ctx, user, p); err != nil {
+		return nil, &kcommon.ServiceError{Code: common.ErrCodeInternal, Message: err.Error()}
+	}
+	return toThriftPrefs(p), nil
+}
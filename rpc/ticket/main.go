@@ -3,10 +3,20 @@ package main
 import (
 	"context"
 	"log"
+	"os"
+	"strconv"
+	"time"
 
+	"github.com/cloudwego/kitex/client"
 	"github.com/cloudwego/kitex/pkg/klog"
+	"github.com/cloudwego/kitex/transport"
+	"github.com/gogogo1024/assist-fusion/internal/ai"
+	"github.com/gogogo1024/assist-fusion/internal/audit"
 	"github.com/gogogo1024/assist-fusion/internal/common"
+	"github.com/gogogo1024/assist-fusion/internal/fieldcrypt"
 	"github.com/gogogo1024/assist-fusion/internal/kitexconf"
+	"github.com/gogogo1024/assist-fusion/internal/notify"
+	"github.com/gogogo1024/assist-fusion/kitex_gen/ai/aiservice"
 	"github.com/gogogo1024/assist-fusion/kitex_gen/ticket/ticketservice"
 	ticketimpl "github.com/gogogo1024/assist-fusion/rpc/ticket/impl"
 )
@@ -20,16 +30,121 @@ func main() {
 		log.Printf("init logger failed (fallback std log only): %v", err)
 	}
 	repo := common.NewMemoryTicketRepo()
-	h := ticketimpl.NewTicketService(repo)
-	opts, err := kitexconf.BuildServerOptions(cfg)
+	var opts []ticketimpl.Option
+	// CSAT_REOPEN_BELOW=3 reopens tickets whose survey rating is 1 or 2
+	if v := os.Getenv("CSAT_REOPEN_BELOW"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			opts = append(opts, ticketimpl.WithCSATReopenBelow(n))
+		}
+	}
+	// AUDIT_SIGNING_KEY enables HMAC signatures on exported audit chain heads
+	if signer := audit.NewSigner(os.Getenv("AUDIT_SIGNING_KEY_ID"), []byte(os.Getenv("AUDIT_SIGNING_KEY"))); signer != nil {
+		opts = append(opts, ticketimpl.WithAuditSigner(signer))
+	}
+	// the in-app inbox is always on; email needs NOTIFY_SMTP_ADDR, webhooks need a per-user URL
+	notifyOpts := []notify.Option{notify.WithChannel(notify.NewWebhookChannel(nil))}
+	if addr := os.Getenv("NOTIFY_SMTP_ADDR"); addr != "" {
+		notifyOpts = append(notifyOpts, notify.WithChannel(notify.NewSMTPChannel(notify.SMTPConfig{
+			Addr:     addr,
+			From:     os.Getenv("NOTIFY_SMTP_FROM"),
+			Username: os.Getenv("NOTIFY_SMTP_USER"),
+			Password: os.Getenv("NOTIFY_SMTP_PASS"),
+			Domain:   os.Getenv("NOTIFY_MAIL_DOMAIN"),
+		})))
+	}
+	// AI_RPC_ADDR enables duplicate detection on create (embeddings from ai-rpc);
+	// TTHeader forwards the tenant so ai-rpc applies that tenant's provider and quota
+	if addr := os.Getenv("AI_RPC_ADDR"); addr != "" {
+		if cli, err := aiservice.NewClient("ai", client.WithHostPorts(addr), client.WithTransportProtocol(transport.TTHeaderFramed)); err == nil {
+			opts = append(opts, ticketimpl.WithEmbedder(ai.NewRPCEmbedder(cli, 0)))
+			report, strict := envFloat("DEDUP_THRESHOLD", 0.85), envFloat("DEDUP_STRICT_THRESHOLD", 0.97)
+			opts = append(opts, ticketimpl.WithDuplicateThresholds(report, strict))
+		} else {
+			log.Printf("ai client init failed, duplicate detection disabled: %v", err)
+		}
+	}
+	// TICKET_RETENTION="resolved:90d:archive,archived:365d:anonymize,deleted:30d:purge"
+	var retentionOn bool
+	if spec := os.Getenv("TICKET_RETENTION"); spec != "" {
+		policies, err := ticketimpl.ParseRetentionPolicies(spec)
+		if err != nil {
+			log.Fatalf("TICKET_RETENTION: %v", err)
+		}
+		opts = append(opts, ticketimpl.WithRetentionPolicies(policies))
+		retentionOn = len(policies) > 0
+	}
+	// TICKET_ESCALATION_PATHS="*:L1>L2>L3>vendor;billing:L1>L2=billing-team>vendor=acme"
+	if spec := os.Getenv("TICKET_ESCALATION_PATHS"); spec != "" {
+		paths, err := ticketimpl.ParseEscalationPaths(spec)
+		if err != nil {
+			log.Fatalf("TICKET_ESCALATION_PATHS: %v", err)
+		}
+		opts = append(opts, ticketimpl.WithEscalationPaths(paths))
+	}
+	// TICKET_PULL_WEIGHTS="priority=4,sla=3,age=1,skill=2" ranks PullNext candidates
+	if spec := os.Getenv("TICKET_PULL_WEIGHTS"); spec != "" {
+		w, err := ticketimpl.ParsePullWeights(spec)
+		if err != nil {
+			log.Fatalf("TICKET_PULL_WEIGHTS: %v", err)
+		}
+		opts = append(opts, ticketimpl.WithPullWeights(w))
+	}
+	if d, err := time.ParseDuration(os.Getenv("TICKET_CLAIM_TTL")); err == nil && d > 0 {
+		opts = append(opts, ticketimpl.WithClaimTTL(d))
+	}
+	if d, err := time.ParseDuration(os.Getenv("TICKET_LOCK_TTL")); err == nil && d > 0 {
+		opts = append(opts, ticketimpl.WithLockTTL(d))
+	}
+	// TICKET_FIELD_KEYFILE encrypts descriptions, event notes and comment bodies at rest;
+	// rotate by appending a key line, the re-encryption job picks it up
+	keyfile := os.Getenv("TICKET_FIELD_KEYFILE")
+	if keyfile != "" {
+		keys, err := fieldcrypt.LoadKeyFile(keyfile)
+		if err != nil {
+			log.Fatalf("TICKET_FIELD_KEYFILE: %v", err)
+		}
+		opts = append(opts, ticketimpl.WithFieldEncryption(fieldcrypt.New(keys)))
+	}
+	notifier := notify.NewDispatcher(notifyOpts...)
+	defer notifier.Close()
+	opts = append(opts, ticketimpl.WithNotifier(notifier))
+	h := ticketimpl.NewTicketService(repo, opts...)
+	if retentionOn {
+		interval := time.Hour
+		if d, err := time.ParseDuration(os.Getenv("TICKET_RETENTION_INTERVAL")); err == nil && d > 0 {
+			interval = d
+		}
+		// TICKET_RETENTION_DRY_RUN=1 only logs what the policies would do
+		dryRun := os.Getenv("TICKET_RETENTION_DRY_RUN") == "1"
+		retentionCtx, stopRetention := context.WithCancel(context.Background())
+		defer stopRetention()
+		go h.RunRetention(retentionCtx, interval, dryRun)
+	}
+	if keyfile != "" {
+		interval := time.Hour
+		if d, err := time.ParseDuration(os.Getenv("TICKET_REENCRYPT_INTERVAL")); err == nil && d > 0 {
+			interval = d
+		}
+		reencryptCtx, stopReencrypt := context.WithCancel(context.Background())
+		defer stopReencrypt()
+		go h.RunReencrypt(reencryptCtx, interval)
+	}
+	svrOpts, err := kitexconf.BuildServerOptions(cfg)
 	if err != nil {
 		klog.Fatalf("build opts: %v", err)
 	}
 	hooks := kitexconf.InitRuntime(context.Background(), cfg)
 	defer hooks.Shutdown(context.Background())
-	svr := ticketservice.NewServer(h, opts...)
+	svr := ticketservice.NewServer(h, svrOpts...)
 	klog.Infof("ticket service starting env=%s addr=%s config=%s", cfg.Env, cfg.Kitex.Address, cfg.RawPath)
 	if err := svr.Run(); err != nil {
 		klog.Errorf("server stopped: %v", err)
 	}
 }
+
+func envFloat(key string, def float64) float64 {
+	if v, err := strconv.ParseFloat(os.Getenv(key), 64); err == nil {
+		return v
+	}
+	return def
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+)
+
+// Actor attribution scenarios (port 18214).
+
+func TestTicketEventActorAttribution(t *testing.T) { // :18214
+	setupOnce(t)
+	base, stop := buildServer(t, ":18214")
+	defer stop()
+	b, _ := json.Marshal(map[string]string{"title": "attribution", "desc": "who did it"})
+	req, _ := http.NewRequest(http.MethodPost, base+pathTickets, bytes.NewReader(b))
+	req.Header.Set(headerContentTypeTest, contentTypeJSON)
+	req.Header.Set("X-User-ID", "u-alice")
+	req.Header.Set("X-User-Name", "Alice")
+	var tk ticketResp
+	doJSON(t, req, http.StatusCreated, &tk)
+
+	req, _ = http.NewRequest(http.MethodPut, base+ticketPrefix+tk.ID+"/assign", nil)
+	req.Header.Set("X-User-ID", "u-bob")
+	doJSON(t, req, http.StatusOK, nil)
+
+	type eventsOut struct {
+		Events []struct {
+			Type  string `json:"type"`
+			Actor struct {
+				ID   string `json:"id"`
+				Kind string `json:"kind"`
+				Name string `json:"display_name"`
+			} `json:"actor"`
+			Source string `json:"source"`
+		} `json:"events"`
+	}
+	var all eventsOut
+	getJSON(t, base+ticketPrefix+tk.ID+"/events", &all)
+	if len(all.Events) != 2 {
+		t.Fatalf("expected 2 events got %#v", all)
+	}
+	created := all.Events[0]
+	if created.Actor.ID != "u-alice" || created.Actor.Name != "Alice" || created.Actor.Kind != "user" || created.Source != "gateway" {
+		t.Fatalf("unexpected created attribution: %#v", created)
+	}
+	var bobs eventsOut
+	getJSON(t, base+ticketPrefix+tk.ID+"/events?actor=u-bob", &bobs)
+	if len(bobs.Events) != 1 || bobs.Events[0].Type != "assigned" {
+		t.Fatalf("expected only bob's assign event: %#v", bobs)
+	}
+}
+
+func doJSON(t *testing.T, req *http.Request, wantCode int, out any) {
+	t.Helper()
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("%s %s err=%v", req.Method, req.URL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != wantCode {
+		raw, _ := io.ReadAll(resp.Body)
+		t.Fatalf("%s %s status=%d want %d body=%s", req.Method, req.URL, resp.StatusCode, wantCode, raw)
+	}
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			t.Fatalf("decode %s: %v", req.URL, err)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// CSAT scenarios (port 18213).
+
+func TestCSATSurveyFlow(t *testing.T) { // :18213
+	setupOnce(t)
+	base, stop := buildServer(t, ":18213")
+	defer stop()
+	tk := createTicket(t, base, "csat", "survey flow")
+	b, _ := json.Marshal(map[string]string{"assignee": "alice"})
+	req, _ := http.NewRequest(http.MethodPut, base+ticketPrefix+tk.ID+"/assign", bytes.NewReader(b))
+	req.Header.Set(headerContentTypeTest, contentTypeJSON)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("assign err=%v", err)
+	}
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	var resolved struct {
+		Status      string `json:"status"`
+		SurveyToken string `json:"survey_token"`
+	}
+	if code := putAndDecode(t, base+ticketPrefix+tk.ID+"/resolve", &resolved); code != http.StatusOK {
+		t.Fatalf("resolve code=%d", code)
+	}
+	if resolved.SurveyToken == "" {
+		t.Fatalf("expected survey token on resolve: %#v", resolved)
+	}
+	token := resolved.SurveyToken
+
+	// the token is a credential: ticket reads never carry it
+	req, _ = http.NewRequest(http.MethodGet, base+ticketPrefix+tk.ID, nil)
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if strings.Contains(string(body), token) {
+		t.Fatalf("ticket read leaks the survey token: %s", body)
+	}
+
+	// low score reopens (test backend runs with reopen threshold 3)
+	var out struct {
+		TicketID string `json:"ticket_id"`
+		Reopened bool   `json:"reopened"`
+	}
+	if code := postSurvey(t, base, token, 2, &out); code != http.StatusOK {
+		t.Fatalf("survey code=%d", code)
+	}
+	if out.TicketID != tk.ID || !out.Reopened {
+		t.Fatalf("unexpected survey result: %#v", out)
+	}
+	if code := postSurvey(t, base, token, 5, nil); code != http.StatusConflict {
+		t.Fatalf("expected 409 on resubmit got %d", code)
+	}
+	if code := postSurvey(t, base, "unknown-token", 5, nil); code != http.StatusNotFound {
+		t.Fatalf("expected 404 for unknown token got %d", code)
+	}
+
+	var report struct {
+		Rows []struct {
+			Key       string  `json:"key"`
+			Responses int     `json:"responses"`
+			Average   float64 `json:"average"`
+		} `json:"rows"`
+	}
+	getJSON(t, base+"/v1/reports/csat?group_by=assignee", &report)
+	found := false
+	for _, r := range report.Rows {
+		if r.Key == "alice" && r.Responses >= 1 {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected csat row for alice: %#v", report)
+	}
+}
+
+func postSurvey(t *testing.T, base, token string, rating int, out any) int {
+	t.Helper()
+	b, _ := json.Marshal(map[string]any{"rating": rating, "comment": "thanks"})
+	resp, err := http.Post(base+"/v1/surveys/"+token, contentTypeJSON, bytes.NewReader(b))
+	if err != nil {
+		t.Fatalf("post survey: %v", err)
+	}
+	defer resp.Body.Close()
+	if out != nil && resp.StatusCode == http.StatusOK {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			t.Fatalf("decode survey: %v", err)
+		}
+	}
+	return resp.StatusCode
+}
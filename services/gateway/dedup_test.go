@@ -0,0 +1,72 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+// Duplicate detection on create (port 18216).
+
+type createOut struct {
+	ID                 string `json:"id"`
+	PossibleDuplicates []struct {
+		ID         string  `json:"id"`
+		Title      string  `json:"title"`
+		Similarity float64 `json:"similarity"`
+	} `json:"possible_duplicates"`
+}
+
+func postTicket(t *testing.T, base string, body map[string]any, want int, out any) {
+	t.Helper()
+	b, _ := json.Marshal(body)
+	req, _ := http.NewRequest(http.MethodPost, base+pathTickets, bytes.NewReader(b))
+	req.Header.Set(headerContentTypeTest, contentTypeJSON)
+	doJSON(t, req, want, out)
+}
+
+func TestCreateReportsPossibleDuplicates(t *testing.T) { // :18216
+	setupOnce(t)
+	base, stop := buildServer(t, ":18216")
+	defer stop()
+	body := map[string]any{"title": "dedup: vpn drops every hour", "desc": "client disconnects at :00"}
+
+	var first createOut
+	postTicket(t, base, body, http.StatusCreated, &first)
+	if len(first.PossibleDuplicates) != 0 {
+		t.Fatalf("first ticket should have no duplicates: %#v", first)
+	}
+
+	var second createOut
+	postTicket(t, base, body, http.StatusCreated, &second)
+	if len(second.PossibleDuplicates) != 1 {
+		t.Fatalf("want 1 duplicate candidate, got %#v", second.PossibleDuplicates)
+	}
+	if d := second.PossibleDuplicates[0]; d.ID != first.ID || d.Title != body["title"] || d.Similarity < 0.99 {
+		t.Fatalf("unexpected candidate %#v (first=%s)", d, first.ID)
+	}
+
+	// strict mode refuses a near-identical ticket
+	body["strict_duplicates"] = true
+	postTicket(t, base, body, http.StatusConflict, nil)
+
+	// unrelated text is not flagged, even in strict mode
+	var other createOut
+	postTicket(t, base, map[string]any{"title": "dedup: new laptop request", "desc": "for the intern", "strict_duplicates": true}, http.StatusCreated, &other)
+	if len(other.PossibleDuplicates) != 0 {
+		t.Fatalf("unrelated ticket flagged: %#v", other.PossibleDuplicates)
+	}
+
+	// resolved tickets are no longer candidates
+	for _, id := range []string{first.ID, second.ID} {
+		if code := putAndDecode(t, base+ticketPrefix+id+"/resolve", nil); code != http.StatusOK {
+			t.Fatalf("resolve %s code=%d", id, code)
+		}
+	}
+	var third createOut
+	postTicket(t, base, body, http.StatusCreated, &third)
+	if len(third.PossibleDuplicates) != 0 {
+		t.Fatalf("resolved tickets still reported: %#v", third.PossibleDuplicates)
+	}
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+// Escalation tiers, tier queues and the escalation report (port 18221).
+
+func TestEscalationTiers(t *testing.T) { // :18221
+	setupOnce(t)
+	base, stop := buildServer(t, ":18221")
+	defer stop()
+	const tenant = "tiers"
+	do := func(method, path string, body any, want int, out any) {
+		t.Helper()
+		var rd *bytes.Reader
+		if body != nil {
+			b, _ := json.Marshal(body)
+			rd = bytes.NewReader(b)
+		} else {
+			rd = bytes.NewReader(nil)
+		}
+		req, _ := http.NewRequest(method, base+path, rd)
+		req.Header.Set(headerContentTypeTest, contentTypeJSON)
+		doJSON(t, asTenant(req, tenant), want, out)
+	}
+	type tierTicket struct {
+		ID       string `json:"id"`
+		Status   string `json:"status"`
+		Tier     string `json:"tier"`
+		Assignee string `json:"assignee"`
+		Events   []struct {
+			Type     string `json:"type"`
+			FromTier string `json:"from_tier"`
+			ToTier   string `json:"to_tier"`
+		} `json:"events"`
+	}
+
+	var tk tierTicket
+	do(http.MethodPost, pathTickets, map[string]any{"title": "tiers: printer on fire", "desc": "x"}, http.StatusCreated, &tk)
+	if tk.Tier != "L1" {
+		t.Fatalf("new ticket tier = %q", tk.Tier)
+	}
+	do(http.MethodPut, ticketPrefix+tk.ID+"/escalate", map[string]string{"assignee": "l2-oncall", "note": "needs hardware"}, http.StatusOK, &tk)
+	last := tk.Events[len(tk.Events)-1]
+	if tk.Tier != "L2" || tk.Status != "escalated" || tk.Assignee != "l2-oncall" || last.FromTier != "L1" || last.ToTier != "L2" {
+		t.Fatalf("after escalate: %+v", tk)
+	}
+	var queue []tierTicket
+	do(http.MethodGet, pathTickets+"?tier=L2", nil, http.StatusOK, &queue)
+	if len(queue) != 1 || queue[0].ID != tk.ID {
+		t.Fatalf("L2 queue = %+v", queue)
+	}
+	do(http.MethodPut, ticketPrefix+tk.ID+"/escalate", map[string]string{"tier": "L1"}, http.StatusConflict, nil)
+	do(http.MethodPut, ticketPrefix+tk.ID+"/escalate", map[string]string{"tier": "L9"}, http.StatusBadRequest, nil)
+	do(http.MethodPut, ticketPrefix+tk.ID+"/deescalate", nil, http.StatusOK, &tk)
+	if tk.Tier != "L1" || tk.Status != "assigned" {
+		t.Fatalf("after deescalate: %+v", tk)
+	}
+	do(http.MethodPut, ticketPrefix+tk.ID+"/deescalate", nil, http.StatusConflict, nil)
+
+	var report struct {
+		Rows []struct {
+			Tier           string  `json:"tier"`
+			Entered        int     `json:"entered"`
+			Escalated      int     `json:"escalated"`
+			EscalationRate float64 `json:"escalation_rate"`
+		} `json:"rows"`
+		TotalEscalations int `json:"total_escalations"`
+	}
+	do(http.MethodGet, "/v1/reports/escalations", nil, http.StatusOK, &report)
+	if report.TotalEscalations != 1 || len(report.Rows) != 2 || report.Rows[0].Tier != "L1" || report.Rows[0].EscalationRate != 0.5 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+}
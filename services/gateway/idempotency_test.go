@@ -0,0 +1,70 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+// Idempotency-Key on ticket and doc creation (port 18218).
+
+func postWithKey(t *testing.T, url, key string, body map[string]any, want int, out any) {
+	t.Helper()
+	b, _ := json.Marshal(body)
+	req, _ := http.NewRequest(http.MethodPost, url, bytes.NewReader(b))
+	req.Header.Set(headerContentTypeTest, contentTypeJSON)
+	req.Header.Set("Idempotency-Key", key)
+	doJSON(t, req, want, out)
+}
+
+func TestIdempotencyKey(t *testing.T) { // :18218
+	setupOnce(t)
+	base, stop := buildServer(t, ":18218")
+	defer stop()
+
+	body := map[string]any{"title": "idem: printer on fire", "desc": "third floor"}
+	var first, retry createOut
+	postWithKey(t, base+pathTickets, "tk-1", body, http.StatusCreated, &first)
+	postWithKey(t, base+pathTickets, "tk-1", body, http.StatusCreated, &retry)
+	if first.ID == "" || retry.ID != first.ID {
+		t.Fatalf("retry created a new ticket: %s vs %s", first.ID, retry.ID)
+	}
+	body["desc"] = "fourth floor"
+	postWithKey(t, base+pathTickets, "tk-1", body, http.StatusConflict, nil)
+	var other createOut
+	postWithKey(t, base+pathTickets, "tk-2", body, http.StatusCreated, &other)
+	if other.ID == first.ID {
+		t.Fatal("a new key must create a new ticket")
+	}
+	// the same key from another tenant is an unrelated request
+	b, _ := json.Marshal(body)
+	req, _ := http.NewRequest(http.MethodPost, base+pathTickets, bytes.NewReader(b))
+	req.Header.Set(headerContentTypeTest, contentTypeJSON)
+	req.Header.Set("Idempotency-Key", "tk-1")
+	doJSON(t, asTenant(req, "acme"), http.StatusCreated, nil)
+
+	doc := map[string]any{"title": "idem doc", "content": "v1"}
+	var d1, d2 struct {
+		ID string `json:"id"`
+	}
+	postWithKey(t, base+"/v1/docs", "doc-1", doc, http.StatusCreated, &d1)
+	postWithKey(t, base+"/v1/docs", "doc-1", doc, http.StatusCreated, &d2)
+	if d1.ID == "" || d2.ID != d1.ID {
+		t.Fatalf("doc retry created a new doc: %s vs %s", d1.ID, d2.ID)
+	}
+	// another user's key is theirs alone
+	b, _ = json.Marshal(doc)
+	req, _ = http.NewRequest(http.MethodPost, base+"/v1/docs", bytes.NewReader(b))
+	req.Header.Set(headerContentTypeTest, contentTypeJSON)
+	req.Header.Set("Idempotency-Key", "doc-1")
+	var d3 struct {
+		ID string `json:"id"`
+	}
+	doJSON(t, asUser(req, "someone-else"), http.StatusCreated, &d3)
+	if d3.ID == "" || d3.ID == d1.ID {
+		t.Fatalf("another user's retry returned doc %s", d3.ID)
+	}
+	doc["content"] = "v2"
+	postWithKey(t, base+"/v1/docs", "doc-1", doc, http.StatusConflict, nil)
+}
@@ -48,6 +48,12 @@ func MapServiceError(ctx *app.RequestContext, err error) bool {
 			status = http.StatusConflict
 		case "kb_unavailable":
 			status = http.StatusServiceUnavailable
+		case "unauthorized":
+			status = http.StatusUnauthorized
+		case "forbidden":
+			status = http.StatusForbidden
+		case "quota_exceeded":
+			status = http.StatusTooManyRequests
 		}
 		HTTPError(ctx, status, codeStr, se.GetMessage())
 		return true
@@ -40,7 +40,10 @@ func RegisterAIRPC(h *server.Hertz, deps DepsAI) {
 		}
 		r, err := cli.Embeddings(c, &commonidl.EmbeddingRequest{Texts: req.Texts, Dim: req.Dim})
 		if err != nil || r == nil {
-			gwerrors.HTTPError(ctx, http.StatusInternalServerError, common.ErrCodeInternal, gwerrors.MsgInternal)
+			// quota_exceeded -> 429; anything else -> 500
+			if !gwerrors.MapServiceError(ctx, err) {
+				gwerrors.HTTPError(ctx, http.StatusInternalServerError, common.ErrCodeInternal, gwerrors.MsgInternal)
+			}
 			return
 		}
 		observability.AIEmbeddingCalls.Add(1)
@@ -62,7 +65,8 @@ func RegisterAIRPC(h *server.Hertz, deps DepsAI) {
 		}
 		// We need direct streaming only if AI provider is openai; otherwise emulate with one-shot.
 		provider := chain.DetectProvider()
-		if provider != chain.ProviderOpenAI {
+		// non-default tenants always go through ai-rpc so their provider config and quotas apply
+		if provider != chain.ProviderOpenAI || common.TenantFromContext(c) != common.DefaultTenant {
 			// fallback single response
 			// Convert to RPC Chat
 			cr := make([]*aidl.ChatMessage, 0, len(req.Messages))
@@ -71,7 +75,9 @@ func RegisterAIRPC(h *server.Hertz, deps DepsAI) {
 			}
 			r, err := cli.Chat(c, &aidl.ChatRequest{Messages: cr})
 			if err != nil || r == nil || r.Message == nil {
-				gwerrors.HTTPError(ctx, http.StatusInternalServerError, common.ErrCodeInternal, gwerrors.MsgInternal)
+				if !gwerrors.MapServiceError(ctx, err) {
+					gwerrors.HTTPError(ctx, http.StatusInternalServerError, common.ErrCodeInternal, gwerrors.MsgInternal)
+				}
 				return
 			}
 			ctx.JSON(http.StatusOK, map[string]string{"role": r.Message.Role, "content": r.Message.Content, "provider": provider})
@@ -2,6 +2,7 @@ package router
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -10,6 +11,7 @@ import (
 
 	"github.com/gogogo1024/assist-fusion/internal/common"
 	"github.com/gogogo1024/assist-fusion/internal/observability"
+	kcommon "github.com/gogogo1024/assist-fusion/kitex_gen/common"
 	kb "github.com/gogogo1024/assist-fusion/kitex_gen/kb"
 	kbcli "github.com/gogogo1024/assist-fusion/kitex_gen/kb/kbservice"
 	gwerrors "github.com/gogogo1024/assist-fusion/services/gateway/internal/errors"
@@ -22,6 +24,7 @@ func RegisterKBRPC(h *server.Hertz, deps DepsKB) {
 	cli := deps.KBClient()
 	registerKBDocCRUD(h, cli)
 	registerKBSearch(h, cli)
+	registerVectorSearch(h, cli)
 	registerKBInfo(h, cli)
 }
 
@@ -32,14 +35,21 @@ func registerKBDocCRUD(h *server.Hertz, cli kbcli.Client) {
 			gwerrors.HTTPError(ctx, http.StatusBadRequest, common.ErrCodeBadRequest, gwerrors.MsgBadRequest)
 			return
 		}
+		if key := string(ctx.Request.Header.Peek(HeaderIdempotencyKey)); key != "" {
+			req.IdempotencyKey = &key
+		}
 		resp, err := cli.AddDoc(c, &req)
+		var se *kcommon.ServiceError
+		if errors.As(err, &se) && se.Code != common.ErrCodeKBUnavailable {
+			// reused idempotency key (409) or invalid key (400)
+			gwerrors.MapServiceError(ctx, se)
+			return
+		}
 		if err != nil || resp == nil || resp.Id == "" {
 			gwerrors.HTTPError(ctx, http.StatusServiceUnavailable, common.ErrCodeKBUnavailable, gwerrors.MsgKBUnavailable)
 			return
 		}
 		observability.KBDocCreated.Add(1)
-		// async best-effort embedding upsert
-		go UpsertDocEmbedding(c, resp.Id, req.Title, req.Content)
 		ctx.JSON(http.StatusCreated, map[string]any{"id": resp.Id})
 	})
 
@@ -50,8 +60,9 @@ func registerKBDocCRUD(h *server.Hertz, cli kbcli.Client) {
 			return
 		}
 		var patch struct {
-			Title   *string `json:"title"`
-			Content *string `json:"content"`
+			Title   *string            `json:"title"`
+			Content *string            `json:"content"`
+			Tags    *map[string]string `json:"tags"`
 		}
 		if b := ctx.Request.Body(); len(b) > 0 {
 			if err := ctx.Bind(&patch); err != nil {
@@ -66,19 +77,72 @@ func registerKBDocCRUD(h *server.Hertz, cli kbcli.Client) {
 		if patch.Content != nil {
 			req.Content = patch.Content
 		}
+		if patch.Tags != nil {
+			// an explicit empty object clears the tags
+			req.Tags = *patch.Tags
+		}
 		if req.Title == nil || *req.Title == "" {
 			gwerrors.HTTPError(ctx, http.StatusBadRequest, common.ErrCodeBadRequest, gwerrors.MsgBadRequest)
 			return
 		}
 		if _, err := cli.UpdateDoc(c, req); err != nil {
+			var se *kcommon.ServiceError
+			if errors.As(err, &se) && se.Code == common.ErrCodeBadRequest {
+				gwerrors.MapServiceError(ctx, se)
+				return
+			}
 			gwerrors.HTTPError(ctx, http.StatusInternalServerError, common.ErrCodeInternal, gwerrors.MsgInternal)
 			return
 		}
 		observability.KBDocUpdated.Add(1)
-		go UpsertDocEmbedding(c, id, derefOr(patch.Title), derefOr(patch.Content))
 		ctx.JSON(http.StatusOK, map[string]any{"id": id})
 	})
 
+	h.GET(PathDocID, func(c context.Context, ctx *app.RequestContext) {
+		doc, err := cli.GetDoc(c, &kb.GetDocRequest{Id: string(ctx.Param("id"))})
+		if err != nil {
+			gwerrors.MapServiceError(ctx, err)
+			return
+		}
+		ctx.JSON(http.StatusOK, doc)
+	})
+
+	// GET /v1/docs?limit=20&order=desc&tag=...&cursor=... pages by updated time
+	h.GET(PathDocs, func(c context.Context, ctx *app.RequestContext) {
+		req := &kb.ListDocsRequest{}
+		if v := ctx.Query("limit"); len(v) > 0 {
+			n, err := strconv.Atoi(string(v))
+			if err != nil || n <= 0 {
+				gwerrors.HTTPError(ctx, http.StatusBadRequest, common.ErrCodeBadRequest, gwerrors.MsgBadRequest)
+				return
+			}
+			limit := int32(min(n, 100))
+			req.Limit = &limit
+		}
+		for _, v := range ctx.QueryArgs().PeekAll("tag") {
+			req.Tags = append(req.Tags, string(v))
+		}
+		if v := string(ctx.Query("tag_mode")); v != "" {
+			req.TagMode = &v
+		}
+		if v := string(ctx.Query("order")); v != "" {
+			req.Order = &v
+		}
+		if v := string(ctx.Query("cursor")); v != "" {
+			req.Cursor = &v
+		}
+		resp, err := cli.ListDocs(c, req)
+		if err != nil {
+			gwerrors.MapServiceError(ctx, err)
+			return
+		}
+		body := map[string]any{"docs": resp.Docs, "total": resp.Total}
+		if resp.NextCursor != nil {
+			body["next_cursor"] = *resp.NextCursor
+		}
+		ctx.JSON(http.StatusOK, body)
+	})
+
 	h.DELETE(PathDocID, func(c context.Context, ctx *app.RequestContext) {
 		id := string(ctx.Param("id"))
 		if id == "" {
@@ -90,7 +154,6 @@ func registerKBDocCRUD(h *server.Hertz, cli kbcli.Client) {
 			return
 		}
 		observability.KBDocDeleted.Add(1)
-		go DeleteDocEmbedding(id)
 		ctx.JSON(http.StatusNoContent, nil)
 	})
 }
@@ -121,7 +184,58 @@ func registerKBSearch(h *server.Hertz, cli kbcli.Client) {
 		if offset != nil {
 			req.Offset = offset
 		}
+		// ?tag=product:vpn&tag=lang:zh requires both; tag_mode=any requires one
+		for _, v := range ctx.QueryArgs().PeekAll("tag") {
+			req.Tags = append(req.Tags, string(v))
+		}
+		if v := string(ctx.Query("tag_mode")); v != "" {
+			req.TagMode = &v
+		}
+		// a bare ?cursor= starts cursor pagination; later pages pass next_cursor back
+		if v, ok := ctx.GetQuery("cursor"); ok {
+			req.Cursor = &v
+		}
+		// mode=vector|hybrid; fusion, weights and rrf_k tune the hybrid ranking
+		if v := string(ctx.Query("mode")); v != "" {
+			req.Mode = &v
+		}
+		if v := string(ctx.Query("fusion")); v != "" {
+			req.Fusion = &v
+		}
+		for name, dst := range map[string]**float64{"lexical_weight": &req.LexicalWeight, "vector_weight": &req.VectorWeight} {
+			if v, ok := ctx.GetQuery(name); ok {
+				f, err := strconv.ParseFloat(v, 64)
+				if err != nil {
+					gwerrors.HTTPError(ctx, http.StatusBadRequest, common.ErrCodeBadRequest, name+" must be a number")
+					return
+				}
+				*dst = &f
+			}
+		}
+		// snippet_length and fragments shape the snippet; pre_tag/post_tag wrap its matches
+		// instead of returning highlights
+		for name, dst := range map[string]**int32{"rrf_k": &req.RrfK, "snippet_length": &req.SnippetLength, "fragments": &req.Fragments} {
+			if v, ok := ctx.GetQuery(name); ok {
+				n, err := strconv.ParseInt(v, 10, 32)
+				if err != nil {
+					gwerrors.HTTPError(ctx, http.StatusBadRequest, common.ErrCodeBadRequest, name+" must be an integer")
+					return
+				}
+				k := int32(n)
+				*dst = &k
+			}
+		}
+		for name, dst := range map[string]**string{"pre_tag": &req.PreTag, "post_tag": &req.PostTag} {
+			if v, ok := ctx.GetQuery(name); ok {
+				*dst = &v
+			}
+		}
 		resp, err := cli.Search(c, req)
+		var se *kcommon.ServiceError
+		if errors.As(err, &se) {
+			gwerrors.MapServiceError(ctx, se)
+			return
+		}
 		if err != nil || resp == nil {
 			gwerrors.HTTPError(ctx, http.StatusServiceUnavailable, common.ErrCodeKBUnavailable, gwerrors.MsgKBUnavailable)
 			return
@@ -136,6 +250,9 @@ func registerKBSearch(h *server.Hertz, cli kbcli.Client) {
 		if resp.NextOffset != nil {
 			body["next_offset"] = *resp.NextOffset
 		}
+		if resp.NextCursor != nil {
+			body["next_cursor"] = *resp.NextCursor
+		}
 		ctx.JSON(http.StatusOK, body)
 	})
 }
@@ -154,10 +271,3 @@ func registerKBInfo(h *server.Hertz, cli kbcli.Client) {
 		ctx.JSON(http.StatusOK, body)
 	})
 }
-
-func derefOr(p *string) string {
-	if p != nil {
-		return *p
-	}
-	return ""
-}
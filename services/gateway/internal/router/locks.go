@@ -0,0 +1,163 @@
+package router
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/app/server"
+	"github.com/cloudwego/hertz/pkg/protocol/http1/resp"
+
+	"github.com/gogogo1024/assist-fusion/internal/gateway"
+	kcommon "github.com/gogogo1024/assist-fusion/kitex_gen/common"
+	"github.com/gogogo1024/assist-fusion/kitex_gen/ticket"
+	gwerrors "github.com/gogogo1024/assist-fusion/services/gateway/internal/errors"
+)
+
+// Event stream tuning: how often ticket-rpc is polled for new lock events, how often an
+// idle stream sends a keep-alive comment, and how long one connection lives before the
+// client reconnects (resuming from Last-Event-ID).
+const (
+	streamPollInterval = 500 * time.Millisecond
+	streamKeepAlive    = 15 * time.Second
+	streamMaxLifetime  = 30 * time.Minute
+)
+
+type lockJSON struct {
+	TicketID   string     `json:"ticket_id"`
+	Holder     *actorJSON `json:"holder,omitempty"`
+	AcquiredAt int64      `json:"acquired_at"`
+	ExpiresAt  int64      `json:"expires_at"`
+}
+
+func normalizeLock(l *kcommon.EditLock) *lockJSON {
+	if l == nil {
+		return nil
+	}
+	out := &lockJSON{TicketID: l.TicketId, AcquiredAt: l.AcquiredAt, ExpiresAt: l.ExpiresAt}
+	if l.Holder != nil {
+		out.Holder = &actorJSON{ID: l.Holder.Id, Kind: l.Holder.Kind, Name: l.Holder.DisplayName}
+	}
+	return out
+}
+
+type lockEventJSON struct {
+	Seq      int64      `json:"seq"`
+	Type     string     `json:"type"`
+	TicketID string     `json:"ticket_id"`
+	Lock     *lockJSON  `json:"lock,omitempty"`
+	Actor    *actorJSON `json:"actor,omitempty"`
+	At       int64      `json:"at"`
+}
+
+func normalizeLockEvent(e *ticket.LockEvent) *lockEventJSON {
+	out := &lockEventJSON{Seq: e.Seq, Type: e.Type, TicketID: e.TicketId, Lock: normalizeLock(e.Lock), At: e.At}
+	if e.Actor != nil {
+		out.Actor = &actorJSON{ID: e.Actor.Id, Kind: e.Actor.Kind, Name: e.Actor.DisplayName}
+	}
+	return out
+}
+
+// registerTicketLocks sets up edit locks and the server-sent event stream that pushes their changes.
+func registerTicketLocks(h *server.Hertz, api gateway.TicketAPI) {
+	// POST acquires the lock, or renews it when the caller already holds it (heartbeat)
+	h.POST(PathTicketLock, func(c context.Context, ctx *app.RequestContext) {
+		if !requireUser(c, ctx) {
+			return
+		}
+		l, err := api.AcquireLock(c, string(ctx.Param("id")))
+		if err != nil {
+			gwerrors.MapServiceError(ctx, err)
+			return
+		}
+		ctx.JSON(http.StatusOK, map[string]any{"lock": normalizeLock(l)})
+	})
+	// ?force=1 lets a supervisor (X-User-Roles) drop another agent's lock
+	h.DELETE(PathTicketLock, func(c context.Context, ctx *app.RequestContext) {
+		if !requireUser(c, ctx) {
+			return
+		}
+		force := string(ctx.Query("force")) == "1" || string(ctx.Query("force")) == "true"
+		if err := api.ReleaseLock(c, string(ctx.Param("id")), force); err != nil {
+			gwerrors.MapServiceError(ctx, err)
+			return
+		}
+		ctx.Status(http.StatusNoContent)
+	})
+	h.GET(PathEventStream, func(c context.Context, ctx *app.RequestContext) {
+		ticketID := string(ctx.Query("ticket_id"))
+		// a reconnecting EventSource resumes after the last event it saw
+		after := int64(-1)
+		if v, err := strconv.ParseInt(string(ctx.Request.Header.Peek("Last-Event-ID")), 10, 64); err == nil && v >= 0 {
+			after = v
+		}
+		first, err := api.LockEvents(c, after, ticketID)
+		if err != nil {
+			gwerrors.MapServiceError(ctx, err)
+			return
+		}
+		streamLockEvents(c, ctx, api, ticketID, first)
+	})
+}
+
+func streamLockEvents(c context.Context, ctx *app.RequestContext, api gateway.TicketAPI, ticketID string, first *ticket.ListLockEventsResponse) {
+	ctx.SetStatusCode(http.StatusOK)
+	ctx.SetContentType("text/event-stream")
+	ctx.Response.Header.Set("Cache-Control", "no-cache")
+	ctx.Response.HijackWriter(resp.NewChunkedBodyWriter(&ctx.Response, ctx.GetWriter()))
+	send := func(chunk string) bool {
+		if _, err := ctx.Write([]byte(chunk)); err != nil {
+			return false
+		}
+		return ctx.Flush() == nil
+	}
+	emit := func(evs []*ticket.LockEvent) bool {
+		for _, e := range evs {
+			b, _ := json.Marshal(normalizeLockEvent(e))
+			if !send(fmt.Sprintf("id: %d\nevent: lock\ndata: %s\n\n", e.Seq, b)) {
+				return false
+			}
+		}
+		return true
+	}
+	// the retry hint and the backlog go out immediately so clients see the stream is live
+	if !send(fmt.Sprintf("retry: %d\n\n", streamPollInterval.Milliseconds()*4)) || !emit(first.Events) {
+		return
+	}
+	cursor := first.LastSeq
+	poll := time.NewTicker(streamPollInterval)
+	defer poll.Stop()
+	idle := time.Now()
+	deadline := time.After(streamMaxLifetime)
+	for {
+		select {
+		case <-c.Done():
+			return
+		case <-deadline:
+			return
+		case <-poll.C:
+		}
+		r, err := api.LockEvents(c, cursor, ticketID)
+		if err != nil {
+			log.Printf("lock event poll: %v", err)
+			continue
+		}
+		if len(r.Events) > 0 {
+			if !emit(r.Events) {
+				return
+			}
+			idle = time.Now()
+		} else if time.Since(idle) >= streamKeepAlive {
+			if !send(": keep-alive\n\n") {
+				return
+			}
+			idle = time.Now()
+		}
+		cursor = r.LastSeq
+	}
+}
@@ -0,0 +1,147 @@
+package router
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/app/server"
+
+	"github.com/gogogo1024/assist-fusion/internal/common"
+	"github.com/gogogo1024/assist-fusion/internal/gateway"
+	kcommon "github.com/gogogo1024/assist-fusion/kitex_gen/common"
+	"github.com/gogogo1024/assist-fusion/kitex_gen/ticket"
+	gwerrors "github.com/gogogo1024/assist-fusion/services/gateway/internal/errors"
+)
+
+// registerTicketWatchers sets up subscribe/unsubscribe. Without ?user_id= the caller is used.
+func registerTicketWatchers(h *server.Hertz, api gateway.TicketAPI) {
+	watch := func(fn func(context.Context, string, string) (*kcommon.Ticket, error)) app.HandlerFunc {
+		return func(c context.Context, ctx *app.RequestContext) {
+			userID := string(ctx.Query("user_id"))
+			if userID == "" && !requireUser(c, ctx) {
+				return
+			}
+			t, err := fn(c, string(ctx.Param("id")), userID)
+			if err != nil {
+				gwerrors.MapServiceError(ctx, err)
+				return
+			}
+			ctx.JSON(200, map[string]any{"id": t.Id, "watchers": t.Watchers})
+		}
+	}
+	h.POST(PathTicketWatchers, watch(api.Watch))
+	h.DELETE(PathTicketWatchers, watch(api.Unwatch))
+}
+
+// registerMyNotifications exposes the caller's in-app inbox and delivery preferences.
+func registerMyNotifications(h *server.Hertz, api gateway.TicketAPI) {
+	h.GET(PathMyNotifications, func(c context.Context, ctx *app.RequestContext) {
+		if !requireUser(c, ctx) {
+			return
+		}
+		unreadOnly := string(ctx.Query("unread")) == "1" || string(ctx.Query("unread")) == "true"
+		limit, _ := strconv.Atoi(string(ctx.Query("limit")))
+		r, err := api.Notifications(c, unreadOnly, int32(limit))
+		if err != nil {
+			gwerrors.MapServiceError(ctx, err)
+			return
+		}
+		items := make([]*notificationJSON, 0, len(r.Items))
+		for _, n := range r.Items {
+			items = append(items, normalizeNotification(n))
+		}
+		ctx.JSON(200, map[string]any{"items": items, "unread": r.Unread})
+	})
+	mark := func(read bool) app.HandlerFunc {
+		return func(c context.Context, ctx *app.RequestContext) {
+			if !requireUser(c, ctx) {
+				return
+			}
+			var req struct {
+				IDs []string `json:"ids"`
+				All bool     `json:"all"`
+			}
+			if err := ctx.Bind(&req); err != nil || (len(req.IDs) == 0 && !req.All) {
+				gwerrors.HTTPError(ctx, http.StatusBadRequest, "bad_request", gwerrors.MsgBadRequest)
+				return
+			}
+			r, err := api.MarkNotifications(c, req.IDs, req.All, read)
+			if err != nil {
+				gwerrors.MapServiceError(ctx, err)
+				return
+			}
+			ctx.JSON(200, map[string]any{"updated": r.Updated, "unread": r.Unread})
+		}
+	}
+	h.POST(PathMyNotificationsRead, mark(true))
+	h.POST(PathMyNotificationsUnread, mark(false))
+	h.GET(PathMyNotificationPrefs, func(c context.Context, ctx *app.RequestContext) {
+		if !requireUser(c, ctx) {
+			return
+		}
+		p, err := api.NotificationPrefs(c)
+		if err != nil {
+			gwerrors.MapServiceError(ctx, err)
+			return
+		}
+		ctx.JSON(200, normalizePrefs(p))
+	})
+	h.PUT(PathMyNotificationPrefs, func(c context.Context, ctx *app.RequestContext) {
+		if !requireUser(c, ctx) {
+			return
+		}
+		var req prefsJSON
+		if err := ctx.Bind(&req); err != nil {
+			gwerrors.HTTPError(ctx, http.StatusBadRequest, "bad_request", gwerrors.MsgBadRequest)
+			return
+		}
+		p, err := api.SetNotificationPrefs(c, &ticket.NotificationPrefs{Channels: req.Channels, Email: req.Email, WebhookUrl: req.WebhookURL})
+		if err != nil {
+			gwerrors.MapServiceError(ctx, err)
+			return
+		}
+		ctx.JSON(200, normalizePrefs(p))
+	})
+}
+
+// requireUser rejects requests without a principal; "me" endpoints have no meaning for anonymous callers.
+// The principal is the one PrincipalMiddleware resolved (the token's subject when a JWT secret is set).
+func requireUser(c context.Context, ctx *app.RequestContext) bool {
+	if a, _ := common.ActorFromContext(c); a.ID == anonymousActor.ID || a.Kind == common.ActorKindSystem {
+		gwerrors.HTTPError(ctx, http.StatusUnauthorized, "unauthorized", "user identity required")
+		return false
+	}
+	return true
+}
+
+type notificationJSON struct {
+	ID        string     `json:"id"`
+	TicketID  string     `json:"ticket_id"`
+	EventType string     `json:"event_type"`
+	Role      string     `json:"role"`
+	Subject   string     `json:"subject"`
+	Body      string     `json:"body"`
+	CreatedAt int64      `json:"created_at"`
+	Read      bool       `json:"read"`
+	Actor     *actorJSON `json:"actor,omitempty"`
+}
+
+func normalizeNotification(n *ticket.Notification) *notificationJSON {
+	out := &notificationJSON{ID: n.Id, TicketID: n.TicketId, EventType: n.EventType, Role: n.Role, Subject: n.Subject, Body: n.Body, CreatedAt: n.CreatedAt, Read: n.Read_}
+	if n.Actor != nil {
+		out.Actor = &actorJSON{ID: n.Actor.Id, Kind: n.Actor.Kind, Name: n.Actor.DisplayName}
+	}
+	return out
+}
+
+type prefsJSON struct {
+	Channels   []string `json:"channels"`
+	Email      string   `json:"email"`
+	WebhookURL string   `json:"webhook_url"`
+}
+
+func normalizePrefs(p *ticket.NotificationPrefs) prefsJSON {
+	return prefsJSON{Channels: p.Channels, Email: p.Email, WebhookURL: p.WebhookUrl}
+}
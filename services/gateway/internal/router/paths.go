@@ -1,19 +1,49 @@
 package router
 
+// HeaderIdempotencyKey makes POST /v1/tickets and POST /v1/docs safe to retry.
+const HeaderIdempotencyKey = "Idempotency-Key"
+
 // Path constants centralizing HTTP routes.
 const (
-	PathTickets        = "/v1/tickets"
-	PathTicketID       = "/v1/tickets/:id"
-	PathTicketAssign   = "/v1/tickets/:id/assign"
-	PathTicketResolve  = "/v1/tickets/:id/resolve"
-	PathTicketEscalate = "/v1/tickets/:id/escalate"
-	PathTicketStart    = "/v1/tickets/:id/start"
-	PathTicketWait     = "/v1/tickets/:id/wait"
-	PathTicketClose    = "/v1/tickets/:id/close"
-	PathTicketCancel   = "/v1/tickets/:id/cancel"
-	PathTicketReopen   = "/v1/tickets/:id/reopen"
-	PathTicketCycles   = "/v1/tickets/:id/cycles"
-	PathTicketEvents   = "/v1/tickets/:id/events"
+	PathTickets          = "/v1/tickets"
+	PathTicketID         = "/v1/tickets/:id"
+	PathTicketAssign     = "/v1/tickets/:id/assign"
+	PathTicketResolve    = "/v1/tickets/:id/resolve"
+	PathTicketEscalate   = "/v1/tickets/:id/escalate"
+	PathTicketDeescalate = "/v1/tickets/:id/deescalate"
+	PathTicketStart      = "/v1/tickets/:id/start"
+	PathTicketWait       = "/v1/tickets/:id/wait"
+	PathTicketClose      = "/v1/tickets/:id/close"
+	PathTicketCancel     = "/v1/tickets/:id/cancel"
+	PathTicketReopen     = "/v1/tickets/:id/reopen"
+	PathTicketCycles     = "/v1/tickets/:id/cycles"
+	PathTicketEvents     = "/v1/tickets/:id/events"
+	PathSurvey           = "/v1/surveys/:token"
+	PathCSATReport       = "/v1/reports/csat"
+	PathEscalationReport = "/v1/reports/escalations"
+	PathTicketWatchers   = "/v1/tickets/:id/watchers"
+	PathTicketComments   = "/v1/tickets/:id/comments"
+	PathTicketRestore    = "/v1/tickets/:id/restore"
+	PathTicketArchive    = "/v1/tickets/:id/archive"
+	PathTicketUnarchive  = "/v1/tickets/:id/unarchive"
+	PathRetentionRun     = "/v1/retention/run"
+
+	PathSubjectExport  = "/v1/subjects/:subject/export"
+	PathSubjectErase   = "/v1/subjects/:subject/erase"
+	PathErasureReceipt = "/v1/erasure-receipts/:id"
+
+	PathQueueNext   = "/v1/queue/next"
+	PathTicketLock  = "/v1/tickets/:id/lock"
+	PathEventStream = "/v1/events/stream"
+
+	PathViews       = "/v1/views"
+	PathViewID      = "/v1/views/:id"
+	PathViewTickets = "/v1/views/:id/tickets"
+
+	PathMyNotifications       = "/v1/me/notifications"
+	PathMyNotificationsRead   = "/v1/me/notifications/read"
+	PathMyNotificationsUnread = "/v1/me/notifications/unread"
+	PathMyNotificationPrefs   = "/v1/me/notification-preferences"
 
 	PathDocs         = "/v1/docs"
 	PathDocID        = "/v1/docs/:id"
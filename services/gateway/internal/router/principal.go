@@ -0,0 +1,132 @@
+package router
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/app"
+
+	"github.com/gogogo1024/assist-fusion/internal/common"
+	gwerrors "github.com/gogogo1024/assist-fusion/services/gateway/internal/errors"
+)
+
+// Principal headers are set by the upstream auth proxy; the gateway trusts them as-is, so the
+// proxy must strip them from client requests. With a JWT secret configured they are ignored:
+// identity, kind and roles come from the verified token only (see principalFromToken).
+const (
+	HeaderUserID    = "X-User-ID"
+	HeaderUserName  = "X-User-Name"
+	HeaderActorKind = "X-Actor-Kind" // user (default) | automation
+	HeaderTeamIDs   = "X-Team-IDs"   // comma separated teams the user belongs to (shared views)
+	HeaderUserRoles = "X-User-Roles" // comma separated roles, e.g. supervisor
+)
+
+// anonymousActor marks requests that reached the gateway without a principal (e.g. public survey links).
+var anonymousActor = common.Actor{ID: "anonymous", Kind: common.ActorKindUser, Name: "anonymous"}
+
+// JWT claims describing the caller when a secret is configured.
+const (
+	subjectClaim   = "sub"        // actor id
+	nameClaim      = "name"       // display name (defaults to the id)
+	actorKindClaim = "actor_kind" // "automation" marks integrations; anything else is a user
+	rolesClaim     = "roles"      // an array or a comma separated string
+)
+
+// PrincipalMiddleware lifts the caller identity into the request context so Kitex clients
+// forward it to ticket-rpc (metainfo over TTHeader) for event attribution. With a non-empty
+// secret the principal is read from the signed bearer token only.
+func PrincipalMiddleware(secret []byte) app.HandlerFunc {
+	return func(c context.Context, ctx *app.RequestContext) {
+		actor, roles := principalFromHeaders(ctx), headerList(ctx, HeaderUserRoles)
+		if len(secret) > 0 {
+			actor, roles = principalFromToken(ctx, secret)
+		}
+		c = common.WithActor(c, actor, common.SourceGateway)
+		c = common.WithTeams(c, headerList(ctx, HeaderTeamIDs))
+		ctx.Next(common.WithRoles(c, roles))
+	}
+}
+
+// principalFromToken reads the caller from the verified bearer token: "sub" is the actor id
+// and "roles" its roles. The actor is automation only when the token says so, through the
+// "actor_kind" claim or the automation role. Without a valid token, or without a subject,
+// the request is anonymous; X-User-ID, X-Actor-Kind and X-User-Roles are never consulted.
+func principalFromToken(ctx *app.RequestContext, secret []byte) (common.Actor, []string) {
+	token, ok := strings.CutPrefix(string(ctx.Request.Header.Peek("Authorization")), "Bearer ")
+	if !ok {
+		return anonymousActor, nil
+	}
+	claims, err := tokenClaims(strings.TrimSpace(token), secret, time.Now())
+	if err != nil {
+		return anonymousActor, nil
+	}
+	var roles []string
+	switch v := claims[rolesClaim].(type) {
+	case string:
+		roles = splitList(v)
+	case []any:
+		for _, r := range v {
+			if r, ok := r.(string); ok && strings.TrimSpace(r) != "" {
+				roles = append(roles, strings.TrimSpace(r))
+			}
+		}
+	}
+	id, _ := claims[subjectClaim].(string)
+	if id = strings.TrimSpace(id); id == "" {
+		return anonymousActor, roles
+	}
+	a := common.Actor{ID: id, Kind: common.ActorKindUser, Name: id}
+	if n, _ := claims[nameClaim].(string); strings.TrimSpace(n) != "" {
+		a.Name = strings.TrimSpace(n)
+	}
+	if k, _ := claims[actorKindClaim].(string); k == common.ActorKindAutomation || slices.Contains(roles, common.ActorKindAutomation) {
+		a.Kind = common.ActorKindAutomation
+	}
+	return a, roles
+}
+
+func principalFromHeaders(ctx *app.RequestContext) common.Actor {
+	id := string(ctx.Request.Header.Peek(HeaderUserID))
+	if id == "" {
+		return anonymousActor
+	}
+	a := common.Actor{ID: id, Kind: common.ActorKindUser, Name: string(ctx.Request.Header.Peek(HeaderUserName))}
+	if a.Name == "" {
+		a.Name = id
+	}
+	// system is reserved for ticket-rpc internals; callers may only claim user or automation
+	if string(ctx.Request.Header.Peek(HeaderActorKind)) == common.ActorKindAutomation {
+		a.Kind = common.ActorKindAutomation
+	}
+	return a
+}
+
+// requireRole answers 403 and returns false unless the caller holds one of roles. Roles are
+// the ones PrincipalMiddleware verified (the token's claim when a JWT secret is configured).
+func requireRole(c context.Context, ctx *app.RequestContext, roles ...string) bool {
+	for _, r := range roles {
+		if common.HasRole(c, r) {
+			return true
+		}
+	}
+	gwerrors.HTTPError(ctx, http.StatusForbidden, common.ErrCodeForbidden, "requires role "+strings.Join(roles, " or "))
+	return false
+}
+
+// headerList splits a comma separated header, dropping empty entries.
+func headerList(ctx *app.RequestContext, name string) []string {
+	return splitList(string(ctx.Request.Header.Peek(name)))
+}
+
+func splitList(s string) []string {
+	var out []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
@@ -0,0 +1,47 @@
+package router
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/app/server"
+
+	"github.com/gogogo1024/assist-fusion/internal/common"
+	"github.com/gogogo1024/assist-fusion/internal/gateway"
+	gwerrors "github.com/gogogo1024/assist-fusion/services/gateway/internal/errors"
+)
+
+// registerPullQueue lets agents pull their next ticket instead of picking from a list.
+func registerPullQueue(h *server.Hertz, api gateway.TicketAPI) {
+	h.POST(PathQueueNext, func(c context.Context, ctx *app.RequestContext) {
+		if !requireUser(c, ctx) {
+			return
+		}
+		var req struct {
+			Skills []string `json:"skills"`
+			Tier   string   `json:"tier"`
+		}
+		if len(ctx.Request.Body()) > 0 {
+			if err := ctx.Bind(&req); err != nil {
+				gwerrors.HTTPError(ctx, http.StatusBadRequest, common.ErrCodeBadRequest, gwerrors.MsgBadRequest)
+				return
+			}
+		}
+		r, err := api.PullNext(c, req.Skills, req.Tier)
+		if err != nil {
+			gwerrors.MapServiceError(ctx, err)
+			return
+		}
+		if r.Ticket == nil {
+			// nothing eligible right now
+			ctx.Status(http.StatusNoContent)
+			return
+		}
+		ctx.JSON(http.StatusOK, map[string]any{
+			"ticket":           normalizeTicket(r.Ticket),
+			"score":            r.Score,
+			"claim_expires_at": r.ClaimExpiresAt,
+		})
+	})
+}
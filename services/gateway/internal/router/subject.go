@@ -0,0 +1,134 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/app/server"
+
+	"github.com/gogogo1024/assist-fusion/internal/common"
+	"github.com/gogogo1024/assist-fusion/internal/gateway"
+	"github.com/gogogo1024/assist-fusion/internal/privacy"
+	kcommon "github.com/gogogo1024/assist-fusion/kitex_gen/common"
+	"github.com/gogogo1024/assist-fusion/kitex_gen/ticket"
+	gwerrors "github.com/gogogo1024/assist-fusion/services/gateway/internal/errors"
+)
+
+// RegisterSubjectRPC exposes data subject access (export) and erasure across ticket-rpc and kb-rpc.
+// Both read or destroy another person's data tenant-wide, so they are limited to admins and supervisors.
+func RegisterSubjectRPC(h *server.Hertz, api gateway.TicketAPI, deps DepsKB) {
+	kbc := deps.KBClient()
+	h.GET(PathSubjectExport, func(c context.Context, ctx *app.RequestContext) {
+		if !requireRole(c, ctx, common.RoleAdmin, common.RoleSupervisor) {
+			return
+		}
+		subject := string(ctx.Param("subject"))
+		tr, err := api.ExportSubject(c, subject)
+		if err != nil {
+			gwerrors.MapServiceError(ctx, err)
+			return
+		}
+		kr, err := kbc.ExportSubject(c, &kcommon.SubjectRequest{Subject: subject})
+		if err != nil {
+			mapKBError(ctx, err)
+			return
+		}
+		tickets := make([]map[string]any, 0, len(tr.Tickets))
+		for _, st := range tr.Tickets {
+			tickets = append(tickets, map[string]any{"roles": st.Roles, "ticket": normalizeTicket(st.Ticket)})
+		}
+		notifications := make([]*notificationJSON, 0, len(tr.Notifications))
+		for _, n := range tr.Notifications {
+			notifications = append(notifications, normalizeNotification(n))
+		}
+		docs := make([]map[string]any, 0, len(kr.Docs))
+		for _, d := range kr.Docs {
+			docs = append(docs, map[string]any{"id": d.Id, "title": d.Title, "content": d.Content})
+		}
+		out := map[string]any{
+			"subject":       tr.Subject,
+			"generated_at":  time.Now().Unix(),
+			"tickets":       tickets,
+			"notifications": notifications,
+			"kb_docs":       docs,
+		}
+		if tr.Prefs != nil {
+			out["preferences"] = normalizePrefs(tr.Prefs)
+		}
+		ctx.JSON(http.StatusOK, out)
+	})
+	h.POST(PathSubjectErase, func(c context.Context, ctx *app.RequestContext) {
+		if !requireRole(c, ctx, common.RoleAdmin, common.RoleSupervisor) {
+			return
+		}
+		sub, err := privacy.NewSubject(string(ctx.Param("subject")))
+		if err != nil {
+			gwerrors.HTTPError(ctx, http.StatusBadRequest, common.ErrCodeBadRequest, err.Error())
+			return
+		}
+		req := &kcommon.SubjectRequest{Subject: sub.Key()}
+		// KB first: if it fails nothing has been erased yet and the request can simply be retried;
+		// kb-rpc re-embeds the redacted documents itself
+		kbSum, err := kbc.EraseSubject(c, req)
+		if err != nil {
+			mapKBError(ctx, err)
+			return
+		}
+		rc, err := api.EraseSubject(c, sub.Key(), []*kcommon.ErasureSummary{kbSum})
+		if err != nil {
+			gwerrors.MapServiceError(ctx, err)
+			return
+		}
+		ctx.JSON(http.StatusOK, normalizeReceipt(rc))
+	})
+	h.GET(PathErasureReceipt, func(c context.Context, ctx *app.RequestContext) {
+		rc, err := api.ErasureReceipt(c, string(ctx.Param("id")))
+		if err != nil {
+			gwerrors.MapServiceError(ctx, err)
+			return
+		}
+		ctx.JSON(http.StatusOK, normalizeReceipt(rc))
+	})
+}
+
+// mapKBError keeps kb-rpc's own error codes and treats transport failures as kb_unavailable.
+func mapKBError(ctx *app.RequestContext, err error) {
+	var se *kcommon.ServiceError
+	if errors.As(err, &se) {
+		gwerrors.MapServiceError(ctx, se)
+		return
+	}
+	gwerrors.HTTPError(ctx, http.StatusServiceUnavailable, common.ErrCodeKBUnavailable, gwerrors.MsgKBUnavailable)
+}
+
+type erasureServiceJSON struct {
+	Service string   `json:"service"`
+	Records []string `json:"records"`
+	Fields  int32    `json:"fields"`
+}
+
+type receiptJSON struct {
+	ID          string                `json:"id"`
+	SubjectHash string                `json:"subject_hash"`
+	Tenant      string                `json:"tenant"`
+	ErasedAt    int64                 `json:"erased_at"`
+	Services    []*erasureServiceJSON `json:"services"`
+	RequestedBy *actorJSON            `json:"requested_by,omitempty"`
+	KeyID       string                `json:"key_id,omitempty"`
+	Signature   string                `json:"signature,omitempty"`
+}
+
+func normalizeReceipt(rc *ticket.ErasureReceipt) *receiptJSON {
+	out := &receiptJSON{ID: rc.Id, SubjectHash: rc.SubjectHash, Tenant: rc.Tenant, ErasedAt: rc.ErasedAt, KeyID: rc.KeyId, Signature: rc.Signature,
+		Services: make([]*erasureServiceJSON, 0, len(rc.Services))}
+	for _, s := range rc.Services {
+		out.Services = append(out.Services, &erasureServiceJSON{Service: s.Service, Records: s.Records, Fields: s.Fields})
+	}
+	if a := rc.RequestedBy; a != nil {
+		out.RequestedBy = &actorJSON{ID: a.Id, Kind: a.Kind, Name: a.DisplayName}
+	}
+	return out
+}
@@ -0,0 +1,128 @@
+package router
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/app"
+
+	"github.com/gogogo1024/assist-fusion/internal/common"
+	gwerrors "github.com/gogogo1024/assist-fusion/services/gateway/internal/errors"
+)
+
+// HeaderTenantID selects the tenant when the request carries no bearer token claim. With a
+// JWT secret configured it is only accepted alongside a token carrying the same tenant.
+const HeaderTenantID = "X-Tenant-ID"
+
+// tenantClaim is the JWT claim holding the tenant id.
+const tenantClaim = "tenant_id"
+
+var (
+	errBadToken       = errors.New("invalid bearer token")
+	errTenantMismatch = errors.New("tenant header does not match token")
+	errNoTenantClaim  = errors.New("bearer token has no tenant claim")
+	errHeaderOnly     = errors.New("tenant header requires a bearer token")
+)
+
+// TenantMiddleware resolves the tenant of each request and scopes the context to it, so every
+// RPC made on its behalf is isolated (metainfo over TTHeader). The "tenant_id" claim of a
+// bearer JWT wins over X-Tenant-ID; without either the request belongs to the default tenant.
+// With a non-empty secret, tokens must carry a valid HS256 signature and a tenant claim, and
+// X-Tenant-ID alone is rejected; otherwise the token and header are assumed to have been
+// verified by the upstream auth proxy.
+func TenantMiddleware(secret []byte) app.HandlerFunc {
+	return func(c context.Context, ctx *app.RequestContext) {
+		tenant, err := tenantFromRequest(ctx, secret)
+		if err != nil {
+			gwerrors.HTTPError(ctx, http.StatusUnauthorized, common.ErrCodeUnauthorized, err.Error())
+			ctx.Abort()
+			return
+		}
+		if !common.ValidTenantID(tenant) {
+			gwerrors.HTTPError(ctx, http.StatusBadRequest, common.ErrCodeBadRequest, "invalid tenant id")
+			ctx.Abort()
+			return
+		}
+		ctx.Next(common.WithTenant(c, tenant))
+	}
+}
+
+func tenantFromRequest(ctx *app.RequestContext, secret []byte) (string, error) {
+	header := strings.TrimSpace(string(ctx.Request.Header.Peek(HeaderTenantID)))
+	auth := string(ctx.Request.Header.Peek("Authorization"))
+	if token, ok := strings.CutPrefix(auth, "Bearer "); ok {
+		claim, err := tenantFromToken(strings.TrimSpace(token), secret, time.Now())
+		if err != nil {
+			return "", err
+		}
+		if claim != "" {
+			if header != "" && header != claim {
+				return "", errTenantMismatch
+			}
+			return claim, nil
+		}
+		if len(secret) > 0 {
+			return "", errNoTenantClaim
+		}
+	}
+	if header != "" {
+		if len(secret) > 0 {
+			return "", errHeaderOnly
+		}
+		return header, nil
+	}
+	return common.DefaultTenant, nil
+}
+
+// tenantFromToken returns the tenant claim of a JWT ("" when the token has none).
+func tenantFromToken(token string, secret []byte, now time.Time) (string, error) {
+	claims, err := tokenClaims(token, secret, now)
+	if err != nil {
+		return "", err
+	}
+	tenant, _ := claims[tenantClaim].(string)
+	return tenant, nil
+}
+
+// tokenClaims decodes the claims of an unexpired JWT, checking its HS256 signature when
+// secret is set.
+func tokenClaims(token string, secret []byte, now time.Time) (map[string]any, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errBadToken
+	}
+	if len(secret) > 0 {
+		var hdr struct {
+			Alg string `json:"alg"`
+		}
+		raw, err := base64.RawURLEncoding.DecodeString(parts[0])
+		if err != nil || json.Unmarshal(raw, &hdr) != nil || hdr.Alg != "HS256" {
+			return nil, errBadToken
+		}
+		mac := hmac.New(sha256.New, secret)
+		mac.Write([]byte(parts[0] + "." + parts[1]))
+		sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+		if err != nil || !hmac.Equal(sig, mac.Sum(nil)) {
+			return nil, errBadToken
+		}
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, errBadToken
+	}
+	var claims map[string]any
+	if err := json.Unmarshal(raw, &claims); err != nil {
+		return nil, errBadToken
+	}
+	if exp, ok := claims["exp"].(float64); ok && now.Unix() >= int64(exp) {
+		return nil, errBadToken
+	}
+	return claims, nil
+}
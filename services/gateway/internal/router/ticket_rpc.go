@@ -2,6 +2,7 @@ package router
 
 import (
 	"context"
+	"strconv"
 	"strings"
 
 	"github.com/cloudwego/hertz/pkg/app"
@@ -9,9 +10,11 @@ import (
 
 	"net/http"
 
+	"github.com/gogogo1024/assist-fusion/internal/common"
 	"github.com/gogogo1024/assist-fusion/internal/gateway"
 	"github.com/gogogo1024/assist-fusion/internal/observability"
 	kcommon "github.com/gogogo1024/assist-fusion/kitex_gen/common"
+	"github.com/gogogo1024/assist-fusion/kitex_gen/ticket"
 	gwerrors "github.com/gogogo1024/assist-fusion/services/gateway/internal/errors"
 )
 
@@ -19,31 +22,68 @@ import (
 func RegisterTicketRPC(h *server.Hertz, api gateway.TicketAPI) {
 	registerTicketCRUD(h, api)
 	registerTicketActions(h, api)
+	registerTicketLifecycle(h, api)
 	registerTicketMeta(h, api)
+	registerTicketCSAT(h, api)
+	registerTicketWatchers(h, api)
+	registerTicketComments(h, api)
+	registerMyNotifications(h, api)
+	registerTicketViews(h, api)
+	registerPullQueue(h, api)
+	registerTicketLocks(h, api)
 }
 
 // registerTicketCRUD sets up create/list/get endpoints.
 func registerTicketCRUD(h *server.Hertz, api gateway.TicketAPI) {
 	h.POST(PathTickets, func(c context.Context, ctx *app.RequestContext) {
 		var req struct {
-			Title string `json:"title"`
-			Desc  string `json:"desc"`
-			Note  string `json:"note"`
+			Title    string `json:"title"`
+			Desc     string `json:"desc"`
+			Note     string `json:"note"`
+			Category string `json:"category"`
+			Customer string `json:"customer"`
+			// Priority is low|normal|high|urgent; DueAt (unix seconds) drives sla_status
+			Priority     string            `json:"priority"`
+			Tags         []string          `json:"tags"`
+			CustomFields map[string]string `json:"custom_fields"`
+			DueAt        int64             `json:"due_at"`
+			// StrictDuplicates rejects near-identical open tickets with 409
+			StrictDuplicates bool `json:"strict_duplicates"`
 		}
 		if err := ctx.Bind(&req); err != nil || req.Title == "" {
 			gwerrors.HTTPError(ctx, http.StatusBadRequest, "bad_request", gwerrors.MsgBadRequest)
 			return
 		}
-		t, err := api.Create(c, req.Title, req.Desc, req.Note)
+		resp, err := api.Create(c, gateway.CreateTicketInput{
+			Title: req.Title, Desc: req.Desc, Note: req.Note, Category: req.Category, Customer: req.Customer,
+			Priority: req.Priority, Tags: req.Tags, CustomFields: req.CustomFields, DueAt: req.DueAt,
+			StrictDuplicates: req.StrictDuplicates,
+			IdempotencyKey:   string(ctx.Request.Header.Peek(HeaderIdempotencyKey)),
+		})
 		if err != nil {
 			gwerrors.MapServiceError(ctx, err)
 			return
 		}
 		observability.TicketCreated.Add(1)
-		ctx.JSON(201, normalizeTicket(t))
+		out := normalizeTicket(resp.GetTicket())
+		if resp.PossibleDuplicates != nil {
+			out.PossibleDuplicates = make([]*duplicateJSON, 0, len(resp.PossibleDuplicates))
+			for _, d := range resp.PossibleDuplicates {
+				out.PossibleDuplicates = append(out.PossibleDuplicates, &duplicateJSON{ID: d.Id, Title: d.Title, Similarity: d.Similarity})
+			}
+		}
+		ctx.JSON(201, out)
 	})
 	h.GET(PathTickets, func(c context.Context, ctx *app.RequestContext) {
-		ts, err := api.List(c)
+		// ?include=archived[,deleted] reveals tickets hidden from the default view
+		var include []string
+		for _, v := range strings.Split(string(ctx.Query("include")), ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				include = append(include, v)
+			}
+		}
+		// ?tier=L2 shows one tier's queue
+		ts, err := api.List(c, gateway.ListFilter{Include: include, Tier: string(ctx.Query("tier"))})
 		if err != nil {
 			gwerrors.MapServiceError(ctx, err)
 			return
@@ -72,11 +112,57 @@ func registerTicketCRUD(h *server.Hertz, api gateway.TicketAPI) {
 // registerTicketActions sets up action endpoints (assign/resolve/escalate/reopen).
 func registerTicketActions(h *server.Hertz, api gateway.TicketAPI) {
 	h.PUT(PathTicketAssign, func(c context.Context, ctx *app.RequestContext) { ticketActionRPC(c, ctx, api.Assign) })
-	h.PUT(PathTicketResolve, func(c context.Context, ctx *app.RequestContext) { ticketActionRPC(c, ctx, api.Resolve) })
+	h.PUT(PathTicketResolve, func(c context.Context, ctx *app.RequestContext) {
+		resp, err := api.Resolve(c, string(ctx.Param("id")), bindActionInput(ctx))
+		if err != nil {
+			gwerrors.MapServiceError(ctx, err)
+			return
+		}
+		// only the resolve response carries the survey token; ticket reads never do
+		out := normalizeTicket(resp.GetTicket())
+		out.SurveyToken = resp.GetSurveyToken()
+		ctx.JSON(200, out)
+	})
 	h.PUT(PathTicketEscalate, func(c context.Context, ctx *app.RequestContext) { ticketActionRPC(c, ctx, api.Escalate) })
+	h.PUT(PathTicketDeescalate, func(c context.Context, ctx *app.RequestContext) { ticketActionRPC(c, ctx, api.Deescalate) })
 	h.PUT(PathTicketReopen, func(c context.Context, ctx *app.RequestContext) { ticketActionRPC(c, ctx, api.Reopen) })
 }
 
+// registerTicketLifecycle sets up soft delete / restore, archival and manual retention runs.
+func registerTicketLifecycle(h *server.Hertz, api gateway.TicketAPI) {
+	h.DELETE(PathTicketID, func(c context.Context, ctx *app.RequestContext) { ticketActionRPC(c, ctx, api.Delete) })
+	h.PUT(PathTicketRestore, func(c context.Context, ctx *app.RequestContext) { ticketActionRPC(c, ctx, api.Restore) })
+	h.PUT(PathTicketArchive, func(c context.Context, ctx *app.RequestContext) { ticketActionRPC(c, ctx, api.Archive) })
+	h.PUT(PathTicketUnarchive, func(c context.Context, ctx *app.RequestContext) { ticketActionRPC(c, ctx, api.Unarchive) })
+	h.POST(PathRetentionRun, func(c context.Context, ctx *app.RequestContext) {
+		// archives, anonymizes and purges tickets across the tenant: supervisors and admins only
+		if !requireRole(c, ctx, common.RoleAdmin, common.RoleSupervisor) {
+			return
+		}
+		// dry run unless the caller explicitly asks for changes
+		var req struct {
+			DryRun *bool `json:"dry_run"`
+		}
+		if b := ctx.Request.Body(); len(b) > 0 {
+			if err := ctx.Bind(&req); err != nil {
+				gwerrors.HTTPError(ctx, http.StatusBadRequest, "bad_request", gwerrors.MsgBadRequest)
+				return
+			}
+		}
+		dryRun := req.DryRun == nil || *req.DryRun
+		r, err := api.ApplyRetention(c, dryRun)
+		if err != nil {
+			gwerrors.MapServiceError(ctx, err)
+			return
+		}
+		actions := make([]map[string]any, 0, len(r.Actions))
+		for _, a := range r.Actions {
+			actions = append(actions, map[string]any{"ticket_id": a.TicketId, "action": a.Action, "policy": a.Policy, "since": a.Since})
+		}
+		ctx.JSON(200, map[string]any{"dry_run": r.DryRun, "ran_at": r.RanAt, "actions": actions})
+	})
+}
+
 // registerTicketMeta sets up informational endpoints (cycles / events)
 func registerTicketMeta(h *server.Hertz, api gateway.TicketAPI) {
 	h.GET(PathTicketCycles, func(c context.Context, ctx *app.RequestContext) {
@@ -91,15 +177,9 @@ func registerTicketMeta(h *server.Hertz, api gateway.TicketAPI) {
 		if tkt, gerr := api.Get(c, id); gerr == nil && tkt != nil && int(tkt.CurrentCycle) < len(cs) {
 			currentFromTicket = &tkt.CurrentCycle
 		}
-		cyclesOut := make([]map[string]any, 0, len(cs))
+		cyclesOut := make([]*cycleJSON, 0, len(cs))
 		for _, cy := range cs {
-			cyclesOut = append(cyclesOut, map[string]any{
-				"created_at":   cy.CreatedAt,
-				"assigned_at":  cy.AssignedAt,
-				"resolved_at":  cy.ResolvedAt,
-				"escalated_at": cy.EscalatedAt,
-				"status":       strings.ToLower(cy.Status.String()),
-			})
+			cyclesOut = append(cyclesOut, normalizeCycle(cy))
 		}
 		current := 0
 		if currentFromTicket != nil {
@@ -111,122 +191,307 @@ func registerTicketMeta(h *server.Hertz, api gateway.TicketAPI) {
 	})
 	h.GET(PathTicketEvents, func(c context.Context, ctx *app.RequestContext) {
 		id := string(ctx.Param("id"))
-		es, err := api.Events(c, id)
+		es, err := api.Events(c, id, gateway.EventFilter{ActorID: string(ctx.Query("actor")), ActorKind: string(ctx.Query("actor_kind"))})
 		if err != nil {
 			gwerrors.MapServiceError(ctx, err)
 			return
 		}
-		ctx.JSON(200, map[string]any{"events": es})
+		out := make([]*eventJSON, 0, len(es))
+		for _, e := range es {
+			out = append(out, normalizeEvent(e))
+		}
+		ctx.JSON(200, map[string]any{"events": out})
 	})
 }
 
-func ticketActionRPC(c context.Context, ctx *app.RequestContext, fn func(context.Context, string, string) (*kcommon.Ticket, error)) {
-	id := string(ctx.Param("id"))
+func ticketActionRPC(c context.Context, ctx *app.RequestContext, fn func(context.Context, string, gateway.ActionInput) (*kcommon.Ticket, error)) {
+	t, err := fn(c, string(ctx.Param("id")), bindActionInput(ctx))
+	if err != nil {
+		gwerrors.MapServiceError(ctx, err)
+		return
+	}
+	ctx.JSON(200, normalizeTicket(t))
+}
+
+// bindActionInput reads the optional JSON body shared by the lifecycle actions.
+func bindActionInput(ctx *app.RequestContext) gateway.ActionInput {
 	var req struct {
-		Note string `json:"note"`
+		Note     string `json:"note"`
+		Assignee string `json:"assignee"`
+		Tier     string `json:"tier"`
 	}
 	if b := ctx.Request.Body(); len(b) > 0 {
 		_ = ctx.Bind(&req)
 	}
-	t, err := fn(c, id, req.Note)
-	if err != nil {
-		gwerrors.MapServiceError(ctx, err)
-		return
+	return gateway.ActionInput{Note: req.Note, Assignee: req.Assignee, Tier: req.Tier}
+}
+
+// registerTicketComments sets up POST /v1/tickets/:id/comments.
+func registerTicketComments(h *server.Hertz, api gateway.TicketAPI) {
+	h.POST(PathTicketComments, func(c context.Context, ctx *app.RequestContext) {
+		var req struct {
+			Body string `json:"body"`
+		}
+		if err := ctx.Bind(&req); err != nil || strings.TrimSpace(req.Body) == "" {
+			gwerrors.HTTPError(ctx, http.StatusBadRequest, "bad_request", gwerrors.MsgBadRequest)
+			return
+		}
+		t, err := api.AddComment(c, string(ctx.Param("id")), req.Body)
+		if err != nil {
+			gwerrors.MapServiceError(ctx, err)
+			return
+		}
+		ctx.JSON(201, normalizeTicket(t))
+	})
+}
+
+// registerTicketCSAT sets up the public survey endpoint and the CSAT report.
+// The survey route carries no auth on purpose: the single-use token is the credential.
+func registerTicketCSAT(h *server.Hertz, api gateway.TicketAPI) {
+	h.POST(PathSurvey, func(c context.Context, ctx *app.RequestContext) {
+		token := string(ctx.Param("token"))
+		var req struct {
+			Rating  int32  `json:"rating"`
+			Comment string `json:"comment"`
+		}
+		if err := ctx.Bind(&req); err != nil || token == "" {
+			gwerrors.HTTPError(ctx, http.StatusBadRequest, "bad_request", gwerrors.MsgBadRequest)
+			return
+		}
+		r, err := api.SubmitSurvey(c, token, req.Rating, req.Comment)
+		if err != nil {
+			gwerrors.MapServiceError(ctx, err)
+			return
+		}
+		ctx.JSON(200, map[string]any{"ticket_id": r.TicketId, "cycle": r.Cycle, "reopened": r.Reopened})
+	})
+	h.GET(PathCSATReport, func(c context.Context, ctx *app.RequestContext) {
+		req := &ticket.CSATReportRequest{GroupBy: string(ctx.Query("group_by"))}
+		if req.GroupBy == "" {
+			req.GroupBy = "period"
+		}
+		if v := string(ctx.Query("period")); v != "" {
+			req.Period = &v
+		}
+		if v, ok := queryInt64(ctx, "from"); ok {
+			req.From = &v
+		}
+		if v, ok := queryInt64(ctx, "to"); ok {
+			req.To = &v
+		}
+		r, err := api.CSATReport(c, req)
+		if err != nil {
+			gwerrors.MapServiceError(ctx, err)
+			return
+		}
+		rows := make([]map[string]any, 0, len(r.Rows))
+		for _, row := range r.Rows {
+			rows = append(rows, map[string]any{
+				"key":             row.Key,
+				"responses":       row.Responses,
+				"average":         row.Average,
+				"satisfied_ratio": row.SatisfiedRatio,
+				"distribution":    row.Distribution,
+			})
+		}
+		ctx.JSON(200, map[string]any{"group_by": req.GroupBy, "rows": rows, "total_responses": r.TotalResponses, "average": r.Average})
+	})
+	h.GET(PathEscalationReport, func(c context.Context, ctx *app.RequestContext) {
+		req := &ticket.EscalationReportRequest{}
+		if v := string(ctx.Query("category")); v != "" {
+			req.Category = &v
+		}
+		if v, ok := queryInt64(ctx, "from"); ok {
+			req.From = &v
+		}
+		if v, ok := queryInt64(ctx, "to"); ok {
+			req.To = &v
+		}
+		r, err := api.EscalationReport(c, req)
+		if err != nil {
+			gwerrors.MapServiceError(ctx, err)
+			return
+		}
+		rows := make([]map[string]any, 0, len(r.Rows))
+		for _, row := range r.Rows {
+			rows = append(rows, map[string]any{
+				"tier":            row.Tier,
+				"entered":         row.Entered,
+				"escalated":       row.Escalated,
+				"deescalated":     row.Deescalated,
+				"resolved":        row.Resolved,
+				"escalation_rate": row.EscalationRate,
+			})
+		}
+		ctx.JSON(200, map[string]any{"rows": rows, "total_escalations": r.TotalEscalations})
+	})
+}
+
+func queryInt64(ctx *app.RequestContext, key string) (int64, bool) {
+	v := ctx.Query(key)
+	if len(v) == 0 {
+		return 0, false
 	}
-	ctx.JSON(200, normalizeTicket(t))
+	n, err := strconv.ParseInt(string(v), 10, 64)
+	return n, err == nil
+}
+
+type csatJSON struct {
+	Rating      int32  `json:"rating"`
+	Comment     string `json:"comment"`
+	SubmittedAt int64  `json:"submitted_at"`
+}
+
+type cycleJSON struct {
+	CreatedAt   int64     `json:"created_at"`
+	AssignedAt  int64     `json:"assigned_at"`
+	ResolvedAt  int64     `json:"resolved_at"`
+	EscalatedAt int64     `json:"escalated_at"`
+	Status      string    `json:"status"`
+	CSAT        *csatJSON `json:"csat,omitempty"`
+	Tier        string    `json:"tier,omitempty"`
+}
+
+type actorJSON struct {
+	ID   string `json:"id"`
+	Kind string `json:"kind"`
+	Name string `json:"display_name"`
+}
+
+type eventJSON struct {
+	Type     string     `json:"type"`
+	At       int64      `json:"at"`
+	Note     string     `json:"note"`
+	Actor    *actorJSON `json:"actor,omitempty"`
+	Source   string     `json:"source,omitempty"`
+	PrevHash string     `json:"prev_hash,omitempty"`
+	Hash     string     `json:"hash,omitempty"`
+	Redacted bool       `json:"redacted,omitempty"`
+	FromTier string     `json:"from_tier,omitempty"`
+	ToTier   string     `json:"to_tier,omitempty"`
+}
+
+type attachmentJSON struct {
+	Name        string `json:"name"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+}
+
+type commentJSON struct {
+	ID          string            `json:"id"`
+	Body        string            `json:"body"`
+	CreatedAt   int64             `json:"created_at"`
+	Actor       *actorJSON        `json:"actor,omitempty"`
+	Source      string            `json:"source,omitempty"`
+	Attachments []*attachmentJSON `json:"attachments,omitempty"`
+}
+
+func normalizeComment(c *kcommon.TicketComment) *commentJSON {
+	out := &commentJSON{ID: c.Id, Body: c.Body, CreatedAt: c.CreatedAt, Source: c.Source}
+	if c.Actor != nil {
+		out.Actor = &actorJSON{ID: c.Actor.Id, Kind: c.Actor.Kind, Name: c.Actor.DisplayName}
+	}
+	for _, a := range c.Attachments {
+		out.Attachments = append(out.Attachments, &attachmentJSON{Name: a.Name, ContentType: a.ContentType, Size: a.Size})
+	}
+	return out
+}
+
+func normalizeEvent(e *kcommon.TicketEvent) *eventJSON {
+	out := &eventJSON{Type: e.Type, At: e.At, Note: e.Note, Source: e.Source, PrevHash: e.PrevHash, Hash: e.Hash, Redacted: e.Redacted, FromTier: e.FromTier, ToTier: e.ToTier}
+	if e.Actor != nil {
+		out.Actor = &actorJSON{ID: e.Actor.Id, Kind: e.Actor.Kind, Name: e.Actor.DisplayName}
+	}
+	return out
+}
+
+type ticketJSON struct {
+	ID           string         `json:"id"`
+	Title        string         `json:"title"`
+	Desc         string         `json:"desc"`
+	Status       string         `json:"status"`
+	Assignee     string         `json:"assignee,omitempty"`
+	Customer     string         `json:"customer,omitempty"`
+	Watchers     []string       `json:"watchers,omitempty"`
+	Category     string         `json:"category,omitempty"`
+	CreatedAt    int64          `json:"created_at"`
+	AssignedAt   int64          `json:"assigned_at"`
+	ResolvedAt   int64          `json:"resolved_at"`
+	EscalatedAt  int64          `json:"escalated_at"`
+	ReopenedAt   int64          `json:"reopened_at"`
+	CurrentCycle int32          `json:"current_cycle"`
+	Cycles       []*cycleJSON   `json:"cycles,omitempty"`
+	Events       []*eventJSON   `json:"events,omitempty"`
+	Comments     []*commentJSON `json:"comments,omitempty"`
+	ArchivedAt   int64          `json:"archived_at,omitempty"`
+	DeletedAt    int64          `json:"deleted_at,omitempty"`
+	Anonymized   bool           `json:"anonymized,omitempty"`
+	Tier         string         `json:"tier,omitempty"`
+
+	Priority     string            `json:"priority,omitempty"`
+	Tags         []string          `json:"tags,omitempty"`
+	CustomFields map[string]string `json:"custom_fields,omitempty"`
+	DueAt        int64             `json:"due_at,omitempty"`
+	// SLAStatus is derived from DueAt: none|on_track|at_risk|breached
+	SLAStatus string `json:"sla_status,omitempty"`
+	// ClaimedBy is set while a pull-queue claim is live
+	ClaimedBy      string `json:"claimed_by,omitempty"`
+	ClaimExpiresAt int64  `json:"claim_expires_at,omitempty"`
+	// Lock is the current edit lock; clients warn before acting on a ticket someone else holds
+	Lock *lockJSON `json:"lock,omitempty"`
+	// SurveyToken is only set on resolve responses: the credential for the customer's survey link.
+	SurveyToken string `json:"survey_token,omitempty"`
+	// PossibleDuplicates is only set on create responses when duplicate detection is enabled.
+	PossibleDuplicates []*duplicateJSON `json:"possible_duplicates,omitempty"`
+}
+
+type duplicateJSON struct {
+	ID         string  `json:"id"`
+	Title      string  `json:"title"`
+	Similarity float64 `json:"similarity"`
+}
+
+func normalizeCycle(c *kcommon.TicketCycle) *cycleJSON {
+	out := &cycleJSON{
+		CreatedAt:   c.CreatedAt,
+		AssignedAt:  c.AssignedAt,
+		ResolvedAt:  c.ResolvedAt,
+		EscalatedAt: c.EscalatedAt,
+		Status:      strings.ToLower(c.Status.String()),
+		Tier:        c.Tier,
+	}
+	if c.Csat != nil {
+		out.CSAT = &csatJSON{Rating: c.Csat.Rating, Comment: c.Csat.Comment, SubmittedAt: c.Csat.SubmittedAt}
+	}
+	return out
 }
 
 // normalizeTicket converts thrift enum TicketStatus (numbers) to expected lowercase strings for HTTP clients.
-func normalizeTicket(t *kcommon.Ticket) *struct {
-	ID           string `json:"id"`
-	Title        string `json:"title"`
-	Desc         string `json:"desc"`
-	Status       string `json:"status"`
-	CreatedAt    int64  `json:"created_at"`
-	AssignedAt   int64  `json:"assigned_at"`
-	ResolvedAt   int64  `json:"resolved_at"`
-	EscalatedAt  int64  `json:"escalated_at"`
-	ReopenedAt   int64  `json:"reopened_at"`
-	CurrentCycle int32  `json:"current_cycle"`
-	Cycles       []*struct {
-		CreatedAt   int64  `json:"created_at"`
-		AssignedAt  int64  `json:"assigned_at"`
-		ResolvedAt  int64  `json:"resolved_at"`
-		EscalatedAt int64  `json:"escalated_at"`
-		Status      string `json:"status"`
-	} `json:"cycles,omitempty"`
-	Events []*struct {
-		Type string `json:"type"`
-		At   int64  `json:"at"`
-		Note string `json:"note"`
-	} `json:"events,omitempty"`
-} {
+func normalizeTicket(t *kcommon.Ticket) *ticketJSON {
 	if t == nil {
 		return nil
 	}
-	status := strings.ToLower(t.Status.String())
-	cycles := make([]*struct {
-		CreatedAt   int64  `json:"created_at"`
-		AssignedAt  int64  `json:"assigned_at"`
-		ResolvedAt  int64  `json:"resolved_at"`
-		EscalatedAt int64  `json:"escalated_at"`
-		Status      string `json:"status"`
-	}, 0, len(t.Cycles))
+	cycles := make([]*cycleJSON, 0, len(t.Cycles))
 	for _, c := range t.Cycles {
-		cycles = append(cycles, &struct {
-			CreatedAt   int64  `json:"created_at"`
-			AssignedAt  int64  `json:"assigned_at"`
-			ResolvedAt  int64  `json:"resolved_at"`
-			EscalatedAt int64  `json:"escalated_at"`
-			Status      string `json:"status"`
-		}{
-			CreatedAt:   c.CreatedAt,
-			AssignedAt:  c.AssignedAt,
-			ResolvedAt:  c.ResolvedAt,
-			EscalatedAt: c.EscalatedAt,
-			Status:      strings.ToLower(c.Status.String()),
-		})
+		cycles = append(cycles, normalizeCycle(c))
 	}
-	events := make([]*struct {
-		Type string `json:"type"`
-		At   int64  `json:"at"`
-		Note string `json:"note"`
-	}, 0, len(t.Events))
+	events := make([]*eventJSON, 0, len(t.Events))
 	for _, e := range t.Events {
-		events = append(events, &struct {
-			Type string `json:"type"`
-			At   int64  `json:"at"`
-			Note string `json:"note"`
-		}{Type: e.Type, At: e.At, Note: e.Note})
+		events = append(events, normalizeEvent(e))
+	}
+	var comments []*commentJSON
+	for _, cm := range t.Comments {
+		comments = append(comments, normalizeComment(cm))
 	}
-	return &struct {
-		ID           string `json:"id"`
-		Title        string `json:"title"`
-		Desc         string `json:"desc"`
-		Status       string `json:"status"`
-		CreatedAt    int64  `json:"created_at"`
-		AssignedAt   int64  `json:"assigned_at"`
-		ResolvedAt   int64  `json:"resolved_at"`
-		EscalatedAt  int64  `json:"escalated_at"`
-		ReopenedAt   int64  `json:"reopened_at"`
-		CurrentCycle int32  `json:"current_cycle"`
-		Cycles       []*struct {
-			CreatedAt   int64  `json:"created_at"`
-			AssignedAt  int64  `json:"assigned_at"`
-			ResolvedAt  int64  `json:"resolved_at"`
-			EscalatedAt int64  `json:"escalated_at"`
-			Status      string `json:"status"`
-		} `json:"cycles,omitempty"`
-		Events []*struct {
-			Type string `json:"type"`
-			At   int64  `json:"at"`
-			Note string `json:"note"`
-		} `json:"events,omitempty"`
-	}{
+	return &ticketJSON{
 		ID:           t.Id,
 		Title:        t.Title,
 		Desc:         t.Desc,
-		Status:       status,
+		Status:       strings.ToLower(t.Status.String()),
+		Assignee:     t.Assignee,
+		Customer:     t.Customer,
+		Watchers:     t.Watchers,
+		Category:     t.Category,
 		CreatedAt:    t.CreatedAt,
 		AssignedAt:   t.AssignedAt,
 		ResolvedAt:   t.ResolvedAt,
@@ -234,6 +499,20 @@ func normalizeTicket(t *kcommon.Ticket) *struct {
 		ReopenedAt:   t.ReopenedAt,
 		CurrentCycle: t.CurrentCycle,
 		Cycles:       cycles,
+		Comments:     comments,
 		Events:       events,
+		ArchivedAt:   t.ArchivedAt,
+		DeletedAt:    t.DeletedAt,
+		Anonymized:   t.Anonymized,
+		Tier:         t.Tier,
+		Priority:     t.Priority,
+		Tags:         t.Tags,
+		CustomFields: t.CustomFields,
+		DueAt:        t.DueAt,
+		SLAStatus:    t.SlaStatus,
+
+		ClaimedBy:      t.ClaimedBy,
+		ClaimExpiresAt: t.ClaimExpiresAt,
+		Lock:           normalizeLock(t.Lock),
 	}
 }
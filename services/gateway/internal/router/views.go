@@ -0,0 +1,176 @@
+package router
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/app/server"
+
+	"github.com/gogogo1024/assist-fusion/internal/common"
+	"github.com/gogogo1024/assist-fusion/internal/gateway"
+	kcommon "github.com/gogogo1024/assist-fusion/kitex_gen/common"
+	"github.com/gogogo1024/assist-fusion/kitex_gen/ticket"
+	gwerrors "github.com/gogogo1024/assist-fusion/services/gateway/internal/errors"
+)
+
+type viewFilterJSON struct {
+	Statuses     []string          `json:"statuses,omitempty"`
+	Assignees    []string          `json:"assignees,omitempty"` // "me" = caller, "none" = unassigned
+	Priorities   []string          `json:"priorities,omitempty"`
+	Tags         []string          `json:"tags,omitempty"` // all must match
+	SLA          []string          `json:"sla,omitempty"`
+	CustomFields map[string]string `json:"custom_fields,omitempty"`
+	Text         string            `json:"text,omitempty"`
+	Tiers        []string          `json:"tiers,omitempty"`
+}
+
+type viewJSON struct {
+	ID        string         `json:"id"`
+	Name      string         `json:"name"`
+	Owner     string         `json:"owner"`
+	Scope     string         `json:"scope"`
+	Team      string         `json:"team,omitempty"`
+	Filter    viewFilterJSON `json:"filter"`
+	SortBy    string         `json:"sort_by"`
+	SortDesc  bool           `json:"sort_desc"`
+	CreatedAt int64          `json:"created_at"`
+	UpdatedAt int64          `json:"updated_at"`
+	// Count is only set by GET /v1/views?counts=1
+	Count *int32 `json:"count,omitempty"`
+}
+
+func normalizeView(v *ticket.SavedView) *viewJSON {
+	out := &viewJSON{ID: v.Id, Name: v.Name, Owner: v.Owner, Scope: v.Scope, Team: v.Team, SortBy: v.SortBy, SortDesc: v.SortDesc, CreatedAt: v.CreatedAt, UpdatedAt: v.UpdatedAt}
+	if f := v.Filter; f != nil {
+		out.Filter = viewFilterJSON{Assignees: f.Assignees, Priorities: f.Priorities, Tags: f.Tags, SLA: f.Sla, CustomFields: f.CustomFields, Text: f.Text, Tiers: f.Tiers}
+		for _, st := range f.Statuses {
+			out.Filter.Statuses = append(out.Filter.Statuses, strings.ToLower(st.String()))
+		}
+	}
+	return out
+}
+
+// bindView reads a view definition from the body; ok is false once a 400 was written.
+func bindView(ctx *app.RequestContext) (*ticket.SavedView, bool) {
+	var in viewJSON
+	if err := ctx.Bind(&in); err != nil {
+		gwerrors.HTTPError(ctx, http.StatusBadRequest, common.ErrCodeBadRequest, gwerrors.MsgBadRequest)
+		return nil, false
+	}
+	f := in.Filter
+	v := &ticket.SavedView{
+		Name: in.Name, Scope: in.Scope, Team: in.Team, SortBy: in.SortBy, SortDesc: in.SortDesc,
+		Filter: &ticket.ViewFilter{Assignees: f.Assignees, Priorities: f.Priorities, Tags: f.Tags, Sla: f.SLA, CustomFields: f.CustomFields, Text: f.Text, Tiers: f.Tiers},
+	}
+	for _, s := range f.Statuses {
+		st, err := kcommon.TicketStatusFromString(strings.ToUpper(s))
+		if err != nil {
+			gwerrors.HTTPError(ctx, http.StatusBadRequest, common.ErrCodeBadRequest, "unknown status "+s)
+			return nil, false
+		}
+		v.Filter.Statuses = append(v.Filter.Statuses, st)
+	}
+	return v, true
+}
+
+// registerTicketViews exposes saved views: personal or team-shared filters over tickets,
+// evaluated by ticket-rpc. Team membership comes from the X-Team-IDs header.
+func registerTicketViews(h *server.Hertz, api gateway.TicketAPI) {
+	h.GET(PathViews, func(c context.Context, ctx *app.RequestContext) {
+		if !requireUser(c, ctx) {
+			return
+		}
+		counts := string(ctx.Query("counts")) == "1" || string(ctx.Query("counts")) == "true"
+		vs, err := api.ListViews(c, counts)
+		if err != nil {
+			gwerrors.MapServiceError(ctx, err)
+			return
+		}
+		items := make([]*viewJSON, 0, len(vs))
+		for _, s := range vs {
+			v := normalizeView(s.View)
+			if counts {
+				n := s.Count
+				v.Count = &n
+			}
+			items = append(items, v)
+		}
+		ctx.JSON(http.StatusOK, map[string]any{"items": items})
+	})
+	h.POST(PathViews, func(c context.Context, ctx *app.RequestContext) {
+		if !requireUser(c, ctx) {
+			return
+		}
+		in, ok := bindView(ctx)
+		if !ok {
+			return
+		}
+		v, err := api.CreateView(c, in)
+		if err != nil {
+			gwerrors.MapServiceError(ctx, err)
+			return
+		}
+		ctx.JSON(http.StatusCreated, normalizeView(v))
+	})
+	h.GET(PathViewID, func(c context.Context, ctx *app.RequestContext) {
+		if !requireUser(c, ctx) {
+			return
+		}
+		v, err := api.GetView(c, string(ctx.Param("id")))
+		if err != nil {
+			gwerrors.MapServiceError(ctx, err)
+			return
+		}
+		ctx.JSON(http.StatusOK, normalizeView(v))
+	})
+	h.PUT(PathViewID, func(c context.Context, ctx *app.RequestContext) {
+		if !requireUser(c, ctx) {
+			return
+		}
+		in, ok := bindView(ctx)
+		if !ok {
+			return
+		}
+		in.Id = string(ctx.Param("id"))
+		v, err := api.UpdateView(c, in)
+		if err != nil {
+			gwerrors.MapServiceError(ctx, err)
+			return
+		}
+		ctx.JSON(http.StatusOK, normalizeView(v))
+	})
+	h.DELETE(PathViewID, func(c context.Context, ctx *app.RequestContext) {
+		if !requireUser(c, ctx) {
+			return
+		}
+		if err := api.DeleteView(c, string(ctx.Param("id"))); err != nil {
+			gwerrors.MapServiceError(ctx, err)
+			return
+		}
+		ctx.Status(http.StatusNoContent)
+	})
+	h.GET(PathViewTickets, func(c context.Context, ctx *app.RequestContext) {
+		if !requireUser(c, ctx) {
+			return
+		}
+		page, _ := strconv.Atoi(string(ctx.Query("page")))
+		size, _ := strconv.Atoi(string(ctx.Query("page_size")))
+		r, err := api.ViewTickets(c, string(ctx.Param("id")), int32(page), int32(size))
+		if err != nil {
+			gwerrors.MapServiceError(ctx, err)
+			return
+		}
+		items := make([]*ticketJSON, 0, len(r.Tickets))
+		for _, t := range r.Tickets {
+			items = append(items, normalizeTicket(t))
+		}
+		pi := r.GetPageInfo()
+		ctx.JSON(http.StatusOK, map[string]any{
+			"items": items, "page": pi.GetPage(), "page_size": pi.GetPageSize(),
+			"total": pi.GetTotalItems(), "total_pages": pi.GetTotalPages(),
+		})
+	})
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// Reading KB documents back: GET /v1/docs/:id and the paged listing (port 18227).
+
+func TestKBGetAndListDocs(t *testing.T) { // :18227
+	setupOnce(t)
+	base, stop := buildServer(t, ":18227")
+	defer stop()
+	const tenant = "kbdocs"
+	do := func(method, path, body string, want int, out any) {
+		t.Helper()
+		req, _ := http.NewRequest(method, base+path, strings.NewReader(body))
+		req.Header.Set(headerContentTypeTest, contentTypeJSON)
+		doJSON(t, asTenant(req, tenant), want, out)
+	}
+	ids := make([]string, 5)
+	for i := range ids {
+		var out createOut
+		do(http.MethodPost, docsPath, fmt.Sprintf(`{"title":"doc %d","content":"body","tags":{"lang":"zh","n":"%d"}}`, i, i%2), http.StatusCreated, &out)
+		ids[i] = out.ID
+	}
+
+	type docJSON struct {
+		ID        string            `json:"id"`
+		Title     string            `json:"title"`
+		Tags      map[string]string `json:"tags"`
+		CreatedAt int64             `json:"created_at"`
+		UpdatedAt int64             `json:"updated_at"`
+	}
+	var got docJSON
+	do(http.MethodGet, docsPath+"/"+ids[3], "", http.StatusOK, &got)
+	if got.Title != "doc 3" || got.Tags["n"] != "1" || got.CreatedAt == 0 || got.UpdatedAt < got.CreatedAt {
+		t.Fatalf("get doc = %+v", got)
+	}
+	do(http.MethodGet, docsPath+"/missing", "", http.StatusNotFound, nil)
+	req, _ := http.NewRequest(http.MethodGet, base+docsPath+"/"+ids[3], nil)
+	doJSON(t, asTenant(req, "kbdocs-other"), http.StatusNotFound, nil)
+
+	type listOut struct {
+		Docs       []docJSON `json:"docs"`
+		Total      int       `json:"total"`
+		NextCursor string    `json:"next_cursor"`
+	}
+	seen := map[string]bool{}
+	query := "?limit=2"
+	for page := 0; ; page++ {
+		var out listOut
+		do(http.MethodGet, docsPath+query, "", http.StatusOK, &out)
+		if out.Total != 5 || page > 3 {
+			t.Fatalf("page %d = %+v", page, out)
+		}
+		for _, d := range out.Docs {
+			if seen[d.ID] {
+				t.Fatalf("page %d repeats %s", page, d.ID)
+			}
+			seen[d.ID] = true
+		}
+		if out.NextCursor == "" {
+			break
+		}
+		query = "?limit=2&cursor=" + url.QueryEscape(out.NextCursor)
+	}
+	if len(seen) != 5 {
+		t.Fatalf("listing saw %d docs", len(seen))
+	}
+
+	var odd listOut
+	do(http.MethodGet, docsPath+"?tag=n:1&order=asc", "", http.StatusOK, &odd)
+	if odd.Total != 2 || len(odd.Docs) != 2 || odd.NextCursor != "" {
+		t.Fatalf("tag-filtered listing = %+v", odd)
+	}
+	do(http.MethodGet, docsPath+"?order=sideways", "", http.StatusBadRequest, nil)
+	do(http.MethodGet, docsPath+"?cursor=garbage", "", http.StatusBadRequest, nil)
+
+	// long markdown articles are chunked; search points at the matching section
+	body := `{"title":"handbook","content":"# Setup\n` + strings.Repeat("background text about the product. ", 200) + `\n# Troubleshooting\n## Network\nreset the zorbulator cache first."}`
+	var handbook createOut
+	do(http.MethodPost, docsPath, body, http.StatusCreated, &handbook)
+	var hits struct {
+		Items []struct {
+			Snippet     string   `json:"snippet"`
+			HeadingPath []string `json:"heading_path"`
+		} `json:"items"`
+	}
+	do(http.MethodGet, "/v1/search?q=zorbulator", "", http.StatusOK, &hits)
+	if len(hits.Items) != 1 || strings.Join(hits.Items[0].HeadingPath, ">") != "Troubleshooting>Network" || !strings.Contains(hits.Items[0].Snippet, "zorbulator") {
+		t.Fatalf("chunked search = %+v", hits)
+	}
+	// an update re-chunks the new content
+	do(http.MethodPut, docsPath+"/"+handbook.ID, `{"title":"handbook","content":"# FAQ\nflush the quuxinator."}`, http.StatusOK, nil)
+	hits.Items = nil
+	do(http.MethodGet, "/v1/search?q=quuxinator", "", http.StatusOK, &hits)
+	if len(hits.Items) != 1 || strings.Join(hits.Items[0].HeadingPath, ">") != "FAQ" {
+		t.Fatalf("search after update = %+v", hits)
+	}
+}
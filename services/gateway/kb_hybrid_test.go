@@ -0,0 +1,111 @@
+package main
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// Vector and hybrid KB search through kb-rpc (port 18228). The mock embedder hashes the
+// whole text, so a query equal to a chunk's embedding text is its exact neighbour.
+
+func TestKBHybridSearch(t *testing.T) { // :18228
+	setupOnce(t)
+	base, stop := buildServer(t, ":18228")
+	defer stop()
+	const tenant = "kbhybrid"
+	do := func(tenant, method, path, body string, want int, out any) {
+		t.Helper()
+		req, _ := http.NewRequest(method, base+path, strings.NewReader(body))
+		req.Header.Set(headerContentTypeTest, contentTypeJSON)
+		doJSON(t, asTenant(req, tenant), want, out)
+	}
+	var router, vpn createOut
+	do(tenant, http.MethodPost, docsPath, `{"title":"路由器指南","content":"路由器断网时先重启"}`, http.StatusCreated, &router)
+	do(tenant, http.MethodPost, docsPath, `{"title":"VPN 手册","content":"连接失败时重启客户端"}`, http.StatusCreated, &vpn)
+
+	type hit struct {
+		ID           string   `json:"id"`
+		Score        float64  `json:"score"`
+		Snippet      string   `json:"snippet"`
+		LexicalScore *float64 `json:"lexical_score"`
+		LexicalRank  *int     `json:"lexical_rank"`
+		VectorScore  *float64 `json:"vector_score"`
+		VectorRank   *int     `json:"vector_rank"`
+	}
+	type searchOut struct {
+		Items []hit `json:"items"`
+		Total int   `json:"total"`
+	}
+	search := func(tenant, query string, want int) searchOut {
+		t.Helper()
+		var out searchOut
+		do(tenant, http.MethodGet, "/v1/search?"+query, "", want, &out)
+		return out
+	}
+	q := "q=" + url.QueryEscape("VPN 手册\n连接失败时重启客户端")
+
+	out := search(tenant, q+"&mode=vector", http.StatusOK)
+	if len(out.Items) != 2 || out.Total != 2 {
+		t.Fatalf("vector search = %+v", out)
+	}
+	top := out.Items[0]
+	if top.ID != vpn.ID || top.VectorScore == nil || *top.VectorScore < 0.999 || *top.VectorRank != 1 || top.LexicalRank != nil || top.Snippet != "连接失败时重启客户端" {
+		t.Fatalf("vector top hit = %+v", top)
+	}
+
+	out = search(tenant, q+"&mode=hybrid", http.StatusOK)
+	if len(out.Items) == 0 || out.Items[0].ID != vpn.ID {
+		t.Fatalf("hybrid search = %+v", out)
+	}
+	top = out.Items[0]
+	if top.LexicalRank == nil || *top.LexicalRank != 1 || top.VectorRank == nil || *top.VectorRank != 1 || top.LexicalScore == nil || *top.LexicalScore <= 0 {
+		t.Fatalf("hybrid components = %+v", top)
+	}
+	if want := 2.0 / 61; top.Score < want-1e-9 || top.Score > want+1e-9 {
+		t.Fatalf("rrf score = %v, want %v", top.Score, want)
+	}
+	out = search(tenant, q+"&mode=hybrid&fusion=weighted&lexical_weight=0&vector_weight=1", http.StatusOK)
+	if out.Items[0].ID != vpn.ID || out.Items[0].Score != 1 {
+		t.Fatalf("vector-only weighted fusion = %+v", out)
+	}
+	// the memory backend cannot combine retrievers itself: native fusion falls back to weighted
+	out = search(tenant, q+"&mode=hybrid&fusion=native", http.StatusOK)
+	if top = out.Items[0]; top.ID != vpn.ID || top.Score != 1 || top.LexicalRank == nil || top.VectorRank == nil {
+		t.Fatalf("native fusion = %+v", out)
+	}
+
+	for _, bad := range []string{
+		q + "&mode=semantic",
+		q + "&mode=hybrid&cursor=",
+		"mode=vector",
+		q + "&mode=hybrid&fusion=linear",
+		q + "&mode=hybrid&lexical_weight=0&vector_weight=0",
+		q + "&mode=hybrid&vector_weight=heavy",
+		q + "&mode=hybrid&rrf_k=0",
+		q + "&mode=hybrid&offset=95&limit=10",
+	} {
+		search(tenant, bad, http.StatusBadRequest)
+	}
+
+	// /v1/search/vector proxies kb-rpc's VectorSearch; total counts the embedded docs
+	var vout struct {
+		Items []hit `json:"items"`
+		Total int   `json:"total"`
+	}
+	do(tenant, http.MethodGet, "/v1/search/vector?limit=1&"+q, "", http.StatusOK, &vout)
+	if len(vout.Items) != 1 || vout.Items[0].ID != vpn.ID || vout.Total != 2 {
+		t.Fatalf("vector endpoint = %+v", vout)
+	}
+	do(tenant, http.MethodGet, "/v1/search/vector?"+q+"&tag_mode=some", "", http.StatusBadRequest, nil)
+
+	// vectors are per tenant and follow deletes
+	if out := search("kbhybrid-other", q+"&mode=vector", http.StatusOK); len(out.Items) != 0 {
+		t.Fatalf("other tenant sees vectors: %+v", out)
+	}
+	do(tenant, http.MethodDelete, docsPath+"/"+vpn.ID, "", http.StatusNoContent, nil)
+	if out := search(tenant, q+"&mode=vector", http.StatusOK); len(out.Items) != 1 || out.Items[0].ID != router.ID {
+		t.Fatalf("after delete = %+v", out)
+	}
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// KB search pages by offset and by cursor never repeat or skip a doc (port 18226).
+
+func TestKBSearchPagesDisjoint(t *testing.T) { // :18226
+	setupOnce(t)
+	base, stop := buildServer(t, ":18226")
+	defer stop()
+	const tenant = "kbpages"
+	get := func(query string, want int, out any) {
+		t.Helper()
+		req, _ := http.NewRequest(http.MethodGet, base+"/v1/search?q=pagewalk&limit=4"+query, nil)
+		doJSON(t, asTenant(req, tenant), want, out)
+	}
+	for i := 0; i < 10; i++ {
+		req, _ := http.NewRequest(http.MethodPost, base+docsPath, strings.NewReader(fmt.Sprintf(`{"title":"pagewalk","content":"same body %d"}`, i%2)))
+		req.Header.Set(headerContentTypeTest, contentTypeJSON)
+		doJSON(t, asTenant(req, tenant), http.StatusCreated, nil)
+	}
+	type pageOut struct {
+		Items []struct {
+			ID string `json:"id"`
+		} `json:"items"`
+		Total      int     `json:"total"`
+		NextOffset *int    `json:"next_offset"`
+		NextCursor *string `json:"next_cursor"`
+	}
+	collect := func(name string, next func(p pageOut) (string, bool)) {
+		t.Helper()
+		seen := map[string]bool{}
+		query := ""
+		if name == "cursor" {
+			query = "&cursor="
+		}
+		for page := 0; page < 5; page++ {
+			var p pageOut
+			get(query, http.StatusOK, &p)
+			for _, it := range p.Items {
+				if seen[it.ID] {
+					t.Fatalf("%s page %d repeats %s", name, page, it.ID)
+				}
+				seen[it.ID] = true
+			}
+			q, more := next(p)
+			if !more {
+				if len(seen) != 10 || p.Total != 10 {
+					t.Fatalf("%s pagination saw %d of %d docs", name, len(seen), p.Total)
+				}
+				return
+			}
+			query = q
+		}
+		t.Fatalf("%s pagination did not finish", name)
+	}
+	collect("offset", func(p pageOut) (string, bool) {
+		if p.NextCursor != nil {
+			t.Fatal("offset page returned a cursor")
+		}
+		if p.NextOffset == nil {
+			return "", false
+		}
+		return fmt.Sprintf("&offset=%d", *p.NextOffset), true
+	})
+	collect("cursor", func(p pageOut) (string, bool) {
+		if p.NextOffset != nil {
+			t.Fatal("cursor page returned next_offset")
+		}
+		if p.NextCursor == nil {
+			return "", false
+		}
+		return "&cursor=" + url.QueryEscape(*p.NextCursor), true
+	})
+	get("&cursor=garbage", http.StatusBadRequest, nil)
+	get("&cursor=&offset=4", http.StatusBadRequest, nil)
+	get("&offset=9999", http.StatusBadRequest, nil)
+	// offset+limit must not wrap around in int32
+	get(fmt.Sprintf("&offset=%d", math.MaxInt32), http.StatusBadRequest, nil)
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// Query-aware snippets and highlights through kb-rpc (port 18229).
+
+func TestKBSearchSnippets(t *testing.T) { // :18229
+	setupOnce(t)
+	base, stop := buildServer(t, ":18229")
+	defer stop()
+	const tenant = "kbsnippet"
+	do := func(method, path, body string, want int, out any) {
+		t.Helper()
+		req, _ := http.NewRequest(method, base+path, strings.NewReader(body))
+		req.Header.Set(headerContentTypeTest, contentTypeJSON)
+		doJSON(t, asTenant(req, tenant), want, out)
+	}
+	content := strings.Repeat("这是一段关于产品背景的介绍。", 20) + "如果 VPN 无法连接，请先重置网络配置。"
+	do(http.MethodPost, docsPath, `{"title":"手册","content":"`+content+`"}`, http.StatusCreated, nil)
+
+	type span struct{ Start, End int }
+	type searchOut struct {
+		Items []struct {
+			Snippet    string `json:"snippet"`
+			Highlights []span `json:"highlights"`
+		} `json:"items"`
+	}
+	search := func(query string, want int) searchOut {
+		t.Helper()
+		var out searchOut
+		do(http.MethodGet, "/v1/search?q="+url.QueryEscape("重置网络")+query, "", want, &out)
+		return out
+	}
+
+	out := search("&snippet_length=30", http.StatusOK)
+	if len(out.Items) != 1 || len(out.Items[0].Highlights) != 1 {
+		t.Fatalf("search = %+v", out)
+	}
+	it := out.Items[0]
+	h := it.Highlights[0]
+	if utf8.RuneCountInString(it.Snippet) > 30 || string([]rune(it.Snippet)[h.Start:h.End]) != "重置网络" {
+		t.Fatalf("snippet = %q, highlights = %+v", it.Snippet, it.Highlights)
+	}
+
+	out = search("&snippet_length=30&pre_tag=%3Cmark%3E&post_tag=%3C%2Fmark%3E", http.StatusOK)
+	if it := out.Items[0]; !strings.Contains(it.Snippet, "请先<mark>重置网络</mark>配置") || it.Highlights != nil {
+		t.Fatalf("tagged snippet = %+v", it)
+	}
+
+	for _, bad := range []string{
+		"&snippet_length=0",
+		"&snippet_length=long",
+		"&snippet_length=5000",
+		"&fragments=6",
+		"&pre_tag=%3Cb%3E",
+		"&pre_tag=" + strings.Repeat("x", 33) + "&post_tag=y",
+	} {
+		search(bad, http.StatusBadRequest)
+	}
+}
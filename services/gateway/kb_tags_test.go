@@ -0,0 +1,79 @@
+package main
+
+import (
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// KB document tags and tag-filtered search (port 18225).
+
+func TestKBTags(t *testing.T) { // :18225
+	setupOnce(t)
+	base, stop := buildServer(t, ":18225")
+	defer stop()
+	const tenant = "kbtags"
+	do := func(method, path, body string, want int, out any) {
+		t.Helper()
+		req, _ := http.NewRequest(method, base+path, strings.NewReader(body))
+		req.Header.Set(headerContentTypeTest, contentTypeJSON)
+		doJSON(t, asTenant(req, tenant), want, out)
+	}
+	var vpn, mail createOut
+	do(http.MethodPost, docsPath, `{"title":"VPN 客服排查","content":"VPN 连接失败","tags":{"Product":"VPN","lang":"zh"}}`, http.StatusCreated, &vpn)
+	do(http.MethodPost, docsPath, `{"title":"邮箱客服配置","content":"邮箱收不到信","tags":{"product":"mail","lang":"zh"}}`, http.StatusCreated, &mail)
+	do(http.MethodPost, docsPath, `{"title":"bad","content":"x","tags":{"a:b":"c"}}`, http.StatusBadRequest, nil)
+
+	type searchOut struct {
+		Items []struct {
+			ID   string            `json:"id"`
+			Tags map[string]string `json:"tags"`
+		} `json:"items"`
+		Total int `json:"total"`
+	}
+	search := func(query string) (searchOut, string) {
+		t.Helper()
+		var out searchOut
+		do(http.MethodGet, "/v1/search?"+query, "", http.StatusOK, &out)
+		ids := make([]string, 0, len(out.Items))
+		for _, it := range out.Items {
+			ids = append(ids, it.ID)
+		}
+		sort.Strings(ids)
+		return out, strings.Join(ids, ",")
+	}
+	both := []string{vpn.ID, mail.ID}
+	sort.Strings(both)
+	q := "q=" + url.QueryEscape("客服")
+
+	if _, got := search(q + "&tag=lang:zh"); got != strings.Join(both, ",") {
+		t.Fatalf("tag=lang:zh -> %s", got)
+	}
+	out, got := search(q + "&tag=lang:zh&tag=product:vpn")
+	if got != vpn.ID || out.Items[0].Tags["product"] != "vpn" {
+		t.Fatalf("all-mode filter -> %+v", out)
+	}
+	if _, got := search(q + "&tag=product:vpn&tag=product:mail&tag_mode=any"); got != strings.Join(both, ",") {
+		t.Fatalf("any-mode filter -> %s", got)
+	}
+	if _, got := search("tag=product:mail"); got != mail.ID {
+		t.Fatalf("tag-only listing -> %s", got)
+	}
+	do(http.MethodGet, "/v1/search?"+q+"&tag=x&tag_mode=some", "", http.StatusBadRequest, nil)
+
+	// PUT replaces the tags; leaving them out keeps them
+	do(http.MethodPut, docsPath+"/"+mail.ID, `{"title":"邮箱客服配置","tags":{"product":"vpn"}}`, http.StatusOK, nil)
+	if _, got := search(q + "&tag=product:vpn"); got != strings.Join(both, ",") {
+		t.Fatalf("after retag -> %s", got)
+	}
+	do(http.MethodPut, docsPath+"/"+mail.ID, `{"title":"邮箱客服配置 v2"}`, http.StatusOK, nil)
+	if _, got := search(q + "&tag=product:vpn"); got != strings.Join(both, ",") {
+		t.Fatalf("title-only update dropped tags -> %s", got)
+	}
+	do(http.MethodPut, docsPath+"/"+mail.ID, `{"title":"邮箱客服配置 v2","tags":{}}`, http.StatusOK, nil)
+	if _, got := search(q + "&tag=lang:zh"); got != vpn.ID {
+		t.Fatalf("after clearing tags -> %s", got)
+	}
+}
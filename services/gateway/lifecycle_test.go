@@ -0,0 +1,86 @@
+package main
+
+import (
+	"net/http"
+	"testing"
+)
+
+// Soft delete, restore and archival (port 18219).
+
+func listTicketIDs(t *testing.T, base, query string) map[string]ticketResp {
+	t.Helper()
+	var list []ticketResp
+	req, _ := http.NewRequest(http.MethodGet, base+pathTickets+query, nil)
+	doJSON(t, req, http.StatusOK, &list)
+	out := map[string]ticketResp{}
+	for _, x := range list {
+		out[x.ID] = x
+	}
+	return out
+}
+
+func TestTicketLifecycle(t *testing.T) { // :18219
+	setupOnce(t)
+	base, stop := buildServer(t, ":18219")
+	defer stop()
+	put := func(id, action string, want int) {
+		t.Helper()
+		req, _ := http.NewRequest(http.MethodPut, base+ticketPrefix+id+"/"+action, nil)
+		doJSON(t, req, want, nil)
+	}
+
+	var tk createOut
+	postTicket(t, base, map[string]any{"title": "lifecycle: stale vpn ticket", "desc": "x"}, http.StatusCreated, &tk)
+
+	// only resolved tickets can be archived
+	put(tk.ID, "archive", http.StatusConflict)
+	put(tk.ID, "resolve", http.StatusOK)
+	put(tk.ID, "archive", http.StatusOK)
+	if _, ok := listTicketIDs(t, base, "")[tk.ID]; ok {
+		t.Fatal("archived ticket listed by default")
+	}
+	if _, ok := listTicketIDs(t, base, "?include=archived")[tk.ID]; !ok {
+		t.Fatal("include=archived should list the ticket")
+	}
+	req, _ := http.NewRequest(http.MethodGet, base+ticketPrefix+tk.ID, nil)
+	doJSON(t, req, http.StatusOK, nil)
+	put(tk.ID, "unarchive", http.StatusOK)
+	if _, ok := listTicketIDs(t, base, "")[tk.ID]; !ok {
+		t.Fatal("unarchived ticket missing from default list")
+	}
+
+	// soft delete hides the ticket everywhere until restored
+	req, _ = http.NewRequest(http.MethodDelete, base+ticketPrefix+tk.ID, nil)
+	doJSON(t, req, http.StatusOK, nil)
+	req, _ = http.NewRequest(http.MethodGet, base+ticketPrefix+tk.ID, nil)
+	doJSON(t, req, http.StatusNotFound, nil)
+	put(tk.ID, "reopen", http.StatusNotFound)
+	if _, ok := listTicketIDs(t, base, "?include=archived")[tk.ID]; ok {
+		t.Fatal("deleted ticket listed")
+	}
+	if _, ok := listTicketIDs(t, base, "?include=deleted")[tk.ID]; !ok {
+		t.Fatal("include=deleted should list the ticket")
+	}
+	put(tk.ID, "restore", http.StatusOK)
+	put(tk.ID, "restore", http.StatusConflict)
+	req, _ = http.NewRequest(http.MethodGet, base+ticketPrefix+tk.ID, nil)
+	doJSON(t, req, http.StatusOK, nil)
+
+	// the test service has no retention policies: a run reports nothing
+	var report struct {
+		DryRun  bool  `json:"dry_run"`
+		Actions []any `json:"actions"`
+	}
+	// runs change tickets across the tenant: agents and anonymous callers are refused
+	req, _ = http.NewRequest(http.MethodPost, base+"/v1/retention/run", nil)
+	doJSON(t, req, http.StatusForbidden, nil)
+	req, _ = http.NewRequest(http.MethodPost, base+"/v1/retention/run", nil)
+	req.Header.Set("X-User-Roles", "agent")
+	doJSON(t, asUser(req, "agent1"), http.StatusForbidden, nil)
+	req, _ = http.NewRequest(http.MethodPost, base+"/v1/retention/run", nil)
+	req.Header.Set("X-User-Roles", "supervisor")
+	doJSON(t, asUser(req, "boss"), http.StatusOK, &report)
+	if !report.DryRun || len(report.Actions) != 0 {
+		t.Fatalf("unexpected retention report: %+v", report)
+	}
+}
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gogogo1024/assist-fusion/internal/common"
+)
+
+// Edit locks and the lock event stream (ports 18224, 18231).
+
+func TestEditLocks(t *testing.T) { // :18224
+	setupOnce(t)
+	base, stop := buildServer(t, ":18224")
+	defer stop()
+	const tenant = "locks"
+	do := func(user, roles, method, path, body string, want int, out any) {
+		t.Helper()
+		req, _ := http.NewRequest(method, base+path, strings.NewReader(body))
+		req.Header.Set(headerContentTypeTest, contentTypeJSON)
+		if user != "" {
+			asUser(req, user)
+		}
+		if roles != "" {
+			req.Header.Set("X-User-Roles", roles)
+		}
+		doJSON(t, asTenant(req, tenant), want, out)
+	}
+	var tk createOut
+	do("ann", "", http.MethodPost, pathTickets, `{"title":"locks: double reply","desc":"x"}`, http.StatusCreated, &tk)
+	lockPath := ticketPrefix + tk.ID + "/lock"
+
+	// subscribe before anything happens; events arrive as "event: lock" frames
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, base+"/v1/events/stream?ticket_id="+tk.ID, nil)
+	resp, err := http.DefaultClient.Do(asTenant(req, tenant))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); !strings.HasPrefix(ct, "text/event-stream") {
+		t.Fatalf("stream content type %q", ct)
+	}
+	type lockEvent struct {
+		Type string `json:"type"`
+		Lock struct {
+			Holder struct {
+				ID string `json:"id"`
+			} `json:"holder"`
+		} `json:"lock"`
+	}
+	events := make(chan lockEvent, 8)
+	go func() {
+		sc := bufio.NewScanner(resp.Body)
+		for sc.Scan() {
+			if data, ok := strings.CutPrefix(sc.Text(), "data: "); ok {
+				var ev lockEvent
+				if json.Unmarshal([]byte(data), &ev) == nil {
+					events <- ev
+				}
+			}
+		}
+	}()
+	next := func(want string) lockEvent {
+		t.Helper()
+		select {
+		case ev := <-events:
+			if ev.Type != want {
+				t.Fatalf("stream event %q, want %q", ev.Type, want)
+			}
+			return ev
+		case <-time.After(5 * time.Second):
+			t.Fatalf("no %q event on the stream", want)
+		}
+		return lockEvent{}
+	}
+
+	var held struct {
+		Lock struct {
+			ExpiresAt int64 `json:"expires_at"`
+		} `json:"lock"`
+	}
+	do("", "", http.MethodPost, lockPath, "", http.StatusUnauthorized, nil)
+	do("ann", "", http.MethodPost, lockPath, "", http.StatusOK, &held)
+	if held.Lock.ExpiresAt <= time.Now().Unix() {
+		t.Fatalf("lock = %+v", held)
+	}
+	if ev := next("acquired"); ev.Lock.Holder.ID != "ann" {
+		t.Fatalf("acquired event = %+v", ev)
+	}
+	do("bob", "", http.MethodPost, lockPath, "", http.StatusConflict, nil)
+	do("bob", "", http.MethodPost, ticketPrefix+tk.ID+"/comments", `{"body":"me too"}`, http.StatusConflict, nil)
+
+	var got struct {
+		Lock *struct {
+			Holder struct {
+				ID string `json:"id"`
+			} `json:"holder"`
+		} `json:"lock"`
+	}
+	do("bob", "", http.MethodGet, ticketPrefix+tk.ID, "", http.StatusOK, &got)
+	if got.Lock == nil || got.Lock.Holder.ID != "ann" {
+		t.Fatalf("ticket lock = %+v", got.Lock)
+	}
+
+	do("bob", "", http.MethodDelete, lockPath+"?force=1", "", http.StatusUnauthorized, nil)
+	do("boss", "supervisor", http.MethodDelete, lockPath+"?force=1", "", http.StatusNoContent, nil)
+	next("force_released")
+	do("bob", "", http.MethodPost, lockPath, "", http.StatusOK, nil)
+	next("acquired")
+	do("bob", "", http.MethodDelete, lockPath, "", http.StatusNoContent, nil)
+	next("released")
+}
+
+// With TENANT_JWT_SECRET set, the supervisor role only counts when the signed token grants it.
+func TestLockForceReleaseSignedRole(t *testing.T) { // :18231
+	setupOnce(t)
+	const secret = "locks-secret"
+	base, stop := buildServerWith(t, &common.Config{HTTPAddr: ":18231", TenantJWTSecret: secret})
+	defer stop()
+	do := func(user string, roles any, header, method, path string, want int, out any) {
+		t.Helper()
+		req, _ := http.NewRequest(method, base+path, strings.NewReader(`{"title":"locks: signed roles","desc":"x"}`))
+		req.Header.Set(headerContentTypeTest, contentTypeJSON)
+		claims := map[string]any{"sub": user, "tenant_id": "lockjwt"}
+		if roles != nil {
+			claims["roles"] = roles
+		}
+		req.Header.Set("Authorization", "Bearer "+signedToken(secret, claims))
+		if header != "" {
+			req.Header.Set("X-User-Roles", header)
+		}
+		doJSON(t, asUser(req, user), want, out)
+	}
+	var tk createOut
+	do("ann", nil, "", http.MethodPost, pathTickets, http.StatusCreated, &tk)
+	lockPath := ticketPrefix + tk.ID + "/lock"
+	do("ann", nil, "", http.MethodPost, lockPath, http.StatusOK, nil)
+	// the header is ignored; the role has to be in the token
+	do("bob", nil, "supervisor", http.MethodDelete, lockPath+"?force=1", http.StatusUnauthorized, nil)
+	do("boss", []string{"agent", "supervisor"}, "", http.MethodDelete, lockPath+"?force=1", http.StatusNoContent, nil)
+	do("ann", nil, "", http.MethodPost, lockPath, http.StatusOK, nil)
+	do("boss", "supervisor", "", http.MethodDelete, lockPath+"?force=1", http.StatusNoContent, nil)
+
+	// identity and actor kind come from the token as well; principal headers are ignored
+	do("ann", nil, "", http.MethodPost, lockPath, http.StatusOK, nil)
+	spoof := func(claims map[string]any, method, path, body string, want int) {
+		t.Helper()
+		req, _ := http.NewRequest(method, base+path, strings.NewReader(body))
+		req.Header.Set(headerContentTypeTest, contentTypeJSON)
+		claims["tenant_id"] = "lockjwt"
+		req.Header.Set("Authorization", "Bearer "+signedToken(secret, claims))
+		req.Header.Set("X-Actor-Kind", "automation")
+		doJSON(t, asUser(req, "ann"), want, nil)
+	}
+	spoof(map[string]any{"sub": "bob"}, http.MethodDelete, lockPath, "", http.StatusConflict)
+	spoof(map[string]any{"sub": "bob"}, http.MethodPost, ticketPrefix+tk.ID+"/comments", `{"body":"me too"}`, http.StatusConflict)
+	spoof(map[string]any{}, http.MethodPost, lockPath, "", http.StatusUnauthorized)
+	// automation is exempt from edit locks only when the token grants it
+	spoof(map[string]any{"sub": "sync-bot", "actor_kind": "automation"}, http.MethodPost, ticketPrefix+tk.ID+"/comments", `{"body":"synced"}`, http.StatusCreated)
+}
@@ -84,6 +84,10 @@ func BuildServer(cfg *common.Config) *server.Hertz {
 		ctx.Response.Header.Set("X-AssistFusion-Version", common.ProjectVersion)
 		ctx.Next(c)
 	})
+	// authenticated principal -> request context (propagated to RPCs for audit attribution)
+	h.Use(router.PrincipalMiddleware([]byte(cfg.TenantJWTSecret)))
+	// tenant (token claim or X-Tenant-ID) -> request context; every RPC is scoped to it
+	h.Use(router.TenantMiddleware([]byte(cfg.TenantJWTSecret)))
 	// domain metrics snapshot under separate path to avoid polluting standard prometheus namespace
 	h.GET("/metrics/domain", func(c context.Context, ctx *app.RequestContext) {
 		ctx.Response.Header.Set(headerContentType, contentTypeTextPlain)
@@ -107,10 +111,9 @@ func BuildServer(cfg *common.Config) *server.Hertz {
 	aiShim := aiClientShim{c: rpcClients.AIClient}
 	router.RegisterKBRPC(h, kbShim)
 	router.RegisterAIRPC(h, aiShim)
-	// enable vector search endpoint (best-effort; relies on AI embeddings)
-	router.EnableVectorSearch(h, rpcClients.AIClient)
 	router.RegisterUI(h, embeddedUIProviderInstance())
 	router.RegisterTicketRPC(h, ad.Ticket)
+	router.RegisterSubjectRPC(h, ad.Ticket, kbShim)
 	return h
 }
 
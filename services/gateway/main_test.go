@@ -91,10 +91,15 @@ func waitReady(t *testing.T, base string) {
 
 func buildServer(t *testing.T, port string) (base string, stop func()) {
 	t.Helper()
-	cfg := &common.Config{HTTPAddr: port}
+	return buildServerWith(t, &common.Config{HTTPAddr: port})
+}
+
+// buildServerWith starts a gateway on cfg.HTTPAddr.
+func buildServerWith(t *testing.T, cfg *common.Config) (base string, stop func()) {
+	t.Helper()
 	h := BuildServer(cfg)
 	go h.Spin()
-	base = "http://127.0.0.1" + port
+	base = "http://127.0.0.1" + cfg.HTTPAddr
 	waitReady(t, base)
 	stop = func() {
 		ctx, cancel := context.WithTimeout(context.Background(), 250*time.Millisecond)
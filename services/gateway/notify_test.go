@@ -0,0 +1,133 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// Watchers & in-app notifications (port 18215).
+
+type inboxOut struct {
+	Items []struct {
+		ID        string `json:"id"`
+		TicketID  string `json:"ticket_id"`
+		EventType string `json:"event_type"`
+		Role      string `json:"role"`
+		Read      bool   `json:"read"`
+	} `json:"items"`
+	Unread int `json:"unread"`
+}
+
+func asUser(req *http.Request, id string) *http.Request {
+	req.Header.Set("X-User-ID", id)
+	return req
+}
+
+// waitInbox polls until user has at least n notifications; delivery is asynchronous.
+func waitInbox(t *testing.T, base, user string, n int) inboxOut {
+	t.Helper()
+	var out inboxOut
+	for deadline := time.Now().Add(3 * time.Second); time.Now().Before(deadline); time.Sleep(20 * time.Millisecond) {
+		req, _ := http.NewRequest(http.MethodGet, base+"/v1/me/notifications", nil)
+		out = inboxOut{}
+		doJSON(t, asUser(req, user), http.StatusOK, &out)
+		if len(out.Items) >= n {
+			return out
+		}
+	}
+	t.Fatalf("inbox of %s: want %d items, got %#v", user, n, out)
+	return out
+}
+
+func TestTicketWatchersAndInbox(t *testing.T) { // :18215
+	setupOnce(t)
+	base, stop := buildServer(t, ":18215")
+	defer stop()
+	b, _ := json.Marshal(map[string]string{"title": "watch me", "desc": "printer on fire", "customer": "n-cust"})
+	req, _ := http.NewRequest(http.MethodPost, base+pathTickets, bytes.NewReader(b))
+	req.Header.Set(headerContentTypeTest, contentTypeJSON)
+	var tk ticketResp
+	doJSON(t, asUser(req, "n-cust"), http.StatusCreated, &tk)
+
+	// anonymous callers have no inbox
+	req, _ = http.NewRequest(http.MethodGet, base+"/v1/me/notifications", nil)
+	doJSON(t, req, http.StatusUnauthorized, nil)
+
+	req, _ = http.NewRequest(http.MethodPost, base+ticketPrefix+tk.ID+"/watchers", nil)
+	var w struct {
+		Watchers []string `json:"watchers"`
+	}
+	doJSON(t, asUser(req, "n-watcher"), http.StatusOK, &w)
+	if len(w.Watchers) != 1 || w.Watchers[0] != "n-watcher" {
+		t.Fatalf("unexpected watchers %#v", w)
+	}
+
+	b, _ = json.Marshal(map[string]string{"assignee": "n-agent"})
+	req, _ = http.NewRequest(http.MethodPut, base+ticketPrefix+tk.ID+"/assign", bytes.NewReader(b))
+	req.Header.Set(headerContentTypeTest, contentTypeJSON)
+	doJSON(t, asUser(req, "n-agent"), http.StatusOK, nil)
+
+	// the assigning agent is the actor and is not notified about their own change
+	cust := waitInbox(t, base, "n-cust", 2) // watcher_added + assigned
+	if cust.Unread != 2 || cust.Items[0].EventType != "assigned" || cust.Items[0].Role != "customer" {
+		t.Fatalf("unexpected customer inbox %#v", cust)
+	}
+	watcher := waitInbox(t, base, "n-watcher", 1)
+	if watcher.Items[0].Role != "watcher" || watcher.Items[0].TicketID != tk.ID {
+		t.Fatalf("unexpected watcher inbox %#v", watcher)
+	}
+
+	b, _ = json.Marshal(map[string]any{"ids": []string{cust.Items[0].ID}})
+	req, _ = http.NewRequest(http.MethodPost, base+"/v1/me/notifications/read", bytes.NewReader(b))
+	req.Header.Set(headerContentTypeTest, contentTypeJSON)
+	var marked struct {
+		Updated int `json:"updated"`
+		Unread  int `json:"unread"`
+	}
+	doJSON(t, asUser(req, "n-cust"), http.StatusOK, &marked)
+	if marked.Updated != 1 || marked.Unread != 1 {
+		t.Fatalf("unexpected mark result %#v", marked)
+	}
+	req, _ = http.NewRequest(http.MethodGet, base+"/v1/me/notifications?unread=1", nil)
+	var unread inboxOut
+	doJSON(t, asUser(req, "n-cust"), http.StatusOK, &unread)
+	if len(unread.Items) != 1 || unread.Items[0].EventType != "watcher_added" {
+		t.Fatalf("unexpected unread list %#v", unread)
+	}
+
+	// preferences are validated before they are stored
+	b, _ = json.Marshal(map[string]any{"channels": []string{"inbox", "email"}})
+	req, _ = http.NewRequest(http.MethodPut, base+"/v1/me/notification-preferences", bytes.NewReader(b))
+	req.Header.Set(headerContentTypeTest, contentTypeJSON)
+	doJSON(t, asUser(req, "n-cust"), http.StatusBadRequest, nil)
+
+	// comments notify with their body
+	b, _ = json.Marshal(map[string]string{"body": "replaced the toner"})
+	req, _ = http.NewRequest(http.MethodPost, base+ticketPrefix+tk.ID+"/comments", bytes.NewReader(b))
+	req.Header.Set(headerContentTypeTest, contentTypeJSON)
+	var commented struct {
+		Comments []struct {
+			Body  string `json:"body"`
+			Actor struct {
+				ID string `json:"id"`
+			} `json:"actor"`
+		} `json:"comments"`
+	}
+	doJSON(t, asUser(req, "n-agent"), http.StatusCreated, &commented)
+	if len(commented.Comments) != 1 || commented.Comments[0].Actor.ID != "n-agent" {
+		t.Fatalf("unexpected comments %#v", commented)
+	}
+	watcher = waitInbox(t, base, "n-watcher", 2)
+	if watcher.Items[0].EventType != "commented" {
+		t.Fatalf("expected comment notification, got %#v", watcher)
+	}
+
+	req, _ = http.NewRequest(http.MethodDelete, base+ticketPrefix+tk.ID+"/watchers", nil)
+	doJSON(t, asUser(req, "n-watcher"), http.StatusOK, &w)
+	if len(w.Watchers) != 0 {
+		t.Fatalf("expected no watchers, got %#v", w)
+	}
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+// Pull queue: agents claim their next ticket (port 18223).
+
+func TestPullQueue(t *testing.T) { // :18223
+	setupOnce(t)
+	base, stop := buildServer(t, ":18223")
+	defer stop()
+	const tenant = "pullq"
+	do := func(user, method, path string, body any, want int, out any) {
+		t.Helper()
+		b, _ := json.Marshal(body)
+		if body == nil {
+			b = nil
+		}
+		req, _ := http.NewRequest(method, base+path, bytes.NewReader(b))
+		req.Header.Set(headerContentTypeTest, contentTypeJSON)
+		if user != "" {
+			asUser(req, user)
+		}
+		doJSON(t, asTenant(req, tenant), want, out)
+	}
+	type pulled struct {
+		Ticket struct {
+			ID        string `json:"id"`
+			Title     string `json:"title"`
+			Status    string `json:"status"`
+			ClaimedBy string `json:"claimed_by"`
+		} `json:"ticket"`
+		Score          float64 `json:"score"`
+		ClaimExpiresAt int64   `json:"claim_expires_at"`
+	}
+
+	do("ann", http.MethodPost, pathTickets, map[string]any{"title": "pullq: minor", "desc": "x", "priority": "low"}, http.StatusCreated, nil)
+	do("ann", http.MethodPost, pathTickets, map[string]any{"title": "pullq: outage", "desc": "x", "priority": "urgent"}, http.StatusCreated, nil)
+	do("", http.MethodPost, "/v1/queue/next", nil, http.StatusUnauthorized, nil)
+
+	var a, b pulled
+	do("ann", http.MethodPost, "/v1/queue/next", nil, http.StatusOK, &a)
+	if a.Ticket.Title != "pullq: outage" || a.Ticket.ClaimedBy != "ann" || a.Score <= 0 || a.ClaimExpiresAt == 0 {
+		t.Fatalf("ann pulled %+v", a)
+	}
+	do("bob", http.MethodPost, "/v1/queue/next", map[string]any{"skills": []string{"network"}}, http.StatusOK, &b)
+	if b.Ticket.Title != "pullq: minor" {
+		t.Fatalf("bob pulled %+v", b)
+	}
+	do("carol", http.MethodPost, "/v1/queue/next", nil, http.StatusNoContent, nil)
+
+	// starting work clears the claim
+	var tk struct {
+		Status    string `json:"status"`
+		ClaimedBy string `json:"claimed_by"`
+	}
+	do("ann", http.MethodPut, ticketPrefix+a.Ticket.ID+"/assign", map[string]string{"assignee": "ann"}, http.StatusOK, &tk)
+	if tk.Status != "assigned" || tk.ClaimedBy != "" {
+		t.Fatalf("after assign: %+v", tk)
+	}
+}
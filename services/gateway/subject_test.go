@@ -0,0 +1,111 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+// Data subject export and erasure across ticket and KB (port 18220).
+
+type subjectExport struct {
+	Subject string `json:"subject"`
+	Tickets []struct {
+		Roles  []string   `json:"roles"`
+		Ticket ticketResp `json:"ticket"`
+	} `json:"tickets"`
+	KBDocs []struct {
+		ID      string `json:"id"`
+		Content string `json:"content"`
+	} `json:"kb_docs"`
+}
+
+type erasureReceipt struct {
+	ID          string `json:"id"`
+	SubjectHash string `json:"subject_hash"`
+	Tenant      string `json:"tenant"`
+	Services    []struct {
+		Service string   `json:"service"`
+		Records []string `json:"records"`
+	} `json:"services"`
+}
+
+func TestSubjectExportAndErase(t *testing.T) { // :18220
+	setupOnce(t)
+	base, stop := buildServer(t, ":18220")
+	defer stop()
+	const tenant, subject = "gdpr", "alice@example.com"
+	post := func(path string, body any, want int, out any) {
+		t.Helper()
+		b, _ := json.Marshal(body)
+		req, _ := http.NewRequest(http.MethodPost, base+path, bytes.NewReader(b))
+		req.Header.Set(headerContentTypeTest, contentTypeJSON)
+		req.Header.Set("X-User-Roles", "admin")
+		doJSON(t, asUser(asTenant(req, tenant), "dpo"), want, out)
+	}
+	get := func(path string, want int, out any) {
+		t.Helper()
+		req, _ := http.NewRequest(http.MethodGet, base+path, nil)
+		req.Header.Set("X-User-Roles", "admin")
+		doJSON(t, asUser(asTenant(req, tenant), "dpo"), want, out)
+	}
+
+	var tk createOut
+	post(pathTickets, map[string]any{"title": "gdpr: invoice copy", "desc": "please send to alice@example.com", "customer": subject}, http.StatusCreated, &tk)
+	var doc struct {
+		ID string `json:"id"`
+	}
+	post("/v1/docs", map[string]string{"title": "vip contacts", "content": "escalations go to alice@example.com first"}, http.StatusCreated, &doc)
+
+	// another person's data is for admins and supervisors only
+	for _, role := range []string{"", "agent"} {
+		req, _ := http.NewRequest(http.MethodGet, base+"/v1/subjects/"+subject+"/export", nil)
+		req = asTenant(req, tenant)
+		if role != "" {
+			req.Header.Set("X-User-Roles", role)
+			req = asUser(req, "agent1")
+		}
+		doJSON(t, req, http.StatusForbidden, nil)
+		req, _ = http.NewRequest(http.MethodPost, base+"/v1/subjects/"+subject+"/erase", nil)
+		req = asTenant(req, tenant)
+		if role != "" {
+			req.Header.Set("X-User-Roles", role)
+			req = asUser(req, "agent1")
+		}
+		doJSON(t, req, http.StatusForbidden, nil)
+	}
+
+	var exp subjectExport
+	get("/v1/subjects/"+subject+"/export", http.StatusOK, &exp)
+	if len(exp.Tickets) != 1 || exp.Tickets[0].Ticket.ID != tk.ID || len(exp.KBDocs) != 1 || exp.KBDocs[0].ID != doc.ID {
+		t.Fatalf("unexpected export: %+v", exp)
+	}
+
+	var rc erasureReceipt
+	post("/v1/subjects/"+subject+"/erase", nil, http.StatusOK, &rc)
+	if rc.ID == "" || rc.Tenant != tenant || len(rc.Services) != 2 || rc.Services[0].Service != "kb" || rc.Services[0].Records[0] != doc.ID {
+		t.Fatalf("unexpected receipt: %+v", rc)
+	}
+
+	exp = subjectExport{}
+	get("/v1/subjects/"+subject+"/export", http.StatusOK, &exp)
+	if len(exp.Tickets) != 0 || len(exp.KBDocs) != 0 {
+		t.Fatalf("subject still present after erasure: %+v", exp)
+	}
+	var tr ticketResp
+	get(ticketPrefix+tk.ID, http.StatusOK, &tr)
+	if tr.Desc != "please send to [redacted]" {
+		t.Fatalf("ticket not redacted: %q", tr.Desc)
+	}
+
+	var again erasureReceipt
+	get("/v1/erasure-receipts/"+rc.ID, http.StatusOK, &again)
+	if again.SubjectHash != rc.SubjectHash {
+		t.Fatalf("receipt mismatch: %+v", again)
+	}
+	// receipts are tenant scoped
+	req, _ := http.NewRequest(http.MethodGet, base+"/v1/erasure-receipts/"+rc.ID, nil)
+	doJSON(t, req, http.StatusNotFound, nil)
+	post("/v1/subjects/al/erase", nil, http.StatusBadRequest, nil)
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/gogogo1024/assist-fusion/internal/common"
+)
+
+// Tenant isolation across ticket and KB routes (ports 18217, 18230).
+
+func asTenant(req *http.Request, tenant string) *http.Request {
+	req.Header.Set("X-Tenant-ID", tenant)
+	return req
+}
+
+// unsignedToken builds a JWT carrying claims; the test gateway has no TENANT_JWT_SECRET.
+func unsignedToken(claims map[string]any) string {
+	enc := base64.RawURLEncoding
+	payload, _ := json.Marshal(claims)
+	return enc.EncodeToString([]byte(`{"alg":"none"}`)) + "." + enc.EncodeToString(payload) + "." + enc.EncodeToString([]byte("sig"))
+}
+
+// signedToken builds an HS256 JWT carrying claims.
+func signedToken(secret string, claims map[string]any) string {
+	enc := base64.RawURLEncoding
+	payload, _ := json.Marshal(claims)
+	unsigned := enc.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`)) + "." + enc.EncodeToString(payload)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(unsigned))
+	return unsigned + "." + enc.EncodeToString(mac.Sum(nil))
+}
+
+func TestTenantIsolation(t *testing.T) { // :18217
+	setupOnce(t)
+	base, stop := buildServer(t, ":18217")
+	defer stop()
+	body, _ := json.Marshal(map[string]any{"title": "tenant: acme payroll export", "desc": "csv is empty"})
+
+	req, _ := http.NewRequest(http.MethodPost, base+pathTickets, bytes.NewReader(body))
+	req.Header.Set(headerContentTypeTest, contentTypeJSON)
+	var tk createOut
+	doJSON(t, asTenant(req, "acme"), http.StatusCreated, &tk)
+
+	req, _ = http.NewRequest(http.MethodGet, base+ticketPrefix+tk.ID, nil)
+	doJSON(t, asTenant(req, "acme"), http.StatusOK, nil)
+	// other tenants (and the default one) see nothing
+	req, _ = http.NewRequest(http.MethodGet, base+ticketPrefix+tk.ID, nil)
+	doJSON(t, asTenant(req, "globex"), http.StatusNotFound, nil)
+	req, _ = http.NewRequest(http.MethodGet, base+ticketPrefix+tk.ID, nil)
+	doJSON(t, req, http.StatusNotFound, nil)
+	req, _ = http.NewRequest(http.MethodPut, base+ticketPrefix+tk.ID+"/resolve", nil)
+	doJSON(t, asTenant(req, "globex"), http.StatusNotFound, nil)
+	var list []ticketResp
+	req, _ = http.NewRequest(http.MethodGet, base+pathTickets, nil)
+	doJSON(t, asTenant(req, "globex"), http.StatusOK, &list)
+	for _, x := range list {
+		if x.ID == tk.ID {
+			t.Fatalf("globex listing contains acme ticket %s", tk.ID)
+		}
+	}
+
+	// duplicate detection does not compare across tenants
+	req, _ = http.NewRequest(http.MethodPost, base+pathTickets, bytes.NewReader(body))
+	req.Header.Set(headerContentTypeTest, contentTypeJSON)
+	var dup createOut
+	doJSON(t, asTenant(req, "globex"), http.StatusCreated, &dup)
+	if len(dup.PossibleDuplicates) != 0 {
+		t.Fatalf("globex ticket matched acme ticket: %#v", dup.PossibleDuplicates)
+	}
+
+	// the bearer token claim selects the tenant; a conflicting header is rejected
+	req, _ = http.NewRequest(http.MethodGet, base+ticketPrefix+tk.ID, nil)
+	req.Header.Set("Authorization", "Bearer "+unsignedToken(map[string]any{"sub": "u1", "tenant_id": "acme"}))
+	doJSON(t, req, http.StatusOK, nil)
+	req, _ = http.NewRequest(http.MethodGet, base+ticketPrefix+tk.ID, nil)
+	req.Header.Set("Authorization", "Bearer "+unsignedToken(map[string]any{"tenant_id": "acme"}))
+	doJSON(t, asTenant(req, "globex"), http.StatusUnauthorized, nil)
+	req, _ = http.NewRequest(http.MethodGet, base+pathTickets, nil)
+	doJSON(t, asTenant(req, "Not A Tenant"), http.StatusBadRequest, nil)
+
+	// KB documents are per tenant as well
+	doc, _ := json.Marshal(map[string]string{"title": "报销制度", "content": "acme 报销需在 30 天内提交"})
+	req, _ = http.NewRequest(http.MethodPost, base+"/v1/docs", bytes.NewReader(doc))
+	req.Header.Set(headerContentTypeTest, contentTypeJSON)
+	doJSON(t, asTenant(req, "acme"), http.StatusCreated, nil)
+	search := func(tenant string) int {
+		var out struct {
+			Total int `json:"total"`
+		}
+		req, _ := http.NewRequest(http.MethodGet, base+"/v1/search?q="+url.QueryEscape("报销制度"), nil)
+		doJSON(t, asTenant(req, tenant), http.StatusOK, &out)
+		return out.Total
+	}
+	if n := search("acme"); n != 1 {
+		t.Fatalf("acme search total=%d, want 1", n)
+	}
+	if n := search("globex"); n != 0 {
+		t.Fatalf("globex search leaked acme docs: total=%d", n)
+	}
+}
+
+// With TENANT_JWT_SECRET set only a verified tenant claim selects a tenant.
+func TestTenantRequiresSignedClaim(t *testing.T) { // :18230
+	setupOnce(t)
+	const secret = "tenant-secret"
+	base, stop := buildServerWith(t, &common.Config{HTTPAddr: ":18230", TenantJWTSecret: secret})
+	defer stop()
+	get := func(auth, tenant string, want int) {
+		t.Helper()
+		req, _ := http.NewRequest(http.MethodGet, base+pathTickets, nil)
+		if auth != "" {
+			req.Header.Set("Authorization", "Bearer "+auth)
+		}
+		if tenant != "" {
+			asTenant(req, tenant)
+		}
+		doJSON(t, req, want, nil)
+	}
+	get(signedToken(secret, map[string]any{"sub": "u1", "tenant_id": "acme"}), "", http.StatusOK)
+	get(signedToken(secret, map[string]any{"tenant_id": "acme"}), "acme", http.StatusOK)
+	// the header alone no longer grants a tenant
+	get("", "acme", http.StatusUnauthorized)
+	// nor does a token without the claim, a forged or unsigned one, or a mismatched header
+	get(signedToken(secret, map[string]any{"sub": "u1"}), "acme", http.StatusUnauthorized)
+	get(signedToken("other", map[string]any{"tenant_id": "acme"}), "", http.StatusUnauthorized)
+	get(unsignedToken(map[string]any{"tenant_id": "acme"}), "", http.StatusUnauthorized)
+	get(signedToken(secret, map[string]any{"tenant_id": "acme"}), "globex", http.StatusUnauthorized)
+	// anonymous requests (public survey links) stay in the default tenant
+	get("", "", http.StatusOK)
+}
@@ -7,8 +7,11 @@ import (
 	"testing"
 	"time"
 
+	"github.com/cloudwego/kitex/client"
 	"github.com/cloudwego/kitex/pkg/klog"
 	"github.com/cloudwego/kitex/server"
+	"github.com/cloudwego/kitex/transport"
+	"github.com/gogogo1024/assist-fusion/internal/ai"
 	"github.com/gogogo1024/assist-fusion/internal/common"
 	rpcClients "github.com/gogogo1024/assist-fusion/internal/gateway/rpc"
 	kbmem "github.com/gogogo1024/assist-fusion/internal/kb"
@@ -60,17 +63,26 @@ func startKitexTestServer(t *testing.T, service string, handler any) (addr strin
 func startAllRPC(t *testing.T) (addrs map[string]string, stops []func()) {
 	t.Helper()
 	addrs = map[string]string{}
+	aAddr, stopA := startKitexTestServer(t, "ai", aiimpl.NewAIService())
+	addrs["ai"] = aAddr
+	stops = append(stops, stopA)
+	// the ticket service embeds new tickets through ai-rpc for duplicate detection
+	dedupAI, err := aiservice.NewClient("ai", client.WithHostPorts(aAddr), client.WithTransportProtocol(transport.TTHeaderFramed))
+	if err != nil {
+		t.Fatalf("ai client: %v", err)
+	}
 	ticketRepo := common.NewMemoryTicketRepo()
-	tAddr, stopT := startKitexTestServer(t, "ticket", ticketimpl.NewTicketService(ticketRepo))
+	tAddr, stopT := startKitexTestServer(t, "ticket", ticketimpl.NewTicketService(ticketRepo,
+		ticketimpl.WithCSATReopenBelow(3),
+		ticketimpl.WithEmbedder(ai.NewRPCEmbedder(dedupAI, 0)),
+	))
 	addrs["ticket"] = tAddr
 	stops = append(stops, stopT)
-	kbRepo := kbmem.NewMemoryRepo()
-	kAddr, stopK := startKitexTestServer(t, "kb", kbimpl.NewKBService(kbRepo))
+	kbRepo := kbmem.PerTenant(func(string) (kbmem.Repo, error) { return kbmem.NewMemoryRepo(), nil })
+	// kb-rpc embeds chunks through the same ai-rpc for vector and hybrid search
+	kAddr, stopK := startKitexTestServer(t, "kb", kbimpl.NewKBService(kbRepo, kbimpl.WithEmbedder(ai.NewRPCEmbedder(dedupAI, 0))))
 	addrs["kb"] = kAddr
 	stops = append(stops, stopK)
-	aAddr, stopA := startKitexTestServer(t, "ai", aiimpl.NewAIService())
-	addrs["ai"] = aAddr
-	stops = append(stops, stopA)
 
 	// Disable consul for tests and init clients with direct host ports.
 	os.Setenv("DISABLE_CONSUL", "1")
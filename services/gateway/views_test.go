@@ -0,0 +1,111 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// Saved views: personal and team-shared filters with counts and pagination (port 18222).
+
+func TestSavedViews(t *testing.T) { // :18222
+	setupOnce(t)
+	base, stop := buildServer(t, ":18222")
+	defer stop()
+	const tenant = "views"
+	do := func(user, teams, method, path string, body any, want int, out any) {
+		t.Helper()
+		var rd *bytes.Reader
+		if body != nil {
+			b, _ := json.Marshal(body)
+			rd = bytes.NewReader(b)
+		} else {
+			rd = bytes.NewReader(nil)
+		}
+		req, _ := http.NewRequest(method, base+path, rd)
+		req.Header.Set(headerContentTypeTest, contentTypeJSON)
+		if user != "" {
+			asUser(req, user)
+		}
+		if teams != "" {
+			req.Header.Set("X-Team-IDs", teams)
+		}
+		doJSON(t, asTenant(req, tenant), want, out)
+	}
+	type viewOut struct {
+		ID    string `json:"id"`
+		Name  string `json:"name"`
+		Scope string `json:"scope"`
+		Count *int   `json:"count"`
+	}
+
+	soon := time.Now().Add(20 * time.Minute).Unix()
+	for i, tk := range []map[string]any{
+		{"title": "views: card declined", "desc": "x", "priority": "low", "tags": []string{"payments"}},
+		{"title": "views: refund missing", "desc": "x", "priority": "urgent", "tags": []string{"payments"}, "custom_fields": map[string]string{"plan": "pro"}, "due_at": soon},
+		{"title": "views: printer jam", "desc": "x", "priority": "high"},
+	} {
+		var out struct {
+			Priority  string            `json:"priority"`
+			Fields    map[string]string `json:"custom_fields"`
+			SLAStatus string            `json:"sla_status"`
+		}
+		do("ann", "", http.MethodPost, pathTickets, tk, http.StatusCreated, &out)
+		if i == 1 && (out.Priority != "urgent" || out.Fields["plan"] != "pro" || out.SLAStatus != "at_risk") {
+			t.Fatalf("created ticket = %+v", out)
+		}
+	}
+	do("ann", "", http.MethodPost, pathTickets, map[string]any{"title": "views: bad", "priority": "asap"}, http.StatusBadRequest, nil)
+
+	var team viewOut
+	do("ann", "support", http.MethodPost, "/v1/views", map[string]any{
+		"name": "Payments", "scope": "team", "team": "support", "sort_by": "priority", "sort_desc": true,
+		"filter": map[string]any{"tags": []string{"payments"}, "statuses": []string{"created"}},
+	}, http.StatusCreated, &team)
+	do("ann", "", http.MethodPost, "/v1/views", map[string]any{"name": "Mine", "filter": map[string]any{"text": "printer"}}, http.StatusCreated, nil)
+	do("ann", "", http.MethodPost, "/v1/views", map[string]any{"name": "x", "filter": map[string]any{"statuses": []string{"lost"}}}, http.StatusBadRequest, nil)
+	do("ann", "", http.MethodPost, "/v1/views", map[string]any{"name": "x", "scope": "team", "team": "support"}, http.StatusBadRequest, nil)
+	do("", "", http.MethodGet, "/v1/views", nil, http.StatusUnauthorized, nil)
+
+	var list struct {
+		Items []viewOut `json:"items"`
+	}
+	do("ann", "support", http.MethodGet, "/v1/views?counts=1", nil, http.StatusOK, &list)
+	if len(list.Items) != 2 || list.Items[0].Name != "Mine" || *list.Items[0].Count != 1 || *list.Items[1].Count != 2 {
+		t.Fatalf("ann's views = %+v", list.Items)
+	}
+	// bob shares the team, not ann's personal view
+	list.Items = nil
+	do("bob", "support", http.MethodGet, "/v1/views", nil, http.StatusOK, &list)
+	if len(list.Items) != 1 || list.Items[0].ID != team.ID || list.Items[0].Count != nil {
+		t.Fatalf("bob's views = %+v", list.Items)
+	}
+	do("eve", "", http.MethodGet, "/v1/views/"+team.ID, nil, http.StatusNotFound, nil)
+
+	var page struct {
+		Items []struct {
+			Title    string `json:"title"`
+			Priority string `json:"priority"`
+		} `json:"items"`
+		Page       int `json:"page"`
+		Total      int `json:"total"`
+		TotalPages int `json:"total_pages"`
+	}
+	do("bob", "support", http.MethodGet, "/v1/views/"+team.ID+"/tickets?page=2&page_size=1", nil, http.StatusOK, &page)
+	if page.Page != 2 || page.Total != 2 || page.TotalPages != 2 || len(page.Items) != 1 || page.Items[0].Priority != "low" {
+		t.Fatalf("page 2 = %+v", page)
+	}
+
+	do("bob", "support", http.MethodPut, "/v1/views/"+team.ID, map[string]any{
+		"name": "Urgent payments", "scope": "team", "team": "support",
+		"filter": map[string]any{"tags": []string{"payments"}, "priorities": []string{"urgent"}, "sla": []string{"at_risk"}},
+	}, http.StatusOK, nil)
+	do("bob", "support", http.MethodGet, "/v1/views/"+team.ID+"/tickets", nil, http.StatusOK, &page)
+	if page.Total != 1 || page.Items[0].Title != "views: refund missing" {
+		t.Fatalf("after update = %+v", page)
+	}
+	do("ann", "support", http.MethodDelete, "/v1/views/"+team.ID, nil, http.StatusNoContent, nil)
+	do("ann", "support", http.MethodGet, "/v1/views/"+team.ID, nil, http.StatusNotFound, nil)
+}